@@ -22,5 +22,6 @@ func main() {
 	}
 
 	// Serve connections.
-	ale.Serve(cfg.Concurrency)
+	ale.Serve(cfg.Concurrency, cfg.HookConcurrency, cfg.EscalateInterval,
+		cfg.DeferInterval, cfg.DigestInterval)
 }
@@ -0,0 +1,26 @@
+// Package main starts the Commander service.
+package main
+
+import (
+	"github.com/thingspect/atlas/internal/atlas-commander/commander"
+	"github.com/thingspect/atlas/internal/atlas-commander/config"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/metric"
+)
+
+func main() {
+	cfg := config.New()
+
+	alog.SetDefault(alog.NewJSON(cfg.LogLevel).WithField("service",
+		commander.ServiceName))
+	metric.SetStatsD(cfg.StatsDAddr, commander.ServiceName)
+
+	// Build Commander.
+	com, err := commander.New(cfg)
+	if err != nil {
+		alog.Fatalf("main commander.New: %v", err)
+	}
+
+	// Serve connections.
+	com.Serve(cfg.Concurrency, cfg.ExpireInterval)
+}
@@ -0,0 +1,26 @@
+// Package main starts the Monitor service.
+package main
+
+import (
+	"github.com/thingspect/atlas/internal/atlas-monitor/config"
+	"github.com/thingspect/atlas/internal/atlas-monitor/monitor"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/metric"
+)
+
+func main() {
+	cfg := config.New()
+
+	alog.SetDefault(alog.NewJSON(cfg.LogLevel).WithField("service",
+		monitor.ServiceName))
+	metric.SetStatsD(cfg.StatsDAddr, monitor.ServiceName)
+
+	// Build Monitor.
+	mon, err := monitor.New(cfg)
+	if err != nil {
+		alog.Fatalf("main monitor.New: %v", err)
+	}
+
+	// Serve connectivity checks.
+	mon.Serve(cfg.Interval)
+}
@@ -0,0 +1,26 @@
+// Package main starts the Purger service.
+package main
+
+import (
+	"github.com/thingspect/atlas/internal/atlas-purger/config"
+	"github.com/thingspect/atlas/internal/atlas-purger/purger"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/metric"
+)
+
+func main() {
+	cfg := config.New()
+
+	alog.SetDefault(alog.NewJSON(cfg.LogLevel).WithField("service",
+		purger.ServiceName))
+	metric.SetStatsD(cfg.StatsDAddr, purger.ServiceName)
+
+	// Build Purger.
+	pur, err := purger.New(cfg)
+	if err != nil {
+		alog.Fatalf("main purger.New: %v", err)
+	}
+
+	// Serve purges.
+	pur.Serve(cfg.Interval)
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS alerts_purge_idx;
+
+ALTER TABLE orgs DROP COLUMN alert_retention_days;
+ALTER TABLE orgs DROP COLUMN event_retention_days;
+ALTER TABLE orgs DROP COLUMN data_point_retention_days;
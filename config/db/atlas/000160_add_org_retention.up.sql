@@ -0,0 +1,5 @@
+ALTER TABLE orgs ADD COLUMN data_point_retention_days integer CHECK (data_point_retention_days > 0);
+ALTER TABLE orgs ADD COLUMN event_retention_days integer CHECK (event_retention_days > 0);
+ALTER TABLE orgs ADD COLUMN alert_retention_days integer CHECK (alert_retention_days > 0);
+
+CREATE INDEX alerts_purge_idx ON alerts (org_id, created_at);
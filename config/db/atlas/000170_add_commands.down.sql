@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS commands_purge_idx;
+DROP INDEX IF EXISTS commands_expire_idx;
+DROP INDEX IF EXISTS commands_lora_queue_id_idx;
+DROP INDEX IF EXISTS commands_list_idx;
+DROP TABLE IF EXISTS commands;
+DROP TYPE IF EXISTS command_status;
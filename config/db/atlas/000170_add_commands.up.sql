@@ -0,0 +1,22 @@
+CREATE TYPE command_status AS ENUM ('COMMAND_STATUS_UNSPECIFIED', 'QUEUED', 'SENT', 'ACKED', 'FAILED', 'EXPIRED');
+
+-- commands is lightly linked to non-org tables for retention purposes
+CREATE TABLE commands (
+  id uuid PRIMARY KEY DEFAULT uuidv7(),
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  uniq_id varchar(40) NOT NULL CHECK (uniq_id = lower(uniq_id)),
+  payload bytea NOT NULL,
+  f_port integer NOT NULL CHECK (f_port >= 0 AND f_port <= 223),
+  status command_status NOT NULL,
+  error varchar(255) NOT NULL,
+  lora_queue_id varchar(40) NOT NULL,
+  expires_at timestamptz NOT NULL,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL,
+  trace_id uuid NOT NULL
+);
+
+CREATE INDEX commands_list_idx ON commands (org_id, uniq_id, created_at DESC);
+CREATE INDEX commands_lora_queue_id_idx ON commands (lora_queue_id) WHERE lora_queue_id != '';
+CREATE INDEX commands_expire_idx ON commands (expires_at) WHERE status IN ('QUEUED', 'SENT');
+CREATE INDEX commands_purge_idx ON commands (org_id, created_at);
@@ -0,0 +1,7 @@
+CREATE TABLE shadows (
+  dev_id uuid PRIMARY KEY REFERENCES devices (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  desired jsonb NOT NULL,
+  version integer NOT NULL,
+  updated_at timestamptz NOT NULL
+);
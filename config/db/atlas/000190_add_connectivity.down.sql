@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS connectivity_intervals_tag_idx;
+DROP INDEX IF EXISTS connectivity_intervals_dev_id_idx;
+DROP TABLE IF EXISTS connectivity_intervals;
+DROP INDEX IF EXISTS connectivity_offline_idx;
+DROP TABLE IF EXISTS connectivity;
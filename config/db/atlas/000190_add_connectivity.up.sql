@@ -0,0 +1,24 @@
+CREATE TABLE connectivity (
+  dev_id uuid PRIMARY KEY REFERENCES devices (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  last_seen_at timestamptz NOT NULL,
+  offline boolean NOT NULL DEFAULT false,
+  changed_at timestamptz NOT NULL
+);
+
+CREATE INDEX connectivity_offline_idx ON connectivity (offline, last_seen_at);
+
+CREATE TABLE connectivity_intervals (
+  id uuid PRIMARY KEY DEFAULT uuidv7(),
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  -- Exactly one of dev_id and tag is set
+  dev_id uuid REFERENCES devices (id) ON DELETE CASCADE,
+  tag varchar(255),
+  interval_sec integer NOT NULL CHECK (interval_sec >= 60 AND interval_sec <= 2592000),
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL,
+  CHECK ((dev_id IS NULL) != (tag IS NULL))
+);
+
+CREATE UNIQUE INDEX connectivity_intervals_dev_id_idx ON connectivity_intervals (org_id, dev_id) WHERE dev_id IS NOT NULL;
+CREATE UNIQUE INDEX connectivity_intervals_tag_idx ON connectivity_intervals (org_id, tag) WHERE tag IS NOT NULL;
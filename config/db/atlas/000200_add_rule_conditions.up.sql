@@ -0,0 +1,9 @@
+CREATE TABLE rule_conditions (
+  rule_id uuid PRIMARY KEY REFERENCES rules (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  for_samples integer NOT NULL CHECK (for_samples >= 1 AND for_samples <= 1000),
+  for_sec integer NOT NULL CHECK (for_sec >= 0 AND for_sec <= 86400),
+  exit_expr varchar(1024) NOT NULL,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL
+);
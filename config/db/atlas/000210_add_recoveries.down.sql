@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS alarm_recoveries;
+
+ALTER TABLE events DROP COLUMN cleared;
@@ -0,0 +1,10 @@
+ALTER TABLE events ADD COLUMN cleared boolean NOT NULL DEFAULT false;
+
+CREATE TABLE alarm_recoveries (
+  alarm_id uuid PRIMARY KEY REFERENCES alarms (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  subject_template varchar(1024) NOT NULL,
+  body_template varchar(4096) NOT NULL,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL
+);
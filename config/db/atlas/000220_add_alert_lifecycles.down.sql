@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS alert_lifecycles_purge_idx;
+DROP INDEX IF EXISTS alert_lifecycles_list_idx;
+DROP INDEX IF EXISTS alert_lifecycles_unresolved_idx;
+DROP TABLE IF EXISTS alert_lifecycles;
+DROP TYPE IF EXISTS alert_state;
@@ -0,0 +1,25 @@
+CREATE TYPE alert_state AS ENUM ('ALERT_STATE_UNSPECIFIED', 'OPEN', 'ACKNOWLEDGED', 'RESOLVED');
+
+-- alert_lifecycles is lightly linked to non-org tables for retention purposes
+CREATE TABLE alert_lifecycles (
+  id uuid PRIMARY KEY DEFAULT uuidv7(),
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  uniq_id varchar(40) NOT NULL CHECK (uniq_id = lower(uniq_id)),
+  alarm_id uuid NOT NULL,
+  state alert_state NOT NULL,
+  assignee_id uuid,
+  opened_at timestamptz NOT NULL,
+  acked_by uuid,
+  acked_at timestamptz,
+  ack_note varchar(1024) NOT NULL,
+  resolved_by uuid,
+  resolved_at timestamptz,
+  resolve_note varchar(1024) NOT NULL,
+  updated_at timestamptz NOT NULL,
+  trace_id uuid NOT NULL
+);
+
+-- Each device and alarm has at most one unresolved alert at a time
+CREATE UNIQUE INDEX alert_lifecycles_unresolved_idx ON alert_lifecycles (org_id, uniq_id, alarm_id) WHERE state != 'RESOLVED';
+CREATE INDEX alert_lifecycles_list_idx ON alert_lifecycles (org_id, opened_at DESC) WHERE state != 'RESOLVED';
+CREATE INDEX alert_lifecycles_purge_idx ON alert_lifecycles (org_id, resolved_at) WHERE state = 'RESOLVED';
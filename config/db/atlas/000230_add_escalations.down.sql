@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS alert_escalations_next_at_idx;
+DROP TABLE IF EXISTS alert_escalations;
+DROP TABLE IF EXISTS alarm_escalations;
@@ -0,0 +1,18 @@
+CREATE TABLE alarm_escalations (
+  alarm_id uuid PRIMARY KEY REFERENCES alarms (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  policy jsonb NOT NULL,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL
+);
+
+CREATE TABLE alert_escalations (
+  lifecycle_id uuid PRIMARY KEY REFERENCES alert_lifecycles (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  alarm_id uuid NOT NULL REFERENCES alarms (id) ON DELETE CASCADE,
+  step integer NOT NULL,
+  next_at timestamptz,
+  event bytea NOT NULL
+);
+
+CREATE INDEX alert_escalations_next_at_idx ON alert_escalations (next_at) WHERE next_at IS NOT NULL;
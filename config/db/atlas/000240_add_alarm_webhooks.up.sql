@@ -0,0 +1,8 @@
+CREATE TABLE alarm_webhooks (
+  alarm_id uuid PRIMARY KEY REFERENCES alarms (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  url varchar(2048) NOT NULL,
+  secret bytea NOT NULL,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL
+);
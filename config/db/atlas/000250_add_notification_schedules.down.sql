@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS deferred_alerts_deliver_at_idx;
+DROP TABLE IF EXISTS deferred_alerts;
+DROP TABLE IF EXISTS user_schedules;
+
+DELETE FROM alerts WHERE status = 'DEFERRED';
+ALTER TYPE alert_status RENAME TO alert_status_old;
+CREATE TYPE alert_status AS ENUM ('ALERT_STATUS_UNSPECIFIED', 'SENT', 'ERROR');
+ALTER TABLE alerts ALTER COLUMN status TYPE alert_status USING status::text::alert_status;
+DROP TYPE alert_status_old;
@@ -0,0 +1,27 @@
+ALTER TYPE alert_status RENAME TO alert_status_old;
+CREATE TYPE alert_status AS ENUM ('ALERT_STATUS_UNSPECIFIED', 'SENT', 'ERROR', 'DEFERRED');
+ALTER TABLE alerts ALTER COLUMN status TYPE alert_status USING status::text::alert_status;
+DROP TYPE alert_status_old;
+
+CREATE TABLE user_schedules (
+  user_id uuid PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  schedule jsonb NOT NULL,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL
+);
+
+CREATE TABLE deferred_alerts (
+  id uuid PRIMARY KEY DEFAULT uuidv7(),
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  alarm_id uuid NOT NULL REFERENCES alarms (id) ON DELETE CASCADE,
+  user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  type alarm_type NOT NULL,
+  subject text NOT NULL,
+  body text NOT NULL,
+  event bytea NOT NULL,
+  deliver_at timestamptz NOT NULL,
+  claimed_until timestamptz
+);
+
+CREATE INDEX deferred_alerts_deliver_at_idx ON deferred_alerts (deliver_at);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS alerts_digest_idx;
+ALTER TABLE alerts DROP COLUMN IF EXISTS digest_id;
+
+DROP INDEX IF EXISTS alert_digests_purge_idx;
+DROP INDEX IF EXISTS alert_digests_flush_at_idx;
+DROP TABLE IF EXISTS alert_digests;
+DROP TABLE IF EXISTS alarm_digests;
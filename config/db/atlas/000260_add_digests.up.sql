@@ -0,0 +1,30 @@
+CREATE TABLE alarm_digests (
+  alarm_id uuid PRIMARY KEY REFERENCES alarms (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  window_minutes integer NOT NULL CHECK (window_minutes > 0),
+  subject_template varchar(1024) NOT NULL,
+  body_template varchar(4096) NOT NULL,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL
+);
+
+-- alert_digests is lightly linked to non-org tables for retention purposes
+CREATE TABLE alert_digests (
+  id uuid PRIMARY KEY DEFAULT uuidv7(),
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  alarm_id uuid NOT NULL,
+  rule_id uuid NOT NULL,
+  user_id uuid NOT NULL,
+  type alarm_type NOT NULL,
+  status alert_status NOT NULL,
+  error varchar(255) NOT NULL,
+  flush_at timestamptz NOT NULL,
+  flushed_at timestamptz,
+  created_at timestamptz NOT NULL
+);
+
+CREATE INDEX alert_digests_flush_at_idx ON alert_digests (flush_at) WHERE flushed_at IS NULL;
+CREATE INDEX alert_digests_purge_idx ON alert_digests (org_id, created_at);
+
+ALTER TABLE alerts ADD COLUMN digest_id uuid;
+CREATE INDEX alerts_digest_idx ON alerts (org_id, digest_id) WHERE digest_id IS NOT NULL;
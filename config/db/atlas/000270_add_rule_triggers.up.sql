@@ -0,0 +1,7 @@
+CREATE TABLE rule_triggers (
+  rule_id uuid PRIMARY KEY REFERENCES rules (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  attrs varchar(40)[] NOT NULL CHECK (cardinality(attrs) BETWEEN 1 AND 20),
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS org_deletions_pending_idx;
+DROP TABLE IF EXISTS org_deletions;
@@ -0,0 +1,11 @@
+-- org_deletions is not linked to orgs, so that progress outlives the org
+CREATE TABLE org_deletions (
+  org_id uuid PRIMARY KEY,
+  step varchar(40) NOT NULL,
+  purged_rows bigint NOT NULL,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL,
+  completed_at timestamptz
+);
+
+CREATE INDEX org_deletions_pending_idx ON org_deletions (created_at) WHERE completed_at IS NULL;
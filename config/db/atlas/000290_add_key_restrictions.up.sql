@@ -0,0 +1,9 @@
+CREATE TABLE key_restrictions (
+  key_id uuid PRIMARY KEY REFERENCES keys (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  expires_at timestamptz,
+  scopes varchar(255)[] NOT NULL,
+  uniq_ids varchar(40)[] NOT NULL,
+  tags varchar(255)[] NOT NULL,
+  created_at timestamptz NOT NULL
+);
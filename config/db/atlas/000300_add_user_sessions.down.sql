@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS user_sessions_purge_idx;
+DROP INDEX IF EXISTS user_sessions_read_and_list_idx;
+DROP INDEX IF EXISTS user_sessions_prev_hash_idx;
+DROP INDEX IF EXISTS user_sessions_refresh_hash_idx;
+DROP TABLE IF EXISTS user_sessions;
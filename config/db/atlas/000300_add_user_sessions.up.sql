@@ -0,0 +1,17 @@
+CREATE TABLE user_sessions (
+  id uuid PRIMARY KEY DEFAULT uuidv7(),
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  -- SHA-256 hashes of the current and previous refresh tokens
+  refresh_hash bytea NOT NULL,
+  prev_hash bytea,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL,
+  expires_at timestamptz NOT NULL,
+  revoked_at timestamptz
+);
+
+CREATE UNIQUE INDEX user_sessions_refresh_hash_idx ON user_sessions (refresh_hash);
+CREATE INDEX user_sessions_prev_hash_idx ON user_sessions (prev_hash);
+CREATE INDEX user_sessions_read_and_list_idx ON user_sessions (org_id, user_id, created_at);
+CREATE INDEX user_sessions_purge_idx ON user_sessions (org_id, expires_at);
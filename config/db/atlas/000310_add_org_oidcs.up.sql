@@ -0,0 +1,12 @@
+CREATE TABLE org_oidcs (
+  org_id uuid PRIMARY KEY REFERENCES orgs (id),
+  issuer varchar(255) NOT NULL,
+  client_id varchar(255) NOT NULL,
+  client_secret bytea,
+  role_claim varchar(255) NOT NULL,
+  role_map jsonb NOT NULL,
+  default_role role NOT NULL,
+  jit_users boolean NOT NULL,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL
+);
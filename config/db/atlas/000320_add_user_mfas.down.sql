@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS org_mfa_policies;
+DROP TABLE IF EXISTS user_mfas;
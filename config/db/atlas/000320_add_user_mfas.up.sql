@@ -0,0 +1,20 @@
+CREATE TABLE user_mfas (
+  user_id uuid PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  -- TOTP secret, encrypted with the PWT key
+  secret bytea NOT NULL,
+  -- SHA-256 hashes of unused recovery codes
+  recovery_hashes varchar(64)[] NOT NULL DEFAULT '{}',
+  -- Last accepted TOTP time step, used to prevent code replay
+  last_step bigint NOT NULL DEFAULT 0,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL,
+  confirmed_at timestamptz
+);
+
+CREATE TABLE org_mfa_policies (
+  org_id uuid PRIMARY KEY REFERENCES orgs (id),
+  min_role role NOT NULL,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL
+);
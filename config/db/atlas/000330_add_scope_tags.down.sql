@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_scopes;
+ALTER TABLE key_restrictions DROP COLUMN IF EXISTS scope_tags;
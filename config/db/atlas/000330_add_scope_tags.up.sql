@@ -0,0 +1,9 @@
+ALTER TABLE key_restrictions ADD COLUMN scope_tags varchar(255)[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE user_scopes (
+  user_id uuid PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+  org_id uuid NOT NULL REFERENCES orgs (id),
+  tags varchar(255)[] NOT NULL,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL
+);
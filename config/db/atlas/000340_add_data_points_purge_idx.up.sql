@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS data_points_purge_idx ON data_points (org_id, created_at);
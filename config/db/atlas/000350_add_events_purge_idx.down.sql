@@ -0,0 +1 @@
+DROP INDEX CONCURRENTLY IF EXISTS events_purge_idx;
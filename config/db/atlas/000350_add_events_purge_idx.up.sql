@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS events_purge_idx ON events (org_id, created_at);
@@ -8,6 +8,8 @@ import (
 
 	"github.com/thingspect/atlas/pkg/alog"
 	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/connectivity"
+	"github.com/thingspect/atlas/pkg/latest"
 	"github.com/thingspect/atlas/pkg/metric"
 	"github.com/thingspect/atlas/pkg/queue"
 	"github.com/thingspect/atlas/proto/go/message"
@@ -21,6 +23,7 @@ func (acc *Accumulator) accumulateMessages() {
 	var processCount int
 	for msg := range acc.vOutSub.C() {
 		// Retrieve published message.
+		receivedAt := time.Now().UTC()
 		metric.Incr("received", nil)
 		vOut := &message.ValidatorOut{}
 		err := proto.Unmarshal(msg.Payload(), vOut)
@@ -72,6 +75,33 @@ func (acc *Accumulator) accumulateMessages() {
 			continue
 		}
 
+		// Update last seen, excluding synthetic connectivity points. The
+		// receive time is used, as data point timestamps are set by devices
+		// and may be backfilled or in the future. The data point is stored, so
+		// a failure is logged but not requeued.
+		if vOut.GetPoint().GetAttr() != connectivity.Attr {
+			ctx, cancel := context.WithTimeout(context.Background(),
+				5*time.Second)
+			err = acc.connDAO.Seen(ctx, vOut.GetDevice().GetId(),
+				vOut.GetDevice().GetOrgId(), receivedAt)
+			cancel()
+			if err != nil {
+				metric.Incr("error", map[string]string{metric.TagFunc: "seen"})
+				logger.Errorf("accumulateMessages acc.connDAO.Seen: %v", err)
+			}
+		}
+
+		// Cache the latest value of the attribute for rule evaluation. The
+		// data point is stored, so a failure is logged but not requeued.
+		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+		err = latest.Set(ctx, acc.latestCache, vOut.GetDevice().GetOrgId(),
+			vOut.GetDevice().GetId(), vOut.GetPoint())
+		cancel()
+		if err != nil {
+			metric.Incr("error", map[string]string{metric.TagFunc: "setlatest"})
+			logger.Errorf("accumulateMessages latest.Set: %v", err)
+		}
+
 		msg.Ack()
 		metric.Incr("processed", nil)
 		logger.Debugf("accumulateMessages processed: %+v", vOut)
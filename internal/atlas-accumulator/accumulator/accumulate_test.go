@@ -10,10 +10,13 @@ import (
 	"uuid"
 
 	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/cache"
 	"github.com/thingspect/atlas/pkg/consterr"
 	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/connectivity"
 	"github.com/thingspect/atlas/pkg/decode"
 	"github.com/thingspect/atlas/pkg/decode/radiobridge"
+	"github.com/thingspect/atlas/pkg/latest"
 	"github.com/thingspect/atlas/pkg/queue"
 	"github.com/thingspect/atlas/pkg/test/matcher"
 	"github.com/thingspect/atlas/pkg/test/random"
@@ -33,7 +36,8 @@ func TestAccumulateMessages(t *testing.T) {
 	dev := random.Device("acc", uuid.NewV7().String())
 
 	tests := []struct {
-		inp *message.ValidatorOut
+		inp          *message.ValidatorOut
+		inpSeenTimes int
 	}{
 		{
 			&message.ValidatorOut{
@@ -44,7 +48,7 @@ func TestAccumulateMessages(t *testing.T) {
 					Token:    uuid.NewV7().String(),
 					TraceId:  uuid.NewV7().String(),
 				}, Device: dev,
-			},
+			}, 1,
 		},
 		{
 			&message.ValidatorOut{
@@ -55,7 +59,7 @@ func TestAccumulateMessages(t *testing.T) {
 					Token:    uuid.NewV7().String(),
 					TraceId:  uuid.NewV7().String(),
 				}, Device: dev,
-			},
+			}, 1,
 		},
 		{
 			&message.ValidatorOut{
@@ -66,7 +70,19 @@ func TestAccumulateMessages(t *testing.T) {
 					Token:    uuid.NewV7().String(),
 					TraceId:  uuid.NewV7().String(),
 				}, Device: dev,
-			},
+			}, 1,
+		},
+		{
+			&message.ValidatorOut{
+				Point: &common.DataPoint{
+					UniqId: random.String(16), Attr: connectivity.Attr,
+					ValOneof: &common.DataPoint_StrVal{
+						StrVal: connectivity.Offline,
+					},
+					Ts:      timestamppb.Now(),
+					TraceId: uuid.NewV7().String(),
+				}, Device: dev,
+			}, 0,
 		},
 	}
 
@@ -86,13 +102,30 @@ func TestAccumulateMessages(t *testing.T) {
 				matcher.NewProtoMatcher(test.inp.GetPoint()),
 				test.inp.GetDevice().GetOrgId()).
 				DoAndReturn(func(_ any, _ any, _ any) error {
-					defer wg.Done()
+					if test.inpSeenTimes == 0 {
+						defer wg.Done()
+					}
 
 					return nil
 				}).Times(1)
 
+			connectivityer := NewMockconnectivityer(gomock.NewController(t))
+			connectivityer.EXPECT().Seen(gomock.Any(),
+				test.inp.GetDevice().GetId(), test.inp.GetDevice().GetOrgId(),
+				matcher.NewRecentMatcher(2*time.Second)).
+				DoAndReturn(func(_ any, _ any, _ any, _ any) error {
+					defer wg.Done()
+
+					return nil
+				}).Times(test.inpSeenTimes)
+
+			latestCache := cache.NewHeap[[]byte]()
+
 			acc := Accumulator{
-				dpDAO: datapointer,
+				dpDAO:   datapointer,
+				connDAO: connectivityer,
+
+				latestCache: latestCache,
 
 				accQueue: vOutQueue,
 				vOutSub:  vOutSub,
@@ -107,6 +140,17 @@ func TestAccumulateMessages(t *testing.T) {
 
 			require.NoError(t, vOutQueue.Publish("", bVOut))
 			wg.Wait()
+
+			// The latest value is cached after the data point is stored.
+			require.Eventually(t, func() bool {
+				points, err := latest.Get(t.Context(), latestCache,
+					test.inp.GetDevice().GetOrgId(),
+					test.inp.GetDevice().GetId(),
+					[]string{test.inp.GetPoint().GetAttr()})
+
+				return err == nil && proto.Equal(test.inp.GetPoint(),
+					points[test.inp.GetPoint().GetAttr()])
+			}, time.Second, 10*time.Millisecond)
 		})
 	}
 }
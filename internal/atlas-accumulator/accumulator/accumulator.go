@@ -8,10 +8,13 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/thingspect/atlas/internal/atlas-accumulator/config"
 	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
 	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/connectivity"
 	"github.com/thingspect/atlas/pkg/dao/datapoint"
 	"github.com/thingspect/atlas/pkg/queue"
 	"github.com/thingspect/proto/go/common"
@@ -25,9 +28,18 @@ type datapointer interface {
 	Create(ctx context.Context, point *common.DataPoint, orgID string) error
 }
 
-// Accumulator holds references to the database and message broker connections.
+// connectivityer defines the methods provided by a connectivity.DAO.
+type connectivityer interface {
+	Seen(ctx context.Context, devID, orgID string, seenAt time.Time) error
+}
+
+// Accumulator holds references to the database, cache, and message broker
+// connections.
 type Accumulator struct {
-	dpDAO datapointer
+	dpDAO   datapointer
+	connDAO connectivityer
+
+	latestCache cache.Cacher[[]byte]
 
 	accQueue queue.Queuer
 	vOutSub  queue.Subber
@@ -47,6 +59,12 @@ func New(cfg *config.Config) (*Accumulator, error) {
 		return nil, err
 	}
 
+	// Set up cache connection.
+	redis, err := cache.NewRedis[[]byte](cfg.RedisHost + ":6379")
+	if err != nil {
+		return nil, err
+	}
+
 	// Build the NSQ connection for consuming.
 	nsq, err := queue.NewNSQ(cfg.NSQPubAddr, cfg.NSQLookupAddrs,
 		cfg.NSQSubChannel)
@@ -66,7 +84,10 @@ func New(cfg *config.Config) (*Accumulator, error) {
 	}
 
 	return &Accumulator{
-		dpDAO: datapoint.NewDAO(pgRW, pgRO),
+		dpDAO:   datapoint.NewDAO(pgRW, pgRO),
+		connDAO: connectivity.NewDAO(pgRW, pgRO),
+
+		latestCache: redis,
 
 		accQueue: nsq,
 		vOutSub:  vOutSub,
@@ -12,6 +12,7 @@ package accumulator
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	common "github.com/thingspect/proto/go/common"
 	gomock "go.uber.org/mock/gomock"
@@ -54,3 +55,41 @@ func (mr *MockdatapointerMockRecorder) Create(ctx, point, orgID any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockdatapointer)(nil).Create), ctx, point, orgID)
 }
+
+// Mockconnectivityer is a mock of connectivityer interface.
+type Mockconnectivityer struct {
+	ctrl     *gomock.Controller
+	recorder *MockconnectivityerMockRecorder
+	isgomock struct{}
+}
+
+// MockconnectivityerMockRecorder is the mock recorder for Mockconnectivityer.
+type MockconnectivityerMockRecorder struct {
+	mock *Mockconnectivityer
+}
+
+// NewMockconnectivityer creates a new mock instance.
+func NewMockconnectivityer(ctrl *gomock.Controller) *Mockconnectivityer {
+	mock := &Mockconnectivityer{ctrl: ctrl}
+	mock.recorder = &MockconnectivityerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockconnectivityer) EXPECT() *MockconnectivityerMockRecorder {
+	return m.recorder
+}
+
+// Seen mocks base method.
+func (m *Mockconnectivityer) Seen(ctx context.Context, devID, orgID string, seenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seen", ctx, devID, orgID, seenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Seen indicates an expected call of Seen.
+func (mr *MockconnectivityerMockRecorder) Seen(ctx, devID, orgID, seenAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seen", reflect.TypeOf((*Mockconnectivityer)(nil).Seen), ctx, devID, orgID, seenAt)
+}
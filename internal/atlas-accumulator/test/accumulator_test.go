@@ -105,6 +105,51 @@ func TestAccumulateMessages(t *testing.T) {
 	}
 }
 
+func TestAccumulateMessagesSeen(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("acc"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createDev, err := globalDevDAO.Create(ctx, random.Device("acc",
+		createOrg.GetId()))
+	t.Logf("createDev, err: %+v, %v", createDev, err)
+	require.NoError(t, err)
+
+	// Devices are seen at the time of receipt, regardless of the data point
+	// timestamp.
+	vOut := &message.ValidatorOut{
+		Point: &common.DataPoint{
+			UniqId: createDev.GetUniqId(), Attr: "acc-motion",
+			ValOneof: &common.DataPoint_IntVal{IntVal: 123},
+			Ts:       timestamppb.New(time.Now().Add(24 * time.Hour)),
+			Token:    uuid.NewV7().String(), TraceId: uuid.NewV7().String(),
+		}, Device: createDev,
+	}
+
+	bVOut, err := proto.Marshal(vOut)
+	require.NoError(t, err)
+	t.Logf("bVOut: %s", bVOut)
+
+	require.NoError(t, globalAccQueue.Publish(globalVOutSubTopic, bVOut))
+	time.Sleep(2 * time.Second)
+
+	ctx, cancel = context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	readConn, err := globalConnDAO.Read(ctx, createDev.GetId(),
+		createOrg.GetId())
+	t.Logf("readConn, err: %+v, %v", readConn, err)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), readConn.GetLastSeenAt().AsTime(),
+		4*time.Second)
+	require.False(t, readConn.GetOffline())
+}
+
 func TestAccumulateMessagesDuplicate(t *testing.T) {
 	t.Parallel()
 
@@ -10,6 +10,7 @@ import (
 	"github.com/thingspect/atlas/internal/atlas-accumulator/accumulator"
 	"github.com/thingspect/atlas/internal/atlas-accumulator/config"
 	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/connectivity"
 	"github.com/thingspect/atlas/pkg/dao/datapoint"
 	"github.com/thingspect/atlas/pkg/dao/device"
 	"github.com/thingspect/atlas/pkg/dao/org"
@@ -22,9 +23,10 @@ var (
 	globalVOutSubTopic string
 	globalAccQueue     queue.Queuer
 
-	globalOrgDAO *org.DAO
-	globalDevDAO *device.DAO
-	globalDPDAO  *datapoint.DAO
+	globalOrgDAO  *org.DAO
+	globalDevDAO  *device.DAO
+	globalDPDAO   *datapoint.DAO
+	globalConnDAO *connectivity.DAO
 )
 
 func TestMain(m *testing.M) {
@@ -33,6 +35,7 @@ func TestMain(m *testing.M) {
 	cfg := config.New()
 	cfg.PgRwURI = testConfig.PgURI
 	cfg.PgRoURI = testConfig.PgURI
+	cfg.RedisHost = testConfig.RedisHost
 
 	cfg.NSQPubAddr = testConfig.NSQPubAddr
 	cfg.NSQLookupAddrs = testConfig.NSQLookupAddrs
@@ -67,6 +70,7 @@ func TestMain(m *testing.M) {
 	globalOrgDAO = org.NewDAO(pg, pg)
 	globalDevDAO = device.NewDAO(pg, pg, nil, 0)
 	globalDPDAO = datapoint.NewDAO(pg, pg)
+	globalConnDAO = connectivity.NewDAO(pg, pg)
 
 	os.Exit(m.Run())
 }
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"strconv"
 	"time"
+	"uuid"
 
 	"github.com/thingspect/atlas/pkg/alog"
 	"github.com/thingspect/atlas/pkg/cache"
@@ -14,12 +16,16 @@ import (
 	"github.com/thingspect/atlas/pkg/template"
 	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
 	"google.golang.org/protobuf/proto"
 )
 
 // ErrUnknownAlarm is returned when sending an alarm of an unknown type.
 const ErrUnknownAlarm consterr.Error = "unknown alarm type"
 
+// webhookType is the metric type of alerts delivered to webhooks.
+const webhookType = "WEBHOOK"
+
 // alertMessages receives event messages, alerts based on alarm processing, and
 // stores the results.
 func (ale *Alerter) alertMessages() {
@@ -80,10 +86,69 @@ func (ale *Alerter) alertMessages() {
 		}
 		logger.Debugf("alertMessages alarms: %+v", alarms)
 
+		// Retrieve webhooks and digests of alarms, and recoveries of alarms
+		// for cleared rules.
+		var hooks map[string]*message.AlarmWebhook
+		var secrets map[string][]byte
+		var digs map[string]*message.AlarmDigest
+		var recs map[string]*message.AlarmRecovery
+		if len(alarms) > 0 {
+			alarmIDs := make([]string, 0, len(alarms))
+			for _, a := range alarms {
+				alarmIDs = append(alarmIDs, a.GetId())
+			}
+
+			dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			hooks, secrets, err = ale.alarmDAO.ListWebhooks(dCtx,
+				eOut.GetDevice().GetOrgId(), alarmIDs)
+			cancel()
+			if err != nil {
+				msg.Requeue()
+				metric.Incr("error",
+					map[string]string{metric.TagFunc: "listwebhooks"})
+				logger.Errorf("alertMessages ale.alarmDAO.ListWebhooks: %v",
+					err)
+
+				continue
+			}
+
+			dCtx, cancel = context.WithTimeout(ctx, 5*time.Second)
+			digs, err = ale.alarmDAO.ListDigests(dCtx,
+				eOut.GetDevice().GetOrgId(), alarmIDs)
+			cancel()
+			if err != nil {
+				msg.Requeue()
+				metric.Incr("error",
+					map[string]string{metric.TagFunc: "listdigests"})
+				logger.Errorf("alertMessages ale.alarmDAO.ListDigests: %v",
+					err)
+
+				continue
+			}
+
+			if eOut.GetCleared() {
+				dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+				recs, err = ale.alarmDAO.ListRecoveries(dCtx,
+					eOut.GetDevice().GetOrgId(), alarmIDs)
+				cancel()
+				if err != nil {
+					msg.Requeue()
+					metric.Incr("error",
+						map[string]string{metric.TagFunc: "listrecoveries"})
+					logger.Errorf("alertMessages ale.alarmDAO.ListRecoveries: "+
+						"%v", err)
+
+					continue
+				}
+			}
+		}
+
 		// Validate, retrieve users, process and send alerts, and store results.
 		for _, a := range alarms {
 			ale.evalAlarms(alog.NewContext(
-				ctx, &alog.CtxLogger{Logger: logger}), eOut, org, a)
+				ctx, &alog.CtxLogger{Logger: logger}), eOut, org, a,
+				recs[a.GetId()], hooks[a.GetId()], secrets[a.GetId()],
+				digs[a.GetId()])
 		}
 
 		msg.Ack()
@@ -98,9 +163,17 @@ func (ale *Alerter) alertMessages() {
 
 // evalAlarms validates alarms, retrieves users, processes and sends alerts, and
 // stores results. Unconditionally acknowledge a message after processing, as
-// there are no guarantees of alarms or users being assigned to an event.
+// there are no guarantees of alarms or users being assigned to an event. For
+// cleared rules, only alarms with a recovery send alerts, using the recovery
+// templates. Otherwise, alerts are not sent while the device's alert lifecycle
+// for the alarm is acknowledged. Alarms with a webhook also publish alerts for
+// delivery to it, in addition to any users. Alerts to users are delivered
+// according to their notification schedules, and those delivered immediately
+// are collected into digests for alarms with a digest.
 func (ale *Alerter) evalAlarms(
 	ctx context.Context, eOut *message.EventerOut, org *api.Org, a *api.Alarm,
+	rec *message.AlarmRecovery, hook *message.AlarmWebhook, secret []byte,
+	dig *message.AlarmDigest,
 ) {
 	logger := alog.FromContext(ctx)
 
@@ -109,6 +182,51 @@ func (ale *Alerter) evalAlarms(
 		return
 	}
 
+	subjTempl, bodyTempl := a.GetSubjectTemplate(), a.GetBodyTemplate()
+	if eOut.GetCleared() {
+		if rec == nil {
+			return
+		}
+
+		subjTempl, bodyTempl = rec.GetSubjectTemplate(), rec.GetBodyTemplate()
+	} else {
+		// Open or retrieve the alert lifecycle. Notifications are suppressed
+		// while acknowledged. On failure, continue to notify rather than risk
+		// a missed alert.
+		dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		lc, err := ale.aleDAO.Open(dCtx, &message.AlertLifecycle{
+			OrgId:   eOut.GetDevice().GetOrgId(),
+			UniqId:  eOut.GetDevice().GetUniqId(),
+			AlarmId: a.GetId(),
+			TraceId: eOut.GetPoint().GetTraceId(),
+		})
+		cancel()
+		if err != nil {
+			metric.Incr("error", map[string]string{metric.TagFunc: "open"})
+			logger.Errorf("alertMessages ale.aleDAO.Open: %v", err)
+		}
+		if lc.GetState() == message.AlertState_ACKNOWLEDGED {
+			metric.Incr("acknowledged", nil)
+			logger.Debugf("alertMessages acknowledged: %+v", lc)
+
+			return
+		}
+
+		// Schedule escalation of the open alert lifecycle, if the alarm has an
+		// escalation policy.
+		if lc.GetState() == message.AlertState_OPEN {
+			dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			err := ale.aleDAO.ScheduleEscalation(dCtx, lc, eOut)
+			cancel()
+			if err != nil {
+				metric.Incr("error",
+					map[string]string{metric.TagFunc: "scheduleescalation"})
+				logger.Errorf("alertMessages ale.aleDAO.ScheduleEscalation: %v",
+					err)
+			}
+		}
+	}
+
 	// Retrieve users. Only active users with matching tags will be returned.
 	dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	users, err := ale.userDAO.ListByTags(dCtx, eOut.GetDevice().GetOrgId(),
@@ -120,103 +238,283 @@ func (ale *Alerter) evalAlarms(
 
 		return
 	}
-	if len(users) == 0 {
+	if len(users) == 0 && hook == nil {
 		return
 	}
 
-	// Generate alert subject and body.
-	subj, err := template.Generate(eOut.GetPoint(), eOut.GetRule(),
-		eOut.GetDevice(), a.GetSubjectTemplate())
-	if err != nil {
-		metric.Incr("error", map[string]string{metric.TagFunc: "gensubject"})
-		logger.Errorf("alertMessages subject template.Generate: %v", err)
-
-		return
+	// Build the template environment, which is completed per recipient.
+	env := &template.Env{
+		Point:  eOut.GetPoint(),
+		Rule:   eOut.GetRule(),
+		Device: eOut.GetDevice(),
+		Org:    org,
+		Alarm:  a,
+		Latest: ale.latestPoints(ctx, eOut.GetDevice(), subjTempl, bodyTempl),
 	}
 
-	body, err := template.Generate(eOut.GetPoint(), eOut.GetRule(),
-		eOut.GetDevice(), a.GetBodyTemplate())
-	if err != nil {
-		metric.Incr("error", map[string]string{metric.TagFunc: "genbody"})
-		logger.Errorf("alertMessages body template.Generate: %v", err)
-
-		return
+	// Process alerts. Webhooks are not rendered for a user, and are generated
+	// as HTML.
+	if hook != nil && !ale.repeated(ctx, eOut, a, uuid.Nil().String()) {
+		subj, body, err := generate(ctx, env, template.ModeHTML, subjTempl,
+			bodyTempl)
+		if err == nil {
+			ale.publishWebhook(ctx, eOut, org, a, hook, secret, subj, body)
+		}
 	}
 
-	// Process alerts.
-	for _, user := range users {
-		// Check cache for existing repeat interval.
-		key := repeatKey(eOut.GetDevice().GetOrgId(), eOut.GetDevice().GetId(),
-			a.GetId(), user.GetId())
-		cCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-		err := ale.cache.SetIfNotExistTTL(cCtx, key, "",
-			time.Duration(a.GetRepeatInterval())*time.Minute)
-		cancel()
-		if err != nil && !errors.Is(err, cache.ErrAlreadyExists) {
-			metric.Incr("error",
-				map[string]string{metric.TagFunc: "setifnotexist"})
-			logger.Errorf("alertMessages ale.cache.SetIfNotExistTTL: %v", err)
+	scheds := ale.listSchedules(ctx, eOut.GetDevice().GetOrgId(), users)
 
+	for _, user := range users {
+		if ale.repeated(ctx, eOut, a, user.GetId()) {
 			continue
 		}
-		if errors.Is(err, cache.ErrAlreadyExists) {
-			metric.Incr("repeat", nil)
+
+		del, alarmType, deliverAt := scheduleAlert(scheds[user.GetId()],
+			a.GetId(), a.GetType(), time.Now())
+		if del == deliverNever {
+			metric.Incr("dropped", nil)
+			logger.Debugf("alertMessages dropped userID: %v", user.GetId())
 
 			continue
 		}
 
-		// Send alert.
-		nCtx, cancel := context.WithTimeout(ctx, time.Minute)
-		switch a.GetType() {
-		case api.AlarmType_APP:
-			err = ale.notify.App(nCtx, user.GetAppKey(), subj, body)
-		case api.AlarmType_SMS:
-			err = ale.notify.SMS(nCtx, user.GetPhone(), subj, body)
-		case api.AlarmType_EMAIL:
-			err = ale.notify.Email(nCtx, org.GetDisplayName(), org.GetEmail(),
-				user.GetEmail(), subj, body)
-		case api.AlarmType_ALARM_TYPE_UNSPECIFIED:
-			fallthrough
-		default:
-			err = ErrUnknownAlarm
+		// Generate alert subject and body for the user, using the template
+		// mode of the delivered alarm type.
+		uEnv := *env
+		uEnv.User = user
+		uEnv.Timezone = scheds[user.GetId()].GetTimezone()
+
+		subj, body, err := generate(ctx, &uEnv, template.ModeFor(alarmType),
+			subjTempl, bodyTempl)
+		if err != nil {
+			continue
 		}
-		cancel()
 
-		alert := &api.Alert{
-			OrgId:   eOut.GetDevice().GetOrgId(),
-			UniqId:  eOut.GetDevice().GetUniqId(),
-			AlarmId: a.GetId(),
-			UserId:  user.GetId(),
-			TraceId: eOut.GetPoint().GetTraceId(),
+		if del == deliverLater {
+			ale.deferAlert(ctx, eOut, org, a, alarmType, user, subj, body,
+				deliverAt)
+
+			continue
 		}
 
-		if err != nil {
-			alert.Status = api.AlertStatus_ERROR
-			alert.Error = err.Error()
-			metric.Incr("error", map[string]string{metric.TagFunc: "notify"})
-			logger.Errorf("alertMessages ale.notify a, err: %+v, %v", a,
-				err.Error())
-		} else {
-			alert.Status = api.AlertStatus_SENT
-			metric.Incr("sent", map[string]string{
-				"type": a.GetType().String(),
-			})
-			logger.Debugf("alertMessages sent user, msg: %+v, %v", user,
-				subj+" - "+body)
-		}
-
-		// Store alert.
-		dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-		err = ale.aleDAO.Create(dCtx, alert)
-		cancel()
-		if err != nil {
-			metric.Incr("error", map[string]string{metric.TagFunc: "create"})
-			logger.Errorf("alertMessages ale.aleDAO.Create: %v", err)
+		if dig != nil {
+			ale.digestAlert(ctx, eOut, org, a, dig, alarmType, user, subj, body)
 
 			continue
 		}
 
-		metric.Incr("created", nil)
-		logger.Debugf("alertMessages created: %+v", alert)
+		ale.notifyUser(ctx, eOut, org, a, alarmType, user, subj, body)
+	}
+}
+
+// generate generates an alert subject and body from templates.
+func generate(
+	ctx context.Context, env *template.Env, mode template.Mode, subjTempl,
+	bodyTempl string,
+) (string, string, error) {
+	logger := alog.FromContext(ctx)
+
+	subj, err := template.Generate(env, mode, subjTempl)
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "gensubject"})
+		logger.Errorf("generate subject template.Generate: %v", err)
+
+		return "", "", err
+	}
+
+	body, err := template.Generate(env, mode, bodyTempl)
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "genbody"})
+		logger.Errorf("generate body template.Generate: %v", err)
+
+		return "", "", err
 	}
+
+	return subj, body, nil
+}
+
+// latestLookback is how far back the latest data points of a device are
+// retrieved for templates.
+const latestLookback = 30 * 24 * time.Hour
+
+// latestPoints retrieves the latest data points of a device, keyed by
+// attribute, if any of the templates call 'latest'. On failure, no data
+// points are returned, and the alert is generated without them.
+func (ale *Alerter) latestPoints(
+	ctx context.Context, dev *api.Device, templs ...string,
+) map[string]*common.DataPoint {
+	if !template.UsesLatest(templs...) {
+		return nil
+	}
+
+	logger := alog.FromContext(ctx)
+
+	dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	points, err := ale.dpDAO.Latest(dCtx, dev.GetOrgId(), "", dev.GetId(),
+		time.Now().Add(-latestLookback))
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "latest"})
+		logger.Errorf("latestPoints ale.dpDAO.Latest: %v", err)
+
+		return nil
+	}
+
+	latest := make(map[string]*common.DataPoint, len(points))
+	for _, point := range points {
+		latest[point.GetAttr()] = point
+	}
+
+	return latest
+}
+
+// listSchedules retrieves the notification schedules of users, keyed by user
+// ID. On failure, no schedules are returned, and alerts are delivered
+// immediately rather than risk a missed alert.
+func (ale *Alerter) listSchedules(
+	ctx context.Context, orgID string, users []*api.User,
+) map[string]*message.NotificationSchedule {
+	if len(users) == 0 {
+		return nil
+	}
+
+	logger := alog.FromContext(ctx)
+
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.GetId())
+	}
+
+	dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	scheds, err := ale.userDAO.ListSchedules(dCtx, orgID, userIDs)
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "listschedules"})
+		logger.Errorf("alertMessages ale.userDAO.ListSchedules: %v", err)
+
+		return nil
+	}
+
+	return scheds
+}
+
+// repeated returns whether an alert to a recipient is within the alarm's
+// repeat interval, and should not be sent. Recoveries are sent once per clear,
+// so they are not subject to the repeat interval. Webhooks are keyed by the
+// nil user ID.
+func (ale *Alerter) repeated(
+	ctx context.Context, eOut *message.EventerOut, a *api.Alarm, userID string,
+) bool {
+	if eOut.GetCleared() {
+		return false
+	}
+
+	logger := alog.FromContext(ctx)
+
+	// Check cache for existing repeat interval.
+	key := repeatKey(eOut.GetDevice().GetOrgId(), eOut.GetDevice().GetId(),
+		a.GetId(), userID)
+	cCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	err := ale.cache.SetIfNotExistTTL(cCtx, key, "",
+		time.Duration(a.GetRepeatInterval())*time.Minute)
+	cancel()
+	if err != nil && !errors.Is(err, cache.ErrAlreadyExists) {
+		metric.Incr("error", map[string]string{metric.TagFunc: "setifnotexist"})
+		logger.Errorf("alertMessages ale.cache.SetIfNotExistTTL: %v", err)
+
+		return true
+	}
+	if errors.Is(err, cache.ErrAlreadyExists) {
+		metric.Incr("repeat", nil)
+
+		return true
+	}
+
+	return false
+}
+
+// notifyUser sends an alert to a user by alarm type and stores the result.
+func (ale *Alerter) notifyUser(
+	ctx context.Context, eOut *message.EventerOut, org *api.Org, a *api.Alarm,
+	alarmType api.AlarmType, user *api.User, subj, body string,
+) {
+	err := ale.sendUser(ctx, org, alarmType, user, subj, body)
+	ale.storeAlert(ctx, eOut, a, user.GetId(), alarmType.String(), err)
+}
+
+// sendUser sends a message to a user by alarm type.
+func (ale *Alerter) sendUser(
+	ctx context.Context, org *api.Org, alarmType api.AlarmType, user *api.User,
+	subj, body string,
+) error {
+	var err error
+	nCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	switch alarmType {
+	case api.AlarmType_APP:
+		err = ale.notify.App(nCtx, user.GetAppKey(), subj, body)
+	case api.AlarmType_SMS:
+		err = ale.notify.SMS(nCtx, user.GetPhone(), subj, body)
+	case api.AlarmType_EMAIL:
+		err = ale.notify.Email(nCtx, org.GetDisplayName(), org.GetEmail(),
+			user.GetEmail(), subj, body)
+	case api.AlarmType_ALARM_TYPE_UNSPECIFIED:
+		fallthrough
+	default:
+		err = ErrUnknownAlarm
+	}
+	cancel()
+
+	return err
+}
+
+// storeAlert stores the result of sending an alert to a recipient.
+func (ale *Alerter) storeAlert(
+	ctx context.Context, eOut *message.EventerOut, a *api.Alarm, userID,
+	alertType string, err error,
+) {
+	logger := alog.FromContext(ctx)
+
+	alert := &api.Alert{
+		OrgId:   eOut.GetDevice().GetOrgId(),
+		UniqId:  eOut.GetDevice().GetUniqId(),
+		AlarmId: a.GetId(),
+		UserId:  userID,
+		TraceId: eOut.GetPoint().GetTraceId(),
+	}
+
+	if err != nil {
+		alert.Status = api.AlertStatus_ERROR
+		alert.Error = err.Error()
+		metric.Incr("error", map[string]string{metric.TagFunc: "notify"})
+		logger.Errorf("alertMessages ale.notify a, err: %+v, %v", a,
+			err.Error())
+	} else {
+		alert.Status = api.AlertStatus_SENT
+		metric.Incr("sent", map[string]string{
+			"type":    alertType,
+			"cleared": strconv.FormatBool(eOut.GetCleared()),
+		})
+		logger.Debugf("alertMessages sent userID, type: %v, %v", userID,
+			alertType)
+	}
+
+	ale.createAlert(ctx, alert)
+}
+
+// createAlert stores an alert.
+func (ale *Alerter) createAlert(ctx context.Context, alert *api.Alert) {
+	logger := alog.FromContext(ctx)
+
+	dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	err := ale.aleDAO.Create(dCtx, alert)
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "create"})
+		logger.Errorf("alertMessages ale.aleDAO.Create: %v", err)
+
+		return
+	}
+
+	metric.Incr("created", nil)
+	logger.Debugf("alertMessages created: %+v", alert)
 }
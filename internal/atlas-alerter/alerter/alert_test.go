@@ -22,6 +22,7 @@ import (
 	"github.com/thingspect/proto/go/common"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const errTestProc consterr.Error = "alerter: test processor error"
@@ -135,8 +136,15 @@ func TestAlertMessages(t *testing.T) {
 			alarmer.EXPECT().List(gomock.Any(), test.inpEOut.GetDevice().
 				GetOrgId(), time.Time{}, "", int32(0), test.inpEOut.GetRule().
 				GetId()).Return(test.inpAlarms, int32(0), nil).Times(1)
+			alarmer.EXPECT().ListWebhooks(gomock.Any(), test.inpEOut.
+				GetDevice().GetOrgId(), gomock.Any()).Return(nil, nil, nil).
+				Times(1)
+			alarmer.EXPECT().ListDigests(gomock.Any(), test.inpEOut.
+				GetDevice().GetOrgId(), gomock.Any()).Return(nil, nil).Times(1)
 
 			userer := NewMockuserer(ctrl)
+			userer.EXPECT().ListSchedules(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil, nil).AnyTimes()
 			userer.EXPECT().ListByTags(gomock.Any(), test.inpEOut.GetDevice().
 				GetOrgId(), test.inpAlarms[0].GetUserTags()).Return(test.
 				inpUsers, nil).Times(test.inpUserTimes)
@@ -163,6 +171,12 @@ func TestAlertMessages(t *testing.T) {
 				Return(nil).Times(test.inpEmailTimes)
 
 			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().Open(gomock.Any(), gomock.Any()).
+				Return(&message.AlertLifecycle{
+					State: message.AlertState_OPEN,
+				}, nil).Times(test.inpUserTimes)
+			alerter.EXPECT().ScheduleEscalation(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil).Times(test.inpUserTimes)
 			alerter.EXPECT().Create(gomock.Any(),
 				matcher.NewProtoMatcher(alert)).
 				DoAndReturn(func(_ any, _ any) error {
@@ -217,6 +231,734 @@ func TestAlertMessages(t *testing.T) {
 	}
 }
 
+func TestAlertMessagesLifecycle(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	alarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	alarm.Status = api.Status_ACTIVE
+	alarm.Type = api.AlarmType_APP
+
+	tests := []struct {
+		inpLC         *message.AlertLifecycle
+		inpLCErr      error
+		inpSchedErr   error
+		inpSchedTimes int
+		inpUserTimes  int
+	}{
+		{
+			&message.AlertLifecycle{State: message.AlertState_OPEN}, nil, nil,
+			1, 1,
+		},
+		{
+			&message.AlertLifecycle{State: message.AlertState_ACKNOWLEDGED},
+			nil, nil, 0, 0,
+		},
+		// Lifecycle and escalation errors do not prevent notification.
+		{nil, errTestProc, nil, 0, 1},
+		{
+			&message.AlertLifecycle{State: message.AlertState_OPEN}, nil,
+			errTestProc, 1, 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can suppress %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			eOut := &message.EventerOut{
+				Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+				Device: random.Device("ale", org.GetId()),
+				Rule:   random.Rule("ale", org.GetId()),
+			}
+			user := random.User("ale", org.GetId())
+
+			eOutQueue := queue.NewFake()
+			eOutSub, err := eOutQueue.Subscribe("")
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().Read(gomock.Any(), org.GetId()).Return(org, nil).
+				Times(1)
+
+			alarmer := NewMockalarmer(ctrl)
+			alarmer.EXPECT().List(gomock.Any(), org.GetId(), time.Time{}, "",
+				int32(0), eOut.GetRule().GetId()).
+				Return([]*api.Alarm{alarm}, int32(0), nil).Times(1)
+			alarmer.EXPECT().ListWebhooks(gomock.Any(), org.GetId(),
+				[]string{alarm.GetId()}).Return(nil, nil, nil).Times(1)
+			alarmer.EXPECT().ListDigests(gomock.Any(), org.GetId(),
+				[]string{alarm.GetId()}).Return(nil, nil).Times(1)
+
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().ListSchedules(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil, nil).AnyTimes()
+			userer.EXPECT().ListByTags(gomock.Any(), org.GetId(),
+				alarm.GetUserTags()).Return([]*api.User{user}, nil).
+				Times(test.inpUserTimes)
+
+			notifier := notify.NewMockNotifier(ctrl)
+			notifier.EXPECT().App(gomock.Any(), user.GetAppKey(), gomock.Any(),
+				gomock.Any()).Return(nil).Times(test.inpUserTimes)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().Open(gomock.Any(), matcher.NewProtoMatcher(
+				&message.AlertLifecycle{
+					OrgId:   org.GetId(),
+					UniqId:  eOut.GetDevice().GetUniqId(),
+					AlarmId: alarm.GetId(),
+					TraceId: eOut.GetPoint().GetTraceId(),
+				})).Return(test.inpLC, test.inpLCErr).Times(1)
+			alerter.EXPECT().ScheduleEscalation(gomock.Any(), test.inpLC,
+				matcher.NewProtoMatcher(eOut)).Return(test.inpSchedErr).
+				Times(test.inpSchedTimes)
+			alerter.EXPECT().Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ any, _ any) error {
+					defer wg.Done()
+
+					return nil
+				}).Times(test.inpUserTimes)
+
+			ale := Alerter{
+				orgDAO:   orger,
+				alarmDAO: alarmer,
+				userDAO:  userer,
+				aleDAO:   alerter,
+				cache:    cache.NewHeap[string](),
+
+				aleQueue: eOutQueue,
+				eOutSub:  eOutSub,
+
+				notify: notifier,
+			}
+			go func() {
+				ale.alertMessages()
+			}()
+
+			bEOut, err := proto.Marshal(eOut)
+			require.NoError(t, err)
+			t.Logf("bEOut: %s", bEOut)
+
+			require.NoError(t, eOutQueue.Publish("", bEOut))
+			if test.inpUserTimes > 0 {
+				wg.Wait()
+			} else {
+				// If the success mode isn't supported by WaitGroup operation,
+				// give it time to traverse the code.
+				time.Sleep(100 * time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestAlertMessagesSchedule(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	alarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	alarm.Status = api.Status_ACTIVE
+	alarm.Type = api.AlarmType_APP
+
+	// Build windows relative to now, in UTC, which are closed and open for the
+	// duration of the test.
+	nowMin := int32(time.Now().UTC().Hour()*60 + time.Now().UTC().Minute())
+	closedApp := &message.ScheduleWindow{
+		Type: api.AlarmType_APP, StartMinute: (nowMin + 60) % minutesPerDay,
+		EndMinute: (nowMin + 61) % minutesPerDay,
+	}
+	openSMS := &message.ScheduleWindow{
+		Type:        api.AlarmType_SMS,
+		StartMinute: (nowMin + minutesPerDay - 60) % minutesPerDay,
+		EndMinute:   (nowMin + 60) % minutesPerDay,
+	}
+
+	sched := func(
+		action message.ScheduleAction, critical ...string,
+	) *message.NotificationSchedule {
+		return &message.NotificationSchedule{
+			Timezone: "UTC",
+			Windows:  []*message.ScheduleWindow{closedApp, openSMS},
+			Action:   action, FallbackType: api.AlarmType_SMS,
+			CriticalAlarmIds: critical,
+		}
+	}
+
+	tests := []struct {
+		inpSched       *message.NotificationSchedule
+		inpSchedErr    error
+		inpDeferErr    error
+		inpDeferTimes  int
+		inpAppTimes    int
+		inpSMSTimes    int
+		inpAlertTimes  int
+		inpRecordTimes int
+		resStatus      api.AlertStatus
+	}{
+		{
+			sched(message.ScheduleAction_DEFER), nil, nil, 1, 0, 0, 0, 1,
+			api.AlertStatus_ALERT_STATUS_UNSPECIFIED,
+		},
+		{
+			sched(message.ScheduleAction_DROP), nil, nil, 0, 0, 0, 0, 0,
+			api.AlertStatus_ALERT_STATUS_UNSPECIFIED,
+		},
+		{
+			sched(message.ScheduleAction_FALLBACK), nil, nil, 0, 0, 1, 1, 0,
+			api.AlertStatus_SENT,
+		},
+		{
+			sched(message.ScheduleAction_DROP, alarm.GetId()), nil, nil, 0, 1,
+			0, 1, 0, api.AlertStatus_SENT,
+		},
+		// Schedule and deferral errors do not prevent notification.
+		{nil, errTestProc, nil, 0, 1, 0, 1, 0, api.AlertStatus_SENT},
+		{
+			sched(message.ScheduleAction_DEFER), nil, errTestProc, 1, 1, 0, 1,
+			0, api.AlertStatus_SENT,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can schedule %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			eOut := &message.EventerOut{
+				Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+				Device: random.Device("ale", org.GetId()),
+				Rule:   random.Rule("ale", org.GetId()),
+			}
+			user := random.User("ale", org.GetId())
+
+			eOutQueue := queue.NewFake()
+			eOutSub, err := eOutQueue.Subscribe("")
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().Read(gomock.Any(), org.GetId()).Return(org, nil).
+				Times(1)
+
+			alarmer := NewMockalarmer(ctrl)
+			alarmer.EXPECT().List(gomock.Any(), org.GetId(), time.Time{}, "",
+				int32(0), eOut.GetRule().GetId()).
+				Return([]*api.Alarm{alarm}, int32(0), nil).Times(1)
+			alarmer.EXPECT().ListWebhooks(gomock.Any(), org.GetId(),
+				[]string{alarm.GetId()}).Return(nil, nil, nil).Times(1)
+			alarmer.EXPECT().ListDigests(gomock.Any(), org.GetId(),
+				[]string{alarm.GetId()}).Return(nil, nil).Times(1)
+
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().ListByTags(gomock.Any(), org.GetId(),
+				alarm.GetUserTags()).Return([]*api.User{user}, nil).Times(1)
+			userer.EXPECT().ListSchedules(gomock.Any(), org.GetId(),
+				[]string{user.GetId()}).
+				Return(map[string]*message.NotificationSchedule{
+					user.GetId(): test.inpSched,
+				}, test.inpSchedErr).Times(1)
+
+			notifier := notify.NewMockNotifier(ctrl)
+			notifier.EXPECT().App(gomock.Any(), user.GetAppKey(), gomock.Any(),
+				gomock.Any()).Return(nil).Times(test.inpAppTimes)
+			notifier.EXPECT().SMS(gomock.Any(), user.GetPhone(), gomock.Any(),
+				gomock.Any()).Return(nil).Times(test.inpSMSTimes)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().Open(gomock.Any(), gomock.Any()).
+				Return(&message.AlertLifecycle{
+					State: message.AlertState_OPEN,
+				}, nil).Times(1)
+			alerter.EXPECT().ScheduleEscalation(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil).Times(1)
+			alerter.EXPECT().Defer(gomock.Any(), gomock.Cond(
+				func(da *message.DeferredAlert) bool {
+					return da.GetUserId() == user.GetId() &&
+						da.GetType() == api.AlarmType_APP &&
+						da.GetDeliverAt().AsTime().After(time.Now())
+				})).DoAndReturn(func(
+				_ any, da *message.DeferredAlert,
+			) (*message.DeferredAlert, error) {
+				return da, test.inpDeferErr
+			}).Times(test.inpDeferTimes)
+			resAlert := &api.Alert{
+				OrgId:   org.GetId(),
+				UniqId:  eOut.GetDevice().GetUniqId(),
+				AlarmId: alarm.GetId(),
+				UserId:  user.GetId(),
+				Status:  test.resStatus,
+				TraceId: eOut.GetPoint().GetTraceId(),
+			}
+			alerter.EXPECT().Create(gomock.Any(),
+				matcher.NewProtoMatcher(resAlert)).
+				DoAndReturn(func(_ any, _ any) error {
+					defer wg.Done()
+
+					return nil
+				}).Times(test.inpAlertTimes)
+			alerter.EXPECT().CreateRecord(gomock.Any(), matcher.NewProtoMatcher(
+				&message.AlertRecord{Alert: resAlert, Deferred: true})).
+				DoAndReturn(func(_ any, _ any) error {
+					defer wg.Done()
+
+					return nil
+				}).Times(test.inpRecordTimes)
+
+			ale := Alerter{
+				orgDAO:   orger,
+				alarmDAO: alarmer,
+				userDAO:  userer,
+				aleDAO:   alerter,
+				cache:    cache.NewHeap[string](),
+
+				aleQueue: eOutQueue,
+				eOutSub:  eOutSub,
+
+				notify: notifier,
+			}
+			go func() {
+				ale.alertMessages()
+			}()
+
+			bEOut, err := proto.Marshal(eOut)
+			require.NoError(t, err)
+			t.Logf("bEOut: %s", bEOut)
+
+			require.NoError(t, eOutQueue.Publish("", bEOut))
+			if test.inpAlertTimes+test.inpRecordTimes > 0 {
+				wg.Wait()
+			} else {
+				// If the success mode isn't supported by WaitGroup operation,
+				// give it time to traverse the code.
+				time.Sleep(100 * time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestAlertMessagesEnv(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	tests := []struct {
+		inpType       api.AlarmType
+		inpLatestErr  error
+		inpSMSTimes   int
+		inpEmailTimes int
+		resSubj       string
+		resBody       string
+	}{
+		{api.AlarmType_SMS, nil, 1, 0, "A & B", "<b> at 00:04"},
+		{
+			api.AlarmType_EMAIL, nil, 0, 1, "A &amp; B",
+			"&lt;b&gt; at 00:04",
+		},
+		// Latest errors do not prevent notification.
+		{api.AlarmType_EMAIL, errTestProc, 0, 1, "A &amp; B", " at 00:04"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can alert %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			alarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+			alarm.Status = api.Status_ACTIVE
+			alarm.Type = test.inpType
+			alarm.SubjectTemplate = `{{.user.Name}}`
+			alarm.BodyTemplate = `{{latest "note"}} at ` +
+				`{{formatTime .point.Ts "15:04"}}`
+
+			eOut := &message.EventerOut{
+				Point: &common.DataPoint{
+					Ts: timestamppb.New(time.Date(2026, 1, 2, 15, 4, 0, 0,
+						time.UTC)),
+					TraceId: uuid.NewV7().String(),
+				},
+				Device: random.Device("ale", org.GetId()),
+				Rule:   random.Rule("ale", org.GetId()),
+			}
+			user := random.User("ale", org.GetId())
+			user.Name = "A & B"
+
+			eOutQueue := queue.NewFake()
+			eOutSub, err := eOutQueue.Subscribe("")
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().Read(gomock.Any(), org.GetId()).Return(org, nil).
+				Times(1)
+
+			alarmer := NewMockalarmer(ctrl)
+			alarmer.EXPECT().List(gomock.Any(), org.GetId(), time.Time{}, "",
+				int32(0), eOut.GetRule().GetId()).
+				Return([]*api.Alarm{alarm}, int32(0), nil).Times(1)
+			alarmer.EXPECT().ListWebhooks(gomock.Any(), org.GetId(),
+				[]string{alarm.GetId()}).Return(nil, nil, nil).Times(1)
+			alarmer.EXPECT().ListDigests(gomock.Any(), org.GetId(),
+				[]string{alarm.GetId()}).Return(nil, nil).Times(1)
+
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().ListByTags(gomock.Any(), org.GetId(),
+				alarm.GetUserTags()).Return([]*api.User{user}, nil).Times(1)
+			userer.EXPECT().ListSchedules(gomock.Any(), org.GetId(),
+				[]string{user.GetId()}).
+				Return(map[string]*message.NotificationSchedule{
+					user.GetId(): {Timezone: "Asia/Tokyo"},
+				}, nil).Times(1)
+
+			datapointer := NewMockdatapointer(ctrl)
+			datapointer.EXPECT().Latest(gomock.Any(), org.GetId(), "",
+				eOut.GetDevice().GetId(), gomock.Any()).
+				Return([]*common.DataPoint{{
+					Attr: "note",
+					ValOneof: &common.DataPoint_StrVal{
+						StrVal: "<b>",
+					},
+				}}, test.inpLatestErr).Times(1)
+
+			notifier := notify.NewMockNotifier(ctrl)
+			notifier.EXPECT().SMS(gomock.Any(), user.GetPhone(), test.resSubj,
+				test.resBody).Return(nil).
+				Times(test.inpSMSTimes)
+			notifier.EXPECT().Email(gomock.Any(), org.GetDisplayName(),
+				org.GetEmail(), user.GetEmail(), test.resSubj, test.resBody).
+				Return(nil).Times(test.inpEmailTimes)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().Open(gomock.Any(), gomock.Any()).
+				Return(&message.AlertLifecycle{
+					State: message.AlertState_OPEN,
+				}, nil).Times(1)
+			alerter.EXPECT().ScheduleEscalation(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil).Times(1)
+			alerter.EXPECT().Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ any, _ any) error {
+					defer wg.Done()
+
+					return nil
+				}).Times(1)
+
+			ale := Alerter{
+				orgDAO:   orger,
+				alarmDAO: alarmer,
+				userDAO:  userer,
+				dpDAO:    datapointer,
+				aleDAO:   alerter,
+				cache:    cache.NewHeap[string](),
+
+				aleQueue: eOutQueue,
+				eOutSub:  eOutSub,
+
+				notify: notifier,
+			}
+			go func() {
+				ale.alertMessages()
+			}()
+
+			bEOut, err := proto.Marshal(eOut)
+			require.NoError(t, err)
+			t.Logf("bEOut: %s", bEOut)
+
+			require.NoError(t, eOutQueue.Publish("", bEOut))
+			wg.Wait()
+		})
+	}
+}
+
+func TestAlertMessagesRecovery(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	alarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	alarm.Status = api.Status_ACTIVE
+	alarm.Type = api.AlarmType_APP
+
+	rec := &message.AlarmRecovery{
+		AlarmId: alarm.GetId(), OrgId: org.GetId(), RuleId: alarm.GetRuleId(),
+		SubjectTemplate: "ale-recovered", BodyTemplate: "{{.rule.Name}} ok",
+	}
+
+	tests := []struct {
+		inpRecs      map[string]*message.AlarmRecovery
+		inpRecsErr   error
+		inpUserTimes int
+		inpAppTimes  int
+	}{
+		{map[string]*message.AlarmRecovery{alarm.GetId(): rec}, nil, 1, 1},
+		{map[string]*message.AlarmRecovery{}, nil, 0, 0},
+		{nil, errTestProc, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can recover %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			eOut := &message.EventerOut{
+				Point:   &common.DataPoint{TraceId: uuid.NewV7().String()},
+				Device:  random.Device("ale", org.GetId()),
+				Rule:    random.Rule("ale", org.GetId()),
+				Cleared: true,
+			}
+			user := random.User("ale", org.GetId())
+
+			eOutQueue := queue.NewFake()
+			eOutSub, err := eOutQueue.Subscribe("")
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().Read(gomock.Any(), org.GetId()).Return(org, nil).
+				Times(1)
+
+			alarmer := NewMockalarmer(ctrl)
+			alarmer.EXPECT().List(gomock.Any(), org.GetId(), time.Time{}, "",
+				int32(0), eOut.GetRule().GetId()).
+				Return([]*api.Alarm{alarm}, int32(0), nil).Times(1)
+			alarmer.EXPECT().ListWebhooks(gomock.Any(), org.GetId(),
+				[]string{alarm.GetId()}).Return(nil, nil, nil).Times(1)
+			alarmer.EXPECT().ListDigests(gomock.Any(), org.GetId(),
+				[]string{alarm.GetId()}).Return(nil, nil).Times(1)
+			alarmer.EXPECT().ListRecoveries(gomock.Any(), org.GetId(),
+				[]string{alarm.GetId()}).Return(test.inpRecs,
+				test.inpRecsErr).Times(1)
+
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().ListSchedules(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil, nil).AnyTimes()
+			userer.EXPECT().ListByTags(gomock.Any(), org.GetId(),
+				alarm.GetUserTags()).Return([]*api.User{user}, nil).
+				Times(test.inpUserTimes)
+
+			// Recoveries use their own templates and bypass the repeat
+			// interval.
+			notifier := notify.NewMockNotifier(ctrl)
+			notifier.EXPECT().App(gomock.Any(), user.GetAppKey(),
+				"ale-recovered", eOut.GetRule().GetName()+" ok").Return(nil).
+				Times(test.inpAppTimes)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().Create(gomock.Any(),
+				matcher.NewProtoMatcher(&api.Alert{
+					OrgId:   org.GetId(),
+					UniqId:  eOut.GetDevice().GetUniqId(),
+					AlarmId: alarm.GetId(),
+					UserId:  user.GetId(),
+					Status:  api.AlertStatus_SENT,
+					TraceId: eOut.GetPoint().GetTraceId(),
+				})).DoAndReturn(func(_ any, _ any) error {
+				defer wg.Done()
+
+				return nil
+			}).Times(test.inpAppTimes)
+
+			ale := Alerter{
+				orgDAO:   orger,
+				alarmDAO: alarmer,
+				userDAO:  userer,
+				aleDAO:   alerter,
+				cache:    cache.NewMockCacher[string](ctrl),
+
+				aleQueue: eOutQueue,
+				eOutSub:  eOutSub,
+
+				notify: notifier,
+			}
+			go func() {
+				ale.alertMessages()
+			}()
+
+			bEOut, err := proto.Marshal(eOut)
+			require.NoError(t, err)
+			t.Logf("bEOut: %s", bEOut)
+
+			require.NoError(t, eOutQueue.Publish("", bEOut))
+			if test.inpAppTimes > 0 {
+				wg.Wait()
+			} else {
+				// If the success mode isn't supported by WaitGroup operation,
+				// give it time to traverse the code.
+				time.Sleep(100 * time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestAlertMessagesWebhook(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	alarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	alarm.Status = api.Status_ACTIVE
+	alarm.Type = api.AlarmType_APP
+	alarm.SubjectTemplate = "ale-subj"
+	alarm.BodyTemplate = "{{.rule.Name}} body"
+
+	hook := &message.AlarmWebhook{
+		AlarmId: alarm.GetId(), OrgId: org.GetId(), RuleId: alarm.GetRuleId(),
+		Url: "https://ale.example.com/hook",
+	}
+	hooks := map[string]*message.AlarmWebhook{alarm.GetId(): hook}
+
+	encSecret := []byte(random.String(10))
+	secrets := map[string][]byte{alarm.GetId(): encSecret}
+
+	tests := []struct {
+		inpHooks     map[string]*message.AlarmWebhook
+		inpSecrets   map[string][]byte
+		inpHooksErr  error
+		inpSeedCache bool
+		inpUserTimes int
+		resPublished bool
+	}{
+		{hooks, secrets, nil, false, 1, true},
+		{hooks, secrets, nil, true, 1, false},
+		{nil, nil, errTestProc, false, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can publish %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			eOut := &message.EventerOut{
+				Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+				Device: random.Device("ale", org.GetId()),
+				Rule:   random.Rule("ale", org.GetId()),
+			}
+
+			eOutQueue := queue.NewFake()
+			eOutSub, err := eOutQueue.Subscribe("")
+			require.NoError(t, err)
+
+			hookQueue := queue.NewFake()
+			hookSub, err := hookQueue.Subscribe("")
+			require.NoError(t, err)
+			hookPubTopic := "topic-" + random.String(10)
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().Read(gomock.Any(), org.GetId()).Return(org, nil).
+				Times(1)
+
+			alarmer := NewMockalarmer(ctrl)
+			alarmer.EXPECT().List(gomock.Any(), org.GetId(), time.Time{}, "",
+				int32(0), eOut.GetRule().GetId()).
+				Return([]*api.Alarm{alarm}, int32(0), nil).Times(1)
+			alarmer.EXPECT().ListWebhooks(gomock.Any(), org.GetId(),
+				[]string{alarm.GetId()}).Return(test.inpHooks,
+				test.inpSecrets, test.inpHooksErr).Times(1)
+			alarmer.EXPECT().ListDigests(gomock.Any(), org.GetId(),
+				[]string{alarm.GetId()}).Return(nil, nil).
+				Times(test.inpUserTimes)
+
+			// Webhooks are delivered without users.
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().ListSchedules(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil, nil).AnyTimes()
+			userer.EXPECT().ListByTags(gomock.Any(), org.GetId(),
+				alarm.GetUserTags()).Return([]*api.User{}, nil).
+				Times(test.inpUserTimes)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().Open(gomock.Any(), gomock.Any()).
+				Return(&message.AlertLifecycle{
+					State: message.AlertState_OPEN,
+				}, nil).Times(test.inpUserTimes)
+			alerter.EXPECT().ScheduleEscalation(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil).Times(test.inpUserTimes)
+
+			cache := cache.NewHeap[string]()
+
+			if test.inpSeedCache {
+				ctx, cancel := context.WithTimeout(t.Context(),
+					5*time.Second)
+				defer cancel()
+
+				key := repeatKey(org.GetId(), eOut.GetDevice().GetId(),
+					alarm.GetId(), uuid.Nil().String())
+				require.NoError(t, cache.SetIfNotExistTTL(ctx, key, "",
+					time.Minute))
+			}
+
+			ale := Alerter{
+				orgDAO:   orger,
+				alarmDAO: alarmer,
+				userDAO:  userer,
+				aleDAO:   alerter,
+				cache:    cache,
+
+				aleQueue:     hookQueue,
+				eOutSub:      eOutSub,
+				hookPubTopic: hookPubTopic,
+			}
+			go func() {
+				ale.alertMessages()
+			}()
+
+			bEOut, err := proto.Marshal(eOut)
+			require.NoError(t, err)
+			t.Logf("bEOut: %s", bEOut)
+
+			require.NoError(t, eOutQueue.Publish("", bEOut))
+
+			if !test.resPublished {
+				select {
+				case msg := <-hookSub.C():
+					t.Fatalf("Unexpected msg: %+v", msg)
+				case <-time.After(100 * time.Millisecond):
+				}
+
+				return
+			}
+
+			select {
+			case msg := <-hookSub.C():
+				msg.Ack()
+				t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(),
+					msg.Payload())
+				require.Equal(t, hookPubTopic, msg.Topic())
+
+				del := &message.WebhookDelivery{}
+				require.NoError(t, proto.Unmarshal(msg.Payload(), del))
+				t.Logf("del: %+v", del)
+
+				require.Equal(t, hook.GetUrl(), del.GetUrl())
+				require.Equal(t, encSecret, del.GetSecret())
+
+				event := del.GetEvent()
+				require.Equal(t, alarm.GetId(), event.GetAlarmId())
+				require.Equal(t, "ale-subj", event.GetSubject())
+				require.Equal(t, eOut.GetRule().GetName()+" body",
+					event.GetBody())
+				require.Equal(t, eOut.GetDevice().GetUniqId(),
+					event.GetDevice().GetUniqId())
+				require.Empty(t, event.GetDevice().GetToken())
+				require.Equal(t, eOut.GetRule().GetId(),
+					event.GetRule().GetId())
+				require.Equal(t, org.GetId(), event.GetOrg().GetId())
+			case <-time.After(2 * time.Second):
+				t.Fatal("Message timed out")
+			}
+		})
+	}
+}
+
 func TestAlertMessagesError(t *testing.T) {
 	t.Parallel()
 
@@ -312,7 +1054,7 @@ func TestAlertMessagesError(t *testing.T) {
 				Rule: &api.Rule{},
 			}, org, nil, []*api.Alarm{badSubj}, nil, []*api.User{
 				random.User("ale", uuid.NewV7().String()),
-			}, nil, nil, nil, nil, 1, 1, 1, 0, 0, 0,
+			}, nil, nil, nil, nil, 1, 1, 1, 1, 0, 0,
 		},
 		// Bad alarm body.
 		{
@@ -321,7 +1063,7 @@ func TestAlertMessagesError(t *testing.T) {
 				Rule: &api.Rule{},
 			}, org, nil, []*api.Alarm{badBody}, nil, []*api.User{
 				random.User("ale", uuid.NewV7().String()),
-			}, nil, nil, nil, nil, 1, 1, 1, 0, 0, 0,
+			}, nil, nil, nil, nil, 1, 1, 1, 1, 0, 0,
 		},
 		// Cacher error.
 		{
@@ -392,7 +1134,18 @@ func TestAlertMessagesError(t *testing.T) {
 				Return(test.inpAlarms, int32(0), test.inpAlarmsErr).
 				Times(test.inpAlarmTimes)
 
+			var hookTimes int
+			if len(test.inpAlarms) > 0 {
+				hookTimes = 1
+			}
+			alarmer.EXPECT().ListWebhooks(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil, nil, nil).Times(hookTimes)
+			alarmer.EXPECT().ListDigests(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil, nil).Times(hookTimes)
+
 			userer := NewMockuserer(ctrl)
+			userer.EXPECT().ListSchedules(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil, nil).AnyTimes()
 			userer.EXPECT().ListByTags(gomock.Any(), gomock.Any(),
 				gomock.Any()).Return(test.inpUsers, test.inpUsersErr).
 				Times(test.inpUserTimes)
@@ -407,6 +1160,12 @@ func TestAlertMessagesError(t *testing.T) {
 				gomock.Any()).Return(test.inpAppErr).Times(test.inpAppTimes)
 
 			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().Open(gomock.Any(), gomock.Any()).
+				Return(&message.AlertLifecycle{
+					State: message.AlertState_OPEN,
+				}, nil).Times(test.inpUserTimes)
+			alerter.EXPECT().ScheduleEscalation(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil).Times(test.inpUserTimes)
 			alerter.EXPECT().Create(gomock.Any(), gomock.Any()).
 				DoAndReturn(func(_ any, _ any) error {
 					defer wg.Done()
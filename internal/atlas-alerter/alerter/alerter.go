@@ -13,19 +13,27 @@ import (
 	"github.com/thingspect/atlas/internal/atlas-alerter/config"
 	"github.com/thingspect/atlas/pkg/alog"
 	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/consterr"
 	"github.com/thingspect/atlas/pkg/dao"
 	"github.com/thingspect/atlas/pkg/dao/alarm"
 	"github.com/thingspect/atlas/pkg/dao/alert"
+	"github.com/thingspect/atlas/pkg/dao/datapoint"
 	"github.com/thingspect/atlas/pkg/dao/org"
 	"github.com/thingspect/atlas/pkg/dao/user"
 	"github.com/thingspect/atlas/pkg/notify"
 	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
 )
 
 // ServiceName provides consistent naming, including logs and metrics.
 const ServiceName = "alerter"
 
+// errHookLength is returned due to insufficient key length.
+// #nosec G101 // False positive for hardcoded credentials.
+const errHookLength consterr.Error = "webhook key must be 32 bytes"
+
 // orger defines the methods provided by an org.DAO.
 type orger interface {
 	Read(ctx context.Context, orgID string) (*api.Org, error)
@@ -33,19 +41,55 @@ type orger interface {
 
 // alarmer defines the methods provided by a alarm.DAO.
 type alarmer interface {
+	Read(ctx context.Context, alarmID, orgID, ruleID string) (*api.Alarm,
+		error)
 	List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string,
 		limit int32, ruleID string) ([]*api.Alarm, int32, error)
+	ListRecoveries(ctx context.Context, orgID string, alarmIDs []string) (
+		map[string]*message.AlarmRecovery, error)
+	ListWebhooks(ctx context.Context, orgID string, alarmIDs []string) (
+		map[string]*message.AlarmWebhook, map[string][]byte, error)
+	ListDigests(ctx context.Context, orgID string, alarmIDs []string) (
+		map[string]*message.AlarmDigest, error)
 }
 
 // userer defines the methods provided by a user.DAO.
 type userer interface {
+	Read(ctx context.Context, userID, orgID string) (*api.User, error)
 	ListByTags(ctx context.Context, orgID string, tags []string) ([]*api.User,
 		error)
+	ListSchedules(ctx context.Context, orgID string, userIDs []string) (
+		map[string]*message.NotificationSchedule, error)
+}
+
+// datapointer defines the methods provided by a datapoint.DAO.
+type datapointer interface {
+	Latest(ctx context.Context, orgID, uniqID, devID string,
+		start time.Time) ([]*common.DataPoint, error)
 }
 
 // alerter defines the methods provided by a alert.DAO.
 type alerter interface {
 	Create(ctx context.Context, alert *api.Alert) error
+	Open(ctx context.Context, lc *message.AlertLifecycle) (
+		*message.AlertLifecycle, error)
+	ScheduleEscalation(ctx context.Context, lc *message.AlertLifecycle,
+		eOut *message.EventerOut) error
+	ClaimEscalations(ctx context.Context, now time.Time, limit int32) (
+		[]*message.AlertEscalation, error)
+	CreateRecord(ctx context.Context, rec *message.AlertRecord) error
+	Defer(ctx context.Context, da *message.DeferredAlert) (
+		*message.DeferredAlert, error)
+	ClaimDeferred(ctx context.Context, now time.Time, lease time.Duration,
+		limit int32) ([]*message.DeferredAlert, error)
+	DeleteDeferred(ctx context.Context, daID, orgID string) error
+	CreateDigest(ctx context.Context, digest *message.AlertDigest) (
+		*message.AlertDigest, error)
+	CreateInDigest(ctx context.Context, alert *api.Alert, digestID string) error
+	ClaimDigests(ctx context.Context, now time.Time, limit int32) (
+		[]*message.AlertDigest, error)
+	CompleteDigest(ctx context.Context, digestID, orgID string,
+		status api.AlertStatus, digestErr string) error
 }
 
 // Alerter holds references to the database and message broker connections.
@@ -53,17 +97,26 @@ type Alerter struct {
 	orgDAO   orger
 	alarmDAO alarmer
 	userDAO  userer
+	dpDAO    datapointer
 	aleDAO   alerter
 	cache    cache.Cacher[string]
 
-	aleQueue queue.Queuer
-	eOutSub  queue.Subber
+	aleQueue     queue.Queuer
+	eOutSub      queue.Subber
+	hookSub      queue.Subber
+	hookPubTopic string
 
-	notify notify.Notifier
+	notify  notify.Notifier
+	hookKey []byte
 }
 
 // New builds a new Alerter and returns a reference to it and an error value.
 func New(cfg *config.Config) (*Alerter, error) {
+	// Validate Config.
+	if len(cfg.HookKey) != 32 {
+		return nil, errHookLength
+	}
+
 	// Set up database connection.
 	pgRW, err := dao.NewPgDB(cfg.PgRwURI)
 	if err != nil {
@@ -81,14 +134,29 @@ func New(cfg *config.Config) (*Alerter, error) {
 		return nil, err
 	}
 
-	// Set up Notifier. Allow a mock for local usage, but warn loudly.
+	// Set up Notifier. Email is sent through an SMTP relay, if configured.
+	// Allow a mock for local usage, but warn loudly.
+	var smtp *notify.SMTP
+	if cfg.SMTPHost != "" {
+		smtp = &notify.SMTP{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			TLS:      cfg.SMTPTLS,
+			Auth:     cfg.SMTPAuth,
+		}
+	}
+
 	var n notify.Notifier
-	if cfg.AppAPIKey == "" || cfg.SMSKeySecret == "" || cfg.EmailAPIKey == "" {
+	if cfg.AppAPIKey == "" || cfg.SMSKeySecret == "" ||
+		(cfg.EmailAPIKey == "" && smtp == nil) {
 		alog.Error("New notify secrets not found, using notify.NewFake()")
 		n = notify.NewFake()
 	} else {
 		n = notify.New(redis, cfg.AppAPIKey, cfg.SMSKeyID, cfg.SMSAccountID,
-			cfg.SMSKeySecret, cfg.SMSPhone, cfg.EmailDomain, cfg.EmailAPIKey)
+			cfg.SMSKeySecret, cfg.SMSPhone, cfg.EmailDomain, cfg.EmailAPIKey,
+			smtp)
 	}
 
 	// Build the NSQ connection for consuming.
@@ -109,26 +177,77 @@ func New(cfg *config.Config) (*Alerter, error) {
 		return nil, err
 	}
 
+	// Webhooks are delivered through their own topic, so that slow webhooks do
+	// not delay other alerts.
+	if err = nsq.Prime(cfg.NSQHookTopic); err != nil {
+		return nil, err
+	}
+
+	hookSub, err := nsq.Subscribe(cfg.NSQHookTopic)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Alerter{
 		orgDAO:   org.NewDAO(pgRW, pgRO),
 		alarmDAO: alarm.NewDAO(pgRW, pgRO),
 		userDAO:  user.NewDAO(pgRW, pgRO),
+		dpDAO:    datapoint.NewDAO(pgRW, pgRO),
 		aleDAO:   alert.NewDAO(pgRW, pgRO),
 		cache:    redis,
 
-		aleQueue: nsq,
-		eOutSub:  eOutSub,
+		aleQueue:     nsq,
+		eOutSub:      eOutSub,
+		hookSub:      hookSub,
+		hookPubTopic: cfg.NSQHookTopic,
 
-		notify: n,
+		notify:  n,
+		hookKey: cfg.HookKey,
 	}, nil
 }
 
-// Serve starts the message alerters.
-func (ale *Alerter) Serve(concurrency int) {
+// Serve starts the message alerters and webhook deliverers, the escalation of
+// unacknowledged alerts, the delivery of deferred alerts, and the flushing of
+// alert digests, which run once per their interval.
+func (ale *Alerter) Serve(
+	concurrency, hookConcurrency int, escalateInterval, deferInterval,
+	digestInterval time.Duration,
+) {
 	for range concurrency {
 		go ale.alertMessages()
 	}
 
+	for range hookConcurrency {
+		go ale.webhookMessages()
+	}
+
+	go func() {
+		ticker := time.NewTicker(escalateInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			ale.escalateAlerts()
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(deferInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			ale.deliverDeferred()
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(digestInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			ale.flushDigests()
+		}
+	}()
+
 	// Handle graceful shutdown.
 	exitChan := make(chan os.Signal, 1)
 	signal.Notify(exitChan, syscall.SIGINT, syscall.SIGTERM)
@@ -138,5 +257,8 @@ func (ale *Alerter) Serve(concurrency int) {
 	if err := ale.eOutSub.Unsubscribe(); err != nil {
 		alog.Errorf("Serve ale.eOutSub.Unsubscribe: %v", err)
 	}
+	if err := ale.hookSub.Unsubscribe(); err != nil {
+		alog.Errorf("Serve ale.hookSub.Unsubscribe: %v", err)
+	}
 	ale.aleQueue.Disconnect()
 }
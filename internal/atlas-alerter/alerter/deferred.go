@@ -0,0 +1,182 @@
+package alerter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/metric"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// deferBatch is the maximum number of deferred alerts claimed at once.
+const deferBatch = 10
+
+// deferLease is the duration for which claimed deferred alerts are leased. It
+// covers the delivery of a full batch, after which undelivered deferred alerts
+// are claimed again.
+const deferLease = 15 * time.Minute
+
+// deferAlert stores an alert to a user for delivery at deliverAt, and records
+// it as deferred. If it cannot be stored, it is sent immediately.
+func (ale *Alerter) deferAlert(
+	ctx context.Context, eOut *message.EventerOut, org *api.Org, a *api.Alarm,
+	alarmType api.AlarmType, user *api.User, subj, body string,
+	deliverAt time.Time,
+) {
+	logger := alog.FromContext(ctx)
+
+	dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	da, err := ale.aleDAO.Defer(dCtx, &message.DeferredAlert{
+		OrgId:     eOut.GetDevice().GetOrgId(),
+		AlarmId:   a.GetId(),
+		UserId:    user.GetId(),
+		Type:      alarmType,
+		Subject:   subj,
+		Body:      body,
+		Event:     eOut,
+		DeliverAt: timestamppb.New(deliverAt),
+	})
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "defer"})
+		logger.Errorf("alertMessages ale.aleDAO.Defer: %v", err)
+
+		ale.notifyUser(ctx, eOut, org, a, alarmType, user, subj, body)
+
+		return
+	}
+
+	metric.Incr("deferred", map[string]string{"type": alarmType.String()})
+	logger.Debugf("alertMessages deferred: %+v", da)
+
+	dCtx, cancel = context.WithTimeout(ctx, 5*time.Second)
+	err = ale.aleDAO.CreateRecord(dCtx, &message.AlertRecord{
+		Alert: &api.Alert{
+			OrgId:   eOut.GetDevice().GetOrgId(),
+			UniqId:  eOut.GetDevice().GetUniqId(),
+			AlarmId: a.GetId(),
+			UserId:  user.GetId(),
+			TraceId: eOut.GetPoint().GetTraceId(),
+		},
+		Deferred: true,
+	})
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "createrecord"})
+		logger.Errorf("alertMessages ale.aleDAO.CreateRecord: %v", err)
+
+		return
+	}
+
+	metric.Incr("created", nil)
+}
+
+// deliverDeferred claims and sends deferred alerts that are due. Deferred
+// alerts are deleted once complete, and are otherwise claimed again when their
+// lease expires.
+func (ale *Alerter) deliverDeferred() {
+	ctx := context.Background()
+
+	for {
+		dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		das, err := ale.aleDAO.ClaimDeferred(dCtx, time.Now(), deferLease,
+			deferBatch)
+		cancel()
+		if err != nil {
+			metric.Incr("error",
+				map[string]string{metric.TagFunc: "claimdeferred"})
+			alog.Errorf("deliverDeferred ale.aleDAO.ClaimDeferred: %v", err)
+
+			return
+		}
+
+		for _, da := range das {
+			logger := alog.
+				WithField("traceID", da.GetEvent().GetPoint().GetTraceId()).
+				WithField("orgID", da.GetOrgId()).
+				WithField("uniqID", da.GetEvent().GetDevice().GetUniqId()).
+				WithField("userID", da.GetUserId())
+
+			if !ale.deliver(alog.NewContext(ctx,
+				&alog.CtxLogger{Logger: logger}), da) {
+				continue
+			}
+
+			dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			err := ale.aleDAO.DeleteDeferred(dCtx, da.GetId(), da.GetOrgId())
+			cancel()
+			if err != nil && !errors.Is(err, dao.ErrNotFound) {
+				metric.Incr("error",
+					map[string]string{metric.TagFunc: "deletedeferred"})
+				logger.Errorf("deliverDeferred ale.aleDAO.DeleteDeferred: %v",
+					err)
+			}
+		}
+
+		if len(das) < deferBatch {
+			return
+		}
+	}
+}
+
+// deliver sends a deferred alert and returns whether it is complete. Alerts of
+// alarms or users that are no longer active are not sent, but are complete.
+// Alerts that could not be read due to a transient error are not complete, so
+// that they are retried.
+func (ale *Alerter) deliver(
+	ctx context.Context, da *message.DeferredAlert,
+) bool {
+	logger := alog.FromContext(ctx)
+	eOut := da.GetEvent()
+
+	// Retrieve org.
+	dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	org, err := ale.orgDAO.Read(dCtx, da.GetOrgId())
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "read"})
+		logger.Errorf("deliver ale.orgDAO.Read: %v", err)
+
+		return errors.Is(err, dao.ErrNotFound)
+	}
+
+	// Retrieve and validate alarm.
+	dCtx, cancel = context.WithTimeout(ctx, 5*time.Second)
+	a, err := ale.alarmDAO.Read(dCtx, da.GetAlarmId(), da.GetOrgId(),
+		eOut.GetRule().GetId())
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "readalarm"})
+		logger.Errorf("deliver ale.alarmDAO.Read: %v", err)
+
+		return errors.Is(err, dao.ErrNotFound)
+	}
+	if a.GetStatus() != api.Status_ACTIVE {
+		return true
+	}
+
+	// Retrieve and validate user.
+	dCtx, cancel = context.WithTimeout(ctx, 5*time.Second)
+	user, err := ale.userDAO.Read(dCtx, da.GetUserId(), da.GetOrgId())
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "readuser"})
+		logger.Errorf("deliver ale.userDAO.Read: %v", err)
+
+		return errors.Is(err, dao.ErrNotFound)
+	}
+	if user.GetStatus() != api.Status_ACTIVE {
+		return true
+	}
+
+	ale.notifyUser(ctx, eOut, org, a, da.GetType(), user, da.GetSubject(),
+		da.GetBody())
+	logger.Debugf("deliver delivered: %+v", da)
+
+	return true
+}
@@ -0,0 +1,196 @@
+//go:build !integration
+
+package alerter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/notify"
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeliverDeferred(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	alarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	alarm.Status = api.Status_ACTIVE
+
+	disAlarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	disAlarm.Status = api.Status_DISABLED
+
+	user := random.User("ale", org.GetId())
+	user.Status = api.Status_ACTIVE
+
+	disUser := random.User("ale", org.GetId())
+	disUser.Status = api.Status_DISABLED
+
+	tests := []struct {
+		inpAlarm     *api.Alarm
+		inpUser      *api.User
+		inpUserTimes int
+		inpSMSTimes  int
+	}{
+		{alarm, user, 1, 1},
+		{disAlarm, user, 0, 0},
+		{alarm, disUser, 1, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can deliver %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			da := &message.DeferredAlert{
+				Id:      uuid.NewV7().String(),
+				OrgId:   org.GetId(),
+				AlarmId: test.inpAlarm.GetId(),
+				UserId:  test.inpUser.GetId(),
+				Type:    api.AlarmType_SMS,
+				Subject: "ale-subj-" + random.String(10),
+				Body:    "ale-body-" + random.String(10),
+				Event: &message.EventerOut{
+					Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+					Device: random.Device("ale", org.GetId()),
+					Rule:   random.Rule("ale", org.GetId()),
+				},
+			}
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().Read(gomock.Any(), org.GetId()).Return(org, nil).
+				Times(1)
+
+			alarmer := NewMockalarmer(ctrl)
+			alarmer.EXPECT().Read(gomock.Any(), test.inpAlarm.GetId(),
+				org.GetId(), da.GetEvent().GetRule().GetId()).
+				Return(test.inpAlarm, nil).Times(1)
+
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().Read(gomock.Any(), test.inpUser.GetId(),
+				org.GetId()).Return(test.inpUser, nil).
+				Times(test.inpUserTimes)
+
+			notifier := notify.NewMockNotifier(ctrl)
+			notifier.EXPECT().SMS(gomock.Any(), test.inpUser.GetPhone(),
+				da.GetSubject(), da.GetBody()).Return(nil).
+				Times(test.inpSMSTimes)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().ClaimDeferred(gomock.Any(),
+				matcher.NewRecentMatcher(2*time.Second), deferLease,
+				int32(deferBatch)).Return([]*message.DeferredAlert{da}, nil).
+				Times(1)
+			alerter.EXPECT().Create(gomock.Any(), matcher.NewProtoMatcher(
+				&api.Alert{
+					OrgId:   org.GetId(),
+					UniqId:  da.GetEvent().GetDevice().GetUniqId(),
+					AlarmId: test.inpAlarm.GetId(),
+					UserId:  test.inpUser.GetId(),
+					Status:  api.AlertStatus_SENT,
+					TraceId: da.GetEvent().GetPoint().GetTraceId(),
+				})).Return(nil).Times(test.inpSMSTimes)
+			alerter.EXPECT().DeleteDeferred(gomock.Any(), da.GetId(),
+				org.GetId()).Return(nil).Times(1)
+
+			ale := Alerter{
+				orgDAO:   orger,
+				alarmDAO: alarmer,
+				userDAO:  userer,
+				aleDAO:   alerter,
+
+				notify: notifier,
+			}
+			ale.deliverDeferred()
+		})
+	}
+}
+
+func TestDeliverDeferredError(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	alarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	alarm.Status = api.Status_ACTIVE
+
+	// Deferred alerts that fail to be read due to a transient error are not
+	// deleted, so that they are claimed again once their lease expires.
+	tests := []struct {
+		inpClaimErr    error
+		inpOrgErr      error
+		inpAlarmErr    error
+		inpUserErr     error
+		inpOrgTimes    int
+		inpAlarmTimes  int
+		inpUserTimes   int
+		inpDeleteTimes int
+	}{
+		{errTestProc, nil, nil, nil, 0, 0, 0, 0},
+		{nil, errTestProc, nil, nil, 1, 0, 0, 0},
+		{nil, nil, errTestProc, nil, 1, 1, 0, 0},
+		{nil, nil, nil, errTestProc, 1, 1, 1, 0},
+		{nil, dao.ErrNotFound, nil, nil, 1, 0, 0, 1},
+		{nil, nil, dao.ErrNotFound, nil, 1, 1, 0, 1},
+		{nil, nil, nil, dao.ErrNotFound, 1, 1, 1, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cannot deliver %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			da := &message.DeferredAlert{
+				Id:      uuid.NewV7().String(),
+				OrgId:   org.GetId(),
+				AlarmId: alarm.GetId(),
+				UserId:  uuid.NewV7().String(),
+				Type:    api.AlarmType_SMS,
+				Event: &message.EventerOut{
+					Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+					Device: random.Device("ale", org.GetId()),
+					Rule:   random.Rule("ale", org.GetId()),
+				},
+			}
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().Read(gomock.Any(), gomock.Any()).
+				Return(org, test.inpOrgErr).Times(test.inpOrgTimes)
+
+			alarmer := NewMockalarmer(ctrl)
+			alarmer.EXPECT().Read(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(alarm, test.inpAlarmErr).
+				Times(test.inpAlarmTimes)
+
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().Read(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, test.inpUserErr).Times(test.inpUserTimes)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().ClaimDeferred(gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Return(
+				[]*message.DeferredAlert{da}, test.inpClaimErr).Times(1)
+			alerter.EXPECT().DeleteDeferred(gomock.Any(), da.GetId(),
+				org.GetId()).Return(errTestProc).Times(test.inpDeleteTimes)
+
+			ale := Alerter{
+				orgDAO:   orger,
+				alarmDAO: alarmer,
+				userDAO:  userer,
+				aleDAO:   alerter,
+
+				notify: notify.NewMockNotifier(ctrl),
+			}
+			ale.deliverDeferred()
+		})
+	}
+}
@@ -0,0 +1,410 @@
+package alerter
+
+import (
+	"context"
+	"errors"
+	"time"
+	"uuid"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/metric"
+	"github.com/thingspect/atlas/pkg/template"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// digestBatch is the maximum number of alert digests claimed at once.
+	digestBatch = 100
+
+	// digestGrace is the time beyond a digest's window that its alerts are
+	// retained in the cache, to allow for flush delays.
+	digestGrace = time.Hour
+
+	// digestSettle is the time beyond a digest's flush time before it is
+	// flushed. It exceeds the cache timeouts of collecting an event, so that
+	// events collected as the window closes are included.
+	digestSettle = 30 * time.Second
+
+	// defaultDigestSubject and defaultDigestBody are the templates used by
+	// alarm digests without templates of their own.
+	defaultDigestSubject = `{{.alarm.Name}}: {{.count}} alerts`
+	defaultDigestBody    = `{{range .events}}{{.device.Name}} ` +
+		`({{.device.UniqId}}): {{.rule.Name}}, {{.point.Attr}} = ` +
+		`{{.pointVal}}{{if .cleared}} (cleared){{end}}
+{{end}}`
+)
+
+// digestAlert collects an alert to a user into the user's pending digest for
+// the alarm, creating the digest if needed. If it cannot be collected, it is
+// sent immediately.
+func (ale *Alerter) digestAlert(
+	ctx context.Context, eOut *message.EventerOut, org *api.Org, a *api.Alarm,
+	dig *message.AlarmDigest, alarmType api.AlarmType, user *api.User, subj,
+	body string,
+) {
+	logger := alog.FromContext(ctx)
+
+	digestID, err := ale.collect(ctx, eOut, a, dig, alarmType, user.GetId())
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "digest"})
+		logger.Errorf("alertMessages ale.collect: %v", err)
+
+		ale.notifyUser(ctx, eOut, org, a, alarmType, user, subj, body)
+
+		return
+	}
+
+	metric.Incr("digested", map[string]string{"type": alarmType.String()})
+	logger.Debugf("alertMessages digested userID, digestID: %v, %v",
+		user.GetId(), digestID)
+}
+
+// collect adds an event to a pending digest in the cache and returns the
+// digest ID. The first event of a window creates the digest, which is flushed
+// once the window has elapsed.
+func (ale *Alerter) collect(
+	ctx context.Context, eOut *message.EventerOut, a *api.Alarm,
+	dig *message.AlarmDigest, alarmType api.AlarmType, userID string,
+) (string, error) {
+	window := time.Duration(dig.GetWindowMinutes()) * time.Minute
+	key := digestKey(eOut.GetDevice().GetOrgId(), a.GetId(), userID)
+	digestID := uuid.NewV7().String()
+
+	cCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	err := ale.cache.SetIfNotExistTTL(cCtx, key, digestID, window)
+	cancel()
+
+	switch {
+	case errors.Is(err, cache.ErrAlreadyExists):
+		cCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		digestID, err = ale.cache.Get(cCtx, key)
+		cancel()
+		if err != nil {
+			return "", err
+		}
+	case err != nil:
+		return "", err
+	default:
+		dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		_, err = ale.aleDAO.CreateDigest(dCtx, &message.AlertDigest{
+			Id:      digestID,
+			OrgId:   eOut.GetDevice().GetOrgId(),
+			AlarmId: a.GetId(),
+			RuleId:  a.GetRuleId(),
+			UserId:  userID,
+			Type:    alarmType,
+			FlushAt: timestamppb.New(time.Now().Add(window)),
+		})
+		cancel()
+		if err != nil {
+			// Release the key, so that the next event may retry creation.
+			cCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			if dErr := ale.cache.Del(cCtx, key); dErr != nil {
+				logger := alog.FromContext(ctx)
+				logger.Errorf("collect ale.cache.Del: %v", dErr)
+			}
+			cancel()
+
+			return "", err
+		}
+	}
+
+	item, err := protojson.Marshal(eOut)
+	if err != nil {
+		return "", err
+	}
+
+	cCtx, cancel = context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	n, err := ale.cache.IncrTTL(cCtx, digestCountKey(digestID),
+		window+digestGrace)
+	if err != nil {
+		return "", err
+	}
+
+	return digestID, ale.cache.SetTTL(cCtx, digestItemKey(digestID, n),
+		string(item), window+digestGrace)
+}
+
+// flushDigests claims and sends alert digests that are due.
+func (ale *Alerter) flushDigests() {
+	ctx := context.Background()
+
+	for {
+		dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		digests, err := ale.aleDAO.ClaimDigests(dCtx,
+			time.Now().Add(-digestSettle), digestBatch)
+		cancel()
+		if err != nil {
+			metric.Incr("error",
+				map[string]string{metric.TagFunc: "claimdigests"})
+			alog.Errorf("flushDigests ale.aleDAO.ClaimDigests: %v", err)
+
+			return
+		}
+
+		for _, digest := range digests {
+			logger := alog.
+				WithField("orgID", digest.GetOrgId()).
+				WithField("digestID", digest.GetId()).
+				WithField("userID", digest.GetUserId())
+
+			ale.flush(alog.NewContext(ctx, &alog.CtxLogger{Logger: logger}),
+				digest)
+		}
+
+		if len(digests) < digestBatch {
+			return
+		}
+	}
+}
+
+// flush sends an alert digest, stores an alert per collected event linked to
+// the digest, and records the result. Digests of alarms or users that are no
+// longer active are not sent. Digests that fail to be sent are recorded as
+// errors.
+func (ale *Alerter) flush(ctx context.Context, digest *message.AlertDigest) {
+	logger := alog.FromContext(ctx)
+
+	// Retrieve collected events, which are removed from the cache once the
+	// results are stored.
+	n, eOuts, err := ale.collected(ctx, digest.GetId())
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "incr"})
+		logger.Errorf("flush ale.collected: %v", err)
+
+		ale.completeDigest(ctx, digest, nil, err)
+
+		return
+	}
+	defer ale.clearDigest(ctx, digest.GetId(), n)
+
+	if len(eOuts) == 0 {
+		return
+	}
+
+	// Retrieve org.
+	dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	org, err := ale.orgDAO.Read(dCtx, digest.GetOrgId())
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "read"})
+		logger.Errorf("flush ale.orgDAO.Read: %v", err)
+
+		ale.completeDigest(ctx, digest, eOuts, err)
+
+		return
+	}
+
+	// Retrieve and validate alarm.
+	dCtx, cancel = context.WithTimeout(ctx, 5*time.Second)
+	a, err := ale.alarmDAO.Read(dCtx, digest.GetAlarmId(), digest.GetOrgId(),
+		digest.GetRuleId())
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "readalarm"})
+		logger.Errorf("flush ale.alarmDAO.Read: %v", err)
+
+		ale.completeDigest(ctx, digest, eOuts, err)
+
+		return
+	}
+	if a.GetStatus() != api.Status_ACTIVE {
+		return
+	}
+
+	// Retrieve and validate user.
+	dCtx, cancel = context.WithTimeout(ctx, 5*time.Second)
+	user, err := ale.userDAO.Read(dCtx, digest.GetUserId(), digest.GetOrgId())
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "readuser"})
+		logger.Errorf("flush ale.userDAO.Read: %v", err)
+
+		ale.completeDigest(ctx, digest, eOuts, err)
+
+		return
+	}
+	if user.GetStatus() != api.Status_ACTIVE {
+		return
+	}
+
+	// Retrieve digest templates. The digest may have since been removed from
+	// the alarm, in which case the defaults are used.
+	dCtx, cancel = context.WithTimeout(ctx, 5*time.Second)
+	digs, err := ale.alarmDAO.ListDigests(dCtx, digest.GetOrgId(),
+		[]string{digest.GetAlarmId()})
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "listdigests"})
+		logger.Errorf("flush ale.alarmDAO.ListDigests: %v", err)
+	}
+
+	subjTempl, bodyTempl := defaultDigestSubject, defaultDigestBody
+	if dig := digs[digest.GetAlarmId()]; dig != nil {
+		if dig.GetSubjectTemplate() != "" {
+			subjTempl = dig.GetSubjectTemplate()
+		}
+		if dig.GetBodyTemplate() != "" {
+			bodyTempl = dig.GetBodyTemplate()
+		}
+	}
+
+	// Generate and send digest.
+	events := make([]*template.DigestEvent, 0, len(eOuts))
+	for _, eOut := range eOuts {
+		events = append(events, &template.DigestEvent{
+			Point:   eOut.GetPoint(),
+			Rule:    eOut.GetRule(),
+			Device:  eOut.GetDevice(),
+			Cleared: eOut.GetCleared(),
+		})
+	}
+
+	scheds := ale.listSchedules(ctx, digest.GetOrgId(), []*api.User{user})
+	env := &template.Env{
+		User:     user,
+		Org:      org,
+		Alarm:    a,
+		Timezone: scheds[user.GetId()].GetTimezone(),
+	}
+	mode := template.ModeFor(digest.GetType())
+
+	subj, err := template.GenerateDigest(env, events, mode, subjTempl)
+	if err == nil {
+		var body string
+		body, err = template.GenerateDigest(env, events, mode, bodyTempl)
+		if err == nil {
+			err = ale.sendUser(ctx, org, digest.GetType(), user, subj, body)
+		}
+	}
+
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "notify"})
+		logger.Errorf("flush ale.notify a, err: %+v, %v", a, err)
+	} else {
+		metric.Incr("sent", map[string]string{
+			"type":   digest.GetType().String(),
+			"digest": "true",
+		})
+		logger.Debugf("flush sent count: %v", len(eOuts))
+	}
+
+	ale.completeDigest(ctx, digest, eOuts, err)
+}
+
+// completeDigest stores an alert per collected event linked to the digest,
+// and records the result of the digest. A non-nil error records an error
+// status.
+func (ale *Alerter) completeDigest(
+	ctx context.Context, digest *message.AlertDigest,
+	eOuts []*message.EventerOut, err error,
+) {
+	logger := alog.FromContext(ctx)
+
+	status := api.AlertStatus_SENT
+	var digestErr string
+	if err != nil {
+		status = api.AlertStatus_ERROR
+		digestErr = err.Error()
+	}
+
+	for _, eOut := range eOuts {
+		dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		err := ale.aleDAO.CreateInDigest(dCtx, &api.Alert{
+			OrgId:   digest.GetOrgId(),
+			UniqId:  eOut.GetDevice().GetUniqId(),
+			AlarmId: digest.GetAlarmId(),
+			UserId:  digest.GetUserId(),
+			Status:  status,
+			Error:   digestErr,
+			TraceId: eOut.GetPoint().GetTraceId(),
+		}, digest.GetId())
+		cancel()
+		if err != nil {
+			metric.Incr("error",
+				map[string]string{metric.TagFunc: "createindigest"})
+			logger.Errorf("completeDigest ale.aleDAO.CreateInDigest: %v", err)
+		}
+	}
+
+	dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	err = ale.aleDAO.CompleteDigest(dCtx, digest.GetId(), digest.GetOrgId(),
+		status, digestErr)
+	cancel()
+	if err != nil {
+		metric.Incr("error",
+			map[string]string{metric.TagFunc: "completedigest"})
+		logger.Errorf("completeDigest ale.aleDAO.CompleteDigest: %v", err)
+	}
+}
+
+// collected retrieves the count of and the events collected into a digest,
+// without removing them. Events that cannot be retrieved are skipped.
+func (ale *Alerter) collected(
+	ctx context.Context, digestID string,
+) (int64, []*message.EventerOut, error) {
+	logger := alog.FromContext(ctx)
+
+	// The count is retrieved by increment, as an incremented value can not be
+	// retrieved by a string cache. Digests are flushed after digestSettle, by
+	// which time no further events are collected.
+	cCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	n, err := ale.cache.IncrTTL(cCtx, digestCountKey(digestID), digestGrace)
+	cancel()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var eOuts []*message.EventerOut
+	for i := int64(1); i < n; i++ {
+		cCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		item, err := ale.cache.Get(cCtx, digestItemKey(digestID, i))
+		cancel()
+		if err != nil {
+			metric.Incr("error", map[string]string{metric.TagFunc: "get"})
+			logger.Errorf("collected ale.cache.Get: %v", err)
+
+			continue
+		}
+
+		eOut := &message.EventerOut{}
+		if err := protojson.Unmarshal([]byte(item), eOut); err != nil {
+			metric.Incr("error", map[string]string{metric.TagFunc: "unmarshal"})
+			logger.Errorf("collected protojson.Unmarshal: %v", err)
+
+			continue
+		}
+		eOuts = append(eOuts, eOut)
+	}
+
+	return n, eOuts, nil
+}
+
+// clearDigest removes the events collected into a digest, and its count, from
+// the cache.
+func (ale *Alerter) clearDigest(ctx context.Context, digestID string, n int64) {
+	for i := int64(1); i < n; i++ {
+		ale.delKey(ctx, digestItemKey(digestID, i))
+	}
+
+	ale.delKey(ctx, digestCountKey(digestID))
+}
+
+// delKey removes a key from the cache. Failures are logged, but are otherwise
+// not handled.
+func (ale *Alerter) delKey(ctx context.Context, key string) {
+	cCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	err := ale.cache.Del(cCtx, key)
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "del"})
+		logger := alog.FromContext(ctx)
+		logger.Errorf("delKey ale.cache.Del: %v", err)
+	}
+}
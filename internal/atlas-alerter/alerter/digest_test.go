@@ -0,0 +1,369 @@
+//go:build !integration
+
+package alerter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/notify"
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestDigestAlert(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	alarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	alarm.Status = api.Status_ACTIVE
+
+	dig := &message.AlarmDigest{
+		AlarmId: alarm.GetId(), OrgId: org.GetId(), RuleId: alarm.GetRuleId(),
+		WindowMinutes: 15,
+	}
+
+	user := random.User("ale", org.GetId())
+	user.Status = api.Status_ACTIVE
+
+	tests := []struct {
+		inpSetErr      error
+		inpGetTimes    int
+		inpCreateErr   error
+		inpCreateTimes int
+		inpDelTimes    int
+		inpIncrTimes   int
+		inpSMSTimes    int
+	}{
+		{nil, 0, nil, 1, 0, 1, 0},
+		{cache.ErrAlreadyExists, 1, nil, 0, 0, 1, 0},
+		{nil, 0, errTestProc, 1, 1, 0, 1},
+		{errTestProc, 0, nil, 0, 0, 0, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can digest %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			eOut := &message.EventerOut{
+				Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+				Device: random.Device("ale", org.GetId()),
+				Rule:   random.Rule("ale", org.GetId()),
+			}
+			key := digestKey(org.GetId(), alarm.GetId(), user.GetId())
+			digestID := uuid.NewV7().String()
+
+			item, err := protojson.Marshal(eOut)
+			require.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+			cacher := cache.NewMockCacher[string](ctrl)
+			cacher.EXPECT().SetIfNotExistTTL(gomock.Any(), key, gomock.Any(),
+				15*time.Minute).DoAndReturn(func(
+				_ context.Context, _ string, value string, _ time.Duration,
+			) error {
+				if test.inpSetErr == nil {
+					digestID = value
+				}
+
+				return test.inpSetErr
+			}).Times(1)
+			cacher.EXPECT().Get(gomock.Any(), key).Return(digestID, nil).
+				Times(test.inpGetTimes)
+			cacher.EXPECT().Del(gomock.Any(), key).Return(nil).
+				Times(test.inpDelTimes)
+			cacher.EXPECT().IncrTTL(gomock.Any(), gomock.Any(),
+				15*time.Minute+digestGrace).Return(int64(2), nil).
+				Times(test.inpIncrTimes)
+			cacher.EXPECT().SetTTL(gomock.Any(), gomock.Cond(
+				func(k string) bool { return k == digestItemKey(digestID, 2) }),
+				string(item), 15*time.Minute+digestGrace).Return(nil).
+				Times(test.inpIncrTimes)
+
+			notifier := notify.NewMockNotifier(ctrl)
+			notifier.EXPECT().SMS(gomock.Any(), user.GetPhone(), "ale-subj",
+				"ale-body").Return(nil).Times(test.inpSMSTimes)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().CreateDigest(gomock.Any(), gomock.Cond(
+				func(d *message.AlertDigest) bool {
+					return d.GetId() == digestID &&
+						d.GetOrgId() == org.GetId() &&
+						d.GetAlarmId() == alarm.GetId() &&
+						d.GetRuleId() == alarm.GetRuleId() &&
+						d.GetUserId() == user.GetId() &&
+						d.GetType() == api.AlarmType_SMS &&
+						time.Until(d.GetFlushAt().AsTime()) > 14*time.Minute
+				})).Return(nil, test.inpCreateErr).Times(test.inpCreateTimes)
+			alerter.EXPECT().Create(gomock.Any(), matcher.NewProtoMatcher(
+				&api.Alert{
+					OrgId:   org.GetId(),
+					UniqId:  eOut.GetDevice().GetUniqId(),
+					AlarmId: alarm.GetId(),
+					UserId:  user.GetId(),
+					Status:  api.AlertStatus_SENT,
+					TraceId: eOut.GetPoint().GetTraceId(),
+				})).Return(nil).Times(test.inpSMSTimes)
+
+			ale := Alerter{
+				aleDAO: alerter,
+				cache:  cacher,
+
+				notify: notifier,
+			}
+
+			ale.digestAlert(t.Context(), eOut, org, alarm, dig,
+				api.AlarmType_SMS, user, "ale-subj", "ale-body")
+		})
+	}
+}
+
+func TestFlushDigests(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	alarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	alarm.Status = api.Status_ACTIVE
+	alarm.Name = "ale-alarm"
+
+	user := random.User("ale", org.GetId())
+	user.Status = api.Status_ACTIVE
+
+	disUser := random.User("ale", org.GetId())
+	disUser.Status = api.Status_DISABLED
+
+	dig := &message.AlarmDigest{
+		AlarmId: alarm.GetId(), OrgId: org.GetId(), RuleId: alarm.GetRuleId(),
+		WindowMinutes: 15, SubjectTemplate: "{{.alarm.Name}}: {{.count}}",
+		BodyTemplate: "{{range .events}}{{.device.UniqId}};{{end}}",
+	}
+
+	tests := []struct {
+		inpUser     *api.User
+		inpSMSErr   error
+		inpSMSTimes int
+		resStatus   api.AlertStatus
+	}{
+		{user, nil, 1, api.AlertStatus_SENT},
+		{user, errTestProc, 1, api.AlertStatus_ERROR},
+		{disUser, nil, 0, api.AlertStatus_SENT},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can flush %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			digest := &message.AlertDigest{
+				Id:      uuid.NewV7().String(),
+				OrgId:   org.GetId(),
+				AlarmId: alarm.GetId(),
+				RuleId:  alarm.GetRuleId(),
+				UserId:  test.inpUser.GetId(),
+				Type:    api.AlarmType_SMS,
+			}
+
+			eOuts := []*message.EventerOut{}
+			items := []string{}
+			for range 2 {
+				eOut := &message.EventerOut{
+					Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+					Device: random.Device("ale", org.GetId()),
+					Rule:   random.Rule("ale", org.GetId()),
+				}
+				eOuts = append(eOuts, eOut)
+
+				item, err := protojson.Marshal(eOut)
+				require.NoError(t, err)
+				items = append(items, string(item))
+			}
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().Read(gomock.Any(), org.GetId()).Return(org, nil).
+				Times(1)
+
+			alarmer := NewMockalarmer(ctrl)
+			alarmer.EXPECT().Read(gomock.Any(), alarm.GetId(), org.GetId(),
+				alarm.GetRuleId()).Return(alarm, nil).Times(1)
+			alarmer.EXPECT().ListDigests(gomock.Any(), org.GetId(),
+				[]string{alarm.GetId()}).Return(map[string]*message.AlarmDigest{
+				alarm.GetId(): dig,
+			}, nil).Times(test.inpSMSTimes)
+
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().ListSchedules(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil, nil).AnyTimes()
+			userer.EXPECT().Read(gomock.Any(), test.inpUser.GetId(),
+				org.GetId()).Return(test.inpUser, nil).Times(1)
+
+			cacher := cache.NewMockCacher[string](ctrl)
+			cacher.EXPECT().IncrTTL(gomock.Any(),
+				digestCountKey(digest.GetId()), digestGrace).
+				Return(int64(3), nil).Times(1)
+			for i, item := range items {
+				key := digestItemKey(digest.GetId(), int64(i+1))
+				cacher.EXPECT().Get(gomock.Any(), key).Return(item, nil).
+					Times(1)
+				cacher.EXPECT().Del(gomock.Any(), key).Return(nil).Times(1)
+			}
+			cacher.EXPECT().Del(gomock.Any(), digestCountKey(digest.GetId())).
+				Return(nil).Times(1)
+
+			notifier := notify.NewMockNotifier(ctrl)
+			notifier.EXPECT().SMS(gomock.Any(), test.inpUser.GetPhone(),
+				"ale-alarm: 2", eOuts[0].GetDevice().GetUniqId()+";"+
+					eOuts[1].GetDevice().GetUniqId()+";").
+				Return(test.inpSMSErr).Times(test.inpSMSTimes)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().ClaimDigests(gomock.Any(), gomock.Cond(
+				func(now time.Time) bool {
+					return time.Since(now) >= digestSettle &&
+						time.Since(now) < digestSettle+2*time.Second
+				}), int32(digestBatch)).
+				Return([]*message.AlertDigest{digest}, nil).Times(1)
+
+			var resErr string
+			if test.inpSMSErr != nil {
+				resErr = test.inpSMSErr.Error()
+			}
+			for _, eOut := range eOuts {
+				alerter.EXPECT().CreateInDigest(gomock.Any(),
+					matcher.NewProtoMatcher(&api.Alert{
+						OrgId:   org.GetId(),
+						UniqId:  eOut.GetDevice().GetUniqId(),
+						AlarmId: alarm.GetId(),
+						UserId:  test.inpUser.GetId(),
+						Status:  test.resStatus,
+						Error:   resErr,
+						TraceId: eOut.GetPoint().GetTraceId(),
+					}), digest.GetId()).Return(nil).Times(test.inpSMSTimes)
+			}
+			alerter.EXPECT().CompleteDigest(gomock.Any(), digest.GetId(),
+				org.GetId(), test.resStatus, resErr).Return(nil).
+				Times(test.inpSMSTimes)
+
+			ale := Alerter{
+				orgDAO:   orger,
+				alarmDAO: alarmer,
+				userDAO:  userer,
+				aleDAO:   alerter,
+				cache:    cacher,
+
+				notify: notifier,
+			}
+			ale.flushDigests()
+		})
+	}
+}
+
+func TestFlushDigestsError(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	alarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	alarm.Status = api.Status_ACTIVE
+
+	item, err := protojson.Marshal(&message.EventerOut{
+		Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+		Device: random.Device("ale", org.GetId()),
+		Rule:   random.Rule("ale", org.GetId()),
+	})
+	require.NoError(t, err)
+
+	// Digests that fail to be read or sent are recorded as errors.
+	tests := []struct {
+		inpClaimErr    error
+		inpIncrErr     error
+		inpOrgErr      error
+		inpAlarmErr    error
+		inpUserErr     error
+		inpIncrTimes   int
+		inpItemTimes   int
+		inpOrgTimes    int
+		inpAlarmTimes  int
+		inpUserTimes   int
+		inpAlertTimes  int
+		inpDigestTimes int
+	}{
+		{errTestProc, nil, nil, nil, nil, 0, 0, 0, 0, 0, 0, 0},
+		{nil, errTestProc, nil, nil, nil, 1, 0, 0, 0, 0, 0, 1},
+		{nil, nil, errTestProc, nil, nil, 1, 1, 1, 0, 0, 1, 1},
+		{nil, nil, nil, errTestProc, nil, 1, 1, 1, 1, 0, 1, 1},
+		{nil, nil, nil, nil, errTestProc, 1, 1, 1, 1, 1, 1, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cannot flush %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			digest := &message.AlertDigest{
+				Id:      uuid.NewV7().String(),
+				OrgId:   org.GetId(),
+				AlarmId: alarm.GetId(),
+				RuleId:  alarm.GetRuleId(),
+				UserId:  uuid.NewV7().String(),
+				Type:    api.AlarmType_SMS,
+			}
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().Read(gomock.Any(), gomock.Any()).
+				Return(org, test.inpOrgErr).Times(test.inpOrgTimes)
+
+			alarmer := NewMockalarmer(ctrl)
+			alarmer.EXPECT().Read(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(alarm, test.inpAlarmErr).
+				Times(test.inpAlarmTimes)
+
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().Read(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, test.inpUserErr).Times(test.inpUserTimes)
+
+			cacher := cache.NewMockCacher[string](ctrl)
+			cacher.EXPECT().IncrTTL(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(int64(2), test.inpIncrErr).Times(test.inpIncrTimes)
+			cacher.EXPECT().Get(gomock.Any(), gomock.Any()).
+				Return(string(item), nil).Times(test.inpItemTimes)
+			cacher.EXPECT().Del(gomock.Any(), gomock.Any()).Return(nil).
+				Times(test.inpItemTimes * 2)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().ClaimDigests(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return([]*message.AlertDigest{digest},
+				test.inpClaimErr).Times(1)
+			alerter.EXPECT().CreateInDigest(gomock.Any(), gomock.Cond(
+				func(a *api.Alert) bool {
+					return a.GetStatus() == api.AlertStatus_ERROR &&
+						a.GetError() == errTestProc.Error()
+				}), digest.GetId()).Return(nil).Times(test.inpAlertTimes)
+			alerter.EXPECT().CompleteDigest(gomock.Any(), digest.GetId(),
+				org.GetId(), api.AlertStatus_ERROR, errTestProc.Error()).
+				Return(nil).Times(test.inpDigestTimes)
+
+			ale := Alerter{
+				orgDAO:   orger,
+				alarmDAO: alarmer,
+				userDAO:  userer,
+				aleDAO:   alerter,
+				cache:    cacher,
+
+				notify: notify.NewMockNotifier(ctrl),
+			}
+			ale.flushDigests()
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package alerter
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/metric"
+	"github.com/thingspect/atlas/pkg/template"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+// escalateBatch is the maximum number of escalations claimed at once.
+const escalateBatch = 100
+
+// escalateAlerts claims and sends escalation steps that are due. Alert
+// lifecycles that are no longer open are not escalated.
+func (ale *Alerter) escalateAlerts() {
+	ctx := context.Background()
+
+	for {
+		dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		escs, err := ale.aleDAO.ClaimEscalations(dCtx, time.Now(),
+			escalateBatch)
+		cancel()
+		if err != nil {
+			metric.Incr("error",
+				map[string]string{metric.TagFunc: "claimescalations"})
+			alog.Errorf("escalateAlerts ale.aleDAO.ClaimEscalations: %v", err)
+
+			return
+		}
+
+		for _, esc := range escs {
+			logger := alog.
+				WithField("traceID", esc.GetEvent().GetPoint().GetTraceId()).
+				WithField("orgID", esc.GetOrgId()).
+				WithField("uniqID", esc.GetEvent().GetDevice().GetUniqId()).
+				WithField("lifecycleID", esc.GetLifecycleId())
+
+			ale.escalate(alog.NewContext(ctx, &alog.CtxLogger{Logger: logger}),
+				esc)
+		}
+
+		if len(escs) < escalateBatch {
+			return
+		}
+	}
+}
+
+// escalate sends an escalation step to users by the step's tags, using the
+// alarm's templates. The step's alarm type is used if specified, otherwise
+// the alarm's type.
+func (ale *Alerter) escalate(
+	ctx context.Context, esc *message.AlertEscalation,
+) {
+	logger := alog.FromContext(ctx)
+	eOut := esc.GetEvent()
+
+	// Retrieve org.
+	dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	org, err := ale.orgDAO.Read(dCtx, esc.GetOrgId())
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "read"})
+		logger.Errorf("escalate ale.orgDAO.Read: %v", err)
+
+		return
+	}
+
+	// Retrieve and validate alarm.
+	dCtx, cancel = context.WithTimeout(ctx, 5*time.Second)
+	a, err := ale.alarmDAO.Read(dCtx, esc.GetAlarmId(), esc.GetOrgId(),
+		eOut.GetRule().GetId())
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "readalarm"})
+		logger.Errorf("escalate ale.alarmDAO.Read: %v", err)
+
+		return
+	}
+	if a.GetStatus() != api.Status_ACTIVE {
+		return
+	}
+
+	// Retrieve users. Only active users with matching tags will be returned.
+	dCtx, cancel = context.WithTimeout(ctx, 5*time.Second)
+	users, err := ale.userDAO.ListByTags(dCtx, esc.GetOrgId(),
+		esc.GetStep().GetUserTags())
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "listbytags"})
+		logger.Errorf("escalate ale.userDAO.ListByTags: %v", err)
+
+		return
+	}
+	if len(users) == 0 {
+		return
+	}
+
+	alarmType := esc.GetStep().GetType()
+	if alarmType == api.AlarmType_ALARM_TYPE_UNSPECIFIED {
+		alarmType = a.GetType()
+	}
+
+	env := &template.Env{
+		Point:  eOut.GetPoint(),
+		Rule:   eOut.GetRule(),
+		Device: eOut.GetDevice(),
+		Org:    org,
+		Alarm:  a,
+		Latest: ale.latestPoints(ctx, eOut.GetDevice(), a.GetSubjectTemplate(),
+			a.GetBodyTemplate()),
+	}
+	scheds := ale.listSchedules(ctx, esc.GetOrgId(), users)
+
+	// Escalations are not subject to the repeat interval, as each step is
+	// sent once per alert lifecycle.
+	for _, user := range users {
+		// Generate alert subject and body for the user.
+		uEnv := *env
+		uEnv.User = user
+		uEnv.Timezone = scheds[user.GetId()].GetTimezone()
+
+		subj, body, err := generate(ctx, &uEnv, template.ModeFor(alarmType),
+			a.GetSubjectTemplate(), a.GetBodyTemplate())
+		if err != nil {
+			continue
+		}
+
+		ale.notifyUser(ctx, eOut, org, a, alarmType, user, subj, body)
+	}
+
+	metric.Incr("escalated", map[string]string{
+		"step": strconv.Itoa(int(esc.GetStepNum())),
+	})
+	logger.Debugf("escalate escalated: %+v", esc)
+}
@@ -0,0 +1,214 @@
+//go:build !integration
+
+package alerter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/thingspect/atlas/pkg/notify"
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEscalateAlerts(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	appAlarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	appAlarm.Status = api.Status_ACTIVE
+	appAlarm.Type = api.AlarmType_APP
+
+	disAlarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	disAlarm.Status = api.Status_DISABLED
+
+	tests := []struct {
+		inpAlarm     *api.Alarm
+		inpStepType  api.AlarmType
+		inpUsers     []*api.User
+		inpUserTimes int
+		inpAppTimes  int
+		inpSMSTimes  int
+	}{
+		{
+			appAlarm, api.AlarmType_ALARM_TYPE_UNSPECIFIED,
+			[]*api.User{random.User("ale", org.GetId())}, 1, 1, 0,
+		},
+		{
+			appAlarm, api.AlarmType_SMS,
+			[]*api.User{random.User("ale", org.GetId())}, 1, 0, 1,
+		},
+		{
+			disAlarm, api.AlarmType_SMS,
+			[]*api.User{random.User("ale", org.GetId())}, 0, 0, 0,
+		},
+		{appAlarm, api.AlarmType_SMS, []*api.User{}, 1, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can escalate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			esc := &message.AlertEscalation{
+				LifecycleId: uuid.NewV7().String(),
+				OrgId:       org.GetId(),
+				AlarmId:     test.inpAlarm.GetId(),
+				StepNum:     1,
+				Step: &message.EscalationStep{
+					DelayMinutes: 5, UserTags: random.Tags("ale", 2),
+					Type: test.inpStepType,
+				},
+				Event: &message.EventerOut{
+					Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+					Device: random.Device("ale", org.GetId()),
+					Rule:   random.Rule("ale", org.GetId()),
+				},
+			}
+
+			var alert *api.Alert
+			if len(test.inpUsers) > 0 {
+				alert = &api.Alert{
+					OrgId:   org.GetId(),
+					UniqId:  esc.GetEvent().GetDevice().GetUniqId(),
+					AlarmId: test.inpAlarm.GetId(),
+					UserId:  test.inpUsers[0].GetId(),
+					Status:  api.AlertStatus_SENT,
+					TraceId: esc.GetEvent().GetPoint().GetTraceId(),
+				}
+			}
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().Read(gomock.Any(), org.GetId()).Return(org, nil).
+				Times(1)
+
+			alarmer := NewMockalarmer(ctrl)
+			alarmer.EXPECT().Read(gomock.Any(), test.inpAlarm.GetId(),
+				org.GetId(), esc.GetEvent().GetRule().GetId()).
+				Return(test.inpAlarm, nil).Times(1)
+
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().ListSchedules(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil, nil).AnyTimes()
+			userer.EXPECT().ListByTags(gomock.Any(), org.GetId(),
+				esc.GetStep().GetUserTags()).Return(test.inpUsers, nil).
+				Times(test.inpUserTimes)
+
+			notifier := notify.NewMockNotifier(ctrl)
+			notifier.EXPECT().App(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil).Times(test.inpAppTimes)
+			notifier.EXPECT().SMS(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil).Times(test.inpSMSTimes)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().ClaimEscalations(gomock.Any(),
+				matcher.NewRecentMatcher(2*time.Second), int32(escalateBatch)).
+				Return([]*message.AlertEscalation{esc}, nil).Times(1)
+			alerter.EXPECT().Create(gomock.Any(),
+				matcher.NewProtoMatcher(alert)).Return(nil).
+				Times(test.inpAppTimes + test.inpSMSTimes)
+
+			ale := Alerter{
+				orgDAO:   orger,
+				alarmDAO: alarmer,
+				userDAO:  userer,
+				aleDAO:   alerter,
+
+				notify: notifier,
+			}
+			ale.escalateAlerts()
+		})
+	}
+}
+
+func TestEscalateAlertsError(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	appAlarm := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	appAlarm.Status = api.Status_ACTIVE
+	appAlarm.Type = api.AlarmType_APP
+
+	badSubj := random.Alarm("ale", org.GetId(), uuid.NewV7().String())
+	badSubj.Status = api.Status_ACTIVE
+	badSubj.SubjectTemplate = `{{if`
+
+	tests := []struct {
+		inpClaimErr   error
+		inpOrgErr     error
+		inpAlarm      *api.Alarm
+		inpAlarmErr   error
+		inpUsersErr   error
+		inpOrgTimes   int
+		inpAlarmTimes int
+		inpUserTimes  int
+	}{
+		{errTestProc, nil, nil, nil, nil, 0, 0, 0},
+		{nil, errTestProc, nil, nil, nil, 1, 0, 0},
+		{nil, nil, nil, errTestProc, nil, 1, 1, 0},
+		{nil, nil, appAlarm, nil, errTestProc, 1, 1, 1},
+		{nil, nil, badSubj, nil, nil, 1, 1, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cannot escalate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			esc := &message.AlertEscalation{
+				LifecycleId: uuid.NewV7().String(),
+				OrgId:       org.GetId(),
+				AlarmId:     uuid.NewV7().String(),
+				StepNum:     1,
+				Step: &message.EscalationStep{
+					DelayMinutes: 5, UserTags: random.Tags("ale", 2),
+				},
+				Event: &message.EventerOut{
+					Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+					Device: random.Device("ale", org.GetId()),
+					Rule:   random.Rule("ale", org.GetId()),
+				},
+			}
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().Read(gomock.Any(), gomock.Any()).
+				Return(org, test.inpOrgErr).Times(test.inpOrgTimes)
+
+			alarmer := NewMockalarmer(ctrl)
+			alarmer.EXPECT().Read(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(test.inpAlarm, test.inpAlarmErr).
+				Times(test.inpAlarmTimes)
+
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().ListSchedules(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil, nil).AnyTimes()
+			userer.EXPECT().ListByTags(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return([]*api.User{
+				random.User("ale", org.GetId()),
+			}, test.inpUsersErr).Times(test.inpUserTimes)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().ClaimEscalations(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return([]*message.AlertEscalation{esc},
+				test.inpClaimErr).Times(1)
+
+			ale := Alerter{
+				orgDAO:   orger,
+				alarmDAO: alarmer,
+				userDAO:  userer,
+				aleDAO:   alerter,
+
+				notify: notify.NewMockNotifier(ctrl),
+			}
+			ale.escalateAlerts()
+		})
+	}
+}
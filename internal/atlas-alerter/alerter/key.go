@@ -7,3 +7,21 @@ func repeatKey(orgID, devID, alarmID, userID string) string {
 	return fmt.Sprintf("alerter:repeat:org:%s:dev:%s:alarm:%s:user:%s", orgID,
 		devID, alarmID, userID)
 }
+
+// digestKey returns a cache key to support collecting alerts into a pending
+// digest per alarm and user.
+func digestKey(orgID, alarmID, userID string) string {
+	return fmt.Sprintf("alerter:digest:org:%s:alarm:%s:user:%s", orgID,
+		alarmID, userID)
+}
+
+// digestCountKey returns a cache key to count the alerts of a digest.
+func digestCountKey(digestID string) string {
+	return "alerter:digest:" + digestID + ":count"
+}
+
+// digestItemKey returns a cache key to store an alert of a digest by its
+// position, starting at 1.
+func digestItemKey(digestID string, n int64) string {
+	return fmt.Sprintf("alerter:digest:%s:item:%d", digestID, n)
+}
@@ -31,3 +31,30 @@ func TestRepeatKey(t *testing.T) {
 		})
 	}
 }
+
+func TestDigestKey(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can key %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			orgID := uuid.NewV7().String()
+			alarmID := uuid.NewV7().String()
+			userID := uuid.NewV7().String()
+			digestID := uuid.NewV7().String()
+
+			key := digestKey(orgID, alarmID, userID)
+			t.Logf("key: %v", key)
+
+			require.Equal(t, fmt.Sprintf("alerter:digest:org:%s:alarm:%s:"+
+				"user:%s", orgID, alarmID, userID), key)
+			require.Equal(t, key, digestKey(orgID, alarmID, userID))
+
+			require.Equal(t, "alerter:digest:"+digestID+":count",
+				digestCountKey(digestID))
+			require.Equal(t, fmt.Sprintf("alerter:digest:%s:item:%d", digestID,
+				i), digestItemKey(digestID, int64(i)))
+		})
+	}
+}
@@ -14,7 +14,9 @@ import (
 	reflect "reflect"
 	time "time"
 
+	message "github.com/thingspect/atlas/proto/go/message"
 	api "github.com/thingspect/proto/go/api"
+	common "github.com/thingspect/proto/go/common"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -97,6 +99,67 @@ func (mr *MockalarmerMockRecorder) List(ctx, orgID, lBoundTS, prevID, limit, rul
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*Mockalarmer)(nil).List), ctx, orgID, lBoundTS, prevID, limit, ruleID)
 }
 
+// ListDigests mocks base method.
+func (m *Mockalarmer) ListDigests(ctx context.Context, orgID string, alarmIDs []string) (map[string]*message.AlarmDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDigests", ctx, orgID, alarmIDs)
+	ret0, _ := ret[0].(map[string]*message.AlarmDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDigests indicates an expected call of ListDigests.
+func (mr *MockalarmerMockRecorder) ListDigests(ctx, orgID, alarmIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDigests", reflect.TypeOf((*Mockalarmer)(nil).ListDigests), ctx, orgID, alarmIDs)
+}
+
+// ListRecoveries mocks base method.
+func (m *Mockalarmer) ListRecoveries(ctx context.Context, orgID string, alarmIDs []string) (map[string]*message.AlarmRecovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecoveries", ctx, orgID, alarmIDs)
+	ret0, _ := ret[0].(map[string]*message.AlarmRecovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecoveries indicates an expected call of ListRecoveries.
+func (mr *MockalarmerMockRecorder) ListRecoveries(ctx, orgID, alarmIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecoveries", reflect.TypeOf((*Mockalarmer)(nil).ListRecoveries), ctx, orgID, alarmIDs)
+}
+
+// ListWebhooks mocks base method.
+func (m *Mockalarmer) ListWebhooks(ctx context.Context, orgID string, alarmIDs []string) (map[string]*message.AlarmWebhook, map[string][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx, orgID, alarmIDs)
+	ret0, _ := ret[0].(map[string]*message.AlarmWebhook)
+	ret1, _ := ret[1].(map[string][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockalarmerMockRecorder) ListWebhooks(ctx, orgID, alarmIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*Mockalarmer)(nil).ListWebhooks), ctx, orgID, alarmIDs)
+}
+
+// Read mocks base method.
+func (m *Mockalarmer) Read(ctx context.Context, alarmID, orgID, ruleID string) (*api.Alarm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, alarmID, orgID, ruleID)
+	ret0, _ := ret[0].(*api.Alarm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockalarmerMockRecorder) Read(ctx, alarmID, orgID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*Mockalarmer)(nil).Read), ctx, alarmID, orgID, ruleID)
+}
+
 // Mockuserer is a mock of userer interface.
 type Mockuserer struct {
 	ctrl     *gomock.Controller
@@ -136,6 +199,75 @@ func (mr *MockusererMockRecorder) ListByTags(ctx, orgID, tags any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTags", reflect.TypeOf((*Mockuserer)(nil).ListByTags), ctx, orgID, tags)
 }
 
+// ListSchedules mocks base method.
+func (m *Mockuserer) ListSchedules(ctx context.Context, orgID string, userIDs []string) (map[string]*message.NotificationSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedules", ctx, orgID, userIDs)
+	ret0, _ := ret[0].(map[string]*message.NotificationSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockusererMockRecorder) ListSchedules(ctx, orgID, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*Mockuserer)(nil).ListSchedules), ctx, orgID, userIDs)
+}
+
+// Read mocks base method.
+func (m *Mockuserer) Read(ctx context.Context, userID, orgID string) (*api.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, userID, orgID)
+	ret0, _ := ret[0].(*api.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockusererMockRecorder) Read(ctx, userID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*Mockuserer)(nil).Read), ctx, userID, orgID)
+}
+
+// Mockdatapointer is a mock of datapointer interface.
+type Mockdatapointer struct {
+	ctrl     *gomock.Controller
+	recorder *MockdatapointerMockRecorder
+	isgomock struct{}
+}
+
+// MockdatapointerMockRecorder is the mock recorder for Mockdatapointer.
+type MockdatapointerMockRecorder struct {
+	mock *Mockdatapointer
+}
+
+// NewMockdatapointer creates a new mock instance.
+func NewMockdatapointer(ctrl *gomock.Controller) *Mockdatapointer {
+	mock := &Mockdatapointer{ctrl: ctrl}
+	mock.recorder = &MockdatapointerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdatapointer) EXPECT() *MockdatapointerMockRecorder {
+	return m.recorder
+}
+
+// Latest mocks base method.
+func (m *Mockdatapointer) Latest(ctx context.Context, orgID, uniqID, devID string, start time.Time) ([]*common.DataPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Latest", ctx, orgID, uniqID, devID, start)
+	ret0, _ := ret[0].([]*common.DataPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Latest indicates an expected call of Latest.
+func (mr *MockdatapointerMockRecorder) Latest(ctx, orgID, uniqID, devID, start any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latest", reflect.TypeOf((*Mockdatapointer)(nil).Latest), ctx, orgID, uniqID, devID, start)
+}
+
 // Mockalerter is a mock of alerter interface.
 type Mockalerter struct {
 	ctrl     *gomock.Controller
@@ -160,6 +292,65 @@ func (m *Mockalerter) EXPECT() *MockalerterMockRecorder {
 	return m.recorder
 }
 
+// ClaimDeferred mocks base method.
+func (m *Mockalerter) ClaimDeferred(ctx context.Context, now time.Time, lease time.Duration, limit int32) ([]*message.DeferredAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeferred", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]*message.DeferredAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeferred indicates an expected call of ClaimDeferred.
+func (mr *MockalerterMockRecorder) ClaimDeferred(ctx, now, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeferred", reflect.TypeOf((*Mockalerter)(nil).ClaimDeferred), ctx, now, lease, limit)
+}
+
+// ClaimDigests mocks base method.
+func (m *Mockalerter) ClaimDigests(ctx context.Context, now time.Time, limit int32) ([]*message.AlertDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDigests", ctx, now, limit)
+	ret0, _ := ret[0].([]*message.AlertDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDigests indicates an expected call of ClaimDigests.
+func (mr *MockalerterMockRecorder) ClaimDigests(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDigests", reflect.TypeOf((*Mockalerter)(nil).ClaimDigests), ctx, now, limit)
+}
+
+// ClaimEscalations mocks base method.
+func (m *Mockalerter) ClaimEscalations(ctx context.Context, now time.Time, limit int32) ([]*message.AlertEscalation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEscalations", ctx, now, limit)
+	ret0, _ := ret[0].([]*message.AlertEscalation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEscalations indicates an expected call of ClaimEscalations.
+func (mr *MockalerterMockRecorder) ClaimEscalations(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEscalations", reflect.TypeOf((*Mockalerter)(nil).ClaimEscalations), ctx, now, limit)
+}
+
+// CompleteDigest mocks base method.
+func (m *Mockalerter) CompleteDigest(ctx context.Context, digestID, orgID string, status api.AlertStatus, digestErr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteDigest", ctx, digestID, orgID, status, digestErr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteDigest indicates an expected call of CompleteDigest.
+func (mr *MockalerterMockRecorder) CompleteDigest(ctx, digestID, orgID, status, digestErr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDigest", reflect.TypeOf((*Mockalerter)(nil).CompleteDigest), ctx, digestID, orgID, status, digestErr)
+}
+
 // Create mocks base method.
 func (m *Mockalerter) Create(ctx context.Context, alert *api.Alert) error {
 	m.ctrl.T.Helper()
@@ -173,3 +364,104 @@ func (mr *MockalerterMockRecorder) Create(ctx, alert any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockalerter)(nil).Create), ctx, alert)
 }
+
+// CreateDigest mocks base method.
+func (m *Mockalerter) CreateDigest(ctx context.Context, digest *message.AlertDigest) (*message.AlertDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDigest", ctx, digest)
+	ret0, _ := ret[0].(*message.AlertDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDigest indicates an expected call of CreateDigest.
+func (mr *MockalerterMockRecorder) CreateDigest(ctx, digest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDigest", reflect.TypeOf((*Mockalerter)(nil).CreateDigest), ctx, digest)
+}
+
+// CreateInDigest mocks base method.
+func (m *Mockalerter) CreateInDigest(ctx context.Context, alert *api.Alert, digestID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInDigest", ctx, alert, digestID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInDigest indicates an expected call of CreateInDigest.
+func (mr *MockalerterMockRecorder) CreateInDigest(ctx, alert, digestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInDigest", reflect.TypeOf((*Mockalerter)(nil).CreateInDigest), ctx, alert, digestID)
+}
+
+// CreateRecord mocks base method.
+func (m *Mockalerter) CreateRecord(ctx context.Context, rec *message.AlertRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecord", ctx, rec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRecord indicates an expected call of CreateRecord.
+func (mr *MockalerterMockRecorder) CreateRecord(ctx, rec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecord", reflect.TypeOf((*Mockalerter)(nil).CreateRecord), ctx, rec)
+}
+
+// Defer mocks base method.
+func (m *Mockalerter) Defer(ctx context.Context, da *message.DeferredAlert) (*message.DeferredAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Defer", ctx, da)
+	ret0, _ := ret[0].(*message.DeferredAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Defer indicates an expected call of Defer.
+func (mr *MockalerterMockRecorder) Defer(ctx, da any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Defer", reflect.TypeOf((*Mockalerter)(nil).Defer), ctx, da)
+}
+
+// DeleteDeferred mocks base method.
+func (m *Mockalerter) DeleteDeferred(ctx context.Context, daID, orgID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeferred", ctx, daID, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeferred indicates an expected call of DeleteDeferred.
+func (mr *MockalerterMockRecorder) DeleteDeferred(ctx, daID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeferred", reflect.TypeOf((*Mockalerter)(nil).DeleteDeferred), ctx, daID, orgID)
+}
+
+// Open mocks base method.
+func (m *Mockalerter) Open(ctx context.Context, lc *message.AlertLifecycle) (*message.AlertLifecycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, lc)
+	ret0, _ := ret[0].(*message.AlertLifecycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockalerterMockRecorder) Open(ctx, lc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*Mockalerter)(nil).Open), ctx, lc)
+}
+
+// ScheduleEscalation mocks base method.
+func (m *Mockalerter) ScheduleEscalation(ctx context.Context, lc *message.AlertLifecycle, eOut *message.EventerOut) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleEscalation", ctx, lc, eOut)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleEscalation indicates an expected call of ScheduleEscalation.
+func (mr *MockalerterMockRecorder) ScheduleEscalation(ctx, lc, eOut any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleEscalation", reflect.TypeOf((*Mockalerter)(nil).ScheduleEscalation), ctx, lc, eOut)
+}
@@ -0,0 +1,122 @@
+package alerter
+
+import (
+	"slices"
+	"time"
+
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+// delivery represents when an alert is delivered under a notification
+// schedule.
+type delivery int
+
+// Alerts are delivered immediately, deferred until a window opens, or dropped.
+const (
+	deliverNow delivery = iota
+	deliverLater
+	deliverNever
+)
+
+// minutesPerDay is the number of minutes in a day.
+const minutesPerDay = 24 * 60
+
+// scheduleAlert returns when and by which alarm type an alert of alarmID is
+// delivered to a user with a notification schedule. For deferred alerts, the
+// time at which to deliver is also returned. Alerts of critical alarms, and
+// users without a schedule, are delivered immediately.
+func scheduleAlert(
+	sched *message.NotificationSchedule, alarmID string,
+	alarmType api.AlarmType, now time.Time,
+) (delivery, api.AlarmType, time.Time) {
+	if sched == nil || slices.Contains(sched.GetCriticalAlarmIds(), alarmID) {
+		return deliverNow, alarmType, time.Time{}
+	}
+
+	open, next := windowOpen(sched, alarmType, now)
+	if open {
+		return deliverNow, alarmType, time.Time{}
+	}
+
+	switch sched.GetAction() {
+	case message.ScheduleAction_DROP:
+		return deliverNever, alarmType, time.Time{}
+	case message.ScheduleAction_FALLBACK:
+		fallType := sched.GetFallbackType()
+		if fallType != api.AlarmType_ALARM_TYPE_UNSPECIFIED &&
+			fallType != alarmType {
+			if fallOpen, _ := windowOpen(sched, fallType, now); fallOpen {
+				return deliverNow, fallType, time.Time{}
+			}
+		}
+	case message.ScheduleAction_SCHEDULE_ACTION_UNSPECIFIED,
+		message.ScheduleAction_DEFER:
+	}
+
+	// Deliver immediately rather than risk a missed alert if a window will
+	// never open, such as for windows without valid days.
+	if next.IsZero() {
+		return deliverNow, alarmType, time.Time{}
+	}
+
+	return deliverLater, alarmType, next
+}
+
+// windowOpen returns whether a window for an alarm type is open at now. If
+// closed, the time at which a window next opens is also returned, or the zero
+// time if none will. Alarm types without windows are always open. Windows are
+// evaluated in the schedule's time zone, or UTC if it is invalid.
+func windowOpen(
+	sched *message.NotificationSchedule, alarmType api.AlarmType,
+	now time.Time,
+) (bool, time.Time) {
+	loc, err := time.LoadLocation(sched.GetTimezone())
+	if err != nil {
+		loc = time.UTC
+	}
+	now = now.In(loc)
+
+	var found bool
+	var next time.Time
+	for _, win := range sched.GetWindows() {
+		if win.GetType() != api.AlarmType_ALARM_TYPE_UNSPECIFIED &&
+			win.GetType() != alarmType {
+			continue
+		}
+		found = true
+
+		length := win.GetEndMinute() - win.GetStartMinute()
+		if length <= 0 {
+			length += minutesPerDay
+		}
+
+		// Windows may open on the previous day and remain open, and open at
+		// least weekly.
+		for offset := -1; offset <= 7; offset++ {
+			day := now.AddDate(0, 0, offset)
+			if len(win.GetDays()) > 0 &&
+				!slices.Contains(win.GetDays(), int32(day.Weekday())) {
+				continue
+			}
+
+			start := time.Date(day.Year(), day.Month(), day.Day(), 0,
+				int(win.GetStartMinute()), 0, 0, loc)
+			end := start.Add(time.Duration(length) * time.Minute)
+
+			if !now.Before(start) && now.Before(end) {
+				return true, time.Time{}
+			}
+
+			if start.After(now) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+	}
+
+	if !found {
+		return true, time.Time{}
+	}
+
+	return false, next.UTC()
+}
@@ -0,0 +1,169 @@
+//go:build !integration
+
+package alerter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+// testSchedule returns a schedule with weekday EMAIL windows of 09:00-17:00
+// and daily overnight SMS windows of 22:00-06:00, in America/Chicago.
+func testSchedule(
+	action message.ScheduleAction, fallType api.AlarmType,
+) *message.NotificationSchedule {
+	return &message.NotificationSchedule{
+		Timezone: "America/Chicago",
+		Windows: []*message.ScheduleWindow{
+			{
+				Type: api.AlarmType_EMAIL, Days: []int32{1, 2, 3, 4, 5},
+				StartMinute: 540, EndMinute: 1020,
+			},
+			{Type: api.AlarmType_SMS, StartMinute: 1320, EndMinute: 360},
+		},
+		Action:       action,
+		FallbackType: fallType,
+	}
+}
+
+func TestWindowOpen(t *testing.T) {
+	t.Parallel()
+
+	sched := testSchedule(message.ScheduleAction_DEFER,
+		api.AlarmType_ALARM_TYPE_UNSPECIFIED)
+
+	tests := []struct {
+		inpType api.AlarmType
+		inpNow  time.Time
+		resOpen bool
+		resNext time.Time
+	}{
+		// Wednesday 12:00 CDT.
+		{
+			api.AlarmType_EMAIL, time.Date(2026, 10, 14, 17, 0, 0, 0,
+				time.UTC), true, time.Time{},
+		},
+		// Wednesday 18:00 CDT.
+		{
+			api.AlarmType_EMAIL, time.Date(2026, 10, 14, 23, 0, 0, 0,
+				time.UTC), false, time.Date(2026, 10, 15, 14, 0, 0, 0,
+				time.UTC),
+		},
+		// Saturday 12:00 CDT.
+		{
+			api.AlarmType_EMAIL, time.Date(2026, 10, 17, 17, 0, 0, 0,
+				time.UTC), false, time.Date(2026, 10, 19, 14, 0, 0, 0,
+				time.UTC),
+		},
+		// Saturday 12:00 CDT, opening Monday 09:00 CST.
+		{
+			api.AlarmType_EMAIL, time.Date(2026, 10, 31, 17, 0, 0, 0,
+				time.UTC), false, time.Date(2026, 11, 2, 15, 0, 0, 0,
+				time.UTC),
+		},
+		// Thursday 02:00 CDT, in a window opened the previous day.
+		{
+			api.AlarmType_SMS, time.Date(2026, 10, 15, 7, 0, 0, 0,
+				time.UTC), true, time.Time{},
+		},
+		// Wednesday 12:00 CDT.
+		{
+			api.AlarmType_SMS, time.Date(2026, 10, 14, 17, 0, 0, 0,
+				time.UTC), false, time.Date(2026, 10, 15, 3, 0, 0, 0,
+				time.UTC),
+		},
+		{api.AlarmType_APP, time.Now(), true, time.Time{}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can evaluate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			open, next := windowOpen(sched, test.inpType, test.inpNow)
+			t.Logf("open, next: %v, %v", open, next)
+			require.Equal(t, test.resOpen, open)
+			require.Equal(t, test.resNext, next)
+		})
+	}
+}
+
+func TestScheduleAlert(t *testing.T) {
+	t.Parallel()
+
+	alarmID := uuid.NewV7().String()
+
+	critical := testSchedule(message.ScheduleAction_DROP,
+		api.AlarmType_ALARM_TYPE_UNSPECIFIED)
+	critical.CriticalAlarmIds = []string{alarmID}
+
+	noDays := &message.NotificationSchedule{
+		Windows: []*message.ScheduleWindow{{Days: []int32{9}}},
+	}
+
+	// Wednesday 12:00 CDT, 18:00 CDT, and 23:00 CDT.
+	noon := time.Date(2026, 10, 14, 17, 0, 0, 0, time.UTC)
+	evening := time.Date(2026, 10, 14, 23, 0, 0, 0, time.UTC)
+	night := time.Date(2026, 10, 15, 4, 0, 0, 0, time.UTC)
+	thursday := time.Date(2026, 10, 15, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		inpSched    *message.NotificationSchedule
+		inpNow      time.Time
+		resDelivery delivery
+		resType     api.AlarmType
+		resAt       time.Time
+	}{
+		{nil, evening, deliverNow, api.AlarmType_EMAIL, time.Time{}},
+		{critical, evening, deliverNow, api.AlarmType_EMAIL, time.Time{}},
+		{
+			testSchedule(message.ScheduleAction_DROP,
+				api.AlarmType_ALARM_TYPE_UNSPECIFIED), noon, deliverNow,
+			api.AlarmType_EMAIL, time.Time{},
+		},
+		{
+			testSchedule(message.ScheduleAction_DROP,
+				api.AlarmType_ALARM_TYPE_UNSPECIFIED), evening, deliverNever,
+			api.AlarmType_EMAIL, time.Time{},
+		},
+		{
+			testSchedule(message.ScheduleAction_DEFER,
+				api.AlarmType_ALARM_TYPE_UNSPECIFIED), evening, deliverLater,
+			api.AlarmType_EMAIL, thursday,
+		},
+		{
+			testSchedule(message.ScheduleAction_SCHEDULE_ACTION_UNSPECIFIED,
+				api.AlarmType_ALARM_TYPE_UNSPECIFIED), evening, deliverLater,
+			api.AlarmType_EMAIL, thursday,
+		},
+		{
+			testSchedule(message.ScheduleAction_FALLBACK,
+				api.AlarmType_SMS), night, deliverNow, api.AlarmType_SMS,
+			time.Time{},
+		},
+		{
+			testSchedule(message.ScheduleAction_FALLBACK,
+				api.AlarmType_SMS), evening, deliverLater,
+			api.AlarmType_EMAIL, thursday,
+		},
+		{noDays, evening, deliverNow, api.AlarmType_EMAIL, time.Time{}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can schedule %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			del, alarmType, at := scheduleAlert(test.inpSched, alarmID,
+				api.AlarmType_EMAIL, test.inpNow)
+			t.Logf("del, alarmType, at: %v, %v, %v", del, alarmType, at)
+			require.Equal(t, test.resDelivery, del)
+			require.Equal(t, test.resType, alarmType)
+			require.Equal(t, test.resAt, at)
+		})
+	}
+}
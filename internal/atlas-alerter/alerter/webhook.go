@@ -0,0 +1,131 @@
+package alerter
+
+import (
+	"bytes"
+	"context"
+	"time"
+	"uuid"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/crypt"
+	"github.com/thingspect/atlas/pkg/metric"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// publishWebhook publishes an alert for delivery to an alarm's webhook, so
+// that slow webhooks do not delay other alerts. Device tokens are not included
+// in the delivered document. On failure, the result is stored.
+func (ale *Alerter) publishWebhook(
+	ctx context.Context, eOut *message.EventerOut, org *api.Org, a *api.Alarm,
+	hook *message.AlarmWebhook, encSecret []byte, subj, body string,
+) {
+	logger := alog.FromContext(ctx)
+
+	dev, ok := proto.Clone(eOut.GetDevice()).(*api.Device)
+	if ok {
+		dev.Token = ""
+	}
+
+	bDel, err := proto.Marshal(&message.WebhookDelivery{
+		Url:    hook.GetUrl(),
+		Secret: encSecret,
+		Event: &message.WebhookEvent{
+			AlarmId: a.GetId(),
+			Subject: subj,
+			Body:    body,
+			Cleared: eOut.GetCleared(),
+			Point:   eOut.GetPoint(),
+			Device:  dev,
+			Rule:    eOut.GetRule(),
+			Org:     org,
+		},
+	})
+	if err == nil {
+		err = ale.aleQueue.Publish(ale.hookPubTopic, bDel)
+	}
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "publish"})
+		logger.Errorf("publishWebhook ale.aleQueue.Publish: %v", err)
+		ale.storeAlert(ctx, eOut, a, uuid.Nil().String(), webhookType, err)
+
+		return
+	}
+
+	logger.Debugf("publishWebhook published alarmID: %v", a.GetId())
+}
+
+// webhookMessages receives webhook delivery messages, delivers alerts to
+// webhooks, and stores the results. Webhooks retry their own failures, so
+// messages are acknowledged after a single delivery.
+func (ale *Alerter) webhookMessages() {
+	alog.Info("webhookMessages starting processor")
+	ctx := context.Background()
+
+	for msg := range ale.hookSub.C() {
+		// Retrieve published message.
+		metric.Incr("received", map[string]string{"type": webhookType})
+		del := &message.WebhookDelivery{}
+		err := proto.Unmarshal(msg.Payload(), del)
+		if err != nil || del.GetEvent().GetPoint() == nil ||
+			del.GetEvent().GetDevice() == nil {
+			msg.Ack()
+
+			if !bytes.Equal([]byte{queue.Prime}, msg.Payload()) {
+				metric.Incr("error",
+					map[string]string{metric.TagFunc: "unmarshal"})
+				alog.Errorf("webhookMessages proto.Unmarshal del, err: %+v, "+
+					"%v", del, err)
+			}
+
+			continue
+		}
+
+		// Set up logging fields.
+		logger := alog.
+			WithField("traceID", del.GetEvent().GetPoint().GetTraceId()).
+			WithField("orgID", del.GetEvent().GetDevice().GetOrgId()).
+			WithField("uniqID", del.GetEvent().GetDevice().GetUniqId()).
+			WithField("alarmID", del.GetEvent().GetAlarmId())
+
+		ale.notifyWebhook(alog.NewContext(ctx, &alog.CtxLogger{
+			Logger: logger,
+		}), del)
+
+		msg.Ack()
+	}
+}
+
+// notifyWebhook delivers an alert to an alarm's webhook and stores the result.
+// Webhook alerts are stored with the nil user ID. A secret that does not
+// decrypt fails the delivery.
+func (ale *Alerter) notifyWebhook(
+	ctx context.Context, del *message.WebhookDelivery,
+) {
+	event := del.GetEvent()
+	doc, err := protojson.Marshal(event)
+
+	// Decrypt secret.
+	var secret []byte
+	if err == nil {
+		secret, err = crypt.Decrypt(ale.hookKey, del.GetSecret())
+	}
+
+	if err == nil {
+		// Send alert.
+		nCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		err = ale.notify.Webhook(nCtx, del.GetUrl(), string(secret), doc)
+		cancel()
+	}
+
+	ale.storeAlert(ctx, &message.EventerOut{
+		Point:   event.GetPoint(),
+		Device:  event.GetDevice(),
+		Rule:    event.GetRule(),
+		Cleared: event.GetCleared(),
+	}, &api.Alarm{Id: event.GetAlarmId()}, uuid.Nil().String(), webhookType,
+		err)
+}
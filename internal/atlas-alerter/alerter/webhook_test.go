@@ -0,0 +1,193 @@
+//go:build !integration
+
+package alerter
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/crypt"
+	"github.com/thingspect/atlas/pkg/notify"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWebhookMessages(t *testing.T) {
+	t.Parallel()
+
+	org := random.Org("ale")
+
+	hookKey := make([]byte, 32)
+	_, err := rand.Read(hookKey)
+	require.NoError(t, err)
+
+	secret := random.String(10)
+	encSecret, err := crypt.Encrypt(hookKey, []byte(secret))
+	require.NoError(t, err)
+
+	tests := []struct {
+		inpSecret      []byte
+		inpWebhookErr  error
+		inpNotifyTimes int
+		resStatus      api.AlertStatus
+		resErr         string
+	}{
+		{encSecret, nil, 1, api.AlertStatus_SENT, ""},
+		{encSecret, errTestProc, 1, api.AlertStatus_ERROR, errTestProc.Error()},
+		{
+			[]byte("ale"), nil, 0, api.AlertStatus_ERROR,
+			crypt.ErrMalformed.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can deliver %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			del := &message.WebhookDelivery{
+				Url:    "https://ale.example.com/hook",
+				Secret: test.inpSecret,
+				Event: &message.WebhookEvent{
+					AlarmId: uuid.NewV7().String(),
+					Subject: "ale-subj",
+					Body:    "ale-body",
+					Point: &common.DataPoint{
+						TraceId: uuid.NewV7().String(),
+					},
+					Device: random.Device("ale", org.GetId()),
+					Rule:   random.Rule("ale", org.GetId()),
+					Org:    org,
+				},
+			}
+
+			hookQueue := queue.NewFake()
+			hookSub, err := hookQueue.Subscribe("")
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			ctrl := gomock.NewController(t)
+			notifier := notify.NewMockNotifier(ctrl)
+			notifier.EXPECT().Webhook(gomock.Any(), del.GetUrl(), secret,
+				gomock.Cond(func(doc []byte) bool {
+					event := &message.WebhookEvent{}
+					if err := protojson.Unmarshal(doc, event); err != nil {
+						return false
+					}
+
+					return proto.Equal(del.GetEvent(), event)
+				})).Return(test.inpWebhookErr).Times(test.inpNotifyTimes)
+
+			alert := &api.Alert{
+				OrgId:   org.GetId(),
+				UniqId:  del.GetEvent().GetDevice().GetUniqId(),
+				AlarmId: del.GetEvent().GetAlarmId(),
+				UserId:  uuid.Nil().String(),
+				Status:  test.resStatus,
+				Error:   test.resErr,
+				TraceId: del.GetEvent().GetPoint().GetTraceId(),
+			}
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().Create(gomock.Any(),
+				matcher.NewProtoMatcher(alert)).
+				DoAndReturn(func(_ any, _ any) error {
+					defer wg.Done()
+
+					return nil
+				}).Times(1)
+
+			ale := Alerter{
+				aleDAO: alerter,
+
+				aleQueue: hookQueue,
+				hookSub:  hookSub,
+
+				notify:  notifier,
+				hookKey: hookKey,
+			}
+			go func() {
+				ale.webhookMessages()
+			}()
+
+			bDel, err := proto.Marshal(del)
+			require.NoError(t, err)
+			t.Logf("bDel: %s", bDel)
+
+			require.NoError(t, hookQueue.Publish("", bDel))
+			wg.Wait()
+		})
+	}
+}
+
+func TestWebhookMessagesError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpDel *message.WebhookDelivery
+	}{
+		// Bad payload.
+		{nil},
+		// Missing data point.
+		{&message.WebhookDelivery{Event: &message.WebhookEvent{
+			Device: random.Device("ale", uuid.NewV7().String()),
+		}}},
+		// Missing device.
+		{&message.WebhookDelivery{Event: &message.WebhookEvent{
+			Point: &common.DataPoint{},
+		}}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cannot deliver %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			hookQueue := queue.NewFake()
+			hookSub, err := hookQueue.Subscribe("")
+			require.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+			notifier := notify.NewMockNotifier(ctrl)
+			notifier.EXPECT().Webhook(gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Times(0)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+			ale := Alerter{
+				aleDAO: alerter,
+
+				aleQueue: hookQueue,
+				hookSub:  hookSub,
+
+				notify: notifier,
+			}
+			go func() {
+				ale.webhookMessages()
+			}()
+
+			bDel := []byte("ale-aaa")
+			if test.inpDel != nil {
+				bDel, err = proto.Marshal(test.inpDel)
+				require.NoError(t, err)
+			}
+			t.Logf("bDel: %s", bDel)
+
+			require.NoError(t, hookQueue.Publish("", bDel))
+			time.Sleep(100 * time.Millisecond)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/thingspect/atlas/internal/atlas-alerter/alerter"
+	"github.com/thingspect/atlas/pkg/crypt"
 	"github.com/thingspect/atlas/pkg/test/random"
 	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
@@ -79,7 +80,7 @@ func TestAlertMessages(t *testing.T) {
 
 			listAlerts, err := globalAleDAO.List(ctx, createOrg.GetId(),
 				dev.GetUniqId(), "", createAlarm.GetId(), createUser.GetId(),
-				time.Now(), time.Now().Add(-4*time.Second))
+				time.Now(), time.Now().Add(-4*time.Second), nil)
 			t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
 			require.NoError(t, err)
 			require.Len(t, listAlerts, 1)
@@ -165,7 +166,7 @@ func TestAlertMessagesRepeat(t *testing.T) {
 
 			listAlerts, err := globalAleDAO.List(ctx, createOrg.GetId(),
 				dev.GetUniqId(), "", createAlarm.GetId(), createUser.GetId(),
-				time.Now(), time.Now().Add(-4*time.Second))
+				time.Now(), time.Now().Add(-4*time.Second), nil)
 			t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
 			require.NoError(t, err)
 			require.Len(t, listAlerts, 1)
@@ -189,6 +190,261 @@ func TestAlertMessagesRepeat(t *testing.T) {
 	}
 }
 
+func TestAlertMessagesRecovery(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("ale"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	rule := random.Rule("ale", createOrg.GetId())
+	rule.Status = api.Status_ACTIVE
+	createRule, err := globalRuleDAO.Create(ctx, rule)
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	user := random.User("dao-user", createOrg.GetId())
+	user.Status = api.Status_ACTIVE
+	createUser, err := globalUserDAO.Create(ctx, user)
+	t.Logf("createUser, err: %+v, %v", createUser, err)
+	require.NoError(t, err)
+
+	var alarmIDs []string
+	for range 2 {
+		alarm := random.Alarm("ale", createOrg.GetId(), createRule.GetId())
+		alarm.Status = api.Status_ACTIVE
+		alarm.Type = api.AlarmType_APP
+		alarm.UserTags = createUser.GetTags()
+		createAlarm, err := globalAlarmDAO.Create(ctx, alarm)
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.NoError(t, err)
+		alarmIDs = append(alarmIDs, createAlarm.GetId())
+	}
+
+	// Only the first alarm notifies on recovery.
+	_, err = globalAlarmDAO.UpsertRecovery(ctx, &message.AlarmRecovery{
+		AlarmId: alarmIDs[0], OrgId: createOrg.GetId(),
+		RuleId: createRule.GetId(), SubjectTemplate: "ale-recovered",
+	})
+	require.NoError(t, err)
+
+	dev := random.Device("ale", createOrg.GetId())
+	dev.Tags = []string{createRule.GetDeviceTag()}
+
+	eOut := &message.EventerOut{
+		Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+		Device: dev, Rule: createRule, Cleared: true,
+	}
+	bEOut, err := proto.Marshal(eOut)
+	require.NoError(t, err)
+	t.Logf("bEOut: %s", bEOut)
+
+	require.NoError(t, globalAleQueue.Publish(globalEOutSubTopic, bEOut))
+	time.Sleep(2 * time.Second)
+
+	for i, alarmID := range alarmIDs {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listAlerts, err := globalAleDAO.List(ctx, createOrg.GetId(),
+			dev.GetUniqId(), "", alarmID, createUser.GetId(), time.Now(),
+			time.Now().Add(-4*time.Second), nil)
+		t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
+		require.NoError(t, err)
+		require.Len(t, listAlerts, 1-i)
+	}
+}
+
+func TestAlertMessagesWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("ale"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	rule := random.Rule("ale", createOrg.GetId())
+	rule.Status = api.Status_ACTIVE
+	createRule, err := globalRuleDAO.Create(ctx, rule)
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	// Webhooks are delivered without users.
+	alarm := random.Alarm("ale", createOrg.GetId(), createRule.GetId())
+	alarm.Status = api.Status_ACTIVE
+	alarm.Type = api.AlarmType_APP
+	createAlarm, err := globalAlarmDAO.Create(ctx, alarm)
+	t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+	require.NoError(t, err)
+
+	secret, err := crypt.Encrypt(globalHookKey, []byte(random.String(10)))
+	require.NoError(t, err)
+
+	_, err = globalAlarmDAO.UpsertWebhook(ctx, &message.AlarmWebhook{
+		AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+		RuleId: createRule.GetId(), Url: "https://ale.example.com/hook",
+	}, secret)
+	require.NoError(t, err)
+
+	dev := random.Device("ale", createOrg.GetId())
+	dev.Tags = []string{createRule.GetDeviceTag()}
+
+	eOut := &message.EventerOut{
+		Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+		Device: dev, Rule: createRule,
+	}
+	bEOut, err := proto.Marshal(eOut)
+	require.NoError(t, err)
+	t.Logf("bEOut: %s", bEOut)
+
+	require.NoError(t, globalAleQueue.Publish(globalEOutSubTopic, bEOut))
+	time.Sleep(2 * time.Second)
+
+	listAlerts, err := globalAleDAO.List(ctx, createOrg.GetId(),
+		dev.GetUniqId(), "", createAlarm.GetId(), uuid.Nil().String(),
+		time.Now(), time.Now().Add(-4*time.Second), nil)
+	t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
+	require.NoError(t, err)
+	require.Len(t, listAlerts, 1)
+	require.Equal(t, api.AlertStatus_SENT, listAlerts[0].GetStatus())
+}
+
+func TestAlertMessagesSchedule(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("ale"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	rule := random.Rule("ale", createOrg.GetId())
+	rule.Status = api.Status_ACTIVE
+	createRule, err := globalRuleDAO.Create(ctx, rule)
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	user := random.User("ale", createOrg.GetId())
+	user.Status = api.Status_ACTIVE
+	createUser, err := globalUserDAO.Create(ctx, user)
+	t.Logf("createUser, err: %+v, %v", createUser, err)
+	require.NoError(t, err)
+
+	// Open a single-minute APP window an hour from now, so that the alert is
+	// deferred.
+	now := time.Now().UTC()
+	start := int32((now.Hour()*60 + now.Minute() + 60) % (24 * 60))
+	_, err = globalUserDAO.UpsertSchedule(ctx, &message.NotificationSchedule{
+		UserId: createUser.GetId(), OrgId: createOrg.GetId(), Timezone: "UTC",
+		Windows: []*message.ScheduleWindow{{
+			Type: api.AlarmType_APP, StartMinute: start,
+			EndMinute: (start + 1) % (24 * 60),
+		}},
+		Action: message.ScheduleAction_DEFER,
+	})
+	require.NoError(t, err)
+
+	alarm := random.Alarm("ale", createOrg.GetId(), createRule.GetId())
+	alarm.Status = api.Status_ACTIVE
+	alarm.Type = api.AlarmType_APP
+	alarm.UserTags = createUser.GetTags()
+	createAlarm, err := globalAlarmDAO.Create(ctx, alarm)
+	t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+	require.NoError(t, err)
+
+	dev := random.Device("ale", createOrg.GetId())
+	dev.Tags = []string{createRule.GetDeviceTag()}
+
+	eOut := &message.EventerOut{
+		Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+		Device: dev, Rule: createRule,
+	}
+	bEOut, err := proto.Marshal(eOut)
+	require.NoError(t, err)
+	t.Logf("bEOut: %s", bEOut)
+
+	require.NoError(t, globalAleQueue.Publish(globalEOutSubTopic, bEOut))
+	time.Sleep(2 * time.Second)
+
+	listAlerts, err := globalAleDAO.List(ctx, createOrg.GetId(),
+		dev.GetUniqId(), "", createAlarm.GetId(), createUser.GetId(),
+		time.Now(), time.Now().Add(-4*time.Second), nil)
+	t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
+	require.NoError(t, err)
+	require.Len(t, listAlerts, 1)
+
+	// Deferred alerts are listed with an unspecified status.
+	require.Equal(t, api.AlertStatus_ALERT_STATUS_UNSPECIFIED,
+		listAlerts[0].GetStatus())
+}
+
+func TestAlertMessagesDigest(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("ale"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	rule := random.Rule("ale", createOrg.GetId())
+	rule.Status = api.Status_ACTIVE
+	createRule, err := globalRuleDAO.Create(ctx, rule)
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	user := random.User("ale", createOrg.GetId())
+	user.Status = api.Status_ACTIVE
+	createUser, err := globalUserDAO.Create(ctx, user)
+	t.Logf("createUser, err: %+v, %v", createUser, err)
+	require.NoError(t, err)
+
+	alarm := random.Alarm("ale", createOrg.GetId(), createRule.GetId())
+	alarm.Status = api.Status_ACTIVE
+	alarm.Type = api.AlarmType_APP
+	alarm.UserTags = createUser.GetTags()
+	createAlarm, err := globalAlarmDAO.Create(ctx, alarm)
+	t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+	require.NoError(t, err)
+
+	_, err = globalAlarmDAO.UpsertDigest(ctx, &message.AlarmDigest{
+		AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+		RuleId: createRule.GetId(), WindowMinutes: 60,
+	})
+	require.NoError(t, err)
+
+	// Alerts are collected into a digest, and are not stored until it is
+	// flushed at the end of its window.
+	for range 2 {
+		dev := random.Device("ale", createOrg.GetId())
+		dev.Tags = []string{createRule.GetDeviceTag()}
+
+		bEOut, err := proto.Marshal(&message.EventerOut{
+			Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+			Device: dev, Rule: createRule,
+		})
+		require.NoError(t, err)
+		t.Logf("bEOut: %s", bEOut)
+
+		require.NoError(t, globalAleQueue.Publish(globalEOutSubTopic, bEOut))
+	}
+	time.Sleep(2 * time.Second)
+
+	listAlerts, err := globalAleDAO.List(ctx, createOrg.GetId(), "", "",
+		createAlarm.GetId(), createUser.GetId(), time.Now(),
+		time.Now().Add(-4*time.Second), nil)
+	t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
+	require.NoError(t, err)
+	require.Empty(t, listAlerts)
+}
+
 func TestAlertMessagesError(t *testing.T) {
 	t.Parallel()
 
@@ -306,7 +562,7 @@ func TestAlertMessagesError(t *testing.T) {
 
 			listAlerts, err := globalAleDAO.List(ctx, createOrg.GetId(),
 				dev.GetUniqId(), "", test.inpAlarmID, createUser.GetId(),
-				time.Now(), time.Now().Add(-4*time.Second))
+				time.Now(), time.Now().Add(-4*time.Second), nil)
 			t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
 			require.NoError(t, err)
 
@@ -3,6 +3,7 @@
 package test
 
 import (
+	"crypto/rand"
 	"log"
 	"os"
 	"testing"
@@ -23,6 +24,7 @@ import (
 var (
 	globalEOutSubTopic string
 	globalAleQueue     queue.Queuer
+	globalHookKey      []byte
 
 	globalOrgDAO   *org.DAO
 	globalRuleDAO  *rule.DAO
@@ -44,10 +46,17 @@ func TestMain(m *testing.M) {
 	cfg.NSQSubTopic += "-test-" + random.String(10)
 	globalEOutSubTopic = cfg.NSQSubTopic
 	log.Printf("TestMain cfg.NSQSubTopic: %v", cfg.NSQSubTopic)
+	cfg.NSQHookTopic += "-test-" + random.String(10)
 	// Use a unique channel for each test run. This prevents failed tests from
 	// interfering with the next run, but does require eventual cleaning.
 	cfg.NSQSubChannel = "alerter-test-" + random.String(10)
 
+	globalHookKey = make([]byte, 32)
+	if _, err := rand.Read(globalHookKey); err != nil {
+		log.Fatalf("TestMain rand.Read: %v", err)
+	}
+	cfg.HookKey = globalHookKey
+
 	// Set up NSQ queue to publish test payloads.
 	var err error
 	globalAleQueue, err = queue.NewNSQ(cfg.NSQPubAddr, nil, "")
@@ -62,7 +71,8 @@ func TestMain(m *testing.M) {
 	}
 
 	// Serve connections.
-	go ale.Serve(cfg.Concurrency)
+	go ale.Serve(cfg.Concurrency, cfg.HookConcurrency, cfg.EscalateInterval,
+		cfg.DeferInterval, cfg.DigestInterval)
 
 	// Set up database connection.
 	pg, err := dao.NewPgDB(cfg.PgRwURI)
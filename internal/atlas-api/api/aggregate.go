@@ -0,0 +1,22 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thingspect/atlas/internal/atlas-api/service"
+	"google.golang.org/grpc/metadata"
+)
+
+// aggregateMetadata forwards the aggregateFunc and aggregateBucket query
+// parameters of an HTTP request to ListDataPoints, which reads them from gRPC
+// metadata as they are not part of ListDataPointsRequest.
+func aggregateMetadata(_ context.Context, r *http.Request) metadata.MD {
+	fn := r.URL.Query().Get("aggregateFunc")
+	if fn == "" {
+		return nil
+	}
+
+	return metadata.Pairs(service.AggregateFuncKey, fn,
+		service.AggregateBucketKey, r.URL.Query().Get("aggregateBucket"))
+}
@@ -0,0 +1,28 @@
+//go:build !integration
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/service"
+)
+
+func TestAggregateMetadata(t *testing.T) {
+	t.Parallel()
+
+	md := aggregateMetadata(t.Context(), httptest.NewRequestWithContext(
+		t.Context(), http.MethodGet,
+		"/v1/datapoints?aggregateFunc=avg&aggregateBucket=1h", nil))
+	t.Logf("md: %+v", md)
+	require.Equal(t, []string{"avg"}, md.Get(service.AggregateFuncKey))
+	require.Equal(t, []string{"1h"}, md.Get(service.AggregateBucketKey))
+
+	md = aggregateMetadata(t.Context(), httptest.NewRequestWithContext(
+		t.Context(), http.MethodGet, "/v1/datapoints?aggregateBucket=1h", nil))
+	t.Logf("md: %+v", md)
+	require.Nil(t, md)
+}
@@ -0,0 +1,98 @@
+package api
+
+//go:generate mockgen -source alert.go -destination mock_alerter_test.go -package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thingspect/atlas/proto/go/message"
+)
+
+// Constants used for alert paths.
+const (
+	alertLifecyclesPath  = "/v1/alerts/lifecycles"
+	alertLifecyclePath   = "/v1/alerts/lifecycles/{id}"
+	alertAcknowledgePath = "/v1/alerts/lifecycles/{id}/acknowledge"
+	alertResolvePath     = "/v1/alerts/lifecycles/{id}/resolve"
+	alertAssigneePath    = "/v1/alerts/lifecycles/{id}/assignee"
+	alertDigestPath      = "/v1/alerts/digests/{id}"
+)
+
+// alerter defines the methods provided by a service.Alert that are not part of
+// the gRPC API.
+type alerter interface {
+	GetAlertLifecycle(ctx context.Context, lcID string) (
+		*message.AlertLifecycle, error)
+	ListAlertLifecycles(ctx context.Context, uniqID, alarmID string) (
+		[]*message.AlertLifecycle, error)
+	AcknowledgeAlert(ctx context.Context, lcID string,
+		action *message.AlertAction) (*message.AlertLifecycle, error)
+	ResolveAlert(ctx context.Context, lcID string,
+		action *message.AlertAction) (*message.AlertLifecycle, error)
+	AssignAlert(ctx context.Context, lcID string,
+		action *message.AlertAction) (*message.AlertLifecycle, error)
+	GetAlertDigest(ctx context.Context, digestID string) (*message.AlertDigest,
+		error)
+}
+
+// alertRoutes returns the routes of alerts that are not part of the gRPC API.
+// Unresolved alert lifecycles are listed, optionally filtered by the uniqId
+// and alarmId query parameters.
+func alertRoutes(aleSvc alerter) []route {
+	return []route{
+		{
+			http.MethodGet, alertLifecyclePath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return aleSvc.GetAlertLifecycle(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodGet, alertLifecyclesPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				query := req.URL.Query()
+				lcs, err := aleSvc.ListAlertLifecycles(ctx, query.Get("uniqId"),
+					query.Get("alarmId"))
+				if err != nil {
+					return nil, err
+				}
+
+				if lcs == nil {
+					lcs = []*message.AlertLifecycle{}
+				}
+
+				return lcs, nil
+			},
+		},
+		alertActionRoute(http.MethodPost, alertAcknowledgePath,
+			aleSvc.AcknowledgeAlert),
+		alertActionRoute(http.MethodPost, alertResolvePath,
+			aleSvc.ResolveAlert),
+		alertActionRoute(http.MethodPut, alertAssigneePath, aleSvc.AssignAlert),
+		{
+			http.MethodGet, alertDigestPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return aleSvc.GetAlertDigest(ctx, req.pathParams["id"])
+			},
+		},
+	}
+}
+
+// alertActionRoute returns a route that decodes an alert action and applies
+// it to an alert lifecycle using actFunc.
+func alertActionRoute(
+	method, path string, actFunc func(ctx context.Context, lcID string,
+		action *message.AlertAction) (*message.AlertLifecycle, error),
+) route {
+	return route{
+		method, path, authUnscoped, http.StatusOK,
+		func(ctx context.Context, req *request) (any, error) {
+			action := &message.AlertAction{}
+			if err := req.decode(action); err != nil {
+				return nil, err
+			}
+
+			return actFunc(ctx, req.pathParams["id"], action)
+		},
+	}
+}
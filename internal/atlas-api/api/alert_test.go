@@ -0,0 +1,103 @@
+//go:build !integration
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"uuid"
+
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAlertRoutes(t *testing.T) {
+	t.Parallel()
+
+	key, user, auth := testAuth(t, "api-alert", api.Role_BUILDER)
+
+	lc := &message.AlertLifecycle{
+		Id: uuid.NewV7().String(), OrgId: user.GetOrgId(),
+		UniqId: "api-lifecycle", AlarmId: uuid.NewV7().String(),
+		State: message.AlertState_OPEN,
+	}
+	ackLC := &message.AlertLifecycle{
+		Id: lc.GetId(), State: message.AlertState_ACKNOWLEDGED,
+		AckNote: "api-lifecycle-ack",
+	}
+	resLC := &message.AlertLifecycle{
+		Id: lc.GetId(), State: message.AlertState_RESOLVED,
+		ResolveNote: "api-lifecycle-res",
+	}
+	asnLC := &message.AlertLifecycle{
+		Id: lc.GetId(), State: message.AlertState_OPEN,
+		AssigneeId: user.GetId(),
+	}
+
+	ctrl := gomock.NewController(t)
+	aleSvc := NewMockalerter(ctrl)
+	aleSvc.EXPECT().GetAlertLifecycle(gomock.Any(), lc.GetId()).
+		Return(lc, nil).Times(1)
+	aleSvc.EXPECT().ListAlertLifecycles(gomock.Any(), "api-lifecycle",
+		lc.GetAlarmId()).Return([]*message.AlertLifecycle{lc}, nil).Times(1)
+	aleSvc.EXPECT().ListAlertLifecycles(gomock.Any(), "", "").Return(nil, nil).
+		Times(1)
+	aleSvc.EXPECT().AcknowledgeAlert(gomock.Any(), lc.GetId(), gomock.Cond(
+		func(inp *message.AlertAction) bool {
+			return inp.GetNote() == "api-lifecycle-ack"
+		})).Return(ackLC, nil).Times(1)
+	aleSvc.EXPECT().ResolveAlert(gomock.Any(), lc.GetId(), gomock.Cond(
+		func(inp *message.AlertAction) bool {
+			return inp.GetNote() == "api-lifecycle-res"
+		})).Return(resLC, nil).Times(1)
+	aleSvc.EXPECT().AssignAlert(gomock.Any(), lc.GetId(), gomock.Cond(
+		func(inp *message.AlertAction) bool {
+			return inp.GetAssigneeId() == user.GetId()
+		})).Return(asnLC, nil).Times(1)
+
+	digest := &message.AlertDigest{
+		Id: uuid.NewV7().String(), OrgId: user.GetOrgId(),
+		Type: api.AlarmType_EMAIL, Status: api.AlertStatus_SENT,
+		Alerts: []*api.Alert{{UniqId: "api-alert"}},
+	}
+	aleSvc.EXPECT().GetAlertDigest(gomock.Any(), digest.GetId()).
+		Return(digest, nil).Times(1)
+
+	path := "/v1/alerts/lifecycles/" + lc.GetId()
+
+	testRoutes(t, alertRoutes(aleSvc), key, []routeTest{
+		{
+			http.MethodGet, path, "", auth, http.StatusOK,
+			`"uniqId":"api-lifecycle"`,
+		},
+		{
+			http.MethodGet, "/v1/alerts/lifecycles?uniqId=api-lifecycle" +
+				"&alarmId=" + lc.GetAlarmId(), "", auth, http.StatusOK,
+			fmt.Sprintf(`"alarmId":"%s"`, lc.GetAlarmId()),
+		},
+		{
+			http.MethodGet, "/v1/alerts/lifecycles", "", auth, http.StatusOK,
+			"[]",
+		},
+		{
+			http.MethodPost, path + "/acknowledge",
+			`{"note": "api-lifecycle-ack"}`, auth, http.StatusOK,
+			`"state":"ACKNOWLEDGED"`,
+		},
+		{
+			http.MethodPost, path + "/resolve", `{"note": "api-lifecycle-res"}`,
+			auth, http.StatusOK, `"state":"RESOLVED"`,
+		},
+		{
+			http.MethodPut, path + "/assignee",
+			fmt.Sprintf(`{"assigneeId": "%s"}`, user.GetId()), auth,
+			http.StatusOK, fmt.Sprintf(`"assigneeId":"%s"`, user.GetId()),
+		},
+		{
+			http.MethodGet, "/v1/alerts/digests/" + digest.GetId(), "", auth,
+			http.StatusOK, `"uniqID":"api-alert"`,
+		},
+	})
+}
@@ -3,35 +3,47 @@ package api
 
 import (
 	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
+	"uuid"
 
 	"github.com/NYTimes/gziphandler"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/thingspect/atlas/internal/atlas-api/config"
 	"github.com/thingspect/atlas/internal/atlas-api/interceptor"
 	"github.com/thingspect/atlas/internal/atlas-api/lora"
+	"github.com/thingspect/atlas/internal/atlas-api/offboard"
+	"github.com/thingspect/atlas/internal/atlas-api/oidc"
 	"github.com/thingspect/atlas/internal/atlas-api/service"
+	"github.com/thingspect/atlas/internal/atlas-api/stream"
 	"github.com/thingspect/atlas/pkg/alog"
 	"github.com/thingspect/atlas/pkg/cache"
 	"github.com/thingspect/atlas/pkg/consterr"
 	"github.com/thingspect/atlas/pkg/dao"
 	"github.com/thingspect/atlas/pkg/dao/alarm"
 	"github.com/thingspect/atlas/pkg/dao/alert"
+	"github.com/thingspect/atlas/pkg/dao/command"
+	"github.com/thingspect/atlas/pkg/dao/connectivity"
 	"github.com/thingspect/atlas/pkg/dao/datapoint"
 	"github.com/thingspect/atlas/pkg/dao/device"
 	"github.com/thingspect/atlas/pkg/dao/event"
 	"github.com/thingspect/atlas/pkg/dao/key"
 	"github.com/thingspect/atlas/pkg/dao/org"
 	"github.com/thingspect/atlas/pkg/dao/rule"
+	"github.com/thingspect/atlas/pkg/dao/shadow"
 	"github.com/thingspect/atlas/pkg/dao/tag"
 	"github.com/thingspect/atlas/pkg/dao/user"
 	"github.com/thingspect/atlas/pkg/notify"
 	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -47,11 +59,19 @@ const (
 	GRPCPort  = ":50051"
 	httpPort  = ":8000"
 	deviceExp = 15 * time.Minute
+
+	offboardBatch    = 1000
+	offboardInterval = time.Minute
+
+	oidcTimeout = 10 * time.Second
 )
 
-// errPWTLength is returned due to insufficient key length.
+// Errors returned due to insufficient key length.
 // #nosec G101 // False positive for hardcoded credentials.
-const errPWTLength consterr.Error = "pwt key must be 32 bytes"
+const (
+	errPWTLength  consterr.Error = "pwt key must be 32 bytes"
+	errHookLength consterr.Error = "webhook key must be 32 bytes"
+)
 
 // API holds references to the gRPC and HTTP servers.
 type API struct {
@@ -59,6 +79,13 @@ type API struct {
 	grpcSrv    *grpc.Server
 	httpSrv    *http.Server
 	httpCancel context.CancelFunc
+
+	subQueue  queue.Queuer
+	mqttQueue queue.Queuer
+	dpHub     *stream.Hub[*message.ValidatorOut]
+	evHub     *stream.Hub[*message.EventerOut]
+
+	offboarder *offboard.Offboarder
 }
 
 // New builds a new API and returns a reference to it and an error value.
@@ -68,6 +95,10 @@ func New(cfg *config.Config) (*API, error) {
 		return nil, errPWTLength
 	}
 
+	if len(cfg.HookKey) != 32 {
+		return nil, errHookLength
+	}
+
 	// Set up database connection.
 	pgRW, err := dao.NewPgDB(cfg.PgRwURI)
 	if err != nil {
@@ -85,14 +116,28 @@ func New(cfg *config.Config) (*API, error) {
 		return nil, err
 	}
 
-	// Set up Notifier. Allow a mock for local usage, but warn loudly.
+	// Set up Notifier. Email is sent through an SMTP relay, if configured.
+	// Allow a mock for local usage, but warn loudly.
+	var smtp *notify.SMTP
+	if cfg.SMTPHost != "" {
+		smtp = &notify.SMTP{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			TLS:      cfg.SMTPTLS,
+			Auth:     cfg.SMTPAuth,
+		}
+	}
+
 	var n notify.Notifier
-	if cfg.AppAPIKey == "" || cfg.SMSKeySecret == "" {
+	if cfg.AppAPIKey == "" || cfg.SMSKeySecret == "" ||
+		(cfg.EmailAPIKey == "" && smtp == nil) {
 		alog.Error("New notify secrets not found, using notify.NewFake()")
 		n = notify.NewFake()
 	} else {
 		n = notify.New(redis, cfg.AppAPIKey, cfg.SMSKeyID, "", cfg.SMSKeySecret,
-			"", "", "")
+			"", cfg.EmailDomain, cfg.EmailAPIKey, smtp)
 	}
 
 	// Build the NSQ connection for publishing.
@@ -101,6 +146,41 @@ func New(cfg *config.Config) (*API, error) {
 		return nil, err
 	}
 
+	// Build the NSQ connection for streaming. Each API instance consumes all
+	// messages, so use a unique, ephemeral channel.
+	subNSQ, err := queue.NewNSQ(cfg.NSQPubAddr, cfg.NSQLookupAddrs,
+		cfg.NSQSubChannelPre+"-"+uuid.NewV7().String()+"#ephemeral")
+	if err != nil {
+		return nil, err
+	}
+
+	// Prime the queue before subscribing to allow for discovery by nsqlookupd.
+	if err = subNSQ.Prime(cfg.NSQSubVOutTopic); err != nil {
+		return nil, err
+	}
+
+	if err = subNSQ.Prime(cfg.NSQSubEOutTopic); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to the topics.
+	vOutSub, err := subNSQ.Subscribe(cfg.NSQSubVOutTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	eOutSub, err := subNSQ.Subscribe(cfg.NSQSubEOutTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	dpHub := stream.NewHub(vOutSub, func() *message.ValidatorOut {
+		return &message.ValidatorOut{}
+	})
+	evHub := stream.NewHub(eOutSub, func() *message.EventerOut {
+		return &message.EventerOut{}
+	})
+
 	// Set up LoRaWAN connection. Allow a mock for local usage, but warn loudly.
 	var cs lora.Loraer
 	if cfg.LoRaAddr == "" {
@@ -114,6 +194,36 @@ func New(cfg *config.Config) (*API, error) {
 		}
 	}
 
+	// Build the MQTT connection for publishing commands.
+	id, err := rand.Int(rand.Reader, big.NewInt(99999))
+	if err != nil {
+		return nil, err
+	}
+
+	mqtt, err := queue.NewMQTT(cfg.MQTTAddr, cfg.MQTTUser, cfg.MQTTPass,
+		fmt.Sprintf("%s-%d", ServiceName, id), queue.DefaultMQTTConnectTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	devDAO := device.NewDAO(pgRW, pgRO, cache.NewHeap[[]byte](), deviceExp)
+	cmdSvc := service.NewCommand(command.NewDAO(pgRW, pgRO), devDAO, mqtt, cs)
+	shadowSvc := service.NewShadow(shadow.NewDAO(pgRW, pgRO), devDAO,
+		datapoint.NewDAO(pgRW, pgRO), mqtt)
+	connSvc := service.NewConnectivity(connectivity.NewDAO(pgRW, pgRO), devDAO)
+	raSvc := service.NewRuleAlarm(rule.NewDAO(pgRW, pgRO), alarm.NewDAO(pgRW,
+		pgRO), cfg.HookKey)
+	aleSvc := service.NewAlert(alert.NewDAO(pgRW, pgRO))
+	userSvc := service.NewUser(user.NewDAO(pgRW, pgRO), n, redis)
+	orgDAO := org.NewDAO(pgRW, pgRO)
+	offboarder := offboard.New(orgDAO, devDAO, cs, redis, offboardBatch)
+	orgSvc := service.NewOrg(orgDAO, redis, offboarder)
+	oidcSvc := service.NewOIDC(orgDAO, user.NewDAO(pgRW, pgRO),
+		oidc.NewClient(oidcTimeout), redis, cfg.PWTKey, cfg.OIDCRedirectURI)
+	resetSvc := service.NewPasswordReset(user.NewDAO(pgRW, pgRO), n, redis,
+		cfg.EmailFrom)
+	lockSvc := service.NewLockout(orgDAO, user.NewDAO(pgRW, pgRO), redis)
+
 	// Register gRPC services.
 	skipAuth := map[string]struct{}{
 		"/thingspect.api.SessionService/Login": {},
@@ -131,27 +241,31 @@ func New(cfg *config.Config) (*API, error) {
 		interceptor.Log(),
 		interceptor.Recover(),
 		interceptor.Auth(skipAuth, cfg.PWTKey, redis),
-		interceptor.Validate(skipValidate)))
-	api.RegisterAlertServiceServer(srv, service.NewAlert(alert.NewDAO(pgRW,
-		pgRO)))
+		interceptor.Validate(skipValidate)), grpc.ChainStreamInterceptor(
+		interceptor.LogStream(),
+		interceptor.RecoverStream(),
+		interceptor.AuthStream(skipAuth, cfg.PWTKey, redis)))
+	api.RegisterAlertServiceServer(srv, aleSvc)
 	api.RegisterDataPointServiceServer(srv, service.NewDataPoint(nsq,
-		cfg.NSQPubTopic, datapoint.NewDAO(pgRW, pgRO)))
-	api.RegisterDeviceServiceServer(srv, service.NewDevice(device.NewDAO(pgRW,
-		pgRO, cache.NewHeap[[]byte](), deviceExp), cs))
+		cfg.NSQPubTopic, datapoint.NewDAO(pgRW, pgRO), devDAO))
+	api.RegisterDeviceServiceServer(srv, service.NewDevice(devDAO, cs))
 	api.RegisterEventServiceServer(srv, service.NewEvent(event.NewDAO(pgRW,
 		pgRO)))
-	api.RegisterOrgServiceServer(srv, service.NewOrg(org.NewDAO(pgRW, pgRO)))
-	api.RegisterRuleAlarmServiceServer(srv,
-		service.NewRuleAlarm(rule.NewDAO(pgRW, pgRO), alarm.NewDAO(pgRW, pgRO)))
-	api.RegisterSessionServiceServer(srv, service.NewSession(user.NewDAO(pgRW,
-		pgRO), key.NewDAO(pgRW, pgRO), redis, cfg.PWTKey))
+	api.RegisterOrgServiceServer(srv, orgSvc)
+	api.RegisterRuleAlarmServiceServer(srv, raSvc)
+	sessSvc := service.NewSession(user.NewDAO(pgRW, pgRO), key.NewDAO(pgRW,
+		pgRO), redis, cfg.PWTKey)
+	api.RegisterSessionServiceServer(srv, sessSvc)
 	api.RegisterTagServiceServer(srv, service.NewTag(tag.NewDAO(pgRW)))
-	api.RegisterUserServiceServer(srv, service.NewUser(user.NewDAO(pgRW, pgRO),
-		n))
+	api.RegisterUserServiceServer(srv, userSvc)
+	message.RegisterStreamServiceServer(srv, service.NewStream(dpHub, evHub))
 
 	// Register gRPC-Gateway handlers.
 	ctx, cancel := context.WithCancel(context.Background())
-	gwMux := runtime.NewServeMux(runtime.WithForwardResponseOption(statusCode))
+	gwMux := runtime.NewServeMux(runtime.WithForwardResponseOption(statusCode),
+		runtime.WithMiddlewares(clientIPMiddleware(cfg.TrustedProxies)),
+		runtime.WithMetadata(clientIPMetadata),
+		runtime.WithMetadata(aggregateMetadata))
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	}
@@ -228,20 +342,75 @@ func New(cfg *config.Config) (*API, error) {
 		return nil, err
 	}
 
-	// OpenAPI.
+	// Streaming.
+	if err := gwMux.HandlePath(http.MethodGet, streamDataPointsPath,
+		streamHandler(gwMux, dpHub, cfg.PWTKey, redis,
+			message.StreamService_StreamDataPoints_FullMethodName, "datapoint",
+			stream.DataPoint)); err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	if err := gwMux.HandlePath(http.MethodGet, streamEventsPath,
+		streamHandler(gwMux, evHub, cfg.PWTKey, redis,
+			message.StreamService_StreamEvents_FullMethodName, "event",
+			stream.Event)); err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	// OIDC single sign-on login, which redirects to the identity provider.
+	if err := gwMux.HandlePath(http.MethodGet, oidcLoginPath,
+		oidcLoginHandler(gwMux, oidcSvc)); err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	// Routes that are not part of the gRPC API.
+	if err := registerRoutes(gwMux, slices.Concat(
+		commandRoutes(cmdSvc),
+		shadowRoutes(shadowSvc),
+		connectivityRoutes(connSvc),
+		ruleAlarmRoutes(raSvc),
+		alertRoutes(aleSvc),
+		sessionRoutes(sessSvc),
+		passwordResetRoutes(resetSvc),
+		userLockoutRoutes(lockSvc),
+		userRoutes(userSvc),
+		orgRoutes(orgSvc),
+		oidcRoutes(oidcSvc),
+	), cfg.PWTKey, redis); err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	// OpenAPI. Streams bypass compression, which buffers until closed.
 	mux := http.NewServeMux()
-	mux.Handle("/v1/", gwMux)
-	mux.Handle("/", http.FileServer(http.Dir("web")))
+	mux.Handle("/v1/", gziphandler.GzipHandler(gwMux))
+	mux.Handle(streamDataPointsPath, gwMux)
+	mux.Handle(streamEventsPath, gwMux)
+	mux.Handle("/", gziphandler.GzipHandler(http.FileServer(http.Dir("web"))))
 
 	return &API{
 		apiHost: cfg.APIHost,
 		grpcSrv: srv,
 		httpSrv: &http.Server{
 			Addr:              cfg.APIHost + httpPort,
-			Handler:           gziphandler.GzipHandler(mux),
+			Handler:           mux,
 			ReadHeaderTimeout: 10 * time.Second,
 		},
 		httpCancel: cancel,
+
+		subQueue:  subNSQ,
+		mqttQueue: mqtt,
+		dpHub:     dpHub,
+		evHub:     evHub,
+
+		offboarder: offboarder,
 	}, nil
 }
 
@@ -262,6 +431,13 @@ func (api *API) Serve() {
 		}
 	}()
 
+	// Serve streams.
+	go api.dpHub.Serve()
+	go api.evHub.Serve()
+
+	// Resume org deletions, including those interrupted by a restart.
+	go api.offboarder.Serve(offboardInterval)
+
 	// Serve gRPC-gateway.
 	go func() {
 		alog.Infof("Listening on %v", api.httpSrv.Addr)
@@ -278,9 +454,22 @@ func (api *API) Serve() {
 	alog.Info("Serve received signal, exiting")
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
+
+	// Close stream hubs first, as open streams would otherwise hold the HTTP
+	// server's shutdown until it times out.
+	if err := api.dpHub.Close(); err != nil {
+		alog.Errorf("Serve api.dpHub.Close: %v", err)
+	}
+	if err := api.evHub.Close(); err != nil {
+		alog.Errorf("Serve api.evHub.Close: %v", err)
+	}
+
 	if err := api.httpSrv.Shutdown(ctx); err != nil {
 		alog.Errorf("Serve api.httpSrv.Shutdown: %v", err)
 	}
 	api.httpCancel()
 	api.grpcSrv.GracefulStop()
+
+	api.subQueue.Disconnect()
+	api.mqttQueue.Disconnect()
 }
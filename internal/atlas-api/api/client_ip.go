@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/thingspect/atlas/internal/atlas-api/service"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// resolveClientIP returns the client IP of an HTTP request. X-Forwarded-For
+// hops are trusted only for the number of proxies in front of the gateway,
+// counted from the right, as earlier hops may be supplied by the client. An
+// empty string is returned if the client IP is unknown.
+func resolveClientIP(r *http.Request, trustedProxies int) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil || trustedProxies <= 0 {
+		return host
+	}
+
+	var hops []string
+	for _, xff := range r.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(xff, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+
+	// The remote address is the last proxy, so trust one fewer header hop.
+	if len(hops) < trustedProxies {
+		return host
+	}
+
+	if hop := hops[len(hops)-trustedProxies]; net.ParseIP(hop) != nil {
+		return hop
+	}
+
+	return host
+}
+
+// clientIPMiddleware builds gRPC-gateway middleware that resolves the client
+// IP of a request and stores it as the request context's peer, for use by
+// handlers and clientIPMetadata.
+func clientIPMiddleware(trustedProxies int) runtime.Middleware {
+	return func(next runtime.HandlerFunc) runtime.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request,
+			pathParams map[string]string,
+		) {
+			if ip := net.ParseIP(resolveClientIP(r,
+				trustedProxies)); ip != nil {
+				r = r.WithContext(peer.NewContext(r.Context(), &peer.Peer{
+					Addr: &net.TCPAddr{IP: ip},
+				}))
+			}
+
+			next(w, r, pathParams)
+		}
+	}
+}
+
+// clientIPMetadata forwards the client IP resolved by clientIPMiddleware to
+// gRPC services.
+func clientIPMetadata(_ context.Context, r *http.Request) metadata.MD {
+	p, ok := peer.FromContext(r.Context())
+	if !ok || p.Addr == nil {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return nil
+	}
+
+	return metadata.Pairs(service.ClientIPKey, host)
+}
@@ -0,0 +1,68 @@
+//go:build !integration
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/service"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestResolveClientIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpXFF            []string
+		inpTrustedProxies int
+		res               string
+	}{
+		{nil, 0, "10.0.0.9"},
+		{[]string{"10.0.0.1"}, 0, "10.0.0.9"},
+		{[]string{"10.0.0.1, 10.0.0.2"}, 1, "10.0.0.2"},
+		{[]string{"10.0.0.1", "10.0.0.2, 10.0.0.3"}, 2, "10.0.0.2"},
+		{[]string{"10.0.0.1"}, 2, "10.0.0.9"},
+		{[]string{"not-an-ip"}, 1, "10.0.0.9"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can resolve %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequestWithContext(t.Context(), http.MethodGet,
+				"/v1/test", nil)
+			r.RemoteAddr = "10.0.0.9:1234"
+			for _, xff := range test.inpXFF {
+				r.Header.Add("X-Forwarded-For", xff)
+			}
+
+			require.Equal(t, test.res, resolveClientIP(r,
+				test.inpTrustedProxies))
+		})
+	}
+}
+
+func TestClientIPMiddleware(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequestWithContext(t.Context(), http.MethodGet,
+		"/v1/test", nil)
+	r.RemoteAddr = "10.0.0.9:1234"
+	r.Header.Set("X-Forwarded-For", "10.0.0.1, 10.0.0.2")
+
+	var md metadata.MD
+	clientIPMiddleware(1)(func(_ http.ResponseWriter, r *http.Request,
+		_ map[string]string,
+	) {
+		md = clientIPMetadata(r.Context(), r)
+	})(httptest.NewRecorder(), r, nil)
+	t.Logf("md: %+v", md)
+	require.Equal(t, []string{"10.0.0.2"}, md.Get(service.ClientIPKey))
+
+	require.Nil(t, clientIPMetadata(t.Context(), httptest.NewRequestWithContext(
+		t.Context(), http.MethodGet, "/v1/test", nil)))
+}
@@ -0,0 +1,85 @@
+package api
+
+//go:generate mockgen -source command.go -destination mock_commander_test.go -package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// commandsPath is the path used to create and list device commands.
+const commandsPath = "/v1/devices/{id}/commands"
+
+// commander defines the methods provided by a service.Command.
+type commander interface {
+	CreateCommand(ctx context.Context, devID string, cmd *message.Command,
+		ttl time.Duration) (*message.Command, error)
+	ListCommands(ctx context.Context, devID string, end,
+		start time.Time) ([]*message.Command, error)
+}
+
+// commandRoutes returns the routes of device commands. Commands are created
+// with an optional ttl query parameter in time.ParseDuration format, and are
+// listed with optional endTime and startTime query parameters parsed as RFC
+// 3339 timestamps.
+func commandRoutes(cmdSvc commander) []route {
+	return []route{
+		{
+			http.MethodPost, commandsPath, authScoped, http.StatusCreated,
+			func(ctx context.Context, req *request) (any, error) {
+				var ttl time.Duration
+				if qTTL := req.URL.Query().Get("ttl"); qTTL != "" {
+					var err error
+					ttl, err = time.ParseDuration(qTTL)
+					if err != nil {
+						return nil, status.Error(codes.InvalidArgument,
+							"invalid TTL")
+					}
+				}
+
+				cmd := &message.Command{}
+				if err := req.decode(cmd); err != nil {
+					return nil, err
+				}
+
+				return cmdSvc.CreateCommand(ctx, req.pathParams["id"], cmd, ttl)
+			},
+		},
+		{
+			http.MethodGet, commandsPath, authScoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				var times [2]time.Time
+				for i, param := range []string{"endTime", "startTime"} {
+					qTime := req.URL.Query().Get(param)
+					if qTime == "" {
+						continue
+					}
+
+					var err error
+					times[i], err = time.Parse(time.RFC3339Nano, qTime)
+					if err != nil {
+						return nil, status.Error(codes.InvalidArgument,
+							"invalid "+param)
+					}
+				}
+
+				cmds, err := cmdSvc.ListCommands(ctx, req.pathParams["id"],
+					times[0], times[1])
+				if err != nil {
+					return nil, err
+				}
+
+				if cmds == nil {
+					cmds = []*message.Command{}
+				}
+
+				return cmds, nil
+			},
+		},
+	}
+}
@@ -0,0 +1,64 @@
+//go:build !integration
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"uuid"
+
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCommandRoutes(t *testing.T) {
+	t.Parallel()
+
+	key, user, auth := testAuth(t, "api-command", api.Role_BUILDER)
+
+	devID := uuid.NewV7().String()
+	cmd := random.Command("api-command", user.GetOrgId())
+	cmd.Id = uuid.NewV7().String()
+
+	ctrl := gomock.NewController(t)
+	cmdSvc := NewMockcommander(ctrl)
+	cmdSvc.EXPECT().CreateCommand(gomock.Any(), devID, gomock.Cond(
+		func(inp *message.Command) bool {
+			return string(inp.GetPayload()) == "reboot" &&
+				inp.GetFPort() == 2
+		}), gomock.Any()).Return(cmd, nil).Times(1)
+	cmdSvc.EXPECT().ListCommands(gomock.Any(), devID, gomock.Any(),
+		gomock.Any()).Return([]*message.Command{cmd}, nil).Times(1)
+	cmdSvc.EXPECT().ListCommands(gomock.Any(), "api-command-perm",
+		gomock.Any(), gomock.Any()).Return(nil,
+		status.Error(codes.PermissionDenied, "permission denied")).Times(1)
+
+	testRoutes(t, commandRoutes(cmdSvc), key, []routeTest{
+		{
+			http.MethodPost, "/v1/devices/" + devID + "/commands?ttl=30m",
+			`{"payload": "cmVib290", "fPort": 2}`, auth, http.StatusCreated,
+			fmt.Sprintf(`"id":"%s"`, cmd.GetId()),
+		},
+		{
+			http.MethodPost, "/v1/devices/" + devID + "/commands?ttl=1x", "{}",
+			auth, http.StatusBadRequest, "invalid TTL",
+		},
+		{
+			http.MethodGet, "/v1/devices/" + devID + "/commands", "", auth,
+			http.StatusOK, fmt.Sprintf(`[{"id":"%s"`, cmd.GetId()),
+		},
+		{
+			http.MethodGet, "/v1/devices/" + devID + "/commands?endTime=x", "",
+			auth, http.StatusBadRequest, "invalid endTime",
+		},
+		{
+			http.MethodGet, "/v1/devices/api-command-perm/commands", "", auth,
+			http.StatusForbidden, "permission denied",
+		},
+	})
+}
@@ -0,0 +1,76 @@
+package api
+
+//go:generate mockgen -source connectivity.go -destination mock_connectivityer_test.go -package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thingspect/atlas/proto/go/message"
+)
+
+// Constants used for connectivity paths.
+const (
+	connectivityPath  = "/v1/devices/{id}/connectivity"
+	connIntervalsPath = "/v1/connectivity/intervals"
+	connIntervalPath  = "/v1/connectivity/intervals/{id}"
+)
+
+// connectivityer defines the methods provided by a service.Connectivity.
+type connectivityer interface {
+	GetConnectivity(ctx context.Context, devID string) (*message.Connectivity,
+		error)
+	UpsertConnectivityInterval(ctx context.Context,
+		inter *message.ConnectivityInterval) (*message.ConnectivityInterval,
+		error)
+	DeleteConnectivityInterval(ctx context.Context, interID string) error
+	ListConnectivityIntervals(ctx context.Context) (
+		[]*message.ConnectivityInterval, error)
+}
+
+// connectivityRoutes returns the routes of device connectivity and expected
+// reporting intervals.
+func connectivityRoutes(connSvc connectivityer) []route {
+	return []route{
+		{
+			http.MethodGet, connectivityPath, authScoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return connSvc.GetConnectivity(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodGet, connIntervalsPath, authScoped, http.StatusOK,
+			func(ctx context.Context, _ *request) (any, error) {
+				inters, err := connSvc.ListConnectivityIntervals(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				if inters == nil {
+					inters = []*message.ConnectivityInterval{}
+				}
+
+				return inters, nil
+			},
+		},
+		{
+			http.MethodPut, connIntervalsPath, authScoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				inter := &message.ConnectivityInterval{}
+				if err := req.decode(inter); err != nil {
+					return nil, err
+				}
+
+				return connSvc.UpsertConnectivityInterval(ctx, inter)
+			},
+		},
+		{
+			http.MethodDelete, connIntervalPath, authScoped,
+			http.StatusNoContent,
+			func(ctx context.Context, req *request) (any, error) {
+				return nil, connSvc.DeleteConnectivityInterval(ctx,
+					req.pathParams["id"])
+			},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+//go:build !integration
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestConnectivityRoutes(t *testing.T) {
+	t.Parallel()
+
+	key, user, auth := testAuth(t, "api-conn", api.Role_BUILDER)
+
+	devID := uuid.NewV7().String()
+	conn := &message.Connectivity{
+		DevId: devID, OrgId: user.GetOrgId(), Offline: true,
+	}
+	inter := &message.ConnectivityInterval{
+		Id: uuid.NewV7().String(), OrgId: user.GetOrgId(), Tag: "api-conn",
+		Interval: durationpb.New(time.Hour),
+	}
+
+	ctrl := gomock.NewController(t)
+	connSvc := NewMockconnectivityer(ctrl)
+	connSvc.EXPECT().GetConnectivity(gomock.Any(), devID).Return(conn, nil).
+		Times(1)
+	connSvc.EXPECT().UpsertConnectivityInterval(gomock.Any(), gomock.Cond(
+		func(inp *message.ConnectivityInterval) bool {
+			return inp.GetTag() == "api-conn" &&
+				inp.GetInterval().AsDuration() == time.Hour
+		})).Return(inter, nil).Times(1)
+	connSvc.EXPECT().ListConnectivityIntervals(gomock.Any()).
+		Return([]*message.ConnectivityInterval{inter}, nil).Times(1)
+	connSvc.EXPECT().DeleteConnectivityInterval(gomock.Any(), inter.GetId()).
+		Return(nil).Times(1)
+	connSvc.EXPECT().DeleteConnectivityInterval(gomock.Any(),
+		"api-conn-notfound").Return(status.Error(codes.NotFound,
+		"object not found")).Times(1)
+
+	testRoutes(t, connectivityRoutes(connSvc), key, []routeTest{
+		{
+			http.MethodGet, "/v1/devices/" + devID + "/connectivity", "",
+			auth, http.StatusOK, `"offline":true`,
+		},
+		{
+			http.MethodPut, "/v1/connectivity/intervals",
+			`{"tag": "api-conn", "interval": "3600s"}`, auth, http.StatusOK,
+			fmt.Sprintf(`"id":"%s"`, inter.GetId()),
+		},
+		{
+			http.MethodGet, "/v1/connectivity/intervals", "", auth,
+			http.StatusOK, fmt.Sprintf(`[{"id":"%s"`, inter.GetId()),
+		},
+		{
+			http.MethodDelete, "/v1/connectivity/intervals/" + inter.GetId(),
+			"", auth, http.StatusNoContent, "",
+		},
+		{
+			http.MethodDelete, "/v1/connectivity/intervals/api-conn-notfound",
+			"", auth, http.StatusNotFound, "object not found",
+		},
+	})
+}
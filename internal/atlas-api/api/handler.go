@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/thingspect/atlas/internal/atlas-api/interceptor"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errTagScoped is returned when a tag scoped session calls a handler that does
+// not filter by scope tags.
+const errTagScoped = "permission denied, unavailable to tag scoped sessions"
+
+// authContext authenticates an HTTP request as the gRPC interceptors do, and
+// returns a context populated with its session and logger. Tag scoped sessions
+// are rejected, as most handlers do not filter by scope tags.
+func authContext(
+	r *http.Request, pwtKey []byte, c cache.Cacher[string],
+) (context.Context, error) {
+	ctx, err := scopedAuthContext(r, pwtKey, c)
+	if err != nil {
+		return nil, err
+	}
+
+	if sess, ok := session.FromContext(ctx); ok && sess.TagScoped() {
+		return nil, status.Error(codes.PermissionDenied, errTagScoped)
+	}
+
+	return ctx, nil
+}
+
+// scopedAuthContext authenticates an HTTP request as authContext does, but
+// permits tag scoped sessions. It is used by handlers whose services enforce
+// scope tags, or that do not access devices.
+func scopedAuthContext(
+	r *http.Request, pwtKey []byte, c cache.Cacher[string],
+) (context.Context, error) {
+	ctx := r.Context()
+
+	sess, err := interceptor.Authenticate(ctx, r.Header.Get("Authorization"),
+		pwtKey, c)
+	if err != nil {
+		return nil, err
+	}
+
+	// HTTP handlers do not serve gRPC methods, so are outside of any API key
+	// scope.
+	if len(sess.Scopes) > 0 {
+		return nil, status.Error(codes.PermissionDenied, interceptor.ErrScope)
+	}
+
+	logger := &alog.CtxLogger{Logger: alog.WithField("path", r.URL.Path).
+		WithField("orgID", sess.OrgID).
+		WithField("traceID", sess.TraceID.String())}
+
+	return session.NewContext(alog.NewContext(ctx, logger), sess), nil
+}
+
+// unauthContext builds a logging context for unauthenticated requests.
+func unauthContext(r *http.Request) context.Context {
+	logger := &alog.CtxLogger{Logger: alog.WithField("path", r.URL.Path)}
+
+	return alog.NewContext(r.Context(), logger)
+}
+
+// writeResponse marshals and writes a response with the provided status code.
+func writeResponse(
+	ctx context.Context, gwMux *runtime.ServeMux, marshaler runtime.Marshaler,
+	w http.ResponseWriter, r *http.Request, code int, resp any,
+) {
+	b, err := marshaler.Marshal(resp)
+	if err != nil {
+		runtime.HTTPError(ctx, gwMux, marshaler, w, r, status.Error(
+			codes.Internal, "encode failure"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", marshaler.ContentType(resp))
+	w.WriteHeader(code)
+	if _, err := w.Write(b); err != nil {
+		alog.FromContext(ctx).Errorf("writeResponse w.Write: %v", err)
+	}
+}
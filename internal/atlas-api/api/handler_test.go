@@ -0,0 +1,76 @@
+//go:build !integration
+
+package api
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthContext(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	user := random.User("api-handler", uuid.NewV7().String())
+	user.Role = api.Role_ADMIN
+	token, _, err := session.GenerateWebToken(key, user)
+	require.NoError(t, err)
+
+	scopeToken, _, err := session.GenerateSessionToken(key, user,
+		uuid.NewV7().String(), []string{"site"})
+	require.NoError(t, err)
+
+	c := cache.NewHeap[string]()
+
+	tests := []struct {
+		inpToken  string
+		inpScoped bool
+		err       error
+	}{
+		{token, false, nil},
+		{token, true, nil},
+		{scopeToken, false, status.Error(codes.PermissionDenied,
+			errTagScoped)},
+		{scopeToken, true, nil},
+		{"", false, status.Error(codes.Unauthenticated, "unauthorized")},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can authenticate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequestWithContext(t.Context(), http.MethodGet,
+				"/v1/test", nil)
+			r.Header.Set("Authorization", "Bearer "+test.inpToken)
+
+			authFunc := authContext
+			if test.inpScoped {
+				authFunc = scopedAuthContext
+			}
+
+			ctx, err := authFunc(r, key, c)
+			t.Logf("ctx, err: %+v, %v", ctx, err)
+			require.Equal(t, test.err, err)
+			if test.err == nil {
+				sess, ok := session.FromContext(ctx)
+				require.True(t, ok)
+				require.Equal(t, user.GetOrgId(), sess.OrgID)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: alert.go
+//
+// Generated by this command:
+//
+//	mockgen -source alert.go -destination mock_alerter_test.go -package api
+//
+
+// Package api is a generated GoMock package.
+package api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockalerter is a mock of alerter interface.
+type Mockalerter struct {
+	ctrl     *gomock.Controller
+	recorder *MockalerterMockRecorder
+	isgomock struct{}
+}
+
+// MockalerterMockRecorder is the mock recorder for Mockalerter.
+type MockalerterMockRecorder struct {
+	mock *Mockalerter
+}
+
+// NewMockalerter creates a new mock instance.
+func NewMockalerter(ctrl *gomock.Controller) *Mockalerter {
+	mock := &Mockalerter{ctrl: ctrl}
+	mock.recorder = &MockalerterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockalerter) EXPECT() *MockalerterMockRecorder {
+	return m.recorder
+}
+
+// AcknowledgeAlert mocks base method.
+func (m *Mockalerter) AcknowledgeAlert(ctx context.Context, lcID string, action *message.AlertAction) (*message.AlertLifecycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeAlert", ctx, lcID, action)
+	ret0, _ := ret[0].(*message.AlertLifecycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcknowledgeAlert indicates an expected call of AcknowledgeAlert.
+func (mr *MockalerterMockRecorder) AcknowledgeAlert(ctx, lcID, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeAlert", reflect.TypeOf((*Mockalerter)(nil).AcknowledgeAlert), ctx, lcID, action)
+}
+
+// AssignAlert mocks base method.
+func (m *Mockalerter) AssignAlert(ctx context.Context, lcID string, action *message.AlertAction) (*message.AlertLifecycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignAlert", ctx, lcID, action)
+	ret0, _ := ret[0].(*message.AlertLifecycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignAlert indicates an expected call of AssignAlert.
+func (mr *MockalerterMockRecorder) AssignAlert(ctx, lcID, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignAlert", reflect.TypeOf((*Mockalerter)(nil).AssignAlert), ctx, lcID, action)
+}
+
+// GetAlertDigest mocks base method.
+func (m *Mockalerter) GetAlertDigest(ctx context.Context, digestID string) (*message.AlertDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertDigest", ctx, digestID)
+	ret0, _ := ret[0].(*message.AlertDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertDigest indicates an expected call of GetAlertDigest.
+func (mr *MockalerterMockRecorder) GetAlertDigest(ctx, digestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertDigest", reflect.TypeOf((*Mockalerter)(nil).GetAlertDigest), ctx, digestID)
+}
+
+// GetAlertLifecycle mocks base method.
+func (m *Mockalerter) GetAlertLifecycle(ctx context.Context, lcID string) (*message.AlertLifecycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertLifecycle", ctx, lcID)
+	ret0, _ := ret[0].(*message.AlertLifecycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertLifecycle indicates an expected call of GetAlertLifecycle.
+func (mr *MockalerterMockRecorder) GetAlertLifecycle(ctx, lcID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertLifecycle", reflect.TypeOf((*Mockalerter)(nil).GetAlertLifecycle), ctx, lcID)
+}
+
+// ListAlertLifecycles mocks base method.
+func (m *Mockalerter) ListAlertLifecycles(ctx context.Context, uniqID, alarmID string) ([]*message.AlertLifecycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlertLifecycles", ctx, uniqID, alarmID)
+	ret0, _ := ret[0].([]*message.AlertLifecycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlertLifecycles indicates an expected call of ListAlertLifecycles.
+func (mr *MockalerterMockRecorder) ListAlertLifecycles(ctx, uniqID, alarmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlertLifecycles", reflect.TypeOf((*Mockalerter)(nil).ListAlertLifecycles), ctx, uniqID, alarmID)
+}
+
+// ResolveAlert mocks base method.
+func (m *Mockalerter) ResolveAlert(ctx context.Context, lcID string, action *message.AlertAction) (*message.AlertLifecycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveAlert", ctx, lcID, action)
+	ret0, _ := ret[0].(*message.AlertLifecycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveAlert indicates an expected call of ResolveAlert.
+func (mr *MockalerterMockRecorder) ResolveAlert(ctx, lcID, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveAlert", reflect.TypeOf((*Mockalerter)(nil).ResolveAlert), ctx, lcID, action)
+}
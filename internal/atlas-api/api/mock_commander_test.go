@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: command.go
+//
+// Generated by this command:
+//
+//	mockgen -source command.go -destination mock_commander_test.go -package api
+//
+
+// Package api is a generated GoMock package.
+package api
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockcommander is a mock of commander interface.
+type Mockcommander struct {
+	ctrl     *gomock.Controller
+	recorder *MockcommanderMockRecorder
+	isgomock struct{}
+}
+
+// MockcommanderMockRecorder is the mock recorder for Mockcommander.
+type MockcommanderMockRecorder struct {
+	mock *Mockcommander
+}
+
+// NewMockcommander creates a new mock instance.
+func NewMockcommander(ctrl *gomock.Controller) *Mockcommander {
+	mock := &Mockcommander{ctrl: ctrl}
+	mock.recorder = &MockcommanderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcommander) EXPECT() *MockcommanderMockRecorder {
+	return m.recorder
+}
+
+// CreateCommand mocks base method.
+func (m *Mockcommander) CreateCommand(ctx context.Context, devID string, cmd *message.Command, ttl time.Duration) (*message.Command, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCommand", ctx, devID, cmd, ttl)
+	ret0, _ := ret[0].(*message.Command)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCommand indicates an expected call of CreateCommand.
+func (mr *MockcommanderMockRecorder) CreateCommand(ctx, devID, cmd, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCommand", reflect.TypeOf((*Mockcommander)(nil).CreateCommand), ctx, devID, cmd, ttl)
+}
+
+// ListCommands mocks base method.
+func (m *Mockcommander) ListCommands(ctx context.Context, devID string, end, start time.Time) ([]*message.Command, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommands", ctx, devID, end, start)
+	ret0, _ := ret[0].([]*message.Command)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommands indicates an expected call of ListCommands.
+func (mr *MockcommanderMockRecorder) ListCommands(ctx, devID, end, start any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommands", reflect.TypeOf((*Mockcommander)(nil).ListCommands), ctx, devID, end, start)
+}
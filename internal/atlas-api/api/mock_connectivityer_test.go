@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: connectivity.go
+//
+// Generated by this command:
+//
+//	mockgen -source connectivity.go -destination mock_connectivityer_test.go -package api
+//
+
+// Package api is a generated GoMock package.
+package api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockconnectivityer is a mock of connectivityer interface.
+type Mockconnectivityer struct {
+	ctrl     *gomock.Controller
+	recorder *MockconnectivityerMockRecorder
+	isgomock struct{}
+}
+
+// MockconnectivityerMockRecorder is the mock recorder for Mockconnectivityer.
+type MockconnectivityerMockRecorder struct {
+	mock *Mockconnectivityer
+}
+
+// NewMockconnectivityer creates a new mock instance.
+func NewMockconnectivityer(ctrl *gomock.Controller) *Mockconnectivityer {
+	mock := &Mockconnectivityer{ctrl: ctrl}
+	mock.recorder = &MockconnectivityerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockconnectivityer) EXPECT() *MockconnectivityerMockRecorder {
+	return m.recorder
+}
+
+// DeleteConnectivityInterval mocks base method.
+func (m *Mockconnectivityer) DeleteConnectivityInterval(ctx context.Context, interID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteConnectivityInterval", ctx, interID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteConnectivityInterval indicates an expected call of DeleteConnectivityInterval.
+func (mr *MockconnectivityerMockRecorder) DeleteConnectivityInterval(ctx, interID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConnectivityInterval", reflect.TypeOf((*Mockconnectivityer)(nil).DeleteConnectivityInterval), ctx, interID)
+}
+
+// GetConnectivity mocks base method.
+func (m *Mockconnectivityer) GetConnectivity(ctx context.Context, devID string) (*message.Connectivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnectivity", ctx, devID)
+	ret0, _ := ret[0].(*message.Connectivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConnectivity indicates an expected call of GetConnectivity.
+func (mr *MockconnectivityerMockRecorder) GetConnectivity(ctx, devID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectivity", reflect.TypeOf((*Mockconnectivityer)(nil).GetConnectivity), ctx, devID)
+}
+
+// ListConnectivityIntervals mocks base method.
+func (m *Mockconnectivityer) ListConnectivityIntervals(ctx context.Context) ([]*message.ConnectivityInterval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConnectivityIntervals", ctx)
+	ret0, _ := ret[0].([]*message.ConnectivityInterval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConnectivityIntervals indicates an expected call of ListConnectivityIntervals.
+func (mr *MockconnectivityerMockRecorder) ListConnectivityIntervals(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConnectivityIntervals", reflect.TypeOf((*Mockconnectivityer)(nil).ListConnectivityIntervals), ctx)
+}
+
+// UpsertConnectivityInterval mocks base method.
+func (m *Mockconnectivityer) UpsertConnectivityInterval(ctx context.Context, inter *message.ConnectivityInterval) (*message.ConnectivityInterval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertConnectivityInterval", ctx, inter)
+	ret0, _ := ret[0].(*message.ConnectivityInterval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertConnectivityInterval indicates an expected call of UpsertConnectivityInterval.
+func (mr *MockconnectivityerMockRecorder) UpsertConnectivityInterval(ctx, inter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertConnectivityInterval", reflect.TypeOf((*Mockconnectivityer)(nil).UpsertConnectivityInterval), ctx, inter)
+}
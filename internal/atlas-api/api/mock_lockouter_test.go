@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_lockout.go
+//
+// Generated by this command:
+//
+//	mockgen -source user_lockout.go -destination mock_lockouter_test.go -package api
+//
+
+// Package api is a generated GoMock package.
+package api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mocklockouter is a mock of lockouter interface.
+type Mocklockouter struct {
+	ctrl     *gomock.Controller
+	recorder *MocklockouterMockRecorder
+	isgomock struct{}
+}
+
+// MocklockouterMockRecorder is the mock recorder for Mocklockouter.
+type MocklockouterMockRecorder struct {
+	mock *Mocklockouter
+}
+
+// NewMocklockouter creates a new mock instance.
+func NewMocklockouter(ctrl *gomock.Controller) *Mocklockouter {
+	mock := &Mocklockouter{ctrl: ctrl}
+	mock.recorder = &MocklockouterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklockouter) EXPECT() *MocklockouterMockRecorder {
+	return m.recorder
+}
+
+// ListUserLockouts mocks base method.
+func (m *Mocklockouter) ListUserLockouts(ctx context.Context) (*message.ListUserLockoutsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLockouts", ctx)
+	ret0, _ := ret[0].(*message.ListUserLockoutsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLockouts indicates an expected call of ListUserLockouts.
+func (mr *MocklockouterMockRecorder) ListUserLockouts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLockouts", reflect.TypeOf((*Mocklockouter)(nil).ListUserLockouts), ctx)
+}
+
+// UnlockUser mocks base method.
+func (m *Mocklockouter) UnlockUser(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MocklockouterMockRecorder) UnlockUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*Mocklockouter)(nil).UnlockUser), ctx, userID)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: oidc.go
+//
+// Generated by this command:
+//
+//	mockgen -source oidc.go -destination mock_oidcer_test.go -package api
+//
+
+// Package api is a generated GoMock package.
+package api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	api "github.com/thingspect/proto/go/api"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockoidcer is a mock of oidcer interface.
+type Mockoidcer struct {
+	ctrl     *gomock.Controller
+	recorder *MockoidcerMockRecorder
+	isgomock struct{}
+}
+
+// MockoidcerMockRecorder is the mock recorder for Mockoidcer.
+type MockoidcerMockRecorder struct {
+	mock *Mockoidcer
+}
+
+// NewMockoidcer creates a new mock instance.
+func NewMockoidcer(ctrl *gomock.Controller) *Mockoidcer {
+	mock := &Mockoidcer{ctrl: ctrl}
+	mock.recorder = &MockoidcerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockoidcer) EXPECT() *MockoidcerMockRecorder {
+	return m.recorder
+}
+
+// CompleteOIDCLogin mocks base method.
+func (m *Mockoidcer) CompleteOIDCLogin(ctx context.Context, state, code string) (*api.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOIDCLogin", ctx, state, code)
+	ret0, _ := ret[0].(*api.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteOIDCLogin indicates an expected call of CompleteOIDCLogin.
+func (mr *MockoidcerMockRecorder) CompleteOIDCLogin(ctx, state, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOIDCLogin", reflect.TypeOf((*Mockoidcer)(nil).CompleteOIDCLogin), ctx, state, code)
+}
+
+// DeleteOrgOIDC mocks base method.
+func (m *Mockoidcer) DeleteOrgOIDC(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrgOIDC", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrgOIDC indicates an expected call of DeleteOrgOIDC.
+func (mr *MockoidcerMockRecorder) DeleteOrgOIDC(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrgOIDC", reflect.TypeOf((*Mockoidcer)(nil).DeleteOrgOIDC), ctx)
+}
+
+// GetOrgOIDC mocks base method.
+func (m *Mockoidcer) GetOrgOIDC(ctx context.Context) (*message.OrgOIDC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgOIDC", ctx)
+	ret0, _ := ret[0].(*message.OrgOIDC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgOIDC indicates an expected call of GetOrgOIDC.
+func (mr *MockoidcerMockRecorder) GetOrgOIDC(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgOIDC", reflect.TypeOf((*Mockoidcer)(nil).GetOrgOIDC), ctx)
+}
+
+// StartOIDCLogin mocks base method.
+func (m *Mockoidcer) StartOIDCLogin(ctx context.Context, orgName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOIDCLogin", ctx, orgName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartOIDCLogin indicates an expected call of StartOIDCLogin.
+func (mr *MockoidcerMockRecorder) StartOIDCLogin(ctx, orgName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOIDCLogin", reflect.TypeOf((*Mockoidcer)(nil).StartOIDCLogin), ctx, orgName)
+}
+
+// UpdateOrgOIDC mocks base method.
+func (m *Mockoidcer) UpdateOrgOIDC(ctx context.Context, conf *message.OrgOIDC) (*message.OrgOIDC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrgOIDC", ctx, conf)
+	ret0, _ := ret[0].(*message.OrgOIDC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrgOIDC indicates an expected call of UpdateOrgOIDC.
+func (mr *MockoidcerMockRecorder) UpdateOrgOIDC(ctx, conf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrgOIDC", reflect.TypeOf((*Mockoidcer)(nil).UpdateOrgOIDC), ctx, conf)
+}
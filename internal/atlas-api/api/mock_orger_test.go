@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: org.go
+//
+// Generated by this command:
+//
+//	mockgen -source org.go -destination mock_orger_test.go -package api
+//
+
+// Package api is a generated GoMock package.
+package api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockorger is a mock of orger interface.
+type Mockorger struct {
+	ctrl     *gomock.Controller
+	recorder *MockorgerMockRecorder
+	isgomock struct{}
+}
+
+// MockorgerMockRecorder is the mock recorder for Mockorger.
+type MockorgerMockRecorder struct {
+	mock *Mockorger
+}
+
+// NewMockorger creates a new mock instance.
+func NewMockorger(ctrl *gomock.Controller) *Mockorger {
+	mock := &Mockorger{ctrl: ctrl}
+	mock.recorder = &MockorgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockorger) EXPECT() *MockorgerMockRecorder {
+	return m.recorder
+}
+
+// GetOrgDeletion mocks base method.
+func (m *Mockorger) GetOrgDeletion(ctx context.Context, orgID string) (*message.OrgDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgDeletion", ctx, orgID)
+	ret0, _ := ret[0].(*message.OrgDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgDeletion indicates an expected call of GetOrgDeletion.
+func (mr *MockorgerMockRecorder) GetOrgDeletion(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgDeletion", reflect.TypeOf((*Mockorger)(nil).GetOrgDeletion), ctx, orgID)
+}
+
+// GetOrgMFAPolicy mocks base method.
+func (m *Mockorger) GetOrgMFAPolicy(ctx context.Context) (*message.OrgMFAPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgMFAPolicy", ctx)
+	ret0, _ := ret[0].(*message.OrgMFAPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgMFAPolicy indicates an expected call of GetOrgMFAPolicy.
+func (mr *MockorgerMockRecorder) GetOrgMFAPolicy(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgMFAPolicy", reflect.TypeOf((*Mockorger)(nil).GetOrgMFAPolicy), ctx)
+}
+
+// GetOrgRetention mocks base method.
+func (m *Mockorger) GetOrgRetention(ctx context.Context) (*message.OrgRetention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgRetention", ctx)
+	ret0, _ := ret[0].(*message.OrgRetention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgRetention indicates an expected call of GetOrgRetention.
+func (mr *MockorgerMockRecorder) GetOrgRetention(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgRetention", reflect.TypeOf((*Mockorger)(nil).GetOrgRetention), ctx)
+}
+
+// UpdateOrgMFAPolicy mocks base method.
+func (m *Mockorger) UpdateOrgMFAPolicy(ctx context.Context, policy *message.OrgMFAPolicy) (*message.OrgMFAPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrgMFAPolicy", ctx, policy)
+	ret0, _ := ret[0].(*message.OrgMFAPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrgMFAPolicy indicates an expected call of UpdateOrgMFAPolicy.
+func (mr *MockorgerMockRecorder) UpdateOrgMFAPolicy(ctx, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrgMFAPolicy", reflect.TypeOf((*Mockorger)(nil).UpdateOrgMFAPolicy), ctx, policy)
+}
+
+// UpdateOrgRetention mocks base method.
+func (m *Mockorger) UpdateOrgRetention(ctx context.Context, ret *message.OrgRetention) (*message.OrgRetention, error) {
+	m.ctrl.T.Helper()
+	ret_2 := m.ctrl.Call(m, "UpdateOrgRetention", ctx, ret)
+	ret0, _ := ret_2[0].(*message.OrgRetention)
+	ret1, _ := ret_2[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrgRetention indicates an expected call of UpdateOrgRetention.
+func (mr *MockorgerMockRecorder) UpdateOrgRetention(ctx, ret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrgRetention", reflect.TypeOf((*Mockorger)(nil).UpdateOrgRetention), ctx, ret)
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_password_reset.go
+//
+// Generated by this command:
+//
+//	mockgen -source user_password_reset.go -destination mock_passwordresetter_test.go -package api
+//
+
+// Package api is a generated GoMock package.
+package api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockpasswordResetter is a mock of passwordResetter interface.
+type MockpasswordResetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordResetterMockRecorder
+	isgomock struct{}
+}
+
+// MockpasswordResetterMockRecorder is the mock recorder for MockpasswordResetter.
+type MockpasswordResetterMockRecorder struct {
+	mock *MockpasswordResetter
+}
+
+// NewMockpasswordResetter creates a new mock instance.
+func NewMockpasswordResetter(ctrl *gomock.Controller) *MockpasswordResetter {
+	mock := &MockpasswordResetter{ctrl: ctrl}
+	mock.recorder = &MockpasswordResetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordResetter) EXPECT() *MockpasswordResetterMockRecorder {
+	return m.recorder
+}
+
+// CompletePasswordReset mocks base method.
+func (m *MockpasswordResetter) CompletePasswordReset(ctx context.Context, req *message.CompletePasswordResetRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompletePasswordReset", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompletePasswordReset indicates an expected call of CompletePasswordReset.
+func (mr *MockpasswordResetterMockRecorder) CompletePasswordReset(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompletePasswordReset", reflect.TypeOf((*MockpasswordResetter)(nil).CompletePasswordReset), ctx, req)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockpasswordResetter) RequestPasswordReset(ctx context.Context, req *message.RequestPasswordResetRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockpasswordResetterMockRecorder) RequestPasswordReset(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockpasswordResetter)(nil).RequestPasswordReset), ctx, req)
+}
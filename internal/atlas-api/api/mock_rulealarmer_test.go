@@ -0,0 +1,322 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rule_alarm.go
+//
+// Generated by this command:
+//
+//	mockgen -source rule_alarm.go -destination mock_rulealarmer_test.go -package api
+//
+
+// Package api is a generated GoMock package.
+package api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	api "github.com/thingspect/proto/go/api"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockruleAlarmer is a mock of ruleAlarmer interface.
+type MockruleAlarmer struct {
+	ctrl     *gomock.Controller
+	recorder *MockruleAlarmerMockRecorder
+	isgomock struct{}
+}
+
+// MockruleAlarmerMockRecorder is the mock recorder for MockruleAlarmer.
+type MockruleAlarmerMockRecorder struct {
+	mock *MockruleAlarmer
+}
+
+// NewMockruleAlarmer creates a new mock instance.
+func NewMockruleAlarmer(ctrl *gomock.Controller) *MockruleAlarmer {
+	mock := &MockruleAlarmer{ctrl: ctrl}
+	mock.recorder = &MockruleAlarmerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockruleAlarmer) EXPECT() *MockruleAlarmerMockRecorder {
+	return m.recorder
+}
+
+// DeleteAlarmDigest mocks base method.
+func (m *MockruleAlarmer) DeleteAlarmDigest(ctx context.Context, alarmID, ruleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlarmDigest", ctx, alarmID, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlarmDigest indicates an expected call of DeleteAlarmDigest.
+func (mr *MockruleAlarmerMockRecorder) DeleteAlarmDigest(ctx, alarmID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlarmDigest", reflect.TypeOf((*MockruleAlarmer)(nil).DeleteAlarmDigest), ctx, alarmID, ruleID)
+}
+
+// DeleteAlarmEscalation mocks base method.
+func (m *MockruleAlarmer) DeleteAlarmEscalation(ctx context.Context, alarmID, ruleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlarmEscalation", ctx, alarmID, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlarmEscalation indicates an expected call of DeleteAlarmEscalation.
+func (mr *MockruleAlarmerMockRecorder) DeleteAlarmEscalation(ctx, alarmID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlarmEscalation", reflect.TypeOf((*MockruleAlarmer)(nil).DeleteAlarmEscalation), ctx, alarmID, ruleID)
+}
+
+// DeleteAlarmRecovery mocks base method.
+func (m *MockruleAlarmer) DeleteAlarmRecovery(ctx context.Context, alarmID, ruleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlarmRecovery", ctx, alarmID, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlarmRecovery indicates an expected call of DeleteAlarmRecovery.
+func (mr *MockruleAlarmerMockRecorder) DeleteAlarmRecovery(ctx, alarmID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlarmRecovery", reflect.TypeOf((*MockruleAlarmer)(nil).DeleteAlarmRecovery), ctx, alarmID, ruleID)
+}
+
+// DeleteAlarmWebhook mocks base method.
+func (m *MockruleAlarmer) DeleteAlarmWebhook(ctx context.Context, alarmID, ruleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlarmWebhook", ctx, alarmID, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlarmWebhook indicates an expected call of DeleteAlarmWebhook.
+func (mr *MockruleAlarmerMockRecorder) DeleteAlarmWebhook(ctx, alarmID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlarmWebhook", reflect.TypeOf((*MockruleAlarmer)(nil).DeleteAlarmWebhook), ctx, alarmID, ruleID)
+}
+
+// DeleteRuleCondition mocks base method.
+func (m *MockruleAlarmer) DeleteRuleCondition(ctx context.Context, ruleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRuleCondition", ctx, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRuleCondition indicates an expected call of DeleteRuleCondition.
+func (mr *MockruleAlarmerMockRecorder) DeleteRuleCondition(ctx, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRuleCondition", reflect.TypeOf((*MockruleAlarmer)(nil).DeleteRuleCondition), ctx, ruleID)
+}
+
+// DeleteRuleTrigger mocks base method.
+func (m *MockruleAlarmer) DeleteRuleTrigger(ctx context.Context, ruleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRuleTrigger", ctx, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRuleTrigger indicates an expected call of DeleteRuleTrigger.
+func (mr *MockruleAlarmerMockRecorder) DeleteRuleTrigger(ctx, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRuleTrigger", reflect.TypeOf((*MockruleAlarmer)(nil).DeleteRuleTrigger), ctx, ruleID)
+}
+
+// GetAlarmDigest mocks base method.
+func (m *MockruleAlarmer) GetAlarmDigest(ctx context.Context, alarmID, ruleID string) (*message.AlarmDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlarmDigest", ctx, alarmID, ruleID)
+	ret0, _ := ret[0].(*message.AlarmDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlarmDigest indicates an expected call of GetAlarmDigest.
+func (mr *MockruleAlarmerMockRecorder) GetAlarmDigest(ctx, alarmID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlarmDigest", reflect.TypeOf((*MockruleAlarmer)(nil).GetAlarmDigest), ctx, alarmID, ruleID)
+}
+
+// GetAlarmEscalation mocks base method.
+func (m *MockruleAlarmer) GetAlarmEscalation(ctx context.Context, alarmID, ruleID string) (*message.AlarmEscalation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlarmEscalation", ctx, alarmID, ruleID)
+	ret0, _ := ret[0].(*message.AlarmEscalation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlarmEscalation indicates an expected call of GetAlarmEscalation.
+func (mr *MockruleAlarmerMockRecorder) GetAlarmEscalation(ctx, alarmID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlarmEscalation", reflect.TypeOf((*MockruleAlarmer)(nil).GetAlarmEscalation), ctx, alarmID, ruleID)
+}
+
+// GetAlarmRecovery mocks base method.
+func (m *MockruleAlarmer) GetAlarmRecovery(ctx context.Context, alarmID, ruleID string) (*message.AlarmRecovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlarmRecovery", ctx, alarmID, ruleID)
+	ret0, _ := ret[0].(*message.AlarmRecovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlarmRecovery indicates an expected call of GetAlarmRecovery.
+func (mr *MockruleAlarmerMockRecorder) GetAlarmRecovery(ctx, alarmID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlarmRecovery", reflect.TypeOf((*MockruleAlarmer)(nil).GetAlarmRecovery), ctx, alarmID, ruleID)
+}
+
+// GetAlarmWebhook mocks base method.
+func (m *MockruleAlarmer) GetAlarmWebhook(ctx context.Context, alarmID, ruleID string) (*message.AlarmWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlarmWebhook", ctx, alarmID, ruleID)
+	ret0, _ := ret[0].(*message.AlarmWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlarmWebhook indicates an expected call of GetAlarmWebhook.
+func (mr *MockruleAlarmerMockRecorder) GetAlarmWebhook(ctx, alarmID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlarmWebhook", reflect.TypeOf((*MockruleAlarmer)(nil).GetAlarmWebhook), ctx, alarmID, ruleID)
+}
+
+// GetRuleCondition mocks base method.
+func (m *MockruleAlarmer) GetRuleCondition(ctx context.Context, ruleID string) (*message.RuleCondition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleCondition", ctx, ruleID)
+	ret0, _ := ret[0].(*message.RuleCondition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleCondition indicates an expected call of GetRuleCondition.
+func (mr *MockruleAlarmerMockRecorder) GetRuleCondition(ctx, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleCondition", reflect.TypeOf((*MockruleAlarmer)(nil).GetRuleCondition), ctx, ruleID)
+}
+
+// GetRuleTrigger mocks base method.
+func (m *MockruleAlarmer) GetRuleTrigger(ctx context.Context, ruleID string) (*message.RuleTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleTrigger", ctx, ruleID)
+	ret0, _ := ret[0].(*message.RuleTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleTrigger indicates an expected call of GetRuleTrigger.
+func (mr *MockruleAlarmerMockRecorder) GetRuleTrigger(ctx, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleTrigger", reflect.TypeOf((*MockruleAlarmer)(nil).GetRuleTrigger), ctx, ruleID)
+}
+
+// TestRuleHistory mocks base method.
+func (m *MockruleAlarmer) TestRuleHistory(ctx context.Context, req *message.TestRuleHistoryRequest) (*api.TestRuleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestRuleHistory", ctx, req)
+	ret0, _ := ret[0].(*api.TestRuleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestRuleHistory indicates an expected call of TestRuleHistory.
+func (mr *MockruleAlarmerMockRecorder) TestRuleHistory(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestRuleHistory", reflect.TypeOf((*MockruleAlarmer)(nil).TestRuleHistory), ctx, req)
+}
+
+// UpdateAlarmDigest mocks base method.
+func (m *MockruleAlarmer) UpdateAlarmDigest(ctx context.Context, alarmID, ruleID string, dig *message.AlarmDigest) (*message.AlarmDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlarmDigest", ctx, alarmID, ruleID, dig)
+	ret0, _ := ret[0].(*message.AlarmDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAlarmDigest indicates an expected call of UpdateAlarmDigest.
+func (mr *MockruleAlarmerMockRecorder) UpdateAlarmDigest(ctx, alarmID, ruleID, dig any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlarmDigest", reflect.TypeOf((*MockruleAlarmer)(nil).UpdateAlarmDigest), ctx, alarmID, ruleID, dig)
+}
+
+// UpdateAlarmEscalation mocks base method.
+func (m *MockruleAlarmer) UpdateAlarmEscalation(ctx context.Context, alarmID, ruleID string, esc *message.AlarmEscalation) (*message.AlarmEscalation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlarmEscalation", ctx, alarmID, ruleID, esc)
+	ret0, _ := ret[0].(*message.AlarmEscalation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAlarmEscalation indicates an expected call of UpdateAlarmEscalation.
+func (mr *MockruleAlarmerMockRecorder) UpdateAlarmEscalation(ctx, alarmID, ruleID, esc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlarmEscalation", reflect.TypeOf((*MockruleAlarmer)(nil).UpdateAlarmEscalation), ctx, alarmID, ruleID, esc)
+}
+
+// UpdateAlarmRecovery mocks base method.
+func (m *MockruleAlarmer) UpdateAlarmRecovery(ctx context.Context, alarmID, ruleID string, rec *message.AlarmRecovery) (*message.AlarmRecovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlarmRecovery", ctx, alarmID, ruleID, rec)
+	ret0, _ := ret[0].(*message.AlarmRecovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAlarmRecovery indicates an expected call of UpdateAlarmRecovery.
+func (mr *MockruleAlarmerMockRecorder) UpdateAlarmRecovery(ctx, alarmID, ruleID, rec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlarmRecovery", reflect.TypeOf((*MockruleAlarmer)(nil).UpdateAlarmRecovery), ctx, alarmID, ruleID, rec)
+}
+
+// UpdateAlarmWebhook mocks base method.
+func (m *MockruleAlarmer) UpdateAlarmWebhook(ctx context.Context, alarmID, ruleID string, hook *message.AlarmWebhook) (*message.AlarmWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlarmWebhook", ctx, alarmID, ruleID, hook)
+	ret0, _ := ret[0].(*message.AlarmWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAlarmWebhook indicates an expected call of UpdateAlarmWebhook.
+func (mr *MockruleAlarmerMockRecorder) UpdateAlarmWebhook(ctx, alarmID, ruleID, hook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlarmWebhook", reflect.TypeOf((*MockruleAlarmer)(nil).UpdateAlarmWebhook), ctx, alarmID, ruleID, hook)
+}
+
+// UpdateRuleCondition mocks base method.
+func (m *MockruleAlarmer) UpdateRuleCondition(ctx context.Context, ruleID string, cond *message.RuleCondition) (*message.RuleCondition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRuleCondition", ctx, ruleID, cond)
+	ret0, _ := ret[0].(*message.RuleCondition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRuleCondition indicates an expected call of UpdateRuleCondition.
+func (mr *MockruleAlarmerMockRecorder) UpdateRuleCondition(ctx, ruleID, cond any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRuleCondition", reflect.TypeOf((*MockruleAlarmer)(nil).UpdateRuleCondition), ctx, ruleID, cond)
+}
+
+// UpdateRuleTrigger mocks base method.
+func (m *MockruleAlarmer) UpdateRuleTrigger(ctx context.Context, ruleID string, trig *message.RuleTrigger) (*message.RuleTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRuleTrigger", ctx, ruleID, trig)
+	ret0, _ := ret[0].(*message.RuleTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRuleTrigger indicates an expected call of UpdateRuleTrigger.
+func (mr *MockruleAlarmerMockRecorder) UpdateRuleTrigger(ctx, ruleID, trig any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRuleTrigger", reflect.TypeOf((*MockruleAlarmer)(nil).UpdateRuleTrigger), ctx, ruleID, trig)
+}
@@ -0,0 +1,220 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: session.go
+//
+// Generated by this command:
+//
+//	mockgen -source session.go -destination mock_sessioner_test.go -package api
+//
+
+// Package api is a generated GoMock package.
+package api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	api "github.com/thingspect/proto/go/api"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mocksessioner is a mock of sessioner interface.
+type Mocksessioner struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionerMockRecorder
+	isgomock struct{}
+}
+
+// MocksessionerMockRecorder is the mock recorder for Mocksessioner.
+type MocksessionerMockRecorder struct {
+	mock *Mocksessioner
+}
+
+// NewMocksessioner creates a new mock instance.
+func NewMocksessioner(ctrl *gomock.Controller) *Mocksessioner {
+	mock := &Mocksessioner{ctrl: ctrl}
+	mock.recorder = &MocksessionerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocksessioner) EXPECT() *MocksessionerMockRecorder {
+	return m.recorder
+}
+
+// ConfirmMFA mocks base method.
+func (m *Mocksessioner) ConfirmMFA(ctx context.Context, req *message.ConfirmMFARequest) (*message.MFARecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmMFA", ctx, req)
+	ret0, _ := ret[0].(*message.MFARecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmMFA indicates an expected call of ConfirmMFA.
+func (mr *MocksessionerMockRecorder) ConfirmMFA(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMFA", reflect.TypeOf((*Mocksessioner)(nil).ConfirmMFA), ctx, req)
+}
+
+// CreateRestrictedKey mocks base method.
+func (m *Mocksessioner) CreateRestrictedKey(ctx context.Context, req *message.CreateRestrictedKeyRequest) (*message.CreateRestrictedKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRestrictedKey", ctx, req)
+	ret0, _ := ret[0].(*message.CreateRestrictedKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRestrictedKey indicates an expected call of CreateRestrictedKey.
+func (mr *MocksessionerMockRecorder) CreateRestrictedKey(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRestrictedKey", reflect.TypeOf((*Mocksessioner)(nil).CreateRestrictedKey), ctx, req)
+}
+
+// EnrollMFA mocks base method.
+func (m *Mocksessioner) EnrollMFA(ctx context.Context, req *message.EnrollMFARequest) (*message.MFAEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMFA", ctx, req)
+	ret0, _ := ret[0].(*message.MFAEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollMFA indicates an expected call of EnrollMFA.
+func (mr *MocksessionerMockRecorder) EnrollMFA(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMFA", reflect.TypeOf((*Mocksessioner)(nil).EnrollMFA), ctx, req)
+}
+
+// GetKeyRestriction mocks base method.
+func (m *Mocksessioner) GetKeyRestriction(ctx context.Context, keyID string) (*message.KeyRestriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyRestriction", ctx, keyID)
+	ret0, _ := ret[0].(*message.KeyRestriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyRestriction indicates an expected call of GetKeyRestriction.
+func (mr *MocksessionerMockRecorder) GetKeyRestriction(ctx, keyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyRestriction", reflect.TypeOf((*Mocksessioner)(nil).GetKeyRestriction), ctx, keyID)
+}
+
+// GetUserMFA mocks base method.
+func (m *Mocksessioner) GetUserMFA(ctx context.Context, userID string) (*message.UserMFA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMFA", ctx, userID)
+	ret0, _ := ret[0].(*message.UserMFA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMFA indicates an expected call of GetUserMFA.
+func (mr *MocksessionerMockRecorder) GetUserMFA(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMFA", reflect.TypeOf((*Mocksessioner)(nil).GetUserMFA), ctx, userID)
+}
+
+// ListUserSessions mocks base method.
+func (m *Mocksessioner) ListUserSessions(ctx context.Context, userID string) (*message.ListUserSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserSessions", ctx, userID)
+	ret0, _ := ret[0].(*message.ListUserSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserSessions indicates an expected call of ListUserSessions.
+func (mr *MocksessionerMockRecorder) ListUserSessions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserSessions", reflect.TypeOf((*Mocksessioner)(nil).ListUserSessions), ctx, userID)
+}
+
+// LoginWithRefresh mocks base method.
+func (m *Mocksessioner) LoginWithRefresh(ctx context.Context, req *api.LoginRequest) (*message.SessionTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginWithRefresh", ctx, req)
+	ret0, _ := ret[0].(*message.SessionTokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginWithRefresh indicates an expected call of LoginWithRefresh.
+func (mr *MocksessionerMockRecorder) LoginWithRefresh(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginWithRefresh", reflect.TypeOf((*Mocksessioner)(nil).LoginWithRefresh), ctx, req)
+}
+
+// Logout mocks base method.
+func (m *Mocksessioner) Logout(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MocksessionerMockRecorder) Logout(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*Mocksessioner)(nil).Logout), ctx)
+}
+
+// RefreshToken mocks base method.
+func (m *Mocksessioner) RefreshToken(ctx context.Context, req *message.RefreshTokenRequest) (*message.SessionTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", ctx, req)
+	ret0, _ := ret[0].(*message.SessionTokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MocksessionerMockRecorder) RefreshToken(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*Mocksessioner)(nil).RefreshToken), ctx, req)
+}
+
+// ResetUserMFA mocks base method.
+func (m *Mocksessioner) ResetUserMFA(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserMFA", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetUserMFA indicates an expected call of ResetUserMFA.
+func (mr *MocksessionerMockRecorder) ResetUserMFA(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserMFA", reflect.TypeOf((*Mocksessioner)(nil).ResetUserMFA), ctx, userID)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *Mocksessioner) RevokeUserSessions(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MocksessionerMockRecorder) RevokeUserSessions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*Mocksessioner)(nil).RevokeUserSessions), ctx, userID)
+}
+
+// VerifyMFA mocks base method.
+func (m *Mocksessioner) VerifyMFA(ctx context.Context, req *message.VerifyMFARequest) (*message.VerifyMFAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFA", ctx, req)
+	ret0, _ := ret[0].(*message.VerifyMFAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MocksessionerMockRecorder) VerifyMFA(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*Mocksessioner)(nil).VerifyMFA), ctx, req)
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: shadow.go
+//
+// Generated by this command:
+//
+//	mockgen -source shadow.go -destination mock_shadower_test.go -package api
+//
+
+// Package api is a generated GoMock package.
+package api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+)
+
+// Mockshadower is a mock of shadower interface.
+type Mockshadower struct {
+	ctrl     *gomock.Controller
+	recorder *MockshadowerMockRecorder
+	isgomock struct{}
+}
+
+// MockshadowerMockRecorder is the mock recorder for Mockshadower.
+type MockshadowerMockRecorder struct {
+	mock *Mockshadower
+}
+
+// NewMockshadower creates a new mock instance.
+func NewMockshadower(ctrl *gomock.Controller) *Mockshadower {
+	mock := &Mockshadower{ctrl: ctrl}
+	mock.recorder = &MockshadowerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockshadower) EXPECT() *MockshadowerMockRecorder {
+	return m.recorder
+}
+
+// DeviceShadow mocks base method.
+func (m *Mockshadower) DeviceShadow(ctx context.Context, uniqID, token string) (*message.Shadow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeviceShadow", ctx, uniqID, token)
+	ret0, _ := ret[0].(*message.Shadow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeviceShadow indicates an expected call of DeviceShadow.
+func (mr *MockshadowerMockRecorder) DeviceShadow(ctx, uniqID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeviceShadow", reflect.TypeOf((*Mockshadower)(nil).DeviceShadow), ctx, uniqID, token)
+}
+
+// GetShadow mocks base method.
+func (m *Mockshadower) GetShadow(ctx context.Context, devID string) (*message.Shadow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShadow", ctx, devID)
+	ret0, _ := ret[0].(*message.Shadow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShadow indicates an expected call of GetShadow.
+func (mr *MockshadowerMockRecorder) GetShadow(ctx, devID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShadow", reflect.TypeOf((*Mockshadower)(nil).GetShadow), ctx, devID)
+}
+
+// UpdateShadowDesired mocks base method.
+func (m *Mockshadower) UpdateShadowDesired(ctx context.Context, devID string, desired *structpb.Struct) (*message.Shadow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShadowDesired", ctx, devID, desired)
+	ret0, _ := ret[0].(*message.Shadow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateShadowDesired indicates an expected call of UpdateShadowDesired.
+func (mr *MockshadowerMockRecorder) UpdateShadowDesired(ctx, devID, desired any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShadowDesired", reflect.TypeOf((*Mockshadower)(nil).UpdateShadowDesired), ctx, devID, desired)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user.go
+//
+// Generated by this command:
+//
+//	mockgen -source user.go -destination mock_userer_test.go -package api
+//
+
+// Package api is a generated GoMock package.
+package api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockuserer is a mock of userer interface.
+type Mockuserer struct {
+	ctrl     *gomock.Controller
+	recorder *MockusererMockRecorder
+	isgomock struct{}
+}
+
+// MockusererMockRecorder is the mock recorder for Mockuserer.
+type MockusererMockRecorder struct {
+	mock *Mockuserer
+}
+
+// NewMockuserer creates a new mock instance.
+func NewMockuserer(ctrl *gomock.Controller) *Mockuserer {
+	mock := &Mockuserer{ctrl: ctrl}
+	mock.recorder = &MockusererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockuserer) EXPECT() *MockusererMockRecorder {
+	return m.recorder
+}
+
+// DeleteUserSchedule mocks base method.
+func (m *Mockuserer) DeleteUserSchedule(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSchedule", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSchedule indicates an expected call of DeleteUserSchedule.
+func (mr *MockusererMockRecorder) DeleteUserSchedule(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSchedule", reflect.TypeOf((*Mockuserer)(nil).DeleteUserSchedule), ctx, userID)
+}
+
+// GetUserSchedule mocks base method.
+func (m *Mockuserer) GetUserSchedule(ctx context.Context, userID string) (*message.NotificationSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSchedule", ctx, userID)
+	ret0, _ := ret[0].(*message.NotificationSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSchedule indicates an expected call of GetUserSchedule.
+func (mr *MockusererMockRecorder) GetUserSchedule(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSchedule", reflect.TypeOf((*Mockuserer)(nil).GetUserSchedule), ctx, userID)
+}
+
+// GetUserScope mocks base method.
+func (m *Mockuserer) GetUserScope(ctx context.Context, userID string) (*message.UserScope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserScope", ctx, userID)
+	ret0, _ := ret[0].(*message.UserScope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserScope indicates an expected call of GetUserScope.
+func (mr *MockusererMockRecorder) GetUserScope(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserScope", reflect.TypeOf((*Mockuserer)(nil).GetUserScope), ctx, userID)
+}
+
+// UpdateUserSchedule mocks base method.
+func (m *Mockuserer) UpdateUserSchedule(ctx context.Context, userID string, sched *message.NotificationSchedule) (*message.NotificationSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserSchedule", ctx, userID, sched)
+	ret0, _ := ret[0].(*message.NotificationSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserSchedule indicates an expected call of UpdateUserSchedule.
+func (mr *MockusererMockRecorder) UpdateUserSchedule(ctx, userID, sched any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSchedule", reflect.TypeOf((*Mockuserer)(nil).UpdateUserSchedule), ctx, userID, sched)
+}
+
+// UpdateUserScope mocks base method.
+func (m *Mockuserer) UpdateUserScope(ctx context.Context, userID string, scope *message.UserScope) (*message.UserScope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserScope", ctx, userID, scope)
+	ret0, _ := ret[0].(*message.UserScope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserScope indicates an expected call of UpdateUserScope.
+func (mr *MockusererMockRecorder) UpdateUserScope(ctx, userID, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserScope", reflect.TypeOf((*Mockuserer)(nil).UpdateUserScope), ctx, userID, scope)
+}
@@ -0,0 +1,113 @@
+package api
+
+//go:generate mockgen -source oidc.go -destination mock_oidcer_test.go -package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// orgOIDCPath is the path of the current organization's OIDC
+	// configuration.
+	orgOIDCPath = "/v1/orgs/oidc"
+
+	// oidcLoginPath is the path used to begin an OIDC login to an
+	// organization.
+	oidcLoginPath = "/v1/sessions/oidc/{orgName}/login"
+
+	// oidcCallbackPath is the path that identity providers redirect to after
+	// authentication. It must match the configured OIDC redirect URI.
+	oidcCallbackPath = "/v1/sessions/oidc/callback"
+)
+
+// oidcer defines the methods provided by a service.OIDC.
+type oidcer interface {
+	GetOrgOIDC(ctx context.Context) (*message.OrgOIDC, error)
+	UpdateOrgOIDC(ctx context.Context, conf *message.OrgOIDC) (
+		*message.OrgOIDC, error)
+	DeleteOrgOIDC(ctx context.Context) error
+	StartOIDCLogin(ctx context.Context, orgName string) (string, error)
+	CompleteOIDCLogin(ctx context.Context, state, code string) (
+		*api.LoginResponse, error)
+}
+
+// oidcRoutes returns the routes of the current organization's OIDC
+// configuration, and the route that completes an OIDC login and returns a
+// session token.
+func oidcRoutes(oidcSvc oidcer) []route {
+	return []route{
+		{
+			http.MethodGet, orgOIDCPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, _ *request) (any, error) {
+				return oidcSvc.GetOrgOIDC(ctx)
+			},
+		},
+		{
+			http.MethodPut, orgOIDCPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				conf := &message.OrgOIDC{}
+				if err := req.decode(conf); err != nil {
+					return nil, err
+				}
+
+				return oidcSvc.UpdateOrgOIDC(ctx, conf)
+			},
+		},
+		{
+			http.MethodDelete, orgOIDCPath, authUnscoped, http.StatusNoContent,
+			func(ctx context.Context, _ *request) (any, error) {
+				return nil, oidcSvc.DeleteOrgOIDC(ctx)
+			},
+		},
+		{
+			http.MethodGet, oidcCallbackPath, authNone, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				// Identity providers report failed authentication by error
+				// parameter.
+				query := req.URL.Query()
+				if query.Get("error") != "" {
+					logger := alog.FromContext(ctx)
+					logger.Infof("oidcRoutes identity provider error, "+
+						"description: %v, %v", query.Get("error"),
+						query.Get("error_description"))
+
+					return nil, status.Error(codes.Unauthenticated,
+						"unauthorized")
+				}
+
+				return oidcSvc.CompleteOIDCLogin(ctx, query.Get("state"),
+					query.Get("code"))
+			},
+		},
+	}
+}
+
+// oidcLoginHandler builds a gRPC-gateway handler that begins an OIDC login
+// and redirects to the organization's identity provider.
+func oidcLoginHandler(
+	gwMux *runtime.ServeMux, oidcSvc oidcer,
+) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request,
+		pathParams map[string]string,
+	) {
+		_, outbound := runtime.MarshalerForRequest(gwMux, r)
+		ctx := unauthContext(r)
+
+		authURL, err := oidcSvc.StartOIDCLogin(ctx, pathParams["orgName"])
+		if err != nil {
+			runtime.HTTPError(ctx, gwMux, outbound, w, r, err)
+
+			return
+		}
+
+		http.Redirect(w, r, authURL, http.StatusFound)
+	}
+}
@@ -0,0 +1,127 @@
+//go:build !integration
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOIDCRoutes(t *testing.T) {
+	t.Parallel()
+
+	key, user, auth := testAuth(t, "api-oidc", api.Role_ADMIN)
+
+	conf := &message.OrgOIDC{
+		OrgId: user.GetOrgId(), Issuer: "https://idp.example.com",
+		ClientId: "api-oidc-client",
+	}
+
+	ctrl := gomock.NewController(t)
+	oidcSvc := NewMockoidcer(ctrl)
+	oidcSvc.EXPECT().GetOrgOIDC(gomock.Any()).Return(conf, nil).Times(1)
+	oidcSvc.EXPECT().UpdateOrgOIDC(gomock.Any(),
+		matcher.NewProtoMatcher(&message.OrgOIDC{
+			Issuer: "https://idp.example.com", ClientId: "api-oidc-client",
+			ClientSecret: "api-oidc-secret",
+		})).Return(conf, nil).Times(1)
+	oidcSvc.EXPECT().DeleteOrgOIDC(gomock.Any()).Return(nil).Times(1)
+	oidcSvc.EXPECT().CompleteOIDCLogin(gomock.Any(), "api-oidc-state",
+		"api-oidc-code").Return(&api.LoginResponse{
+		Token: "api-oidc-token",
+	}, nil).Times(1)
+
+	testRoutes(t, oidcRoutes(oidcSvc), key, []routeTest{
+		{
+			http.MethodGet, orgOIDCPath, "", auth, http.StatusOK,
+			`"api-oidc-client"`,
+		},
+		{
+			http.MethodPut, orgOIDCPath,
+			`{"issuer":"https://idp.example.com",` +
+				`"clientId":"api-oidc-client",` +
+				`"clientSecret":"api-oidc-secret"}`, auth, http.StatusOK,
+			`"api-oidc-client"`,
+		},
+		{http.MethodDelete, orgOIDCPath, "", auth, http.StatusNoContent, ""},
+		{
+			http.MethodGet, "/v1/sessions/oidc/callback?state=api-oidc-state" +
+				"&code=api-oidc-code", "", "", http.StatusOK,
+			`"api-oidc-token"`,
+		},
+		{
+			http.MethodGet, "/v1/sessions/oidc/callback?state=api-oidc-state" +
+				"&error=access_denied", "", "", http.StatusUnauthorized,
+			"unauthorized",
+		},
+	})
+}
+
+func TestOIDCLoginHandler(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	oidcSvc := NewMockoidcer(ctrl)
+	oidcSvc.EXPECT().StartOIDCLogin(gomock.Any(), "api-oidc").
+		Return("https://idp.example.com/authorize", nil).Times(1)
+	oidcSvc.EXPECT().StartOIDCLogin(gomock.Any(), "api-oidc-unknown").
+		Return("", status.Error(codes.Unauthenticated, "unauthorized")).
+		Times(1)
+
+	gwMux := runtime.NewServeMux()
+	require.NoError(t, gwMux.HandlePath(http.MethodGet, oidcLoginPath,
+		oidcLoginHandler(gwMux, oidcSvc)))
+
+	// Close the server after parallel subtests complete.
+	srv := httptest.NewServer(gwMux)
+	t.Cleanup(srv.Close)
+
+	// Do not follow redirects to the identity provider.
+	client := &http.Client{
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	tests := []struct {
+		inpOrgName  string
+		resCode     int
+		resLocation string
+	}{
+		{"api-oidc", http.StatusFound, "https://idp.example.com/authorize"},
+		{"api-oidc-unknown", http.StatusUnauthorized, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can handle %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequestWithContext(t.Context(),
+				http.MethodGet, srv.URL+"/v1/sessions/oidc/"+test.inpOrgName+
+					"/login", nil)
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, resp.Body.Close()) }()
+
+			body, err := io.ReadAll(resp.Body)
+			t.Logf("body, err: %s, %v", body, err)
+			require.NoError(t, err)
+			require.Equal(t, test.resCode, resp.StatusCode)
+			require.Equal(t, test.resLocation, resp.Header.Get("Location"))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+//go:generate mockgen -source org.go -destination mock_orger_test.go -package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thingspect/atlas/proto/go/message"
+)
+
+// Constants used for org paths.
+const (
+	orgDeletionPath  = "/v1/orgs/{id}/deletion"
+	orgMFAPolicyPath = "/v1/orgs/mfa"
+	orgRetentionPath = "/v1/orgs/retention"
+)
+
+// orger defines the methods provided by a service.Org that are not part of the
+// gRPC API.
+type orger interface {
+	GetOrgDeletion(ctx context.Context, orgID string) (*message.OrgDeletion,
+		error)
+	GetOrgMFAPolicy(ctx context.Context) (*message.OrgMFAPolicy, error)
+	UpdateOrgMFAPolicy(ctx context.Context, policy *message.OrgMFAPolicy) (
+		*message.OrgMFAPolicy, error)
+	GetOrgRetention(ctx context.Context) (*message.OrgRetention, error)
+	UpdateOrgRetention(ctx context.Context, ret *message.OrgRetention) (
+		*message.OrgRetention, error)
+}
+
+// orgRoutes returns the routes of organizations that are not part of the gRPC
+// API.
+func orgRoutes(orgSvc orger) []route {
+	return []route{
+		{
+			http.MethodGet, orgDeletionPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return orgSvc.GetOrgDeletion(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodGet, orgMFAPolicyPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, _ *request) (any, error) {
+				return orgSvc.GetOrgMFAPolicy(ctx)
+			},
+		},
+		{
+			http.MethodPut, orgMFAPolicyPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				policy := &message.OrgMFAPolicy{}
+				if err := req.decode(policy); err != nil {
+					return nil, err
+				}
+
+				return orgSvc.UpdateOrgMFAPolicy(ctx, policy)
+			},
+		},
+		{
+			http.MethodGet, orgRetentionPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, _ *request) (any, error) {
+				return orgSvc.GetOrgRetention(ctx)
+			},
+		},
+		{
+			http.MethodPut, orgRetentionPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				ret := &message.OrgRetention{}
+				if err := req.decode(ret); err != nil {
+					return nil, err
+				}
+
+				return orgSvc.UpdateOrgRetention(ctx, ret)
+			},
+		},
+	}
+}
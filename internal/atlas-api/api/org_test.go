@@ -0,0 +1,63 @@
+//go:build !integration
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"uuid"
+
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrgRoutes(t *testing.T) {
+	t.Parallel()
+
+	key, user, auth := testAuth(t, "api-org", api.Role_SYS_ADMIN)
+
+	orgID := uuid.NewV7().String()
+	del := &message.OrgDeletion{
+		OrgId: orgID, Step: "data_points", PurgedRows: 5,
+	}
+	policy := &message.OrgMFAPolicy{
+		OrgId: user.GetOrgId(), MinRole: api.Role_ADMIN,
+	}
+	ret := &message.OrgRetention{OrgId: user.GetOrgId(), DataPointDays: 30}
+
+	ctrl := gomock.NewController(t)
+	orgSvc := NewMockorger(ctrl)
+	orgSvc.EXPECT().GetOrgDeletion(gomock.Any(), orgID).Return(del, nil).
+		Times(1)
+	orgSvc.EXPECT().GetOrgMFAPolicy(gomock.Any()).Return(policy, nil).Times(1)
+	orgSvc.EXPECT().UpdateOrgMFAPolicy(gomock.Any(),
+		matcher.NewProtoMatcher(&message.OrgMFAPolicy{
+			MinRole: api.Role_ADMIN,
+		})).Return(policy, nil).Times(1)
+	orgSvc.EXPECT().GetOrgRetention(gomock.Any()).Return(ret, nil).Times(1)
+	orgSvc.EXPECT().UpdateOrgRetention(gomock.Any(),
+		matcher.NewProtoMatcher(&message.OrgRetention{DataPointDays: 30})).
+		Return(ret, nil).Times(1)
+
+	testRoutes(t, orgRoutes(orgSvc), key, []routeTest{
+		{
+			http.MethodGet, "/v1/orgs/" + orgID + "/deletion", "", auth,
+			http.StatusOK, `"data_points"`,
+		},
+		{http.MethodGet, orgMFAPolicyPath, "", auth, http.StatusOK, `"ADMIN"`},
+		{
+			http.MethodPut, orgMFAPolicyPath, `{"minRole":"ADMIN"}`, auth,
+			http.StatusOK, `"ADMIN"`,
+		},
+		{
+			http.MethodGet, orgRetentionPath, "", auth, http.StatusOK,
+			`"dataPointDays":30`,
+		},
+		{
+			http.MethodPut, orgRetentionPath, `{"dataPointDays":30}`, auth,
+			http.StatusOK, `"dataPointDays":30`,
+		},
+	})
+}
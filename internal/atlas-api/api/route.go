@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/thingspect/atlas/pkg/cache"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// authMode is the authentication applied to a route's requests.
+type authMode int
+
+// Authentication modes of routes.
+const (
+	// authUnscoped authenticates by authContext, rejecting tag scoped
+	// sessions.
+	authUnscoped authMode = iota
+	// authScoped authenticates by scopedAuthContext, for services that enforce
+	// scope tags or do not access devices.
+	authScoped
+	// authOptional authenticates by scopedAuthContext only if an Authorization
+	// header is present.
+	authOptional
+	// authNone does not authenticate.
+	authNone
+)
+
+// request is the HTTP request provided to a route's call.
+type request struct {
+	*http.Request
+
+	pathParams map[string]string
+	inbound    runtime.Marshaler
+}
+
+// decode decodes the request body into msg.
+func (req *request) decode(msg proto.Message) error {
+	if err := req.inbound.NewDecoder(req.Body).Decode(msg); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
+
+// route is a custom HTTP route, served by jsonHandler, for methods that are
+// not part of the gRPC API. A route's call returns its response, or nil for
+// routes that respond without a body.
+type route struct {
+	method string
+	path   string
+	auth   authMode
+	code   int
+	call   func(ctx context.Context, req *request) (any, error)
+}
+
+// jsonHandler builds a gRPC-gateway handler that authenticates a request as
+// required by its route, calls the route, and writes the response with the
+// route's status code.
+func jsonHandler(
+	gwMux *runtime.ServeMux, rt route, pwtKey []byte, c cache.Cacher[string],
+) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request,
+		pathParams map[string]string,
+	) {
+		inbound, outbound := runtime.MarshalerForRequest(gwMux, r)
+
+		var ctx context.Context
+		var err error
+		switch {
+		case rt.auth == authUnscoped:
+			ctx, err = authContext(r, pwtKey, c)
+		case rt.auth == authScoped, rt.auth == authOptional &&
+			r.Header.Get("Authorization") != "":
+			ctx, err = scopedAuthContext(r, pwtKey, c)
+		default:
+			ctx = unauthContext(r)
+		}
+		if err != nil {
+			runtime.HTTPError(r.Context(), gwMux, outbound, w, r, err)
+
+			return
+		}
+
+		resp, err := rt.call(ctx, &request{
+			Request: r, pathParams: pathParams, inbound: inbound,
+		})
+		if err != nil {
+			runtime.HTTPError(ctx, gwMux, outbound, w, r, err)
+
+			return
+		}
+
+		if resp == nil {
+			w.WriteHeader(rt.code)
+
+			return
+		}
+
+		writeResponse(ctx, gwMux, outbound, w, r, rt.code, resp)
+	}
+}
+
+// registerRoutes registers routes with a gRPC-gateway mux.
+func registerRoutes(
+	gwMux *runtime.ServeMux, routes []route, pwtKey []byte,
+	c cache.Cacher[string],
+) error {
+	for _, rt := range routes {
+		if err := gwMux.HandlePath(rt.method, rt.path, jsonHandler(gwMux, rt,
+			pwtKey, c)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,307 @@
+//go:build !integration
+
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"uuid"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/interceptor"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// routeTest is a request to a route and its expected response. The inpAuth
+// field is the full Authorization header, if present.
+type routeTest struct {
+	inpMethod string
+	inpPath   string
+	inpBody   string
+	inpAuth   string
+	resCode   int
+	resBody   string
+}
+
+// testRoutes serves routes and runs each test against them as a parallel
+// subtest.
+func testRoutes(
+	t *testing.T, routes []route, pwtKey []byte, tests []routeTest,
+) {
+	t.Helper()
+
+	gwMux := runtime.NewServeMux()
+	require.NoError(t, registerRoutes(gwMux, routes, pwtKey,
+		cache.NewHeap[string]()))
+
+	// Close the server after parallel subtests complete.
+	srv := httptest.NewServer(gwMux)
+	t.Cleanup(srv.Close)
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can route %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequestWithContext(t.Context(), test.inpMethod,
+				srv.URL+test.inpPath, strings.NewReader(test.inpBody))
+			require.NoError(t, err)
+			if test.inpAuth != "" {
+				req.Header.Set("Authorization", test.inpAuth)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, resp.Body.Close()) }()
+
+			body, err := io.ReadAll(resp.Body)
+			t.Logf("body, err: %s, %v", body, err)
+			require.NoError(t, err)
+			require.Equal(t, test.resCode, resp.StatusCode)
+			require.Contains(t, string(body), test.resBody)
+		})
+	}
+}
+
+// testAuth generates a web token key and 'Authorization' header for a random
+// user with a role.
+func testAuth(
+	t *testing.T, prefix string, role api.Role,
+) ([]byte, *api.User, string) {
+	t.Helper()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	user := random.User(prefix, uuid.NewV7().String())
+	user.Role = role
+	token, _, err := session.GenerateWebToken(key, user)
+	require.NoError(t, err)
+
+	return key, user, "Bearer " + token
+}
+
+func TestJSONHandler(t *testing.T) {
+	t.Parallel()
+
+	key, user, auth := testAuth(t, "api-route", api.Role_ADMIN)
+
+	tagToken, _, err := session.GenerateSessionToken(key, user,
+		uuid.NewV7().String(), []string{"site"})
+	require.NoError(t, err)
+	tagAuth := "Bearer " + tagToken
+
+	keyToken, err := session.GenerateRestrictedKeyToken(key, api.Role_ADMIN,
+		&message.KeyRestriction{
+			KeyId: uuid.NewV7().String(), OrgId: user.GetOrgId(),
+			Scopes: []string{"thingspect.api.OrgService"},
+		})
+	require.NoError(t, err)
+	keyAuth := "Bearer " + keyToken
+
+	// echo responds with the path ID and the organization ID of the session,
+	// if authenticated.
+	echo := func(ctx context.Context, req *request) (any, error) {
+		scope := &message.UserScope{UserId: req.pathParams["id"]}
+		if sess, ok := session.FromContext(ctx); ok {
+			scope.OrgId = sess.OrgID
+		}
+
+		return scope, nil
+	}
+
+	routes := []route{
+		{http.MethodGet, "/v1/test/unscoped/{id}", authUnscoped, http.StatusOK,
+			echo},
+		{http.MethodGet, "/v1/test/scoped/{id}", authScoped, http.StatusOK,
+			echo},
+		{http.MethodGet, "/v1/test/optional/{id}", authOptional,
+			http.StatusCreated, echo},
+		{http.MethodGet, "/v1/test/none/{id}", authNone, http.StatusOK, echo},
+		{
+			http.MethodPut, "/v1/test/decode", authNone, http.StatusOK,
+			func(_ context.Context, req *request) (any, error) {
+				scope := &message.UserScope{}
+				if err := req.decode(scope); err != nil {
+					return nil, err
+				}
+
+				return scope, nil
+			},
+		},
+		{
+			http.MethodDelete, "/v1/test/{id}", authNone, http.StatusNoContent,
+			func(_ context.Context, req *request) (any, error) {
+				if req.pathParams["id"] == "perm" {
+					return nil, status.Error(codes.PermissionDenied,
+						"permission denied")
+				}
+
+				return nil, nil
+			},
+		},
+	}
+
+	orgBody := `"orgId":"` + user.GetOrgId() + `"`
+
+	testRoutes(t, routes, key, []routeTest{
+		{
+			http.MethodGet, "/v1/test/unscoped/route-id", "", auth,
+			http.StatusOK, orgBody,
+		},
+		{
+			http.MethodGet, "/v1/test/unscoped/route-id", "", tagAuth,
+			http.StatusForbidden, errTagScoped,
+		},
+		{
+			http.MethodGet, "/v1/test/unscoped/route-id", "", keyAuth,
+			http.StatusForbidden, interceptor.ErrScope,
+		},
+		{
+			http.MethodGet, "/v1/test/unscoped/route-id", "", "",
+			http.StatusUnauthorized, "unauthorized",
+		},
+		{
+			http.MethodGet, "/v1/test/scoped/route-id", "", auth, http.StatusOK,
+			`"userId":"route-id"`,
+		},
+		{
+			http.MethodGet, "/v1/test/scoped/route-id", "", tagAuth,
+			http.StatusOK, orgBody,
+		},
+		{
+			http.MethodGet, "/v1/test/scoped/route-id", "", keyAuth,
+			http.StatusForbidden, interceptor.ErrScope,
+		},
+		{
+			http.MethodGet, "/v1/test/scoped/route-id", "", "",
+			http.StatusUnauthorized, "unauthorized",
+		},
+		{
+			http.MethodGet, "/v1/test/optional/route-id", "", tagAuth,
+			http.StatusCreated, orgBody,
+		},
+		{
+			http.MethodGet, "/v1/test/optional/route-id", "", "",
+			http.StatusCreated, `"orgId":""`,
+		},
+		{
+			http.MethodGet, "/v1/test/optional/route-id", "", "Bearer bad",
+			http.StatusUnauthorized, "unauthorized",
+		},
+		{
+			http.MethodGet, "/v1/test/none/route-id", "", auth, http.StatusOK,
+			`"orgId":""`,
+		},
+		{
+			http.MethodPut, "/v1/test/decode", `{"tags":["route-tag"]}`, "",
+			http.StatusOK, `"tags":["route-tag"]`,
+		},
+		{
+			http.MethodPut, "/v1/test/decode", "{", "", http.StatusBadRequest,
+			"unexpected EOF",
+		},
+		{
+			http.MethodDelete, "/v1/test/route-id", "", "",
+			http.StatusNoContent, "",
+		},
+		{
+			http.MethodDelete, "/v1/test/perm", "", "", http.StatusForbidden,
+			"permission denied",
+		},
+	})
+}
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Parallel()
+
+	routes := allRoutes(t)
+
+	t.Run("Register routes", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, registerRoutes(runtime.NewServeMux(), routes, nil,
+			nil))
+	})
+
+	t.Run("Routes are unique", func(t *testing.T) {
+		t.Parallel()
+
+		seen := map[string]struct{}{}
+		for _, rt := range routes {
+			methodPath := rt.method + " " + rt.path
+			require.NotContains(t, seen, methodPath)
+			seen[methodPath] = struct{}{}
+		}
+	})
+
+	t.Run("Tag scoped routes are reviewed", func(t *testing.T) {
+		t.Parallel()
+
+		// Tag scoped sessions may only call routes that filter by scope tags,
+		// or that do not access devices of other scopes.
+		scoped := map[string]struct{}{
+			"GET " + commandsPath:         {},
+			"POST " + commandsPath:        {},
+			"GET " + shadowPath:           {},
+			"PUT " + shadowDesiredPath:    {},
+			"GET " + connectivityPath:     {},
+			"GET " + connIntervalsPath:    {},
+			"PUT " + connIntervalsPath:    {},
+			"DELETE " + connIntervalPath:  {},
+			"GET " + userSchedulePath:     {},
+			"PUT " + userSchedulePath:     {},
+			"DELETE " + userSchedulePath:  {},
+			"POST " + testRuleHistoryPath: {},
+			"POST " + logoutPath:          {},
+			"GET " + userSessionsPath:     {},
+			"DELETE " + userSessionsPath:  {},
+			"POST " + enrollMFAPath:       {},
+			"POST " + confirmMFAPath:      {},
+			"GET " + userMFAPath:          {},
+			"DELETE " + userMFAPath:       {},
+		}
+
+		for _, rt := range routes {
+			if rt.auth == authScoped || rt.auth == authOptional {
+				require.Contains(t, scoped, rt.method+" "+rt.path)
+			}
+		}
+	})
+}
+
+// allRoutes returns the routes registered by New, with mock services.
+func allRoutes(t *testing.T) []route {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	return slices.Concat(
+		commandRoutes(NewMockcommander(ctrl)),
+		shadowRoutes(NewMockshadower(ctrl)),
+		connectivityRoutes(NewMockconnectivityer(ctrl)),
+		ruleAlarmRoutes(NewMockruleAlarmer(ctrl)),
+		alertRoutes(NewMockalerter(ctrl)),
+		sessionRoutes(NewMocksessioner(ctrl)),
+		passwordResetRoutes(NewMockpasswordResetter(ctrl)),
+		userLockoutRoutes(NewMocklockouter(ctrl)),
+		userRoutes(NewMockuserer(ctrl)),
+		orgRoutes(NewMockorger(ctrl)),
+		oidcRoutes(NewMockoidcer(ctrl)),
+	)
+}
@@ -0,0 +1,235 @@
+package api
+
+//go:generate mockgen -source rule_alarm.go -destination mock_rulealarmer_test.go -package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+// Constants used for rule and alarm paths.
+const (
+	ruleConditionPath   = "/v1/rules/{id}/condition"
+	ruleTriggerPath     = "/v1/rules/{id}/trigger"
+	testRuleHistoryPath = "/v1/rules/test/history"
+	alarmRecoveryPath   = "/v1/rules/{ruleId}/alarms/{id}/recovery"
+	alarmEscalationPath = "/v1/rules/{ruleId}/alarms/{id}/escalation"
+	alarmWebhookPath    = "/v1/rules/{ruleId}/alarms/{id}/webhook"
+	alarmDigestPath     = "/v1/rules/{ruleId}/alarms/{id}/digest"
+)
+
+// ruleAlarmer defines the methods provided by a service.RuleAlarm that are
+// not part of the gRPC API.
+type ruleAlarmer interface {
+	GetRuleCondition(ctx context.Context, ruleID string) (
+		*message.RuleCondition, error)
+	UpdateRuleCondition(ctx context.Context, ruleID string,
+		cond *message.RuleCondition) (*message.RuleCondition, error)
+	DeleteRuleCondition(ctx context.Context, ruleID string) error
+	GetRuleTrigger(ctx context.Context, ruleID string) (
+		*message.RuleTrigger, error)
+	UpdateRuleTrigger(ctx context.Context, ruleID string,
+		trig *message.RuleTrigger) (*message.RuleTrigger, error)
+	DeleteRuleTrigger(ctx context.Context, ruleID string) error
+	TestRuleHistory(ctx context.Context, req *message.TestRuleHistoryRequest) (
+		*api.TestRuleResponse, error)
+	GetAlarmRecovery(ctx context.Context, alarmID, ruleID string) (
+		*message.AlarmRecovery, error)
+	UpdateAlarmRecovery(ctx context.Context, alarmID, ruleID string,
+		rec *message.AlarmRecovery) (*message.AlarmRecovery, error)
+	DeleteAlarmRecovery(ctx context.Context, alarmID, ruleID string) error
+	GetAlarmEscalation(ctx context.Context, alarmID, ruleID string) (
+		*message.AlarmEscalation, error)
+	UpdateAlarmEscalation(ctx context.Context, alarmID, ruleID string,
+		esc *message.AlarmEscalation) (*message.AlarmEscalation, error)
+	DeleteAlarmEscalation(ctx context.Context, alarmID, ruleID string) error
+	GetAlarmWebhook(ctx context.Context, alarmID, ruleID string) (
+		*message.AlarmWebhook, error)
+	UpdateAlarmWebhook(ctx context.Context, alarmID, ruleID string,
+		hook *message.AlarmWebhook) (*message.AlarmWebhook, error)
+	DeleteAlarmWebhook(ctx context.Context, alarmID, ruleID string) error
+	GetAlarmDigest(ctx context.Context, alarmID, ruleID string) (
+		*message.AlarmDigest, error)
+	UpdateAlarmDigest(ctx context.Context, alarmID, ruleID string,
+		dig *message.AlarmDigest) (*message.AlarmDigest, error)
+	DeleteAlarmDigest(ctx context.Context, alarmID, ruleID string) error
+}
+
+// ruleAlarmRoutes returns the routes of rules and alarms that are not part of
+// the gRPC API.
+// Alarm paths identify the alarm by id and its rule by ruleId.
+func ruleAlarmRoutes(raSvc ruleAlarmer) []route {
+	return []route{
+		{
+			http.MethodGet, ruleConditionPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return raSvc.GetRuleCondition(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodPut, ruleConditionPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				cond := &message.RuleCondition{}
+				if err := req.decode(cond); err != nil {
+					return nil, err
+				}
+
+				return raSvc.UpdateRuleCondition(ctx, req.pathParams["id"],
+					cond)
+			},
+		},
+		{
+			http.MethodDelete, ruleConditionPath, authUnscoped,
+			http.StatusNoContent,
+			func(ctx context.Context, req *request) (any, error) {
+				return nil, raSvc.DeleteRuleCondition(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodGet, ruleTriggerPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return raSvc.GetRuleTrigger(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodPut, ruleTriggerPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				trig := &message.RuleTrigger{}
+				if err := req.decode(trig); err != nil {
+					return nil, err
+				}
+
+				return raSvc.UpdateRuleTrigger(ctx, req.pathParams["id"], trig)
+			},
+		},
+		{
+			http.MethodDelete, ruleTriggerPath, authUnscoped,
+			http.StatusNoContent,
+			func(ctx context.Context, req *request) (any, error) {
+				return nil, raSvc.DeleteRuleTrigger(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodPost, testRuleHistoryPath, authScoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				testReq := &message.TestRuleHistoryRequest{}
+				if err := req.decode(testReq); err != nil {
+					return nil, err
+				}
+
+				return raSvc.TestRuleHistory(ctx, testReq)
+			},
+		},
+		{
+			http.MethodGet, alarmRecoveryPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return raSvc.GetAlarmRecovery(ctx, req.pathParams["id"],
+					req.pathParams["ruleId"])
+			},
+		},
+		{
+			http.MethodPut, alarmRecoveryPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				rec := &message.AlarmRecovery{}
+				if err := req.decode(rec); err != nil {
+					return nil, err
+				}
+
+				return raSvc.UpdateAlarmRecovery(ctx, req.pathParams["id"],
+					req.pathParams["ruleId"], rec)
+			},
+		},
+		{
+			http.MethodDelete, alarmRecoveryPath, authUnscoped,
+			http.StatusNoContent,
+			func(ctx context.Context, req *request) (any, error) {
+				return nil, raSvc.DeleteAlarmRecovery(ctx, req.pathParams["id"],
+					req.pathParams["ruleId"])
+			},
+		},
+		{
+			http.MethodGet, alarmEscalationPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return raSvc.GetAlarmEscalation(ctx, req.pathParams["id"],
+					req.pathParams["ruleId"])
+			},
+		},
+		{
+			http.MethodPut, alarmEscalationPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				esc := &message.AlarmEscalation{}
+				if err := req.decode(esc); err != nil {
+					return nil, err
+				}
+
+				return raSvc.UpdateAlarmEscalation(ctx, req.pathParams["id"],
+					req.pathParams["ruleId"], esc)
+			},
+		},
+		{
+			http.MethodDelete, alarmEscalationPath, authUnscoped,
+			http.StatusNoContent,
+			func(ctx context.Context, req *request) (any, error) {
+				return nil, raSvc.DeleteAlarmEscalation(ctx,
+					req.pathParams["id"], req.pathParams["ruleId"])
+			},
+		},
+		{
+			http.MethodGet, alarmWebhookPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return raSvc.GetAlarmWebhook(ctx, req.pathParams["id"],
+					req.pathParams["ruleId"])
+			},
+		},
+		{
+			http.MethodPut, alarmWebhookPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				hook := &message.AlarmWebhook{}
+				if err := req.decode(hook); err != nil {
+					return nil, err
+				}
+
+				return raSvc.UpdateAlarmWebhook(ctx, req.pathParams["id"],
+					req.pathParams["ruleId"], hook)
+			},
+		},
+		{
+			http.MethodDelete, alarmWebhookPath, authUnscoped,
+			http.StatusNoContent,
+			func(ctx context.Context, req *request) (any, error) {
+				return nil, raSvc.DeleteAlarmWebhook(ctx, req.pathParams["id"],
+					req.pathParams["ruleId"])
+			},
+		},
+		{
+			http.MethodGet, alarmDigestPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return raSvc.GetAlarmDigest(ctx, req.pathParams["id"],
+					req.pathParams["ruleId"])
+			},
+		},
+		{
+			http.MethodPut, alarmDigestPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				dig := &message.AlarmDigest{}
+				if err := req.decode(dig); err != nil {
+					return nil, err
+				}
+
+				return raSvc.UpdateAlarmDigest(ctx, req.pathParams["id"],
+					req.pathParams["ruleId"], dig)
+			},
+		},
+		{
+			http.MethodDelete, alarmDigestPath, authUnscoped,
+			http.StatusNoContent,
+			func(ctx context.Context, req *request) (any, error) {
+				return nil, raSvc.DeleteAlarmDigest(ctx, req.pathParams["id"],
+					req.pathParams["ruleId"])
+			},
+		},
+	}
+}
@@ -0,0 +1,223 @@
+//go:build !integration
+
+package api
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestRuleAlarmRoutes(t *testing.T) {
+	t.Parallel()
+
+	key, user, auth := testAuth(t, "api-rule-alarm", api.Role_BUILDER)
+
+	ruleID := uuid.NewV7().String()
+	rulePath := "/v1/rules/" + ruleID
+	alarmID := uuid.NewV7().String()
+	alarmPath := rulePath + "/alarms/" + alarmID
+
+	ctrl := gomock.NewController(t)
+	raSvc := NewMockruleAlarmer(ctrl)
+
+	cond := &message.RuleCondition{
+		RuleId: ruleID, OrgId: user.GetOrgId(), ForSamples: 3,
+		ForDuration: durationpb.New(5 * time.Minute),
+	}
+	raSvc.EXPECT().GetRuleCondition(gomock.Any(), ruleID).Return(cond, nil).
+		Times(1)
+	raSvc.EXPECT().UpdateRuleCondition(gomock.Any(), ruleID, gomock.Cond(
+		func(inp *message.RuleCondition) bool {
+			return inp.GetForSamples() == 3 &&
+				inp.GetForDuration().AsDuration() == 5*time.Minute
+		})).Return(cond, nil).Times(1)
+	raSvc.EXPECT().DeleteRuleCondition(gomock.Any(), ruleID).Return(nil).
+		Times(1)
+
+	trig := &message.RuleTrigger{
+		RuleId: ruleID, OrgId: user.GetOrgId(),
+		Attrs: []string{"api-rule-alarm-door", "api-rule-alarm-temp"},
+	}
+	raSvc.EXPECT().GetRuleTrigger(gomock.Any(), ruleID).Return(trig, nil).
+		Times(1)
+	raSvc.EXPECT().UpdateRuleTrigger(gomock.Any(), ruleID, gomock.Cond(
+		func(inp *message.RuleTrigger) bool {
+			return slices.Equal(inp.GetAttrs(), trig.GetAttrs())
+		})).Return(trig, nil).Times(1)
+	raSvc.EXPECT().DeleteRuleTrigger(gomock.Any(), ruleID).Return(nil).
+		Times(1)
+
+	raSvc.EXPECT().TestRuleHistory(gomock.Any(), gomock.Cond(
+		func(inp *message.TestRuleHistoryRequest) bool {
+			return inp.GetRule().GetExpr() == `delta() > 5` &&
+				len(inp.GetHistory()) == 2 &&
+				inp.GetLatest()["api-rule-alarm-door"].GetBoolVal()
+		})).Return(&api.TestRuleResponse{Result: true}, nil).Times(1)
+	raSvc.EXPECT().TestRuleHistory(gomock.Any(), gomock.Cond(
+		func(inp *message.TestRuleHistoryRequest) bool {
+			return inp.GetRule().GetExpr() == `1 + "aaa"`
+		})).Return(nil, status.Error(codes.InvalidArgument,
+		"invalid operation")).Times(1)
+
+	rec := &message.AlarmRecovery{
+		AlarmId: alarmID, OrgId: user.GetOrgId(), RuleId: ruleID,
+		SubjectTemplate: "api-rule-alarm",
+	}
+	raSvc.EXPECT().GetAlarmRecovery(gomock.Any(), alarmID, ruleID).
+		Return(rec, nil).Times(1)
+	raSvc.EXPECT().UpdateAlarmRecovery(gomock.Any(), alarmID, ruleID,
+		gomock.Cond(func(inp *message.AlarmRecovery) bool {
+			return inp.GetSubjectTemplate() == "api-rule-alarm"
+		})).Return(rec, nil).Times(1)
+	raSvc.EXPECT().DeleteAlarmRecovery(gomock.Any(), alarmID, ruleID).
+		Return(nil).Times(1)
+
+	esc := &message.AlarmEscalation{
+		AlarmId: alarmID, OrgId: user.GetOrgId(), RuleId: ruleID,
+		Steps: []*message.EscalationStep{
+			{DelayMinutes: 5, UserTags: []string{"api-rule-alarm"}},
+		},
+	}
+	raSvc.EXPECT().GetAlarmEscalation(gomock.Any(), alarmID, ruleID).
+		Return(esc, nil).Times(1)
+	raSvc.EXPECT().UpdateAlarmEscalation(gomock.Any(), alarmID, ruleID,
+		gomock.Cond(func(inp *message.AlarmEscalation) bool {
+			return len(inp.GetSteps()) == 1 &&
+				inp.GetSteps()[0].GetDelayMinutes() == 5
+		})).Return(esc, nil).Times(1)
+	raSvc.EXPECT().DeleteAlarmEscalation(gomock.Any(), alarmID, ruleID).
+		Return(nil).Times(1)
+
+	hook := &message.AlarmWebhook{
+		AlarmId: alarmID, OrgId: user.GetOrgId(), RuleId: ruleID,
+		Url: "https://api-rule-alarm.example.com/hook", Secret: "api-secret",
+	}
+	raSvc.EXPECT().GetAlarmWebhook(gomock.Any(), alarmID, ruleID).
+		Return(&message.AlarmWebhook{
+			AlarmId: alarmID, OrgId: user.GetOrgId(), RuleId: ruleID,
+			Url: hook.GetUrl(),
+		}, nil).Times(1)
+	raSvc.EXPECT().UpdateAlarmWebhook(gomock.Any(), alarmID, ruleID,
+		gomock.Cond(func(inp *message.AlarmWebhook) bool {
+			return inp.GetUrl() == hook.GetUrl()
+		})).Return(hook, nil).Times(1)
+	raSvc.EXPECT().DeleteAlarmWebhook(gomock.Any(), alarmID, ruleID).
+		Return(nil).Times(1)
+
+	dig := &message.AlarmDigest{
+		AlarmId: alarmID, OrgId: user.GetOrgId(), RuleId: ruleID,
+		WindowMinutes: 15,
+	}
+	raSvc.EXPECT().GetAlarmDigest(gomock.Any(), alarmID, ruleID).
+		Return(dig, nil).Times(1)
+	raSvc.EXPECT().UpdateAlarmDigest(gomock.Any(), alarmID, ruleID,
+		gomock.Cond(func(inp *message.AlarmDigest) bool {
+			return inp.GetWindowMinutes() == 30
+		})).Return(dig, nil).Times(1)
+	raSvc.EXPECT().DeleteAlarmDigest(gomock.Any(), alarmID, ruleID).
+		Return(nil).Times(1)
+
+	testRoutes(t, ruleAlarmRoutes(raSvc), key, []routeTest{
+		{
+			http.MethodGet, rulePath + "/condition", "", auth, http.StatusOK,
+			`"forSamples":3`,
+		},
+		{
+			http.MethodPut, rulePath + "/condition",
+			`{"forSamples": 3, "forDuration": "300s"}`, auth, http.StatusOK,
+			`"forDuration":"300s"`,
+		},
+		{
+			http.MethodDelete, rulePath + "/condition", "", auth,
+			http.StatusNoContent, "",
+		},
+		{
+			http.MethodGet, rulePath + "/trigger", "", auth, http.StatusOK,
+			`"api-rule-alarm-temp"`,
+		},
+		{
+			http.MethodPut, rulePath + "/trigger",
+			`{"attrs": ["api-rule-alarm-door", "api-rule-alarm-temp"]}`, auth,
+			http.StatusOK, `"ruleId":"` + ruleID + `"`,
+		},
+		{
+			http.MethodDelete, rulePath + "/trigger", "", auth,
+			http.StatusNoContent, "",
+		},
+		{
+			http.MethodPost, testRuleHistoryPath,
+			`{"rule": {"attr": "api-rule-alarm-temp", ` +
+				`"expr": "delta() > 5"}, ` +
+				`"point": {"attr": "api-rule-alarm-temp", "intVal": 30}, ` +
+				`"history": [{"intVal": 20}, {"intVal": 10}], ` +
+				`"latest": {"api-rule-alarm-door": {"boolVal": true}}}`, auth,
+			http.StatusOK, `"result":true`,
+		},
+		{
+			http.MethodPost, testRuleHistoryPath,
+			`{"rule": {"expr": "1 + \"aaa\""}}`, auth, http.StatusBadRequest,
+			"invalid operation",
+		},
+		{
+			http.MethodGet, alarmPath + "/recovery", "", auth, http.StatusOK,
+			`"subjectTemplate":"api-rule-alarm"`,
+		},
+		{
+			http.MethodPut, alarmPath + "/recovery",
+			`{"subjectTemplate": "api-rule-alarm"}`, auth, http.StatusOK,
+			`"alarmId":"` + alarmID + `"`,
+		},
+		{
+			http.MethodDelete, alarmPath + "/recovery", "", auth,
+			http.StatusNoContent, "",
+		},
+		{
+			http.MethodGet, alarmPath + "/escalation", "", auth, http.StatusOK,
+			`"delayMinutes":5`,
+		},
+		{
+			http.MethodPut, alarmPath + "/escalation",
+			`{"steps": [{"delayMinutes": 5, "userTags": ["api-rule-alarm"]}]}`,
+			auth, http.StatusOK, `"alarmId":"` + alarmID + `"`,
+		},
+		{
+			http.MethodDelete, alarmPath + "/escalation", "", auth,
+			http.StatusNoContent, "",
+		},
+		{
+			http.MethodGet, alarmPath + "/webhook", "", auth, http.StatusOK,
+			`"url":"https://api-rule-alarm.example.com/hook"`,
+		},
+		{
+			http.MethodPut, alarmPath + "/webhook",
+			`{"url": "https://api-rule-alarm.example.com/hook"}`, auth,
+			http.StatusOK, `"secret":"api-secret"`,
+		},
+		{
+			http.MethodDelete, alarmPath + "/webhook", "", auth,
+			http.StatusNoContent, "",
+		},
+		{
+			http.MethodGet, alarmPath + "/digest", "", auth, http.StatusOK,
+			`"windowMinutes":15`,
+		},
+		{
+			http.MethodPut, alarmPath + "/digest", `{"windowMinutes": 30}`,
+			auth, http.StatusOK, `"alarmId":"` + alarmID + `"`,
+		},
+		{
+			http.MethodDelete, alarmPath + "/digest", "", auth,
+			http.StatusNoContent, "",
+		},
+	})
+}
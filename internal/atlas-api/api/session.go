@@ -0,0 +1,189 @@
+package api
+
+//go:generate mockgen -source session.go -destination mock_sessioner_test.go -package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+const (
+	// restrictedKeysPath is the path used to create restricted API keys.
+	restrictedKeysPath = "/v1/sessions/keys/restricted"
+
+	// keyRestrictionPath is the path of an API key's restriction.
+	keyRestrictionPath = "/v1/sessions/keys/{id}/restriction"
+
+	// sessionTokensPath is the path used to log in with a refresh token.
+	sessionTokensPath = "/v1/sessions/tokens"
+
+	// refreshTokenPath is the path used to exchange a refresh token.
+	refreshTokenPath = "/v1/sessions/tokens/refresh"
+
+	// logoutPath is the path used to revoke the current session.
+	logoutPath = "/v1/sessions/logout"
+
+	// userSessionsPath is the path of a user's sessions.
+	userSessionsPath = "/v1/users/{id}/sessions"
+
+	// verifyMFAPath is the path used to complete a login with a second
+	// factor.
+	verifyMFAPath = "/v1/sessions/mfa/verify"
+
+	// enrollMFAPath is the path used to begin TOTP enrollment.
+	enrollMFAPath = "/v1/sessions/mfa/enroll"
+
+	// confirmMFAPath is the path used to confirm TOTP enrollment.
+	confirmMFAPath = "/v1/sessions/mfa/confirm"
+
+	// userMFAPath is the path of a user's MFA status.
+	userMFAPath = "/v1/users/{id}/mfa"
+)
+
+// sessioner defines the methods provided by a service.Session that are not
+// part of the gRPC API.
+type sessioner interface {
+	CreateRestrictedKey(ctx context.Context,
+		req *message.CreateRestrictedKeyRequest) (
+		*message.CreateRestrictedKeyResponse, error)
+	GetKeyRestriction(ctx context.Context, keyID string) (
+		*message.KeyRestriction, error)
+	LoginWithRefresh(ctx context.Context, req *api.LoginRequest) (
+		*message.SessionTokens, error)
+	RefreshToken(ctx context.Context, req *message.RefreshTokenRequest) (
+		*message.SessionTokens, error)
+	Logout(ctx context.Context) error
+	ListUserSessions(ctx context.Context, userID string) (
+		*message.ListUserSessionsResponse, error)
+	RevokeUserSessions(ctx context.Context, userID string) error
+	VerifyMFA(ctx context.Context, req *message.VerifyMFARequest) (
+		*message.VerifyMFAResponse, error)
+	EnrollMFA(ctx context.Context, req *message.EnrollMFARequest) (
+		*message.MFAEnrollment, error)
+	ConfirmMFA(ctx context.Context, req *message.ConfirmMFARequest) (
+		*message.MFARecoveryCodes, error)
+	GetUserMFA(ctx context.Context, userID string) (*message.UserMFA, error)
+	ResetUserMFA(ctx context.Context, userID string) error
+}
+
+// sessionRoutes returns the routes of sessions that are not part of the gRPC
+// API. API keys are created with an expiration, scopes, or device
+// restrictions.
+// Logins and refresh token exchanges return session tokens, including a
+// refresh token. Logouts revoke the session of the current access token.
+// MFA logins are completed with an MFA challenge token and a TOTP or recovery
+// code. TOTP enrollment is authenticated by access token, or by an MFA
+// challenge token that requires enrollment, and is confirmed to return
+// recovery codes.
+func sessionRoutes(sessSvc sessioner) []route {
+	return []route{
+		{
+			http.MethodPost, restrictedKeysPath, authUnscoped,
+			http.StatusCreated,
+			func(ctx context.Context, req *request) (any, error) {
+				keyReq := &message.CreateRestrictedKeyRequest{}
+				if err := req.decode(keyReq); err != nil {
+					return nil, err
+				}
+
+				return sessSvc.CreateRestrictedKey(ctx, keyReq)
+			},
+		},
+		{
+			http.MethodGet, keyRestrictionPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return sessSvc.GetKeyRestriction(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodPost, sessionTokensPath, authNone, http.StatusCreated,
+			func(ctx context.Context, req *request) (any, error) {
+				loginReq := &api.LoginRequest{}
+				if err := req.decode(loginReq); err != nil {
+					return nil, err
+				}
+
+				return sessSvc.LoginWithRefresh(ctx, loginReq)
+			},
+		},
+		{
+			http.MethodPost, refreshTokenPath, authNone, http.StatusCreated,
+			func(ctx context.Context, req *request) (any, error) {
+				refreshReq := &message.RefreshTokenRequest{}
+				if err := req.decode(refreshReq); err != nil {
+					return nil, err
+				}
+
+				return sessSvc.RefreshToken(ctx, refreshReq)
+			},
+		},
+		{
+			http.MethodPost, logoutPath, authScoped, http.StatusNoContent,
+			func(ctx context.Context, _ *request) (any, error) {
+				return nil, sessSvc.Logout(ctx)
+			},
+		},
+		{
+			http.MethodGet, userSessionsPath, authScoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return sessSvc.ListUserSessions(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodDelete, userSessionsPath, authScoped,
+			http.StatusNoContent,
+			func(ctx context.Context, req *request) (any, error) {
+				return nil, sessSvc.RevokeUserSessions(ctx,
+					req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodPost, verifyMFAPath, authNone, http.StatusCreated,
+			func(ctx context.Context, req *request) (any, error) {
+				verifyReq := &message.VerifyMFARequest{}
+				if err := req.decode(verifyReq); err != nil {
+					return nil, err
+				}
+
+				return sessSvc.VerifyMFA(ctx, verifyReq)
+			},
+		},
+		{
+			http.MethodPost, enrollMFAPath, authOptional, http.StatusCreated,
+			func(ctx context.Context, req *request) (any, error) {
+				enrollReq := &message.EnrollMFARequest{}
+				if err := req.decode(enrollReq); err != nil {
+					return nil, err
+				}
+
+				return sessSvc.EnrollMFA(ctx, enrollReq)
+			},
+		},
+		{
+			http.MethodPost, confirmMFAPath, authScoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				confirmReq := &message.ConfirmMFARequest{}
+				if err := req.decode(confirmReq); err != nil {
+					return nil, err
+				}
+
+				return sessSvc.ConfirmMFA(ctx, confirmReq)
+			},
+		},
+		{
+			http.MethodGet, userMFAPath, authScoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return sessSvc.GetUserMFA(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodDelete, userMFAPath, authScoped, http.StatusNoContent,
+			func(ctx context.Context, req *request) (any, error) {
+				return nil, sessSvc.ResetUserMFA(ctx, req.pathParams["id"])
+			},
+		},
+	}
+}
@@ -0,0 +1,136 @@
+//go:build !integration
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"uuid"
+
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSessionRoutes(t *testing.T) {
+	t.Parallel()
+
+	key, user, auth := testAuth(t, "api-session", api.Role_ADMIN)
+
+	keyID := uuid.NewV7().String()
+	res := &message.KeyRestriction{
+		KeyId: keyID, OrgId: user.GetOrgId(),
+		Scopes: []string{"thingspect.api.DataPointService"},
+	}
+	tokens := &message.SessionTokens{
+		Token: "api-session-token", RefreshToken: "api-session-refresh",
+	}
+	sessID := uuid.NewV7().String()
+
+	ctrl := gomock.NewController(t)
+	sessSvc := NewMocksessioner(ctrl)
+	sessSvc.EXPECT().CreateRestrictedKey(gomock.Any(), gomock.Any()).
+		Return(&message.CreateRestrictedKeyResponse{
+			Key: &api.Key{Id: keyID}, Restriction: res, Token: "api-keyres",
+		}, nil).Times(1)
+	sessSvc.EXPECT().GetKeyRestriction(gomock.Any(), keyID).Return(res, nil).
+		Times(1)
+	sessSvc.EXPECT().LoginWithRefresh(gomock.Any(), gomock.Any()).
+		Return(tokens, nil).Times(1)
+	sessSvc.EXPECT().RefreshToken(gomock.Any(),
+		matcher.NewProtoMatcher(&message.RefreshTokenRequest{
+			RefreshToken: "api-session-refresh",
+		})).Return(tokens, nil).Times(1)
+	sessSvc.EXPECT().Logout(gomock.Any()).Return(nil).Times(1)
+	sessSvc.EXPECT().ListUserSessions(gomock.Any(), user.GetId()).
+		Return(&message.ListUserSessionsResponse{
+			Sessions: []*message.UserSession{{Id: sessID}},
+		}, nil).Times(1)
+	sessSvc.EXPECT().RevokeUserSessions(gomock.Any(), user.GetId()).
+		Return(nil).Times(1)
+	sessSvc.EXPECT().VerifyMFA(gomock.Any(),
+		matcher.NewProtoMatcher(&message.VerifyMFARequest{
+			Token: "api-mfa-token", Code: "123456",
+		})).Return(&message.VerifyMFAResponse{
+		Tokens: &message.SessionTokens{Token: "api-mfa-session"},
+	}, nil).Times(1)
+	sessSvc.EXPECT().EnrollMFA(gomock.Any(),
+		matcher.NewProtoMatcher(&message.EnrollMFARequest{})).
+		Return(&message.MFAEnrollment{Secret: "api-mfa-secret"}, nil).Times(1)
+	sessSvc.EXPECT().EnrollMFA(gomock.Any(),
+		matcher.NewProtoMatcher(&message.EnrollMFARequest{
+			Token: "api-mfa-token",
+		})).Return(&message.MFAEnrollment{Secret: "api-mfa-chal"}, nil).
+		Times(1)
+	sessSvc.EXPECT().ConfirmMFA(gomock.Any(),
+		matcher.NewProtoMatcher(&message.ConfirmMFARequest{Code: "123456"})).
+		Return(&message.MFARecoveryCodes{Codes: []string{"api-mfa-code"}},
+			nil).Times(1)
+	sessSvc.EXPECT().GetUserMFA(gomock.Any(), user.GetId()).
+		Return(&message.UserMFA{UserId: user.GetId(), Enrolled: true}, nil).
+		Times(1)
+	sessSvc.EXPECT().ResetUserMFA(gomock.Any(), user.GetId()).Return(nil).
+		Times(1)
+
+	testRoutes(t, sessionRoutes(sessSvc), key, []routeTest{
+		{
+			http.MethodPost, "/v1/sessions/keys/restricted",
+			`{"key":{"name":"api-keyres","role":"PUBLISHER"}}`, auth,
+			http.StatusCreated, `"api-keyres"`,
+		},
+		{
+			http.MethodGet, "/v1/sessions/keys/" + keyID + "/restriction", "",
+			auth, http.StatusOK, `"thingspect.api.DataPointService"`,
+		},
+		{
+			http.MethodPost, "/v1/sessions/tokens",
+			`{"email":"api-session@thingspect.com","orgName":"api-session",` +
+				`"password":"api-session"}`, "", http.StatusCreated,
+			`"api-session-refresh"`,
+		},
+		{
+			http.MethodPost, "/v1/sessions/tokens/refresh",
+			`{"refreshToken":"api-session-refresh"}`, "", http.StatusCreated,
+			`"api-session-token"`,
+		},
+		{
+			http.MethodPost, "/v1/sessions/logout", "", auth,
+			http.StatusNoContent, "",
+		},
+		{
+			http.MethodGet, "/v1/users/" + user.GetId() + "/sessions", "", auth,
+			http.StatusOK, sessID,
+		},
+		{
+			http.MethodDelete, "/v1/users/" + user.GetId() + "/sessions", "",
+			auth, http.StatusNoContent, "",
+		},
+		{
+			http.MethodPost, "/v1/sessions/mfa/verify",
+			`{"token":"api-mfa-token","code":"123456"}`, "", http.StatusCreated,
+			`"api-mfa-session"`,
+		},
+		{
+			http.MethodPost, "/v1/sessions/mfa/enroll", "{}", auth,
+			http.StatusCreated, `"api-mfa-secret"`,
+		},
+		{
+			http.MethodPost, "/v1/sessions/mfa/enroll",
+			`{"token":"api-mfa-token"}`, "", http.StatusCreated,
+			`"api-mfa-chal"`,
+		},
+		{
+			http.MethodPost, "/v1/sessions/mfa/confirm", `{"code":"123456"}`,
+			auth, http.StatusOK, `"api-mfa-code"`,
+		},
+		{
+			http.MethodGet, "/v1/users/" + user.GetId() + "/mfa", "", auth,
+			http.StatusOK, `"enrolled":true`,
+		},
+		{
+			http.MethodDelete, "/v1/users/" + user.GetId() + "/mfa", "", auth,
+			http.StatusNoContent, "",
+		},
+	})
+}
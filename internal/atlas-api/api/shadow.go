@@ -0,0 +1,71 @@
+package api
+
+//go:generate mockgen -source shadow.go -destination mock_shadower_test.go -package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Constants used for shadow paths.
+const (
+	shadowPath        = "/v1/devices/{id}/shadow"
+	shadowDesiredPath = "/v1/devices/{id}/shadow/desired"
+	deviceShadowPath  = "/v1/shadows/{uniqId}"
+	deviceTokenPrefix = "Device "
+)
+
+// shadower defines the methods provided by a service.Shadow.
+type shadower interface {
+	GetShadow(ctx context.Context, devID string) (*message.Shadow, error)
+	UpdateShadowDesired(ctx context.Context, devID string,
+		desired *structpb.Struct) (*message.Shadow, error)
+	DeviceShadow(ctx context.Context, uniqID, token string) (*message.Shadow,
+		error)
+}
+
+// shadowRoutes returns the routes of device shadows. A device retrieves its
+// own shadow by deviceShadowPath, authenticated by an 'Authorization: Device
+// ...' header containing its device token. The desired state request body is
+// a JSON object of attributes.
+func shadowRoutes(shadowSvc shadower) []route {
+	return []route{
+		{
+			http.MethodGet, shadowPath, authScoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return shadowSvc.GetShadow(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodPut, shadowDesiredPath, authScoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				desired := &structpb.Struct{}
+				if err := req.decode(desired); err != nil {
+					return nil, err
+				}
+
+				return shadowSvc.UpdateShadowDesired(ctx, req.pathParams["id"],
+					desired)
+			},
+		},
+		{
+			http.MethodGet, deviceShadowPath, authNone, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				auth := req.Header.Get("Authorization")
+				if !strings.HasPrefix(auth, deviceTokenPrefix) {
+					return nil, status.Error(codes.Unauthenticated,
+						"unauthorized")
+				}
+
+				return shadowSvc.DeviceShadow(ctx, req.pathParams["uniqId"],
+					strings.TrimPrefix(auth, deviceTokenPrefix))
+			},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+//go:build !integration
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestShadowRoutes(t *testing.T) {
+	t.Parallel()
+
+	key, user, auth := testAuth(t, "api-shadow", api.Role_BUILDER)
+
+	dev := random.Device("api-shadow", user.GetOrgId())
+	desired, err := structpb.NewStruct(map[string]any{"led": true})
+	require.NoError(t, err)
+	shadow := &message.Shadow{
+		DevId: dev.GetId(), OrgId: dev.GetOrgId(), UniqId: dev.GetUniqId(),
+		Desired: desired, Delta: desired, Version: 4,
+	}
+
+	ctrl := gomock.NewController(t)
+	shadowSvc := NewMockshadower(ctrl)
+	shadowSvc.EXPECT().GetShadow(gomock.Any(), dev.GetId()).Return(shadow,
+		nil).Times(1)
+	shadowSvc.EXPECT().UpdateShadowDesired(gomock.Any(), dev.GetId(),
+		gomock.Cond(func(inp *structpb.Struct) bool {
+			return inp.GetFields()["led"].GetBoolValue()
+		})).Return(shadow, nil).Times(1)
+	shadowSvc.EXPECT().DeviceShadow(gomock.Any(), dev.GetUniqId(),
+		dev.GetToken()).Return(shadow, nil).Times(1)
+	shadowSvc.EXPECT().DeviceShadow(gomock.Any(), dev.GetUniqId(),
+		"api-shadow").Return(nil, status.Error(codes.Unauthenticated,
+		"unauthorized")).Times(1)
+
+	testRoutes(t, shadowRoutes(shadowSvc), key, []routeTest{
+		{
+			http.MethodGet, "/v1/devices/" + dev.GetId() + "/shadow", "", auth,
+			http.StatusOK, `"version":4`,
+		},
+		{
+			http.MethodPut, "/v1/devices/" + dev.GetId() + "/shadow/desired",
+			`{"led": true}`, auth, http.StatusOK, `"delta":{"led":true}`,
+		},
+		{
+			http.MethodPut, "/v1/devices/" + dev.GetId() + "/shadow/desired",
+			`[]`, auth, http.StatusBadRequest, "",
+		},
+		{
+			http.MethodGet, "/v1/shadows/" + dev.GetUniqId(), "",
+			"Device " + dev.GetToken(), http.StatusOK,
+			fmt.Sprintf(`"uniqId":"%s"`, dev.GetUniqId()),
+		},
+		{
+			http.MethodGet, "/v1/shadows/" + dev.GetUniqId(), "",
+			"Device api-shadow", http.StatusUnauthorized, "unauthorized",
+		},
+		{
+			http.MethodGet, "/v1/shadows/" + dev.GetUniqId(), "", auth,
+			http.StatusUnauthorized, "unauthorized",
+		},
+	})
+}
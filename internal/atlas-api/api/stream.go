@@ -0,0 +1,137 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/thingspect/atlas/internal/atlas-api/interceptor"
+	"github.com/thingspect/atlas/internal/atlas-api/stream"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/metric"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Constants used for streaming paths and behavior.
+const (
+	streamDataPointsPath = "/v1/datapoints/stream"
+	streamEventsPath     = "/v1/events/stream"
+	streamKeepAlive      = 30 * time.Second
+)
+
+// streamHandler builds a gRPC-gateway handler that streams messages from a
+// Hub as server-sent events, for the gRPC streaming method. Requests are
+// authenticated and authorized as they are by the gRPC interceptors, and are
+// filtered by the uniqId, deviceId, tag, and attr query parameters, and by the
+// session's scope tags.
+func streamHandler[T stream.Messager, M proto.Message](
+	gwMux *runtime.ServeMux, hub *stream.Hub[T], pwtKey []byte,
+	c cache.Cacher[string], method, event string, toMsg func(T) M,
+) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		ctx := r.Context()
+		_, marshaler := runtime.MarshalerForRequest(gwMux, r)
+
+		// Retrieve token from 'Authorization: Bearer ...' header, falling
+		// back to the token query parameter for EventSource clients.
+		auth := r.Header.Get("Authorization")
+		if token := r.URL.Query().Get("token"); auth == "" && token != "" {
+			auth = "Bearer " + token
+		}
+
+		sess, err := interceptor.Authenticate(ctx, auth, pwtKey, c)
+		if err != nil {
+			runtime.HTTPError(ctx, gwMux, marshaler, w, r, err)
+
+			return
+		}
+
+		if sess.Role < api.Role_VIEWER {
+			runtime.HTTPError(ctx, gwMux, marshaler, w, r, status.Error(
+				codes.PermissionDenied, fmt.Sprintf(
+					"permission denied, %s role required",
+					api.Role_VIEWER.String())))
+
+			return
+		}
+
+		if !sess.InScope(method) {
+			runtime.HTTPError(ctx, gwMux, marshaler, w, r, status.Error(
+				codes.PermissionDenied, interceptor.ErrScope))
+
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			runtime.HTTPError(ctx, gwMux, marshaler, w, r, status.Error(
+				codes.Unimplemented, "streaming unsupported"))
+
+			return
+		}
+
+		query := r.URL.Query()
+		sub := hub.Subscribe(stream.Filter{
+			OrgID:  sess.OrgID,
+			UniqID: query.Get("uniqId"),
+			DevID:  query.Get("deviceId"),
+			Tag:    query.Get("tag"),
+			Attr:   query.Get("attr"),
+
+			ScopeTags: sess.ScopeTags,
+		})
+		defer sub.Unsubscribe()
+
+		logger := alog.WithField("orgID", sess.OrgID).
+			WithField("traceID", sess.TraceID.String())
+		logger.Debugf("streamHandler subscribed: %v", r.URL.Path)
+		metric.Incr("subscribed", map[string]string{"event": event})
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		ticker := time.NewTicker(streamKeepAlive)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Debugf("streamHandler unsubscribed: %v", ctx.Err())
+
+				return
+			case <-ticker.C:
+				_, err = fmt.Fprint(w, ": keepalive\n\n")
+			case msg, ok := <-sub.C():
+				if !ok {
+					logger.Debug("streamHandler hub closed")
+
+					return
+				}
+
+				var b []byte
+				b, err = marshaler.Marshal(toMsg(msg))
+				if err != nil {
+					logger.Errorf("streamHandler marshaler.Marshal: %v", err)
+
+					continue
+				}
+
+				_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b)
+			}
+			if err != nil {
+				logger.Debugf("streamHandler write: %v", err)
+
+				return
+			}
+
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,197 @@
+//go:build !integration
+
+package api
+
+import (
+	"bufio"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"uuid"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/internal/atlas-api/stream"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestStreamHandler(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	user := random.User("api-stream", uuid.NewV7().String())
+	user.Role = api.Role_VIEWER
+	viewerToken, _, err := session.GenerateWebToken(key, user)
+	require.NoError(t, err)
+
+	contact := random.User("api-stream", user.GetOrgId())
+	contact.Role = api.Role_CONTACT
+	contactToken, _, err := session.GenerateWebToken(key, contact)
+	require.NoError(t, err)
+
+	scopeToken, _, err := session.GenerateSessionToken(key, user,
+		uuid.NewV7().String(), []string{"api-stream-site"})
+	require.NoError(t, err)
+
+	keyToken, err := session.GenerateRestrictedKeyToken(key, api.Role_VIEWER,
+		&message.KeyRestriction{
+			KeyId: uuid.NewV7().String(), OrgId: user.GetOrgId(),
+			Scopes: []string{"thingspect.api.DeviceService"},
+		})
+	require.NoError(t, err)
+
+	eOutQueue := queue.NewFake()
+	eOutSub, err := eOutQueue.Subscribe("")
+	require.NoError(t, err)
+
+	evHub := stream.NewHub(eOutSub, func() *message.EventerOut {
+		return &message.EventerOut{}
+	})
+	go evHub.Serve()
+
+	gwMux := runtime.NewServeMux()
+	require.NoError(t, gwMux.HandlePath(http.MethodGet, streamEventsPath,
+		streamHandler(gwMux, evHub, key, cache.NewHeap[string](),
+			message.StreamService_StreamEvents_FullMethodName, "event",
+			stream.Event)))
+
+	// Close the server after parallel subtests complete.
+	srv := httptest.NewServer(gwMux)
+	t.Cleanup(srv.Close)
+
+	t.Run("Stream events by valid token", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-stream", user.GetOrgId())
+		eOut := &message.EventerOut{
+			Point: &common.DataPoint{
+				UniqId: dev.GetUniqId(), Attr: "motion",
+				ValOneof: &common.DataPoint_IntVal{IntVal: 123},
+				Ts:       timestamppb.Now(), TraceId: uuid.NewV7().String(),
+			}, Device: dev, Rule: random.Rule("api-stream", user.GetOrgId()),
+		}
+		bEOut, err := proto.Marshal(eOut)
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet,
+			srv.URL+streamEventsPath+"?uniqId="+dev.GetUniqId()+"&token="+
+				url.QueryEscape(viewerToken), nil)
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, resp.Body.Close()) }()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		require.NoError(t, eOutQueue.Publish("", bEOut))
+
+		scanner := bufio.NewScanner(resp.Body)
+		require.True(t, scanner.Scan())
+		require.Equal(t, "event: event", scanner.Text())
+		require.True(t, scanner.Scan())
+		t.Logf("scanner.Text(): %v", scanner.Text())
+		require.True(t, strings.HasPrefix(scanner.Text(), "data: "))
+		require.Contains(t, scanner.Text(), fmt.Sprintf(`"ruleID":"%s"`,
+			eOut.GetRule().GetId()))
+		require.Contains(t, scanner.Text(), `"cleared":false`)
+	})
+
+	t.Run("Stream events with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		outDev := random.Device("api-stream", user.GetOrgId())
+		outDev.Tags = []string{"api-stream-site2"}
+		inDev := random.Device("api-stream", user.GetOrgId())
+		inDev.Tags = []string{"api-stream-site/b1"}
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet,
+			srv.URL+streamEventsPath, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+scopeToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, resp.Body.Close()) }()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// Publish an out of scope event before an in scope event, and verify
+		// that only the latter is received.
+		for _, dev := range []*api.Device{outDev, inDev} {
+			bEOut, err := proto.Marshal(&message.EventerOut{
+				Point: &common.DataPoint{
+					UniqId: dev.GetUniqId(), Attr: "motion",
+					ValOneof: &common.DataPoint_IntVal{IntVal: 123},
+					Ts:       timestamppb.Now(),
+				}, Device: dev,
+				Rule: random.Rule("api-stream", user.GetOrgId()),
+			})
+			require.NoError(t, err)
+			require.NoError(t, eOutQueue.Publish("", bEOut))
+		}
+
+		scanner := bufio.NewScanner(resp.Body)
+		require.True(t, scanner.Scan())
+		require.Equal(t, "event: event", scanner.Text())
+		require.True(t, scanner.Scan())
+		t.Logf("scanner.Text(): %v", scanner.Text())
+		require.Contains(t, scanner.Text(), fmt.Sprintf(`"uniqID":"%s"`,
+			inDev.GetUniqId()))
+		require.NotContains(t, scanner.Text(), outDev.GetUniqId())
+	})
+
+	t.Run("Stream events without token", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet,
+			srv.URL+streamEventsPath, nil)
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Stream events with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet,
+			srv.URL+streamEventsPath, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+contactToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+	t.Run("Stream events with API key out of scope", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet,
+			srv.URL+streamEventsPath, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+keyToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
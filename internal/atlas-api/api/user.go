@@ -0,0 +1,80 @@
+package api
+
+//go:generate mockgen -source user.go -destination mock_userer_test.go -package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thingspect/atlas/proto/go/message"
+)
+
+// Constants used for user paths.
+const (
+	userSchedulePath = "/v1/users/{id}/schedule"
+	userScopePath    = "/v1/users/{id}/scope"
+)
+
+// userer defines the methods provided by a service.User that are not part of
+// the gRPC API.
+type userer interface {
+	GetUserSchedule(ctx context.Context, userID string) (
+		*message.NotificationSchedule, error)
+	UpdateUserSchedule(ctx context.Context, userID string,
+		sched *message.NotificationSchedule) (*message.NotificationSchedule,
+		error)
+	DeleteUserSchedule(ctx context.Context, userID string) error
+	GetUserScope(ctx context.Context, userID string) (*message.UserScope,
+		error)
+	UpdateUserScope(ctx context.Context, userID string,
+		scope *message.UserScope) (*message.UserScope, error)
+}
+
+// userRoutes returns the routes of users that are not part of the gRPC API.
+func userRoutes(userSvc userer) []route {
+	return []route{
+		{
+			http.MethodGet, userSchedulePath, authScoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return userSvc.GetUserSchedule(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodPut, userSchedulePath, authScoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				sched := &message.NotificationSchedule{}
+				if err := req.decode(sched); err != nil {
+					return nil, err
+				}
+
+				return userSvc.UpdateUserSchedule(ctx, req.pathParams["id"],
+					sched)
+			},
+		},
+		{
+			http.MethodDelete, userSchedulePath, authScoped,
+			http.StatusNoContent,
+			func(ctx context.Context, req *request) (any, error) {
+				return nil, userSvc.DeleteUserSchedule(ctx,
+					req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodGet, userScopePath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				return userSvc.GetUserScope(ctx, req.pathParams["id"])
+			},
+		},
+		{
+			http.MethodPut, userScopePath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, req *request) (any, error) {
+				scope := &message.UserScope{}
+				if err := req.decode(scope); err != nil {
+					return nil, err
+				}
+
+				return userSvc.UpdateUserScope(ctx, req.pathParams["id"], scope)
+			},
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+//go:generate mockgen -source user_lockout.go -destination mock_lockouter_test.go -package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thingspect/atlas/proto/go/message"
+)
+
+const (
+	// userLockoutsPath is the path used to list users with failed logins.
+	userLockoutsPath = "/v1/users/lockouts"
+
+	// userLockoutPath is the path of a user's login lockout.
+	userLockoutPath = "/v1/users/{id}/lockout"
+)
+
+// lockouter defines the methods provided by a service.Lockout.
+type lockouter interface {
+	ListUserLockouts(ctx context.Context) (*message.ListUserLockoutsResponse,
+		error)
+	UnlockUser(ctx context.Context, userID string) error
+}
+
+// userLockoutRoutes returns the routes that list users of the current
+// organization with failed logins, and clear a user's failed logins, including
+// any lockout.
+func userLockoutRoutes(lockSvc lockouter) []route {
+	return []route{
+		{
+			http.MethodGet, userLockoutsPath, authUnscoped, http.StatusOK,
+			func(ctx context.Context, _ *request) (any, error) {
+				return lockSvc.ListUserLockouts(ctx)
+			},
+		},
+		{
+			http.MethodDelete, userLockoutPath, authUnscoped,
+			http.StatusNoContent,
+			func(ctx context.Context, req *request) (any, error) {
+				return nil, lockSvc.UnlockUser(ctx, req.pathParams["id"])
+			},
+		},
+	}
+}
@@ -0,0 +1,51 @@
+//go:build !integration
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"uuid"
+
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUserLockoutRoutes(t *testing.T) {
+	t.Parallel()
+
+	key, _, auth := testAuth(t, "api-lockout", api.Role_ADMIN)
+
+	lockedID := uuid.NewV7().String()
+	unknownID := uuid.NewV7().String()
+
+	ctrl := gomock.NewController(t)
+	lockSvc := NewMocklockouter(ctrl)
+	lockSvc.EXPECT().ListUserLockouts(gomock.Any()).Return(
+		&message.ListUserLockoutsResponse{
+			Lockouts: []*message.UserLockout{
+				{UserId: lockedID, FailedAttempts: 10, Locked: true},
+			},
+		}, nil).Times(1)
+	lockSvc.EXPECT().UnlockUser(gomock.Any(), lockedID).Return(nil).Times(1)
+	lockSvc.EXPECT().UnlockUser(gomock.Any(), unknownID).Return(
+		status.Error(codes.NotFound, "dao: object not found")).Times(1)
+
+	testRoutes(t, userLockoutRoutes(lockSvc), key, []routeTest{
+		{
+			http.MethodGet, userLockoutsPath, "", auth, http.StatusOK,
+			`"locked":true`,
+		},
+		{
+			http.MethodDelete, "/v1/users/" + lockedID + "/lockout", "", auth,
+			http.StatusNoContent, "",
+		},
+		{
+			http.MethodDelete, "/v1/users/" + unknownID + "/lockout", "", auth,
+			http.StatusNotFound, "object not found",
+		},
+	})
+}
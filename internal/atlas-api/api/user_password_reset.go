@@ -0,0 +1,59 @@
+package api
+
+//go:generate mockgen -source user_password_reset.go -destination mock_passwordresetter_test.go -package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thingspect/atlas/proto/go/message"
+)
+
+const (
+	// passwordResetPath is the path used to request a password reset token by
+	// email.
+	passwordResetPath = "/v1/sessions/password/reset"
+
+	// passwordResetCompletePath is the path used to set a new password using
+	// a password reset token.
+	passwordResetCompletePath = "/v1/sessions/password/reset/complete"
+)
+
+// passwordResetter defines the methods provided by a service.PasswordReset.
+type passwordResetter interface {
+	RequestPasswordReset(ctx context.Context,
+		req *message.RequestPasswordResetRequest) error
+	CompletePasswordReset(ctx context.Context,
+		req *message.CompletePasswordResetRequest) error
+}
+
+// passwordResetRoutes returns the routes that email a password reset token,
+// and set a new password using a password reset token. Responses do not
+// indicate whether the user exists.
+func passwordResetRoutes(resetSvc passwordResetter) []route {
+	return []route{
+		{
+			http.MethodPost, passwordResetPath, authNone, http.StatusAccepted,
+			func(ctx context.Context, req *request) (any, error) {
+				resetReq := &message.RequestPasswordResetRequest{}
+				if err := req.decode(resetReq); err != nil {
+					return nil, err
+				}
+
+				return nil, resetSvc.RequestPasswordReset(ctx, resetReq)
+			},
+		},
+		{
+			http.MethodPost, passwordResetCompletePath, authNone,
+			http.StatusNoContent,
+			func(ctx context.Context, req *request) (any, error) {
+				completeReq := &message.CompletePasswordResetRequest{}
+				if err := req.decode(completeReq); err != nil {
+					return nil, err
+				}
+
+				return nil, resetSvc.CompletePasswordReset(ctx, completeReq)
+			},
+		},
+	}
+}
@@ -0,0 +1,61 @@
+//go:build !integration
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/proto/go/message"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPasswordResetRoutes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	resetSvc := NewMockpasswordResetter(ctrl)
+	resetSvc.EXPECT().RequestPasswordReset(gomock.Any(),
+		matcher.NewProtoMatcher(&message.RequestPasswordResetRequest{
+			Email: "api-reset@example.com", OrgName: "api-reset",
+		})).Return(nil).Times(1)
+	resetSvc.EXPECT().RequestPasswordReset(gomock.Any(),
+		matcher.NewProtoMatcher(&message.RequestPasswordResetRequest{
+			Email: "api-reset@example.com", OrgName: "api-reset-rate",
+		})).Return(status.Error(codes.ResourceExhausted, "too many")).Times(1)
+	resetSvc.EXPECT().CompletePasswordReset(gomock.Any(),
+		matcher.NewProtoMatcher(&message.CompletePasswordResetRequest{
+			Token: "api-reset-token", Password: "api-reset-pass",
+		})).Return(nil).Times(1)
+	resetSvc.EXPECT().CompletePasswordReset(gomock.Any(),
+		matcher.NewProtoMatcher(&message.CompletePasswordResetRequest{
+			Token: "api-reset-bad", Password: "api-reset-pass",
+		})).Return(status.Error(codes.Unauthenticated, "unauthorized")).
+		Times(1)
+
+	testRoutes(t, passwordResetRoutes(resetSvc), nil, []routeTest{
+		{
+			http.MethodPost, passwordResetPath,
+			`{"email":"api-reset@example.com","orgName":"api-reset"}`, "",
+			http.StatusAccepted, "",
+		},
+		{
+			http.MethodPost, passwordResetPath,
+			`{"email":"api-reset@example.com","orgName":"api-reset-rate"}`, "",
+			http.StatusTooManyRequests, "too many",
+		},
+		{
+			http.MethodPost, passwordResetCompletePath,
+			`{"token":"api-reset-token","password":"api-reset-pass"}`, "",
+			http.StatusNoContent, "",
+		},
+		{
+			http.MethodPost, passwordResetCompletePath,
+			`{"token":"api-reset-bad","password":"api-reset-pass"}`, "",
+			http.StatusUnauthorized, "unauthorized",
+		},
+	})
+}
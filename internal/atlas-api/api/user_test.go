@@ -0,0 +1,78 @@
+//go:build !integration
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserRoutes(t *testing.T) {
+	t.Parallel()
+
+	key, user, auth := testAuth(t, "api-user", api.Role_ADMIN)
+
+	sched := &message.NotificationSchedule{
+		UserId: user.GetId(), OrgId: user.GetOrgId(),
+		Timezone: "America/Chicago",
+		Windows: []*message.ScheduleWindow{
+			{StartMinute: 540, EndMinute: 1020},
+		},
+		Action: message.ScheduleAction_DROP,
+	}
+
+	ctrl := gomock.NewController(t)
+	userSvc := NewMockuserer(ctrl)
+	userSvc.EXPECT().GetUserSchedule(gomock.Any(), user.GetId()).
+		Return(sched, nil).Times(1)
+	userSvc.EXPECT().UpdateUserSchedule(gomock.Any(), user.GetId(),
+		gomock.Cond(func(inp *message.NotificationSchedule) bool {
+			return inp.GetTimezone() == sched.GetTimezone() &&
+				inp.GetAction() == sched.GetAction()
+		})).Return(sched, nil).Times(1)
+	userSvc.EXPECT().DeleteUserSchedule(gomock.Any(), user.GetId()).
+		Return(nil).Times(1)
+
+	scope := &message.UserScope{
+		UserId: user.GetId(), OrgId: user.GetOrgId(),
+		Tags: []string{"site/b1"},
+	}
+	userSvc.EXPECT().GetUserScope(gomock.Any(), user.GetId()).
+		Return(scope, nil).Times(1)
+	userSvc.EXPECT().UpdateUserScope(gomock.Any(), user.GetId(),
+		gomock.Cond(func(inp *message.UserScope) bool {
+			return len(inp.GetTags()) == 1 &&
+				inp.GetTags()[0] == scope.GetTags()[0]
+		})).Return(scope, nil).Times(1)
+
+	path := "/v1/users/" + user.GetId()
+
+	testRoutes(t, userRoutes(userSvc), key, []routeTest{
+		{
+			http.MethodGet, path + "/schedule", "", auth, http.StatusOK,
+			`"timezone":"America/Chicago"`,
+		},
+		{
+			http.MethodPut, path + "/schedule",
+			`{"timezone": "America/Chicago", "action": "DROP"}`, auth,
+			http.StatusOK, fmt.Sprintf(`"userId":"%s"`, user.GetId()),
+		},
+		{
+			http.MethodDelete, path + "/schedule", "", auth,
+			http.StatusNoContent, "",
+		},
+		{
+			http.MethodGet, path + "/scope", "", auth, http.StatusOK,
+			`"tags":["site/b1"]`,
+		},
+		{
+			http.MethodPut, path + "/scope", `{"tags": ["site/b1"]}`, auth,
+			http.StatusOK, fmt.Sprintf(`"userId":"%s"`, user.GetId()),
+		},
+	})
+}
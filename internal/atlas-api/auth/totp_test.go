@@ -0,0 +1,136 @@
+//go:build !integration
+
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/random"
+)
+
+func TestTOTP(t *testing.T) {
+	t.Parallel()
+
+	// RFC 6238 Appendix B SHA-1 vectors, truncated to 6 digits.
+	secret := b32.EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		inp int64
+		res string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can generate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			code, err := TOTP(secret, time.Unix(test.inp, 0))
+			t.Logf("code, err: %v, %v", code, err)
+			require.NoError(t, err)
+			require.Equal(t, test.res, code)
+		})
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	t.Parallel()
+
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	now := time.Now()
+
+	tests := []struct {
+		inpOffset time.Duration
+		err       error
+	}{
+		{0, nil},
+		{-totpStep * time.Second, nil},
+		{totpStep * time.Second, nil},
+		{-2 * totpStep * time.Second, ErrInvalidCode},
+		{2 * totpStep * time.Second, ErrInvalidCode},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can validate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			code, err := TOTP(secret, now.Add(test.inpOffset))
+			require.NoError(t, err)
+
+			step, err := ValidateTOTP(secret, code, now)
+			t.Logf("step, err: %v, %v", step, err)
+			require.Equal(t, test.err, err)
+			if test.err == nil {
+				require.Equal(t, now.Add(test.inpOffset).Unix()/totpStep, step)
+			}
+		})
+	}
+
+	t.Run("Validate malformed code", func(t *testing.T) {
+		t.Parallel()
+
+		step, err := ValidateTOTP(secret, random.String(10), now)
+		t.Logf("step, err: %v, %v", step, err)
+		require.Zero(t, step)
+		require.Equal(t, ErrInvalidCode, err)
+	})
+
+	t.Run("Validate with malformed secret", func(t *testing.T) {
+		t.Parallel()
+
+		step, err := ValidateTOTP("!", "123456", now)
+		t.Logf("step, err: %v, %v", step, err)
+		require.Zero(t, step)
+		require.Error(t, err)
+	})
+}
+
+func TestTOTPURI(t *testing.T) {
+	t.Parallel()
+
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	uri := TOTPURI(secret, "auth@example.com")
+	t.Logf("uri: %v", uri)
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", u.Scheme)
+	require.Equal(t, "totp", u.Host)
+	require.Equal(t, "/Thingspect:auth@example.com", u.Path)
+	require.Equal(t, secret, u.Query().Get("secret"))
+	require.Equal(t, "Thingspect", u.Query().Get("issuer"))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	t.Parallel()
+
+	codes, hashes := GenerateRecoveryCodes(10)
+	t.Logf("codes, hashes: %v, %v", codes, hashes)
+	require.Len(t, codes, 10)
+	require.Len(t, hashes, 10)
+
+	for i, code := range codes {
+		require.Len(t, code, 11)
+		require.Equal(t, hashes[i], HashRecoveryCode(code))
+		require.Equal(t, hashes[i], HashRecoveryCode(strings.ToLower(
+			strings.ReplaceAll(code, "-", ""))))
+		require.Len(t, hashes[i], 64)
+	}
+
+	require.NotEqual(t, codes[0], codes[1])
+}
@@ -19,6 +19,10 @@ import (
 const (
 	errUnauth = "unauthorized"
 	keyAuth   = "authorization"
+
+	// ErrScope is the PermissionDenied message for calls outside of the scopes
+	// of an API key.
+	ErrScope = "permission denied, method not in key scope"
 )
 
 // Auth performs authentication and authorization via web token, and implements
@@ -33,49 +37,114 @@ func Auth(
 			return handler(ctx, req)
 		}
 
-		// Retrieve token from 'Authorization: Bearer ...' header.
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, errUnauth)
+		ctx, err := authContext(ctx, info.FullMethod, pwtKey, c)
+		if err != nil {
+			return nil, err
 		}
 
-		auth := md[keyAuth]
-		if len(auth) < 1 {
-			return nil, status.Error(codes.Unauthenticated, errUnauth)
-		}
+		return handler(ctx, req)
+	}
+}
 
-		if !strings.HasPrefix(auth[0], "Bearer ") {
-			return nil, status.Error(codes.Unauthenticated, errUnauth)
+// AuthStream performs authentication and authorization via web token, and
+// implements the grpc.StreamServerInterceptor type signature.
+func AuthStream(
+	skipPaths map[string]struct{}, pwtKey []byte, c cache.Cacher[string],
+) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if _, ok := skipPaths[info.FullMethod]; ok {
+			return handler(srv, ss)
 		}
 
-		// Validate token.
-		token := strings.TrimPrefix(auth[0], "Bearer ")
-		sess, err := session.ValidateWebToken(pwtKey, token)
+		ctx, err := authContext(ss.Context(), info.FullMethod, pwtKey, c)
 		if err != nil {
-			return nil, status.Error(codes.Unauthenticated, errUnauth)
+			return err
 		}
 
-		// Check for disabled API key. Disabled can return nil or non-nil except
-		//  cache.ErrNotFound.
-		if sess.KeyID != "" {
-			if _, err := c.Get(ctx, key.Disabled(sess.OrgID,
-				sess.KeyID)); !errors.Is(err, cache.ErrNotFound) {
-				return nil, status.Error(codes.Unauthenticated, errUnauth)
-			}
-		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
 
-		// Add logging fields.
-		logger := alog.FromContext(ctx)
-		if sess.UserID != "" {
-			logger.Logger = logger.WithField("userID", sess.UserID)
-		} else {
-			logger.Logger = logger.WithField("keyID", sess.KeyID)
-		}
-		logger.Logger = logger.WithField("orgID", sess.OrgID)
-		logger.Logger = logger.WithField("traceID", sess.TraceID.String())
+// authContext authenticates and authorizes a call to a method, and returns a
+// context containing its Session and an error value.
+func authContext(
+	ctx context.Context, method string, pwtKey []byte, c cache.Cacher[string],
+) (context.Context, error) {
+	// Retrieve token from 'Authorization: Bearer ...' header.
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
 
-		ctx = session.NewContext(ctx, sess)
+	auth := md[keyAuth]
+	if len(auth) < 1 {
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
 
-		return handler(ctx, req)
+	sess, err := Authenticate(ctx, auth[0], pwtKey, c)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sess.InScope(method) {
+		return nil, status.Error(codes.PermissionDenied, ErrScope)
+	}
+
+	// Add logging fields.
+	logger := alog.FromContext(ctx)
+	if sess.UserID != "" {
+		logger.Logger = logger.WithField("userID", sess.UserID)
+	} else {
+		logger.Logger = logger.WithField("keyID", sess.KeyID)
+	}
+	logger.Logger = logger.WithField("orgID", sess.OrgID)
+	logger.Logger = logger.WithField("traceID", sess.TraceID.String())
+
+	return session.NewContext(ctx, sess), nil
+}
+
+// Authenticate validates an 'Authorization: Bearer ...' header value and
+// returns a Session and an error value. It is used by Auth and by HTTP handlers
+// that are served outside of gRPC.
+func Authenticate(
+	ctx context.Context, auth string, pwtKey []byte, c cache.Cacher[string],
+) (*session.Session, error) {
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	// Validate token.
+	token := strings.TrimPrefix(auth, "Bearer ")
+	sess, err := session.ValidateWebToken(pwtKey, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	// Check for an org pending deletion, which disables all of its sessions.
+	if _, err := c.Get(ctx,
+		key.OrgDisabled(sess.OrgID)); !errors.Is(err, cache.ErrNotFound) {
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	// Check for revoked user session. SessionRevoked can return nil or non-nil
+	// except cache.ErrNotFound.
+	if sess.SessionID != "" {
+		if _, err := c.Get(ctx, key.SessionRevoked(sess.OrgID,
+			sess.SessionID)); !errors.Is(err, cache.ErrNotFound) {
+			return nil, status.Error(codes.Unauthenticated, errUnauth)
+		}
 	}
+
+	// Check for disabled API key. Disabled can return nil or non-nil except
+	//  cache.ErrNotFound.
+	if sess.KeyID != "" {
+		if _, err := c.Get(ctx, key.Disabled(sess.OrgID,
+			sess.KeyID)); !errors.Is(err, cache.ErrNotFound) {
+			return nil, status.Error(codes.Unauthenticated, errUnauth)
+		}
+	}
+
+	return sess, nil
 }
@@ -7,13 +7,17 @@ import (
 	"crypto/rand"
 	"fmt"
 	"testing"
+	"time"
 	"uuid"
 
 	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
 	"github.com/thingspect/atlas/internal/atlas-api/session"
 	"github.com/thingspect/atlas/pkg/cache"
 	"github.com/thingspect/atlas/pkg/consterr"
 	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -41,6 +45,20 @@ func TestAuth(t *testing.T) {
 	t.Logf("keyToken, err: %v, %v", keyToken, err)
 	require.NoError(t, err)
 
+	scopedToken, err := session.GenerateRestrictedKeyToken(key,
+		api.Role_BUILDER, &message.KeyRestriction{
+			KeyId: uuid.NewV7().String(), OrgId: user.GetOrgId(),
+			Scopes: []string{"thingspect.api.DeviceService"},
+		})
+	t.Logf("scopedToken, err: %v, %v", scopedToken, err)
+	require.NoError(t, err)
+
+	sessUser := random.User("auth", uuid.NewV7().String())
+	sessToken, _, err := session.GenerateSessionToken(key, sessUser,
+		uuid.NewV7().String(), nil)
+	t.Logf("sessToken, err: %v, %v", sessToken, err)
+	require.NoError(t, err)
+
 	skipPath := random.String(10)
 
 	tests := []struct {
@@ -55,12 +73,30 @@ func TestAuth(t *testing.T) {
 		{
 			[]string{keyAuth, "Bearer " + webToken},
 			nil, nil, &grpc.UnaryServerInfo{FullMethod: random.String(10)},
-			cache.ErrNotFound, 0, nil,
+			cache.ErrNotFound, 1, nil,
 		},
 		{
 			[]string{keyAuth, "Bearer " + keyToken},
 			nil, nil, &grpc.UnaryServerInfo{FullMethod: random.String(10)},
-			cache.ErrNotFound, 1, nil,
+			cache.ErrNotFound, 2, nil,
+		},
+		{
+			[]string{keyAuth, "Bearer " + sessToken},
+			nil, nil, &grpc.UnaryServerInfo{FullMethod: random.String(10)},
+			cache.ErrNotFound, 2, nil,
+		},
+		{
+			[]string{keyAuth, "Bearer " + scopedToken}, nil, nil,
+			&grpc.UnaryServerInfo{
+				FullMethod: "/thingspect.api.DeviceService/GetDevice",
+			}, cache.ErrNotFound, 2, nil,
+		},
+		{
+			[]string{keyAuth, "Bearer " + scopedToken}, errTestFunc, nil,
+			&grpc.UnaryServerInfo{
+				FullMethod: "/thingspect.api.OrgService/GetOrg",
+			}, cache.ErrNotFound, 2, status.Error(codes.PermissionDenied,
+				ErrScope),
 		},
 		{
 			nil, errTestFunc,
@@ -95,6 +131,12 @@ func TestAuth(t *testing.T) {
 			cache.ErrNotFound, 0, status.Error(codes.Unauthenticated,
 				errUnauth),
 		},
+		{
+			[]string{keyAuth, "Bearer " + webToken},
+			errTestFunc, nil,
+			&grpc.UnaryServerInfo{FullMethod: random.String(10)}, nil,
+			1, status.Error(codes.Unauthenticated, errUnauth),
+		},
 		{
 			[]string{keyAuth, "Bearer " + keyToken},
 			errTestFunc, nil,
@@ -135,3 +177,113 @@ func TestAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthRevokedSession(t *testing.T) {
+	t.Parallel()
+
+	pwtKey := make([]byte, 32)
+	_, err := rand.Read(pwtKey)
+	require.NoError(t, err)
+
+	user := random.User("auth", uuid.NewV7().String())
+	sessID := uuid.NewV7().String()
+	sessToken, _, err := session.GenerateSessionToken(pwtKey, user, sessID,
+		nil)
+	t.Logf("sessToken, err: %v, %v", sessToken, err)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
+	defer cancel()
+
+	c := cache.NewHeap[string]()
+	require.NoError(t, c.Set(ctx, key.SessionRevoked(user.GetOrgId(), sessID),
+		""))
+
+	sess, err := Authenticate(ctx, "Bearer "+sessToken, pwtKey, c)
+	t.Logf("sess, err: %+v, %v", sess, err)
+	require.Nil(t, sess)
+	require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+}
+
+func TestAuthStream(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	user := random.User("auth", uuid.NewV7().String())
+	webToken, _, err := session.GenerateWebToken(key, user)
+	t.Logf("webToken, err: %v, %v", webToken, err)
+	require.NoError(t, err)
+
+	scopedToken, err := session.GenerateRestrictedKeyToken(key,
+		api.Role_BUILDER, &message.KeyRestriction{
+			KeyId: uuid.NewV7().String(), OrgId: user.GetOrgId(),
+			Scopes: []string{"thingspect.api.DeviceService"},
+		})
+	t.Logf("scopedToken, err: %v, %v", scopedToken, err)
+	require.NoError(t, err)
+
+	skipPath := random.String(10)
+
+	tests := []struct {
+		inpMD         []string
+		inpSkipPaths  map[string]struct{}
+		inpMethod     string
+		inpCacheTimes int
+		resSess       bool
+		err           error
+	}{
+		{
+			[]string{keyAuth, "Bearer " + webToken}, nil,
+			random.String(10), 1, true, nil,
+		},
+		{nil, map[string]struct{}{skipPath: {}}, skipPath, 0, false, nil},
+		{
+			[]string{keyAuth, "Bearer " + scopedToken}, nil,
+			message.StreamService_StreamEvents_FullMethodName, 2, false,
+			status.Error(codes.PermissionDenied, ErrScope),
+		},
+		{
+			nil, nil, random.String(10), 0, false,
+			status.Error(codes.Unauthenticated, errUnauth),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can auth %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			cacher := cache.NewMockCacher[string](gomock.NewController(t))
+			cacher.EXPECT().Get(gomock.Any(), gomock.Any()).
+				Return("", cache.ErrNotFound).Times(test.inpCacheTimes)
+
+			ctx := t.Context()
+			if test.inpMD != nil {
+				ctx = metadata.NewIncomingContext(ctx,
+					metadata.Pairs(test.inpMD...))
+			}
+
+			var handled bool
+			handler := func(_ any, ss grpc.ServerStream) error {
+				handled = true
+				sess, ok := session.FromContext(ss.Context())
+				require.Equal(t, test.resSess, ok)
+				if ok {
+					require.Equal(t, user.GetOrgId(), sess.OrgID)
+				}
+
+				return nil
+			}
+
+			err := AuthStream(test.inpSkipPaths, key, cacher)(nil,
+				&serverStream{ctx: ctx}, &grpc.StreamServerInfo{
+					FullMethod: test.inpMethod,
+				}, handler)
+			t.Logf("err: %v", err)
+			require.Equal(t, test.err, err)
+			require.Equal(t, test.err == nil, handled)
+		})
+	}
+}
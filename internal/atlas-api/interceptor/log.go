@@ -1,5 +1,6 @@
 // Package interceptor provides functions to intercept the execution of an RPC
-// on the server and implement the grpc.UnaryServerInterceptor type signature.
+// on the server and implement the grpc.UnaryServerInterceptor and
+// grpc.StreamServerInterceptor type signatures.
 package interceptor
 
 import (
@@ -21,19 +22,7 @@ func Log() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (any, error) {
-		// Set up logging fields and context.
-		logger := &alog.CtxLogger{Logger: alog.WithField(
-			"path", info.FullMethod)}
-		ctx = alog.NewContext(ctx, logger)
-
-		// Populate additional fields with metadata.
-		if md, ok := metadata.FromIncomingContext(ctx); ok {
-			for k, v := range md {
-				if !strings.Contains(k, "authorization") {
-					logger.Logger = logger.WithField(k, strings.Join(v, ","))
-				}
-			}
-		}
+		ctx, logger := logContext(ctx, info.FullMethod)
 
 		start := time.Now()
 		resp, err := handler(ctx, req)
@@ -66,3 +55,56 @@ func Log() grpc.UnaryServerInterceptor {
 		return resp, err
 	}
 }
+
+// LogStream logs streams and metadata, sends metrics, and implements the
+// grpc.StreamServerInterceptor type signature. Streamed responses are not
+// logged.
+func LogStream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, logger := logContext(ss.Context(), info.FullMethod)
+
+		start := time.Now()
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		dur := time.Since(start)
+
+		// Send metrics.
+		metric.Timing("durms", dur, map[string]string{"path": info.FullMethod})
+		if err != nil {
+			metric.Incr("error", map[string]string{"path": info.FullMethod})
+		}
+
+		flog := logger.
+			WithField("durms", fmt.Sprintf("%d", dur/time.Millisecond)).
+			WithField("code", status.Code(err).String())
+
+		if err != nil {
+			flog.Info(err)
+		} else {
+			flog.Info("stream ended")
+		}
+
+		return err
+	}
+}
+
+// logContext builds a logger for a call to a method, populated with fields
+// from metadata, and returns it and a context containing it.
+func logContext(
+	ctx context.Context, method string,
+) (context.Context, *alog.CtxLogger) {
+	logger := &alog.CtxLogger{Logger: alog.WithField("path", method)}
+	ctx = alog.NewContext(ctx, logger)
+
+	// Populate additional fields with metadata.
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for k, v := range md {
+			if !strings.Contains(k, "authorization") {
+				logger.Logger = logger.WithField(k, strings.Join(v, ","))
+			}
+		}
+	}
+
+	return ctx, logger
+}
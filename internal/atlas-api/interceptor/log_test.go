@@ -57,3 +57,38 @@ func TestLog(t *testing.T) {
 		})
 	}
 }
+
+func TestLogStream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpMD         []string
+		inpHandlerErr error
+	}{
+		{nil, nil},
+		{[]string{random.String(10), random.String(10)}, nil},
+		{nil, io.EOF},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can log %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := metadata.NewIncomingContext(t.Context(),
+				metadata.Pairs(test.inpMD...))
+
+			handler := func(_ any, ss grpc.ServerStream) error {
+				// The logger is added to a new context.
+				require.NotEqual(t, ctx, ss.Context())
+
+				return test.inpHandlerErr
+			}
+
+			err := LogStream()(nil, &serverStream{ctx: ctx},
+				&grpc.StreamServerInfo{FullMethod: random.String(10)},
+				handler)
+			t.Logf("err: %v", err)
+			require.Equal(t, test.inpHandlerErr, err)
+		})
+	}
+}
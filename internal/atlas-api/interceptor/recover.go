@@ -27,3 +27,21 @@ func Recover() grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+// RecoverStream recovers from panics and replaces them with internal server
+// errors, and implements the grpc.StreamServerInterceptor type signature.
+func RecoverStream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				alog.FromContext(ss.Context()).Errorf("RecoverStream: %v - %s",
+					r, debug.Stack())
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
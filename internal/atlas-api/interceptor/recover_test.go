@@ -36,3 +36,28 @@ func TestRecover(t *testing.T) {
 		})
 	}
 }
+
+func TestRecoverStream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpHandler grpc.StreamHandler
+		err        error
+	}{
+		{func(_ any, _ grpc.ServerStream) error { return nil }, nil},
+		{func(_ any, _ grpc.ServerStream) error {
+			panic("panic")
+		}, status.Error(codes.Internal, "internal server error")},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can recover %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			err := RecoverStream()(nil, &serverStream{ctx: t.Context()}, nil,
+				test.inpHandler)
+			t.Logf("err: %v", err)
+			require.Equal(t, test.err, err)
+		})
+	}
+}
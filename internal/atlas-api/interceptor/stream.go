@@ -0,0 +1,20 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// serverStream wraps a grpc.ServerStream to replace its context, such as with
+// logging fields or a Session.
+type serverStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+// Context returns the serverStream's context.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
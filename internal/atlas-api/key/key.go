@@ -7,3 +7,69 @@ import "fmt"
 func Disabled(orgID, keyID string) string {
 	return fmt.Sprintf("api:disabled:org:%s:key:%s", orgID, keyID)
 }
+
+// OrgDisabled returns a cache key to support organizations pending deletion.
+func OrgDisabled(orgID string) string {
+	return fmt.Sprintf("api:disabled:org:%s", orgID)
+}
+
+// OrgDeletion returns a cache key to support leases on organization purges.
+func OrgDeletion(orgID string) string {
+	return fmt.Sprintf("api:deletion:org:%s", orgID)
+}
+
+// SessionRevoked returns a cache key to support revoked user sessions.
+func SessionRevoked(orgID, sessID string) string {
+	return fmt.Sprintf("api:revoked:org:%s:session:%s", orgID, sessID)
+}
+
+// OIDCState returns a cache key to support pending OIDC logins.
+func OIDCState(state string) string {
+	return fmt.Sprintf("api:oidc:state:%s", state)
+}
+
+// MFAChallenge returns a cache key to support pending MFA logins.
+func MFAChallenge(token string) string {
+	return fmt.Sprintf("api:mfa:challenge:%s", token)
+}
+
+// MFAAttempts returns a cache key to support counting MFA challenge attempts.
+func MFAAttempts(token string) string {
+	return fmt.Sprintf("api:mfa:attempts:%s", token)
+}
+
+// PasswordReset returns a cache key to support pending password resets by
+// token hash.
+func PasswordReset(tokenHash string) string {
+	return fmt.Sprintf("api:reset:token:%s", tokenHash)
+}
+
+// PasswordResetUser returns a cache key to support the latest password reset
+// token of a user.
+func PasswordResetUser(orgID, userID string) string {
+	return fmt.Sprintf("api:reset:org:%s:user:%s", orgID, userID)
+}
+
+// PasswordResetAttempt returns a cache key to support rate limits on password
+// reset completions by client IP.
+func PasswordResetAttempt(ip string) string {
+	return fmt.Sprintf("api:reset:attempt:ip:%s", ip)
+}
+
+// PasswordResetRate returns a cache key to support rate limits on password
+// reset requests.
+func PasswordResetRate(orgName, email string) string {
+	return fmt.Sprintf("api:reset:rate:org:%s:email:%s", orgName, email)
+}
+
+// LoginFailures returns a cache key to support counts of failed logins by org
+// name and email.
+func LoginFailures(orgName, email string) string {
+	return fmt.Sprintf("api:login:org:%s:email:%s", orgName, email)
+}
+
+// LoginIPFailures returns a cache key to support counts of failed logins by
+// source IP.
+func LoginIPFailures(ip string) string {
+	return fmt.Sprintf("api:login:ip:%s", ip)
+}
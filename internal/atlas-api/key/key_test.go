@@ -8,6 +8,7 @@ import (
 	"uuid"
 
 	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/random"
 )
 
 func TestDisabled(t *testing.T) {
@@ -29,3 +30,170 @@ func TestDisabled(t *testing.T) {
 		})
 	}
 }
+
+func TestOrgDisabled(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can key %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			orgID := uuid.NewV7().String()
+
+			key := OrgDisabled(orgID)
+			t.Logf("key: %v", key)
+
+			require.Equal(t, fmt.Sprintf("api:disabled:org:%s", orgID), key)
+			require.Equal(t, key, OrgDisabled(orgID))
+			require.NotEqual(t, key, Disabled(orgID, ""))
+		})
+	}
+}
+
+func TestOrgDeletion(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can key %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			orgID := uuid.NewV7().String()
+
+			key := OrgDeletion(orgID)
+			t.Logf("key: %v", key)
+
+			require.Equal(t, fmt.Sprintf("api:deletion:org:%s", orgID), key)
+			require.Equal(t, key, OrgDeletion(orgID))
+		})
+	}
+}
+
+func TestSessionRevoked(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can key %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			orgID := uuid.NewV7().String()
+			sessID := uuid.NewV7().String()
+
+			key := SessionRevoked(orgID, sessID)
+			t.Logf("key: %v", key)
+
+			require.Equal(t, fmt.Sprintf("api:revoked:org:%s:session:%s", orgID,
+				sessID), key)
+			require.Equal(t, key, SessionRevoked(orgID, sessID))
+			require.NotEqual(t, key, Disabled(orgID, sessID))
+		})
+	}
+}
+
+func TestOIDCState(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can key %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			state := random.String(43)
+
+			key := OIDCState(state)
+			t.Logf("key: %v", key)
+
+			require.Equal(t, fmt.Sprintf("api:oidc:state:%s", state), key)
+			require.Equal(t, key, OIDCState(state))
+		})
+	}
+}
+
+func TestMFAChallenge(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can key %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			token := random.String(26)
+
+			key := MFAChallenge(token)
+			t.Logf("key: %v", key)
+
+			require.Equal(t, fmt.Sprintf("api:mfa:challenge:%s", token), key)
+			require.Equal(t, key, MFAChallenge(token))
+			require.NotEqual(t, key, OIDCState(token))
+		})
+	}
+}
+
+func TestMFAAttempts(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can key %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			token := random.String(26)
+
+			key := MFAAttempts(token)
+			t.Logf("key: %v", key)
+
+			require.Equal(t, fmt.Sprintf("api:mfa:attempts:%s", token), key)
+			require.Equal(t, key, MFAAttempts(token))
+			require.NotEqual(t, key, MFAChallenge(token))
+		})
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can key %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			hash := random.String(64)
+			orgID := uuid.NewV7().String()
+			userID := uuid.NewV7().String()
+			email := random.Email()
+			ip := fmt.Sprintf("10.0.0.%d", i)
+
+			key := PasswordReset(hash)
+			userKey := PasswordResetUser(orgID, userID)
+			rateKey := PasswordResetRate(orgID, email)
+			t.Logf("key, userKey, rateKey: %v, %v, %v", key, userKey, rateKey)
+
+			require.Equal(t, fmt.Sprintf("api:reset:token:%s", hash), key)
+			require.Equal(t, fmt.Sprintf("api:reset:attempt:ip:%s", ip),
+				PasswordResetAttempt(ip))
+			require.Equal(t, fmt.Sprintf("api:reset:org:%s:user:%s", orgID,
+				userID), userKey)
+			require.Equal(t, fmt.Sprintf("api:reset:rate:org:%s:email:%s",
+				orgID, email), rateKey)
+			require.NotEqual(t, userKey, PasswordResetUser(userID, orgID))
+		})
+	}
+}
+
+func TestLoginFailures(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can key %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			orgName := random.String(10)
+			email := random.Email()
+			ip := fmt.Sprintf("10.0.0.%d", i)
+
+			key := LoginFailures(orgName, email)
+			ipKey := LoginIPFailures(ip)
+			t.Logf("key, ipKey: %v, %v", key, ipKey)
+
+			require.Equal(t, fmt.Sprintf("api:login:org:%s:email:%s", orgName,
+				email), key)
+			require.Equal(t, fmt.Sprintf("api:login:ip:%s", ip), ipKey)
+			require.NotEqual(t, key, LoginFailures(email, orgName))
+		})
+	}
+}
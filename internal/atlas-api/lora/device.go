@@ -46,3 +46,25 @@ func (cs *Chirpstack) DeleteDevice(ctx context.Context, uniqID string) error {
 
 	return err
 }
+
+// EnqueueDownlink enqueues a confirmed downlink to a device by UniqID, port,
+// and payload, and returns the queue item ID. The queue item ID is included in
+// subsequent ACK and TX ACK events.
+func (cs *Chirpstack) EnqueueDownlink(
+	ctx context.Context, uniqID string, fPort uint32, data []byte,
+) (string, error) {
+	devCli := api.NewDeviceServiceClient(cs.conn)
+	resp, err := devCli.Enqueue(ctx, &api.EnqueueDeviceQueueItemRequest{
+		QueueItem: &api.DeviceQueueItem{
+			DevEui:    uniqID,
+			Confirmed: true,
+			FPort:     fPort,
+			Data:      data,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GetId(), nil
+}
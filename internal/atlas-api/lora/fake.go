@@ -1,6 +1,9 @@
 package lora
 
 import (
+	"context"
+	"uuid"
+
 	"github.com/thingspect/atlas/pkg/alog"
 	"go.uber.org/mock/gomock"
 )
@@ -19,6 +22,12 @@ func NewFake() Loraer {
 		Return(nil).AnyTimes()
 	loraer.EXPECT().DeleteDevice(gomock.Any(), gomock.Any()).Return(nil).
 		AnyTimes()
+	loraer.EXPECT().EnqueueDownlink(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).DoAndReturn(func(_ context.Context, _ string, _ uint32,
+		_ []byte,
+	) (string, error) {
+		return uuid.NewV7().String(), nil
+	}).AnyTimes()
 
 	return loraer
 }
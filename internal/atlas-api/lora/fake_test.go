@@ -31,6 +31,11 @@ func TestNewFake(t *testing.T) {
 			require.NoError(t, loraer.CreateDevice(ctx, random.String(16),
 				random.String(32)))
 			require.NoError(t, loraer.DeleteDevice(ctx, random.String(16)))
+
+			queueID, err := loraer.EnqueueDownlink(ctx, random.String(16), 1,
+				random.Bytes(10))
+			require.NoError(t, err)
+			require.NotEmpty(t, queueID)
 		})
 	}
 }
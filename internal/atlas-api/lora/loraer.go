@@ -15,4 +15,8 @@ type Loraer interface {
 	CreateDevice(ctx context.Context, uniqID, appKey string) error
 	// DeleteDevice deletes a device by UniqID.
 	DeleteDevice(ctx context.Context, uniqID string) error
+	// EnqueueDownlink enqueues a confirmed downlink to a device by UniqID,
+	// port, and payload, and returns the queue item ID.
+	EnqueueDownlink(ctx context.Context, uniqID string, fPort uint32,
+		data []byte) (string, error)
 }
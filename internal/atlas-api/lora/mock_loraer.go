@@ -95,3 +95,18 @@ func (mr *MockLoraerMockRecorder) DeleteGateway(ctx, uniqID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGateway", reflect.TypeOf((*MockLoraer)(nil).DeleteGateway), ctx, uniqID)
 }
+
+// EnqueueDownlink mocks base method.
+func (m *MockLoraer) EnqueueDownlink(ctx context.Context, uniqID string, fPort uint32, data []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueDownlink", ctx, uniqID, fPort, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueDownlink indicates an expected call of EnqueueDownlink.
+func (mr *MockLoraerMockRecorder) EnqueueDownlink(ctx, uniqID, fPort, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueDownlink", reflect.TypeOf((*MockLoraer)(nil).EnqueueDownlink), ctx, uniqID, fPort, data)
+}
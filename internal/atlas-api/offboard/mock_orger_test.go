@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: offboard.go
+//
+// Generated by this command:
+//
+//	mockgen -source offboard.go -destination mock_orger_test.go -package offboard
+//
+
+// Package offboard is a generated GoMock package.
+package offboard
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	api "github.com/thingspect/proto/go/api"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrger is a mock of Orger interface.
+type MockOrger struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrgerMockRecorder
+	isgomock struct{}
+}
+
+// MockOrgerMockRecorder is the mock recorder for MockOrger.
+type MockOrgerMockRecorder struct {
+	mock *MockOrger
+}
+
+// NewMockOrger creates a new mock instance.
+func NewMockOrger(ctrl *gomock.Controller) *MockOrger {
+	mock := &MockOrger{ctrl: ctrl}
+	mock.recorder = &MockOrgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrger) EXPECT() *MockOrgerMockRecorder {
+	return m.recorder
+}
+
+// CompleteDeletion mocks base method.
+func (m *MockOrger) CompleteDeletion(ctx context.Context, orgID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteDeletion", ctx, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteDeletion indicates an expected call of CompleteDeletion.
+func (mr *MockOrgerMockRecorder) CompleteDeletion(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDeletion", reflect.TypeOf((*MockOrger)(nil).CompleteDeletion), ctx, orgID)
+}
+
+// ListDeletions mocks base method.
+func (m *MockOrger) ListDeletions(ctx context.Context) ([]*message.OrgDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletions", ctx)
+	ret0, _ := ret[0].([]*message.OrgDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletions indicates an expected call of ListDeletions.
+func (mr *MockOrgerMockRecorder) ListDeletions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletions", reflect.TypeOf((*MockOrger)(nil).ListDeletions), ctx)
+}
+
+// PurgeDeletion mocks base method.
+func (m *MockOrger) PurgeDeletion(ctx context.Context, orgID string, limit int32) (string, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletion", ctx, orgID, limit)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PurgeDeletion indicates an expected call of PurgeDeletion.
+func (mr *MockOrgerMockRecorder) PurgeDeletion(ctx, orgID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletion", reflect.TypeOf((*MockOrger)(nil).PurgeDeletion), ctx, orgID, limit)
+}
+
+// UpdateDeletion mocks base method.
+func (m *MockOrger) UpdateDeletion(ctx context.Context, orgID, step string, count int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeletion", ctx, orgID, step, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeletion indicates an expected call of UpdateDeletion.
+func (mr *MockOrgerMockRecorder) UpdateDeletion(ctx, orgID, step, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeletion", reflect.TypeOf((*MockOrger)(nil).UpdateDeletion), ctx, orgID, step, count)
+}
+
+// MockDevicer is a mock of Devicer interface.
+type MockDevicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDevicerMockRecorder
+	isgomock struct{}
+}
+
+// MockDevicerMockRecorder is the mock recorder for MockDevicer.
+type MockDevicerMockRecorder struct {
+	mock *MockDevicer
+}
+
+// NewMockDevicer creates a new mock instance.
+func NewMockDevicer(ctrl *gomock.Controller) *MockDevicer {
+	mock := &MockDevicer{ctrl: ctrl}
+	mock.recorder = &MockDevicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDevicer) EXPECT() *MockDevicerMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockDevicer) Delete(ctx context.Context, devID, orgID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, devID, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDevicerMockRecorder) Delete(ctx, devID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDevicer)(nil).Delete), ctx, devID, orgID)
+}
+
+// List mocks base method.
+func (m *MockDevicer) List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string, limit int32, tag string, scopeTags []string) ([]*api.Device, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, orgID, lBoundTS, prevID, limit, tag, scopeTags)
+	ret0, _ := ret[0].([]*api.Device)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockDevicerMockRecorder) List(ctx, orgID, lBoundTS, prevID, limit, tag, scopeTags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDevicer)(nil).List), ctx, orgID, lBoundTS, prevID, limit, tag, scopeTags)
+}
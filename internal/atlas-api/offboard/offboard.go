@@ -0,0 +1,274 @@
+// Package offboard provides functions to purge organizations that are pending
+// deletion.
+package offboard
+
+//go:generate mockgen -source offboard.go -destination mock_orger_test.go -package offboard
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/internal/atlas-api/lora"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/org"
+	"github.com/thingspect/atlas/pkg/metric"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// leaseExp is the expiration of a purge lease. Leases are renewed after each
+// batch, and expire to allow purges that were interrupted by a crash to
+// resume.
+const leaseExp = 5 * time.Minute
+
+// Orger defines the methods provided by an org.DAO.
+type Orger interface {
+	ListDeletions(ctx context.Context) ([]*message.OrgDeletion, error)
+	UpdateDeletion(ctx context.Context, orgID, step string,
+		count int64) error
+	PurgeDeletion(ctx context.Context, orgID string, limit int32) (string,
+		int64, error)
+	CompleteDeletion(ctx context.Context, orgID string) error
+}
+
+// Devicer defines the methods provided by a device.DAO.
+type Devicer interface {
+	List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string,
+		limit int32, tag string, scopeTags []string) ([]*api.Device, int32,
+		error)
+	Delete(ctx context.Context, devID, orgID string) error
+}
+
+// Offboarder purges organizations that are pending deletion.
+type Offboarder struct {
+	orgDAO Orger
+	devDAO Devicer
+	lora   lora.Loraer
+	cache  cache.Cacher[string]
+
+	batchSize int32
+}
+
+// New instantiates and returns a new Offboarder.
+func New(
+	orgDAO Orger, devDAO Devicer, lora lora.Loraer, cache cache.Cacher[string],
+	batchSize int32,
+) *Offboarder {
+	return &Offboarder{
+		orgDAO: orgDAO,
+		devDAO: devDAO,
+		lora:   lora,
+		cache:  cache,
+
+		batchSize: batchSize,
+	}
+}
+
+// Start purges an organization in the background.
+func (o *Offboarder) Start(orgID string) {
+	go o.purge(orgID)
+}
+
+// Serve resumes pending deletions immediately and then once per interval, such
+// as those interrupted by a crash. Serve blocks and should be run in a
+// goroutine.
+func (o *Offboarder) Serve(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		o.resume()
+		<-ticker.C
+	}
+}
+
+// resume purges all pending deletions.
+func (o *Offboarder) resume() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	dels, err := o.orgDAO.ListDeletions(ctx)
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "listdeletions"})
+		alog.Errorf("resume o.orgDAO.ListDeletions: %v", err)
+
+		return
+	}
+
+	for _, del := range dels {
+		o.purge(del.GetOrgId())
+	}
+}
+
+// purge removes an organization's devices, including from LoRaWAN, purges its
+// child rows in batches, and removes the organization. Progress is recorded
+// after each batch, and each step is idempotent, so that purges can resume.
+// Only one instance purges an organization at a time.
+func (o *Offboarder) purge(orgID string) {
+	logger := alog.WithField("orgID", orgID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	err := o.cache.SetIfNotExistTTL(ctx, key.OrgDeletion(orgID), "", leaseExp)
+	cancel()
+	if errors.Is(err, cache.ErrAlreadyExists) {
+		logger.Debug("purge lease held, skipping")
+
+		return
+	}
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "lease"})
+		logger.Errorf("purge o.cache.SetIfNotExistTTL: %v", err)
+
+		return
+	}
+
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := o.cache.Del(ctx, key.OrgDeletion(orgID)); err != nil {
+			logger.Errorf("purge o.cache.Del: %v", err)
+		}
+	}()
+
+	if err := o.removeDevices(orgID); err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "removedevices"})
+		logger.Errorf("purge o.removeDevices: %v", err)
+
+		return
+	}
+
+	total, err := o.purgeTables(orgID)
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "purgetables"})
+		logger.Errorf("purge o.purgeTables: %v", err)
+
+		return
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	err = o.orgDAO.CompleteDeletion(ctx, orgID)
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{
+			metric.TagFunc: "completedeletion",
+		})
+		logger.Errorf("purge o.orgDAO.CompleteDeletion: %v", err)
+
+		return
+	}
+
+	metric.Incr("deleted", nil)
+	logger.Infof("purge deleted org, purged %v rows", total)
+}
+
+// removeDevices removes an organization's devices in batches, including any
+// LoRaWAN devices and gateways.
+func (o *Offboarder) removeDevices(orgID string) error {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(),
+			30*time.Second)
+
+		// Removed devices are no longer listed, so the first page is always
+		// requested.
+		devs, _, err := o.devDAO.List(ctx, orgID, time.Time{}, "", o.batchSize,
+			"", nil)
+		if err != nil {
+			cancel()
+
+			return err
+		}
+
+		for _, dev := range devs {
+			if err := o.removeDevice(ctx, dev); err != nil {
+				cancel()
+
+				return err
+			}
+		}
+
+		if len(devs) > 0 {
+			if err := o.progress(ctx, orgID, org.StepDevices,
+				int64(len(devs))); err != nil {
+				cancel()
+
+				return err
+			}
+		}
+		cancel()
+
+		if len(devs) < int(o.batchSize) {
+			return nil
+		}
+	}
+}
+
+// removeDevice removes a device, including any LoRaWAN device and gateway.
+func (o *Offboarder) removeDevice(ctx context.Context, dev *api.Device) error {
+	// Delete any gateways and devices present. 'Unauthenticated' is currently
+	// returned for gateways and devices that do not exist.
+	err := o.lora.DeleteGateway(ctx, dev.GetUniqId())
+	if code := status.Code(err); code != codes.OK &&
+		code != codes.Unauthenticated {
+		return err
+	}
+
+	err = o.lora.DeleteDevice(ctx, dev.GetUniqId())
+	if code := status.Code(err); code != codes.OK &&
+		code != codes.Unauthenticated {
+		return err
+	}
+
+	if err := o.devDAO.Delete(ctx, dev.GetId(),
+		dev.GetOrgId()); err != nil && !errors.Is(err, dao.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// purgeTables purges an organization's child rows in batches, and returns the
+// number of rows deleted.
+func (o *Offboarder) purgeTables(orgID string) (int64, error) {
+	var total int64
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		table, count, err := o.orgDAO.PurgeDeletion(ctx, orgID, o.batchSize)
+		if err != nil {
+			cancel()
+
+			return total, err
+		}
+
+		if count == 0 {
+			cancel()
+
+			return total, nil
+		}
+
+		total += count
+		metric.Count("purged", int(count), map[string]string{"table": table})
+
+		err = o.progress(ctx, orgID, table, count)
+		cancel()
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// progress records the progress of a deletion and renews its lease.
+func (o *Offboarder) progress(
+	ctx context.Context, orgID, step string, count int64,
+) error {
+	if err := o.orgDAO.UpdateDeletion(ctx, orgID, step, count); err != nil {
+		return err
+	}
+
+	return o.cache.SetTTL(ctx, key.OrgDeletion(orgID), "", leaseExp)
+}
@@ -0,0 +1,251 @@
+//go:build !integration
+
+package offboard
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/internal/atlas-api/lora"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/consterr"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/org"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	errTestProc consterr.Error = "offboard: test processor error"
+	testTimeout                = 2 * time.Second
+)
+
+func TestPurge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpDevCounts []int
+		inpLoraErr   error
+		inpDevErr    error
+		inpCounts    []int64
+	}{
+		{[]int{0}, nil, nil, []int64{0}},
+		{[]int{2, 1}, nil, nil, []int64{2, 2, 1, 0}},
+		{[]int{2, 0}, status.Error(codes.Unauthenticated, "not found"),
+			dao.ErrNotFound, []int64{1, 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can purge %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			orgID := uuid.NewV7().String()
+
+			ctrl := gomock.NewController(t)
+			devicer := NewMockDevicer(ctrl)
+			loraer := lora.NewMockLoraer(ctrl)
+			orger := NewMockOrger(ctrl)
+
+			var calls []any
+			for _, devCount := range test.inpDevCounts {
+				devs := []*api.Device{}
+				for range devCount {
+					devs = append(devs, random.Device("off", orgID))
+				}
+
+				calls = append(calls, devicer.EXPECT().List(gomock.Any(), orgID,
+					time.Time{}, "", int32(2), "", nil).
+					Return(devs, int32(len(devs)), nil).Times(1))
+
+				for _, dev := range devs {
+					loraer.EXPECT().DeleteGateway(gomock.Any(),
+						dev.GetUniqId()).Return(test.inpLoraErr).Times(1)
+					loraer.EXPECT().DeleteDevice(gomock.Any(),
+						dev.GetUniqId()).Return(test.inpLoraErr).Times(1)
+					devicer.EXPECT().Delete(gomock.Any(), dev.GetId(),
+						orgID).Return(test.inpDevErr).Times(1)
+				}
+
+				if devCount > 0 {
+					calls = append(calls, orger.EXPECT().UpdateDeletion(
+						gomock.Any(), orgID, org.StepDevices,
+						int64(devCount)).Return(nil).Times(1))
+				}
+			}
+
+			for _, count := range test.inpCounts {
+				var table string
+				if count > 0 {
+					table = "data_points"
+				}
+
+				calls = append(calls, orger.EXPECT().PurgeDeletion(
+					gomock.Any(), orgID, int32(2)).Return(table, count, nil).
+					Times(1))
+
+				if count > 0 {
+					calls = append(calls, orger.EXPECT().UpdateDeletion(
+						gomock.Any(), orgID, table, count).Return(nil).
+						Times(1))
+				}
+			}
+
+			calls = append(calls, orger.EXPECT().CompleteDeletion(gomock.Any(),
+				orgID).Return(nil).Times(1))
+			gomock.InOrder(calls...)
+
+			c := cache.NewHeap[string]()
+			New(orger, devicer, loraer, c, 2).purge(orgID)
+
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			// Verify the lease is released.
+			_, err := c.Get(ctx, key.OrgDeletion(orgID))
+			t.Logf("err: %v", err)
+			require.Equal(t, cache.ErrNotFound, err)
+		})
+	}
+}
+
+func TestPurgeError(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.NewV7().String()
+	dev := random.Device("off", orgID)
+
+	tests := []struct {
+		inpLease      bool
+		inpListErr    error
+		inpLoraErr    error
+		inpDevErr     error
+		inpPurgeErr   error
+		inpPurgeTimes int
+		inpCompErr    error
+		inpCompTimes  int
+	}{
+		// Lease held by another purge.
+		{true, nil, nil, nil, nil, 0, nil, 0},
+		// Device removal errors.
+		{false, errTestProc, nil, nil, nil, 0, nil, 0},
+		{false, nil, status.Error(codes.Unavailable, "unavailable"), nil,
+			nil, 0, nil, 0},
+		{false, nil, nil, errTestProc, nil, 0, nil, 0},
+		// Purge and completion errors.
+		{false, nil, nil, nil, errTestProc, 1, nil, 0},
+		{false, nil, nil, nil, nil, 1, dao.ErrInvalidFormat, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cannot purge %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			devicer := NewMockDevicer(ctrl)
+			loraer := lora.NewMockLoraer(ctrl)
+			orger := NewMockOrger(ctrl)
+
+			devs := []*api.Device{}
+			if test.inpListErr == nil {
+				devs = append(devs, dev)
+			}
+
+			listTimes := 1
+			if test.inpLease {
+				listTimes = 0
+			}
+			devicer.EXPECT().List(gomock.Any(), orgID, time.Time{}, "",
+				int32(2), "", nil).Return(devs, int32(len(devs)),
+				test.inpListErr).Times(listTimes)
+
+			loraTimes, devTimes, updateTimes := 0, 0, 0
+			if len(devs) > 0 && !test.inpLease {
+				loraTimes = 1
+				if test.inpLoraErr == nil {
+					devTimes = 1
+					if test.inpDevErr == nil {
+						updateTimes = 1
+					}
+				}
+			}
+			loraer.EXPECT().DeleteGateway(gomock.Any(), dev.GetUniqId()).
+				Return(test.inpLoraErr).Times(loraTimes)
+			loraer.EXPECT().DeleteDevice(gomock.Any(), dev.GetUniqId()).
+				Return(nil).Times(devTimes)
+			devicer.EXPECT().Delete(gomock.Any(), dev.GetId(), orgID).
+				Return(test.inpDevErr).Times(devTimes)
+			orger.EXPECT().UpdateDeletion(gomock.Any(), orgID,
+				org.StepDevices, int64(1)).Return(nil).Times(updateTimes)
+
+			orger.EXPECT().PurgeDeletion(gomock.Any(), orgID, int32(2)).
+				Return("", int64(0), test.inpPurgeErr).
+				Times(test.inpPurgeTimes)
+			orger.EXPECT().CompleteDeletion(gomock.Any(), orgID).
+				Return(test.inpCompErr).Times(test.inpCompTimes)
+
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			c := cache.NewHeap[string]()
+			if test.inpLease {
+				require.NoError(t, c.Set(ctx, key.OrgDeletion(orgID), ""))
+			}
+
+			New(orger, devicer, loraer, c, 2).purge(orgID)
+
+			// Verify the lease is released, unless held by another purge.
+			_, err := c.Get(ctx, key.OrgDeletion(orgID))
+			t.Logf("err: %v", err)
+			if test.inpLease {
+				require.NoError(t, err)
+			} else {
+				require.Equal(t, cache.ErrNotFound, err)
+			}
+		})
+	}
+}
+
+func TestResume(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Resume pending deletions", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+
+		ctrl := gomock.NewController(t)
+		orger := NewMockOrger(ctrl)
+		orger.EXPECT().ListDeletions(gomock.Any()).
+			Return([]*message.OrgDeletion{{OrgId: orgID}}, nil).Times(1)
+		orger.EXPECT().PurgeDeletion(gomock.Any(), orgID, int32(2)).
+			Return("", int64(0), nil).Times(1)
+		orger.EXPECT().CompleteDeletion(gomock.Any(), orgID).Return(nil).
+			Times(1)
+
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().List(gomock.Any(), orgID, time.Time{}, "", int32(2),
+			"", nil).Return(nil, int32(0), nil).Times(1)
+
+		New(orger, devicer, lora.NewMockLoraer(ctrl), cache.NewHeap[string](),
+			2).resume()
+	})
+
+	t.Run("Resume with list error", func(t *testing.T) {
+		t.Parallel()
+
+		orger := NewMockOrger(gomock.NewController(t))
+		orger.EXPECT().ListDeletions(gomock.Any()).Return(nil, errTestProc).
+			Times(1)
+
+		New(orger, nil, nil, nil, 2).resume()
+	})
+}
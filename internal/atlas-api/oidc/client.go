@@ -0,0 +1,206 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+)
+
+// maxBody is the maximum response body size read from an identity provider.
+const maxBody = 1 << 20
+
+// Client holds references to an HTTP client and implements the Authenticator
+// interface.
+type Client struct {
+	httpClient *http.Client
+}
+
+// Verify Client implements Authenticator.
+var _ Authenticator = &Client{}
+
+// NewClient builds a new Authenticator and returns it.
+func NewClient(timeout time.Duration) Authenticator {
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: timeout,
+			// Identity provider endpoints do not redirect.
+			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// provider holds the discovered endpoints of an identity provider.
+type provider struct {
+	Issuer   string `json:"issuer"`
+	AuthURL  string `json:"authorization_endpoint"`
+	TokenURL string `json:"token_endpoint"`
+	JWKSURL  string `json:"jwks_uri"`
+}
+
+// discover retrieves the configuration of an identity provider by issuer.
+func (c *Client) discover(ctx context.Context, issuer string) (
+	*provider, error,
+) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration",
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDiscovery, err)
+	}
+
+	prov := &provider{}
+	if err := c.doJSON(req, prov); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDiscovery, err)
+	}
+
+	if prov.Issuer != issuer {
+		return nil, fmt.Errorf("%w: issuer mismatch %q", ErrDiscovery,
+			prov.Issuer)
+	}
+
+	if prov.AuthURL == "" || prov.TokenURL == "" || prov.JWKSURL == "" {
+		return nil, fmt.Errorf("%w: missing endpoints", ErrDiscovery)
+	}
+
+	return prov, nil
+}
+
+// AuthCodeURL returns the identity provider URL that a user is redirected to
+// for authentication, using the provided state, nonce, and PKCE challenge.
+func (c *Client) AuthCodeURL(
+	ctx context.Context, conf *Config, state, nonce, challenge string,
+) (string, error) {
+	prov, err := c.discover(ctx, conf.Issuer)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(prov.AuthURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrDiscovery, err)
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", conf.ClientID)
+	query.Set("redirect_uri", conf.RedirectURI)
+	query.Set("scope", "openid email profile")
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", challenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// Identify exchanges an authorization code and PKCE verifier for an ID token,
+// verifies it against the provided nonce, and returns its claims.
+func (c *Client) Identify(
+	ctx context.Context, conf *Config, code, verifier, nonce string,
+) (*Claims, error) {
+	prov, err := c.discover(ctx, conf.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	rawToken, err := c.exchange(ctx, prov, conf, code, verifier)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := c.keys(ctx, prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return verify(rawToken, keys, prov.Issuer, conf.ClientID, nonce,
+		time.Now())
+}
+
+// exchange exchanges an authorization code for a raw ID token.
+func (c *Client) exchange(
+	ctx context.Context, prov *provider, conf *Config, code, verifier string,
+) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", conf.RedirectURI)
+	form.Set("code_verifier", verifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		prov.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrExchange, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(conf.ClientID),
+		url.QueryEscape(conf.ClientSecret))
+
+	tokenResp := &struct {
+		IDToken string `json:"id_token"`
+	}{}
+	if err := c.doJSON(req, tokenResp); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrExchange, err)
+	}
+
+	if tokenResp.IDToken == "" {
+		return "", fmt.Errorf("%w: missing id_token", ErrExchange)
+	}
+
+	return tokenResp.IDToken, nil
+}
+
+// keys retrieves the signing keys of an identity provider.
+func (c *Client) keys(ctx context.Context, prov *provider) ([]*jwk, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, prov.JWKSURL,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDiscovery, err)
+	}
+
+	set := &struct {
+		Keys []*jwk `json:"keys"`
+	}{}
+	if err := c.doJSON(req, set); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDiscovery, err)
+	}
+
+	return set.Keys, nil
+}
+
+// doJSON performs a request and decodes a successful JSON response into v.
+func (c *Client) doJSON(req *http.Request, v any) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger := alog.FromContext(req.Context())
+			logger.Errorf("doJSON resp.Body.Close: %v", err)
+		}
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBody))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, body)
+	}
+
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,190 @@
+//go:build !integration
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/idp"
+	"github.com/thingspect/atlas/pkg/test/random"
+)
+
+const testTimeout = 5 * time.Second
+
+// authorize follows an authorization URL to the mock IdP and returns the
+// authorization code and state of its redirect.
+func authorize(t *testing.T, authURL string) (string, string) {
+	t.Helper()
+
+	client := &http.Client{
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet,
+		authURL, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+
+	return loc.Query().Get("code"), loc.Query().Get("state")
+}
+
+func TestIdentify(t *testing.T) {
+	t.Parallel()
+
+	mockIdP, err := idp.New()
+	require.NoError(t, err)
+	t.Cleanup(mockIdP.Close)
+
+	email := random.Email()
+	mockIdP.SetClaims(map[string]any{
+		"sub": random.String(10), "email": email, "email_verified": true,
+		"name": "oidc-" + random.String(10),
+	})
+
+	conf := &Config{
+		Issuer: mockIdP.URL, ClientID: mockIdP.ClientID,
+		ClientSecret: mockIdP.ClientSecret,
+		RedirectURI:  "https://atlas.example.com/v1/sessions/oidc/callback",
+	}
+
+	client := NewClient(testTimeout)
+
+	t.Run("Identify valid user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		state, err := NewRandom()
+		require.NoError(t, err)
+		nonce, err := NewRandom()
+		require.NoError(t, err)
+		verifier, challenge, err := NewPKCE()
+		require.NoError(t, err)
+
+		authURL, err := client.AuthCodeURL(ctx, conf, state, nonce, challenge)
+		t.Logf("authURL, err: %v, %v", authURL, err)
+		require.NoError(t, err)
+
+		code, retState := authorize(t, authURL)
+		require.Equal(t, state, retState)
+
+		claims, err := client.Identify(ctx, conf, code, verifier, nonce)
+		t.Logf("claims, err: %+v, %v", claims, err)
+		require.NoError(t, err)
+		require.Equal(t, email, claims.Email)
+		require.True(t, claims.EmailVerified)
+		require.NotEmpty(t, claims.Subject)
+		require.NotEmpty(t, claims.Name)
+
+		// Codes may be used once.
+		claims, err = client.Identify(ctx, conf, code, verifier, nonce)
+		t.Logf("claims, err: %+v, %v", claims, err)
+		require.Nil(t, claims)
+		require.ErrorIs(t, err, ErrExchange)
+	})
+
+	t.Run("Identify with wrong verifier", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		_, challenge, err := NewPKCE()
+		require.NoError(t, err)
+
+		authURL, err := client.AuthCodeURL(ctx, conf, "state", "nonce",
+			challenge)
+		require.NoError(t, err)
+
+		code, _ := authorize(t, authURL)
+
+		claims, err := client.Identify(ctx, conf, code, random.String(43),
+			"nonce")
+		t.Logf("claims, err: %+v, %v", claims, err)
+		require.Nil(t, claims)
+		require.ErrorIs(t, err, ErrExchange)
+	})
+
+	t.Run("Identify with wrong nonce", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		verifier, challenge, err := NewPKCE()
+		require.NoError(t, err)
+
+		authURL, err := client.AuthCodeURL(ctx, conf, "state", "nonce",
+			challenge)
+		require.NoError(t, err)
+
+		code, _ := authorize(t, authURL)
+
+		claims, err := client.Identify(ctx, conf, code, verifier,
+			random.String(10))
+		t.Logf("claims, err: %+v, %v", claims, err)
+		require.Nil(t, claims)
+		require.ErrorIs(t, err, ErrToken)
+	})
+
+	t.Run("Identify with wrong client secret", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		verifier, challenge, err := NewPKCE()
+		require.NoError(t, err)
+
+		authURL, err := client.AuthCodeURL(ctx, conf, "state", "nonce",
+			challenge)
+		require.NoError(t, err)
+
+		code, _ := authorize(t, authURL)
+
+		badConf := *conf
+		badConf.ClientSecret = random.String(20)
+		claims, err := client.Identify(ctx, &badConf, code, verifier, "nonce")
+		t.Logf("claims, err: %+v, %v", claims, err)
+		require.Nil(t, claims)
+		require.ErrorIs(t, err, ErrExchange)
+	})
+
+	t.Run("Discover unknown issuer", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		badConf := *conf
+		badConf.Issuer = mockIdP.URL + "/unknown"
+		authURL, err := client.AuthCodeURL(ctx, &badConf, "state", "nonce",
+			"challenge")
+		t.Logf("authURL, err: %v, %v", authURL, err)
+		require.Empty(t, authURL)
+		require.ErrorIs(t, err, ErrDiscovery)
+	})
+}
+
+func TestChallenge(t *testing.T) {
+	t.Parallel()
+
+	// RFC 7636 Appendix B.
+	require.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
@@ -0,0 +1,84 @@
+// Package oidc provides an OpenID Connect relying party for single sign-on
+// using the authorization code flow.
+package oidc
+
+//go:generate mockgen -source oidc.go -destination mock_authenticator.go -package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/thingspect/atlas/pkg/consterr"
+)
+
+// Errors returned due to OIDC failures.
+const (
+	ErrDiscovery consterr.Error = "oidc: provider discovery failed"
+	ErrExchange  consterr.Error = "oidc: code exchange failed"
+	ErrToken     consterr.Error = "oidc: invalid ID token"
+)
+
+// randLen is the length in bytes of random values, such as states, nonces,
+// and PKCE verifiers.
+const randLen = 32
+
+// Config holds the settings of a client registered with an identity provider.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+}
+
+// Claims holds the verified claims of an ID token.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+
+	// Raw holds all claims, including those above, as decoded from JSON.
+	Raw map[string]any
+}
+
+// Authenticator defines the methods provided by a Client.
+type Authenticator interface {
+	// AuthCodeURL returns the identity provider URL that a user is redirected
+	// to for authentication, using the provided state, nonce, and PKCE
+	// challenge.
+	AuthCodeURL(ctx context.Context, conf *Config, state, nonce,
+		challenge string) (string, error)
+	// Identify exchanges an authorization code and PKCE verifier for an ID
+	// token, verifies it against the provided nonce, and returns its claims.
+	Identify(ctx context.Context, conf *Config, code, verifier,
+		nonce string) (*Claims, error)
+}
+
+// NewRandom returns a random, URL-safe value for use as a state or nonce.
+func NewRandom() (string, error) {
+	b := make([]byte, randLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// NewPKCE returns a PKCE verifier and its S256 challenge.
+func NewPKCE() (string, string, error) {
+	verifier, err := NewRandom()
+	if err != nil {
+		return "", "", err
+	}
+
+	return verifier, Challenge(verifier), nil
+}
+
+// Challenge returns the S256 PKCE challenge of a verifier.
+func Challenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
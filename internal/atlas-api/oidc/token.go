@@ -0,0 +1,237 @@
+package oidc
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// leeway is the allowed clock skew when validating token expiration.
+const leeway = time.Minute
+
+// jwk represents a JSON Web Key.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// header represents the supported fields of a JWT header.
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// verify verifies the signature and standard claims of a raw ID token, and
+// returns its claims. Only the RS256 and ES256 algorithms are supported.
+func verify(
+	rawToken string, keys []*jwk, issuer, clientID, nonce string, now time.Time,
+) (*Claims, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed", ErrToken)
+	}
+
+	bHead, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrToken, err)
+	}
+
+	head := &header{}
+	if err := json.Unmarshal(bHead, head); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrToken, err)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrToken, err)
+	}
+
+	if err := verifySig(head, keys, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	bPayload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrToken, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(bPayload))
+	dec.UseNumber()
+	raw := map[string]any{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrToken, err)
+	}
+
+	if err := validateClaims(raw, issuer, clientID, nonce, now); err != nil {
+		return nil, err
+	}
+
+	claims := &Claims{Raw: raw}
+	claims.Subject, _ = raw["sub"].(string)
+	claims.Email, _ = raw["email"].(string)
+	claims.Name, _ = raw["name"].(string)
+
+	// Some providers encode email_verified as a string.
+	switch verified := raw["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = verified
+	case string:
+		claims.EmailVerified = verified == "true"
+	}
+
+	return claims, nil
+}
+
+// verifySig verifies the signature of a JWT signing input using a matching key.
+func verifySig(head *header, keys []*jwk, input string, sig []byte) error {
+	hash := sha256.Sum256([]byte(input))
+
+	for _, key := range keys {
+		if head.Kid != "" && key.Kid != head.Kid {
+			continue
+		}
+
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		switch {
+		case head.Alg == "RS256" && key.Kty == "RSA":
+			pub, err := key.rsaKey()
+			if err != nil {
+				return err
+			}
+
+			if rsa.VerifyPKCS1v15(pub, crypto.SHA256, hash[:], sig) == nil {
+				return nil
+			}
+		case head.Alg == "ES256" && key.Kty == "EC" && key.Crv == "P-256":
+			pub, err := key.ecKey()
+			if err != nil {
+				return err
+			}
+
+			if len(sig) == 64 && ecdsa.Verify(pub, hash[:],
+				new(big.Int).SetBytes(sig[:32]),
+				new(big.Int).SetBytes(sig[32:])) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: signature not verified", ErrToken)
+}
+
+// validateClaims validates the standard claims of an ID token.
+func validateClaims(
+	raw map[string]any, issuer, clientID, nonce string, now time.Time,
+) error {
+	if iss, _ := raw["iss"].(string); iss != issuer {
+		return fmt.Errorf("%w: issuer mismatch", ErrToken)
+	}
+
+	var auds []string
+	switch aud := raw["aud"].(type) {
+	case string:
+		auds = []string{aud}
+	case []any:
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				auds = append(auds, s)
+			}
+		}
+	}
+
+	if !slices.Contains(auds, clientID) {
+		return fmt.Errorf("%w: audience mismatch", ErrToken)
+	}
+
+	if azp, ok := raw["azp"].(string); ok && azp != clientID {
+		return fmt.Errorf("%w: authorized party mismatch", ErrToken)
+	}
+
+	exp, err := numericDate(raw["exp"])
+	if err != nil || !now.Before(exp.Add(leeway)) {
+		return fmt.Errorf("%w: expired", ErrToken)
+	}
+
+	if tokNonce, _ := raw["nonce"].(string); tokNonce != nonce {
+		return fmt.Errorf("%w: nonce mismatch", ErrToken)
+	}
+
+	if sub, _ := raw["sub"].(string); sub == "" {
+		return fmt.Errorf("%w: missing subject", ErrToken)
+	}
+
+	return nil
+}
+
+// numericDate converts a JSON number of seconds since the epoch to a time.
+func numericDate(v any) (time.Time, error) {
+	num, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: invalid date", ErrToken)
+	}
+
+	secs, err := num.Float64()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrToken, err)
+	}
+
+	return time.Unix(int64(secs), 0), nil
+}
+
+// rsaKey returns the RSA public key of a JWK.
+func (k *jwk) rsaKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrToken, err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, fmt.Errorf("%w: invalid exponent", ErrToken)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// ecKey returns the P-256 public key of a JWK.
+func (k *jwk) ecKey() (*ecdsa.PublicKey, error) {
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil || len(x) != 32 {
+		return nil, fmt.Errorf("%w: invalid x coordinate", ErrToken)
+	}
+
+	y, err := base64.RawURLEncoding.DecodeString(k.Y)
+	if err != nil || len(y) != 32 {
+		return nil, fmt.Errorf("%w: invalid y coordinate", ErrToken)
+	}
+
+	pub, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(),
+		append(append([]byte{4}, x...), y...))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrToken, err)
+	}
+
+	return pub, nil
+}
@@ -0,0 +1,188 @@
+//go:build !integration
+
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/idp"
+	"github.com/thingspect/atlas/pkg/test/random"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	mockIdP, err := idp.New()
+	require.NoError(t, err)
+	t.Cleanup(mockIdP.Close)
+
+	// Use the mock IdP's published keys.
+	client, _ := NewClient(testTimeout).(*Client)
+	prov, err := client.discover(t.Context(), mockIdP.URL)
+	require.NoError(t, err)
+	keys, err := client.keys(t.Context(), prov)
+	require.NoError(t, err)
+
+	email := random.Email()
+	nonce := random.String(10)
+	now := time.Now()
+
+	valid := func() map[string]any {
+		return map[string]any{
+			"iss": mockIdP.URL, "aud": mockIdP.ClientID, "sub": "oidc-sub",
+			"exp": now.Add(time.Hour).Unix(), "iat": now.Unix(),
+			"nonce": nonce, "email": email, "email_verified": "true",
+		}
+	}
+
+	tests := []struct {
+		inpMod func(claims map[string]any)
+		err    string
+	}{
+		{func(_ map[string]any) {}, ""},
+		{func(claims map[string]any) {
+			claims["aud"] = []any{"other", mockIdP.ClientID}
+		}, ""},
+		{func(claims map[string]any) {
+			claims["exp"] = now.Add(-30 * time.Second).Unix()
+		}, ""},
+		{func(claims map[string]any) { claims["iss"] = "https://other" },
+			"issuer mismatch"},
+		{func(claims map[string]any) { claims["aud"] = "other" },
+			"audience mismatch"},
+		{func(claims map[string]any) { claims["azp"] = "other" },
+			"authorized party mismatch"},
+		{func(claims map[string]any) {
+			claims["exp"] = now.Add(-2 * time.Minute).Unix()
+		}, "expired"},
+		{func(claims map[string]any) { delete(claims, "exp") }, "expired"},
+		{func(claims map[string]any) { claims["nonce"] = "other" },
+			"nonce mismatch"},
+		{func(claims map[string]any) { delete(claims, "sub") },
+			"missing subject"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can verify %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			claims := valid()
+			test.inpMod(claims)
+
+			token, err := mockIdP.SignToken(claims)
+			require.NoError(t, err)
+
+			verClaims, err := verify(token, keys, mockIdP.URL,
+				mockIdP.ClientID, nonce, now)
+			t.Logf("verClaims, err: %+v, %v", verClaims, err)
+			if test.err == "" {
+				require.NoError(t, err)
+				require.Equal(t, email, verClaims.Email)
+				require.True(t, verClaims.EmailVerified)
+			} else {
+				require.ErrorIs(t, err, ErrToken)
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+
+	t.Run("Verify tampered token", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := mockIdP.SignToken(valid())
+		require.NoError(t, err)
+
+		claims := valid()
+		claims["email"] = random.Email()
+		bClaims, err := json.Marshal(claims)
+		require.NoError(t, err)
+
+		parts := strings.Split(token, ".")
+		parts[1] = base64.RawURLEncoding.EncodeToString(bClaims)
+
+		verClaims, err := verify(strings.Join(parts, "."), keys, mockIdP.URL,
+			mockIdP.ClientID, nonce, now)
+		t.Logf("verClaims, err: %+v, %v", verClaims, err)
+		require.Nil(t, verClaims)
+		require.ErrorContains(t, err, "signature not verified")
+	})
+
+	t.Run("Verify unsigned token", func(t *testing.T) {
+		t.Parallel()
+
+		bClaims, err := json.Marshal(valid())
+		require.NoError(t, err)
+
+		token := base64.RawURLEncoding.EncodeToString(
+			[]byte(`{"alg":"none"}`)) + "." +
+			base64.RawURLEncoding.EncodeToString(bClaims) + "."
+
+		verClaims, err := verify(token, keys, mockIdP.URL, mockIdP.ClientID,
+			nonce, now)
+		t.Logf("verClaims, err: %+v, %v", verClaims, err)
+		require.Nil(t, verClaims)
+		require.ErrorContains(t, err, "signature not verified")
+	})
+
+	t.Run("Verify malformed token", func(t *testing.T) {
+		t.Parallel()
+
+		verClaims, err := verify(random.String(20), keys, mockIdP.URL,
+			mockIdP.ClientID, nonce, now)
+		t.Logf("verClaims, err: %+v, %v", verClaims, err)
+		require.Nil(t, verClaims)
+		require.ErrorIs(t, err, ErrToken)
+	})
+}
+
+func TestVerifyES256(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	pub, err := key.PublicKey.Bytes()
+	require.NoError(t, err)
+
+	keys := []*jwk{{
+		Kty: "EC", Kid: "es", Crv: "P-256",
+		X: base64.RawURLEncoding.EncodeToString(pub[1:33]),
+		Y: base64.RawURLEncoding.EncodeToString(pub[33:]),
+	}}
+
+	bClaims, err := json.Marshal(map[string]any{
+		"iss": "https://idp", "aud": "atlas", "sub": "oidc-sub",
+		"exp": time.Now().Add(time.Hour).Unix(), "nonce": "nonce",
+		"email": "oidc@example.com",
+	})
+	require.NoError(t, err)
+
+	input := base64.RawURLEncoding.EncodeToString(
+		[]byte(`{"alg":"ES256","kid":"es"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(bClaims)
+	hash := sha256.Sum256([]byte(input))
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	claims, err := verify(input+"."+base64.RawURLEncoding.EncodeToString(sig),
+		keys, "https://idp", "atlas", "nonce", time.Now())
+	t.Logf("claims, err: %+v, %v", claims, err)
+	require.NoError(t, err)
+	require.Equal(t, "oidc@example.com", claims.Email)
+	require.False(t, claims.EmailVerified)
+}
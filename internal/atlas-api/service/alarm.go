@@ -12,6 +12,7 @@ import (
 	"github.com/thingspect/atlas/internal/atlas-api/session"
 	"github.com/thingspect/atlas/pkg/alog"
 	"github.com/thingspect/atlas/pkg/template"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -29,6 +30,26 @@ type Alarmer interface {
 	Delete(ctx context.Context, alarmID, orgID, ruleID string) error
 	List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string,
 		limit int32, ruleID string) ([]*api.Alarm, int32, error)
+	UpsertRecovery(ctx context.Context, rec *message.AlarmRecovery) (
+		*message.AlarmRecovery, error)
+	ReadRecovery(ctx context.Context, alarmID, orgID, ruleID string) (
+		*message.AlarmRecovery, error)
+	DeleteRecovery(ctx context.Context, alarmID, orgID, ruleID string) error
+	UpsertEscalation(ctx context.Context, esc *message.AlarmEscalation) (
+		*message.AlarmEscalation, error)
+	ReadEscalation(ctx context.Context, alarmID, orgID, ruleID string) (
+		*message.AlarmEscalation, error)
+	DeleteEscalation(ctx context.Context, alarmID, orgID, ruleID string) error
+	UpsertDigest(ctx context.Context, dig *message.AlarmDigest) (
+		*message.AlarmDigest, error)
+	ReadDigest(ctx context.Context, alarmID, orgID, ruleID string) (
+		*message.AlarmDigest, error)
+	DeleteDigest(ctx context.Context, alarmID, orgID, ruleID string) error
+	UpsertWebhook(ctx context.Context, hook *message.AlarmWebhook,
+		secret []byte) (*message.AlarmWebhook, error)
+	ReadWebhook(ctx context.Context, alarmID, orgID, ruleID string) (
+		*message.AlarmWebhook, error)
+	DeleteWebhook(ctx context.Context, alarmID, orgID, ruleID string) error
 }
 
 // CreateAlarm creates an alarm.
@@ -40,8 +61,20 @@ func (ra *RuleAlarm) CreateAlarm(
 		return nil, errPerm(api.Role_BUILDER)
 	}
 
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
 	req.Alarm.OrgId = sess.OrgID
 
+	for _, templ := range []string{
+		req.GetAlarm().GetSubjectTemplate(), req.GetAlarm().GetBodyTemplate(),
+	} {
+		if err := template.Validate(templ); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
 	alarm, err := ra.alarmDAO.Create(ctx, req.GetAlarm())
 	if err != nil {
 		return nil, errToStatus(err)
@@ -65,6 +98,10 @@ func (ra *RuleAlarm) GetAlarm(ctx context.Context, req *api.GetAlarmRequest) (
 		return nil, errPerm(api.Role_VIEWER)
 	}
 
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
 	alarm, err := ra.alarmDAO.Read(ctx, req.GetId(), sess.OrgID, req.GetRuleId())
 	if err != nil {
 		return nil, errToStatus(err)
@@ -83,6 +120,10 @@ func (ra *RuleAlarm) UpdateAlarm(
 		return nil, errPerm(api.Role_BUILDER)
 	}
 
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
 	if req.GetAlarm() == nil {
 		return nil, status.Error(codes.InvalidArgument,
 			req.Validate().Error())
@@ -117,6 +158,14 @@ func (ra *RuleAlarm) UpdateAlarm(
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	for _, templ := range []string{
+		req.GetAlarm().GetSubjectTemplate(), req.GetAlarm().GetBodyTemplate(),
+	} {
+		if err := template.Validate(templ); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
 	alarm, err := ra.alarmDAO.Update(ctx, req.GetAlarm())
 	if err != nil {
 		return nil, errToStatus(err)
@@ -134,6 +183,10 @@ func (ra *RuleAlarm) DeleteAlarm(
 		return nil, errPerm(api.Role_BUILDER)
 	}
 
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
 	if err := ra.alarmDAO.Delete(ctx, req.GetId(), sess.OrgID,
 		req.GetRuleId()); err != nil {
 		return nil, errToStatus(err)
@@ -157,6 +210,10 @@ func (ra *RuleAlarm) ListAlarms(
 		return nil, errPerm(api.Role_VIEWER)
 	}
 
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
 	if req.GetPageSize() == 0 {
 		req.PageSize = defaultPageSize
 	}
@@ -202,7 +259,15 @@ func (ra *RuleAlarm) TestAlarm(ctx context.Context, req *api.TestAlarmRequest) (
 		return nil, errPerm(api.Role_BUILDER)
 	}
 
-	subj, err := template.Generate(req.GetPoint(), req.GetRule(), req.GetDevice(),
+	env := &template.Env{
+		Point:  req.GetPoint(),
+		Rule:   req.GetRule(),
+		Device: req.GetDevice(),
+		Alarm:  req.GetAlarm(),
+	}
+	mode := template.ModeFor(req.GetAlarm().GetType())
+
+	subj, err := template.Generate(env, mode,
 		req.GetAlarm().GetSubjectTemplate())
 	if err != nil {
 		// Template does not provide sentinel errors, always consider errors to
@@ -210,8 +275,7 @@ func (ra *RuleAlarm) TestAlarm(ctx context.Context, req *api.TestAlarmRequest) (
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	body, err := template.Generate(req.GetPoint(), req.GetRule(), req.GetDevice(),
-		req.GetAlarm().GetBodyTemplate())
+	body, err := template.Generate(env, mode, req.GetAlarm().GetBodyTemplate())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
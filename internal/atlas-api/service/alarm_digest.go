@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/template"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxDigestWindow is the maximum window, in minutes, of an alarm digest.
+const maxDigestWindow = 24 * 60
+
+// GetAlarmDigest retrieves an alarm's digest by alarm ID and rule ID.
+func (ra *RuleAlarm) GetAlarmDigest(
+	ctx context.Context, alarmID, ruleID string,
+) (*message.AlarmDigest, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	dig, err := ra.alarmDAO.ReadDigest(ctx, alarmID, sess.OrgID, ruleID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return dig, nil
+}
+
+// UpdateAlarmDigest creates or replaces an alarm's digest by alarm ID and rule
+// ID. Once set, alerts of the alarm are collected per user over the window and
+// sent as a single notification.
+func (ra *RuleAlarm) UpdateAlarmDigest(
+	ctx context.Context, alarmID, ruleID string, dig *message.AlarmDigest,
+) (*message.AlarmDigest, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return nil, errPerm(api.Role_BUILDER)
+	}
+
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	if dig.GetWindowMinutes() < 1 || dig.GetWindowMinutes() > maxDigestWindow {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"window minutes must be between 1 and %d", maxDigestWindow)
+	}
+
+	for _, templ := range []string{
+		dig.GetSubjectTemplate(), dig.GetBodyTemplate(),
+	} {
+		if err := template.Validate(templ); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	dig.AlarmId = alarmID
+	dig.OrgId = sess.OrgID
+	dig.RuleId = ruleID
+
+	upDig, err := ra.alarmDAO.UpsertDigest(ctx, dig)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return upDig, nil
+}
+
+// DeleteAlarmDigest deletes an alarm's digest by alarm ID and rule ID. Alerts
+// of the alarm are again sent individually, and pending digests are still sent
+// at the end of their windows.
+func (ra *RuleAlarm) DeleteAlarmDigest(
+	ctx context.Context, alarmID, ruleID string,
+) error {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return errPerm(api.Role_BUILDER)
+	}
+
+	if sess.TagScoped() {
+		return errScope()
+	}
+
+	if err := ra.alarmDAO.DeleteDigest(ctx, alarmID, sess.OrgID,
+		ruleID); err != nil {
+		return errToStatus(err)
+	}
+
+	return nil
+}
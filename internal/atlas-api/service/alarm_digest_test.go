@@ -0,0 +1,255 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAlarmDigest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get digest by valid alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		dig := &message.AlarmDigest{
+			AlarmId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+			RuleId: uuid.NewV7().String(), WindowMinutes: 15,
+		}
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().ReadDigest(gomock.Any(), dig.GetAlarmId(),
+			dig.GetOrgId(), dig.GetRuleId()).Return(dig, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dig.GetOrgId(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		getDig, err := raSvc.GetAlarmDigest(ctx, dig.GetAlarmId(),
+			dig.GetRuleId())
+		t.Logf("getDig, err: %+v, %v", getDig, err)
+		require.NoError(t, err)
+		require.Equal(t, dig, getDig)
+	})
+
+	t.Run("Get digest with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		getDig, err := raSvc.GetAlarmDigest(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("getDig, err: %+v, %v", getDig, err)
+		require.Nil(t, getDig)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("Get digest by unknown alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().ReadDigest(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		getDig, err := raSvc.GetAlarmDigest(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("getDig, err: %+v, %v", getDig, err)
+		require.Nil(t, getDig)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestUpdateAlarmDigest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Update digest by valid alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		alarmID := uuid.NewV7().String()
+		ruleID := uuid.NewV7().String()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().UpsertDigest(gomock.Any(), gomock.Cond(
+			func(inp *message.AlarmDigest) bool {
+				return inp.GetAlarmId() == alarmID &&
+					inp.GetOrgId() == orgID && inp.GetRuleId() == ruleID
+			})).DoAndReturn(func(_ any, dig *message.AlarmDigest) (
+			*message.AlarmDigest, error,
+		) {
+			return dig, nil
+		}).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		upDig, err := raSvc.UpdateAlarmDigest(ctx, alarmID, ruleID,
+			&message.AlarmDigest{
+				WindowMinutes: 15, BodyTemplate: "{{.count}} alerts",
+			})
+		t.Logf("upDig, err: %+v, %v", upDig, err)
+		require.NoError(t, err)
+		require.Equal(t, "{{.count}} alerts", upDig.GetBodyTemplate())
+	})
+
+	t.Run("Update digest with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		upDig, err := raSvc.UpdateAlarmDigest(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(), nil)
+		t.Logf("upDig, err: %+v, %v", upDig, err)
+		require.Nil(t, upDig)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Update digest with invalid window", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		upDig, err := raSvc.UpdateAlarmDigest(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(), &message.AlarmDigest{WindowMinutes: 1441})
+		t.Logf("upDig, err: %+v, %v", upDig, err)
+		require.Nil(t, upDig)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"window minutes must be between 1 and 1440"), err)
+	})
+
+	t.Run("Update digest with invalid template", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		upDig, err := raSvc.UpdateAlarmDigest(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(), &message.AlarmDigest{
+				WindowMinutes: 15, SubjectTemplate: "{{if",
+			})
+		t.Logf("upDig, err: %+v, %v", upDig, err)
+		require.Nil(t, upDig)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Update digest by unknown alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().UpsertDigest(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		upDig, err := raSvc.UpdateAlarmDigest(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(),
+			&message.AlarmDigest{WindowMinutes: 15})
+		t.Logf("upDig, err: %+v, %v", upDig, err)
+		require.Nil(t, upDig)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestDeleteAlarmDigest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Delete digest by valid alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		alarmID := uuid.NewV7().String()
+		ruleID := uuid.NewV7().String()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().DeleteDigest(gomock.Any(), alarmID, orgID, ruleID).
+			Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		err := raSvc.DeleteAlarmDigest(ctx, alarmID, ruleID)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete digest with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		err := raSvc.DeleteAlarmDigest(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Delete digest by unknown alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().DeleteDigest(gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		err := raSvc.DeleteAlarmDigest(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Constants used for escalation policy bounds.
+const (
+	maxEscSteps = 10
+	maxEscDelay = 7 * 24 * 60
+)
+
+// GetAlarmEscalation retrieves an alarm's escalation policy by alarm ID and
+// rule ID.
+func (ra *RuleAlarm) GetAlarmEscalation(
+	ctx context.Context, alarmID, ruleID string,
+) (*message.AlarmEscalation, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	esc, err := ra.alarmDAO.ReadEscalation(ctx, alarmID, sess.OrgID, ruleID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return esc, nil
+}
+
+// UpdateAlarmEscalation creates or replaces an alarm's escalation policy by
+// alarm ID and rule ID. Steps are executed in order while an alert remains
+// unacknowledged.
+func (ra *RuleAlarm) UpdateAlarmEscalation(
+	ctx context.Context, alarmID, ruleID string, esc *message.AlarmEscalation,
+) (*message.AlarmEscalation, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return nil, errPerm(api.Role_BUILDER)
+	}
+
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	if len(esc.GetSteps()) == 0 || len(esc.GetSteps()) > maxEscSteps {
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("steps must be between 1 and %d", maxEscSteps))
+	}
+
+	for _, step := range esc.GetSteps() {
+		if step.GetDelayMinutes() < 1 || step.GetDelayMinutes() > maxEscDelay {
+			return nil, status.Error(codes.InvalidArgument,
+				fmt.Sprintf("delay must be between 1 and %d minutes",
+					maxEscDelay))
+		}
+
+		if len(step.GetUserTags()) == 0 {
+			return nil, status.Error(codes.InvalidArgument,
+				"step user tags required")
+		}
+
+		if _, ok := api.AlarmType_name[int32(step.GetType())]; !ok {
+			return nil, status.Error(codes.InvalidArgument,
+				"unknown step alarm type")
+		}
+	}
+
+	esc.AlarmId = alarmID
+	esc.OrgId = sess.OrgID
+	esc.RuleId = ruleID
+
+	upEsc, err := ra.alarmDAO.UpsertEscalation(ctx, esc)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return upEsc, nil
+}
+
+// DeleteAlarmEscalation deletes an alarm's escalation policy by alarm ID and
+// rule ID. Pending escalations of the alarm's alerts are not sent.
+func (ra *RuleAlarm) DeleteAlarmEscalation(
+	ctx context.Context, alarmID, ruleID string,
+) error {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return errPerm(api.Role_BUILDER)
+	}
+
+	if sess.TagScoped() {
+		return errScope()
+	}
+
+	if err := ra.alarmDAO.DeleteEscalation(ctx, alarmID, sess.OrgID,
+		ruleID); err != nil {
+		return errToStatus(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,296 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAlarmEscalation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get escalation by valid alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		esc := &message.AlarmEscalation{
+			AlarmId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+			RuleId: uuid.NewV7().String(), Steps: []*message.EscalationStep{
+				{DelayMinutes: 5, UserTags: []string{"api-escalation"}},
+			},
+		}
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().ReadEscalation(gomock.Any(), esc.GetAlarmId(),
+			esc.GetOrgId(), esc.GetRuleId()).Return(esc, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: esc.GetOrgId(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		getEsc, err := raSvc.GetAlarmEscalation(ctx, esc.GetAlarmId(),
+			esc.GetRuleId())
+		t.Logf("getEsc, err: %+v, %v", getEsc, err)
+		require.NoError(t, err)
+		require.Equal(t, esc, getEsc)
+	})
+
+	t.Run("Get escalation with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		getEsc, err := raSvc.GetAlarmEscalation(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("getEsc, err: %+v, %v", getEsc, err)
+		require.Nil(t, getEsc)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("Get escalation by unknown alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().ReadEscalation(gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		getEsc, err := raSvc.GetAlarmEscalation(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("getEsc, err: %+v, %v", getEsc, err)
+		require.Nil(t, getEsc)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestUpdateAlarmEscalation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Update escalation by valid alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		alarmID := uuid.NewV7().String()
+		ruleID := uuid.NewV7().String()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().UpsertEscalation(gomock.Any(), gomock.Cond(
+			func(inp *message.AlarmEscalation) bool {
+				return inp.GetAlarmId() == alarmID &&
+					inp.GetOrgId() == orgID && inp.GetRuleId() == ruleID
+			})).DoAndReturn(func(_ any, esc *message.AlarmEscalation) (
+			*message.AlarmEscalation, error,
+		) {
+			return esc, nil
+		}).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		upEsc, err := raSvc.UpdateAlarmEscalation(ctx, alarmID, ruleID,
+			&message.AlarmEscalation{Steps: []*message.EscalationStep{
+				{DelayMinutes: 5, UserTags: []string{"api-escalation-1"}},
+				{
+					DelayMinutes: 15, UserTags: []string{"api-escalation-2"},
+					Type: api.AlarmType_SMS,
+				},
+			}})
+		t.Logf("upEsc, err: %+v, %v", upEsc, err)
+		require.NoError(t, err)
+		require.Len(t, upEsc.GetSteps(), 2)
+	})
+
+	t.Run("Update escalation with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		upEsc, err := raSvc.UpdateAlarmEscalation(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(), nil)
+		t.Logf("upEsc, err: %+v, %v", upEsc, err)
+		require.Nil(t, upEsc)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	badSteps := make([]*message.EscalationStep, maxEscSteps+1)
+	for i := range badSteps {
+		badSteps[i] = &message.EscalationStep{
+			DelayMinutes: 5, UserTags: []string{"api-escalation"},
+		}
+	}
+
+	invTests := []struct {
+		inpSteps []*message.EscalationStep
+		err      string
+	}{
+		{nil, fmt.Sprintf("steps must be between 1 and %d", maxEscSteps)},
+		{
+			badSteps, fmt.Sprintf("steps must be between 1 and %d",
+				maxEscSteps),
+		},
+		{
+			[]*message.EscalationStep{
+				{UserTags: []string{"api-escalation"}},
+			}, fmt.Sprintf("delay must be between 1 and %d minutes",
+				maxEscDelay),
+		},
+		{
+			[]*message.EscalationStep{
+				{
+					DelayMinutes: maxEscDelay + 1,
+					UserTags:     []string{"api-escalation"},
+				},
+			}, fmt.Sprintf("delay must be between 1 and %d minutes",
+				maxEscDelay),
+		},
+		{
+			[]*message.EscalationStep{{DelayMinutes: 5}},
+			"step user tags required",
+		},
+		{
+			[]*message.EscalationStep{
+				{
+					DelayMinutes: 5, UserTags: []string{"api-escalation"},
+					Type: 999,
+				},
+			}, "unknown step alarm type",
+		},
+	}
+
+	for _, test := range invTests {
+		t.Run(fmt.Sprintf("Cannot update %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+				&session.Session{
+					OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN,
+				}), testTimeout)
+			defer cancel()
+
+			raSvc := NewRuleAlarm(nil, nil, nil)
+			upEsc, err := raSvc.UpdateAlarmEscalation(ctx,
+				uuid.NewV7().String(), uuid.NewV7().String(),
+				&message.AlarmEscalation{Steps: test.inpSteps})
+			t.Logf("upEsc, err: %+v, %v", upEsc, err)
+			require.Nil(t, upEsc)
+			require.Equal(t, status.Error(codes.InvalidArgument, test.err),
+				err)
+		})
+	}
+
+	t.Run("Update escalation by unknown alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().UpsertEscalation(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		upEsc, err := raSvc.UpdateAlarmEscalation(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(), &message.AlarmEscalation{
+				Steps: []*message.EscalationStep{
+					{DelayMinutes: 5, UserTags: []string{"api-escalation"}},
+				},
+			})
+		t.Logf("upEsc, err: %+v, %v", upEsc, err)
+		require.Nil(t, upEsc)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestDeleteAlarmEscalation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Delete escalation by valid alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		alarmID := uuid.NewV7().String()
+		ruleID := uuid.NewV7().String()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().DeleteEscalation(gomock.Any(), alarmID, orgID,
+			ruleID).Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		err := raSvc.DeleteAlarmEscalation(ctx, alarmID, ruleID)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete escalation with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		err := raSvc.DeleteAlarmEscalation(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Delete escalation by unknown alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().DeleteEscalation(gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		err := raSvc.DeleteAlarmEscalation(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
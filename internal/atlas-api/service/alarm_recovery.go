@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/template"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetAlarmRecovery retrieves an alarm's recovery by alarm ID and rule ID.
+func (ra *RuleAlarm) GetAlarmRecovery(
+	ctx context.Context, alarmID, ruleID string,
+) (*message.AlarmRecovery, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	rec, err := ra.alarmDAO.ReadRecovery(ctx, alarmID, sess.OrgID, ruleID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return rec, nil
+}
+
+// UpdateAlarmRecovery creates or replaces an alarm's recovery by alarm ID and
+// rule ID. Once set, the alarm also notifies when its rule clears.
+func (ra *RuleAlarm) UpdateAlarmRecovery(
+	ctx context.Context, alarmID, ruleID string, rec *message.AlarmRecovery,
+) (*message.AlarmRecovery, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return nil, errPerm(api.Role_BUILDER)
+	}
+
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	if rec.GetSubjectTemplate() == "" && rec.GetBodyTemplate() == "" {
+		return nil, status.Error(codes.InvalidArgument,
+			"subject or body template required")
+	}
+
+	for _, templ := range []string{
+		rec.GetSubjectTemplate(), rec.GetBodyTemplate(),
+	} {
+		if err := template.Validate(templ); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	rec.AlarmId = alarmID
+	rec.OrgId = sess.OrgID
+	rec.RuleId = ruleID
+
+	upRec, err := ra.alarmDAO.UpsertRecovery(ctx, rec)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return upRec, nil
+}
+
+// DeleteAlarmRecovery deletes an alarm's recovery by alarm ID and rule ID. The
+// alarm no longer notifies when its rule clears.
+func (ra *RuleAlarm) DeleteAlarmRecovery(
+	ctx context.Context, alarmID, ruleID string,
+) error {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return errPerm(api.Role_BUILDER)
+	}
+
+	if sess.TagScoped() {
+		return errScope()
+	}
+
+	if err := ra.alarmDAO.DeleteRecovery(ctx, alarmID, sess.OrgID,
+		ruleID); err != nil {
+		return errToStatus(err)
+	}
+
+	return nil
+}
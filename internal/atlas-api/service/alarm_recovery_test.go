@@ -0,0 +1,252 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAlarmRecovery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get recovery by valid alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &message.AlarmRecovery{
+			AlarmId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+			RuleId: uuid.NewV7().String(), SubjectTemplate: "api-recovery",
+		}
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().ReadRecovery(gomock.Any(), rec.GetAlarmId(),
+			rec.GetOrgId(), rec.GetRuleId()).Return(rec, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: rec.GetOrgId(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		getRec, err := raSvc.GetAlarmRecovery(ctx, rec.GetAlarmId(),
+			rec.GetRuleId())
+		t.Logf("getRec, err: %+v, %v", getRec, err)
+		require.NoError(t, err)
+		require.Equal(t, rec, getRec)
+	})
+
+	t.Run("Get recovery with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		getRec, err := raSvc.GetAlarmRecovery(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("getRec, err: %+v, %v", getRec, err)
+		require.Nil(t, getRec)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("Get recovery by unknown alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().ReadRecovery(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		getRec, err := raSvc.GetAlarmRecovery(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("getRec, err: %+v, %v", getRec, err)
+		require.Nil(t, getRec)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestUpdateAlarmRecovery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Update recovery by valid alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		alarmID := uuid.NewV7().String()
+		ruleID := uuid.NewV7().String()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().UpsertRecovery(gomock.Any(), gomock.Cond(
+			func(inp *message.AlarmRecovery) bool {
+				return inp.GetAlarmId() == alarmID &&
+					inp.GetOrgId() == orgID && inp.GetRuleId() == ruleID
+			})).DoAndReturn(func(_ any, rec *message.AlarmRecovery) (
+			*message.AlarmRecovery, error,
+		) {
+			return rec, nil
+		}).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		upRec, err := raSvc.UpdateAlarmRecovery(ctx, alarmID, ruleID,
+			&message.AlarmRecovery{BodyTemplate: "api-recovery"})
+		t.Logf("upRec, err: %+v, %v", upRec, err)
+		require.NoError(t, err)
+		require.Equal(t, "api-recovery", upRec.GetBodyTemplate())
+	})
+
+	t.Run("Update recovery with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		upRec, err := raSvc.UpdateAlarmRecovery(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(), nil)
+		t.Logf("upRec, err: %+v, %v", upRec, err)
+		require.Nil(t, upRec)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Update recovery without templates", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		upRec, err := raSvc.UpdateAlarmRecovery(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(), &message.AlarmRecovery{})
+		t.Logf("upRec, err: %+v, %v", upRec, err)
+		require.Nil(t, upRec)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"subject or body template required"), err)
+	})
+
+	t.Run("Update recovery with invalid template", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		upRec, err := raSvc.UpdateAlarmRecovery(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(), &message.AlarmRecovery{BodyTemplate: `{{if`})
+		t.Logf("upRec, err: %+v, %v", upRec, err)
+		require.Nil(t, upRec)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"template: template:1: unclosed action"), err)
+	})
+
+	t.Run("Update recovery by unknown alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().UpsertRecovery(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		upRec, err := raSvc.UpdateAlarmRecovery(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(),
+			&message.AlarmRecovery{SubjectTemplate: "api-recovery"})
+		t.Logf("upRec, err: %+v, %v", upRec, err)
+		require.Nil(t, upRec)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestDeleteAlarmRecovery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Delete recovery by valid alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		alarmID := uuid.NewV7().String()
+		ruleID := uuid.NewV7().String()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().DeleteRecovery(gomock.Any(), alarmID, orgID, ruleID).
+			Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		err := raSvc.DeleteAlarmRecovery(ctx, alarmID, ruleID)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete recovery with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		err := raSvc.DeleteAlarmRecovery(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Delete recovery by unknown alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().DeleteRecovery(gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		err := raSvc.DeleteAlarmRecovery(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
@@ -42,7 +42,7 @@ func TestCreateAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		createAlarm, err := raSvc.CreateAlarm(ctx, &api.CreateAlarmRequest{
 			Alarm: alarm,
 		})
@@ -57,7 +57,7 @@ func TestCreateAlarm(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		createAlarm, err := raSvc.CreateAlarm(ctx, &api.CreateAlarmRequest{})
 		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
 		require.Nil(t, createAlarm)
@@ -72,13 +72,30 @@ func TestCreateAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		createAlarm, err := raSvc.CreateAlarm(ctx, &api.CreateAlarmRequest{})
 		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
 		require.Nil(t, createAlarm)
 		require.Equal(t, errPerm(api.Role_BUILDER), err)
 	})
 
+	t.Run("Create alarm with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		createAlarm, err := raSvc.CreateAlarm(ctx, &api.CreateAlarmRequest{})
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.Nil(t, createAlarm)
+		require.Equal(t, errScope(), err)
+	})
+
 	t.Run("Create invalid alarm", func(t *testing.T) {
 		t.Parallel()
 
@@ -95,7 +112,7 @@ func TestCreateAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		createAlarm, err := raSvc.CreateAlarm(ctx, &api.CreateAlarmRequest{
 			Alarm: alarm,
 		})
@@ -104,6 +121,28 @@ func TestCreateAlarm(t *testing.T) {
 		require.Equal(t, status.Error(codes.InvalidArgument,
 			"dao: invalid format"), err)
 	})
+
+	t.Run("Create alarm with invalid template", func(t *testing.T) {
+		t.Parallel()
+
+		alarm := random.Alarm("api-alarm", uuid.NewV7().String(),
+			uuid.NewV7().String())
+		alarm.BodyTemplate = `{{if`
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: alarm.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		createAlarm, err := raSvc.CreateAlarm(ctx, &api.CreateAlarmRequest{
+			Alarm: alarm,
+		})
+		t.Logf("alarm, createAlarm, err: %+v, %+v, %v", alarm, createAlarm, err)
+		require.Nil(t, createAlarm)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"template: template:1: unclosed action"), err)
+	})
 }
 
 func TestGetAlarm(t *testing.T) {
@@ -125,7 +164,7 @@ func TestGetAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		getAlarm, err := raSvc.GetAlarm(ctx, &api.GetAlarmRequest{
 			Id: alarm.GetId(), RuleId: alarm.GetRuleId(),
 		})
@@ -140,7 +179,7 @@ func TestGetAlarm(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		getAlarm, err := raSvc.GetAlarm(ctx, &api.GetAlarmRequest{})
 		t.Logf("getAlarm, err: %+v, %v", getAlarm, err)
 		require.Nil(t, getAlarm)
@@ -155,7 +194,7 @@ func TestGetAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		getAlarm, err := raSvc.GetAlarm(ctx, &api.GetAlarmRequest{})
 		t.Logf("getAlarm, err: %+v, %v", getAlarm, err)
 		require.Nil(t, getAlarm)
@@ -174,7 +213,7 @@ func TestGetAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		getAlarm, err := raSvc.GetAlarm(ctx, &api.GetAlarmRequest{
 			Id: uuid.NewV7().String(), RuleId: uuid.NewV7().String(),
 		})
@@ -204,7 +243,7 @@ func TestUpdateAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		updateAlarm, err := raSvc.UpdateAlarm(ctx, &api.UpdateAlarmRequest{
 			Alarm: alarm,
 		})
@@ -246,7 +285,7 @@ func TestUpdateAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		updateAlarm, err := raSvc.UpdateAlarm(ctx, &api.UpdateAlarmRequest{
 			Alarm: part, UpdateMask: &fieldmaskpb.FieldMask{
 				Paths: []string{"status", "subject_template", "user_tags"},
@@ -264,7 +303,7 @@ func TestUpdateAlarm(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		updateAlarm, err := raSvc.UpdateAlarm(ctx, &api.UpdateAlarmRequest{})
 		t.Logf("updateAlarm, err: %+v, %v", updateAlarm, err)
 		require.Nil(t, updateAlarm)
@@ -279,7 +318,7 @@ func TestUpdateAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		updateAlarm, err := raSvc.UpdateAlarm(ctx, &api.UpdateAlarmRequest{})
 		t.Logf("updateAlarm, err: %+v, %v", updateAlarm, err)
 		require.Nil(t, updateAlarm)
@@ -294,7 +333,7 @@ func TestUpdateAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		updateAlarm, err := raSvc.UpdateAlarm(ctx, &api.UpdateAlarmRequest{
 			Alarm: nil,
 		})
@@ -315,7 +354,7 @@ func TestUpdateAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		updateAlarm, err := raSvc.UpdateAlarm(ctx, &api.UpdateAlarmRequest{
 			Alarm: alarm, UpdateMask: &fieldmaskpb.FieldMask{
 				Paths: []string{"aaa"},
@@ -344,7 +383,7 @@ func TestUpdateAlarm(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		updateAlarm, err := raSvc.UpdateAlarm(ctx, &api.UpdateAlarmRequest{
 			Alarm: part, UpdateMask: &fieldmaskpb.FieldMask{
 				Paths: []string{"status"},
@@ -368,7 +407,7 @@ func TestUpdateAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		updateAlarm, err := raSvc.UpdateAlarm(ctx, &api.UpdateAlarmRequest{
 			Alarm: alarm,
 		})
@@ -395,7 +434,7 @@ func TestUpdateAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		updateAlarm, err := raSvc.UpdateAlarm(ctx, &api.UpdateAlarmRequest{
 			Alarm: alarm,
 		})
@@ -404,6 +443,28 @@ func TestUpdateAlarm(t *testing.T) {
 		require.Equal(t, status.Error(codes.InvalidArgument,
 			"dao: invalid format"), err)
 	})
+
+	t.Run("Update alarm with invalid template", func(t *testing.T) {
+		t.Parallel()
+
+		alarm := random.Alarm("api-alarm", uuid.NewV7().String(),
+			uuid.NewV7().String())
+		alarm.SubjectTemplate = `{{unknown .pointVal}}`
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: alarm.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		updateAlarm, err := raSvc.UpdateAlarm(ctx, &api.UpdateAlarmRequest{
+			Alarm: alarm,
+		})
+		t.Logf("alarm, updateAlarm, err: %+v, %+v, %v", alarm, updateAlarm, err)
+		require.Nil(t, updateAlarm)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			`template: template:1: function "unknown" not defined`), err)
+	})
 }
 
 func TestDeleteAlarm(t *testing.T) {
@@ -423,7 +484,7 @@ func TestDeleteAlarm(t *testing.T) {
 		), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		_, err := raSvc.DeleteAlarm(ctx, &api.DeleteAlarmRequest{
 			Id: uuid.NewV7().String(),
 		})
@@ -437,7 +498,7 @@ func TestDeleteAlarm(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		_, err := raSvc.DeleteAlarm(ctx, &api.DeleteAlarmRequest{})
 		t.Logf("err: %v", err)
 		require.Equal(t, errPerm(api.Role_BUILDER), err)
@@ -453,7 +514,7 @@ func TestDeleteAlarm(t *testing.T) {
 		), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		_, err := raSvc.DeleteAlarm(ctx, &api.DeleteAlarmRequest{})
 		t.Logf("err: %v", err)
 		require.Equal(t, errPerm(api.Role_BUILDER), err)
@@ -473,7 +534,7 @@ func TestDeleteAlarm(t *testing.T) {
 		), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		_, err := raSvc.DeleteAlarm(ctx, &api.DeleteAlarmRequest{
 			Id: uuid.NewV7().String(),
 		})
@@ -511,7 +572,7 @@ func TestListAlarms(t *testing.T) {
 		), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		listAlarms, err := raSvc.ListAlarms(ctx, &api.ListAlarmsRequest{})
 		t.Logf("listAlarms, err: %+v, %v", listAlarms, err)
 		require.NoError(t, err)
@@ -549,7 +610,7 @@ func TestListAlarms(t *testing.T) {
 		), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		listAlarms, err := raSvc.ListAlarms(ctx, &api.ListAlarmsRequest{
 			PageSize: 2,
 		})
@@ -567,7 +628,7 @@ func TestListAlarms(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		listAlarms, err := raSvc.ListAlarms(ctx, &api.ListAlarmsRequest{})
 		t.Logf("listAlarms, err: %+v, %v", listAlarms, err)
 		require.Nil(t, listAlarms)
@@ -584,7 +645,7 @@ func TestListAlarms(t *testing.T) {
 		), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		listAlarms, err := raSvc.ListAlarms(ctx, &api.ListAlarmsRequest{})
 		t.Logf("listAlarms, err: %+v, %v", listAlarms, err)
 		require.Nil(t, listAlarms)
@@ -601,7 +662,7 @@ func TestListAlarms(t *testing.T) {
 		), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		listAlarms, err := raSvc.ListAlarms(ctx, &api.ListAlarmsRequest{
 			PageToken: badUUID,
 		})
@@ -626,7 +687,7 @@ func TestListAlarms(t *testing.T) {
 		), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		listAlarms, err := raSvc.ListAlarms(ctx, &api.ListAlarmsRequest{})
 		t.Logf("listAlarms, err: %+v, %v", listAlarms, err)
 		require.Nil(t, listAlarms)
@@ -660,7 +721,7 @@ func TestListAlarms(t *testing.T) {
 		), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, alarmer)
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
 		listAlarms, err := raSvc.ListAlarms(ctx, &api.ListAlarmsRequest{
 			PageSize: 2,
 		})
@@ -743,7 +804,7 @@ func TestTestAlarm(t *testing.T) {
 					testTimeout)
 				defer cancel()
 
-				raSvc := NewRuleAlarm(nil, nil)
+				raSvc := NewRuleAlarm(nil, nil, nil)
 				testRes, err := raSvc.TestAlarm(ctx, &api.TestAlarmRequest{
 					Point: test.inpPoint, Rule: test.inpRule,
 					Device: test.inpDev, Alarm: &api.Alarm{
@@ -770,7 +831,7 @@ func TestTestAlarm(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		testRes, err := raSvc.TestAlarm(ctx, &api.TestAlarmRequest{})
 		t.Logf("testRes, err: %+v, %v", testRes, err)
 		require.Nil(t, testRes)
@@ -785,7 +846,7 @@ func TestTestAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		testRes, err := raSvc.TestAlarm(ctx, &api.TestAlarmRequest{})
 		t.Logf("testRes, err: %+v, %v", testRes, err)
 		require.Nil(t, testRes)
@@ -800,7 +861,7 @@ func TestTestAlarm(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		testRes, err := raSvc.TestAlarm(ctx, &api.TestAlarmRequest{
 			Point: &common.DataPoint{}, Rule: nil, Device: nil,
 			Alarm: &api.Alarm{SubjectTemplate: `test`, BodyTemplate: `{{if`},
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"net/url"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/crypt"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Constants used for webhook bounds.
+const (
+	maxHookURL    = 2048
+	maxHookSecret = 255
+)
+
+// GetAlarmWebhook retrieves an alarm's webhook by alarm ID and rule ID. The
+// secret is not returned.
+func (ra *RuleAlarm) GetAlarmWebhook(
+	ctx context.Context, alarmID, ruleID string,
+) (*message.AlarmWebhook, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	hook, err := ra.alarmDAO.ReadWebhook(ctx, alarmID, sess.OrgID, ruleID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return hook, nil
+}
+
+// UpdateAlarmWebhook creates or replaces an alarm's webhook by alarm ID and
+// rule ID. Once set, the alarm also delivers its alerts to the webhook. If a
+// secret is not provided, one is generated. The secret is stored encrypted, and
+// is only returned by this call.
+func (ra *RuleAlarm) UpdateAlarmWebhook(
+	ctx context.Context, alarmID, ruleID string, hook *message.AlarmWebhook,
+) (*message.AlarmWebhook, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return nil, errPerm(api.Role_BUILDER)
+	}
+
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	if u, err := url.Parse(hook.GetUrl()); err != nil || u.Scheme != "https" ||
+		u.Host == "" || len(hook.GetUrl()) > maxHookURL {
+		return nil, status.Error(codes.InvalidArgument,
+			"webhook URL must be a valid HTTPS URL")
+	}
+
+	if len(hook.GetSecret()) > maxHookSecret {
+		return nil, status.Error(codes.InvalidArgument,
+			"webhook secret too long")
+	}
+
+	if hook.GetSecret() == "" {
+		hook.Secret = rand.Text()
+	}
+
+	secret, err := crypt.Encrypt(ra.hookKey, []byte(hook.GetSecret()))
+	if err != nil {
+		logger := alog.FromContext(ctx)
+		logger.Errorf("UpdateAlarmWebhook crypt.Encrypt: %v", err)
+
+		return nil, errToStatus(err)
+	}
+
+	hook.AlarmId = alarmID
+	hook.OrgId = sess.OrgID
+	hook.RuleId = ruleID
+
+	upHook, err := ra.alarmDAO.UpsertWebhook(ctx, hook, secret)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return upHook, nil
+}
+
+// DeleteAlarmWebhook deletes an alarm's webhook by alarm ID and rule ID. The
+// alarm no longer delivers alerts to the webhook.
+func (ra *RuleAlarm) DeleteAlarmWebhook(
+	ctx context.Context, alarmID, ruleID string,
+) error {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return errPerm(api.Role_BUILDER)
+	}
+
+	if sess.TagScoped() {
+		return errScope()
+	}
+
+	if err := ra.alarmDAO.DeleteWebhook(ctx, alarmID, sess.OrgID,
+		ruleID); err != nil {
+		return errToStatus(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,334 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/crypt"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetAlarmWebhook(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []api.Role{api.Role_VIEWER, api.Role_BUILDER} {
+		t.Run(fmt.Sprintf("Can get %v", test), func(t *testing.T) {
+			t.Parallel()
+
+			hook := &message.AlarmWebhook{
+				AlarmId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+				RuleId: uuid.NewV7().String(),
+				Url:    "https://api-webhook.example.com/hook",
+			}
+			retHook, _ := proto.Clone(hook).(*message.AlarmWebhook)
+
+			alarmer := NewMockAlarmer(gomock.NewController(t))
+			alarmer.EXPECT().ReadWebhook(gomock.Any(), hook.GetAlarmId(),
+				hook.GetOrgId(), hook.GetRuleId()).Return(retHook, nil).
+				Times(1)
+
+			ctx, cancel := context.WithTimeout(session.NewContext(
+				t.Context(), &session.Session{
+					OrgID: hook.GetOrgId(), Role: test,
+				}), testTimeout)
+			defer cancel()
+
+			raSvc := NewRuleAlarm(nil, alarmer, nil)
+			getHook, err := raSvc.GetAlarmWebhook(ctx, hook.GetAlarmId(),
+				hook.GetRuleId())
+			t.Logf("getHook, err: %+v, %v", getHook, err)
+			require.NoError(t, err)
+			require.EqualExportedValues(t, hook, getHook)
+		})
+	}
+
+	t.Run("Get webhook with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		getHook, err := raSvc.GetAlarmWebhook(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("getHook, err: %+v, %v", getHook, err)
+		require.Nil(t, getHook)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("Get webhook by unknown alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().ReadWebhook(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		getHook, err := raSvc.GetAlarmWebhook(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("getHook, err: %+v, %v", getHook, err)
+		require.Nil(t, getHook)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestUpdateAlarmWebhook(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		inpSecret string
+	}{
+		{"api-webhook-secret"},
+		{""},
+	} {
+		t.Run(fmt.Sprintf("Can update %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			orgID := uuid.NewV7().String()
+			alarmID := uuid.NewV7().String()
+			ruleID := uuid.NewV7().String()
+
+			hookKey := make([]byte, 32)
+			_, err := rand.Read(hookKey)
+			require.NoError(t, err)
+
+			// Secrets are stored encrypted.
+			var secret []byte
+			alarmer := NewMockAlarmer(gomock.NewController(t))
+			alarmer.EXPECT().UpsertWebhook(gomock.Any(), gomock.Cond(
+				func(inp *message.AlarmWebhook) bool {
+					return inp.GetAlarmId() == alarmID &&
+						inp.GetOrgId() == orgID && inp.GetRuleId() == ruleID
+				}), gomock.Any()).DoAndReturn(func(_ any,
+				hook *message.AlarmWebhook, encSecret []byte,
+			) (*message.AlarmWebhook, error) {
+				secret, err = crypt.Decrypt(hookKey, encSecret)
+
+				return hook, err
+			}).Times(1)
+
+			ctx, cancel := context.WithTimeout(session.NewContext(
+				t.Context(), &session.Session{
+					OrgID: orgID, Role: api.Role_BUILDER,
+				}), testTimeout)
+			defer cancel()
+
+			raSvc := NewRuleAlarm(nil, alarmer, hookKey)
+			upHook, err := raSvc.UpdateAlarmWebhook(ctx, alarmID, ruleID,
+				&message.AlarmWebhook{
+					Url:    "https://api-webhook.example.com/hook",
+					Secret: test.inpSecret,
+				})
+			t.Logf("upHook, err: %+v, %v", upHook, err)
+			require.NoError(t, err)
+			require.NotEmpty(t, upHook.GetSecret())
+			require.Equal(t, upHook.GetSecret(), string(secret))
+			if test.inpSecret != "" {
+				require.Equal(t, test.inpSecret, upHook.GetSecret())
+			}
+		})
+	}
+
+	t.Run("Update webhook with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		upHook, err := raSvc.UpdateAlarmWebhook(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(), nil)
+		t.Logf("upHook, err: %+v, %v", upHook, err)
+		require.Nil(t, upHook)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Update webhook with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		upHook, err := raSvc.UpdateAlarmWebhook(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(), nil)
+		t.Logf("upHook, err: %+v, %v", upHook, err)
+		require.Nil(t, upHook)
+		require.Equal(t, errScope(), err)
+	})
+
+	invTests := []struct {
+		inpURL    string
+		inpSecret string
+		err       string
+	}{
+		{"", "", "webhook URL must be a valid HTTPS URL"},
+		{
+			"http://api-webhook.example.com/hook", "",
+			"webhook URL must be a valid HTTPS URL",
+		},
+		{"https:///hook", "", "webhook URL must be a valid HTTPS URL"},
+		{"https://%zz", "", "webhook URL must be a valid HTTPS URL"},
+		{
+			"https://api-webhook.example.com/" + random.String(2048), "",
+			"webhook URL must be a valid HTTPS URL",
+		},
+		{
+			"https://api-webhook.example.com/hook", random.String(256),
+			"webhook secret too long",
+		},
+	}
+
+	for _, test := range invTests {
+		t.Run(fmt.Sprintf("Cannot update %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+				&session.Session{
+					OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN,
+				}), testTimeout)
+			defer cancel()
+
+			raSvc := NewRuleAlarm(nil, nil, nil)
+			upHook, err := raSvc.UpdateAlarmWebhook(ctx,
+				uuid.NewV7().String(), uuid.NewV7().String(),
+				&message.AlarmWebhook{
+					Url: test.inpURL, Secret: test.inpSecret,
+				})
+			t.Logf("upHook, err: %+v, %v", upHook, err)
+			require.Nil(t, upHook)
+			require.Equal(t, status.Error(codes.InvalidArgument, test.err),
+				err)
+		})
+	}
+
+	t.Run("Update webhook with invalid key", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		upHook, err := raSvc.UpdateAlarmWebhook(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(), &message.AlarmWebhook{
+				Url: "https://api-webhook.example.com/hook",
+			})
+		t.Logf("upHook, err: %+v, %v", upHook, err)
+		require.Nil(t, upHook)
+		require.Equal(t, codes.Unknown, status.Code(err))
+	})
+
+	t.Run("Update webhook by unknown alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().UpsertWebhook(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, make([]byte, 32))
+		upHook, err := raSvc.UpdateAlarmWebhook(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String(), &message.AlarmWebhook{
+				Url: "https://api-webhook.example.com/hook",
+			})
+		t.Logf("upHook, err: %+v, %v", upHook, err)
+		require.Nil(t, upHook)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestDeleteAlarmWebhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Delete webhook by valid alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		alarmID := uuid.NewV7().String()
+		ruleID := uuid.NewV7().String()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().DeleteWebhook(gomock.Any(), alarmID, orgID, ruleID).
+			Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		err := raSvc.DeleteAlarmWebhook(ctx, alarmID, ruleID)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete webhook with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		err := raSvc.DeleteAlarmWebhook(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Delete webhook by unknown alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alarmer := NewMockAlarmer(gomock.NewController(t))
+		alarmer.EXPECT().DeleteWebhook(gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, alarmer, nil)
+		err := raSvc.DeleteAlarmWebhook(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
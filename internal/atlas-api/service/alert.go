@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -15,10 +16,23 @@ import (
 // Alerter defines the methods provided by an alert.DAO.
 type Alerter interface {
 	List(ctx context.Context, orgID, uniqID, devID, alarmID, userID string, end,
-		start time.Time) ([]*api.Alert, error)
+		start time.Time, scopeTags []string) ([]*api.Alert, error)
+	ReadLifecycle(ctx context.Context, lcID, orgID string) (
+		*message.AlertLifecycle, error)
+	Acknowledge(ctx context.Context, lcID, orgID, userID, note string) (
+		*message.AlertLifecycle, error)
+	Resolve(ctx context.Context, lcID, orgID, userID, note string) (
+		*message.AlertLifecycle, error)
+	Assign(ctx context.Context, lcID, orgID, assigneeID string) (
+		*message.AlertLifecycle, error)
+	ListOpen(ctx context.Context, orgID, uniqID, alarmID string) (
+		[]*message.AlertLifecycle, error)
+	ReadDigest(ctx context.Context, digestID, orgID string) (
+		*message.AlertDigest, error)
 }
 
-// Alert service contains functions to query alerts.
+// Alert service contains functions to query alerts and manage alert
+// lifecycles.
 type Alert struct {
 	api.UnimplementedAlertServiceServer
 
@@ -33,7 +47,8 @@ func NewAlert(aleDAO Alerter) *Alert {
 }
 
 // ListAlerts retrieves all alerts for a device, alarm, and/or user in a [end,
-// start) time range, in descending timestamp order.
+// start) time range, in descending timestamp order. Tag scoped sessions only
+// retrieve alerts for devices with tags in scope.
 func (a *Alert) ListAlerts(ctx context.Context, req *api.ListAlertsRequest) (
 	*api.ListAlertsResponse, error,
 ) {
@@ -68,7 +83,7 @@ func (a *Alert) ListAlerts(ctx context.Context, req *api.ListAlertsRequest) (
 	}
 
 	alerts, err := a.aleDAO.List(ctx, sess.OrgID, uniqID, devID, req.GetAlarmId(),
-		req.GetUserId(), end, start)
+		req.GetUserId(), end, start, sess.ScopeTags)
 	if err != nil {
 		return nil, errToStatus(err)
 	}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+// GetAlertDigest retrieves an alert digest by ID, along with the alerts it
+// contains.
+func (a *Alert) GetAlertDigest(ctx context.Context, digestID string) (
+	*message.AlertDigest, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	// Alert digests are not filtered by scope tags, so are unavailable to tag
+	// scoped sessions.
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	digest, err := a.aleDAO.ReadDigest(ctx, digestID, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return digest, nil
+}
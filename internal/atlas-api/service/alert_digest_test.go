@@ -0,0 +1,82 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAlertDigest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get digest by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		digest := &message.AlertDigest{
+			Id: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+			Type: api.AlarmType_EMAIL, Status: api.AlertStatus_SENT,
+			Alerts: []*api.Alert{{UniqId: "api-digest"}},
+		}
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().ReadDigest(gomock.Any(), digest.GetId(),
+			digest.GetOrgId()).Return(digest, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: digest.GetOrgId(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		getDigest, err := aleSvc.GetAlertDigest(ctx, digest.GetId())
+		t.Logf("getDigest, err: %+v, %v", getDigest, err)
+		require.NoError(t, err)
+		require.Equal(t, digest, getDigest)
+	})
+
+	t.Run("Get digest with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(nil)
+		getDigest, err := aleSvc.GetAlertDigest(ctx, uuid.NewV7().String())
+		t.Logf("getDigest, err: %+v, %v", getDigest, err)
+		require.Nil(t, getDigest)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("Get digest by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().ReadDigest(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		getDigest, err := aleSvc.GetAlertDigest(ctx, uuid.NewV7().String())
+		t.Logf("getDigest, err: %+v, %v", getDigest, err)
+		require.Nil(t, getDigest)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+// GetAlertLifecycle retrieves an alert lifecycle by ID.
+func (a *Alert) GetAlertLifecycle(ctx context.Context, lcID string) (
+	*message.AlertLifecycle, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	// Alert lifecycles are not filtered by scope tags, so are unavailable to tag
+	// scoped sessions.
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	lc, err := a.aleDAO.ReadLifecycle(ctx, lcID, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return lc, nil
+}
+
+// ListAlertLifecycles retrieves all unresolved alert lifecycles for an
+// optional device UniqID and alarm ID, in descending open timestamp order.
+func (a *Alert) ListAlertLifecycles(
+	ctx context.Context, uniqID, alarmID string,
+) ([]*message.AlertLifecycle, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	// Alert lifecycles are not filtered by scope tags, so are unavailable to tag
+	// scoped sessions.
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	lcs, err := a.aleDAO.ListOpen(ctx, sess.OrgID, uniqID, alarmID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return lcs, nil
+}
+
+// AcknowledgeAlert acknowledges an open alert lifecycle by ID on behalf of
+// the session user. Further alerts for the device and alarm are suppressed
+// until the lifecycle is resolved.
+func (a *Alert) AcknowledgeAlert(
+	ctx context.Context, lcID string, action *message.AlertAction,
+) (*message.AlertLifecycle, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return nil, errPerm(api.Role_BUILDER)
+	}
+
+	// Alert lifecycles are not filtered by scope tags, so are unavailable to tag
+	// scoped sessions.
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	lc, err := a.aleDAO.Acknowledge(ctx, lcID, sess.OrgID, sess.UserID,
+		action.GetNote())
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return lc, nil
+}
+
+// ResolveAlert resolves an unresolved alert lifecycle by ID on behalf of the
+// session user. A subsequent alert for the device and alarm opens a new
+// lifecycle.
+func (a *Alert) ResolveAlert(
+	ctx context.Context, lcID string, action *message.AlertAction,
+) (*message.AlertLifecycle, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return nil, errPerm(api.Role_BUILDER)
+	}
+
+	// Alert lifecycles are not filtered by scope tags, so are unavailable to tag
+	// scoped sessions.
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	lc, err := a.aleDAO.Resolve(ctx, lcID, sess.OrgID, sess.UserID,
+		action.GetNote())
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return lc, nil
+}
+
+// AssignAlert assigns an unresolved alert lifecycle by ID to a user in the
+// same org. An empty assignee ID unassigns the alert lifecycle.
+func (a *Alert) AssignAlert(
+	ctx context.Context, lcID string, action *message.AlertAction,
+) (*message.AlertLifecycle, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return nil, errPerm(api.Role_BUILDER)
+	}
+
+	// Alert lifecycles are not filtered by scope tags, so are unavailable to tag
+	// scoped sessions.
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	lc, err := a.aleDAO.Assign(ctx, lcID, sess.OrgID, action.GetAssigneeId())
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return lc, nil
+}
@@ -0,0 +1,369 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAlertLifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get lifecycle by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		lc := &message.AlertLifecycle{
+			Id: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+			State: message.AlertState_OPEN,
+		}
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().ReadLifecycle(gomock.Any(), lc.GetId(),
+			lc.GetOrgId()).Return(lc, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: lc.GetOrgId(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		getLC, err := aleSvc.GetAlertLifecycle(ctx, lc.GetId())
+		t.Logf("getLC, err: %+v, %v", getLC, err)
+		require.NoError(t, err)
+		require.Equal(t, lc, getLC)
+	})
+
+	t.Run("Get lifecycle with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(nil)
+		getLC, err := aleSvc.GetAlertLifecycle(ctx, uuid.NewV7().String())
+		t.Logf("getLC, err: %+v, %v", getLC, err)
+		require.Nil(t, getLC)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("Get lifecycle with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(nil)
+		getLC, err := aleSvc.GetAlertLifecycle(ctx, uuid.NewV7().String())
+		t.Logf("getLC, err: %+v, %v", getLC, err)
+		require.Nil(t, getLC)
+		require.Equal(t, errScope(), err)
+	})
+
+	t.Run("Get lifecycle by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().ReadLifecycle(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		getLC, err := aleSvc.GetAlertLifecycle(ctx, uuid.NewV7().String())
+		t.Logf("getLC, err: %+v, %v", getLC, err)
+		require.Nil(t, getLC)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestListAlertLifecycles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("List lifecycles by valid UniqID and alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		uniqID := "api-lifecycle-" + uuid.NewV7().String()
+		alarmID := uuid.NewV7().String()
+		lcs := []*message.AlertLifecycle{{
+			Id: uuid.NewV7().String(), OrgId: orgID, UniqId: uniqID,
+			AlarmId: alarmID, State: message.AlertState_ACKNOWLEDGED,
+		}}
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().ListOpen(gomock.Any(), orgID, uniqID, alarmID).
+			Return(lcs, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		listLCs, err := aleSvc.ListAlertLifecycles(ctx, uniqID, alarmID)
+		t.Logf("listLCs, err: %+v, %v", listLCs, err)
+		require.NoError(t, err)
+		require.Equal(t, lcs, listLCs)
+	})
+
+	t.Run("List lifecycles with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(nil)
+		listLCs, err := aleSvc.ListAlertLifecycles(ctx, "", "")
+		t.Logf("listLCs, err: %+v, %v", listLCs, err)
+		require.Nil(t, listLCs)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("List lifecycles by invalid alarm ID", func(t *testing.T) {
+		t.Parallel()
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().ListOpen(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		listLCs, err := aleSvc.ListAlertLifecycles(ctx, "", "api-lifecycle")
+		t.Logf("listLCs, err: %+v, %v", listLCs, err)
+		require.Nil(t, listLCs)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"dao: invalid format"), err)
+	})
+}
+
+func TestAcknowledgeAlert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Acknowledge lifecycle by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		userID := uuid.NewV7().String()
+		lc := &message.AlertLifecycle{
+			Id: uuid.NewV7().String(), OrgId: orgID,
+			State: message.AlertState_ACKNOWLEDGED, AckedBy: userID,
+			AckNote: "api-lifecycle",
+		}
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().Acknowledge(gomock.Any(), lc.GetId(), orgID, userID,
+			"api-lifecycle").Return(lc, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: userID, OrgID: orgID, Role: api.Role_BUILDER,
+			}), testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		ackLC, err := aleSvc.AcknowledgeAlert(ctx, lc.GetId(),
+			&message.AlertAction{Note: "api-lifecycle"})
+		t.Logf("ackLC, err: %+v, %v", ackLC, err)
+		require.NoError(t, err)
+		require.Equal(t, lc, ackLC)
+	})
+
+	t.Run("Acknowledge lifecycle with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(nil)
+		ackLC, err := aleSvc.AcknowledgeAlert(ctx, uuid.NewV7().String(), nil)
+		t.Logf("ackLC, err: %+v, %v", ackLC, err)
+		require.Nil(t, ackLC)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Acknowledge lifecycle that is not open", func(t *testing.T) {
+		t.Parallel()
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().Acknowledge(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), "").Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		ackLC, err := aleSvc.AcknowledgeAlert(ctx, uuid.NewV7().String(),
+			&message.AlertAction{})
+		t.Logf("ackLC, err: %+v, %v", ackLC, err)
+		require.Nil(t, ackLC)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestResolveAlert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Resolve lifecycle by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		userID := uuid.NewV7().String()
+		lc := &message.AlertLifecycle{
+			Id: uuid.NewV7().String(), OrgId: orgID,
+			State: message.AlertState_RESOLVED, ResolvedBy: userID,
+			ResolveNote: "api-lifecycle",
+		}
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().Resolve(gomock.Any(), lc.GetId(), orgID, userID,
+			"api-lifecycle").Return(lc, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: userID, OrgID: orgID, Role: api.Role_BUILDER,
+			}), testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		resLC, err := aleSvc.ResolveAlert(ctx, lc.GetId(),
+			&message.AlertAction{Note: "api-lifecycle"})
+		t.Logf("resLC, err: %+v, %v", resLC, err)
+		require.NoError(t, err)
+		require.Equal(t, lc, resLC)
+	})
+
+	t.Run("Resolve lifecycle with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(nil)
+		resLC, err := aleSvc.ResolveAlert(ctx, uuid.NewV7().String(), nil)
+		t.Logf("resLC, err: %+v, %v", resLC, err)
+		require.Nil(t, resLC)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Resolve lifecycle that is resolved", func(t *testing.T) {
+		t.Parallel()
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().Resolve(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), "").Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		resLC, err := aleSvc.ResolveAlert(ctx, uuid.NewV7().String(),
+			&message.AlertAction{})
+		t.Logf("resLC, err: %+v, %v", resLC, err)
+		require.Nil(t, resLC)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestAssignAlert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Assign lifecycle by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		assigneeID := uuid.NewV7().String()
+		lc := &message.AlertLifecycle{
+			Id: uuid.NewV7().String(), OrgId: orgID,
+			State: message.AlertState_OPEN, AssigneeId: assigneeID,
+		}
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().Assign(gomock.Any(), lc.GetId(), orgID, assigneeID).
+			Return(lc, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		asnLC, err := aleSvc.AssignAlert(ctx, lc.GetId(),
+			&message.AlertAction{AssigneeId: assigneeID})
+		t.Logf("asnLC, err: %+v, %v", asnLC, err)
+		require.NoError(t, err)
+		require.Equal(t, lc, asnLC)
+	})
+
+	t.Run("Assign lifecycle with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(nil)
+		asnLC, err := aleSvc.AssignAlert(ctx, uuid.NewV7().String(), nil)
+		t.Logf("asnLC, err: %+v, %v", asnLC, err)
+		require.Nil(t, asnLC)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Assign lifecycle to unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().Assign(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		asnLC, err := aleSvc.AssignAlert(ctx, uuid.NewV7().String(),
+			&message.AlertAction{AssigneeId: uuid.NewV7().String()})
+		t.Logf("asnLC, err: %+v, %v", asnLC, err)
+		require.Nil(t, asnLC)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
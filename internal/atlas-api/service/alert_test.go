@@ -34,7 +34,8 @@ func TestListAlerts(t *testing.T) {
 
 		alerter := NewMockAlerter(gomock.NewController(t))
 		alerter.EXPECT().List(gomock.Any(), alert.GetOrgId(), alert.GetUniqId(),
-			"", "", "", end, start).Return([]*api.Alert{retAlert}, nil).Times(1)
+			"", "", "", end, start, gomock.Nil()).
+			Return([]*api.Alert{retAlert}, nil).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
 			&session.Session{OrgID: alert.GetOrgId(), Role: api.Role_ADMIN}),
@@ -52,6 +53,29 @@ func TestListAlerts(t *testing.T) {
 			&api.ListAlertsResponse{Alerts: []*api.Alert{alert}}, listAlerts)
 	})
 
+	t.Run("List alerts with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		scopeTags := []string{"site"}
+
+		alerter := NewMockAlerter(gomock.NewController(t))
+		alerter.EXPECT().List(gomock.Any(), orgID, "", "", "", "",
+			gomock.Any(), gomock.Any(), scopeTags).Return(nil, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: orgID, Role: api.Role_ADMIN, ScopeTags: scopeTags,
+			}), testTimeout)
+		defer cancel()
+
+		aleSvc := NewAlert(alerter)
+		listAlerts, err := aleSvc.ListAlerts(ctx, &api.ListAlertsRequest{})
+		t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
+		require.NoError(t, err)
+		require.Empty(t, listAlerts.GetAlerts())
+	})
+
 	t.Run("List alerts by valid dev ID with alarm ID", func(t *testing.T) {
 		t.Parallel()
 
@@ -63,7 +87,8 @@ func TestListAlerts(t *testing.T) {
 		alerter := NewMockAlerter(gomock.NewController(t))
 		alerter.EXPECT().List(gomock.Any(), alert.GetOrgId(), "", devID,
 			alarmID, "", matcher.NewRecentMatcher(2*time.Second),
-			matcher.NewRecentMatcher(24*time.Hour+2*time.Second)).
+			matcher.NewRecentMatcher(24*time.Hour+2*time.Second),
+			gomock.Nil()).
 			Return([]*api.Alert{retAlert}, nil).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
@@ -92,7 +117,8 @@ func TestListAlerts(t *testing.T) {
 		alerter := NewMockAlerter(gomock.NewController(t))
 		alerter.EXPECT().List(gomock.Any(), alert.GetOrgId(), "", "",
 			"", userID, matcher.NewRecentMatcher(2*time.Second),
-			matcher.NewRecentMatcher(24*time.Hour+2*time.Second)).
+			matcher.NewRecentMatcher(24*time.Hour+2*time.Second),
+			gomock.Nil()).
 			Return([]*api.Alert{retAlert}, nil).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
@@ -162,8 +188,8 @@ func TestListAlerts(t *testing.T) {
 
 		alerter := NewMockAlerter(gomock.NewController(t))
 		alerter.EXPECT().List(gomock.Any(), "aaa", gomock.Any(), gomock.Any(),
-			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil,
-			dao.ErrInvalidFormat).Times(1)
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Nil()).Return(nil, dao.ErrInvalidFormat).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
 			&session.Session{OrgID: "aaa", Role: api.Role_ADMIN}), testTimeout)
@@ -0,0 +1,199 @@
+package service
+
+//go:generate mockgen -source command.go -destination mock_commander_test.go -package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-api/lora"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/metric"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Constants used for command behavior.
+const (
+	defaultCommandTTL = time.Hour
+	maxCommandTTL     = 7 * 24 * time.Hour
+	maxCommandErrLen  = 255
+)
+
+// Commander defines the methods provided by a command.DAO.
+type Commander interface {
+	Create(ctx context.Context, cmd *message.Command) (*message.Command, error)
+	UpdateStatus(ctx context.Context, ack *message.CommandAck) error
+	List(ctx context.Context, orgID, uniqID string, end,
+		start time.Time) ([]*message.Command, error)
+}
+
+// Command service contains functions to create and query device commands.
+type Command struct {
+	cmdDAO    Commander
+	devDAO    Devicer
+	mqttQueue queue.Queuer
+	lora      lora.Loraer
+}
+
+// NewCommand instantiates and returns a new Command service.
+func NewCommand(
+	cmdDAO Commander, devDAO Devicer, mqttQueue queue.Queuer, lora lora.Loraer,
+) *Command {
+	return &Command{
+		cmdDAO:    cmdDAO,
+		devDAO:    devDAO,
+		mqttQueue: mqttQueue,
+		lora:      lora,
+	}
+}
+
+// CreateCommand creates a command for a device and delivers it. Commands with
+// a non-zero FPort are enqueued as LoRaWAN downlinks, and all others are
+// published to the device's MQTT command topic. A ttl of zero uses the
+// default. Delivery failures are recorded on the returned command.
+func (c *Command) CreateCommand(
+	ctx context.Context, devID string, cmd *message.Command, ttl time.Duration,
+) (*message.Command, error) {
+	logger := alog.FromContext(ctx)
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return nil, errPerm(api.Role_BUILDER)
+	}
+
+	if len(cmd.GetPayload()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "payload required")
+	}
+
+	if ttl == 0 {
+		ttl = defaultCommandTTL
+	}
+
+	if ttl < 0 || ttl > maxCommandTTL {
+		return nil, status.Error(codes.InvalidArgument, "invalid TTL")
+	}
+
+	dev, err := readScoped(ctx, c.devDAO, sess, "", devID)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd.OrgId = sess.OrgID
+	cmd.UniqId = dev.GetUniqId()
+	cmd.Status = message.CommandStatus_QUEUED
+	cmd.Error = ""
+	cmd.LoraQueueId = ""
+	cmd.ExpiresAt = timestamppb.New(time.Now().Add(ttl))
+	cmd.TraceId = sess.TraceID.String()
+
+	cmd, err = c.cmdDAO.Create(ctx, cmd)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	ack := &message.CommandAck{
+		Id:      cmd.GetId(),
+		OrgId:   cmd.GetOrgId(),
+		UniqId:  cmd.GetUniqId(),
+		TraceId: cmd.GetTraceId(),
+	}
+
+	if cmd.GetFPort() > 0 {
+		ack.Status = message.CommandStatus_QUEUED
+		ack.LoraQueueId, err = c.lora.EnqueueDownlink(ctx, cmd.GetUniqId(),
+			cmd.GetFPort(), cmd.GetPayload())
+	} else {
+		// Mark the command as sent before publishing, so that a device
+		// acknowledgment may not precede it.
+		ack.Status = message.CommandStatus_SENT
+		if err := c.cmdDAO.UpdateStatus(ctx, ack); err != nil {
+			return nil, errToStatus(err)
+		}
+
+		err = c.publish(cmd)
+	}
+	if err != nil {
+		logger.Errorf("CreateCommand delivery: %v", err)
+		metric.Incr("error", map[string]string{metric.TagFunc: "command"})
+
+		ack.Status = message.CommandStatus_FAILED
+		ack.Error = err.Error()
+		if len(ack.GetError()) > maxCommandErrLen {
+			ack.Error = ack.GetError()[:maxCommandErrLen]
+		}
+	}
+
+	// Published commands have already been marked as sent. Commands that
+	// have since been acknowledged or expired are not updated.
+	if ack.GetStatus() != message.CommandStatus_SENT {
+		if err := c.cmdDAO.UpdateStatus(ctx, ack); err != nil &&
+			!errors.Is(err, dao.ErrNotFound) {
+			return nil, errToStatus(err)
+		}
+	}
+
+	cmd.Status = ack.GetStatus()
+	cmd.Error = ack.GetError()
+	cmd.LoraQueueId = ack.GetLoraQueueId()
+
+	logger.Debugf("CreateCommand cmd: %+v", cmd)
+
+	return cmd, nil
+}
+
+// publish publishes a command to its device's MQTT command topic.
+func (c *Command) publish(cmd *message.Command) error {
+	bCmd, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	return c.mqttQueue.Publish(fmt.Sprintf("v1/%s/%s/cmd", cmd.GetOrgId(),
+		cmd.GetUniqId()), bCmd)
+}
+
+// ListCommands retrieves all commands for a device in a [end, start) time
+// range, in descending timestamp order. Zero times default to the last 24
+// hours.
+func (c *Command) ListCommands(
+	ctx context.Context, devID string, end, start time.Time,
+) ([]*message.Command, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	if end.IsZero() {
+		end = time.Now().UTC()
+	}
+
+	if start.IsZero() || !start.Before(end) {
+		start = end.Add(-24 * time.Hour)
+	}
+
+	if end.Sub(start) > 90*24*time.Hour {
+		return nil, status.Error(codes.InvalidArgument,
+			"maximum time range exceeded")
+	}
+
+	dev, err := readScoped(ctx, c.devDAO, sess, "", devID)
+	if err != nil {
+		return nil, err
+	}
+
+	cmds, err := c.cmdDAO.List(ctx, sess.OrgID, dev.GetUniqId(), end, start)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return cmds, nil
+}
@@ -0,0 +1,449 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/lora"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCreateCommand(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Create valid MQTT command", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-command", uuid.NewV7().String())
+		cmd := random.Command("api-command", dev.GetOrgId())
+		cmd.FPort = 0
+		retCmd, _ := proto.Clone(cmd).(*message.Command)
+		retCmd.Id = uuid.NewV7().String()
+		retCmd.UniqId = dev.GetUniqId()
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		commander := NewMockCommander(ctrl)
+		commander.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Return(retCmd, nil).Times(1)
+		commander.EXPECT().UpdateStatus(gomock.Any(), gomock.Cond(
+			func(ack *message.CommandAck) bool {
+				return ack.GetId() == retCmd.GetId() &&
+					ack.GetStatus() == message.CommandStatus_SENT
+			})).Return(nil).Times(1)
+
+		mqttQueue := queue.NewFake()
+		cmdSub, err := mqttQueue.Subscribe("")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(commander, devicer, mqttQueue, nil)
+		createCmd, err := cmdSvc.CreateCommand(ctx, dev.GetId(), cmd, 0)
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.NoError(t, err)
+		require.Equal(t, message.CommandStatus_SENT, createCmd.GetStatus())
+
+		select {
+		case msg := <-cmdSub.C():
+			msg.Ack()
+			t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(), msg.Payload())
+			require.Equal(t, fmt.Sprintf("v1/%s/%s/cmd", dev.GetOrgId(),
+				dev.GetUniqId()), msg.Topic())
+
+			pubCmd := &message.Command{}
+			require.NoError(t, proto.Unmarshal(msg.Payload(), pubCmd))
+			require.Equal(t, retCmd.GetId(), pubCmd.GetId())
+			require.Equal(t, cmd.GetPayload(), pubCmd.GetPayload())
+		case <-time.After(testTimeout):
+			t.Fatal("Message timed out")
+		}
+	})
+
+	t.Run("Create valid LoRaWAN command", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-command", uuid.NewV7().String())
+		cmd := random.Command("api-command", dev.GetOrgId())
+		cmd.FPort = 2
+		retCmd, _ := proto.Clone(cmd).(*message.Command)
+		retCmd.Id = uuid.NewV7().String()
+		retCmd.UniqId = dev.GetUniqId()
+		queueID := uuid.NewV7().String()
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		commander := NewMockCommander(ctrl)
+		commander.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Return(retCmd, nil).Times(1)
+		commander.EXPECT().UpdateStatus(gomock.Any(), matcher.NewProtoMatcher(
+			&message.CommandAck{
+				Id: retCmd.GetId(), OrgId: dev.GetOrgId(),
+				UniqId: dev.GetUniqId(), LoraQueueId: queueID,
+				Status: message.CommandStatus_QUEUED, TraceId: cmd.GetTraceId(),
+			})).Return(nil).Times(1)
+		loraer := lora.NewMockLoraer(ctrl)
+		loraer.EXPECT().EnqueueDownlink(gomock.Any(), dev.GetUniqId(),
+			uint32(2), cmd.GetPayload()).Return(queueID, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(commander, devicer, nil, loraer)
+		createCmd, err := cmdSvc.CreateCommand(ctx, dev.GetId(), cmd, time.Hour)
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.NoError(t, err)
+		require.Equal(t, message.CommandStatus_QUEUED, createCmd.GetStatus())
+		require.Equal(t, queueID, createCmd.GetLoraQueueId())
+	})
+
+	t.Run("Create MQTT command with failed delivery", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-command", uuid.NewV7().String())
+		cmd := random.Command("api-command", dev.GetOrgId())
+		cmd.FPort = 0
+		retCmd, _ := proto.Clone(cmd).(*message.Command)
+		retCmd.Id = uuid.NewV7().String()
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		commander := NewMockCommander(ctrl)
+		commander.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Return(retCmd, nil).Times(1)
+		gomock.InOrder(
+			commander.EXPECT().UpdateStatus(gomock.Any(), gomock.Cond(
+				func(ack *message.CommandAck) bool {
+					return ack.GetStatus() == message.CommandStatus_SENT
+				})).Return(nil).Times(1),
+			commander.EXPECT().UpdateStatus(gomock.Any(), gomock.Cond(
+				func(ack *message.CommandAck) bool {
+					return ack.GetStatus() == message.CommandStatus_FAILED &&
+						ack.GetError() == queue.ErrTimeout.Error()
+				})).Return(nil).Times(1),
+		)
+		mqttQueue := queue.NewMockQueuer(ctrl)
+		mqttQueue.EXPECT().Publish(gomock.Any(), gomock.Any()).
+			Return(queue.ErrTimeout).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(commander, devicer, mqttQueue, nil)
+		createCmd, err := cmdSvc.CreateCommand(ctx, dev.GetId(), cmd, 0)
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.NoError(t, err)
+		require.Equal(t, message.CommandStatus_FAILED, createCmd.GetStatus())
+	})
+
+	t.Run("Create LoRaWAN command completed before update", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-command", uuid.NewV7().String())
+		cmd := random.Command("api-command", dev.GetOrgId())
+		cmd.FPort = 2
+		retCmd, _ := proto.Clone(cmd).(*message.Command)
+		retCmd.Id = uuid.NewV7().String()
+		queueID := uuid.NewV7().String()
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		commander := NewMockCommander(ctrl)
+		commander.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Return(retCmd, nil).Times(1)
+		commander.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).
+			Return(dao.ErrNotFound).Times(1)
+		loraer := lora.NewMockLoraer(ctrl)
+		loraer.EXPECT().EnqueueDownlink(gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(queueID, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(commander, devicer, nil, loraer)
+		createCmd, err := cmdSvc.CreateCommand(ctx, dev.GetId(), cmd, 0)
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.NoError(t, err)
+		require.Equal(t, queueID, createCmd.GetLoraQueueId())
+	})
+
+	t.Run("Create LoRaWAN command with failed delivery", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-command", uuid.NewV7().String())
+		cmd := random.Command("api-command", dev.GetOrgId())
+		cmd.FPort = 3
+		retCmd, _ := proto.Clone(cmd).(*message.Command)
+		retCmd.Id = uuid.NewV7().String()
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		commander := NewMockCommander(ctrl)
+		commander.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Return(retCmd, nil).Times(1)
+		commander.EXPECT().UpdateStatus(gomock.Any(), gomock.Cond(
+			func(ack *message.CommandAck) bool {
+				return ack.GetStatus() == message.CommandStatus_FAILED &&
+					ack.GetError() == dao.ErrInvalidFormat.Error()
+			})).Return(nil).Times(1)
+		loraer := lora.NewMockLoraer(ctrl)
+		loraer.EXPECT().EnqueueDownlink(gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return("", dao.ErrInvalidFormat).
+			Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(commander, devicer, nil, loraer)
+		createCmd, err := cmdSvc.CreateCommand(ctx, dev.GetId(), cmd, 0)
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.NoError(t, err)
+		require.Equal(t, message.CommandStatus_FAILED, createCmd.GetStatus())
+	})
+
+	t.Run("Create command with invalid session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(nil, nil, nil, nil)
+		createCmd, err := cmdSvc.CreateCommand(ctx, uuid.NewV7().String(),
+			nil, 0)
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.Nil(t, createCmd)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Create command with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(nil, nil, nil, nil)
+		createCmd, err := cmdSvc.CreateCommand(ctx, uuid.NewV7().String(),
+			nil, 0)
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.Nil(t, createCmd)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Create command without payload", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(nil, nil, nil, nil)
+		createCmd, err := cmdSvc.CreateCommand(ctx, uuid.NewV7().String(),
+			&message.Command{}, 0)
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.Nil(t, createCmd)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"payload required"), err)
+	})
+
+	t.Run("Create command with invalid TTL", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(nil, nil, nil, nil)
+		createCmd, err := cmdSvc.CreateCommand(ctx, uuid.NewV7().String(),
+			random.Command("api-command", uuid.NewV7().String()),
+			8*24*time.Hour)
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.Nil(t, createCmd)
+		require.Equal(t, status.Error(codes.InvalidArgument, "invalid TTL"),
+			err)
+	})
+
+	t.Run("Create command by unknown device", func(t *testing.T) {
+		t.Parallel()
+
+		devicer := NewMockDevicer(gomock.NewController(t))
+		devicer.EXPECT().Read(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(nil, devicer, nil, nil)
+		createCmd, err := cmdSvc.CreateCommand(ctx, uuid.NewV7().String(),
+			random.Command("api-command", uuid.NewV7().String()), 0)
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.Nil(t, createCmd)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+
+	t.Run("Create command with DAO error", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-command", uuid.NewV7().String())
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		commander := NewMockCommander(ctrl)
+		commander.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(commander, devicer, nil, nil)
+		createCmd, err := cmdSvc.CreateCommand(ctx, dev.GetId(),
+			random.Command("api-command", dev.GetOrgId()), 0)
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.Nil(t, createCmd)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"dao: invalid format"), err)
+	})
+}
+
+func TestListCommands(t *testing.T) {
+	t.Parallel()
+
+	t.Run("List commands by valid device ID", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-command", uuid.NewV7().String())
+		cmd := random.Command("api-command", dev.GetOrgId())
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		commander := NewMockCommander(ctrl)
+		commander.EXPECT().List(gomock.Any(), dev.GetOrgId(), dev.GetUniqId(),
+			matcher.NewRecentMatcher(2*time.Second),
+			matcher.NewRecentMatcher(24*time.Hour+2*time.Second)).
+			Return([]*message.Command{cmd}, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(commander, devicer, nil, nil)
+		listCmds, err := cmdSvc.ListCommands(ctx, dev.GetId(), time.Time{},
+			time.Time{})
+		t.Logf("listCmds, err: %+v, %v", listCmds, err)
+		require.NoError(t, err)
+		require.Equal(t, []*message.Command{cmd}, listCmds)
+	})
+
+	t.Run("List commands with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(nil, nil, nil, nil)
+		listCmds, err := cmdSvc.ListCommands(ctx, uuid.NewV7().String(),
+			time.Time{}, time.Time{})
+		t.Logf("listCmds, err: %+v, %v", listCmds, err)
+		require.Nil(t, listCmds)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("List commands by invalid time range", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		end := time.Now()
+
+		cmdSvc := NewCommand(nil, nil, nil, nil)
+		listCmds, err := cmdSvc.ListCommands(ctx, uuid.NewV7().String(), end,
+			end.Add(-91*24*time.Hour))
+		t.Logf("listCmds, err: %+v, %v", listCmds, err)
+		require.Nil(t, listCmds)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"maximum time range exceeded"), err)
+	})
+
+	t.Run("List commands with DAO error", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-command", uuid.NewV7().String())
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		commander := NewMockCommander(ctrl)
+		commander.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(nil, dao.ErrInvalidFormat).
+			Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		cmdSvc := NewCommand(commander, devicer, nil, nil)
+		listCmds, err := cmdSvc.ListCommands(ctx, dev.GetId(), time.Time{},
+			time.Time{})
+		t.Logf("listCmds, err: %+v, %v", listCmds, err)
+		require.Nil(t, listCmds)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"dao: invalid format"), err)
+	})
+}
@@ -0,0 +1,207 @@
+package service
+
+//go:generate mockgen -source connectivity.go -destination mock_connectivityer_test.go -package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Constants used for connectivity interval bounds.
+const (
+	minConnInterval = time.Minute
+	maxConnInterval = 30 * 24 * time.Hour
+)
+
+// Connectivityer defines the methods provided by a connectivity.DAO.
+type Connectivityer interface {
+	Read(ctx context.Context, devID, orgID string) (*message.Connectivity,
+		error)
+	UpsertInterval(ctx context.Context, inter *message.ConnectivityInterval) (
+		*message.ConnectivityInterval, error)
+	DeleteInterval(ctx context.Context, interID, orgID string) error
+	ListIntervals(ctx context.Context, orgID string) (
+		[]*message.ConnectivityInterval, error)
+}
+
+// Connectivity service contains functions to query device connectivity and
+// modify expected reporting intervals.
+type Connectivity struct {
+	connDAO Connectivityer
+	devDAO  Devicer
+}
+
+// NewConnectivity instantiates and returns a new Connectivity service.
+func NewConnectivity(connDAO Connectivityer, devDAO Devicer) *Connectivity {
+	return &Connectivity{
+		connDAO: connDAO,
+		devDAO:  devDAO,
+	}
+}
+
+// GetConnectivity retrieves a device's connectivity by device ID. Devices that
+// have never reported are not found.
+func (c *Connectivity) GetConnectivity(ctx context.Context, devID string) (
+	*message.Connectivity, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	if sess.TagScoped() {
+		if _, err := readScoped(ctx, c.devDAO, sess, "", devID); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := c.connDAO.Read(ctx, devID, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return conn, nil
+}
+
+// UpsertConnectivityInterval creates or updates an expected reporting interval
+// for a device or tag. Devices with both use the device interval, and devices
+// with multiple tag intervals use the shortest.
+func (c *Connectivity) UpsertConnectivityInterval(
+	ctx context.Context, inter *message.ConnectivityInterval,
+) (*message.ConnectivityInterval, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return nil, errPerm(api.Role_BUILDER)
+	}
+
+	if (inter.GetDevId() == "") == (inter.GetTag() == "") {
+		return nil, status.Error(codes.InvalidArgument,
+			"exactly one of device ID or tag required")
+	}
+
+	if d := inter.GetInterval().AsDuration(); d < minConnInterval ||
+		d > maxConnInterval {
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("interval must be between %v and %v",
+				minConnInterval, maxConnInterval))
+	}
+
+	// Verify the device belongs to the org and is in scope.
+	if inter.GetDevId() != "" {
+		if _, err := readScoped(ctx, c.devDAO, sess, "",
+			inter.GetDevId()); err != nil {
+			return nil, err
+		}
+	}
+
+	if inter.GetTag() != "" && !sess.TagInScope(inter.GetTag()) {
+		return nil, errScope()
+	}
+
+	inter.OrgId = sess.OrgID
+
+	upInter, err := c.connDAO.UpsertInterval(ctx, inter)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return upInter, nil
+}
+
+// DeleteConnectivityInterval deletes an expected reporting interval by ID.
+// Tag scoped sessions may only delete intervals within their scope.
+func (c *Connectivity) DeleteConnectivityInterval(
+	ctx context.Context, interID string,
+) error {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return errPerm(api.Role_BUILDER)
+	}
+
+	// Intervals that are out of scope are reported as not found.
+	if sess.TagScoped() {
+		inters, err := c.connDAO.ListIntervals(ctx, sess.OrgID)
+		if err != nil {
+			return errToStatus(err)
+		}
+
+		inters, err = c.scopeIntervals(ctx, sess, inters)
+		if err != nil {
+			return err
+		}
+
+		if !slices.ContainsFunc(inters,
+			func(inter *message.ConnectivityInterval) bool {
+				return inter.GetId() == interID
+			}) {
+			return errToStatus(dao.ErrNotFound)
+		}
+	}
+
+	if err := c.connDAO.DeleteInterval(ctx, interID, sess.OrgID); err != nil {
+		return errToStatus(err)
+	}
+
+	return nil
+}
+
+// ListConnectivityIntervals retrieves all expected reporting intervals. Tag
+// scoped sessions receive only intervals within their scope.
+func (c *Connectivity) ListConnectivityIntervals(ctx context.Context) (
+	[]*message.ConnectivityInterval, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	inters, err := c.connDAO.ListIntervals(ctx, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return c.scopeIntervals(ctx, sess, inters)
+}
+
+// scopeIntervals filters expected reporting intervals to those within a
+// session's scope tags. Device intervals are in scope if their device is.
+func (c *Connectivity) scopeIntervals(
+	ctx context.Context, sess *session.Session,
+	inters []*message.ConnectivityInterval,
+) ([]*message.ConnectivityInterval, error) {
+	if !sess.TagScoped() {
+		return inters, nil
+	}
+
+	scoped := []*message.ConnectivityInterval{}
+	for _, inter := range inters {
+		if inter.GetTag() != "" {
+			if sess.TagInScope(inter.GetTag()) {
+				scoped = append(scoped, inter)
+			}
+
+			continue
+		}
+
+		_, err := readScoped(ctx, c.devDAO, sess, "", inter.GetDevId())
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		scoped = append(scoped, inter)
+	}
+
+	return scoped, nil
+}
@@ -0,0 +1,446 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetConnectivity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get connectivity by valid device ID", func(t *testing.T) {
+		t.Parallel()
+
+		conn := &message.Connectivity{
+			DevId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+			LastSeenAt: timestamppb.Now(), Offline: true,
+		}
+
+		connectivityer := NewMockConnectivityer(gomock.NewController(t))
+		connectivityer.EXPECT().Read(gomock.Any(), conn.GetDevId(),
+			conn.GetOrgId()).Return(conn, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: conn.GetOrgId(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(connectivityer, nil)
+		getConn, err := connSvc.GetConnectivity(ctx, conn.GetDevId())
+		t.Logf("getConn, err: %+v, %v", getConn, err)
+		require.NoError(t, err)
+		require.Equal(t, conn, getConn)
+	})
+
+	t.Run("Get connectivity with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(nil, nil)
+		getConn, err := connSvc.GetConnectivity(ctx, uuid.NewV7().String())
+		t.Logf("getConn, err: %+v, %v", getConn, err)
+		require.Nil(t, getConn)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("Get connectivity by unknown device ID", func(t *testing.T) {
+		t.Parallel()
+
+		connectivityer := NewMockConnectivityer(gomock.NewController(t))
+		connectivityer.EXPECT().Read(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(connectivityer, nil)
+		getConn, err := connSvc.GetConnectivity(ctx, uuid.NewV7().String())
+		t.Logf("getConn, err: %+v, %v", getConn, err)
+		require.Nil(t, getConn)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"), err)
+	})
+}
+
+func TestUpsertConnectivityInterval(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Upsert valid device interval", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-conn", uuid.NewV7().String())
+		inter := &message.ConnectivityInterval{
+			DevId: dev.GetId(), Interval: durationpb.New(time.Hour),
+		}
+		retInter := &message.ConnectivityInterval{
+			Id: uuid.NewV7().String(), OrgId: dev.GetOrgId(),
+			DevId: dev.GetId(), Interval: durationpb.New(time.Hour),
+		}
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		connectivityer := NewMockConnectivityer(ctrl)
+		connectivityer.EXPECT().UpsertInterval(gomock.Any(), gomock.Cond(
+			func(inp *message.ConnectivityInterval) bool {
+				return inp.GetOrgId() == dev.GetOrgId()
+			})).Return(retInter, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(connectivityer, devicer)
+		upInter, err := connSvc.UpsertConnectivityInterval(ctx, inter)
+		t.Logf("upInter, err: %+v, %v", upInter, err)
+		require.NoError(t, err)
+		require.Equal(t, retInter, upInter)
+	})
+
+	t.Run("Upsert valid tag interval", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		inter := &message.ConnectivityInterval{
+			Tag: random.String(10), Interval: durationpb.New(time.Hour),
+		}
+
+		connectivityer := NewMockConnectivityer(gomock.NewController(t))
+		connectivityer.EXPECT().UpsertInterval(gomock.Any(), inter).
+			Return(inter, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(connectivityer, nil)
+		upInter, err := connSvc.UpsertConnectivityInterval(ctx, inter)
+		t.Logf("upInter, err: %+v, %v", upInter, err)
+		require.NoError(t, err)
+		require.Equal(t, orgID, upInter.GetOrgId())
+	})
+
+	t.Run("Upsert interval with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(nil, nil)
+		upInter, err := connSvc.UpsertConnectivityInterval(ctx, nil)
+		t.Logf("upInter, err: %+v, %v", upInter, err)
+		require.Nil(t, upInter)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Upsert invalid intervals", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			inp *message.ConnectivityInterval
+			err string
+		}{
+			{&message.ConnectivityInterval{
+				Interval: durationpb.New(time.Hour),
+			}, "exactly one of device ID or tag required"},
+			{&message.ConnectivityInterval{
+				DevId: uuid.NewV7().String(), Tag: random.String(10),
+				Interval: durationpb.New(time.Hour),
+			}, "exactly one of device ID or tag required"},
+			{&message.ConnectivityInterval{
+				Tag: random.String(10), Interval: durationpb.New(time.Second),
+			}, "interval must be between 1m0s and 720h0m0s"},
+			{&message.ConnectivityInterval{
+				Tag: random.String(10),
+			}, "interval must be between 1m0s and 720h0m0s"},
+		}
+
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("Cannot upsert %+v", test), func(t *testing.T) {
+				t.Parallel()
+
+				ctx, cancel := context.WithTimeout(session.NewContext(
+					t.Context(), &session.Session{
+						OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN,
+					}), testTimeout)
+				defer cancel()
+
+				connSvc := NewConnectivity(nil, nil)
+				upInter, err := connSvc.UpsertConnectivityInterval(ctx,
+					test.inp)
+				t.Logf("upInter, err: %+v, %v", upInter, err)
+				require.Nil(t, upInter)
+				require.Equal(t, status.Error(codes.InvalidArgument, test.err),
+					err)
+			})
+		}
+	})
+
+	t.Run("Upsert interval by unknown device ID", func(t *testing.T) {
+		t.Parallel()
+
+		devicer := NewMockDevicer(gomock.NewController(t))
+		devicer.EXPECT().Read(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(nil, devicer)
+		upInter, err := connSvc.UpsertConnectivityInterval(ctx,
+			&message.ConnectivityInterval{
+				DevId: uuid.NewV7().String(), Interval: durationpb.New(time.Hour),
+			})
+		t.Logf("upInter, err: %+v, %v", upInter, err)
+		require.Nil(t, upInter)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"), err)
+	})
+}
+
+func TestDeleteConnectivityInterval(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Delete interval by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		interID := uuid.NewV7().String()
+
+		connectivityer := NewMockConnectivityer(gomock.NewController(t))
+		connectivityer.EXPECT().DeleteInterval(gomock.Any(), interID, orgID).
+			Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(connectivityer, nil)
+		err := connSvc.DeleteConnectivityInterval(ctx, interID)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete interval with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		inDev := random.Device("api-conn", orgID)
+		inDev.Tags = []string{"site/b1"}
+		inters := []*message.ConnectivityInterval{
+			{Id: uuid.NewV7().String(), OrgId: orgID, Tag: "site"},
+			{Id: uuid.NewV7().String(), OrgId: orgID, Tag: "site2"},
+			{Id: uuid.NewV7().String(), OrgId: orgID, DevId: inDev.GetId()},
+		}
+
+		tests := []struct {
+			inpInterID string
+			err        error
+		}{
+			{inters[0].GetId(), nil},
+			{inters[1].GetId(), status.Error(codes.NotFound,
+				"dao: object not found")},
+			{inters[2].GetId(), nil},
+			{uuid.NewV7().String(), status.Error(codes.NotFound,
+				"dao: object not found")},
+		}
+
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("Can delete %+v", test), func(t *testing.T) {
+				t.Parallel()
+
+				ctrl := gomock.NewController(t)
+				connectivityer := NewMockConnectivityer(ctrl)
+				connectivityer.EXPECT().ListIntervals(gomock.Any(), orgID).
+					Return(inters, nil).Times(1)
+				if test.err == nil {
+					connectivityer.EXPECT().DeleteInterval(gomock.Any(),
+						test.inpInterID, orgID).Return(nil).Times(1)
+				}
+
+				devicer := NewMockDevicer(ctrl)
+				devicer.EXPECT().Read(gomock.Any(), inDev.GetId(), orgID).
+					Return(inDev, nil).Times(1)
+
+				ctx, cancel := context.WithTimeout(session.NewContext(
+					t.Context(), &session.Session{
+						OrgID: orgID, Role: api.Role_BUILDER,
+						ScopeTags: []string{"site"},
+					}), testTimeout)
+				defer cancel()
+
+				connSvc := NewConnectivity(connectivityer, devicer)
+				err := connSvc.DeleteConnectivityInterval(ctx,
+					test.inpInterID)
+				t.Logf("err: %v", err)
+				require.Equal(t, test.err, err)
+			})
+		}
+	})
+
+	t.Run("Delete interval with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(nil, nil)
+		err := connSvc.DeleteConnectivityInterval(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Delete interval by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		connectivityer := NewMockConnectivityer(gomock.NewController(t))
+		connectivityer.EXPECT().DeleteInterval(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(connectivityer, nil)
+		err := connSvc.DeleteConnectivityInterval(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"), err)
+	})
+}
+
+func TestListConnectivityIntervals(t *testing.T) {
+	t.Parallel()
+
+	t.Run("List intervals by valid org ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		inters := []*message.ConnectivityInterval{{
+			Id: uuid.NewV7().String(), OrgId: orgID, Tag: random.String(10),
+			Interval: durationpb.New(time.Hour),
+		}}
+
+		connectivityer := NewMockConnectivityer(gomock.NewController(t))
+		connectivityer.EXPECT().ListIntervals(gomock.Any(), orgID).
+			Return(inters, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(connectivityer, nil)
+		listInters, err := connSvc.ListConnectivityIntervals(ctx)
+		t.Logf("listInters, err: %+v, %v", listInters, err)
+		require.NoError(t, err)
+		require.Equal(t, inters, listInters)
+	})
+
+	t.Run("List intervals with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		inDev := random.Device("api-conn", orgID)
+		inDev.Tags = []string{"site/b1"}
+		outDev := random.Device("api-conn", orgID)
+		outDev.Tags = []string{"site2"}
+		inters := []*message.ConnectivityInterval{
+			{Id: uuid.NewV7().String(), OrgId: orgID, Tag: "site/b1"},
+			{Id: uuid.NewV7().String(), OrgId: orgID, Tag: "site2"},
+			{Id: uuid.NewV7().String(), OrgId: orgID, DevId: inDev.GetId()},
+			{Id: uuid.NewV7().String(), OrgId: orgID, DevId: outDev.GetId()},
+		}
+
+		ctrl := gomock.NewController(t)
+		connectivityer := NewMockConnectivityer(ctrl)
+		connectivityer.EXPECT().ListIntervals(gomock.Any(), orgID).
+			Return(inters, nil).Times(1)
+
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), inDev.GetId(), orgID).
+			Return(inDev, nil).Times(1)
+		devicer.EXPECT().Read(gomock.Any(), outDev.GetId(), orgID).
+			Return(outDev, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: orgID, Role: api.Role_VIEWER,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(connectivityer, devicer)
+		listInters, err := connSvc.ListConnectivityIntervals(ctx)
+		t.Logf("listInters, err: %+v, %v", listInters, err)
+		require.NoError(t, err)
+		require.Equal(t, []*message.ConnectivityInterval{inters[0], inters[2]},
+			listInters)
+	})
+
+	t.Run("List intervals with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(nil, nil)
+		listInters, err := connSvc.ListConnectivityIntervals(ctx)
+		t.Logf("listInters, err: %+v, %v", listInters, err)
+		require.Nil(t, listInters)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("List intervals with DAO error", func(t *testing.T) {
+		t.Parallel()
+
+		connectivityer := NewMockConnectivityer(gomock.NewController(t))
+		connectivityer.EXPECT().ListIntervals(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		connSvc := NewConnectivity(connectivityer, nil)
+		listInters, err := connSvc.ListConnectivityIntervals(ctx)
+		t.Logf("listInters, err: %+v, %v", listInters, err)
+		require.Nil(t, listInters)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"dao: invalid format"), err)
+	})
+}
@@ -4,12 +4,18 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/thingspect/atlas/internal/atlas-api/session"
 	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/datapoint"
 	"github.com/thingspect/atlas/pkg/metric"
 	"github.com/thingspect/atlas/pkg/queue"
 	"github.com/thingspect/atlas/proto/go/message"
@@ -24,12 +30,18 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// maxAggregateBuckets is the maximum count of buckets, and therefore data
+// points, returned by an aggregation.
+const maxAggregateBuckets = 10000
+
 // DataPointer defines the methods provided by a datapoint.DAO.
 type DataPointer interface {
 	List(ctx context.Context, orgID, uniqID, devID, attr string, end,
 		start time.Time) ([]*common.DataPoint, error)
 	Latest(ctx context.Context, orgID, uniqID, devID string,
 		start time.Time) ([]*common.DataPoint, error)
+	Aggregate(ctx context.Context, orgID, uniqID, devID, attr, fn string,
+		bucket time.Duration, end, start time.Time) ([]*common.DataPoint, error)
 }
 
 // DataPoint service contains functions to create and query data points.
@@ -39,18 +51,20 @@ type DataPoint struct {
 	dpQueue     queue.Queuer
 	vInPubTopic string
 
-	dpDAO DataPointer
+	dpDAO  DataPointer
+	devDAO Devicer
 }
 
 // NewDataPoint instantiates and returns a new DataPoint service.
 func NewDataPoint(
-	pubQueue queue.Queuer, pubTopic string, dpDAO DataPointer,
+	pubQueue queue.Queuer, pubTopic string, dpDAO DataPointer, devDAO Devicer,
 ) *DataPoint {
 	return &DataPoint{
 		dpQueue:     pubQueue,
 		vInPubTopic: pubTopic,
 
-		dpDAO: dpDAO,
+		dpDAO:  dpDAO,
+		devDAO: devDAO,
 	}
 }
 
@@ -66,6 +80,15 @@ func (d *DataPoint) PublishDataPoints(
 
 	logger.Logger = logger.WithField("paylType", "api")
 
+	// Verify all devices are allowed before publishing any data points.
+	if sess.PublishRestricted() || sess.TagScoped() {
+		for _, point := range req.GetPoints() {
+			if err := d.canPublish(ctx, sess, point.GetUniqId()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Build and publish ValidatorIn messages.
 	for _, point := range req.GetPoints() {
 		vIn := &message.ValidatorIn{
@@ -105,8 +128,46 @@ func (d *DataPoint) PublishDataPoints(
 	return &emptypb.Empty{}, nil
 }
 
+// canPublish verifies that a session restricted to a subset of devices or
+// scoped by tag may publish for a device by uniq ID.
+func (d *DataPoint) canPublish(
+	ctx context.Context, sess *session.Session, uniqID string,
+) error {
+	if !sess.TagScoped() && sess.CanPublish(uniqID, nil) {
+		return nil
+	}
+
+	dev, err := d.devDAO.ReadByUniqID(ctx, uniqID)
+	if err != nil && !errors.Is(err, dao.ErrNotFound) {
+		return errToStatus(err)
+	}
+
+	// Do not reveal whether a device exists in another org.
+	if dev == nil || dev.GetOrgId() != sess.OrgID {
+		dev = &api.Device{}
+	}
+
+	if !sess.CanPublish(uniqID, dev.GetTags()) {
+		return status.Error(codes.PermissionDenied,
+			"permission denied, device not allowed for key")
+	}
+
+	if !sess.InTagScope(dev.GetTags()) {
+		return errScope()
+	}
+
+	return nil
+}
+
 // ListDataPoints retrieves all data points for a device in a [end, start) time
 // range, in descending timestamp order.
+//
+// Data points of an attribute are instead aggregated into one data point per
+// time bucket if the AggregateFuncKey metadata is provided, with a function of
+// avg, min, max, sum, count, first, or last. The AggregateBucketKey metadata
+// is required with it, and provides the bucket width in time.ParseDuration
+// format or in whole days, such as "1d". Over HTTP, the aggregateFunc and
+// aggregateBucket query parameters are forwarded as this metadata.
 func (d *DataPoint) ListDataPoints(
 	ctx context.Context, req *api.ListDataPointsRequest,
 ) (*api.ListDataPointsResponse, error) {
@@ -140,6 +201,40 @@ func (d *DataPoint) ListDataPoints(
 			"maximum time range exceeded")
 	}
 
+	if sess.TagScoped() {
+		if _, err := readScoped(ctx, d.devDAO, sess, uniqID,
+			devID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Aggregate data points when requested through metadata, until the
+	// aggregation fields are available on ListDataPointsRequest.
+	if fn, bucketStr, ok := aggregateFromContext(ctx); ok {
+		if _, ok := datapoint.Funcs[fn]; !ok {
+			return nil, status.Error(codes.InvalidArgument,
+				"invalid aggregate function")
+		}
+
+		bucket, err := parseBucket(bucketStr, end.Sub(start))
+		if err != nil {
+			return nil, err
+		}
+
+		if req.GetAttr() == "" {
+			return nil, status.Error(codes.InvalidArgument,
+				"attr required for aggregation")
+		}
+
+		points, err := d.dpDAO.Aggregate(ctx, sess.OrgID, uniqID, devID,
+			req.GetAttr(), fn, bucket, end, start)
+		if err != nil {
+			return nil, errToStatus(err)
+		}
+
+		return &api.ListDataPointsResponse{Points: points}, nil
+	}
+
 	points, err := d.dpDAO.List(ctx, sess.OrgID, uniqID, devID, req.GetAttr(), end,
 		start)
 	if err != nil {
@@ -181,6 +276,13 @@ func (d *DataPoint) LatestDataPoints(
 			"maximum time range exceeded")
 	}
 
+	if sess.TagScoped() {
+		if _, err := readScoped(ctx, d.devDAO, sess, uniqID,
+			devID); err != nil {
+			return nil, err
+		}
+	}
+
 	points, err := d.dpDAO.Latest(ctx, sess.OrgID, uniqID, devID, start)
 	if err != nil {
 		return nil, errToStatus(err)
@@ -188,3 +290,62 @@ func (d *DataPoint) LatestDataPoints(
 
 	return &api.LatestDataPointsResponse{Points: points}, nil
 }
+
+// aggregateFromContext returns the aggregation function and bucket width
+// requested through incoming metadata, if present.
+func aggregateFromContext(ctx context.Context) (string, string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", "", false
+	}
+
+	fns := md.Get(AggregateFuncKey)
+	if len(fns) == 0 || fns[0] == "" {
+		return "", "", false
+	}
+
+	var bucket string
+	if buckets := md.Get(AggregateBucketKey); len(buckets) > 0 {
+		bucket = buckets[0]
+	}
+
+	return strings.ToLower(fns[0]), bucket, true
+}
+
+// parseBucket parses a bucket width in time.ParseDuration format, with
+// additional support for whole days, such as "1d". Buckets must be at least a
+// second wide, and must divide the time range into at most
+// maxAggregateBuckets.
+func parseBucket(bucket string, timeRange time.Duration) (
+	time.Duration, error,
+) {
+	errBucket := status.Error(codes.InvalidArgument, "invalid aggregate bucket")
+
+	var dur time.Duration
+	if days, ok := strings.CutSuffix(bucket, "d"); ok {
+		// Bound days to prevent overflow of the bucket duration.
+		d, err := strconv.Atoi(days)
+		if err != nil || d > int(math.MaxInt64/(24*time.Hour)) {
+			return 0, errBucket
+		}
+
+		dur = time.Duration(d) * 24 * time.Hour
+	} else {
+		var err error
+		if dur, err = time.ParseDuration(bucket); err != nil {
+			return 0, errBucket
+		}
+	}
+
+	if dur < time.Second {
+		return 0, errBucket
+	}
+
+	if timeRange/dur > maxAggregateBuckets {
+		return 0, status.Error(codes.InvalidArgument, fmt.Sprintf(
+			"aggregate bucket must divide time range into at most %d buckets",
+			maxAggregateBuckets))
+	}
+
+	return dur, nil
+}
@@ -4,6 +4,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 	"uuid"
@@ -20,6 +21,7 @@ import (
 	"github.com/thingspect/proto/go/common"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -48,7 +50,7 @@ func TestPublishDataPoints(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(dpQueue, vInPubTopic, nil)
+		dpSvc := NewDataPoint(dpQueue, vInPubTopic, nil, nil)
 		_, err = dpSvc.PublishDataPoints(ctx, &api.PublishDataPointsRequest{
 			Points: []*common.DataPoint{point},
 		})
@@ -94,7 +96,7 @@ func TestPublishDataPoints(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(pubQueue, pubTopic, nil)
+		dpSvc := NewDataPoint(pubQueue, pubTopic, nil, nil)
 		_, err = dpSvc.PublishDataPoints(ctx, &api.PublishDataPointsRequest{
 			Points: []*common.DataPoint{point},
 		})
@@ -132,7 +134,7 @@ func TestPublishDataPoints(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", nil)
+		dpSvc := NewDataPoint(nil, "", nil, nil)
 		_, err := dpSvc.PublishDataPoints(ctx, &api.PublishDataPointsRequest{})
 		t.Logf("err: %v", err)
 		require.Equal(t, errPerm(api.Role_PUBLISHER), err)
@@ -146,7 +148,7 @@ func TestPublishDataPoints(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", nil)
+		dpSvc := NewDataPoint(nil, "", nil, nil)
 		_, err := dpSvc.PublishDataPoints(ctx, &api.PublishDataPointsRequest{})
 		t.Logf("err: %v", err)
 		require.Equal(t, errPerm(api.Role_PUBLISHER), err)
@@ -171,13 +173,125 @@ func TestPublishDataPoints(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(queuer, vInPubTopic, nil)
+		dpSvc := NewDataPoint(queuer, vInPubTopic, nil, nil)
 		_, err := dpSvc.PublishDataPoints(ctx, &api.PublishDataPointsRequest{
 			Points: []*common.DataPoint{point},
 		})
 		t.Logf("err: %v", err)
 		require.Equal(t, status.Error(codes.Internal, "publish failure"), err)
 	})
+
+	t.Run("Publish data points with restricted key", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		tag := random.String(10)
+		uniqID := "api-point-" + random.String(16)
+
+		tagDev := random.Device("api-point", orgID)
+		tagDev.Tags = []string{tag}
+		otherDev := random.Device("api-point", uuid.NewV7().String())
+		otherDev.Tags = []string{tag}
+
+		tests := []struct {
+			inpUniqID   string
+			inpDev      *api.Device
+			inpErr      error
+			inpDevTimes int
+			err         error
+		}{
+			{uniqID, nil, nil, 0, nil},
+			{tagDev.GetUniqId(), tagDev, nil, 1, nil},
+			{otherDev.GetUniqId(), otherDev, nil, 1, status.Error(
+				codes.PermissionDenied,
+				"permission denied, device not allowed for key")},
+			{random.String(16), nil, dao.ErrNotFound, 1, status.Error(
+				codes.PermissionDenied,
+				"permission denied, device not allowed for key")},
+			{random.String(16), nil, dao.ErrInvalidFormat, 1, status.Error(
+				codes.InvalidArgument, "dao: invalid format")},
+		}
+
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("Can publish %+v", test), func(t *testing.T) {
+				t.Parallel()
+
+				devicer := NewMockDevicer(gomock.NewController(t))
+				devicer.EXPECT().ReadByUniqID(gomock.Any(), test.inpUniqID).
+					Return(test.inpDev, test.inpErr).Times(test.inpDevTimes)
+
+				ctx, cancel := context.WithTimeout(session.NewContext(
+					t.Context(), &session.Session{
+						OrgID: orgID, Role: api.Role_PUBLISHER,
+						UniqIDs: []string{uniqID}, Tags: []string{tag},
+					}), testTimeout)
+				defer cancel()
+
+				dpSvc := NewDataPoint(queue.NewFake(), "topic-"+
+					random.String(10), nil, devicer)
+				_, err := dpSvc.PublishDataPoints(ctx,
+					&api.PublishDataPointsRequest{
+						Points: []*common.DataPoint{{
+							UniqId: test.inpUniqID, Attr: radiobridge.AttrCount,
+							ValOneof: &common.DataPoint_IntVal{IntVal: 123},
+						}},
+					})
+				t.Logf("err: %v", err)
+				require.Equal(t, test.err, err)
+			})
+		}
+	})
+}
+
+func TestPublishDataPointsScoped(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.NewV7().String()
+
+	scopeDev := random.Device("api-point", orgID)
+	scopeDev.Tags = []string{"site/b1"}
+	outDev := random.Device("api-point", orgID)
+	outDev.Tags = []string{"site2"}
+
+	tests := []struct {
+		inpUniqID string
+		inpDev    *api.Device
+		inpErr    error
+		err       error
+	}{
+		{scopeDev.GetUniqId(), scopeDev, nil, nil},
+		{outDev.GetUniqId(), outDev, nil, errScope()},
+		{random.String(16), nil, dao.ErrNotFound, errScope()},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can publish %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			devicer := NewMockDevicer(gomock.NewController(t))
+			devicer.EXPECT().ReadByUniqID(gomock.Any(), test.inpUniqID).
+				Return(test.inpDev, test.inpErr).Times(1)
+
+			ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+				&session.Session{
+					OrgID: orgID, Role: api.Role_PUBLISHER,
+					ScopeTags: []string{"site"},
+				}), testTimeout)
+			defer cancel()
+
+			dpSvc := NewDataPoint(queue.NewFake(), "topic-"+random.String(10),
+				nil, devicer)
+			_, err := dpSvc.PublishDataPoints(ctx,
+				&api.PublishDataPointsRequest{
+					Points: []*common.DataPoint{{
+						UniqId: test.inpUniqID, Attr: radiobridge.AttrCount,
+						ValOneof: &common.DataPoint_IntVal{IntVal: 123},
+					}},
+				})
+			t.Logf("err: %v", err)
+			require.Equal(t, test.err, err)
+		})
+	}
 }
 
 func TestListDataPoints(t *testing.T) {
@@ -204,7 +318,7 @@ func TestListDataPoints(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", datapointer)
+		dpSvc := NewDataPoint(nil, "", datapointer, nil)
 		listPoints, err := dpSvc.ListDataPoints(ctx, &api.ListDataPointsRequest{
 			IdOneof: &api.ListDataPointsRequest_UniqId{UniqId: point.GetUniqId()},
 			EndTime: timestamppb.New(end), StartTime: timestamppb.New(start),
@@ -238,7 +352,7 @@ func TestListDataPoints(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", datapointer)
+		dpSvc := NewDataPoint(nil, "", datapointer, nil)
 		listPoints, err := dpSvc.ListDataPoints(ctx, &api.ListDataPointsRequest{
 			IdOneof: &api.ListDataPointsRequest_DeviceId{DeviceId: devID},
 			Attr:    point.GetAttr(),
@@ -250,13 +364,101 @@ func TestListDataPoints(t *testing.T) {
 		}, listPoints)
 	})
 
+	t.Run("List data points with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		dev := random.Device("api-point", orgID)
+		dev.Tags = []string{"site/b1"}
+
+		devicer := NewMockDevicer(gomock.NewController(t))
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), orgID).Return(dev,
+			nil).Times(1)
+
+		datapointer := NewMockDataPointer(gomock.NewController(t))
+		datapointer.EXPECT().List(gomock.Any(), orgID, "", dev.GetId(), "",
+			gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: orgID, Role: api.Role_ADMIN, ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		dpSvc := NewDataPoint(nil, "", datapointer, devicer)
+		listPoints, err := dpSvc.ListDataPoints(ctx, &api.ListDataPointsRequest{
+			IdOneof: &api.ListDataPointsRequest_DeviceId{DeviceId: dev.GetId()},
+		})
+		t.Logf("listPoints, err: %+v, %v", listPoints, err)
+		require.NoError(t, err)
+		require.Empty(t, listPoints.GetPoints())
+	})
+
+	t.Run("List data points out of scope", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-point", uuid.NewV7().String())
+		dev.Tags = []string{"site2"}
+
+		devicer := NewMockDevicer(gomock.NewController(t))
+		devicer.EXPECT().ReadByUniqID(gomock.Any(), dev.GetUniqId()).
+			Return(dev, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: dev.GetOrgId(), Role: api.Role_ADMIN,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		dpSvc := NewDataPoint(nil, "", nil, devicer)
+		listPoints, err := dpSvc.ListDataPoints(ctx, &api.ListDataPointsRequest{
+			IdOneof: &api.ListDataPointsRequest_UniqId{UniqId: dev.GetUniqId()},
+		})
+		t.Logf("listPoints, err: %+v, %v", listPoints, err)
+		require.Nil(t, listPoints)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+
+	t.Run("List data points from another org with scope tags",
+		func(t *testing.T) {
+			t.Parallel()
+
+			dev := random.Device("api-point", uuid.NewV7().String())
+			dev.Tags = []string{"site"}
+
+			devicer := NewMockDevicer(gomock.NewController(t))
+			devicer.EXPECT().ReadByUniqID(gomock.Any(), dev.GetUniqId()).
+				Return(dev, nil).Times(1)
+
+			ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+				&session.Session{
+					OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN,
+					ScopeTags: []string{"site"},
+				}), testTimeout)
+			defer cancel()
+
+			dpSvc := NewDataPoint(nil, "", nil, devicer)
+			listPoints, err := dpSvc.ListDataPoints(ctx,
+				&api.ListDataPointsRequest{
+					IdOneof: &api.ListDataPointsRequest_UniqId{
+						UniqId: dev.GetUniqId(),
+					},
+				})
+			t.Logf("listPoints, err: %+v, %v", listPoints, err)
+			require.Nil(t, listPoints)
+			require.Equal(t, status.Error(codes.NotFound,
+				"dao: object not found"), err)
+		})
+
 	t.Run("List data points with invalid session", func(t *testing.T) {
 		t.Parallel()
 
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", nil)
+		dpSvc := NewDataPoint(nil, "", nil, nil)
 		listPoints, err := dpSvc.ListDataPoints(ctx,
 			&api.ListDataPointsRequest{})
 		t.Logf("listPoints, err: %+v, %v", listPoints, err)
@@ -272,7 +474,7 @@ func TestListDataPoints(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", nil)
+		dpSvc := NewDataPoint(nil, "", nil, nil)
 		listPoints, err := dpSvc.ListDataPoints(ctx,
 			&api.ListDataPointsRequest{})
 		t.Logf("listPoints, err: %+v, %v", listPoints, err)
@@ -288,7 +490,7 @@ func TestListDataPoints(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", nil)
+		dpSvc := NewDataPoint(nil, "", nil, nil)
 		listPoints, err := dpSvc.ListDataPoints(ctx, &api.ListDataPointsRequest{
 			IdOneof: &api.ListDataPointsRequest_UniqId{UniqId: "api-point-" +
 				random.String(16)}, EndTime: timestamppb.Now(),
@@ -312,7 +514,7 @@ func TestListDataPoints(t *testing.T) {
 			&session.Session{OrgID: "aaa", Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", datapointer)
+		dpSvc := NewDataPoint(nil, "", datapointer, nil)
 		listPoints, err := dpSvc.ListDataPoints(ctx, &api.ListDataPointsRequest{
 			IdOneof: &api.ListDataPointsRequest_UniqId{UniqId: "api-point-" +
 				random.String(16)},
@@ -322,6 +524,173 @@ func TestListDataPoints(t *testing.T) {
 		require.Equal(t, status.Error(codes.InvalidArgument,
 			"dao: invalid format"), err)
 	})
+
+	t.Run("Aggregate data points by valid UniqID with ts", func(t *testing.T) {
+		t.Parallel()
+
+		point := &common.DataPoint{
+			UniqId: "api-point-" + random.String(16), Attr: radiobridge.AttrCount,
+			ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 12.5},
+			Ts:       timestamppb.Now(),
+		}
+		retPoint, _ := proto.Clone(point).(*common.DataPoint)
+		orgID := uuid.NewV7().String()
+		end := time.Now().UTC()
+		start := time.Now().UTC().Add(-15 * time.Minute)
+
+		datapointer := NewMockDataPointer(gomock.NewController(t))
+		datapointer.EXPECT().Aggregate(gomock.Any(), orgID, point.GetUniqId(),
+			"", point.GetAttr(), "avg", 24*time.Hour, end, start).
+			Return([]*common.DataPoint{retPoint}, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(
+			metadata.NewIncomingContext(t.Context(), metadata.Pairs(
+				AggregateFuncKey, "AVG", AggregateBucketKey, "1d")),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		dpSvc := NewDataPoint(nil, "", datapointer, nil)
+		listPoints, err := dpSvc.ListDataPoints(ctx, &api.ListDataPointsRequest{
+			IdOneof: &api.ListDataPointsRequest_UniqId{UniqId: point.GetUniqId()},
+			Attr:    point.GetAttr(), EndTime: timestamppb.New(end),
+			StartTime: timestamppb.New(start),
+		})
+		t.Logf("point, listPoints, err: %+v, %+v, %v", point, listPoints, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, &api.ListDataPointsResponse{
+			Points: []*common.DataPoint{point},
+		}, listPoints)
+	})
+
+	t.Run("Aggregate data points by invalid bucket", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(
+			metadata.NewIncomingContext(t.Context(), metadata.Pairs(
+				AggregateFuncKey, "avg", AggregateBucketKey, "1x")),
+			&session.Session{OrgID: uuid.NewV7().String(),
+				Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		dpSvc := NewDataPoint(nil, "", nil, nil)
+		listPoints, err := dpSvc.ListDataPoints(ctx, &api.ListDataPointsRequest{
+			IdOneof: &api.ListDataPointsRequest_UniqId{UniqId: "api-point-" +
+				random.String(16)}, Attr: radiobridge.AttrCount,
+		})
+		t.Logf("listPoints, err: %+v, %v", listPoints, err)
+		require.Nil(t, listPoints)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"invalid aggregate bucket"), err)
+	})
+
+	t.Run("Aggregate data points without attr", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(
+			metadata.NewIncomingContext(t.Context(), metadata.Pairs(
+				AggregateFuncKey, "count", AggregateBucketKey, "1h")),
+			&session.Session{OrgID: uuid.NewV7().String(),
+				Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		dpSvc := NewDataPoint(nil, "", nil, nil)
+		listPoints, err := dpSvc.ListDataPoints(ctx, &api.ListDataPointsRequest{
+			IdOneof: &api.ListDataPointsRequest_UniqId{UniqId: "api-point-" +
+				random.String(16)},
+		})
+		t.Logf("listPoints, err: %+v, %v", listPoints, err)
+		require.Nil(t, listPoints)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"attr required for aggregation"), err)
+	})
+
+	t.Run("Aggregate data points by invalid function", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(
+			metadata.NewIncomingContext(t.Context(), metadata.Pairs(
+				AggregateFuncKey, "median", AggregateBucketKey, "1m")),
+			&session.Session{OrgID: uuid.NewV7().String(),
+				Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		dpSvc := NewDataPoint(nil, "", nil, nil)
+		listPoints, err := dpSvc.ListDataPoints(ctx, &api.ListDataPointsRequest{
+			IdOneof: &api.ListDataPointsRequest_UniqId{UniqId: "api-point-" +
+				random.String(16)}, Attr: radiobridge.AttrCount,
+		})
+		t.Logf("listPoints, err: %+v, %v", listPoints, err)
+		require.Nil(t, listPoints)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"invalid aggregate function"), err)
+	})
+
+	t.Run("Aggregate data points with DAO failure", func(t *testing.T) {
+		t.Parallel()
+
+		datapointer := NewMockDataPointer(gomock.NewController(t))
+		datapointer.EXPECT().Aggregate(gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any(), "max", time.Minute,
+			gomock.Any(), gomock.Any()).Return(nil, dao.ErrInvalidFormat).
+			Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(
+			metadata.NewIncomingContext(t.Context(), metadata.Pairs(
+				AggregateFuncKey, "max", AggregateBucketKey, "1m")),
+			&session.Session{OrgID: uuid.NewV7().String(),
+				Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		dpSvc := NewDataPoint(nil, "", datapointer, nil)
+		listPoints, err := dpSvc.ListDataPoints(ctx, &api.ListDataPointsRequest{
+			IdOneof: &api.ListDataPointsRequest_UniqId{UniqId: "api-point-" +
+				random.String(16)}, Attr: radiobridge.AttrCount,
+		})
+		t.Logf("listPoints, err: %+v, %v", listPoints, err)
+		require.Nil(t, listPoints)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"dao: invalid format"), err)
+	})
+}
+
+func TestParseBucket(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpBucket string
+		inpRange  time.Duration
+		res       time.Duration
+		err       string
+	}{
+		{"1m", 24 * time.Hour, time.Minute, ""},
+		{"2d", 90 * 24 * time.Hour, 48 * time.Hour, ""},
+		{"10s", 24 * time.Hour, 10 * time.Second, ""},
+		{"1x", 24 * time.Hour, 0, "invalid aggregate bucket"},
+		{"xd", 24 * time.Hour, 0, "invalid aggregate bucket"},
+		{"0s", 24 * time.Hour, 0, "invalid aggregate bucket"},
+		{"-1m", 24 * time.Hour, 0, "invalid aggregate bucket"},
+		{"-1d", 24 * time.Hour, 0, "invalid aggregate bucket"},
+		{"999999999d", 24 * time.Hour, 0, "invalid aggregate bucket"},
+		{"500ms", time.Minute, 0, "invalid aggregate bucket"},
+		{"1s", 24 * time.Hour, 0, "at most 10000 buckets"},
+		{"1m", 90 * 24 * time.Hour, 0, "at most 10000 buckets"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can parse %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			res, err := parseBucket(test.inpBucket, test.inpRange)
+			t.Logf("res, err: %v, %v", res, err)
+			require.Equal(t, test.res, res)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
 }
 
 func TestLatestDataPoints(t *testing.T) {
@@ -347,7 +716,7 @@ func TestLatestDataPoints(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", datapointer)
+		dpSvc := NewDataPoint(nil, "", datapointer, nil)
 		latPoints, err := dpSvc.LatestDataPoints(ctx,
 			&api.LatestDataPointsRequest{
 				IdOneof: &api.LatestDataPointsRequest_UniqId{
@@ -382,7 +751,7 @@ func TestLatestDataPoints(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", datapointer)
+		dpSvc := NewDataPoint(nil, "", datapointer, nil)
 		latPoints, err := dpSvc.LatestDataPoints(ctx,
 			&api.LatestDataPointsRequest{
 				IdOneof: &api.LatestDataPointsRequest_DeviceId{
@@ -397,13 +766,43 @@ func TestLatestDataPoints(t *testing.T) {
 		}, latPoints)
 	})
 
+	t.Run("Latest data points out of scope", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-point", uuid.NewV7().String())
+		dev.Tags = []string{"site2"}
+
+		devicer := NewMockDevicer(gomock.NewController(t))
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: dev.GetOrgId(), Role: api.Role_ADMIN,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		dpSvc := NewDataPoint(nil, "", nil, devicer)
+		latPoints, err := dpSvc.LatestDataPoints(ctx,
+			&api.LatestDataPointsRequest{
+				IdOneof: &api.LatestDataPointsRequest_DeviceId{
+					DeviceId: dev.GetId(),
+				},
+			})
+		t.Logf("latPoints, err: %+v, %v", latPoints, err)
+		require.Nil(t, latPoints)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+
 	t.Run("Latest data points with invalid session", func(t *testing.T) {
 		t.Parallel()
 
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", nil)
+		dpSvc := NewDataPoint(nil, "", nil, nil)
 		latPoints, err := dpSvc.LatestDataPoints(ctx,
 			&api.LatestDataPointsRequest{})
 		t.Logf("latPoints, err: %+v, %v", latPoints, err)
@@ -419,7 +818,7 @@ func TestLatestDataPoints(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", nil)
+		dpSvc := NewDataPoint(nil, "", nil, nil)
 		latPoints, err := dpSvc.LatestDataPoints(ctx,
 			&api.LatestDataPointsRequest{})
 		t.Logf("latPoints, err: %+v, %v", latPoints, err)
@@ -435,7 +834,7 @@ func TestLatestDataPoints(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", nil)
+		dpSvc := NewDataPoint(nil, "", nil, nil)
 		latPoints, err := dpSvc.LatestDataPoints(ctx,
 			&api.LatestDataPointsRequest{
 				IdOneof: &api.LatestDataPointsRequest_UniqId{
@@ -462,7 +861,7 @@ func TestLatestDataPoints(t *testing.T) {
 			&session.Session{OrgID: "aaa", Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		dpSvc := NewDataPoint(nil, "", datapointer)
+		dpSvc := NewDataPoint(nil, "", datapointer, nil)
 		latPoints, err := dpSvc.LatestDataPoints(ctx,
 			&api.LatestDataPointsRequest{
 				IdOneof: &api.LatestDataPointsRequest_UniqId{
@@ -25,10 +25,12 @@ import (
 type Devicer interface {
 	Create(ctx context.Context, dev *api.Device) (*api.Device, error)
 	Read(ctx context.Context, devID, orgID string) (*api.Device, error)
+	ReadByUniqID(ctx context.Context, uniqID string) (*api.Device, error)
 	Update(ctx context.Context, dev *api.Device) (*api.Device, error)
 	Delete(ctx context.Context, devID, orgID string) error
 	List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string,
-		limit int32, tag string) ([]*api.Device, int32, error)
+		limit int32, tag string, scopeTags []string) ([]*api.Device, int32,
+		error)
 }
 
 // Device service contains functions to query and modify devices.
@@ -56,6 +58,10 @@ func (d *Device) CreateDevice(
 		return nil, errPerm(api.Role_BUILDER)
 	}
 
+	if !sess.InTagScope(req.GetDevice().GetTags()) {
+		return nil, errScope()
+	}
+
 	req.Device.OrgId = sess.OrgID
 
 	dev, err := d.devDAO.Create(ctx, req.GetDevice())
@@ -82,9 +88,9 @@ func (d *Device) CreateDeviceLoRaWAN(
 		return nil, errPerm(api.Role_BUILDER)
 	}
 
-	dev, err := d.devDAO.Read(ctx, req.GetId(), sess.OrgID)
+	dev, err := readScoped(ctx, d.devDAO, sess, "", req.GetId())
 	if err != nil {
-		return nil, errToStatus(err)
+		return nil, err
 	}
 
 	switch v := req.GetTypeOneof().(type) {
@@ -116,9 +122,9 @@ func (d *Device) GetDevice(ctx context.Context, req *api.GetDeviceRequest) (
 		return nil, errPerm(api.Role_VIEWER)
 	}
 
-	dev, err := d.devDAO.Read(ctx, req.GetId(), sess.OrgID)
+	dev, err := readScoped(ctx, d.devDAO, sess, "", req.GetId())
 	if err != nil {
-		return nil, errToStatus(err)
+		return nil, err
 	}
 
 	return dev, nil
@@ -149,9 +155,10 @@ func (d *Device) UpdateDevice(
 				"invalid field mask")
 		}
 
-		dev, err := d.devDAO.Read(ctx, req.GetDevice().GetId(), sess.OrgID)
+		dev, err := readScoped(ctx, d.devDAO, sess, "",
+			req.GetDevice().GetId())
 		if err != nil {
-			return nil, errToStatus(err)
+			return nil, err
 		}
 
 		fmutils.Filter(req.GetDevice(), req.GetUpdateMask().GetPaths())
@@ -160,6 +167,12 @@ func (d *Device) UpdateDevice(
 		}
 		proto.Merge(dev, req.GetDevice())
 		req.Device = dev
+	} else if sess.TagScoped() {
+		// Verify the existing device is in scope for full updates.
+		if _, err := readScoped(ctx, d.devDAO, sess, "",
+			req.GetDevice().GetId()); err != nil {
+			return nil, err
+		}
 	}
 
 	// Validate after merge to support partial updates.
@@ -167,6 +180,11 @@ func (d *Device) UpdateDevice(
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	// Do not allow tag scoped sessions to move a device out of scope.
+	if !sess.InTagScope(req.GetDevice().GetTags()) {
+		return nil, errScope()
+	}
+
 	dev, err := d.devDAO.Update(ctx, req.GetDevice())
 	if err != nil {
 		return nil, errToStatus(err)
@@ -185,9 +203,9 @@ func (d *Device) DeleteDeviceLoRaWAN(
 		return nil, errPerm(api.Role_BUILDER)
 	}
 
-	dev, err := d.devDAO.Read(ctx, req.GetId(), sess.OrgID)
+	dev, err := readScoped(ctx, d.devDAO, sess, "", req.GetId())
 	if err != nil {
-		return nil, errToStatus(err)
+		return nil, err
 	}
 
 	// Delete any gateways and devices present. 'Unauthenticated' is currently
@@ -225,6 +243,13 @@ func (d *Device) DeleteDevice(
 		return nil, errPerm(api.Role_BUILDER)
 	}
 
+	if sess.TagScoped() {
+		if _, err := readScoped(ctx, d.devDAO, sess, "",
+			req.GetId()); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := d.devDAO.Delete(ctx, req.GetId(), sess.OrgID); err != nil {
 		return nil, errToStatus(err)
 	}
@@ -238,7 +263,8 @@ func (d *Device) DeleteDevice(
 	return &emptypb.Empty{}, nil
 }
 
-// ListDevices retrieves all devices.
+// ListDevices retrieves all devices. Tag scoped sessions only retrieve devices
+// with tags in scope.
 func (d *Device) ListDevices(
 	ctx context.Context, req *api.ListDevicesRequest,
 ) (*api.ListDevicesResponse, error) {
@@ -258,7 +284,7 @@ func (d *Device) ListDevices(
 
 	// Retrieve PageSize+1 entries to find last page.
 	devs, count, err := d.devDAO.List(ctx, sess.OrgID, lBoundTS, prevID,
-		req.GetPageSize()+1, req.GetTag())
+		req.GetPageSize()+1, req.GetTag(), sess.ScopeTags)
 	if err != nil {
 		return nil, errToStatus(err)
 	}
@@ -76,6 +76,28 @@ func TestCreateDevice(t *testing.T) {
 		require.Equal(t, errPerm(api.Role_BUILDER), err)
 	})
 
+	t.Run("Create device out of scope", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-device", uuid.NewV7().String())
+		dev.Tags = []string{"site2"}
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: dev.GetOrgId(), Role: api.Role_ADMIN,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		devSvc := NewDevice(nil, nil)
+		createDev, err := devSvc.CreateDevice(ctx, &api.CreateDeviceRequest{
+			Device: dev,
+		})
+		t.Logf("createDev, err: %+v, %v", createDev, err)
+		require.Nil(t, createDev)
+		require.Equal(t, errScope(), err)
+	})
+
 	t.Run("Create invalid device", func(t *testing.T) {
 		t.Parallel()
 
@@ -305,6 +327,32 @@ func TestGetDevice(t *testing.T) {
 		require.Equal(t, errPerm(api.Role_VIEWER), err)
 	})
 
+	t.Run("Get device out of scope", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-device", uuid.NewV7().String())
+		dev.Tags = []string{"site2"}
+
+		devicer := NewMockDevicer(gomock.NewController(t))
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: dev.GetOrgId(), Role: api.Role_ADMIN,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		devSvc := NewDevice(devicer, nil)
+		getDev, err := devSvc.GetDevice(ctx,
+			&api.GetDeviceRequest{Id: dev.GetId()})
+		t.Logf("getDev, err: %+v, %v", getDev, err)
+		require.Nil(t, getDev)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+
 	t.Run("Get device by unknown ID", func(t *testing.T) {
 		t.Parallel()
 
@@ -486,6 +534,34 @@ func TestUpdateDevice(t *testing.T) {
 			err)
 	})
 
+	t.Run("Update device out of scope", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-device", uuid.NewV7().String())
+		dev.Tags = []string{"site/b1"}
+		retDev, _ := proto.Clone(dev).(*api.Device)
+		dev.Tags = []string{"site2"}
+
+		devicer := NewMockDevicer(gomock.NewController(t))
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(retDev, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: dev.GetOrgId(), Role: api.Role_ADMIN,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		devSvc := NewDevice(devicer, nil)
+		updateDev, err := devSvc.UpdateDevice(ctx, &api.UpdateDeviceRequest{
+			Device: dev,
+		})
+		t.Logf("updateDev, err: %+v, %v", updateDev, err)
+		require.Nil(t, updateDev)
+		require.Equal(t, errScope(), err)
+	})
+
 	t.Run("Update device validation failure", func(t *testing.T) {
 		t.Parallel()
 
@@ -745,6 +821,32 @@ func TestDeleteDevice(t *testing.T) {
 		require.Equal(t, errPerm(api.Role_BUILDER), err)
 	})
 
+	t.Run("Delete device out of scope", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-device", uuid.NewV7().String())
+		dev.Tags = []string{"site2"}
+
+		devicer := NewMockDevicer(gomock.NewController(t))
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: dev.GetOrgId(), Role: api.Role_ADMIN,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		devSvc := NewDevice(devicer, nil)
+		_, err := devSvc.DeleteDevice(ctx, &api.DeleteDeviceRequest{
+			Id: dev.GetId(),
+		})
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+
 	t.Run("Delete device by unknown ID", func(t *testing.T) {
 		t.Parallel()
 
@@ -783,7 +885,7 @@ func TestListDevices(t *testing.T) {
 
 		devicer := NewMockDevicer(gomock.NewController(t))
 		devicer.EXPECT().List(gomock.Any(), orgID, time.Time{}, "", int32(51),
-			"").Return(devs, int32(3), nil).Times(1)
+			"", gomock.Nil()).Return(devs, int32(3), nil).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}),
@@ -799,6 +901,32 @@ func TestListDevices(t *testing.T) {
 			&api.ListDevicesResponse{Devices: devs, TotalSize: 3}, listDevs)
 	})
 
+	t.Run("List devices with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		scopeTags := []string{"site"}
+
+		devs := []*api.Device{random.Device("api-device", orgID)}
+
+		devicer := NewMockDevicer(gomock.NewController(t))
+		devicer.EXPECT().List(gomock.Any(), orgID, time.Time{}, "", int32(51),
+			"", scopeTags).Return(devs, int32(1), nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: orgID, Role: api.Role_ADMIN, ScopeTags: scopeTags,
+			}), testTimeout)
+		defer cancel()
+
+		devSvc := NewDevice(devicer, nil)
+		listDevs, err := devSvc.ListDevices(ctx, &api.ListDevicesRequest{})
+		t.Logf("listDevs, err: %+v, %v", listDevs, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t,
+			&api.ListDevicesResponse{Devices: devs, TotalSize: 1}, listDevs)
+	})
+
 	t.Run("List devices by valid org ID with next page", func(t *testing.T) {
 		t.Parallel()
 
@@ -816,7 +944,7 @@ func TestListDevices(t *testing.T) {
 
 		devicer := NewMockDevicer(gomock.NewController(t))
 		devicer.EXPECT().List(gomock.Any(), orgID, time.Time{}, "", int32(3),
-			"").Return(devs, int32(3), nil).Times(1)
+			"", gomock.Nil()).Return(devs, int32(3), nil).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
@@ -885,7 +1013,7 @@ func TestListDevices(t *testing.T) {
 
 		devicer := NewMockDevicer(gomock.NewController(t))
 		devicer.EXPECT().List(gomock.Any(), "aaa", gomock.Any(), gomock.Any(),
-			gomock.Any(), gomock.Any()).Return(nil, int32(0),
+			gomock.Any(), gomock.Any(), gomock.Nil()).Return(nil, int32(0),
 			dao.ErrInvalidFormat).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
@@ -914,7 +1042,7 @@ func TestListDevices(t *testing.T) {
 
 		devicer := NewMockDevicer(gomock.NewController(t))
 		devicer.EXPECT().List(gomock.Any(), orgID, time.Time{}, "", int32(3),
-			"").Return(devs, int32(3), nil).Times(1)
+			"", gomock.Nil()).Return(devs, int32(3), nil).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
@@ -17,8 +17,11 @@ import (
 
 // Constants used for endpoint behavior.
 const (
-	StatusCodeKey   = "atlas-status-code"
-	defaultPageSize = 50
+	StatusCodeKey      = "atlas-status-code"
+	AggregateFuncKey   = "atlas-aggregate-func"
+	AggregateBucketKey = "atlas-aggregate-bucket"
+	ClientIPKey        = "atlas-client-ip"
+	defaultPageSize    = 50
 )
 
 // errToCode maps package errors to gRPC error codes.
@@ -55,3 +58,10 @@ func errPerm(role api.Role) error {
 	return status.Error(codes.PermissionDenied,
 		fmt.Sprintf("permission denied, %s role required", role.String()))
 }
+
+// errScope returns a PermissionDenied status due to tags outside of a tag
+// scoped session's scope.
+func errScope() error {
+	return status.Error(codes.PermissionDenied,
+		"permission denied, tags not in scope")
+}
@@ -15,8 +15,9 @@ import (
 // Eventer defines the methods provided by a event.DAO.
 type Eventer interface {
 	List(ctx context.Context, orgID, uniqID, devID, ruleID string, end,
-		start time.Time) ([]*api.Event, error)
-	Latest(ctx context.Context, orgID, ruleID string) ([]*api.Event, error)
+		start time.Time, scopeTags []string) ([]*api.Event, error)
+	Latest(ctx context.Context, orgID, ruleID string, scopeTags []string) (
+		[]*api.Event, error)
 }
 
 // Event service contains functions to query events.
@@ -34,7 +35,8 @@ func NewEvent(evDAO Eventer) *Event {
 }
 
 // ListEvents retrieves all events for a device in a [end, start) time range,
-// in descending timestamp order.
+// in descending timestamp order. Tag scoped sessions only retrieve events for
+// devices with tags in scope.
 func (e *Event) ListEvents(ctx context.Context, req *api.ListEventsRequest) (
 	*api.ListEventsResponse, error,
 ) {
@@ -69,7 +71,7 @@ func (e *Event) ListEvents(ctx context.Context, req *api.ListEventsRequest) (
 	}
 
 	events, err := e.evDAO.List(ctx, sess.OrgID, uniqID, devID, req.GetRuleId(), end,
-		start)
+		start, sess.ScopeTags)
 	if err != nil {
 		return nil, errToStatus(err)
 	}
@@ -78,7 +80,8 @@ func (e *Event) ListEvents(ctx context.Context, req *api.ListEventsRequest) (
 }
 
 // LatestEvents retrieves the latest event for each of an organization's
-// devices.
+// devices. Tag scoped sessions only retrieve events for devices with tags in
+// scope.
 func (e *Event) LatestEvents(
 	ctx context.Context, req *api.LatestEventsRequest,
 ) (*api.LatestEventsResponse, error) {
@@ -87,7 +90,8 @@ func (e *Event) LatestEvents(
 		return nil, errPerm(api.Role_VIEWER)
 	}
 
-	events, err := e.evDAO.Latest(ctx, sess.OrgID, req.GetRuleId())
+	events, err := e.evDAO.Latest(ctx, sess.OrgID, req.GetRuleId(),
+		sess.ScopeTags)
 	if err != nil {
 		return nil, errToStatus(err)
 	}
@@ -34,7 +34,8 @@ func TestListEvents(t *testing.T) {
 
 		eventer := NewMockEventer(gomock.NewController(t))
 		eventer.EXPECT().List(gomock.Any(), event.GetOrgId(), event.GetUniqId(),
-			"", "", end, start).Return([]*api.Event{retEvent}, nil).Times(1)
+			"", "", end, start, gomock.Nil()).
+			Return([]*api.Event{retEvent}, nil).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
 			&session.Session{OrgID: event.GetOrgId(), Role: api.Role_ADMIN}),
@@ -62,7 +63,8 @@ func TestListEvents(t *testing.T) {
 		eventer := NewMockEventer(gomock.NewController(t))
 		eventer.EXPECT().List(gomock.Any(), event.GetOrgId(), "", devID,
 			event.GetRuleId(), matcher.NewRecentMatcher(2*time.Second),
-			matcher.NewRecentMatcher(24*time.Hour+2*time.Second)).
+			matcher.NewRecentMatcher(24*time.Hour+2*time.Second),
+			gomock.Nil()).
 			Return([]*api.Event{retEvent}, nil).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
@@ -134,7 +136,7 @@ func TestListEvents(t *testing.T) {
 
 		eventer := NewMockEventer(gomock.NewController(t))
 		eventer.EXPECT().List(gomock.Any(), "aaa", gomock.Any(), gomock.Any(),
-			gomock.Any(), gomock.Any(), gomock.Any()).Return(nil,
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Nil()).Return(nil,
 			dao.ErrInvalidFormat).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
@@ -161,8 +163,8 @@ func TestLatestEvents(t *testing.T) {
 		orgID := uuid.NewV7().String()
 
 		eventer := NewMockEventer(gomock.NewController(t))
-		eventer.EXPECT().Latest(gomock.Any(), orgID, event.GetRuleId()).
-			Return([]*api.Event{retEvent}, nil).Times(1)
+		eventer.EXPECT().Latest(gomock.Any(), orgID, event.GetRuleId(),
+			gomock.Nil()).Return([]*api.Event{retEvent}, nil).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
@@ -178,6 +180,29 @@ func TestLatestEvents(t *testing.T) {
 			&api.LatestEventsResponse{Events: []*api.Event{event}}, latEvents)
 	})
 
+	t.Run("Latest events with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		scopeTags := []string{"site"}
+
+		eventer := NewMockEventer(gomock.NewController(t))
+		eventer.EXPECT().Latest(gomock.Any(), orgID, "", scopeTags).
+			Return(nil, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: orgID, Role: api.Role_ADMIN, ScopeTags: scopeTags,
+			}), testTimeout)
+		defer cancel()
+
+		evSvc := NewEvent(eventer)
+		latEvents, err := evSvc.LatestEvents(ctx, &api.LatestEventsRequest{})
+		t.Logf("latEvents, err: %+v, %v", latEvents, err)
+		require.NoError(t, err)
+		require.Empty(t, latEvents.GetEvents())
+	})
+
 	t.Run("Latest events with invalid session", func(t *testing.T) {
 		t.Parallel()
 
@@ -210,8 +235,8 @@ func TestLatestEvents(t *testing.T) {
 		t.Parallel()
 
 		eventer := NewMockEventer(gomock.NewController(t))
-		eventer.EXPECT().Latest(gomock.Any(), "aaa", gomock.Any()).Return(nil,
-			dao.ErrInvalidFormat).Times(1)
+		eventer.EXPECT().Latest(gomock.Any(), "aaa", gomock.Any(),
+			gomock.Nil()).Return(nil, dao.ErrInvalidFormat).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
 			&session.Session{OrgID: "aaa", Role: api.Role_ADMIN}), testTimeout)
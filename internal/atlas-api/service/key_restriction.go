@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Constants used for key restriction bounds. Uniq ID and tag lengths match the
+// database schema.
+const (
+	maxResScopes  = 50
+	maxResDevices = 50
+	maxResUniqID  = 40
+	maxResTag     = 255
+	maxScopeTags  = 50
+)
+
+// Scopes are a service, such as 'thingspect.api.DeviceService', or a full
+// method, such as '/thingspect.api.DeviceService/GetDevice'.
+var reScope = regexp.MustCompile(
+	`^(thingspect\.api\.[A-Za-z]+Service|/thingspect\.api\.[A-Za-z]+Service/[A-Za-z]+)$`)
+
+// CreateRestrictedKey creates an API key with an expiration, scopes, device
+// restrictions, or scope tags. Tag scoped sessions may only create keys with
+// scope tags that are within their own scope.
+func (s *Session) CreateRestrictedKey(
+	ctx context.Context, req *message.CreateRestrictedKeyRequest,
+) (*message.CreateRestrictedKeyResponse, error) {
+	logger := alog.FromContext(ctx)
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	if err := req.GetKey().Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Only system admins can create keys with system admin role.
+	if sess.Role < api.Role_SYS_ADMIN &&
+		req.GetKey().GetRole() == api.Role_SYS_ADMIN {
+		return nil, status.Error(codes.PermissionDenied,
+			"permission denied, role modification not allowed")
+	}
+
+	res := req.GetRestriction()
+	if res == nil {
+		res = &message.KeyRestriction{}
+	}
+	if err := validateRestriction(req.GetKey().GetRole(), res); err != nil {
+		return nil, err
+	}
+
+	if sess.TagScoped() {
+		if len(res.GetScopeTags()) == 0 {
+			return nil, errScope()
+		}
+
+		for _, tag := range res.GetScopeTags() {
+			if !sess.TagInScope(tag) {
+				return nil, errScope()
+			}
+		}
+	}
+
+	req.Key.OrgId = sess.OrgID
+
+	key, res, err := s.keyDAO.CreateRestricted(ctx, req.GetKey(), res)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	token, err := session.GenerateRestrictedKeyToken(s.pwtKey, key.GetRole(),
+		res)
+	if err != nil {
+		logger.Errorf("CreateRestrictedKey "+
+			"session.GenerateRestrictedKeyToken: %v", err)
+
+		return nil, errToStatus(err)
+	}
+
+	return &message.CreateRestrictedKeyResponse{
+		Key: key, Restriction: res, Token: token,
+	}, nil
+}
+
+// validateRestriction validates a key restriction for a role, and normalizes
+// its uniq IDs.
+func validateRestriction(role api.Role, res *message.KeyRestriction) error {
+	if res.GetExpiresAt() != nil &&
+		!res.GetExpiresAt().AsTime().After(time.Now()) {
+		return status.Error(codes.InvalidArgument,
+			"expires_at must be in the future")
+	}
+
+	if len(res.GetScopes()) > maxResScopes {
+		return status.Error(codes.InvalidArgument,
+			fmt.Sprintf("scopes must contain at most %d scopes", maxResScopes))
+	}
+
+	for _, scope := range res.GetScopes() {
+		if !reScope.MatchString(scope) {
+			return status.Error(codes.InvalidArgument,
+				"scopes must be a service or full method")
+		}
+	}
+
+	if err := validateScopeTags(res.GetScopeTags()); err != nil {
+		return err
+	}
+
+	if len(res.GetUniqIds()) == 0 && len(res.GetTags()) == 0 {
+		return nil
+	}
+
+	if role != api.Role_PUBLISHER {
+		return status.Error(codes.InvalidArgument,
+			"uniq_ids and tags are only supported for the PUBLISHER role")
+	}
+
+	if len(res.GetUniqIds())+len(res.GetTags()) > maxResDevices {
+		return status.Error(codes.InvalidArgument,
+			fmt.Sprintf("uniq_ids and tags must contain at most %d entries",
+				maxResDevices))
+	}
+
+	for i, uniqID := range res.GetUniqIds() {
+		if uniqID == "" || len(uniqID) > maxResUniqID {
+			return status.Error(codes.InvalidArgument,
+				fmt.Sprintf("uniq_ids must be between 1 and %d characters",
+					maxResUniqID))
+		}
+
+		res.UniqIds[i] = strings.ToLower(uniqID)
+	}
+
+	for _, tag := range res.GetTags() {
+		if tag == "" || len(tag) > maxResTag {
+			return status.Error(codes.InvalidArgument,
+				fmt.Sprintf("tags must be between 1 and %d characters",
+					maxResTag))
+		}
+	}
+
+	return nil
+}
+
+// validateScopeTags validates scope tags for a key restriction or user scope.
+func validateScopeTags(tags []string) error {
+	if len(tags) > maxScopeTags {
+		return status.Error(codes.InvalidArgument,
+			fmt.Sprintf("scope_tags must contain at most %d tags",
+				maxScopeTags))
+	}
+
+	for _, tag := range tags {
+		if tag == "" || len(tag) > maxResTag {
+			return status.Error(codes.InvalidArgument,
+				fmt.Sprintf("scope_tags must be between 1 and %d characters",
+					maxResTag))
+		}
+	}
+
+	return nil
+}
+
+// GetKeyRestriction retrieves an API key's restriction by key ID.
+func (s *Session) GetKeyRestriction(ctx context.Context, keyID string) (
+	*message.KeyRestriction, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	res, err := s.keyDAO.ReadRestriction(ctx, keyID, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return res, nil
+}
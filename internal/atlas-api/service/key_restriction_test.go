@@ -0,0 +1,358 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCreateRestrictedKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Create valid restricted key", func(t *testing.T) {
+		t.Parallel()
+
+		key := random.Key("api-key", uuid.NewV7().String())
+		key.Role = api.Role_PUBLISHER
+		retKey, _ := proto.Clone(key).(*api.Key)
+		retKey.Id = uuid.NewV7().String()
+
+		res := &message.KeyRestriction{
+			ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+			Scopes:    []string{"thingspect.api.DataPointService"},
+			UniqIds:   []string{"API-KEY-" + random.String(10)},
+			Tags:      []string{random.String(10)},
+			ScopeTags: []string{"site"},
+		}
+		retRes, _ := proto.Clone(res).(*message.KeyRestriction)
+		retRes.KeyId = retKey.GetId()
+		retRes.OrgId = key.GetOrgId()
+		retRes.UniqIds = []string{strings.ToLower(res.GetUniqIds()[0])}
+
+		keyer := NewMockKeyer(gomock.NewController(t))
+		keyer.EXPECT().CreateRestricted(gomock.Any(), key, res).
+			Return(retKey, retRes, nil).Times(1)
+
+		pwtKey := make([]byte, 32)
+		_, err := rand.Read(pwtKey)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: key.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		keySvc := NewSession(nil, keyer, nil, pwtKey)
+		createKey, err := keySvc.CreateRestrictedKey(ctx,
+			&message.CreateRestrictedKeyRequest{Key: key, Restriction: res})
+		t.Logf("key, createKey, err: %+v, %+v, %v", key, createKey, err)
+		require.NoError(t, err)
+		require.Equal(t, retKey, createKey.GetKey())
+		require.Equal(t, retRes, createKey.GetRestriction())
+
+		// Uniq IDs are normalized before creation.
+		require.Equal(t, retRes.GetUniqIds(), res.GetUniqIds())
+
+		sess, err := session.ValidateWebToken(pwtKey, createKey.GetToken())
+		t.Logf("sess, err: %+v, %v", sess, err)
+		require.NoError(t, err)
+		require.Equal(t, retKey.GetId(), sess.KeyID)
+		require.Equal(t, res.GetScopes(), sess.Scopes)
+		require.Equal(t, retRes.GetUniqIds(), sess.UniqIDs)
+		require.Equal(t, res.GetTags(), sess.Tags)
+		require.Equal(t, res.GetScopeTags(), sess.ScopeTags)
+	})
+
+	t.Run("Create restricted key with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		keySvc := NewSession(nil, nil, nil, nil)
+		createKey, err := keySvc.CreateRestrictedKey(ctx,
+			&message.CreateRestrictedKeyRequest{})
+		t.Logf("createKey, err: %+v, %v", createKey, err)
+		require.Nil(t, createKey)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Create sysadmin restricted key as non-sysadmin", func(t *testing.T) {
+		t.Parallel()
+
+		key := random.Key("api-key", uuid.NewV7().String())
+		key.Role = api.Role_SYS_ADMIN
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		keySvc := NewSession(nil, nil, nil, nil)
+		createKey, err := keySvc.CreateRestrictedKey(ctx,
+			&message.CreateRestrictedKeyRequest{Key: key})
+		t.Logf("key, createKey, err: %+v, %+v, %v", key, createKey, err)
+		require.Nil(t, createKey)
+		require.Equal(t, status.Error(codes.PermissionDenied,
+			"permission denied, role modification not allowed"), err)
+	})
+
+	t.Run("Create restricted key with invalid key", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		keySvc := NewSession(nil, nil, nil, nil)
+		createKey, err := keySvc.CreateRestrictedKey(ctx,
+			&message.CreateRestrictedKeyRequest{Key: &api.Key{}})
+		t.Logf("createKey, err: %+v, %v", createKey, err)
+		require.Nil(t, createKey)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Create restricted key with invalid restriction", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			inpRole api.Role
+			inpRes  *message.KeyRestriction
+			err     string
+		}{
+			{
+				api.Role_PUBLISHER, &message.KeyRestriction{
+					ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute)),
+				}, "expires_at must be in the future",
+			},
+			{
+				api.Role_PUBLISHER, &message.KeyRestriction{
+					Scopes: []string{"thingspect.api.DataPointService/"},
+				}, "scopes must be a service or full method",
+			},
+			{
+				api.Role_PUBLISHER, &message.KeyRestriction{
+					Scopes: make([]string, maxResScopes+1),
+				}, "scopes must contain at most 50 scopes",
+			},
+			{
+				api.Role_ADMIN, &message.KeyRestriction{
+					Tags: []string{random.String(10)},
+				}, "uniq_ids and tags are only supported for the PUBLISHER role",
+			},
+			{
+				api.Role_PUBLISHER, &message.KeyRestriction{
+					Tags: make([]string, maxResDevices+1),
+				}, "uniq_ids and tags must contain at most 50 entries",
+			},
+			{
+				api.Role_PUBLISHER, &message.KeyRestriction{
+					UniqIds: []string{random.String(41)},
+				}, "uniq_ids must be between 1 and 40 characters",
+			},
+			{
+				api.Role_PUBLISHER, &message.KeyRestriction{
+					Tags: []string{""},
+				}, "tags must be between 1 and 255 characters",
+			},
+			{
+				api.Role_VIEWER, &message.KeyRestriction{
+					ScopeTags: make([]string, maxScopeTags+1),
+				}, "scope_tags must contain at most 50 tags",
+			},
+			{
+				api.Role_VIEWER, &message.KeyRestriction{
+					ScopeTags: []string{random.String(256)},
+				}, "scope_tags must be between 1 and 255 characters",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("Can validate %+v", test), func(t *testing.T) {
+				t.Parallel()
+
+				key := random.Key("api-key", uuid.NewV7().String())
+				key.Role = test.inpRole
+
+				ctx, cancel := context.WithTimeout(session.NewContext(
+					t.Context(), &session.Session{
+						OrgID: key.GetOrgId(), Role: api.Role_ADMIN,
+					}), testTimeout)
+				defer cancel()
+
+				keySvc := NewSession(nil, nil, nil, nil)
+				createKey, err := keySvc.CreateRestrictedKey(ctx,
+					&message.CreateRestrictedKeyRequest{
+						Key: key, Restriction: test.inpRes,
+					})
+				t.Logf("createKey, err: %+v, %v", createKey, err)
+				require.Nil(t, createKey)
+				require.Equal(t, status.Error(codes.InvalidArgument, test.err),
+					err)
+			})
+		}
+	})
+
+	t.Run("Create restricted key with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			inpScopeTags []string
+			err          error
+		}{
+			{[]string{"site/b1"}, nil},
+			{[]string{"site", "site/b1"}, nil},
+			{nil, errScope()},
+			{[]string{"site2"}, errScope()},
+			{[]string{"site/b1", "site2"}, errScope()},
+		}
+
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("Can create %+v", test), func(t *testing.T) {
+				t.Parallel()
+
+				key := random.Key("api-key", uuid.NewV7().String())
+				key.Role = api.Role_VIEWER
+				retKey, _ := proto.Clone(key).(*api.Key)
+				retKey.Id = uuid.NewV7().String()
+				res := &message.KeyRestriction{ScopeTags: test.inpScopeTags}
+				retRes, _ := proto.Clone(res).(*message.KeyRestriction)
+				retRes.KeyId = retKey.GetId()
+				retRes.OrgId = key.GetOrgId()
+
+				keyer := NewMockKeyer(gomock.NewController(t))
+				if test.err == nil {
+					keyer.EXPECT().CreateRestricted(gomock.Any(), key, res).
+						Return(retKey, retRes, nil).Times(1)
+				}
+
+				pwtKey := make([]byte, 32)
+				_, err := rand.Read(pwtKey)
+				require.NoError(t, err)
+
+				ctx, cancel := context.WithTimeout(session.NewContext(
+					t.Context(), &session.Session{
+						OrgID: key.GetOrgId(), Role: api.Role_ADMIN,
+						ScopeTags: []string{"site"},
+					}), testTimeout)
+				defer cancel()
+
+				keySvc := NewSession(nil, keyer, nil, pwtKey)
+				createKey, err := keySvc.CreateRestrictedKey(ctx,
+					&message.CreateRestrictedKeyRequest{
+						Key: key, Restriction: res,
+					})
+				t.Logf("createKey, err: %+v, %v", createKey, err)
+				require.Equal(t, test.err, err)
+			})
+		}
+	})
+
+	t.Run("Create restricted key with invalid org ID", func(t *testing.T) {
+		t.Parallel()
+
+		key := random.Key("api-key", uuid.NewV7().String())
+		key.Role = api.Role_PUBLISHER
+
+		keyer := NewMockKeyer(gomock.NewController(t))
+		keyer.EXPECT().CreateRestricted(gomock.Any(), key, gomock.Any()).
+			Return(nil, nil, dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: key.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		keySvc := NewSession(nil, keyer, nil, nil)
+		createKey, err := keySvc.CreateRestrictedKey(ctx,
+			&message.CreateRestrictedKeyRequest{Key: key})
+		t.Logf("key, createKey, err: %+v, %+v, %v", key, createKey, err)
+		require.Nil(t, createKey)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"dao: invalid format"), err)
+	})
+}
+
+func TestGetKeyRestriction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get restriction by valid key ID", func(t *testing.T) {
+		t.Parallel()
+
+		res := &message.KeyRestriction{
+			KeyId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+			Scopes: []string{"thingspect.api.DeviceService"},
+		}
+
+		keyer := NewMockKeyer(gomock.NewController(t))
+		keyer.EXPECT().ReadRestriction(gomock.Any(), res.GetKeyId(),
+			res.GetOrgId()).Return(res, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: res.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		keySvc := NewSession(nil, keyer, nil, nil)
+		getRes, err := keySvc.GetKeyRestriction(ctx, res.GetKeyId())
+		t.Logf("getRes, err: %+v, %v", getRes, err)
+		require.NoError(t, err)
+		require.Equal(t, res, getRes)
+	})
+
+	t.Run("Get restriction with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		keySvc := NewSession(nil, nil, nil, nil)
+		getRes, err := keySvc.GetKeyRestriction(ctx, uuid.NewV7().String())
+		t.Logf("getRes, err: %+v, %v", getRes, err)
+		require.Nil(t, getRes)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Get restriction by unknown key ID", func(t *testing.T) {
+		t.Parallel()
+
+		keyer := NewMockKeyer(gomock.NewController(t))
+		keyer.EXPECT().ReadRestriction(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		keySvc := NewSession(nil, keyer, nil, nil)
+		getRes, err := keySvc.GetKeyRestriction(ctx, uuid.NewV7().String())
+		t.Logf("getRes, err: %+v, %v", getRes, err)
+		require.Nil(t, getRes)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/metric"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Constants used for login throttling. Failed logins are counted per email
+// and org name, and per source IP, within loginFailWindow. Failures past
+// loginDelayAfter are delayed, and logins are locked out at loginLockout or
+// loginIPLockout failures until the window expires.
+const (
+	loginFailWindow = 15 * time.Minute
+	loginDelayAfter = 3
+	loginBaseDelay  = 250 * time.Millisecond
+	loginMaxDelay   = 5 * time.Second
+	loginLockout    = 10
+	loginIPLockout  = 100
+)
+
+// loginDelay returns the delay applied to a failed login, which doubles with
+// each failure past loginDelayAfter, up to loginMaxDelay.
+func loginDelay(failures int64) time.Duration {
+	if failures <= loginDelayAfter {
+		return 0
+	}
+
+	shift := min(failures-loginDelayAfter-1, 8)
+	delay := loginBaseDelay << shift
+
+	return min(delay, loginMaxDelay)
+}
+
+// clientIP returns the source IP of a request. Requests from a loopback peer,
+// such as the gRPC gateway, use the client IP resolved by the gateway, if
+// present. Client-supplied forwarding headers are not trusted. An empty string
+// is returned if the source is unknown.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if gwIP := md.Get(ClientIPKey); len(gwIP) > 0 && gwIP[0] != "" {
+				return gwIP[0]
+			}
+		}
+	}
+
+	return host
+}
+
+// loginFailures retrieves a count of failed logins by cache key. Cache
+// failures are logged and treated as no failures, so that logins remain
+// available.
+func loginFailures(
+	ctx context.Context, c cache.Cacher[string], failKey string,
+) int64 {
+	val, err := c.Get(ctx, failKey)
+	if errors.Is(err, cache.ErrNotFound) {
+		return 0
+	}
+	if err != nil {
+		logger := alog.FromContext(ctx)
+		logger.Errorf("loginFailures c.Get: %v", err)
+
+		return 0
+	}
+
+	failures, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		logger := alog.FromContext(ctx)
+		logger.Errorf("loginFailures strconv.ParseInt: %v", err)
+
+		return 0
+	}
+
+	return failures
+}
+
+// lockedOut returns whether logins by email and org name, or from a source
+// IP, are locked out, along with the count of failures by email and org name.
+func (s *Session) lockedOut(ctx context.Context, orgName, email, ip string) (
+	bool, int64,
+) {
+	if ip != "" && loginFailures(ctx, s.cache,
+		key.LoginIPFailures(ip)) >= loginIPLockout {
+		return true, 0
+	}
+
+	failures := loginFailures(ctx, s.cache, key.LoginFailures(orgName, email))
+
+	return failures >= loginLockout, failures
+}
+
+// incrFailures increments a count of failed logins by cache key, and returns
+// the new count. The first failure in a window atomically sets the window's
+// expiration, and an increment preserves it.
+func incrFailures(
+	ctx context.Context, c cache.Cacher[string], failKey string,
+) int64 {
+	failures, err := c.IncrTTL(ctx, failKey, loginFailWindow)
+	if err != nil {
+		logger := alog.FromContext(ctx)
+		logger.Errorf("incrFailures c.IncrTTL: %v", err)
+
+		return 0
+	}
+
+	return failures
+}
+
+// failLogin records a failed login by email and org name, and by source IP.
+// Lockouts are logged and counted as they occur. The failure is delayed
+// according to its count.
+func (s *Session) failLogin(ctx context.Context, orgName, email, ip string) {
+	logger := alog.FromContext(ctx)
+
+	failures := incrFailures(ctx, s.cache, key.LoginFailures(orgName, email))
+	if failures == loginLockout {
+		metric.Incr("lockout", map[string]string{"type": "account"})
+		logger.Infof("failLogin locked out OrgName, Email: %v, %v", orgName,
+			email)
+	}
+
+	if ip != "" {
+		ipFailures := incrFailures(ctx, s.cache, key.LoginIPFailures(ip))
+		if ipFailures == loginIPLockout {
+			metric.Incr("lockout", map[string]string{"type": "ip"})
+			logger.Infof("failLogin locked out IP: %v", ip)
+		}
+
+		failures = max(failures, ipFailures)
+	}
+
+	select {
+	case <-time.After(loginDelay(failures)):
+	case <-ctx.Done():
+	}
+}
+
+// clearFailures clears the count of failed logins by email and org name, if
+// any preceded a completed login.
+func (s *Session) clearFailures(
+	ctx context.Context, orgName, email string, failures int64,
+) {
+	if failures == 0 {
+		return
+	}
+
+	if err := s.cache.Del(ctx, key.LoginFailures(orgName, email)); err != nil {
+		logger := alog.FromContext(ctx)
+		logger.Errorf("clearFailures s.cache.Del: %v", err)
+	}
+}
@@ -0,0 +1,286 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginDelay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inp int64
+		res time.Duration
+	}{
+		{0, 0},
+		{loginDelayAfter, 0},
+		{loginDelayAfter + 1, loginBaseDelay},
+		{loginDelayAfter + 2, 2 * loginBaseDelay},
+		{loginDelayAfter + 3, 4 * loginBaseDelay},
+		{loginLockout, loginMaxDelay},
+		{loginIPLockout, loginMaxDelay},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can delay %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.res, loginDelay(test.inp))
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	t.Parallel()
+
+	gwPeer := &peer.Peer{Addr: &net.TCPAddr{
+		IP: net.ParseIP("127.0.0.1"), Port: 50051,
+	}}
+	remotePeer := &peer.Peer{Addr: &net.TCPAddr{
+		IP: net.ParseIP("10.0.0.3"), Port: 50051,
+	}}
+
+	tests := []struct {
+		inp context.Context
+		res string
+	}{
+		{peer.NewContext(metadata.NewIncomingContext(t.Context(),
+			metadata.Pairs(ClientIPKey, "10.0.0.1")), gwPeer), "10.0.0.1"},
+		{peer.NewContext(t.Context(), gwPeer), "127.0.0.1"},
+		{peer.NewContext(t.Context(), remotePeer), "10.0.0.3"},
+		{peer.NewContext(metadata.NewIncomingContext(t.Context(),
+			metadata.Pairs(ClientIPKey, "10.0.0.1", "x-forwarded-for",
+				"10.0.0.2")), remotePeer), "10.0.0.3"},
+		{metadata.NewIncomingContext(t.Context(), metadata.Pairs(
+			"x-forwarded-for", "10.0.0.1, 10.0.0.2")), ""},
+		{t.Context(), ""},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can parse %+v", test.res), func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.res, clientIP(test.inp))
+		})
+	}
+}
+
+func TestLoginThrottle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Log in locked out user", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-throttle")
+		user := random.User("api-throttle", org.GetId())
+
+		ctrl := gomock.NewController(t)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			user.GetEmail())).Return(strconv.Itoa(loginLockout), nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(NewMockUserer(ctrl), nil, cacher, nil)
+		loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
+			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
+		})
+		t.Logf("loginResp, err: %+v, %v", loginResp, err)
+		require.Nil(t, loginResp)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Log in locked out IP", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-throttle")
+		user := random.User("api-throttle", org.GetId())
+		ip := "10.0.0.4"
+
+		ctrl := gomock.NewController(t)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginIPFailures(ip)).
+			Return(strconv.Itoa(loginIPLockout), nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(peer.NewContext(t.Context(),
+			&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip)}}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(NewMockUserer(ctrl), nil, cacher, nil)
+		loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
+			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
+		})
+		t.Logf("loginResp, err: %+v, %v", loginResp, err)
+		require.Nil(t, loginResp)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Log in to lockout with delay", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-throttle")
+		user := random.User("api-throttle", org.GetId())
+		user.Status = api.Status_ACTIVE
+		ip := "10.0.0.5"
+
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, globalHash, nil).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginIPFailures(ip)).
+			Return("", cache.ErrNotFound).Times(1)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			user.GetEmail())).Return(strconv.Itoa(loginDelayAfter), nil).
+			Times(1)
+		cacher.EXPECT().IncrTTL(gomock.Any(), key.LoginFailures(
+			org.GetName(), user.GetEmail()), loginFailWindow).
+			Return(int64(loginLockout), nil).Times(1)
+		cacher.EXPECT().IncrTTL(gomock.Any(), key.LoginIPFailures(ip),
+			loginFailWindow).Return(int64(1), nil).Times(1)
+
+		// Cancel early to avoid waiting for the full delay.
+		ctx, cancel := context.WithTimeout(peer.NewContext(t.Context(),
+			&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip)}}), time.Second)
+		defer cancel()
+
+		start := time.Now()
+		sessSvc := NewSession(userer, nil, cacher, nil)
+		loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
+			Email: user.GetEmail(), OrgName: org.GetName(),
+			Password: random.String(10),
+		})
+		t.Logf("loginResp, err: %+v, %v", loginResp, err)
+		require.Nil(t, loginResp)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+		require.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+
+	t.Run("Log in valid user resets failures", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-throttle")
+		user := random.User("api-throttle", org.GetId())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, globalHash, nil).Times(1)
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), org.GetId()).
+			Return(&message.UserMFA{}, nil, nil).Times(1)
+		userer.EXPECT().ReadScope(gomock.Any(), user.GetId(), org.GetId()).
+			Return(nil, dao.ErrNotFound).Times(1)
+		userer.EXPECT().CreateSession(gomock.Any(), user.GetId(), org.GetId(),
+			gomock.Any(), gomock.Any()).Return(&message.UserSession{
+			Id: uuid.NewV7().String(),
+		}, nil).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			user.GetEmail())).Return("2", nil).Times(1)
+		cacher.EXPECT().Del(gomock.Any(), key.LoginFailures(org.GetName(),
+			user.GetEmail())).Return(nil).Times(1)
+
+		pwtKey := make([]byte, 32)
+		_, err := rand.Read(pwtKey)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, cacher, pwtKey)
+		loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
+			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
+		})
+		t.Logf("loginResp, err: %+v, %v", loginResp, err)
+		require.NoError(t, err)
+		require.Greater(t, len(loginResp.GetToken()), 90)
+	})
+
+	t.Run("Log in with MFA preserves failures", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-throttle")
+		user := random.User("api-throttle", org.GetId())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, globalHash, nil).Times(1)
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), org.GetId()).
+			Return(&message.UserMFA{Enrolled: true}, nil, nil).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			user.GetEmail())).Return("2", nil).Times(1)
+		cacher.EXPECT().SetTTL(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, cacher, nil)
+		loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
+			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
+		})
+		t.Logf("loginResp, err: %+v, %v", loginResp, err)
+		require.Nil(t, loginResp)
+		require.Equal(t, "mfa required", status.Convert(err).Message())
+	})
+
+	t.Run("Log in with cache failure", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-throttle")
+		user := random.User("api-throttle", org.GetId())
+		user.Status = api.Status_ACTIVE
+
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, globalHash, nil).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			user.GetEmail())).Return("", errors.New("cache failure")).Times(1)
+		cacher.EXPECT().IncrTTL(gomock.Any(), key.LoginFailures(
+			org.GetName(), user.GetEmail()), loginFailWindow).
+			Return(int64(0), errors.New("cache failure")).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, cacher, nil)
+		loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
+			Email: user.GetEmail(), OrgName: org.GetName(),
+			Password: random.String(10),
+		})
+		t.Logf("loginResp, err: %+v, %v", loginResp, err)
+		require.Nil(t, loginResp)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+}
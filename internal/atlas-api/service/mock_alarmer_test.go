@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	message "github.com/thingspect/atlas/proto/go/message"
 	api "github.com/thingspect/proto/go/api"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -71,6 +72,62 @@ func (mr *MockAlarmerMockRecorder) Delete(ctx, alarmID, orgID, ruleID any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAlarmer)(nil).Delete), ctx, alarmID, orgID, ruleID)
 }
 
+// DeleteDigest mocks base method.
+func (m *MockAlarmer) DeleteDigest(ctx context.Context, alarmID, orgID, ruleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDigest", ctx, alarmID, orgID, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDigest indicates an expected call of DeleteDigest.
+func (mr *MockAlarmerMockRecorder) DeleteDigest(ctx, alarmID, orgID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDigest", reflect.TypeOf((*MockAlarmer)(nil).DeleteDigest), ctx, alarmID, orgID, ruleID)
+}
+
+// DeleteEscalation mocks base method.
+func (m *MockAlarmer) DeleteEscalation(ctx context.Context, alarmID, orgID, ruleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEscalation", ctx, alarmID, orgID, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEscalation indicates an expected call of DeleteEscalation.
+func (mr *MockAlarmerMockRecorder) DeleteEscalation(ctx, alarmID, orgID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEscalation", reflect.TypeOf((*MockAlarmer)(nil).DeleteEscalation), ctx, alarmID, orgID, ruleID)
+}
+
+// DeleteRecovery mocks base method.
+func (m *MockAlarmer) DeleteRecovery(ctx context.Context, alarmID, orgID, ruleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecovery", ctx, alarmID, orgID, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecovery indicates an expected call of DeleteRecovery.
+func (mr *MockAlarmerMockRecorder) DeleteRecovery(ctx, alarmID, orgID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecovery", reflect.TypeOf((*MockAlarmer)(nil).DeleteRecovery), ctx, alarmID, orgID, ruleID)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockAlarmer) DeleteWebhook(ctx context.Context, alarmID, orgID, ruleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, alarmID, orgID, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockAlarmerMockRecorder) DeleteWebhook(ctx, alarmID, orgID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockAlarmer)(nil).DeleteWebhook), ctx, alarmID, orgID, ruleID)
+}
+
 // List mocks base method.
 func (m *MockAlarmer) List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string, limit int32, ruleID string) ([]*api.Alarm, int32, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +159,66 @@ func (mr *MockAlarmerMockRecorder) Read(ctx, alarmID, orgID, ruleID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockAlarmer)(nil).Read), ctx, alarmID, orgID, ruleID)
 }
 
+// ReadDigest mocks base method.
+func (m *MockAlarmer) ReadDigest(ctx context.Context, alarmID, orgID, ruleID string) (*message.AlarmDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadDigest", ctx, alarmID, orgID, ruleID)
+	ret0, _ := ret[0].(*message.AlarmDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadDigest indicates an expected call of ReadDigest.
+func (mr *MockAlarmerMockRecorder) ReadDigest(ctx, alarmID, orgID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadDigest", reflect.TypeOf((*MockAlarmer)(nil).ReadDigest), ctx, alarmID, orgID, ruleID)
+}
+
+// ReadEscalation mocks base method.
+func (m *MockAlarmer) ReadEscalation(ctx context.Context, alarmID, orgID, ruleID string) (*message.AlarmEscalation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadEscalation", ctx, alarmID, orgID, ruleID)
+	ret0, _ := ret[0].(*message.AlarmEscalation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadEscalation indicates an expected call of ReadEscalation.
+func (mr *MockAlarmerMockRecorder) ReadEscalation(ctx, alarmID, orgID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEscalation", reflect.TypeOf((*MockAlarmer)(nil).ReadEscalation), ctx, alarmID, orgID, ruleID)
+}
+
+// ReadRecovery mocks base method.
+func (m *MockAlarmer) ReadRecovery(ctx context.Context, alarmID, orgID, ruleID string) (*message.AlarmRecovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadRecovery", ctx, alarmID, orgID, ruleID)
+	ret0, _ := ret[0].(*message.AlarmRecovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadRecovery indicates an expected call of ReadRecovery.
+func (mr *MockAlarmerMockRecorder) ReadRecovery(ctx, alarmID, orgID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRecovery", reflect.TypeOf((*MockAlarmer)(nil).ReadRecovery), ctx, alarmID, orgID, ruleID)
+}
+
+// ReadWebhook mocks base method.
+func (m *MockAlarmer) ReadWebhook(ctx context.Context, alarmID, orgID, ruleID string) (*message.AlarmWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadWebhook", ctx, alarmID, orgID, ruleID)
+	ret0, _ := ret[0].(*message.AlarmWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadWebhook indicates an expected call of ReadWebhook.
+func (mr *MockAlarmerMockRecorder) ReadWebhook(ctx, alarmID, orgID, ruleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadWebhook", reflect.TypeOf((*MockAlarmer)(nil).ReadWebhook), ctx, alarmID, orgID, ruleID)
+}
+
 // Update mocks base method.
 func (m *MockAlarmer) Update(ctx context.Context, alarm *api.Alarm) (*api.Alarm, error) {
 	m.ctrl.T.Helper()
@@ -116,3 +233,63 @@ func (mr *MockAlarmerMockRecorder) Update(ctx, alarm any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAlarmer)(nil).Update), ctx, alarm)
 }
+
+// UpsertDigest mocks base method.
+func (m *MockAlarmer) UpsertDigest(ctx context.Context, dig *message.AlarmDigest) (*message.AlarmDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertDigest", ctx, dig)
+	ret0, _ := ret[0].(*message.AlarmDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertDigest indicates an expected call of UpsertDigest.
+func (mr *MockAlarmerMockRecorder) UpsertDigest(ctx, dig any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertDigest", reflect.TypeOf((*MockAlarmer)(nil).UpsertDigest), ctx, dig)
+}
+
+// UpsertEscalation mocks base method.
+func (m *MockAlarmer) UpsertEscalation(ctx context.Context, esc *message.AlarmEscalation) (*message.AlarmEscalation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertEscalation", ctx, esc)
+	ret0, _ := ret[0].(*message.AlarmEscalation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertEscalation indicates an expected call of UpsertEscalation.
+func (mr *MockAlarmerMockRecorder) UpsertEscalation(ctx, esc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEscalation", reflect.TypeOf((*MockAlarmer)(nil).UpsertEscalation), ctx, esc)
+}
+
+// UpsertRecovery mocks base method.
+func (m *MockAlarmer) UpsertRecovery(ctx context.Context, rec *message.AlarmRecovery) (*message.AlarmRecovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRecovery", ctx, rec)
+	ret0, _ := ret[0].(*message.AlarmRecovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertRecovery indicates an expected call of UpsertRecovery.
+func (mr *MockAlarmerMockRecorder) UpsertRecovery(ctx, rec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRecovery", reflect.TypeOf((*MockAlarmer)(nil).UpsertRecovery), ctx, rec)
+}
+
+// UpsertWebhook mocks base method.
+func (m *MockAlarmer) UpsertWebhook(ctx context.Context, hook *message.AlarmWebhook, secret []byte) (*message.AlarmWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertWebhook", ctx, hook, secret)
+	ret0, _ := ret[0].(*message.AlarmWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertWebhook indicates an expected call of UpsertWebhook.
+func (mr *MockAlarmerMockRecorder) UpsertWebhook(ctx, hook, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertWebhook", reflect.TypeOf((*MockAlarmer)(nil).UpsertWebhook), ctx, hook, secret)
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	message "github.com/thingspect/atlas/proto/go/message"
 	api "github.com/thingspect/proto/go/api"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,17 +43,107 @@ func (m *MockAlerter) EXPECT() *MockAlerterMockRecorder {
 	return m.recorder
 }
 
+// Acknowledge mocks base method.
+func (m *MockAlerter) Acknowledge(ctx context.Context, lcID, orgID, userID, note string) (*message.AlertLifecycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acknowledge", ctx, lcID, orgID, userID, note)
+	ret0, _ := ret[0].(*message.AlertLifecycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acknowledge indicates an expected call of Acknowledge.
+func (mr *MockAlerterMockRecorder) Acknowledge(ctx, lcID, orgID, userID, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acknowledge", reflect.TypeOf((*MockAlerter)(nil).Acknowledge), ctx, lcID, orgID, userID, note)
+}
+
+// Assign mocks base method.
+func (m *MockAlerter) Assign(ctx context.Context, lcID, orgID, assigneeID string) (*message.AlertLifecycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, lcID, orgID, assigneeID)
+	ret0, _ := ret[0].(*message.AlertLifecycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockAlerterMockRecorder) Assign(ctx, lcID, orgID, assigneeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockAlerter)(nil).Assign), ctx, lcID, orgID, assigneeID)
+}
+
 // List mocks base method.
-func (m *MockAlerter) List(ctx context.Context, orgID, uniqID, devID, alarmID, userID string, end, start time.Time) ([]*api.Alert, error) {
+func (m *MockAlerter) List(ctx context.Context, orgID, uniqID, devID, alarmID, userID string, end, start time.Time, scopeTags []string) ([]*api.Alert, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, orgID, uniqID, devID, alarmID, userID, end, start)
+	ret := m.ctrl.Call(m, "List", ctx, orgID, uniqID, devID, alarmID, userID, end, start, scopeTags)
 	ret0, _ := ret[0].([]*api.Alert)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockAlerterMockRecorder) List(ctx, orgID, uniqID, devID, alarmID, userID, end, start any) *gomock.Call {
+func (mr *MockAlerterMockRecorder) List(ctx, orgID, uniqID, devID, alarmID, userID, end, start, scopeTags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAlerter)(nil).List), ctx, orgID, uniqID, devID, alarmID, userID, end, start, scopeTags)
+}
+
+// ListOpen mocks base method.
+func (m *MockAlerter) ListOpen(ctx context.Context, orgID, uniqID, alarmID string) ([]*message.AlertLifecycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpen", ctx, orgID, uniqID, alarmID)
+	ret0, _ := ret[0].([]*message.AlertLifecycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpen indicates an expected call of ListOpen.
+func (mr *MockAlerterMockRecorder) ListOpen(ctx, orgID, uniqID, alarmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpen", reflect.TypeOf((*MockAlerter)(nil).ListOpen), ctx, orgID, uniqID, alarmID)
+}
+
+// ReadDigest mocks base method.
+func (m *MockAlerter) ReadDigest(ctx context.Context, digestID, orgID string) (*message.AlertDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadDigest", ctx, digestID, orgID)
+	ret0, _ := ret[0].(*message.AlertDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadDigest indicates an expected call of ReadDigest.
+func (mr *MockAlerterMockRecorder) ReadDigest(ctx, digestID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadDigest", reflect.TypeOf((*MockAlerter)(nil).ReadDigest), ctx, digestID, orgID)
+}
+
+// ReadLifecycle mocks base method.
+func (m *MockAlerter) ReadLifecycle(ctx context.Context, lcID, orgID string) (*message.AlertLifecycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadLifecycle", ctx, lcID, orgID)
+	ret0, _ := ret[0].(*message.AlertLifecycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadLifecycle indicates an expected call of ReadLifecycle.
+func (mr *MockAlerterMockRecorder) ReadLifecycle(ctx, lcID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadLifecycle", reflect.TypeOf((*MockAlerter)(nil).ReadLifecycle), ctx, lcID, orgID)
+}
+
+// Resolve mocks base method.
+func (m *MockAlerter) Resolve(ctx context.Context, lcID, orgID, userID, note string) (*message.AlertLifecycle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, lcID, orgID, userID, note)
+	ret0, _ := ret[0].(*message.AlertLifecycle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockAlerterMockRecorder) Resolve(ctx, lcID, orgID, userID, note any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAlerter)(nil).List), ctx, orgID, uniqID, devID, alarmID, userID, end, start)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockAlerter)(nil).Resolve), ctx, lcID, orgID, userID, note)
 }
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: command.go
+//
+// Generated by this command:
+//
+//	mockgen -source command.go -destination mock_commander_test.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCommander is a mock of Commander interface.
+type MockCommander struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommanderMockRecorder
+	isgomock struct{}
+}
+
+// MockCommanderMockRecorder is the mock recorder for MockCommander.
+type MockCommanderMockRecorder struct {
+	mock *MockCommander
+}
+
+// NewMockCommander creates a new mock instance.
+func NewMockCommander(ctrl *gomock.Controller) *MockCommander {
+	mock := &MockCommander{ctrl: ctrl}
+	mock.recorder = &MockCommanderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommander) EXPECT() *MockCommanderMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommander) Create(ctx context.Context, cmd *message.Command) (*message.Command, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, cmd)
+	ret0, _ := ret[0].(*message.Command)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommanderMockRecorder) Create(ctx, cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommander)(nil).Create), ctx, cmd)
+}
+
+// List mocks base method.
+func (m *MockCommander) List(ctx context.Context, orgID, uniqID string, end, start time.Time) ([]*message.Command, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, orgID, uniqID, end, start)
+	ret0, _ := ret[0].([]*message.Command)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCommanderMockRecorder) List(ctx, orgID, uniqID, end, start any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCommander)(nil).List), ctx, orgID, uniqID, end, start)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockCommander) UpdateStatus(ctx context.Context, ack *message.CommandAck) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, ack)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockCommanderMockRecorder) UpdateStatus(ctx, ack any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockCommander)(nil).UpdateStatus), ctx, ack)
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: connectivity.go
+//
+// Generated by this command:
+//
+//	mockgen -source connectivity.go -destination mock_connectivityer_test.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConnectivityer is a mock of Connectivityer interface.
+type MockConnectivityer struct {
+	ctrl     *gomock.Controller
+	recorder *MockConnectivityerMockRecorder
+	isgomock struct{}
+}
+
+// MockConnectivityerMockRecorder is the mock recorder for MockConnectivityer.
+type MockConnectivityerMockRecorder struct {
+	mock *MockConnectivityer
+}
+
+// NewMockConnectivityer creates a new mock instance.
+func NewMockConnectivityer(ctrl *gomock.Controller) *MockConnectivityer {
+	mock := &MockConnectivityer{ctrl: ctrl}
+	mock.recorder = &MockConnectivityerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConnectivityer) EXPECT() *MockConnectivityerMockRecorder {
+	return m.recorder
+}
+
+// DeleteInterval mocks base method.
+func (m *MockConnectivityer) DeleteInterval(ctx context.Context, interID, orgID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInterval", ctx, interID, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInterval indicates an expected call of DeleteInterval.
+func (mr *MockConnectivityerMockRecorder) DeleteInterval(ctx, interID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInterval", reflect.TypeOf((*MockConnectivityer)(nil).DeleteInterval), ctx, interID, orgID)
+}
+
+// ListIntervals mocks base method.
+func (m *MockConnectivityer) ListIntervals(ctx context.Context, orgID string) ([]*message.ConnectivityInterval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIntervals", ctx, orgID)
+	ret0, _ := ret[0].([]*message.ConnectivityInterval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIntervals indicates an expected call of ListIntervals.
+func (mr *MockConnectivityerMockRecorder) ListIntervals(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIntervals", reflect.TypeOf((*MockConnectivityer)(nil).ListIntervals), ctx, orgID)
+}
+
+// Read mocks base method.
+func (m *MockConnectivityer) Read(ctx context.Context, devID, orgID string) (*message.Connectivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, devID, orgID)
+	ret0, _ := ret[0].(*message.Connectivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockConnectivityerMockRecorder) Read(ctx, devID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockConnectivityer)(nil).Read), ctx, devID, orgID)
+}
+
+// UpsertInterval mocks base method.
+func (m *MockConnectivityer) UpsertInterval(ctx context.Context, inter *message.ConnectivityInterval) (*message.ConnectivityInterval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertInterval", ctx, inter)
+	ret0, _ := ret[0].(*message.ConnectivityInterval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertInterval indicates an expected call of UpsertInterval.
+func (mr *MockConnectivityerMockRecorder) UpsertInterval(ctx, inter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertInterval", reflect.TypeOf((*MockConnectivityer)(nil).UpsertInterval), ctx, inter)
+}
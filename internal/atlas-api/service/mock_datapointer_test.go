@@ -42,6 +42,21 @@ func (m *MockDataPointer) EXPECT() *MockDataPointerMockRecorder {
 	return m.recorder
 }
 
+// Aggregate mocks base method.
+func (m *MockDataPointer) Aggregate(ctx context.Context, orgID, uniqID, devID, attr, fn string, bucket time.Duration, end, start time.Time) ([]*common.DataPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Aggregate", ctx, orgID, uniqID, devID, attr, fn, bucket, end, start)
+	ret0, _ := ret[0].([]*common.DataPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Aggregate indicates an expected call of Aggregate.
+func (mr *MockDataPointerMockRecorder) Aggregate(ctx, orgID, uniqID, devID, attr, fn, bucket, end, start any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockDataPointer)(nil).Aggregate), ctx, orgID, uniqID, devID, attr, fn, bucket, end, start)
+}
+
 // Latest mocks base method.
 func (m *MockDataPointer) Latest(ctx context.Context, orgID, uniqID, devID string, start time.Time) ([]*common.DataPoint, error) {
 	m.ctrl.T.Helper()
@@ -72,9 +72,9 @@ func (mr *MockDevicerMockRecorder) Delete(ctx, devID, orgID any) *gomock.Call {
 }
 
 // List mocks base method.
-func (m *MockDevicer) List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string, limit int32, tag string) ([]*api.Device, int32, error) {
+func (m *MockDevicer) List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string, limit int32, tag string, scopeTags []string) ([]*api.Device, int32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, orgID, lBoundTS, prevID, limit, tag)
+	ret := m.ctrl.Call(m, "List", ctx, orgID, lBoundTS, prevID, limit, tag, scopeTags)
 	ret0, _ := ret[0].([]*api.Device)
 	ret1, _ := ret[1].(int32)
 	ret2, _ := ret[2].(error)
@@ -82,9 +82,9 @@ func (m *MockDevicer) List(ctx context.Context, orgID string, lBoundTS time.Time
 }
 
 // List indicates an expected call of List.
-func (mr *MockDevicerMockRecorder) List(ctx, orgID, lBoundTS, prevID, limit, tag any) *gomock.Call {
+func (mr *MockDevicerMockRecorder) List(ctx, orgID, lBoundTS, prevID, limit, tag, scopeTags any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDevicer)(nil).List), ctx, orgID, lBoundTS, prevID, limit, tag)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDevicer)(nil).List), ctx, orgID, lBoundTS, prevID, limit, tag, scopeTags)
 }
 
 // Read mocks base method.
@@ -102,6 +102,21 @@ func (mr *MockDevicerMockRecorder) Read(ctx, devID, orgID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockDevicer)(nil).Read), ctx, devID, orgID)
 }
 
+// ReadByUniqID mocks base method.
+func (m *MockDevicer) ReadByUniqID(ctx context.Context, uniqID string) (*api.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByUniqID", ctx, uniqID)
+	ret0, _ := ret[0].(*api.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByUniqID indicates an expected call of ReadByUniqID.
+func (mr *MockDevicerMockRecorder) ReadByUniqID(ctx, uniqID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByUniqID", reflect.TypeOf((*MockDevicer)(nil).ReadByUniqID), ctx, uniqID)
+}
+
 // Update mocks base method.
 func (m *MockDevicer) Update(ctx context.Context, dev *api.Device) (*api.Device, error) {
 	m.ctrl.T.Helper()
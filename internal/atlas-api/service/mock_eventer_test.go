@@ -43,31 +43,31 @@ func (m *MockEventer) EXPECT() *MockEventerMockRecorder {
 }
 
 // Latest mocks base method.
-func (m *MockEventer) Latest(ctx context.Context, orgID, ruleID string) ([]*api.Event, error) {
+func (m *MockEventer) Latest(ctx context.Context, orgID, ruleID string, scopeTags []string) ([]*api.Event, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Latest", ctx, orgID, ruleID)
+	ret := m.ctrl.Call(m, "Latest", ctx, orgID, ruleID, scopeTags)
 	ret0, _ := ret[0].([]*api.Event)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Latest indicates an expected call of Latest.
-func (mr *MockEventerMockRecorder) Latest(ctx, orgID, ruleID any) *gomock.Call {
+func (mr *MockEventerMockRecorder) Latest(ctx, orgID, ruleID, scopeTags any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latest", reflect.TypeOf((*MockEventer)(nil).Latest), ctx, orgID, ruleID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latest", reflect.TypeOf((*MockEventer)(nil).Latest), ctx, orgID, ruleID, scopeTags)
 }
 
 // List mocks base method.
-func (m *MockEventer) List(ctx context.Context, orgID, uniqID, devID, ruleID string, end, start time.Time) ([]*api.Event, error) {
+func (m *MockEventer) List(ctx context.Context, orgID, uniqID, devID, ruleID string, end, start time.Time, scopeTags []string) ([]*api.Event, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, orgID, uniqID, devID, ruleID, end, start)
+	ret := m.ctrl.Call(m, "List", ctx, orgID, uniqID, devID, ruleID, end, start, scopeTags)
 	ret0, _ := ret[0].([]*api.Event)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockEventerMockRecorder) List(ctx, orgID, uniqID, devID, ruleID, end, start any) *gomock.Call {
+func (mr *MockEventerMockRecorder) List(ctx, orgID, uniqID, devID, ruleID, end, start, scopeTags any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockEventer)(nil).List), ctx, orgID, uniqID, devID, ruleID, end, start)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockEventer)(nil).List), ctx, orgID, uniqID, devID, ruleID, end, start, scopeTags)
 }
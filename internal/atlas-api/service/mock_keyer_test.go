@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	message "github.com/thingspect/atlas/proto/go/message"
 	api "github.com/thingspect/proto/go/api"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -57,6 +58,22 @@ func (mr *MockKeyerMockRecorder) Create(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockKeyer)(nil).Create), ctx, key)
 }
 
+// CreateRestricted mocks base method.
+func (m *MockKeyer) CreateRestricted(ctx context.Context, key *api.Key, res *message.KeyRestriction) (*api.Key, *message.KeyRestriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRestricted", ctx, key, res)
+	ret0, _ := ret[0].(*api.Key)
+	ret1, _ := ret[1].(*message.KeyRestriction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateRestricted indicates an expected call of CreateRestricted.
+func (mr *MockKeyerMockRecorder) CreateRestricted(ctx, key, res any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRestricted", reflect.TypeOf((*MockKeyer)(nil).CreateRestricted), ctx, key, res)
+}
+
 // Delete mocks base method.
 func (m *MockKeyer) Delete(ctx context.Context, keyID, orgID string) error {
 	m.ctrl.T.Helper()
@@ -86,3 +103,18 @@ func (mr *MockKeyerMockRecorder) List(ctx, orgID, lBoundTS, prevID, limit any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockKeyer)(nil).List), ctx, orgID, lBoundTS, prevID, limit)
 }
+
+// ReadRestriction mocks base method.
+func (m *MockKeyer) ReadRestriction(ctx context.Context, keyID, orgID string) (*message.KeyRestriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadRestriction", ctx, keyID, orgID)
+	ret0, _ := ret[0].(*message.KeyRestriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadRestriction indicates an expected call of ReadRestriction.
+func (mr *MockKeyerMockRecorder) ReadRestriction(ctx, keyID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRestriction", reflect.TypeOf((*MockKeyer)(nil).ReadRestriction), ctx, keyID, orgID)
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	message "github.com/thingspect/atlas/proto/go/message"
 	api "github.com/thingspect/proto/go/api"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -57,20 +58,6 @@ func (mr *MockOrgerMockRecorder) Create(ctx, org any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrger)(nil).Create), ctx, org)
 }
 
-// Delete mocks base method.
-func (m *MockOrger) Delete(ctx context.Context, orgID string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, orgID)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Delete indicates an expected call of Delete.
-func (mr *MockOrgerMockRecorder) Delete(ctx, orgID any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrger)(nil).Delete), ctx, orgID)
-}
-
 // List mocks base method.
 func (m *MockOrger) List(ctx context.Context, lBoundTS time.Time, prevID string, limit int32) ([]*api.Org, int32, error) {
 	m.ctrl.T.Helper()
@@ -87,6 +74,21 @@ func (mr *MockOrgerMockRecorder) List(ctx, lBoundTS, prevID, limit any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOrger)(nil).List), ctx, lBoundTS, prevID, limit)
 }
 
+// MarkDelete mocks base method.
+func (m *MockOrger) MarkDelete(ctx context.Context, orgID string) (*message.OrgDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelete", ctx, orgID)
+	ret0, _ := ret[0].(*message.OrgDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkDelete indicates an expected call of MarkDelete.
+func (mr *MockOrgerMockRecorder) MarkDelete(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelete", reflect.TypeOf((*MockOrger)(nil).MarkDelete), ctx, orgID)
+}
+
 // Read mocks base method.
 func (m *MockOrger) Read(ctx context.Context, orgID string) (*api.Org, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +104,51 @@ func (mr *MockOrgerMockRecorder) Read(ctx, orgID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockOrger)(nil).Read), ctx, orgID)
 }
 
+// ReadDeletion mocks base method.
+func (m *MockOrger) ReadDeletion(ctx context.Context, orgID string) (*message.OrgDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadDeletion", ctx, orgID)
+	ret0, _ := ret[0].(*message.OrgDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadDeletion indicates an expected call of ReadDeletion.
+func (mr *MockOrgerMockRecorder) ReadDeletion(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadDeletion", reflect.TypeOf((*MockOrger)(nil).ReadDeletion), ctx, orgID)
+}
+
+// ReadMFAPolicy mocks base method.
+func (m *MockOrger) ReadMFAPolicy(ctx context.Context, orgID string) (*message.OrgMFAPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMFAPolicy", ctx, orgID)
+	ret0, _ := ret[0].(*message.OrgMFAPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadMFAPolicy indicates an expected call of ReadMFAPolicy.
+func (mr *MockOrgerMockRecorder) ReadMFAPolicy(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMFAPolicy", reflect.TypeOf((*MockOrger)(nil).ReadMFAPolicy), ctx, orgID)
+}
+
+// ReadRetention mocks base method.
+func (m *MockOrger) ReadRetention(ctx context.Context, orgID string) (*message.OrgRetention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadRetention", ctx, orgID)
+	ret0, _ := ret[0].(*message.OrgRetention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadRetention indicates an expected call of ReadRetention.
+func (mr *MockOrgerMockRecorder) ReadRetention(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRetention", reflect.TypeOf((*MockOrger)(nil).ReadRetention), ctx, orgID)
+}
+
 // Update mocks base method.
 func (m *MockOrger) Update(ctx context.Context, org *api.Org) (*api.Org, error) {
 	m.ctrl.T.Helper()
@@ -116,3 +163,68 @@ func (mr *MockOrgerMockRecorder) Update(ctx, org any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrger)(nil).Update), ctx, org)
 }
+
+// UpdateRetention mocks base method.
+func (m *MockOrger) UpdateRetention(ctx context.Context, ret *message.OrgRetention) error {
+	m.ctrl.T.Helper()
+	ret_2 := m.ctrl.Call(m, "UpdateRetention", ctx, ret)
+	ret0, _ := ret_2[0].(error)
+	return ret0
+}
+
+// UpdateRetention indicates an expected call of UpdateRetention.
+func (mr *MockOrgerMockRecorder) UpdateRetention(ctx, ret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRetention", reflect.TypeOf((*MockOrger)(nil).UpdateRetention), ctx, ret)
+}
+
+// UpsertMFAPolicy mocks base method.
+func (m *MockOrger) UpsertMFAPolicy(ctx context.Context, policy *message.OrgMFAPolicy) (*message.OrgMFAPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMFAPolicy", ctx, policy)
+	ret0, _ := ret[0].(*message.OrgMFAPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMFAPolicy indicates an expected call of UpsertMFAPolicy.
+func (mr *MockOrgerMockRecorder) UpsertMFAPolicy(ctx, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMFAPolicy", reflect.TypeOf((*MockOrger)(nil).UpsertMFAPolicy), ctx, policy)
+}
+
+// MockOffboarder is a mock of Offboarder interface.
+type MockOffboarder struct {
+	ctrl     *gomock.Controller
+	recorder *MockOffboarderMockRecorder
+	isgomock struct{}
+}
+
+// MockOffboarderMockRecorder is the mock recorder for MockOffboarder.
+type MockOffboarderMockRecorder struct {
+	mock *MockOffboarder
+}
+
+// NewMockOffboarder creates a new mock instance.
+func NewMockOffboarder(ctrl *gomock.Controller) *MockOffboarder {
+	mock := &MockOffboarder{ctrl: ctrl}
+	mock.recorder = &MockOffboarderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOffboarder) EXPECT() *MockOffboarderMockRecorder {
+	return m.recorder
+}
+
+// Start mocks base method.
+func (m *MockOffboarder) Start(orgID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Start", orgID)
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockOffboarderMockRecorder) Start(orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockOffboarder)(nil).Start), orgID)
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: oidc.go
+//
+// Generated by this command:
+//
+//	mockgen -source oidc.go -destination mock_orgoidcer_test.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrgOIDCer is a mock of OrgOIDCer interface.
+type MockOrgOIDCer struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrgOIDCerMockRecorder
+	isgomock struct{}
+}
+
+// MockOrgOIDCerMockRecorder is the mock recorder for MockOrgOIDCer.
+type MockOrgOIDCerMockRecorder struct {
+	mock *MockOrgOIDCer
+}
+
+// NewMockOrgOIDCer creates a new mock instance.
+func NewMockOrgOIDCer(ctrl *gomock.Controller) *MockOrgOIDCer {
+	mock := &MockOrgOIDCer{ctrl: ctrl}
+	mock.recorder = &MockOrgOIDCerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrgOIDCer) EXPECT() *MockOrgOIDCerMockRecorder {
+	return m.recorder
+}
+
+// DeleteOIDC mocks base method.
+func (m *MockOrgOIDCer) DeleteOIDC(ctx context.Context, orgID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOIDC", ctx, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOIDC indicates an expected call of DeleteOIDC.
+func (mr *MockOrgOIDCerMockRecorder) DeleteOIDC(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOIDC", reflect.TypeOf((*MockOrgOIDCer)(nil).DeleteOIDC), ctx, orgID)
+}
+
+// ReadOIDC mocks base method.
+func (m *MockOrgOIDCer) ReadOIDC(ctx context.Context, orgID string) (*message.OrgOIDC, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadOIDC", ctx, orgID)
+	ret0, _ := ret[0].(*message.OrgOIDC)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadOIDC indicates an expected call of ReadOIDC.
+func (mr *MockOrgOIDCerMockRecorder) ReadOIDC(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadOIDC", reflect.TypeOf((*MockOrgOIDCer)(nil).ReadOIDC), ctx, orgID)
+}
+
+// ReadOIDCByName mocks base method.
+func (m *MockOrgOIDCer) ReadOIDCByName(ctx context.Context, orgName string) (*message.OrgOIDC, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadOIDCByName", ctx, orgName)
+	ret0, _ := ret[0].(*message.OrgOIDC)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadOIDCByName indicates an expected call of ReadOIDCByName.
+func (mr *MockOrgOIDCerMockRecorder) ReadOIDCByName(ctx, orgName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadOIDCByName", reflect.TypeOf((*MockOrgOIDCer)(nil).ReadOIDCByName), ctx, orgName)
+}
+
+// UpsertOIDC mocks base method.
+func (m *MockOrgOIDCer) UpsertOIDC(ctx context.Context, oidc *message.OrgOIDC, secret []byte) (*message.OrgOIDC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertOIDC", ctx, oidc, secret)
+	ret0, _ := ret[0].(*message.OrgOIDC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertOIDC indicates an expected call of UpsertOIDC.
+func (mr *MockOrgOIDCerMockRecorder) UpsertOIDC(ctx, oidc, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOIDC", reflect.TypeOf((*MockOrgOIDCer)(nil).UpsertOIDC), ctx, oidc, secret)
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	message "github.com/thingspect/atlas/proto/go/message"
 	api "github.com/thingspect/proto/go/api"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -71,6 +72,34 @@ func (mr *MockRulerMockRecorder) Delete(ctx, ruleID, orgID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRuler)(nil).Delete), ctx, ruleID, orgID)
 }
 
+// DeleteCondition mocks base method.
+func (m *MockRuler) DeleteCondition(ctx context.Context, ruleID, orgID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCondition", ctx, ruleID, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCondition indicates an expected call of DeleteCondition.
+func (mr *MockRulerMockRecorder) DeleteCondition(ctx, ruleID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCondition", reflect.TypeOf((*MockRuler)(nil).DeleteCondition), ctx, ruleID, orgID)
+}
+
+// DeleteTrigger mocks base method.
+func (m *MockRuler) DeleteTrigger(ctx context.Context, ruleID, orgID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrigger", ctx, ruleID, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTrigger indicates an expected call of DeleteTrigger.
+func (mr *MockRulerMockRecorder) DeleteTrigger(ctx, ruleID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrigger", reflect.TypeOf((*MockRuler)(nil).DeleteTrigger), ctx, ruleID, orgID)
+}
+
 // List mocks base method.
 func (m *MockRuler) List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string, limit int32) ([]*api.Rule, int32, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +131,36 @@ func (mr *MockRulerMockRecorder) Read(ctx, ruleID, orgID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockRuler)(nil).Read), ctx, ruleID, orgID)
 }
 
+// ReadCondition mocks base method.
+func (m *MockRuler) ReadCondition(ctx context.Context, ruleID, orgID string) (*message.RuleCondition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadCondition", ctx, ruleID, orgID)
+	ret0, _ := ret[0].(*message.RuleCondition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadCondition indicates an expected call of ReadCondition.
+func (mr *MockRulerMockRecorder) ReadCondition(ctx, ruleID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCondition", reflect.TypeOf((*MockRuler)(nil).ReadCondition), ctx, ruleID, orgID)
+}
+
+// ReadTrigger mocks base method.
+func (m *MockRuler) ReadTrigger(ctx context.Context, ruleID, orgID string) (*message.RuleTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadTrigger", ctx, ruleID, orgID)
+	ret0, _ := ret[0].(*message.RuleTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadTrigger indicates an expected call of ReadTrigger.
+func (mr *MockRulerMockRecorder) ReadTrigger(ctx, ruleID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadTrigger", reflect.TypeOf((*MockRuler)(nil).ReadTrigger), ctx, ruleID, orgID)
+}
+
 // Update mocks base method.
 func (m *MockRuler) Update(ctx context.Context, rule *api.Rule) (*api.Rule, error) {
 	m.ctrl.T.Helper()
@@ -116,3 +175,33 @@ func (mr *MockRulerMockRecorder) Update(ctx, rule any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRuler)(nil).Update), ctx, rule)
 }
+
+// UpsertCondition mocks base method.
+func (m *MockRuler) UpsertCondition(ctx context.Context, cond *message.RuleCondition) (*message.RuleCondition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCondition", ctx, cond)
+	ret0, _ := ret[0].(*message.RuleCondition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCondition indicates an expected call of UpsertCondition.
+func (mr *MockRulerMockRecorder) UpsertCondition(ctx, cond any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCondition", reflect.TypeOf((*MockRuler)(nil).UpsertCondition), ctx, cond)
+}
+
+// UpsertTrigger mocks base method.
+func (m *MockRuler) UpsertTrigger(ctx context.Context, trig *message.RuleTrigger) (*message.RuleTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTrigger", ctx, trig)
+	ret0, _ := ret[0].(*message.RuleTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTrigger indicates an expected call of UpsertTrigger.
+func (mr *MockRulerMockRecorder) UpsertTrigger(ctx, trig any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTrigger", reflect.TypeOf((*MockRuler)(nil).UpsertTrigger), ctx, trig)
+}
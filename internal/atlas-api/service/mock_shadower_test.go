@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: shadow.go
+//
+// Generated by this command:
+//
+//	mockgen -source shadow.go -destination mock_shadower_test.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShadower is a mock of Shadower interface.
+type MockShadower struct {
+	ctrl     *gomock.Controller
+	recorder *MockShadowerMockRecorder
+	isgomock struct{}
+}
+
+// MockShadowerMockRecorder is the mock recorder for MockShadower.
+type MockShadowerMockRecorder struct {
+	mock *MockShadower
+}
+
+// NewMockShadower creates a new mock instance.
+func NewMockShadower(ctrl *gomock.Controller) *MockShadower {
+	mock := &MockShadower{ctrl: ctrl}
+	mock.recorder = &MockShadowerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShadower) EXPECT() *MockShadowerMockRecorder {
+	return m.recorder
+}
+
+// ReadDesired mocks base method.
+func (m *MockShadower) ReadDesired(ctx context.Context, devID, orgID string) (*message.Shadow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadDesired", ctx, devID, orgID)
+	ret0, _ := ret[0].(*message.Shadow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadDesired indicates an expected call of ReadDesired.
+func (mr *MockShadowerMockRecorder) ReadDesired(ctx, devID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadDesired", reflect.TypeOf((*MockShadower)(nil).ReadDesired), ctx, devID, orgID)
+}
+
+// UpdateDesired mocks base method.
+func (m *MockShadower) UpdateDesired(ctx context.Context, shadow *message.Shadow) (*message.Shadow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDesired", ctx, shadow)
+	ret0, _ := ret[0].(*message.Shadow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDesired indicates an expected call of UpdateDesired.
+func (mr *MockShadowerMockRecorder) UpdateDesired(ctx, shadow any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDesired", reflect.TypeOf((*MockShadower)(nil).UpdateDesired), ctx, shadow)
+}
@@ -41,16 +41,16 @@ func (m *MockTagger) EXPECT() *MockTaggerMockRecorder {
 }
 
 // List mocks base method.
-func (m *MockTagger) List(ctx context.Context, orgID string) ([]string, error) {
+func (m *MockTagger) List(ctx context.Context, orgID string, scopeTags []string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, orgID)
+	ret := m.ctrl.Call(m, "List", ctx, orgID, scopeTags)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockTaggerMockRecorder) List(ctx, orgID any) *gomock.Call {
+func (mr *MockTaggerMockRecorder) List(ctx, orgID, scopeTags any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTagger)(nil).List), ctx, orgID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTagger)(nil).List), ctx, orgID, scopeTags)
 }
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	message "github.com/thingspect/atlas/proto/go/message"
 	api "github.com/thingspect/proto/go/api"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,6 +43,20 @@ func (m *MockUserer) EXPECT() *MockUsererMockRecorder {
 	return m.recorder
 }
 
+// ConfirmMFA mocks base method.
+func (m *MockUserer) ConfirmMFA(ctx context.Context, userID, orgID string, step int64, recoveryHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmMFA", ctx, userID, orgID, step, recoveryHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmMFA indicates an expected call of ConfirmMFA.
+func (mr *MockUsererMockRecorder) ConfirmMFA(ctx, userID, orgID, step, recoveryHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMFA", reflect.TypeOf((*MockUserer)(nil).ConfirmMFA), ctx, userID, orgID, step, recoveryHashes)
+}
+
 // Create mocks base method.
 func (m *MockUserer) Create(ctx context.Context, user *api.User) (*api.User, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +72,21 @@ func (mr *MockUsererMockRecorder) Create(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserer)(nil).Create), ctx, user)
 }
 
+// CreateSession mocks base method.
+func (m *MockUserer) CreateSession(ctx context.Context, userID, orgID string, refreshHash []byte, expiresAt time.Time) (*message.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, userID, orgID, refreshHash, expiresAt)
+	ret0, _ := ret[0].(*message.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockUsererMockRecorder) CreateSession(ctx, userID, orgID, refreshHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockUserer)(nil).CreateSession), ctx, userID, orgID, refreshHash, expiresAt)
+}
+
 // Delete mocks base method.
 func (m *MockUserer) Delete(ctx context.Context, userID, orgID string) error {
 	m.ctrl.T.Helper()
@@ -71,6 +101,34 @@ func (mr *MockUsererMockRecorder) Delete(ctx, userID, orgID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserer)(nil).Delete), ctx, userID, orgID)
 }
 
+// DeleteMFA mocks base method.
+func (m *MockUserer) DeleteMFA(ctx context.Context, userID, orgID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMFA", ctx, userID, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMFA indicates an expected call of DeleteMFA.
+func (mr *MockUsererMockRecorder) DeleteMFA(ctx, userID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMFA", reflect.TypeOf((*MockUserer)(nil).DeleteMFA), ctx, userID, orgID)
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockUserer) DeleteSchedule(ctx context.Context, userID, orgID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", ctx, userID, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockUsererMockRecorder) DeleteSchedule(ctx, userID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockUserer)(nil).DeleteSchedule), ctx, userID, orgID)
+}
+
 // List mocks base method.
 func (m *MockUserer) List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string, limit int32, tag string) ([]*api.User, int32, error) {
 	m.ctrl.T.Helper()
@@ -87,6 +145,21 @@ func (mr *MockUsererMockRecorder) List(ctx, orgID, lBoundTS, prevID, limit, tag
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserer)(nil).List), ctx, orgID, lBoundTS, prevID, limit, tag)
 }
 
+// ListSessions mocks base method.
+func (m *MockUserer) ListSessions(ctx context.Context, userID, orgID string) ([]*message.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, userID, orgID)
+	ret0, _ := ret[0].([]*message.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockUsererMockRecorder) ListSessions(ctx, userID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockUserer)(nil).ListSessions), ctx, userID, orgID)
+}
+
 // Read mocks base method.
 func (m *MockUserer) Read(ctx context.Context, userID, orgID string) (*api.User, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +191,111 @@ func (mr *MockUsererMockRecorder) ReadByEmail(ctx, email, orgName any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByEmail", reflect.TypeOf((*MockUserer)(nil).ReadByEmail), ctx, email, orgName)
 }
 
+// ReadMFA mocks base method.
+func (m *MockUserer) ReadMFA(ctx context.Context, userID, orgID string) (*message.UserMFA, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMFA", ctx, userID, orgID)
+	ret0, _ := ret[0].(*message.UserMFA)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadMFA indicates an expected call of ReadMFA.
+func (mr *MockUsererMockRecorder) ReadMFA(ctx, userID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMFA", reflect.TypeOf((*MockUserer)(nil).ReadMFA), ctx, userID, orgID)
+}
+
+// ReadSchedule mocks base method.
+func (m *MockUserer) ReadSchedule(ctx context.Context, userID, orgID string) (*message.NotificationSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSchedule", ctx, userID, orgID)
+	ret0, _ := ret[0].(*message.NotificationSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSchedule indicates an expected call of ReadSchedule.
+func (mr *MockUsererMockRecorder) ReadSchedule(ctx, userID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSchedule", reflect.TypeOf((*MockUserer)(nil).ReadSchedule), ctx, userID, orgID)
+}
+
+// ReadScope mocks base method.
+func (m *MockUserer) ReadScope(ctx context.Context, userID, orgID string) (*message.UserScope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadScope", ctx, userID, orgID)
+	ret0, _ := ret[0].(*message.UserScope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadScope indicates an expected call of ReadScope.
+func (mr *MockUsererMockRecorder) ReadScope(ctx, userID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadScope", reflect.TypeOf((*MockUserer)(nil).ReadScope), ctx, userID, orgID)
+}
+
+// RevokeReusedSession mocks base method.
+func (m *MockUserer) RevokeReusedSession(ctx context.Context, refreshHash []byte) (*message.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeReusedSession", ctx, refreshHash)
+	ret0, _ := ret[0].(*message.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeReusedSession indicates an expected call of RevokeReusedSession.
+func (mr *MockUsererMockRecorder) RevokeReusedSession(ctx, refreshHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeReusedSession", reflect.TypeOf((*MockUserer)(nil).RevokeReusedSession), ctx, refreshHash)
+}
+
+// RevokeSession mocks base method.
+func (m *MockUserer) RevokeSession(ctx context.Context, sessID, userID, orgID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessID, userID, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockUsererMockRecorder) RevokeSession(ctx, sessID, userID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockUserer)(nil).RevokeSession), ctx, sessID, userID, orgID)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockUserer) RevokeSessions(ctx context.Context, userID, orgID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", ctx, userID, orgID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockUsererMockRecorder) RevokeSessions(ctx, userID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockUserer)(nil).RevokeSessions), ctx, userID, orgID)
+}
+
+// RotateSession mocks base method.
+func (m *MockUserer) RotateSession(ctx context.Context, refreshHash, newHash []byte, expiresAt time.Time) (*message.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSession", ctx, refreshHash, newHash, expiresAt)
+	ret0, _ := ret[0].(*message.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSession indicates an expected call of RotateSession.
+func (mr *MockUsererMockRecorder) RotateSession(ctx, refreshHash, newHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSession", reflect.TypeOf((*MockUserer)(nil).RotateSession), ctx, refreshHash, newHash, expiresAt)
+}
+
 // Update mocks base method.
 func (m *MockUserer) Update(ctx context.Context, user *api.User) (*api.User, error) {
 	m.ctrl.T.Helper()
@@ -146,3 +324,75 @@ func (mr *MockUsererMockRecorder) UpdatePassword(ctx, userID, orgID, passHash an
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserer)(nil).UpdatePassword), ctx, userID, orgID, passHash)
 }
+
+// UpsertMFA mocks base method.
+func (m *MockUserer) UpsertMFA(ctx context.Context, userID, orgID string, secret []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMFA", ctx, userID, orgID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertMFA indicates an expected call of UpsertMFA.
+func (mr *MockUsererMockRecorder) UpsertMFA(ctx, userID, orgID, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMFA", reflect.TypeOf((*MockUserer)(nil).UpsertMFA), ctx, userID, orgID, secret)
+}
+
+// UpsertSchedule mocks base method.
+func (m *MockUserer) UpsertSchedule(ctx context.Context, sched *message.NotificationSchedule) (*message.NotificationSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSchedule", ctx, sched)
+	ret0, _ := ret[0].(*message.NotificationSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertSchedule indicates an expected call of UpsertSchedule.
+func (mr *MockUsererMockRecorder) UpsertSchedule(ctx, sched any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSchedule", reflect.TypeOf((*MockUserer)(nil).UpsertSchedule), ctx, sched)
+}
+
+// UpsertScope mocks base method.
+func (m *MockUserer) UpsertScope(ctx context.Context, scope *message.UserScope) (*message.UserScope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertScope", ctx, scope)
+	ret0, _ := ret[0].(*message.UserScope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertScope indicates an expected call of UpsertScope.
+func (mr *MockUsererMockRecorder) UpsertScope(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertScope", reflect.TypeOf((*MockUserer)(nil).UpsertScope), ctx, scope)
+}
+
+// UseMFAStep mocks base method.
+func (m *MockUserer) UseMFAStep(ctx context.Context, userID, orgID string, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseMFAStep", ctx, userID, orgID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseMFAStep indicates an expected call of UseMFAStep.
+func (mr *MockUsererMockRecorder) UseMFAStep(ctx, userID, orgID, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseMFAStep", reflect.TypeOf((*MockUserer)(nil).UseMFAStep), ctx, userID, orgID, step)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockUserer) UseRecoveryCode(ctx context.Context, userID, orgID, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, orgID, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockUsererMockRecorder) UseRecoveryCode(ctx, userID, orgID, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockUserer)(nil).UseRecoveryCode), ctx, userID, orgID, hash)
+}
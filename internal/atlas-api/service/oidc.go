@@ -0,0 +1,430 @@
+package service
+
+//go:generate mockgen -source oidc.go -destination mock_orgoidcer_test.go -package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/internal/atlas-api/oidc"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/crypt"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Constants used for OIDC logins and configuration bounds.
+const (
+	oidcStateExp = 10 * time.Minute
+	maxOIDCField = 255
+)
+
+// OrgOIDCer defines the OIDC methods provided by an org.DAO.
+type OrgOIDCer interface {
+	UpsertOIDC(ctx context.Context, oidc *message.OrgOIDC, secret []byte) (
+		*message.OrgOIDC, error)
+	ReadOIDC(ctx context.Context, orgID string) (*message.OrgOIDC, []byte,
+		error)
+	ReadOIDCByName(ctx context.Context, orgName string) (*message.OrgOIDC,
+		[]byte, error)
+	DeleteOIDC(ctx context.Context, orgID string) error
+}
+
+// OIDC service contains functions to configure and perform OpenID Connect
+// single sign-on.
+type OIDC struct {
+	orgDAO  OrgOIDCer
+	userDAO Userer
+	authn   oidc.Authenticator
+	cache   cache.Cacher[string]
+
+	pwtKey      []byte
+	redirectURI string
+}
+
+// NewOIDC instantiates and returns a new OIDC service.
+func NewOIDC(
+	orgDAO OrgOIDCer, userDAO Userer, authn oidc.Authenticator,
+	cache cache.Cacher[string], pwtKey []byte, redirectURI string,
+) *OIDC {
+	return &OIDC{
+		orgDAO:  orgDAO,
+		userDAO: userDAO,
+		authn:   authn,
+		cache:   cache,
+
+		pwtKey:      pwtKey,
+		redirectURI: redirectURI,
+	}
+}
+
+// oidcState holds the pending state of an OIDC login.
+type oidcState struct {
+	OrgID    string `json:"orgID"`
+	OrgName  string `json:"orgName"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+// GetOrgOIDC retrieves the OIDC configuration of the current organization.
+// The client secret is never returned.
+func (o *OIDC) GetOrgOIDC(ctx context.Context) (*message.OrgOIDC, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	conf, _, err := o.orgDAO.ReadOIDC(ctx, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return conf, nil
+}
+
+// UpdateOrgOIDC creates or replaces the OIDC configuration of the current
+// organization. If a client secret is not provided, any existing secret is
+// retained.
+func (o *OIDC) UpdateOrgOIDC(ctx context.Context, conf *message.OrgOIDC) (
+	*message.OrgOIDC, error,
+) {
+	logger := alog.FromContext(ctx)
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	if !validIssuer(conf.GetIssuer()) {
+		return nil, status.Error(codes.InvalidArgument,
+			"issuer must be a valid HTTPS URL")
+	}
+
+	if conf.GetClientId() == "" || len(conf.GetClientId()) > maxOIDCField ||
+		len(conf.GetClientSecret()) > maxOIDCField ||
+		len(conf.GetRoleClaim()) > maxOIDCField {
+		return nil, status.Error(codes.InvalidArgument,
+			"invalid client ID, client secret, or role claim")
+	}
+
+	// Single sign-on may not grant contact or system admin roles.
+	for _, role := range conf.GetRoleMap() {
+		if !validOIDCRole(role) {
+			return nil, status.Error(codes.InvalidArgument,
+				"role map roles must be VIEWER, BUILDER, or ADMIN")
+		}
+	}
+
+	if conf.GetDefaultRole() != api.Role_ROLE_UNSPECIFIED &&
+		!validOIDCRole(conf.GetDefaultRole()) {
+		return nil, status.Error(codes.InvalidArgument,
+			"default role must be VIEWER, BUILDER, or ADMIN")
+	}
+
+	var secret []byte
+	if conf.GetClientSecret() != "" {
+		var err error
+		secret, err = crypt.Encrypt(o.pwtKey, []byte(conf.GetClientSecret()))
+		if err != nil {
+			logger.Errorf("UpdateOrgOIDC crypt.Encrypt: %v", err)
+
+			return nil, errToStatus(err)
+		}
+	}
+
+	conf.OrgId = sess.OrgID
+
+	upConf, err := o.orgDAO.UpsertOIDC(ctx, conf, secret)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return upConf, nil
+}
+
+// DeleteOrgOIDC deletes the OIDC configuration of the current organization.
+func (o *OIDC) DeleteOrgOIDC(ctx context.Context) error {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return errPerm(api.Role_ADMIN)
+	}
+
+	if err := o.orgDAO.DeleteOIDC(ctx, sess.OrgID); err != nil {
+		return errToStatus(err)
+	}
+
+	return nil
+}
+
+// StartOIDCLogin begins an OIDC login to an organization by name, and returns
+// the identity provider URL that the user is redirected to.
+func (o *OIDC) StartOIDCLogin(ctx context.Context, orgName string) (string,
+	error,
+) {
+	logger := alog.FromContext(ctx)
+
+	conf, secret, err := o.orgDAO.ReadOIDCByName(ctx, orgName)
+	if err != nil {
+		logger.Debugf("StartOIDCLogin o.orgDAO.ReadOIDCByName orgName, err: "+
+			"%v, %v", orgName, err)
+
+		return "", status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	state, err := oidc.NewRandom()
+	if err != nil {
+		logger.Errorf("StartOIDCLogin oidc.NewRandom: %v", err)
+
+		return "", status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	nonce, err := oidc.NewRandom()
+	if err != nil {
+		logger.Errorf("StartOIDCLogin oidc.NewRandom: %v", err)
+
+		return "", status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	verifier, challenge, err := oidc.NewPKCE()
+	if err != nil {
+		logger.Errorf("StartOIDCLogin oidc.NewPKCE: %v", err)
+
+		return "", status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	bState, err := json.Marshal(&oidcState{
+		OrgID: conf.GetOrgId(), OrgName: orgName, Nonce: nonce,
+		Verifier: verifier,
+	})
+	if err != nil {
+		logger.Errorf("StartOIDCLogin json.Marshal: %v", err)
+
+		return "", status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	if err := o.cache.SetTTL(ctx, key.OIDCState(state), string(bState),
+		oidcStateExp); err != nil {
+		return "", errToStatus(err)
+	}
+
+	authURL, err := o.authn.AuthCodeURL(ctx, o.authConfig(conf, secret), state,
+		nonce, challenge)
+	if err != nil {
+		logger.Errorf("StartOIDCLogin o.authn.AuthCodeURL: %v", err)
+
+		return "", status.Error(codes.Unavailable,
+			"identity provider unavailable")
+	}
+
+	return authURL, nil
+}
+
+// CompleteOIDCLogin completes an OIDC login using the state and authorization
+// code returned by the identity provider. Users are matched by verified email
+// address, and are created if the organization allows it. Existing users above
+// the admin role may not log in by single sign-on. Users that must provide a
+// second factor receive an MFA challenge, as with password logins.
+func (o *OIDC) CompleteOIDCLogin(ctx context.Context, state, code string) (
+	*api.LoginResponse, error,
+) {
+	logger := alog.FromContext(ctx)
+
+	if state == "" || code == "" {
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	// States may be used once.
+	bState, err := o.cache.Get(ctx, key.OIDCState(state))
+	if err != nil {
+		logger.Debugf("CompleteOIDCLogin o.cache.Get: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	if err := o.cache.Del(ctx, key.OIDCState(state)); err != nil {
+		logger.Errorf("CompleteOIDCLogin o.cache.Del: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	pending := &oidcState{}
+	if err := json.Unmarshal([]byte(bState), pending); err != nil {
+		logger.Errorf("CompleteOIDCLogin json.Unmarshal: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	logger.Logger = logger.WithField("orgID", pending.OrgID)
+
+	conf, secret, err := o.orgDAO.ReadOIDC(ctx, pending.OrgID)
+	if err != nil {
+		logger.Debugf("CompleteOIDCLogin o.orgDAO.ReadOIDC: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	claims, err := o.authn.Identify(ctx, o.authConfig(conf, secret), code,
+		pending.Verifier, pending.Nonce)
+	if err != nil {
+		logger.Infof("CompleteOIDCLogin o.authn.Identify: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		logger.Infof("CompleteOIDCLogin unverified email, claims: %+v",
+			claims)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	user, _, err := o.userDAO.ReadByEmail(ctx, claims.Email, pending.OrgName)
+	if errors.Is(err, dao.ErrNotFound) && conf.GetJitUsers() {
+		user, err = o.createUser(ctx, conf, claims)
+	}
+	// Single sign-on may not log in contacts or system admins, so that an
+	// identity provider configured by an org admin may not assume a role
+	// above its own.
+	if err != nil || user.GetStatus() != api.Status_ACTIVE ||
+		!validOIDCRole(user.GetRole()) {
+		logger.Debugf("CompleteOIDCLogin user, err: %+v, %v", user, err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	// Single sign-on does not bypass the user's second factor or the
+	// organization's MFA policy.
+	if err := challengeMFA(ctx, o.userDAO, o.cache, user, pending.OrgName,
+		user.GetEmail(), false); err != nil {
+		return nil, err
+	}
+
+	tokens, err := newSession(ctx, o.userDAO, o.pwtKey, user, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.LoginResponse{
+		Token: tokens.GetToken(), ExpiresAt: tokens.GetExpiresAt(),
+	}, nil
+}
+
+// createUser creates a user just-in-time from verified claims. Users without
+// a matching role are not created when the organization has no default role.
+func (o *OIDC) createUser(
+	ctx context.Context, conf *message.OrgOIDC, claims *oidc.Claims,
+) (*api.User, error) {
+	logger := alog.FromContext(ctx)
+
+	role := oidcRole(conf, claims)
+	if role < api.Role_VIEWER {
+		return nil, dao.ErrNotFound
+	}
+
+	name := claims.Name
+	if l := utf8.RuneCountInString(name); l < 5 || l > 80 {
+		name = claims.Email
+	}
+
+	user := &api.User{
+		OrgId: conf.GetOrgId(), Name: name, Email: claims.Email, Role: role,
+		Status: api.Status_ACTIVE,
+		Tags:   []string{strings.ToLower(role.String())},
+	}
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := o.userDAO.Create(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("createUser created OIDC user: %+v", user)
+
+	return user, nil
+}
+
+// authConfig builds an oidc.Config from an organization's configuration and
+// its stored client secret.
+func (o *OIDC) authConfig(conf *message.OrgOIDC, secret []byte) *oidc.Config {
+	authConf := &oidc.Config{
+		Issuer: conf.GetIssuer(), ClientID: conf.GetClientId(),
+		RedirectURI: o.redirectURI,
+	}
+
+	// A secret that does not decrypt is treated as absent, and the identity
+	// provider will reject the exchange.
+	if len(secret) > 0 {
+		if plain, err := crypt.Decrypt(o.pwtKey, secret); err == nil {
+			authConf.ClientSecret = string(plain)
+		}
+	}
+
+	return authConf
+}
+
+// oidcRole returns the highest role mapped from the values of an
+// organization's role claim, falling back to its default role.
+func oidcRole(conf *message.OrgOIDC, claims *oidc.Claims) api.Role {
+	var vals []string
+	switch claim := claims.Raw[conf.GetRoleClaim()].(type) {
+	case string:
+		vals = []string{claim}
+	case []any:
+		for _, val := range claim {
+			if s, ok := val.(string); ok {
+				vals = append(vals, s)
+			}
+		}
+	}
+
+	role := api.Role_ROLE_UNSPECIFIED
+	for _, val := range vals {
+		if mapped, ok := conf.GetRoleMap()[val]; ok && mapped > role {
+			role = mapped
+		}
+	}
+
+	if role == api.Role_ROLE_UNSPECIFIED {
+		return conf.GetDefaultRole()
+	}
+
+	return role
+}
+
+// validOIDCRole returns whether a role may be granted by single sign-on.
+func validOIDCRole(role api.Role) bool {
+	return role == api.Role_VIEWER || role == api.Role_BUILDER ||
+		role == api.Role_ADMIN
+}
+
+// validIssuer returns whether an issuer is a valid HTTPS URL. HTTP is allowed
+// for loopback hosts to support local identity providers.
+func validIssuer(issuer string) bool {
+	u, err := url.Parse(issuer)
+	if err != nil || u.Host == "" || len(issuer) > maxOIDCField ||
+		u.RawQuery != "" || u.Fragment != "" {
+		return false
+	}
+
+	if u.Scheme == "https" {
+		return true
+	}
+
+	ip := net.ParseIP(u.Hostname())
+
+	return u.Scheme == "http" && (u.Hostname() == "localhost" ||
+		(ip != nil && ip.IsLoopback()))
+}
@@ -0,0 +1,718 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/internal/atlas-api/oidc"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/crypt"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// testRedirectURI is the redirect URI used by OIDC tests.
+const testRedirectURI = "https://atlas.example.com/v1/sessions/oidc/callback"
+
+// randOrgOIDC returns a random OrgOIDC configuration.
+func randOrgOIDC(orgID string) *message.OrgOIDC {
+	return &message.OrgOIDC{
+		OrgId: orgID, Issuer: "https://idp.example.com/" + random.String(10),
+		ClientId: random.String(10), RoleClaim: "groups",
+		RoleMap: map[string]api.Role{
+			"atlas-admins": api.Role_ADMIN, "atlas-builders": api.Role_BUILDER,
+		},
+		DefaultRole: api.Role_VIEWER, JitUsers: true,
+	}
+}
+
+func TestGetOrgOIDC(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get OIDC by valid org", func(t *testing.T) {
+		t.Parallel()
+
+		conf := randOrgOIDC(uuid.NewV7().String())
+		retConf, _ := proto.Clone(conf).(*message.OrgOIDC)
+
+		orgOIDCer := NewMockOrgOIDCer(gomock.NewController(t))
+		orgOIDCer.EXPECT().ReadOIDC(gomock.Any(), conf.GetOrgId()).
+			Return(retConf, random.Bytes(20), nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: conf.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, nil, nil, nil, nil, testRedirectURI)
+		getConf, err := oidcSvc.GetOrgOIDC(ctx)
+		t.Logf("conf, getConf, err: %+v, %+v, %v", conf, getConf, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, conf, getConf)
+	})
+
+	t.Run("Get OIDC with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(nil, nil, nil, nil, nil, testRedirectURI)
+		getConf, err := oidcSvc.GetOrgOIDC(ctx)
+		t.Logf("getConf, err: %+v, %v", getConf, err)
+		require.Nil(t, getConf)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Get OIDC by unconfigured org", func(t *testing.T) {
+		t.Parallel()
+
+		orgOIDCer := NewMockOrgOIDCer(gomock.NewController(t))
+		orgOIDCer.EXPECT().ReadOIDC(gomock.Any(), gomock.Any()).
+			Return(nil, nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, nil, nil, nil, nil, testRedirectURI)
+		getConf, err := oidcSvc.GetOrgOIDC(ctx)
+		t.Logf("getConf, err: %+v, %v", getConf, err)
+		require.Nil(t, getConf)
+		require.Equal(t, status.Error(codes.NotFound,
+			"dao: object not found"), err)
+	})
+}
+
+func TestUpdateOrgOIDC(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Update OIDC with secret", func(t *testing.T) {
+		t.Parallel()
+
+		conf := randOrgOIDC(uuid.NewV7().String())
+		conf.ClientSecret = random.String(20)
+		secret := conf.GetClientSecret()
+		retConf, _ := proto.Clone(conf).(*message.OrgOIDC)
+		retConf.ClientSecret = ""
+
+		pwtKey := make([]byte, 32)
+		_, err := rand.Read(pwtKey)
+		require.NoError(t, err)
+
+		orgOIDCer := NewMockOrgOIDCer(gomock.NewController(t))
+		orgOIDCer.EXPECT().UpsertOIDC(gomock.Any(), gomock.Any(),
+			gomock.Any()).DoAndReturn(func(_ context.Context,
+			_ *message.OrgOIDC, encSecret []byte,
+		) (*message.OrgOIDC, error) {
+			plain, err := crypt.Decrypt(pwtKey, encSecret)
+			require.NoError(t, err)
+			require.Equal(t, secret, string(plain))
+
+			return retConf, nil
+		}).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: conf.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, nil, nil, nil, pwtKey, testRedirectURI)
+		upConf, err := oidcSvc.UpdateOrgOIDC(ctx, conf)
+		t.Logf("conf, upConf, err: %+v, %+v, %v", conf, upConf, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, retConf, upConf)
+	})
+
+	t.Run("Update OIDC without secret", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		conf := randOrgOIDC("")
+		conf.Issuer = "http://127.0.0.1:8080"
+		retConf, _ := proto.Clone(conf).(*message.OrgOIDC)
+		retConf.OrgId = orgID
+
+		orgOIDCer := NewMockOrgOIDCer(gomock.NewController(t))
+		orgOIDCer.EXPECT().UpsertOIDC(gomock.Any(),
+			matcher.NewProtoMatcher(retConf), nil).Return(retConf, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, nil, nil, nil, nil, testRedirectURI)
+		upConf, err := oidcSvc.UpdateOrgOIDC(ctx, conf)
+		t.Logf("conf, upConf, err: %+v, %+v, %v", conf, upConf, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, retConf, upConf)
+	})
+
+	t.Run("Update OIDC with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(nil, nil, nil, nil, nil, testRedirectURI)
+		upConf, err := oidcSvc.UpdateOrgOIDC(ctx, randOrgOIDC(""))
+		t.Logf("upConf, err: %+v, %v", upConf, err)
+		require.Nil(t, upConf)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	for _, test := range []struct {
+		inpMod func(conf *message.OrgOIDC)
+		err    string
+	}{
+		{func(conf *message.OrgOIDC) { conf.Issuer = "http://idp.example.com" },
+			"issuer must be a valid HTTPS URL"},
+		{func(conf *message.OrgOIDC) { conf.Issuer = "https://" },
+			"issuer must be a valid HTTPS URL"},
+		{func(conf *message.OrgOIDC) { conf.Issuer = ":" },
+			"issuer must be a valid HTTPS URL"},
+		{func(conf *message.OrgOIDC) {
+			conf.Issuer = "https://idp.example.com?tenant=atlas"
+		}, "issuer must be a valid HTTPS URL"},
+		{func(conf *message.OrgOIDC) { conf.ClientId = "" },
+			"invalid client ID, client secret, or role claim"},
+		{func(conf *message.OrgOIDC) { conf.ClientSecret = random.String(256) },
+			"invalid client ID, client secret, or role claim"},
+		{func(conf *message.OrgOIDC) { conf.RoleClaim = random.String(256) },
+			"invalid client ID, client secret, or role claim"},
+		{func(conf *message.OrgOIDC) {
+			conf.RoleMap["atlas-sys"] = api.Role_SYS_ADMIN
+		}, "role map roles must be VIEWER, BUILDER, or ADMIN"},
+		{func(conf *message.OrgOIDC) { conf.DefaultRole = api.Role_CONTACT },
+			"default role must be VIEWER, BUILDER, or ADMIN"},
+	} {
+		t.Run(fmt.Sprintf("Cannot update %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			conf := randOrgOIDC("")
+			test.inpMod(conf)
+
+			ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+				&session.Session{
+					OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN,
+				}), testTimeout)
+			defer cancel()
+
+			oidcSvc := NewOIDC(nil, nil, nil, nil, nil, testRedirectURI)
+			upConf, err := oidcSvc.UpdateOrgOIDC(ctx, conf)
+			t.Logf("upConf, err: %+v, %v", upConf, err)
+			require.Nil(t, upConf)
+			require.Equal(t, status.Error(codes.InvalidArgument, test.err), err)
+		})
+	}
+}
+
+func TestDeleteOrgOIDC(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Delete OIDC by valid org", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+
+		orgOIDCer := NewMockOrgOIDCer(gomock.NewController(t))
+		orgOIDCer.EXPECT().DeleteOIDC(gomock.Any(), orgID).Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, nil, nil, nil, nil, testRedirectURI)
+		err := oidcSvc.DeleteOrgOIDC(ctx)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete OIDC with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(nil, nil, nil, nil, nil, testRedirectURI)
+		err := oidcSvc.DeleteOrgOIDC(ctx)
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Delete OIDC by unconfigured org", func(t *testing.T) {
+		t.Parallel()
+
+		orgOIDCer := NewMockOrgOIDCer(gomock.NewController(t))
+		orgOIDCer.EXPECT().DeleteOIDC(gomock.Any(), gomock.Any()).
+			Return(dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, nil, nil, nil, nil, testRedirectURI)
+		err := oidcSvc.DeleteOrgOIDC(ctx)
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.NotFound,
+			"dao: object not found"), err)
+	})
+}
+
+func TestStartOIDCLogin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Start login by valid org", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-oidc")
+		conf := randOrgOIDC(org.GetId())
+		secret := random.String(20)
+
+		pwtKey := make([]byte, 32)
+		_, err := rand.Read(pwtKey)
+		require.NoError(t, err)
+
+		encSecret, err := crypt.Encrypt(pwtKey, []byte(secret))
+		require.NoError(t, err)
+
+		var state, nonce string
+
+		ctrl := gomock.NewController(t)
+		orgOIDCer := NewMockOrgOIDCer(ctrl)
+		orgOIDCer.EXPECT().ReadOIDCByName(gomock.Any(), org.GetName()).
+			Return(conf, encSecret, nil).Times(1)
+		authn := oidc.NewMockAuthenticator(ctrl)
+		authn.EXPECT().AuthCodeURL(gomock.Any(), &oidc.Config{
+			Issuer: conf.GetIssuer(), ClientID: conf.GetClientId(),
+			ClientSecret: secret, RedirectURI: testRedirectURI,
+		}, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *oidc.Config, inpState, inpNonce,
+				challenge string,
+			) (string, error) {
+				state = inpState
+				nonce = inpNonce
+
+				return conf.GetIssuer() + "/authorize?state=" +
+					url.QueryEscape(inpState) + "&challenge=" + challenge, nil
+			}).Times(1)
+
+		c := cache.NewHeap[string]()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, nil, authn, c, pwtKey, testRedirectURI)
+		authURL, err := oidcSvc.StartOIDCLogin(ctx, org.GetName())
+		t.Logf("authURL, err: %v, %v", authURL, err)
+		require.NoError(t, err)
+		require.Contains(t, authURL, url.QueryEscape(state))
+
+		bState, err := c.Get(ctx, key.OIDCState(state))
+		t.Logf("bState, err: %v, %v", bState, err)
+		require.NoError(t, err)
+
+		pending := &oidcState{}
+		require.NoError(t, json.Unmarshal([]byte(bState), pending))
+		require.Equal(t, org.GetId(), pending.OrgID)
+		require.Equal(t, org.GetName(), pending.OrgName)
+		require.Equal(t, nonce, pending.Nonce)
+		require.Contains(t, authURL, oidc.Challenge(pending.Verifier))
+	})
+
+	t.Run("Start login by unconfigured org", func(t *testing.T) {
+		t.Parallel()
+
+		orgOIDCer := NewMockOrgOIDCer(gomock.NewController(t))
+		orgOIDCer.EXPECT().ReadOIDCByName(gomock.Any(), gomock.Any()).
+			Return(nil, nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, nil, nil, nil, nil, testRedirectURI)
+		authURL, err := oidcSvc.StartOIDCLogin(ctx, random.String(10))
+		t.Logf("authURL, err: %v, %v", authURL, err)
+		require.Empty(t, authURL)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Start login with unavailable provider", func(t *testing.T) {
+		t.Parallel()
+
+		conf := randOrgOIDC(uuid.NewV7().String())
+
+		ctrl := gomock.NewController(t)
+		orgOIDCer := NewMockOrgOIDCer(ctrl)
+		orgOIDCer.EXPECT().ReadOIDCByName(gomock.Any(), gomock.Any()).
+			Return(conf, nil, nil).Times(1)
+		authn := oidc.NewMockAuthenticator(ctrl)
+		authn.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return("", oidc.ErrDiscovery).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, nil, authn, cache.NewHeap[string](), nil,
+			testRedirectURI)
+		authURL, err := oidcSvc.StartOIDCLogin(ctx, random.String(10))
+		t.Logf("authURL, err: %v, %v", authURL, err)
+		require.Empty(t, authURL)
+		require.Equal(t, status.Error(codes.Unavailable,
+			"identity provider unavailable"), err)
+	})
+}
+
+func TestCompleteOIDCLogin(t *testing.T) {
+	t.Parallel()
+
+	pwtKey := make([]byte, 32)
+	_, err := rand.Read(pwtKey)
+	require.NoError(t, err)
+
+	// pendingLogin caches a pending login and returns its state.
+	pendingLogin := func(t *testing.T, c cache.Cacher[string],
+		org *api.Org,
+	) string {
+		t.Helper()
+
+		state := random.String(43)
+		bState, err := json.Marshal(&oidcState{
+			OrgID: org.GetId(), OrgName: org.GetName(), Nonce: "nonce",
+			Verifier: "verifier",
+		})
+		require.NoError(t, err)
+		require.NoError(t, c.Set(t.Context(), key.OIDCState(state),
+			string(bState)))
+
+		return state
+	}
+
+	t.Run("Complete login by existing user", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-oidc")
+		user := random.User("api-oidc", org.GetId())
+		user.Role = api.Role_BUILDER
+		user.Status = api.Status_ACTIVE
+		conf := randOrgOIDC(org.GetId())
+		sessID := uuid.NewV7().String()
+
+		c := cache.NewHeap[string]()
+		state := pendingLogin(t, c, org)
+
+		ctrl := gomock.NewController(t)
+		orgOIDCer := NewMockOrgOIDCer(ctrl)
+		orgOIDCer.EXPECT().ReadOIDC(gomock.Any(), org.GetId()).
+			Return(conf, nil, nil).Times(1)
+		authn := oidc.NewMockAuthenticator(ctrl)
+		authn.EXPECT().Identify(gomock.Any(), gomock.Any(), "code", "verifier",
+			"nonce").Return(&oidc.Claims{
+			Email: user.GetEmail(), EmailVerified: true,
+		}, nil).Times(1)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, nil, nil).Times(1)
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), org.GetId()).
+			Return(&message.UserMFA{}, nil, nil).Times(1)
+		userer.EXPECT().ReadScope(gomock.Any(), user.GetId(), org.GetId()).
+			Return(nil, dao.ErrNotFound).Times(1)
+		userer.EXPECT().CreateSession(gomock.Any(), user.GetId(), org.GetId(),
+			gomock.Any(), gomock.Any()).Return(&message.UserSession{
+			Id: sessID,
+		}, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, userer, authn, c, pwtKey,
+			testRedirectURI)
+		loginResp, err := oidcSvc.CompleteOIDCLogin(ctx, state, "code")
+		t.Logf("loginResp, err: %+v, %v", loginResp, err)
+		require.NoError(t, err)
+
+		sess, err := session.ValidateWebToken(pwtKey, loginResp.GetToken())
+		t.Logf("sess, err: %+v, %v", sess, err)
+		require.NoError(t, err)
+		require.Equal(t, sessID, sess.SessionID)
+		require.Equal(t, user.GetId(), sess.UserID)
+		require.Equal(t, api.Role_BUILDER, sess.Role)
+
+		// States may be used once.
+		loginResp, err = oidcSvc.CompleteOIDCLogin(ctx, state, "code")
+		t.Logf("loginResp, err: %+v, %v", loginResp, err)
+		require.Nil(t, loginResp)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	for _, test := range []struct {
+		inpRoleClaim any
+		resRole      api.Role
+	}{
+		{nil, api.Role_VIEWER},
+		{"atlas-builders", api.Role_BUILDER},
+		{[]any{"atlas-builders", "atlas-admins", 5}, api.Role_ADMIN},
+		{[]any{"other"}, api.Role_VIEWER},
+	} {
+		t.Run(fmt.Sprintf("Can create user %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			org := random.Org("api-oidc")
+			conf := randOrgOIDC(org.GetId())
+			email := random.Email()
+			name := "api-oidc-" + random.String(10)
+
+			c := cache.NewHeap[string]()
+			state := pendingLogin(t, c, org)
+
+			createUser := &api.User{
+				OrgId: org.GetId(), Name: name, Email: email,
+				Role: test.resRole, Status: api.Status_ACTIVE,
+				Tags: []string{strings.ToLower(test.resRole.String())},
+			}
+			retUser, _ := proto.Clone(createUser).(*api.User)
+			retUser.Id = uuid.NewV7().String()
+
+			ctrl := gomock.NewController(t)
+			orgOIDCer := NewMockOrgOIDCer(ctrl)
+			orgOIDCer.EXPECT().ReadOIDC(gomock.Any(), org.GetId()).
+				Return(conf, nil, nil).Times(1)
+			authn := oidc.NewMockAuthenticator(ctrl)
+			authn.EXPECT().Identify(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Return(&oidc.Claims{
+				Email: email, EmailVerified: true, Name: name,
+				Raw: map[string]any{"groups": test.inpRoleClaim},
+			}, nil).Times(1)
+			userer := NewMockUserer(ctrl)
+			userer.EXPECT().ReadByEmail(gomock.Any(), email, org.GetName()).
+				Return(nil, nil, dao.ErrNotFound).Times(1)
+			userer.EXPECT().Create(gomock.Any(),
+				matcher.NewProtoMatcher(createUser)).Return(retUser, nil).
+				Times(1)
+			userer.EXPECT().ReadMFA(gomock.Any(), retUser.GetId(),
+				org.GetId()).Return(&message.UserMFA{}, nil, nil).Times(1)
+			userer.EXPECT().ReadScope(gomock.Any(), retUser.GetId(),
+				org.GetId()).Return(nil, dao.ErrNotFound).Times(1)
+			userer.EXPECT().CreateSession(gomock.Any(), retUser.GetId(),
+				org.GetId(), gomock.Any(), gomock.Any()).Return(
+				&message.UserSession{Id: uuid.NewV7().String()}, nil).Times(1)
+
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			oidcSvc := NewOIDC(orgOIDCer, userer, authn, c, pwtKey,
+				testRedirectURI)
+			loginResp, err := oidcSvc.CompleteOIDCLogin(ctx, state, "code")
+			t.Logf("loginResp, err: %+v, %v", loginResp, err)
+			require.NoError(t, err)
+
+			sess, err := session.ValidateWebToken(pwtKey, loginResp.GetToken())
+			t.Logf("sess, err: %+v, %v", sess, err)
+			require.NoError(t, err)
+			require.Equal(t, retUser.GetId(), sess.UserID)
+			require.Equal(t, test.resRole, sess.Role)
+		})
+	}
+
+	t.Run("Complete login with MFA", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-oidc")
+		user := random.User("api-oidc", org.GetId())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+
+		c := cache.NewHeap[string]()
+		state := pendingLogin(t, c, org)
+
+		ctrl := gomock.NewController(t)
+		orgOIDCer := NewMockOrgOIDCer(ctrl)
+		orgOIDCer.EXPECT().ReadOIDC(gomock.Any(), org.GetId()).
+			Return(randOrgOIDC(org.GetId()), nil, nil).Times(1)
+		authn := oidc.NewMockAuthenticator(ctrl)
+		authn.EXPECT().Identify(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(&oidc.Claims{
+			Email: user.GetEmail(), EmailVerified: true,
+		}, nil).Times(1)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, nil, nil).Times(1)
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), org.GetId()).
+			Return(&message.UserMFA{Required: true}, nil, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, userer, authn, c, pwtKey,
+			testRedirectURI)
+		loginResp, err := oidcSvc.CompleteOIDCLogin(ctx, state, "code")
+		t.Logf("loginResp, err: %+v, %v", loginResp, err)
+		require.Nil(t, loginResp)
+
+		st := status.Convert(err)
+		require.Equal(t, codes.Unauthenticated, st.Code())
+		require.Equal(t, "mfa enrollment required", st.Message())
+		require.Len(t, st.Details(), 1)
+
+		chal, ok := st.Details()[0].(*message.MFAChallenge)
+		require.True(t, ok)
+		require.True(t, chal.GetEnrollmentRequired())
+
+		_, err = c.Get(ctx, key.MFAChallenge(chal.GetToken()))
+		require.NoError(t, err)
+	})
+
+	t.Run("Complete login by unknown state", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(nil, nil, nil, cache.NewHeap[string](), nil,
+			testRedirectURI)
+		loginResp, err := oidcSvc.CompleteOIDCLogin(ctx, random.String(43),
+			"code")
+		t.Logf("loginResp, err: %+v, %v", loginResp, err)
+		require.Nil(t, loginResp)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Complete login with failed identification", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-oidc")
+
+		c := cache.NewHeap[string]()
+		state := pendingLogin(t, c, org)
+
+		ctrl := gomock.NewController(t)
+		orgOIDCer := NewMockOrgOIDCer(ctrl)
+		orgOIDCer.EXPECT().ReadOIDC(gomock.Any(), org.GetId()).
+			Return(randOrgOIDC(org.GetId()), nil, nil).Times(1)
+		authn := oidc.NewMockAuthenticator(ctrl)
+		authn.EXPECT().Identify(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(nil, oidc.ErrToken).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, nil, authn, c, pwtKey, testRedirectURI)
+		loginResp, err := oidcSvc.CompleteOIDCLogin(ctx, state, "code")
+		t.Logf("loginResp, err: %+v, %v", loginResp, err)
+		require.Nil(t, loginResp)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Complete login with unverified email", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-oidc")
+
+		c := cache.NewHeap[string]()
+		state := pendingLogin(t, c, org)
+
+		ctrl := gomock.NewController(t)
+		orgOIDCer := NewMockOrgOIDCer(ctrl)
+		orgOIDCer.EXPECT().ReadOIDC(gomock.Any(), org.GetId()).
+			Return(randOrgOIDC(org.GetId()), nil, nil).Times(1)
+		authn := oidc.NewMockAuthenticator(ctrl)
+		authn.EXPECT().Identify(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(&oidc.Claims{
+			Email: random.Email(),
+		}, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		oidcSvc := NewOIDC(orgOIDCer, nil, authn, c, pwtKey, testRedirectURI)
+		loginResp, err := oidcSvc.CompleteOIDCLogin(ctx, state, "code")
+		t.Logf("loginResp, err: %+v, %v", loginResp, err)
+		require.Nil(t, loginResp)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	for _, test := range []struct {
+		inpJIT         bool
+		inpDefaultRole api.Role
+		inpUser        *api.User
+	}{
+		{false, api.Role_VIEWER, nil},
+		{true, api.Role_ROLE_UNSPECIFIED, nil},
+		{true, api.Role_VIEWER, &api.User{
+			Role: api.Role_ADMIN, Status: api.Status_DISABLED,
+		}},
+		{true, api.Role_VIEWER, &api.User{
+			Role: api.Role_CONTACT, Status: api.Status_ACTIVE,
+		}},
+		{true, api.Role_VIEWER, &api.User{
+			Role: api.Role_SYS_ADMIN, Status: api.Status_ACTIVE,
+		}},
+	} {
+		t.Run(fmt.Sprintf("Cannot log in %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			org := random.Org("api-oidc")
+			conf := randOrgOIDC(org.GetId())
+			conf.JitUsers = test.inpJIT
+			conf.DefaultRole = test.inpDefaultRole
+
+			c := cache.NewHeap[string]()
+			state := pendingLogin(t, c, org)
+
+			var readErr error
+			if test.inpUser == nil {
+				readErr = dao.ErrNotFound
+			}
+
+			ctrl := gomock.NewController(t)
+			orgOIDCer := NewMockOrgOIDCer(ctrl)
+			orgOIDCer.EXPECT().ReadOIDC(gomock.Any(), org.GetId()).
+				Return(conf, nil, nil).Times(1)
+			authn := oidc.NewMockAuthenticator(ctrl)
+			authn.EXPECT().Identify(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Return(&oidc.Claims{
+				Email: random.Email(), EmailVerified: true,
+			}, nil).Times(1)
+			userer := NewMockUserer(ctrl)
+			userer.EXPECT().ReadByEmail(gomock.Any(), gomock.Any(),
+				org.GetName()).Return(test.inpUser, nil, readErr).Times(1)
+
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			oidcSvc := NewOIDC(orgOIDCer, userer, authn, c, pwtKey,
+				testRedirectURI)
+			loginResp, err := oidcSvc.CompleteOIDCLogin(ctx, state, "code")
+			t.Logf("loginResp, err: %+v, %v", loginResp, err)
+			require.Nil(t, loginResp)
+			require.Equal(t, status.Error(codes.Unauthenticated, errUnauth),
+				err)
+		})
+	}
+}
@@ -9,8 +9,11 @@ import (
 	"time"
 
 	"github.com/mennanov/fmutils"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
 	"github.com/thingspect/atlas/internal/atlas-api/session"
 	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -25,22 +28,43 @@ type Orger interface {
 	Create(ctx context.Context, org *api.Org) (*api.Org, error)
 	Read(ctx context.Context, orgID string) (*api.Org, error)
 	Update(ctx context.Context, org *api.Org) (*api.Org, error)
-	Delete(ctx context.Context, orgID string) error
 	List(ctx context.Context, lBoundTS time.Time, prevID string,
 		limit int32) ([]*api.Org, int32, error)
+	MarkDelete(ctx context.Context, orgID string) (*message.OrgDeletion,
+		error)
+	ReadDeletion(ctx context.Context, orgID string) (*message.OrgDeletion,
+		error)
+	UpsertMFAPolicy(ctx context.Context, policy *message.OrgMFAPolicy) (
+		*message.OrgMFAPolicy, error)
+	ReadMFAPolicy(ctx context.Context, orgID string) (*message.OrgMFAPolicy,
+		error)
+	ReadRetention(ctx context.Context, orgID string) (*message.OrgRetention,
+		error)
+	UpdateRetention(ctx context.Context, ret *message.OrgRetention) error
+}
+
+// Offboarder defines the methods provided by an offboard.Offboarder.
+type Offboarder interface {
+	Start(orgID string)
 }
 
 // Org service contains functions to query and modify organizations.
 type Org struct {
 	api.UnimplementedOrgServiceServer
 
-	orgDAO Orger
+	orgDAO     Orger
+	cache      cache.Cacher[string]
+	offboarder Offboarder
 }
 
 // NewOrg instantiates and returns a new Org service.
-func NewOrg(orgDAO Orger) *Org {
+func NewOrg(
+	orgDAO Orger, cache cache.Cacher[string], offboarder Offboarder,
+) *Org {
 	return &Org{
-		orgDAO: orgDAO,
+		orgDAO:     orgDAO,
+		cache:      cache,
+		offboarder: offboarder,
 	}
 }
 
@@ -137,7 +161,9 @@ func (o *Org) UpdateOrg(ctx context.Context, req *api.UpdateOrgRequest) (
 	return org, nil
 }
 
-// DeleteOrg deletes an organization by ID.
+// DeleteOrg deletes an organization by ID. The organization and its devices,
+// users, and API keys are disabled immediately, and its data is purged in the
+// background.
 func (o *Org) DeleteOrg(ctx context.Context, req *api.DeleteOrgRequest) (
 	*emptypb.Empty, error,
 ) {
@@ -146,12 +172,25 @@ func (o *Org) DeleteOrg(ctx context.Context, req *api.DeleteOrgRequest) (
 		return nil, errPerm(api.Role_SYS_ADMIN)
 	}
 
-	if err := o.orgDAO.Delete(ctx, req.GetId()); err != nil {
+	// Deleting the org of the session would also disable the session.
+	if req.GetId() == sess.OrgID {
+		return nil, status.Error(codes.InvalidArgument,
+			"cannot delete own organization")
+	}
+
+	if _, err := o.orgDAO.MarkDelete(ctx, req.GetId()); err != nil {
 		return nil, errToStatus(err)
 	}
 
+	// Disable all sessions of the org, including those of API keys.
+	if err := o.cache.Set(ctx, key.OrgDisabled(req.GetId()), ""); err != nil {
+		return nil, errToStatus(err)
+	}
+
+	o.offboarder.Start(req.GetId())
+
 	if err := grpc.SetHeader(ctx, metadata.Pairs(StatusCodeKey,
-		strconv.Itoa(http.StatusNoContent))); err != nil {
+		strconv.Itoa(http.StatusAccepted))); err != nil {
 		logger := alog.FromContext(ctx)
 		logger.Errorf("DeleteOrg grpc.SetHeader: %v", err)
 	}
@@ -159,6 +198,23 @@ func (o *Org) DeleteOrg(ctx context.Context, req *api.DeleteOrgRequest) (
 	return &emptypb.Empty{}, nil
 }
 
+// GetOrgDeletion retrieves the progress of an organization's deletion by ID.
+func (o *Org) GetOrgDeletion(ctx context.Context, orgID string) (
+	*message.OrgDeletion, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_SYS_ADMIN {
+		return nil, errPerm(api.Role_SYS_ADMIN)
+	}
+
+	del, err := o.orgDAO.ReadDeletion(ctx, orgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return del, nil
+}
+
 // ListOrgs retrieves all organizations.
 func (o *Org) ListOrgs(ctx context.Context, req *api.ListOrgsRequest) (
 	*api.ListOrgsResponse, error,
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetOrgMFAPolicy retrieves the MFA policy of the current organization. If no
+// policy has been set, MFA is optional.
+func (o *Org) GetOrgMFAPolicy(ctx context.Context) (
+	*message.OrgMFAPolicy, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	policy, err := o.orgDAO.ReadMFAPolicy(ctx, sess.OrgID)
+	if errors.Is(err, dao.ErrNotFound) {
+		return &message.OrgMFAPolicy{
+			OrgId: sess.OrgID, MinRole: api.Role_ROLE_UNSPECIFIED,
+		}, nil
+	}
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return policy, nil
+}
+
+// UpdateOrgMFAPolicy creates or replaces the MFA policy of the current
+// organization. Users with a role at or above MinRole must use MFA to log in.
+func (o *Org) UpdateOrgMFAPolicy(
+	ctx context.Context, policy *message.OrgMFAPolicy,
+) (*message.OrgMFAPolicy, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	if _, ok := api.Role_name[int32(policy.GetMinRole())]; !ok ||
+		(policy.GetMinRole() != api.Role_ROLE_UNSPECIFIED &&
+			policy.GetMinRole() < api.Role_VIEWER) {
+		return nil, status.Error(codes.InvalidArgument,
+			"invalid MFA policy min role")
+	}
+
+	policy.OrgId = sess.OrgID
+
+	policy, err := o.orgDAO.UpsertMFAPolicy(ctx, policy)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,180 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetOrgMFAPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get MFA policy", func(t *testing.T) {
+		t.Parallel()
+
+		policy := &message.OrgMFAPolicy{
+			OrgId: uuid.NewV7().String(), MinRole: api.Role_ADMIN,
+		}
+		retPolicy, _ := proto.Clone(policy).(*message.OrgMFAPolicy)
+
+		orger := NewMockOrger(gomock.NewController(t))
+		orger.EXPECT().ReadMFAPolicy(gomock.Any(), policy.GetOrgId()).
+			Return(retPolicy, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: policy.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(orger, nil, nil)
+		getPolicy, err := orgSvc.GetOrgMFAPolicy(ctx)
+		t.Logf("policy, getPolicy, err: %+v, %+v, %v", policy, getPolicy, err)
+		require.NoError(t, err)
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(policy, getPolicy) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", policy, getPolicy)
+		}
+	})
+
+	t.Run("Get default MFA policy", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+
+		orger := NewMockOrger(gomock.NewController(t))
+		orger.EXPECT().ReadMFAPolicy(gomock.Any(), orgID).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(orger, nil, nil)
+		getPolicy, err := orgSvc.GetOrgMFAPolicy(ctx)
+		t.Logf("getPolicy, err: %+v, %v", getPolicy, err)
+		require.NoError(t, err)
+		require.Equal(t, orgID, getPolicy.GetOrgId())
+		require.Equal(t, api.Role_ROLE_UNSPECIFIED, getPolicy.GetMinRole())
+	})
+
+	t.Run("Get MFA policy with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(nil, nil, nil)
+		getPolicy, err := orgSvc.GetOrgMFAPolicy(ctx)
+		t.Logf("getPolicy, err: %+v, %v", getPolicy, err)
+		require.Nil(t, getPolicy)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Get MFA policy with DAO failure", func(t *testing.T) {
+		t.Parallel()
+
+		orger := NewMockOrger(gomock.NewController(t))
+		orger.EXPECT().ReadMFAPolicy(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(orger, nil, nil)
+		getPolicy, err := orgSvc.GetOrgMFAPolicy(ctx)
+		t.Logf("getPolicy, err: %+v, %v", getPolicy, err)
+		require.Nil(t, getPolicy)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestUpdateOrgMFAPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Update MFA policy", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		policy := &message.OrgMFAPolicy{
+			OrgId: orgID, MinRole: api.Role_ADMIN,
+		}
+		retPolicy, _ := proto.Clone(policy).(*message.OrgMFAPolicy)
+
+		orger := NewMockOrger(gomock.NewController(t))
+		orger.EXPECT().UpsertMFAPolicy(gomock.Any(),
+			matcher.NewProtoMatcher(policy)).Return(retPolicy, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(orger, nil, nil)
+		updPolicy, err := orgSvc.UpdateOrgMFAPolicy(ctx,
+			&message.OrgMFAPolicy{
+				OrgId: uuid.NewV7().String(), MinRole: api.Role_ADMIN,
+			})
+		t.Logf("policy, updPolicy, err: %+v, %+v, %v", policy, updPolicy, err)
+		require.NoError(t, err)
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(policy, updPolicy) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", policy, updPolicy)
+		}
+	})
+
+	t.Run("Update MFA policy with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(nil, nil, nil)
+		updPolicy, err := orgSvc.UpdateOrgMFAPolicy(ctx,
+			&message.OrgMFAPolicy{MinRole: api.Role_ADMIN})
+		t.Logf("updPolicy, err: %+v, %v", updPolicy, err)
+		require.Nil(t, updPolicy)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Update MFA policy with invalid role", func(t *testing.T) {
+		t.Parallel()
+
+		for _, role := range []api.Role{api.Role_CONTACT, api.Role(99)} {
+			ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+				&session.Session{
+					OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN,
+				}), testTimeout)
+
+			orgSvc := NewOrg(nil, nil, nil)
+			updPolicy, err := orgSvc.UpdateOrgMFAPolicy(ctx,
+				&message.OrgMFAPolicy{MinRole: role})
+			cancel()
+			t.Logf("updPolicy, err: %+v, %v", updPolicy, err)
+			require.Nil(t, updPolicy)
+			require.Equal(t, status.Error(codes.InvalidArgument,
+				"invalid MFA policy min role"), err)
+		}
+	})
+}
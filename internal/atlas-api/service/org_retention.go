@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetOrgRetention retrieves the retention policy of the current organization.
+// Zero values indicate that the platform default applies.
+func (o *Org) GetOrgRetention(ctx context.Context) (
+	*message.OrgRetention, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	ret, err := o.orgDAO.ReadRetention(ctx, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return ret, nil
+}
+
+// UpdateOrgRetention replaces the retention policy of the current
+// organization. Zero values revert to the platform default.
+func (o *Org) UpdateOrgRetention(
+	ctx context.Context, ret *message.OrgRetention,
+) (*message.OrgRetention, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	if ret.GetDataPointDays() < 0 || ret.GetEventDays() < 0 ||
+		ret.GetAlertDays() < 0 {
+		return nil, status.Error(codes.InvalidArgument,
+			"retention days must not be negative")
+	}
+
+	ret.OrgId = sess.OrgID
+
+	if err := o.orgDAO.UpdateRetention(ctx, ret); err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return ret, nil
+}
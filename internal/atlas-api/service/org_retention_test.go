@@ -0,0 +1,172 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetOrgRetention(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get retention", func(t *testing.T) {
+		t.Parallel()
+
+		ret := &message.OrgRetention{
+			OrgId: uuid.NewV7().String(), DataPointDays: 30, AlertDays: 7,
+		}
+		retRet, _ := proto.Clone(ret).(*message.OrgRetention)
+
+		orger := NewMockOrger(gomock.NewController(t))
+		orger.EXPECT().ReadRetention(gomock.Any(), ret.GetOrgId()).
+			Return(retRet, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: ret.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(orger, nil, nil)
+		getRet, err := orgSvc.GetOrgRetention(ctx)
+		t.Logf("ret, getRet, err: %+v, %+v, %v", ret, getRet, err)
+		require.NoError(t, err)
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(ret, getRet) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", ret, getRet)
+		}
+	})
+
+	t.Run("Get retention with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(nil, nil, nil)
+		getRet, err := orgSvc.GetOrgRetention(ctx)
+		t.Logf("getRet, err: %+v, %v", getRet, err)
+		require.Nil(t, getRet)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Get retention with DAO failure", func(t *testing.T) {
+		t.Parallel()
+
+		orger := NewMockOrger(gomock.NewController(t))
+		orger.EXPECT().ReadRetention(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(orger, nil, nil)
+		getRet, err := orgSvc.GetOrgRetention(ctx)
+		t.Logf("getRet, err: %+v, %v", getRet, err)
+		require.Nil(t, getRet)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestUpdateOrgRetention(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Update retention", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		ret := &message.OrgRetention{OrgId: orgID, EventDays: 90}
+
+		orger := NewMockOrger(gomock.NewController(t))
+		orger.EXPECT().UpdateRetention(gomock.Any(),
+			matcher.NewProtoMatcher(ret)).Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(orger, nil, nil)
+		updRet, err := orgSvc.UpdateOrgRetention(ctx, &message.OrgRetention{
+			OrgId: uuid.NewV7().String(), EventDays: 90,
+		})
+		t.Logf("ret, updRet, err: %+v, %+v, %v", ret, updRet, err)
+		require.NoError(t, err)
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(ret, updRet) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", ret, updRet)
+		}
+	})
+
+	t.Run("Update retention with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(nil, nil, nil)
+		updRet, err := orgSvc.UpdateOrgRetention(ctx,
+			&message.OrgRetention{EventDays: 90})
+		t.Logf("updRet, err: %+v, %v", updRet, err)
+		require.Nil(t, updRet)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Update retention with negative days", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(nil, nil, nil)
+		updRet, err := orgSvc.UpdateOrgRetention(ctx,
+			&message.OrgRetention{AlertDays: -1})
+		t.Logf("updRet, err: %+v, %v", updRet, err)
+		require.Nil(t, updRet)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"retention days must not be negative"), err)
+	})
+
+	t.Run("Update retention with DAO failure", func(t *testing.T) {
+		t.Parallel()
+
+		orger := NewMockOrger(gomock.NewController(t))
+		orger.EXPECT().UpdateRetention(gomock.Any(), gomock.Any()).
+			Return(dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(orger, nil, nil)
+		updRet, err := orgSvc.UpdateOrgRetention(ctx,
+			&message.OrgRetention{DataPointDays: 30})
+		t.Logf("updRet, err: %+v, %v", updRet, err)
+		require.Nil(t, updRet)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
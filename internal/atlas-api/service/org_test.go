@@ -9,10 +9,13 @@ import (
 	"uuid"
 
 	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
 	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/cache"
 	"github.com/thingspect/atlas/pkg/dao"
 	"github.com/thingspect/atlas/pkg/test/matcher"
 	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc/codes"
@@ -38,7 +41,7 @@ func TestCreateOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		createOrg, err := orgSvc.CreateOrg(ctx, &api.CreateOrgRequest{Org: org})
 		t.Logf("org, createOrg, err: %+v, %+v, %v", org, createOrg, err)
 		require.NoError(t, err)
@@ -51,7 +54,7 @@ func TestCreateOrg(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		createOrg, err := orgSvc.CreateOrg(ctx, &api.CreateOrgRequest{})
 		t.Logf("createOrg, err: %+v, %v", createOrg, err)
 		require.Nil(t, createOrg)
@@ -66,7 +69,7 @@ func TestCreateOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		createOrg, err := orgSvc.CreateOrg(ctx, &api.CreateOrgRequest{})
 		t.Logf("createOrg, err: %+v, %v", createOrg, err)
 		require.Nil(t, createOrg)
@@ -87,7 +90,7 @@ func TestCreateOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		createOrg, err := orgSvc.CreateOrg(ctx, &api.CreateOrgRequest{Org: org})
 		t.Logf("org, createOrg, err: %+v, %+v, %v", org, createOrg, err)
 		require.Nil(t, createOrg)
@@ -114,7 +117,7 @@ func TestGetOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		getOrg, err := orgSvc.GetOrg(ctx, &api.GetOrgRequest{Id: org.GetId()})
 		t.Logf("org, getOrg, err: %+v, %+v, %v", org, getOrg, err)
 		require.NoError(t, err)
@@ -127,7 +130,7 @@ func TestGetOrg(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		getOrg, err := orgSvc.GetOrg(ctx, &api.GetOrgRequest{})
 		t.Logf("getOrg, err: %+v, %v", getOrg, err)
 		require.Nil(t, getOrg)
@@ -142,7 +145,7 @@ func TestGetOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		getOrg, err := orgSvc.GetOrg(ctx, &api.GetOrgRequest{})
 		t.Logf("getOrg, err: %+v, %v", getOrg, err)
 		require.Nil(t, getOrg)
@@ -161,7 +164,7 @@ func TestGetOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		getOrg, err := orgSvc.GetOrg(ctx,
 			&api.GetOrgRequest{Id: uuid.NewV7().String()})
 		t.Logf("getOrg, err: %+v, %v", getOrg, err)
@@ -188,7 +191,7 @@ func TestUpdateOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		updateOrg, err := orgSvc.UpdateOrg(ctx, &api.UpdateOrgRequest{Org: org})
 		t.Logf("org, updateOrg, err: %+v, %+v, %v", org, updateOrg, err)
 		require.NoError(t, err)
@@ -219,7 +222,7 @@ func TestUpdateOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		updateOrg, err := orgSvc.UpdateOrg(ctx, &api.UpdateOrgRequest{
 			Org:        part,
 			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
@@ -235,7 +238,7 @@ func TestUpdateOrg(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		updateOrg, err := orgSvc.UpdateOrg(ctx, &api.UpdateOrgRequest{})
 		t.Logf("updateOrg, err: %+v, %v", updateOrg, err)
 		require.Nil(t, updateOrg)
@@ -250,7 +253,7 @@ func TestUpdateOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		updateOrg, err := orgSvc.UpdateOrg(ctx, &api.UpdateOrgRequest{})
 		t.Logf("updateOrg, err: %+v, %v", updateOrg, err)
 		require.Nil(t, updateOrg)
@@ -268,7 +271,7 @@ func TestUpdateOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		updateOrg, err := orgSvc.UpdateOrg(ctx, &api.UpdateOrgRequest{Org: org})
 		t.Logf("org, updateOrg, err: %+v, %+v, %v", org, updateOrg, err)
 		require.Nil(t, updateOrg)
@@ -283,7 +286,7 @@ func TestUpdateOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		updateOrg, err := orgSvc.UpdateOrg(ctx,
 			&api.UpdateOrgRequest{Org: random.Org("api-org")})
 		t.Logf("updateOrg, err: %+v, %v", updateOrg, err)
@@ -301,7 +304,7 @@ func TestUpdateOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		updateOrg, err := orgSvc.UpdateOrg(ctx, &api.UpdateOrgRequest{
 			Org: org, UpdateMask: &fieldmaskpb.FieldMask{
 				Paths: []string{random.String(10)},
@@ -328,7 +331,7 @@ func TestUpdateOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		updateOrg, err := orgSvc.UpdateOrg(ctx, &api.UpdateOrgRequest{
 			Org:        part,
 			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
@@ -350,7 +353,7 @@ func TestUpdateOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		updateOrg, err := orgSvc.UpdateOrg(ctx, &api.UpdateOrgRequest{Org: org})
 		t.Logf("org, updateOrg, err: %+v, %+v, %v", org, updateOrg, err)
 		require.Nil(t, updateOrg)
@@ -374,7 +377,7 @@ func TestUpdateOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		updateOrg, err := orgSvc.UpdateOrg(ctx, &api.UpdateOrgRequest{Org: org})
 		t.Logf("org, updateOrg, err: %+v, %+v, %v", org, updateOrg, err)
 		require.Nil(t, updateOrg)
@@ -389,17 +392,27 @@ func TestDeleteOrg(t *testing.T) {
 	t.Run("Delete org by valid ID", func(t *testing.T) {
 		t.Parallel()
 
-		orger := NewMockOrger(gomock.NewController(t))
-		orger.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		orgID := uuid.NewV7().String()
+
+		ctrl := gomock.NewController(t)
+		orger := NewMockOrger(ctrl)
+		orger.EXPECT().MarkDelete(gomock.Any(), orgID).
+			Return(&message.OrgDeletion{OrgId: orgID}, nil).Times(1)
+		offboarder := NewMockOffboarder(ctrl)
+		offboarder.EXPECT().Start(orgID).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
 			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_SYS_ADMIN}),
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
-		_, err := orgSvc.DeleteOrg(ctx,
-			&api.DeleteOrgRequest{Id: uuid.NewV7().String()})
+		c := cache.NewHeap[string]()
+		orgSvc := NewOrg(orger, c, offboarder)
+		_, err := orgSvc.DeleteOrg(ctx, &api.DeleteOrgRequest{Id: orgID})
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		_, err = c.Get(ctx, key.OrgDisabled(orgID))
 		t.Logf("err: %v", err)
 		require.NoError(t, err)
 	})
@@ -410,7 +423,7 @@ func TestDeleteOrg(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		_, err := orgSvc.DeleteOrg(ctx, &api.DeleteOrgRequest{})
 		t.Logf("err: %v", err)
 		require.Equal(t, errPerm(api.Role_SYS_ADMIN), err)
@@ -424,31 +437,140 @@ func TestDeleteOrg(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		_, err := orgSvc.DeleteOrg(ctx, &api.DeleteOrgRequest{})
 		t.Logf("err: %v", err)
 		require.Equal(t, errPerm(api.Role_SYS_ADMIN), err)
 	})
 
+	t.Run("Delete own org", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_SYS_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(nil, nil, nil)
+		_, err := orgSvc.DeleteOrg(ctx, &api.DeleteOrgRequest{Id: orgID})
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"cannot delete own organization"), err)
+	})
+
 	t.Run("Delete org by unknown ID", func(t *testing.T) {
 		t.Parallel()
 
 		orger := NewMockOrger(gomock.NewController(t))
-		orger.EXPECT().Delete(gomock.Any(), gomock.Any()).
-			Return(dao.ErrNotFound).Times(1)
+		orger.EXPECT().MarkDelete(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
 			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_SYS_ADMIN}),
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		_, err := orgSvc.DeleteOrg(ctx,
 			&api.DeleteOrgRequest{Id: uuid.NewV7().String()})
 		t.Logf("err: %v", err)
 		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
 			err)
 	})
+
+	t.Run("Delete org with cacher error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		orger := NewMockOrger(ctrl)
+		orger.EXPECT().MarkDelete(gomock.Any(), gomock.Any()).
+			Return(&message.OrgDeletion{}, nil).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Set(gomock.Any(), gomock.Any(), "").
+			Return(dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_SYS_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(orger, cacher, nil)
+		_, err := orgSvc.DeleteOrg(ctx,
+			&api.DeleteOrgRequest{Id: uuid.NewV7().String()})
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"dao: invalid format"), err)
+	})
+}
+
+func TestGetOrgDeletion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get org deletion by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		del := &message.OrgDeletion{
+			OrgId: uuid.NewV7().String(), Step: "data_points", PurgedRows: 5,
+		}
+		retDel, _ := proto.Clone(del).(*message.OrgDeletion)
+
+		orger := NewMockOrger(gomock.NewController(t))
+		orger.EXPECT().ReadDeletion(gomock.Any(), del.GetOrgId()).
+			Return(retDel, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_SYS_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(orger, nil, nil)
+		getDel, err := orgSvc.GetOrgDeletion(ctx, del.GetOrgId())
+		t.Logf("del, getDel, err: %+v, %+v, %v", del, getDel, err)
+		require.NoError(t, err)
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(del, getDel) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", del, getDel)
+		}
+	})
+
+	t.Run("Get org deletion with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(nil, nil, nil)
+		getDel, err := orgSvc.GetOrgDeletion(ctx, uuid.NewV7().String())
+		t.Logf("getDel, err: %+v, %v", getDel, err)
+		require.Nil(t, getDel)
+		require.Equal(t, errPerm(api.Role_SYS_ADMIN), err)
+	})
+
+	t.Run("Get org deletion by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		orger := NewMockOrger(gomock.NewController(t))
+		orger.EXPECT().ReadDeletion(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_SYS_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		orgSvc := NewOrg(orger, nil, nil)
+		getDel, err := orgSvc.GetOrgDeletion(ctx, uuid.NewV7().String())
+		t.Logf("getDel, err: %+v, %v", getDel, err)
+		require.Nil(t, getDel)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
 }
 
 func TestListOrgs(t *testing.T) {
@@ -474,7 +596,7 @@ func TestListOrgs(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		listOrgs, err := orgSvc.ListOrgs(ctx, &api.ListOrgsRequest{})
 		t.Logf("listOrgs, err: %+v, %v", listOrgs, err)
 		require.NoError(t, err)
@@ -507,7 +629,7 @@ func TestListOrgs(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		listOrgs, err := orgSvc.ListOrgs(ctx, &api.ListOrgsRequest{PageSize: 2})
 		t.Logf("listOrgs, err: %+v, %v", listOrgs, err)
 		require.NoError(t, err)
@@ -523,7 +645,7 @@ func TestListOrgs(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		listOrgs, err := orgSvc.ListOrgs(ctx, &api.ListOrgsRequest{})
 		t.Logf("listOrgs, err: %+v, %v", listOrgs, err)
 		require.Nil(t, listOrgs)
@@ -543,7 +665,7 @@ func TestListOrgs(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		listOrgs, err := orgSvc.ListOrgs(ctx, &api.ListOrgsRequest{})
 		t.Logf("listOrgs, err: %+v, %v", listOrgs, err)
 		require.NoError(t, err)
@@ -566,7 +688,7 @@ func TestListOrgs(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		listOrgs, err := orgSvc.ListOrgs(ctx, &api.ListOrgsRequest{})
 		t.Logf("listOrgs, err: %+v, %v", listOrgs, err)
 		require.Nil(t, listOrgs)
@@ -582,7 +704,7 @@ func TestListOrgs(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(nil)
+		orgSvc := NewOrg(nil, nil, nil)
 		listOrgs, err := orgSvc.ListOrgs(ctx,
 			&api.ListOrgsRequest{PageToken: badUUID})
 		t.Logf("listOrgs, err: %+v, %v", listOrgs, err)
@@ -603,7 +725,7 @@ func TestListOrgs(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		listOrgs, err := orgSvc.ListOrgs(ctx, &api.ListOrgsRequest{})
 		t.Logf("listOrgs, err: %+v, %v", listOrgs, err)
 		require.Nil(t, listOrgs)
@@ -632,7 +754,7 @@ func TestListOrgs(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		orgSvc := NewOrg(orger)
+		orgSvc := NewOrg(orger, nil, nil)
 		listOrgs, err := orgSvc.ListOrgs(ctx, &api.ListOrgsRequest{PageSize: 2})
 		t.Logf("listOrgs, err: %+v, %v", listOrgs, err)
 		require.NoError(t, err)
@@ -4,6 +4,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/thingspect/atlas/internal/atlas-api/session"
 	"github.com/thingspect/atlas/pkg/alog"
 	"github.com/thingspect/atlas/pkg/rule"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -30,21 +32,41 @@ type Ruler interface {
 	Delete(ctx context.Context, ruleID, orgID string) error
 	List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string,
 		limit int32) ([]*api.Rule, int32, error)
+	UpsertCondition(ctx context.Context, cond *message.RuleCondition) (
+		*message.RuleCondition, error)
+	ReadCondition(ctx context.Context, ruleID, orgID string) (
+		*message.RuleCondition, error)
+	DeleteCondition(ctx context.Context, ruleID, orgID string) error
+	UpsertTrigger(ctx context.Context, trig *message.RuleTrigger) (
+		*message.RuleTrigger, error)
+	ReadTrigger(ctx context.Context, ruleID, orgID string) (
+		*message.RuleTrigger, error)
+	DeleteTrigger(ctx context.Context, ruleID, orgID string) error
 }
 
+// maxTestHistory is the maximum count of history data points when testing a
+// rule, which matches the history retained by the eventer.
+const maxTestHistory = rule.MaxHistory
+
 // RuleAlarm service contains functions to query and modify rules and alarms.
+// Rules and alarms are not filtered by scope tags, so are unavailable to tag
+// scoped sessions other than for testing.
 type RuleAlarm struct {
 	api.UnimplementedRuleAlarmServiceServer
 
 	ruleDAO  Ruler
 	alarmDAO Alarmer
+
+	hookKey []byte
 }
 
 // NewRuleAlarm instantiates and returns a new RuleAlarm service.
-func NewRuleAlarm(ruleDAO Ruler, alarmDAO Alarmer) *RuleAlarm {
+func NewRuleAlarm(ruleDAO Ruler, alarmDAO Alarmer, hookKey []byte) *RuleAlarm {
 	return &RuleAlarm{
 		ruleDAO:  ruleDAO,
 		alarmDAO: alarmDAO,
+
+		hookKey: hookKey,
 	}
 }
 
@@ -57,6 +79,10 @@ func (ra *RuleAlarm) CreateRule(
 		return nil, errPerm(api.Role_BUILDER)
 	}
 
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
 	req.Rule.OrgId = sess.OrgID
 
 	rule, err := ra.ruleDAO.Create(ctx, req.GetRule())
@@ -82,6 +108,10 @@ func (ra *RuleAlarm) GetRule(ctx context.Context, req *api.GetRuleRequest) (
 		return nil, errPerm(api.Role_VIEWER)
 	}
 
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
 	rule, err := ra.ruleDAO.Read(ctx, req.GetId(), sess.OrgID)
 	if err != nil {
 		return nil, errToStatus(err)
@@ -100,6 +130,10 @@ func (ra *RuleAlarm) UpdateRule(
 		return nil, errPerm(api.Role_BUILDER)
 	}
 
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
 	if req.GetRule() == nil {
 		return nil, status.Error(codes.InvalidArgument,
 			req.Validate().Error())
@@ -147,6 +181,10 @@ func (ra *RuleAlarm) DeleteRule(
 		return nil, errPerm(api.Role_BUILDER)
 	}
 
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
 	if err := ra.ruleDAO.Delete(ctx, req.GetId(), sess.OrgID); err != nil {
 		return nil, errToStatus(err)
 	}
@@ -169,6 +207,10 @@ func (ra *RuleAlarm) ListRules(ctx context.Context, req *api.ListRulesRequest) (
 		return nil, errPerm(api.Role_VIEWER)
 	}
 
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
 	if req.GetPageSize() == 0 {
 		req.PageSize = defaultPageSize
 	}
@@ -209,6 +251,16 @@ func (ra *RuleAlarm) ListRules(ctx context.Context, req *api.ListRulesRequest) (
 func (ra *RuleAlarm) TestRule(ctx context.Context, req *api.TestRuleRequest) (
 	*api.TestRuleResponse, error,
 ) {
+	return ra.TestRuleHistory(ctx, &message.TestRuleHistoryRequest{
+		Rule: req.GetRule(), Point: req.GetPoint(),
+	})
+}
+
+// TestRuleHistory tests a rule against a data point, along with sample history
+// of its attribute and latest values of other attributes.
+func (ra *RuleAlarm) TestRuleHistory(
+	ctx context.Context, req *message.TestRuleHistoryRequest,
+) (*api.TestRuleResponse, error) {
 	sess, ok := session.FromContext(ctx)
 	if !ok || sess.Role < api.Role_BUILDER {
 		return nil, errPerm(api.Role_BUILDER)
@@ -219,12 +271,20 @@ func (ra *RuleAlarm) TestRule(ctx context.Context, req *api.TestRuleRequest) (
 			"data point and rule attribute mismatch")
 	}
 
+	if len(req.GetHistory()) > maxTestHistory {
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("history must contain at most %d data points",
+				maxTestHistory))
+	}
+
 	// Default to current timestamp if not provided.
 	if req.GetPoint().GetTs() == nil {
 		req.Point.Ts = timestamppb.Now()
 	}
 
-	res, err := rule.Eval(req.GetPoint(), req.GetRule().GetExpr())
+	res, err := rule.Eval(req.GetPoint(), &rule.Env{
+		Latest: req.GetLatest(), History: req.GetHistory(),
+	}, req.GetRule().GetExpr())
 	if err != nil {
 		// Expr does not provide sentinel errors, always consider errors to be
 		// invalid input.
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Constants used for rule condition bounds.
+const (
+	maxCondSamples  = 1000
+	maxCondDuration = 24 * time.Hour
+)
+
+// GetRuleCondition retrieves a rule's condition by rule ID.
+func (ra *RuleAlarm) GetRuleCondition(ctx context.Context, ruleID string) (
+	*message.RuleCondition, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	cond, err := ra.ruleDAO.ReadCondition(ctx, ruleID, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return cond, nil
+}
+
+// UpdateRuleCondition creates or replaces a rule's condition by rule ID. A
+// ForSamples of zero is treated as one.
+func (ra *RuleAlarm) UpdateRuleCondition(
+	ctx context.Context, ruleID string, cond *message.RuleCondition,
+) (*message.RuleCondition, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return nil, errPerm(api.Role_BUILDER)
+	}
+
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	if cond.GetForSamples() == 0 {
+		cond.ForSamples = 1
+	}
+
+	if cond.GetForSamples() < 0 || cond.GetForSamples() > maxCondSamples {
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("samples must be between 1 and %d", maxCondSamples))
+	}
+
+	if d := cond.GetForDuration().AsDuration(); d < 0 ||
+		d > maxCondDuration {
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("duration must be between 0s and %v",
+				maxCondDuration))
+	}
+
+	cond.RuleId = ruleID
+	cond.OrgId = sess.OrgID
+
+	upCond, err := ra.ruleDAO.UpsertCondition(ctx, cond)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return upCond, nil
+}
+
+// DeleteRuleCondition deletes a rule's condition by rule ID. The rule returns
+// to generating an event for each matching data point.
+func (ra *RuleAlarm) DeleteRuleCondition(
+	ctx context.Context, ruleID string,
+) error {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return errPerm(api.Role_BUILDER)
+	}
+
+	if sess.TagScoped() {
+		return errScope()
+	}
+
+	if err := ra.ruleDAO.DeleteCondition(ctx, ruleID,
+		sess.OrgID); err != nil {
+		return errToStatus(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestGetRuleCondition(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get condition by valid rule ID", func(t *testing.T) {
+		t.Parallel()
+
+		cond := &message.RuleCondition{
+			RuleId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+			ForSamples: 3,
+		}
+
+		ruler := NewMockRuler(gomock.NewController(t))
+		ruler.EXPECT().ReadCondition(gomock.Any(), cond.GetRuleId(),
+			cond.GetOrgId()).Return(cond, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: cond.GetOrgId(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(ruler, nil, nil)
+		getCond, err := raSvc.GetRuleCondition(ctx, cond.GetRuleId())
+		t.Logf("getCond, err: %+v, %v", getCond, err)
+		require.NoError(t, err)
+		require.Equal(t, cond, getCond)
+	})
+
+	t.Run("Get condition with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		getCond, err := raSvc.GetRuleCondition(ctx, uuid.NewV7().String())
+		t.Logf("getCond, err: %+v, %v", getCond, err)
+		require.Nil(t, getCond)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("Get condition by unknown rule ID", func(t *testing.T) {
+		t.Parallel()
+
+		ruler := NewMockRuler(gomock.NewController(t))
+		ruler.EXPECT().ReadCondition(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(ruler, nil, nil)
+		getCond, err := raSvc.GetRuleCondition(ctx, uuid.NewV7().String())
+		t.Logf("getCond, err: %+v, %v", getCond, err)
+		require.Nil(t, getCond)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestUpdateRuleCondition(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Update condition by valid rule ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		ruleID := uuid.NewV7().String()
+
+		ruler := NewMockRuler(gomock.NewController(t))
+		ruler.EXPECT().UpsertCondition(gomock.Any(), gomock.Cond(
+			func(inp *message.RuleCondition) bool {
+				return inp.GetRuleId() == ruleID &&
+					inp.GetOrgId() == orgID && inp.GetForSamples() == 1
+			})).DoAndReturn(func(_ any, cond *message.RuleCondition) (
+			*message.RuleCondition, error,
+		) {
+			return cond, nil
+		}).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(ruler, nil, nil)
+		upCond, err := raSvc.UpdateRuleCondition(ctx, ruleID,
+			&message.RuleCondition{
+				ForDuration: durationpb.New(5 * time.Minute),
+				ExitExpr:    `pointVal < 25`,
+			})
+		t.Logf("upCond, err: %+v, %v", upCond, err)
+		require.NoError(t, err)
+		require.Equal(t, `pointVal < 25`, upCond.GetExitExpr())
+	})
+
+	t.Run("Update condition with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		upCond, err := raSvc.UpdateRuleCondition(ctx, uuid.NewV7().String(),
+			nil)
+		t.Logf("upCond, err: %+v, %v", upCond, err)
+		require.Nil(t, upCond)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Update invalid conditions", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			inp *message.RuleCondition
+			err string
+		}{
+			{
+				&message.RuleCondition{ForSamples: -1},
+				"samples must be between 1 and 1000",
+			},
+			{
+				&message.RuleCondition{ForSamples: 1001},
+				"samples must be between 1 and 1000",
+			},
+			{
+				&message.RuleCondition{ForDuration: durationpb.New(-time.Second)},
+				"duration must be between 0s and 24h0m0s",
+			},
+			{
+				&message.RuleCondition{
+					ForDuration: durationpb.New(25 * time.Hour),
+				}, "duration must be between 0s and 24h0m0s",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("Cannot update %+v", test), func(t *testing.T) {
+				t.Parallel()
+
+				ctx, cancel := context.WithTimeout(session.NewContext(
+					t.Context(), &session.Session{
+						OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN,
+					}), testTimeout)
+				defer cancel()
+
+				raSvc := NewRuleAlarm(nil, nil, nil)
+				upCond, err := raSvc.UpdateRuleCondition(ctx,
+					uuid.NewV7().String(), test.inp)
+				t.Logf("upCond, err: %+v, %v", upCond, err)
+				require.Nil(t, upCond)
+				require.Equal(t, status.Error(codes.InvalidArgument, test.err),
+					err)
+			})
+		}
+	})
+
+	t.Run("Update condition by unknown rule ID", func(t *testing.T) {
+		t.Parallel()
+
+		ruler := NewMockRuler(gomock.NewController(t))
+		ruler.EXPECT().UpsertCondition(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(ruler, nil, nil)
+		upCond, err := raSvc.UpdateRuleCondition(ctx, uuid.NewV7().String(),
+			&message.RuleCondition{ForSamples: 2})
+		t.Logf("upCond, err: %+v, %v", upCond, err)
+		require.Nil(t, upCond)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestDeleteRuleCondition(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Delete condition by valid rule ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		ruleID := uuid.NewV7().String()
+
+		ruler := NewMockRuler(gomock.NewController(t))
+		ruler.EXPECT().DeleteCondition(gomock.Any(), ruleID, orgID).
+			Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(ruler, nil, nil)
+		err := raSvc.DeleteRuleCondition(ctx, ruleID)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete condition with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		err := raSvc.DeleteRuleCondition(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Delete condition by unknown rule ID", func(t *testing.T) {
+		t.Parallel()
+
+		ruler := NewMockRuler(gomock.NewController(t))
+		ruler.EXPECT().DeleteCondition(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(ruler, nil, nil)
+		err := raSvc.DeleteRuleCondition(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/thingspect/atlas/pkg/rule"
 	"github.com/thingspect/atlas/pkg/test/matcher"
 	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
 	"github.com/thingspect/proto/go/common"
 	"go.uber.org/mock/gomock"
@@ -42,7 +43,7 @@ func TestCreateRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		createRule, err := raSvc.CreateRule(ctx, &api.CreateRuleRequest{
 			Rule: rule,
 		})
@@ -57,7 +58,7 @@ func TestCreateRule(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		createRule, err := raSvc.CreateRule(ctx, &api.CreateRuleRequest{})
 		t.Logf("createRule, err: %+v, %v", createRule, err)
 		require.Nil(t, createRule)
@@ -72,13 +73,30 @@ func TestCreateRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		createRule, err := raSvc.CreateRule(ctx, &api.CreateRuleRequest{})
 		t.Logf("createRule, err: %+v, %v", createRule, err)
 		require.Nil(t, createRule)
 		require.Equal(t, errPerm(api.Role_BUILDER), err)
 	})
 
+	t.Run("Create rule with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		createRule, err := raSvc.CreateRule(ctx, &api.CreateRuleRequest{})
+		t.Logf("createRule, err: %+v, %v", createRule, err)
+		require.Nil(t, createRule)
+		require.Equal(t, errScope(), err)
+	})
+
 	t.Run("Create invalid rule", func(t *testing.T) {
 		t.Parallel()
 
@@ -94,7 +112,7 @@ func TestCreateRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		createRule, err := raSvc.CreateRule(ctx, &api.CreateRuleRequest{
 			Rule: rule,
 		})
@@ -123,7 +141,7 @@ func TestGetRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		getRule, err := raSvc.GetRule(ctx, &api.GetRuleRequest{Id: rule.GetId()})
 		t.Logf("rule, getRule, err: %+v, %+v, %v", rule, getRule, err)
 		require.NoError(t, err)
@@ -136,7 +154,7 @@ func TestGetRule(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		getRule, err := raSvc.GetRule(ctx, &api.GetRuleRequest{})
 		t.Logf("getRule, err: %+v, %v", getRule, err)
 		require.Nil(t, getRule)
@@ -151,7 +169,7 @@ func TestGetRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		getRule, err := raSvc.GetRule(ctx, &api.GetRuleRequest{})
 		t.Logf("getRule, err: %+v, %v", getRule, err)
 		require.Nil(t, getRule)
@@ -170,7 +188,7 @@ func TestGetRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		getRule, err := raSvc.GetRule(ctx, &api.GetRuleRequest{
 			Id: uuid.NewV7().String(),
 		})
@@ -198,7 +216,7 @@ func TestUpdateRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		updateRule, err := raSvc.UpdateRule(ctx, &api.UpdateRuleRequest{
 			Rule: rule,
 		})
@@ -234,7 +252,7 @@ func TestUpdateRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		updateRule, err := raSvc.UpdateRule(ctx, &api.UpdateRuleRequest{
 			Rule: part, UpdateMask: &fieldmaskpb.FieldMask{
 				Paths: []string{"status", "expr"},
@@ -251,7 +269,7 @@ func TestUpdateRule(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		updateRule, err := raSvc.UpdateRule(ctx, &api.UpdateRuleRequest{})
 		t.Logf("updateRule, err: %+v, %v", updateRule, err)
 		require.Nil(t, updateRule)
@@ -266,7 +284,7 @@ func TestUpdateRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		updateRule, err := raSvc.UpdateRule(ctx, &api.UpdateRuleRequest{})
 		t.Logf("updateRule, err: %+v, %v", updateRule, err)
 		require.Nil(t, updateRule)
@@ -281,7 +299,7 @@ func TestUpdateRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		updateRule, err := raSvc.UpdateRule(ctx, &api.UpdateRuleRequest{
 			Rule: nil,
 		})
@@ -301,7 +319,7 @@ func TestUpdateRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		updateRule, err := raSvc.UpdateRule(ctx, &api.UpdateRuleRequest{
 			Rule: rule, UpdateMask: &fieldmaskpb.FieldMask{
 				Paths: []string{"aaa"},
@@ -327,7 +345,7 @@ func TestUpdateRule(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		updateRule, err := raSvc.UpdateRule(ctx, &api.UpdateRuleRequest{
 			Rule: part, UpdateMask: &fieldmaskpb.FieldMask{
 				Paths: []string{"status"},
@@ -350,7 +368,7 @@ func TestUpdateRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		updateRule, err := raSvc.UpdateRule(ctx, &api.UpdateRuleRequest{
 			Rule: rule,
 		})
@@ -376,7 +394,7 @@ func TestUpdateRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		updateRule, err := raSvc.UpdateRule(ctx, &api.UpdateRuleRequest{
 			Rule: rule,
 		})
@@ -402,7 +420,7 @@ func TestDeleteRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		_, err := raSvc.DeleteRule(ctx, &api.DeleteRuleRequest{
 			Id: uuid.NewV7().String(),
 		})
@@ -416,7 +434,7 @@ func TestDeleteRule(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		_, err := raSvc.DeleteRule(ctx, &api.DeleteRuleRequest{})
 		t.Logf("err: %v", err)
 		require.Equal(t, errPerm(api.Role_BUILDER), err)
@@ -430,7 +448,7 @@ func TestDeleteRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		_, err := raSvc.DeleteRule(ctx, &api.DeleteRuleRequest{})
 		t.Logf("err: %v", err)
 		require.Equal(t, errPerm(api.Role_BUILDER), err)
@@ -448,7 +466,7 @@ func TestDeleteRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		_, err := raSvc.DeleteRule(ctx, &api.DeleteRuleRequest{
 			Id: uuid.NewV7().String(),
 		})
@@ -480,7 +498,7 @@ func TestListRules(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		listRules, err := raSvc.ListRules(ctx, &api.ListRulesRequest{})
 		t.Logf("listRules, err: %+v, %v", listRules, err)
 		require.NoError(t, err)
@@ -512,7 +530,7 @@ func TestListRules(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		listRules, err := raSvc.ListRules(ctx, &api.ListRulesRequest{
 			PageSize: 2,
 		})
@@ -530,7 +548,7 @@ func TestListRules(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		listRules, err := raSvc.ListRules(ctx, &api.ListRulesRequest{})
 		t.Logf("listRules, err: %+v, %v", listRules, err)
 		require.Nil(t, listRules)
@@ -545,7 +563,7 @@ func TestListRules(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		listRules, err := raSvc.ListRules(ctx, &api.ListRulesRequest{})
 		t.Logf("listRules, err: %+v, %v", listRules, err)
 		require.Nil(t, listRules)
@@ -560,7 +578,7 @@ func TestListRules(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		listRules, err := raSvc.ListRules(ctx, &api.ListRulesRequest{
 			PageToken: badUUID,
 		})
@@ -581,7 +599,7 @@ func TestListRules(t *testing.T) {
 			&session.Session{OrgID: "aaa", Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		listRules, err := raSvc.ListRules(ctx, &api.ListRulesRequest{})
 		t.Logf("listRules, err: %+v, %v", listRules, err)
 		require.Nil(t, listRules)
@@ -609,7 +627,7 @@ func TestListRules(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(ruler, nil)
+		raSvc := NewRuleAlarm(ruler, nil, nil)
 		listRules, err := raSvc.ListRules(ctx, &api.ListRulesRequest{
 			PageSize: 2,
 		})
@@ -692,7 +710,7 @@ func TestTestRule(t *testing.T) {
 					testTimeout)
 				defer cancel()
 
-				raSvc := NewRuleAlarm(nil, nil)
+				raSvc := NewRuleAlarm(nil, nil, nil)
 				testRes, err := raSvc.TestRule(ctx, &api.TestRuleRequest{
 					Point: test.inpPoint, Rule: &api.Rule{
 						Attr: test.inpPoint.GetAttr(), Expr: test.inpRuleExpr,
@@ -717,7 +735,7 @@ func TestTestRule(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		testRes, err := raSvc.TestRule(ctx, &api.TestRuleRequest{})
 		t.Logf("testRes, err: %+v, %v", testRes, err)
 		require.Nil(t, testRes)
@@ -732,7 +750,7 @@ func TestTestRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		testRes, err := raSvc.TestRule(ctx, &api.TestRuleRequest{})
 		t.Logf("testRes, err: %+v, %v", testRes, err)
 		require.Nil(t, testRes)
@@ -750,7 +768,7 @@ func TestTestRule(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		raSvc := NewRuleAlarm(nil, nil)
+		raSvc := NewRuleAlarm(nil, nil, nil)
 		testRes, err := raSvc.TestRule(ctx, &api.TestRuleRequest{
 			Point: point, Rule: rule,
 		})
@@ -760,3 +778,111 @@ func TestTestRule(t *testing.T) {
 			"data point and rule attribute mismatch"), err)
 	})
 }
+
+func TestTestRuleHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Test rules with history and latest", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		attr := "api-rule" + random.String(10)
+
+		point := &common.DataPoint{
+			Attr: attr, ValOneof: &common.DataPoint_IntVal{IntVal: 30},
+			Ts: timestamppb.New(now),
+		}
+		history := []*common.DataPoint{
+			{
+				Attr: attr, ValOneof: &common.DataPoint_IntVal{IntVal: 20},
+				Ts: timestamppb.New(now.Add(-2 * time.Minute)),
+			},
+			{
+				Attr: attr, ValOneof: &common.DataPoint_IntVal{IntVal: 10},
+				Ts: timestamppb.New(now.Add(-10 * time.Minute)),
+			},
+		}
+		latest := map[string]*common.DataPoint{
+			"api-rule-door": {ValOneof: &common.DataPoint_BoolVal{
+				BoolVal: true,
+			}},
+		}
+
+		tests := []struct {
+			inpRuleExpr string
+			res         bool
+			err         string
+		}{
+			{`avg("5m") == 25 && countOver("15m") == 3`, true, ""},
+			{`delta() == 10 && rate() == 5`, true, ""},
+			{`latest["api-rule-door"].val && maxOver("1h") == 30`, true, ""},
+			{`minOver("5m") < 20`, false, ""},
+			{`avg("48h") > 0`, false, "window must be"},
+		}
+
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("Can evaluate %+v", test), func(t *testing.T) {
+				t.Parallel()
+
+				ctx, cancel := context.WithTimeout(session.NewContext(
+					t.Context(), &session.Session{
+						OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER,
+					}), testTimeout)
+				defer cancel()
+
+				raSvc := NewRuleAlarm(nil, nil, nil)
+				testRes, err := raSvc.TestRuleHistory(ctx,
+					&message.TestRuleHistoryRequest{
+						Rule:  &api.Rule{Attr: attr, Expr: test.inpRuleExpr},
+						Point: point, History: history, Latest: latest,
+					})
+				t.Logf("testRes, err: %+v, %v", testRes, err)
+				if test.err == "" {
+					require.Equal(t, test.res, testRes.GetResult())
+					require.NoError(t, err)
+				} else {
+					require.Nil(t, testRes)
+					require.Equal(t, codes.InvalidArgument, status.Code(err))
+					require.Contains(t, err.Error(), test.err)
+				}
+			})
+		}
+	})
+
+	t.Run("Test rule with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		testRes, err := raSvc.TestRuleHistory(ctx,
+			&message.TestRuleHistoryRequest{})
+		t.Logf("testRes, err: %+v, %v", testRes, err)
+		require.Nil(t, testRes)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Test rule with too much history", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		testRes, err := raSvc.TestRuleHistory(ctx,
+			&message.TestRuleHistoryRequest{
+				Rule:    &api.Rule{Expr: rule.ExprTrue},
+				Point:   &common.DataPoint{},
+				History: make([]*common.DataPoint, maxTestHistory+1),
+			})
+		t.Logf("testRes, err: %+v, %v", testRes, err)
+		require.Nil(t, testRes)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"history must contain at most 1440 data points"), err)
+	})
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Constants used for rule trigger bounds, which match the database schema.
+const (
+	maxTrigAttrs   = 20
+	maxTrigAttrLen = 40
+)
+
+// GetRuleTrigger retrieves a rule's trigger by rule ID.
+func (ra *RuleAlarm) GetRuleTrigger(ctx context.Context, ruleID string) (
+	*message.RuleTrigger, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	trig, err := ra.ruleDAO.ReadTrigger(ctx, ruleID, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return trig, nil
+}
+
+// UpdateRuleTrigger creates or replaces a rule's trigger by rule ID. The rule
+// is evaluated on data points of its own attribute and of each trigger
+// attribute.
+func (ra *RuleAlarm) UpdateRuleTrigger(
+	ctx context.Context, ruleID string, trig *message.RuleTrigger,
+) (*message.RuleTrigger, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return nil, errPerm(api.Role_BUILDER)
+	}
+
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	if len(trig.GetAttrs()) == 0 || len(trig.GetAttrs()) > maxTrigAttrs {
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("attrs must contain between 1 and %d attributes",
+				maxTrigAttrs))
+	}
+
+	for _, attr := range trig.GetAttrs() {
+		if attr == "" || len(attr) > maxTrigAttrLen {
+			return nil, status.Error(codes.InvalidArgument,
+				fmt.Sprintf("attrs must be between 1 and %d characters",
+					maxTrigAttrLen))
+		}
+	}
+
+	trig.RuleId = ruleID
+	trig.OrgId = sess.OrgID
+
+	upTrig, err := ra.ruleDAO.UpsertTrigger(ctx, trig)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return upTrig, nil
+}
+
+// DeleteRuleTrigger deletes a rule's trigger by rule ID. The rule returns to
+// being evaluated only on data points of its own attribute.
+func (ra *RuleAlarm) DeleteRuleTrigger(
+	ctx context.Context, ruleID string,
+) error {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return errPerm(api.Role_BUILDER)
+	}
+
+	if sess.TagScoped() {
+		return errScope()
+	}
+
+	if err := ra.ruleDAO.DeleteTrigger(ctx, ruleID,
+		sess.OrgID); err != nil {
+		return errToStatus(err)
+	}
+
+	return nil
+}
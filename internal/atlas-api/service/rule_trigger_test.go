@@ -0,0 +1,264 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetRuleTrigger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get trigger by valid rule ID", func(t *testing.T) {
+		t.Parallel()
+
+		trig := &message.RuleTrigger{
+			RuleId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+			Attrs: []string{"api-rule-" + random.String(10)},
+		}
+
+		ruler := NewMockRuler(gomock.NewController(t))
+		ruler.EXPECT().ReadTrigger(gomock.Any(), trig.GetRuleId(),
+			trig.GetOrgId()).Return(trig, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: trig.GetOrgId(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(ruler, nil, nil)
+		getTrig, err := raSvc.GetRuleTrigger(ctx, trig.GetRuleId())
+		t.Logf("getTrig, err: %+v, %v", getTrig, err)
+		require.NoError(t, err)
+		require.Equal(t, trig, getTrig)
+	})
+
+	t.Run("Get trigger with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		getTrig, err := raSvc.GetRuleTrigger(ctx, uuid.NewV7().String())
+		t.Logf("getTrig, err: %+v, %v", getTrig, err)
+		require.Nil(t, getTrig)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("Get trigger by unknown rule ID", func(t *testing.T) {
+		t.Parallel()
+
+		ruler := NewMockRuler(gomock.NewController(t))
+		ruler.EXPECT().ReadTrigger(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(ruler, nil, nil)
+		getTrig, err := raSvc.GetRuleTrigger(ctx, uuid.NewV7().String())
+		t.Logf("getTrig, err: %+v, %v", getTrig, err)
+		require.Nil(t, getTrig)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestUpdateRuleTrigger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Update trigger by valid rule ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		ruleID := uuid.NewV7().String()
+		attrs := []string{"api-rule-" + random.String(10)}
+
+		ruler := NewMockRuler(gomock.NewController(t))
+		ruler.EXPECT().UpsertTrigger(gomock.Any(), gomock.Cond(
+			func(inp *message.RuleTrigger) bool {
+				return inp.GetRuleId() == ruleID && inp.GetOrgId() == orgID
+			})).DoAndReturn(func(_ any, trig *message.RuleTrigger) (
+			*message.RuleTrigger, error,
+		) {
+			return trig, nil
+		}).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(ruler, nil, nil)
+		upTrig, err := raSvc.UpdateRuleTrigger(ctx, ruleID,
+			&message.RuleTrigger{Attrs: attrs})
+		t.Logf("upTrig, err: %+v, %v", upTrig, err)
+		require.NoError(t, err)
+		require.Equal(t, attrs, upTrig.GetAttrs())
+	})
+
+	t.Run("Update trigger with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		upTrig, err := raSvc.UpdateRuleTrigger(ctx, uuid.NewV7().String(),
+			nil)
+		t.Logf("upTrig, err: %+v, %v", upTrig, err)
+		require.Nil(t, upTrig)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Update invalid triggers", func(t *testing.T) {
+		t.Parallel()
+
+		tooMany := make([]string, 21)
+		for i := range tooMany {
+			tooMany[i] = "api-rule-" + random.String(10)
+		}
+
+		tests := []struct {
+			inp *message.RuleTrigger
+			err string
+		}{
+			{
+				&message.RuleTrigger{},
+				"attrs must contain between 1 and 20 attributes",
+			},
+			{
+				&message.RuleTrigger{Attrs: tooMany},
+				"attrs must contain between 1 and 20 attributes",
+			},
+			{
+				&message.RuleTrigger{Attrs: []string{""}},
+				"attrs must be between 1 and 40 characters",
+			},
+			{
+				&message.RuleTrigger{Attrs: []string{strings.Repeat("a", 41)}},
+				"attrs must be between 1 and 40 characters",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("Cannot update %+v", test), func(t *testing.T) {
+				t.Parallel()
+
+				ctx, cancel := context.WithTimeout(session.NewContext(
+					t.Context(), &session.Session{
+						OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN,
+					}), testTimeout)
+				defer cancel()
+
+				raSvc := NewRuleAlarm(nil, nil, nil)
+				upTrig, err := raSvc.UpdateRuleTrigger(ctx,
+					uuid.NewV7().String(), test.inp)
+				t.Logf("upTrig, err: %+v, %v", upTrig, err)
+				require.Nil(t, upTrig)
+				require.Equal(t, status.Error(codes.InvalidArgument, test.err),
+					err)
+			})
+		}
+	})
+
+	t.Run("Update trigger by unknown rule ID", func(t *testing.T) {
+		t.Parallel()
+
+		ruler := NewMockRuler(gomock.NewController(t))
+		ruler.EXPECT().UpsertTrigger(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(ruler, nil, nil)
+		upTrig, err := raSvc.UpdateRuleTrigger(ctx, uuid.NewV7().String(),
+			&message.RuleTrigger{Attrs: []string{"api-rule-aaa"}})
+		t.Logf("upTrig, err: %+v, %v", upTrig, err)
+		require.Nil(t, upTrig)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestDeleteRuleTrigger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Delete trigger by valid rule ID", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		ruleID := uuid.NewV7().String()
+
+		ruler := NewMockRuler(gomock.NewController(t))
+		ruler.EXPECT().DeleteTrigger(gomock.Any(), ruleID, orgID).
+			Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(ruler, nil, nil)
+		err := raSvc.DeleteRuleTrigger(ctx, ruleID)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete trigger with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(nil, nil, nil)
+		err := raSvc.DeleteRuleTrigger(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Delete trigger by unknown rule ID", func(t *testing.T) {
+		t.Parallel()
+
+		ruler := NewMockRuler(gomock.NewController(t))
+		ruler.EXPECT().DeleteTrigger(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		raSvc := NewRuleAlarm(ruler, nil, nil)
+		err := raSvc.DeleteRuleTrigger(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
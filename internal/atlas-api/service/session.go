@@ -13,6 +13,7 @@ import (
 	"github.com/thingspect/atlas/internal/atlas-api/session"
 	"github.com/thingspect/atlas/pkg/alog"
 	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -27,6 +28,10 @@ const errUnauth = "unauthorized"
 // Keyer defines the methods provided by a key.DAO.
 type Keyer interface {
 	Create(ctx context.Context, key *api.Key) (*api.Key, error)
+	CreateRestricted(ctx context.Context, key *api.Key,
+		res *message.KeyRestriction) (*api.Key, *message.KeyRestriction, error)
+	ReadRestriction(ctx context.Context, keyID, orgID string) (
+		*message.KeyRestriction, error)
 	Delete(ctx context.Context, keyID, orgID string) error
 	List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string,
 		limit int32) ([]*api.Key, int32, error)
@@ -59,8 +64,36 @@ func NewSession(
 // Login logs in a user.
 func (s *Session) Login(ctx context.Context, req *api.LoginRequest) (
 	*api.LoginResponse, error,
+) {
+	tokens, err := s.login(ctx, req, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.LoginResponse{
+		Token: tokens.GetToken(), ExpiresAt: tokens.GetExpiresAt(),
+	}, nil
+}
+
+// authenticate authenticates a user by email, organization name, and password,
+// and returns the count of failed logins that preceded it. Failures are not
+// cleared until the login is complete.
+func (s *Session) authenticate(ctx context.Context, req *api.LoginRequest) (
+	*api.User, int64, error,
 ) {
 	logger := alog.FromContext(ctx)
+	ip := clientIP(ctx)
+
+	// Hash the provided password if locked out to prevent account enumeration
+	// attacks.
+	locked, failures := s.lockedOut(ctx, req.GetOrgName(), req.GetEmail(), ip)
+	if locked {
+		_, hashErr := auth.HashPass(req.GetPassword())
+		logger.Debugf("authenticate s.lockedOut Email, OrgName, IP, hashErr: "+
+			"%v, %v, %v, %v", req.GetEmail(), req.GetOrgName(), ip, hashErr)
+
+		return nil, 0, status.Error(codes.Unauthenticated, errUnauth)
+	}
 
 	user, hash, err := s.userDAO.ReadByEmail(ctx, req.GetEmail(),
 		req.GetOrgName())
@@ -68,11 +101,12 @@ func (s *Session) Login(ctx context.Context, req *api.LoginRequest) (
 	// enumeration attacks.
 	if err != nil {
 		_, hashErr := auth.HashPass(req.GetPassword())
-		logger.Debugf("Login s.userDAO.ReadByEmail Email, OrgName, err, "+
-			"hashErr: %v, %v, %v, %v", req.GetEmail(), req.GetOrgName(), err,
-			hashErr)
+		logger.Debugf("authenticate s.userDAO.ReadByEmail Email, OrgName, "+
+			"err, hashErr: %v, %v, %v, %v", req.GetEmail(), req.GetOrgName(),
+			err, hashErr)
+		s.failLogin(ctx, req.GetOrgName(), req.GetEmail(), ip)
 
-		return nil, status.Error(codes.Unauthenticated, errUnauth)
+		return nil, 0, status.Error(codes.Unauthenticated, errUnauth)
 	}
 
 	logger.Logger = logger.WithField("userID", user.GetId()).WithField("orgID",
@@ -81,20 +115,14 @@ func (s *Session) Login(ctx context.Context, req *api.LoginRequest) (
 	if err := auth.CompareHashPass(hash, req.GetPassword()); err != nil ||
 		user.GetStatus() != api.Status_ACTIVE ||
 		user.GetRole() < api.Role_VIEWER {
-		logger.Debugf("Login crypto.CompareHashPass err, user.Status: %v, %s",
-			err, user.GetStatus())
-
-		return nil, status.Error(codes.Unauthenticated, errUnauth)
-	}
+		logger.Debugf("authenticate crypto.CompareHashPass err, user.Status: "+
+			"%v, %s", err, user.GetStatus())
+		s.failLogin(ctx, req.GetOrgName(), req.GetEmail(), ip)
 
-	token, exp, err := session.GenerateWebToken(s.pwtKey, user)
-	if err != nil {
-		logger.Errorf("Login session.GenerateWebToken: %v", err)
-
-		return nil, status.Error(codes.Unauthenticated, errUnauth)
+		return nil, 0, status.Error(codes.Unauthenticated, errUnauth)
 	}
 
-	return &api.LoginResponse{Token: token, ExpiresAt: exp}, nil
+	return user, failures, nil
 }
 
 // CreateKey creates an API key.
@@ -114,6 +142,12 @@ func (s *Session) CreateKey(ctx context.Context, req *api.CreateKeyRequest) (
 			"permission denied, role modification not allowed")
 	}
 
+	// Keys created by tag scoped sessions must carry scope tags, which are
+	// only supported by restricted keys.
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
 	req.Key.OrgId = sess.OrgID
 
 	key, err := s.keyDAO.Create(ctx, req.GetKey())
@@ -10,10 +10,12 @@ import (
 	"uuid"
 
 	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
 	"github.com/thingspect/atlas/internal/atlas-api/session"
 	"github.com/thingspect/atlas/pkg/cache"
 	"github.com/thingspect/atlas/pkg/dao"
 	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc/codes"
@@ -35,6 +37,14 @@ func TestLogin(t *testing.T) {
 		userer := NewMockUserer(gomock.NewController(t))
 		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(), org.GetName()).
 			Return(user, globalHash, nil).Times(1)
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), org.GetId()).
+			Return(&message.UserMFA{}, nil, nil).Times(1)
+		userer.EXPECT().ReadScope(gomock.Any(), user.GetId(), org.GetId()).
+			Return(nil, dao.ErrNotFound).Times(1)
+		userer.EXPECT().CreateSession(gomock.Any(), user.GetId(), org.GetId(),
+			gomock.Any(), gomock.Any()).Return(&message.UserSession{
+			Id: uuid.NewV7().String(),
+		}, nil).Times(1)
 
 		pwtKey := make([]byte, 32)
 		_, err := rand.Read(pwtKey)
@@ -43,7 +53,7 @@ func TestLogin(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		sessSvc := NewSession(userer, nil, nil, pwtKey)
+		sessSvc := NewSession(userer, nil, cache.NewHeap[string](), pwtKey)
 		loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
 			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
 		})
@@ -63,9 +73,16 @@ func TestLogin(t *testing.T) {
 		user := random.User("api-session", org.GetId())
 		user.Status = api.Status_ACTIVE
 
-		userer := NewMockUserer(gomock.NewController(t))
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
 		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
 			org.GetName()).Return(nil, nil, dao.ErrNotFound).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			user.GetEmail())).Return("", cache.ErrNotFound).Times(1)
+		cacher.EXPECT().IncrTTL(gomock.Any(), key.LoginFailures(
+			org.GetName(), user.GetEmail()), loginFailWindow).
+			Return(int64(1), nil).Times(1)
 
 		pwtKey := make([]byte, 32)
 		_, err := rand.Read(pwtKey)
@@ -74,7 +91,7 @@ func TestLogin(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		sessSvc := NewSession(userer, nil, nil, pwtKey)
+		sessSvc := NewSession(userer, nil, cacher, pwtKey)
 		loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
 			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
 		})
@@ -90,9 +107,16 @@ func TestLogin(t *testing.T) {
 		user := random.User("api-session", org.GetId())
 		user.Status = api.Status_ACTIVE
 
-		userer := NewMockUserer(gomock.NewController(t))
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
 		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
 			org.GetName()).Return(user, globalHash, nil).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			user.GetEmail())).Return("", cache.ErrNotFound).Times(1)
+		cacher.EXPECT().IncrTTL(gomock.Any(), key.LoginFailures(
+			org.GetName(), user.GetEmail()), loginFailWindow).
+			Return(int64(1), nil).Times(1)
 
 		pwtKey := make([]byte, 32)
 		_, err := rand.Read(pwtKey)
@@ -101,7 +125,7 @@ func TestLogin(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		sessSvc := NewSession(userer, nil, nil, pwtKey)
+		sessSvc := NewSession(userer, nil, cacher, pwtKey)
 		loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
 			Email: user.GetEmail(), OrgName: org.GetName(),
 			Password: random.String(10),
@@ -118,9 +142,16 @@ func TestLogin(t *testing.T) {
 		user := random.User("api-session", org.GetId())
 		user.Status = api.Status_DISABLED
 
-		userer := NewMockUserer(gomock.NewController(t))
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
 		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(), org.GetName()).
 			Return(user, globalHash, nil).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			user.GetEmail())).Return("", cache.ErrNotFound).Times(1)
+		cacher.EXPECT().IncrTTL(gomock.Any(), key.LoginFailures(
+			org.GetName(), user.GetEmail()), loginFailWindow).
+			Return(int64(1), nil).Times(1)
 
 		pwtKey := make([]byte, 32)
 		_, err := rand.Read(pwtKey)
@@ -129,7 +160,7 @@ func TestLogin(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		sessSvc := NewSession(userer, nil, nil, pwtKey)
+		sessSvc := NewSession(userer, nil, cacher, pwtKey)
 		loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
 			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
 		})
@@ -146,9 +177,16 @@ func TestLogin(t *testing.T) {
 		user.Role = api.Role_CONTACT
 		user.Status = api.Status_ACTIVE
 
-		userer := NewMockUserer(gomock.NewController(t))
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
 		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
 			org.GetName()).Return(user, globalHash, nil).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			user.GetEmail())).Return("", cache.ErrNotFound).Times(1)
+		cacher.EXPECT().IncrTTL(gomock.Any(), key.LoginFailures(
+			org.GetName(), user.GetEmail()), loginFailWindow).
+			Return(int64(1), nil).Times(1)
 
 		pwtKey := make([]byte, 32)
 		_, err := rand.Read(pwtKey)
@@ -157,7 +195,7 @@ func TestLogin(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		sessSvc := NewSession(userer, nil, nil, pwtKey)
+		sessSvc := NewSession(userer, nil, cacher, pwtKey)
 		loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
 			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
 		})
@@ -177,11 +215,19 @@ func TestLogin(t *testing.T) {
 		userer := NewMockUserer(gomock.NewController(t))
 		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
 			org.GetName()).Return(user, globalHash, nil).Times(1)
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), org.GetId()).
+			Return(&message.UserMFA{}, nil, nil).Times(1)
+		userer.EXPECT().ReadScope(gomock.Any(), user.GetId(), org.GetId()).
+			Return(nil, dao.ErrNotFound).Times(1)
+		userer.EXPECT().CreateSession(gomock.Any(), user.GetId(), org.GetId(),
+			gomock.Any(), gomock.Any()).Return(&message.UserSession{
+			Id: uuid.NewV7().String(),
+		}, nil).Times(1)
 
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		sessSvc := NewSession(userer, nil, nil, nil)
+		sessSvc := NewSession(userer, nil, cache.NewHeap[string](), nil)
 		loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
 			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
 		})
@@ -271,6 +317,26 @@ func TestCreateKey(t *testing.T) {
 			"denied, role modification not allowed"), err)
 	})
 
+	t.Run("Create key with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		key := random.Key("api-key", uuid.NewV7().String())
+		key.Role = api.Role_VIEWER
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: key.GetOrgId(), Role: api.Role_ADMIN,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		keySvc := NewSession(nil, nil, nil, nil)
+		createKey, err := keySvc.CreateKey(ctx, &api.CreateKeyRequest{Key: key})
+		t.Logf("key, createKey, err: %+v, %+v, %v", key, createKey, err)
+		require.Nil(t, createKey)
+		require.Equal(t, errScope(), err)
+	})
+
 	t.Run("Create invalid key", func(t *testing.T) {
 		t.Parallel()
 
@@ -314,7 +380,7 @@ func TestCreateKey(t *testing.T) {
 		t.Logf("key, createKey, err: %+v, %+v, %v", key, createKey, err)
 		require.Nil(t, createKey)
 		require.Equal(t, status.Error(codes.Unknown,
-			"auth: incorrect key length"), err)
+			"crypt: incorrect key length"), err)
 	})
 }
 
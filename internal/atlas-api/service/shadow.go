@@ -0,0 +1,227 @@
+package service
+
+//go:generate mockgen -source shadow.go -destination mock_shadower_test.go -package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/metric"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Constants used for shadow behavior.
+const (
+	maxDesiredAttrs = 100
+	reportedWindow  = 30 * 24 * time.Hour
+)
+
+// Shadower defines the methods provided by a shadow.DAO.
+type Shadower interface {
+	ReadDesired(ctx context.Context, devID, orgID string) (*message.Shadow,
+		error)
+	UpdateDesired(ctx context.Context, shadow *message.Shadow) (
+		*message.Shadow, error)
+}
+
+// Shadow service contains functions to query and modify device shadows.
+type Shadow struct {
+	shadowDAO Shadower
+	devDAO    Devicer
+	dpDAO     DataPointer
+	mqttQueue queue.Queuer
+}
+
+// NewShadow instantiates and returns a new Shadow service.
+func NewShadow(
+	shadowDAO Shadower, devDAO Devicer, dpDAO DataPointer,
+	mqttQueue queue.Queuer,
+) *Shadow {
+	return &Shadow{
+		shadowDAO: shadowDAO,
+		devDAO:    devDAO,
+		dpDAO:     dpDAO,
+		mqttQueue: mqttQueue,
+	}
+}
+
+// GetShadow retrieves a device's shadow by device ID.
+func (s *Shadow) GetShadow(ctx context.Context, devID string) (
+	*message.Shadow, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_VIEWER {
+		return nil, errPerm(api.Role_VIEWER)
+	}
+
+	dev, err := readScoped(ctx, s.devDAO, sess, "", devID)
+	if err != nil {
+		return nil, err
+	}
+
+	shadow, err := s.build(ctx, dev, nil)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return shadow, nil
+}
+
+// UpdateShadowDesired replaces a device's desired state by device ID. The
+// resulting shadow is published to the device on 'v1/:orgID/:uniqID/shadow'.
+func (s *Shadow) UpdateShadowDesired(
+	ctx context.Context, devID string, desired *structpb.Struct,
+) (*message.Shadow, error) {
+	logger := alog.FromContext(ctx)
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_BUILDER {
+		return nil, errPerm(api.Role_BUILDER)
+	}
+
+	if desired == nil {
+		desired = &structpb.Struct{}
+	}
+
+	if len(desired.GetFields()) > maxDesiredAttrs {
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("maximum of %d desired attributes exceeded",
+				maxDesiredAttrs))
+	}
+
+	dev, err := readScoped(ctx, s.devDAO, sess, "", devID)
+	if err != nil {
+		return nil, err
+	}
+
+	updShadow, err := s.shadowDAO.UpdateDesired(ctx, &message.Shadow{
+		DevId: dev.GetId(), OrgId: dev.GetOrgId(), Desired: desired,
+	})
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	shadow, err := s.build(ctx, dev, updShadow)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	// Desired state is persisted, so a failed publish is recoverable by the
+	// device fetching its shadow.
+	bShadow, err := proto.Marshal(shadow)
+	if err == nil {
+		err = s.mqttQueue.Publish(fmt.Sprintf("v1/%s/%s/shadow",
+			shadow.GetOrgId(), shadow.GetUniqId()), bShadow)
+	}
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "shadow"})
+		logger.Errorf("UpdateShadowDesired publish: %v", err)
+	}
+
+	return shadow, nil
+}
+
+// DeviceShadow retrieves a device's shadow by unique ID, authenticated by
+// device token rather than session.
+func (s *Shadow) DeviceShadow(ctx context.Context, uniqID, token string) (
+	*message.Shadow, error,
+) {
+	dev, err := s.devDAO.ReadByUniqID(ctx, uniqID)
+	if err != nil && !errors.Is(err, dao.ErrNotFound) {
+		return nil, errToStatus(err)
+	}
+
+	// Do not reveal whether a device exists.
+	if dev == nil || dev.GetStatus() != api.Status_ACTIVE ||
+		subtle.ConstantTimeCompare([]byte(dev.GetToken()),
+			[]byte(token)) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+
+	shadow, err := s.build(ctx, dev, nil)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return shadow, nil
+}
+
+// build builds a device's shadow from its desired state and latest data
+// points. If desired is nil, it is read from the database.
+func (s *Shadow) build(
+	ctx context.Context, dev *api.Device, desired *message.Shadow,
+) (*message.Shadow, error) {
+	if desired == nil {
+		var err error
+		desired, err = s.shadowDAO.ReadDesired(ctx, dev.GetId(),
+			dev.GetOrgId())
+		if errors.Is(err, dao.ErrNotFound) {
+			desired = &message.Shadow{Desired: &structpb.Struct{}}
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	points, err := s.dpDAO.Latest(ctx, dev.GetOrgId(), "", dev.GetId(),
+		time.Now().UTC().Add(-reportedWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	shadow := &message.Shadow{
+		DevId:     dev.GetId(),
+		OrgId:     dev.GetOrgId(),
+		UniqId:    dev.GetUniqId(),
+		Desired:   desired.GetDesired(),
+		Reported:  &structpb.Struct{Fields: map[string]*structpb.Value{}},
+		Delta:     &structpb.Struct{Fields: map[string]*structpb.Value{}},
+		Version:   desired.GetVersion(),
+		UpdatedAt: desired.GetUpdatedAt(),
+	}
+
+	for _, point := range points {
+		shadow.Reported.Fields[point.GetAttr()] = pointToValue(point)
+	}
+
+	for attr, val := range shadow.GetDesired().GetFields() {
+		if rep, ok := shadow.GetReported().GetFields()[attr]; !ok ||
+			!proto.Equal(rep, val) {
+			shadow.Delta.Fields[attr] = val
+		}
+	}
+
+	return shadow, nil
+}
+
+// pointToValue converts a data point's value to a structpb.Value. Bytes are
+// encoded as standard base64, matching their JSON representation.
+func pointToValue(point *common.DataPoint) *structpb.Value {
+	switch v := point.GetValOneof().(type) {
+	case *common.DataPoint_IntVal:
+		return structpb.NewNumberValue(float64(v.IntVal))
+	case *common.DataPoint_Fl64Val:
+		return structpb.NewNumberValue(v.Fl64Val)
+	case *common.DataPoint_StrVal:
+		return structpb.NewStringValue(v.StrVal)
+	case *common.DataPoint_BoolVal:
+		return structpb.NewBoolValue(v.BoolVal)
+	case *common.DataPoint_BytesVal:
+		return structpb.NewStringValue(base64.StdEncoding.EncodeToString(
+			v.BytesVal))
+	default:
+		return structpb.NewNullValue()
+	}
+}
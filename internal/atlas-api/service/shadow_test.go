@@ -0,0 +1,422 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetShadow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get shadow by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-shadow", uuid.NewV7().String())
+		desired, err := structpb.NewStruct(map[string]any{
+			"setpoint": 21, "mode": "heat",
+		})
+		require.NoError(t, err)
+		updatedAt := timestamppb.Now()
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		shadower := NewMockShadower(ctrl)
+		shadower.EXPECT().ReadDesired(gomock.Any(), dev.GetId(),
+			dev.GetOrgId()).Return(&message.Shadow{
+			DevId: dev.GetId(), OrgId: dev.GetOrgId(), Desired: desired,
+			Version: 3, UpdatedAt: updatedAt,
+		}, nil).Times(1)
+		datapointer := NewMockDataPointer(ctrl)
+		datapointer.EXPECT().Latest(gomock.Any(), dev.GetOrgId(), "",
+			dev.GetId(), gomock.Any()).Return([]*common.DataPoint{
+			{Attr: "setpoint", ValOneof: &common.DataPoint_IntVal{IntVal: 21}},
+			{Attr: "mode", ValOneof: &common.DataPoint_StrVal{StrVal: "cool"}},
+			{Attr: "temp", ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 19.5}},
+		}, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		shadowSvc := NewShadow(shadower, devicer, datapointer, nil)
+		getShadow, err := shadowSvc.GetShadow(ctx, dev.GetId())
+		t.Logf("getShadow, err: %+v, %v", getShadow, err)
+		require.NoError(t, err)
+
+		reported, err := structpb.NewStruct(map[string]any{
+			"setpoint": 21, "mode": "cool", "temp": 19.5,
+		})
+		require.NoError(t, err)
+		delta, err := structpb.NewStruct(map[string]any{"mode": "heat"})
+		require.NoError(t, err)
+
+		require.EqualExportedValues(t, &message.Shadow{
+			DevId: dev.GetId(), OrgId: dev.GetOrgId(), UniqId: dev.GetUniqId(),
+			Desired: desired, Reported: reported, Delta: delta, Version: 3,
+			UpdatedAt: updatedAt,
+		}, getShadow)
+	})
+
+	t.Run("Get shadow without desired state", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-shadow", uuid.NewV7().String())
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		shadower := NewMockShadower(ctrl)
+		shadower.EXPECT().ReadDesired(gomock.Any(), dev.GetId(),
+			dev.GetOrgId()).Return(nil, dao.ErrNotFound).Times(1)
+		datapointer := NewMockDataPointer(ctrl)
+		datapointer.EXPECT().Latest(gomock.Any(), dev.GetOrgId(), "",
+			dev.GetId(), gomock.Any()).Return([]*common.DataPoint{
+			{Attr: "led", ValOneof: &common.DataPoint_BoolVal{BoolVal: true}},
+		}, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		shadowSvc := NewShadow(shadower, devicer, datapointer, nil)
+		getShadow, err := shadowSvc.GetShadow(ctx, dev.GetId())
+		t.Logf("getShadow, err: %+v, %v", getShadow, err)
+		require.NoError(t, err)
+		require.Empty(t, getShadow.GetDesired().GetFields())
+		require.Empty(t, getShadow.GetDelta().GetFields())
+		require.True(t, getShadow.GetReported().GetFields()["led"].
+			GetBoolValue())
+		require.Zero(t, getShadow.GetVersion())
+	})
+
+	t.Run("Get shadow with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT}),
+			testTimeout)
+		defer cancel()
+
+		shadowSvc := NewShadow(nil, nil, nil, nil)
+		getShadow, err := shadowSvc.GetShadow(ctx, uuid.NewV7().String())
+		t.Logf("getShadow, err: %+v, %v", getShadow, err)
+		require.Nil(t, getShadow)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("Get shadow out of scope", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-shadow", uuid.NewV7().String())
+		dev.Tags = []string{"site2"}
+
+		devicer := NewMockDevicer(gomock.NewController(t))
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: dev.GetOrgId(), Role: api.Role_ADMIN,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		shadowSvc := NewShadow(nil, devicer, nil, nil)
+		getShadow, err := shadowSvc.GetShadow(ctx, dev.GetId())
+		t.Logf("getShadow, err: %+v, %v", getShadow, err)
+		require.Nil(t, getShadow)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+
+	t.Run("Get shadow by unknown device", func(t *testing.T) {
+		t.Parallel()
+
+		devicer := NewMockDevicer(gomock.NewController(t))
+		devicer.EXPECT().Read(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		shadowSvc := NewShadow(nil, devicer, nil, nil)
+		getShadow, err := shadowSvc.GetShadow(ctx, uuid.NewV7().String())
+		t.Logf("getShadow, err: %+v, %v", getShadow, err)
+		require.Nil(t, getShadow)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestUpdateShadowDesired(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Update desired by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-shadow", uuid.NewV7().String())
+		desired, err := structpb.NewStruct(map[string]any{"led": false})
+		require.NoError(t, err)
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		shadower := NewMockShadower(ctrl)
+		shadower.EXPECT().UpdateDesired(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, shadow *message.Shadow) (*message.Shadow,
+				error,
+			) {
+				shadow.Version = 2
+				shadow.UpdatedAt = timestamppb.Now()
+
+				return shadow, nil
+			}).Times(1)
+		datapointer := NewMockDataPointer(ctrl)
+		datapointer.EXPECT().Latest(gomock.Any(), dev.GetOrgId(), "",
+			dev.GetId(), gomock.Any()).Return([]*common.DataPoint{
+			{Attr: "led", ValOneof: &common.DataPoint_BoolVal{BoolVal: true}},
+		}, nil).Times(1)
+
+		mqttQueue := queue.NewFake()
+		shadowSub, err := mqttQueue.Subscribe("")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		shadowSvc := NewShadow(shadower, devicer, datapointer, mqttQueue)
+		updShadow, err := shadowSvc.UpdateShadowDesired(ctx, dev.GetId(),
+			desired)
+		t.Logf("updShadow, err: %+v, %v", updShadow, err)
+		require.NoError(t, err)
+		require.Equal(t, int32(2), updShadow.GetVersion())
+		require.False(t, updShadow.GetDelta().GetFields()["led"].GetBoolValue())
+		require.Len(t, updShadow.GetDelta().GetFields(), 1)
+
+		select {
+		case msg := <-shadowSub.C():
+			msg.Ack()
+			t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(), msg.Payload())
+			require.Equal(t, fmt.Sprintf("v1/%s/%s/shadow", dev.GetOrgId(),
+				dev.GetUniqId()), msg.Topic())
+
+			pubShadow := &message.Shadow{}
+			require.NoError(t, proto.Unmarshal(msg.Payload(), pubShadow))
+			require.EqualExportedValues(t, updShadow, pubShadow)
+		case <-time.After(testTimeout):
+			t.Fatal("Message timed out")
+		}
+	})
+
+	t.Run("Update desired with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		shadowSvc := NewShadow(nil, nil, nil, nil)
+		updShadow, err := shadowSvc.UpdateShadowDesired(ctx,
+			uuid.NewV7().String(), nil)
+		t.Logf("updShadow, err: %+v, %v", updShadow, err)
+		require.Nil(t, updShadow)
+		require.Equal(t, errPerm(api.Role_BUILDER), err)
+	})
+
+	t.Run("Update desired with too many attributes", func(t *testing.T) {
+		t.Parallel()
+
+		desired := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		for i := range maxDesiredAttrs + 1 {
+			desired.Fields["attr"+strconv.Itoa(i)] = structpb.NewNumberValue(1)
+		}
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		shadowSvc := NewShadow(nil, nil, nil, nil)
+		updShadow, err := shadowSvc.UpdateShadowDesired(ctx,
+			uuid.NewV7().String(), desired)
+		t.Logf("updShadow, err: %+v, %v", updShadow, err)
+		require.Nil(t, updShadow)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"maximum of 100 desired attributes exceeded"), err)
+	})
+
+	t.Run("Update desired with DAO error", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-shadow", uuid.NewV7().String())
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+			Return(dev, nil).Times(1)
+		shadower := NewMockShadower(ctrl)
+		shadower.EXPECT().UpdateDesired(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		shadowSvc := NewShadow(shadower, devicer, nil, nil)
+		updShadow, err := shadowSvc.UpdateShadowDesired(ctx, dev.GetId(), nil)
+		t.Logf("updShadow, err: %+v, %v", updShadow, err)
+		require.Nil(t, updShadow)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"dao: invalid format"), err)
+	})
+}
+
+func TestDeviceShadow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get shadow by valid token", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-shadow", uuid.NewV7().String())
+		dev.Status = api.Status_ACTIVE
+
+		ctrl := gomock.NewController(t)
+		devicer := NewMockDevicer(ctrl)
+		devicer.EXPECT().ReadByUniqID(gomock.Any(), dev.GetUniqId()).
+			Return(dev, nil).Times(1)
+		shadower := NewMockShadower(ctrl)
+		shadower.EXPECT().ReadDesired(gomock.Any(), dev.GetId(),
+			dev.GetOrgId()).Return(nil, dao.ErrNotFound).Times(1)
+		datapointer := NewMockDataPointer(ctrl)
+		datapointer.EXPECT().Latest(gomock.Any(), dev.GetOrgId(), "",
+			dev.GetId(), gomock.Any()).Return(nil, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		shadowSvc := NewShadow(shadower, devicer, datapointer, nil)
+		devShadow, err := shadowSvc.DeviceShadow(ctx, dev.GetUniqId(),
+			dev.GetToken())
+		t.Logf("devShadow, err: %+v, %v", devShadow, err)
+		require.NoError(t, err)
+		require.Equal(t, dev.GetUniqId(), devShadow.GetUniqId())
+	})
+
+	t.Run("Get shadow by invalid token", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-shadow", uuid.NewV7().String())
+		dev.Status = api.Status_ACTIVE
+
+		disabled := random.Device("api-shadow", uuid.NewV7().String())
+		disabled.Status = api.Status_DISABLED
+
+		tests := []struct {
+			inpDev   *api.Device
+			inpErr   error
+			inpToken string
+		}{
+			{dev, nil, uuid.NewV7().String()},
+			{dev, nil, ""},
+			{disabled, nil, disabled.GetToken()},
+			{nil, dao.ErrNotFound, uuid.NewV7().String()},
+		}
+
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("Cannot get %+v", test), func(t *testing.T) {
+				t.Parallel()
+
+				devicer := NewMockDevicer(gomock.NewController(t))
+				devicer.EXPECT().ReadByUniqID(gomock.Any(), gomock.Any()).
+					Return(test.inpDev, test.inpErr).Times(1)
+
+				ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+				defer cancel()
+
+				shadowSvc := NewShadow(nil, devicer, nil, nil)
+				devShadow, err := shadowSvc.DeviceShadow(ctx,
+					test.inpDev.GetUniqId(), test.inpToken)
+				t.Logf("devShadow, err: %+v, %v", devShadow, err)
+				require.Nil(t, devShadow)
+				require.Equal(t, status.Error(codes.Unauthenticated,
+					"unauthorized"), err)
+			})
+		}
+	})
+}
+
+func TestPointToValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inp *common.DataPoint
+		res *structpb.Value
+	}{
+		{
+			&common.DataPoint{ValOneof: &common.DataPoint_IntVal{IntVal: 7}},
+			structpb.NewNumberValue(7),
+		},
+		{
+			&common.DataPoint{
+				ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 7.5},
+			}, structpb.NewNumberValue(7.5),
+		},
+		{
+			&common.DataPoint{
+				ValOneof: &common.DataPoint_StrVal{StrVal: "api-shadow"},
+			}, structpb.NewStringValue("api-shadow"),
+		},
+		{
+			&common.DataPoint{
+				ValOneof: &common.DataPoint_BoolVal{BoolVal: true},
+			}, structpb.NewBoolValue(true),
+		},
+		{
+			&common.DataPoint{
+				ValOneof: &common.DataPoint_BytesVal{BytesVal: []byte{0x01}},
+			}, structpb.NewStringValue("AQ=="),
+		},
+		{&common.DataPoint{}, structpb.NewNullValue()},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can convert %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualExportedValues(t, test.res, pointToValue(test.inp))
+		})
+	}
+}
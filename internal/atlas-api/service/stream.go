@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/internal/atlas-api/stream"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+// Stream service contains functions to stream live data points and events.
+type Stream struct {
+	message.UnimplementedStreamServiceServer
+
+	dpHub *stream.Hub[*message.ValidatorOut]
+	evHub *stream.Hub[*message.EventerOut]
+}
+
+// NewStream instantiates and returns a new Stream service.
+func NewStream(
+	dpHub *stream.Hub[*message.ValidatorOut],
+	evHub *stream.Hub[*message.EventerOut],
+) *Stream {
+	return &Stream{
+		dpHub: dpHub,
+		evHub: evHub,
+	}
+}
+
+// StreamDataPoints streams data points as they are received, by filter. Tag
+// scoped sessions only receive data points for devices with tags in scope.
+func (s *Stream) StreamDataPoints(
+	req *message.StreamDataPointsRequest,
+	srv message.StreamService_StreamDataPointsServer,
+) error {
+	sess, ok := session.FromContext(srv.Context())
+	if !ok || sess.Role < api.Role_VIEWER {
+		return errPerm(api.Role_VIEWER)
+	}
+
+	sub := s.dpHub.Subscribe(stream.Filter{
+		OrgID:  sess.OrgID,
+		UniqID: req.GetUniqId(),
+		DevID:  req.GetDeviceId(),
+		Tag:    req.GetTag(),
+		Attr:   req.GetAttr(),
+
+		ScopeTags: sess.ScopeTags,
+	})
+	defer sub.Unsubscribe()
+
+	return serveSub(srv.Context(), sub, func(vOut *message.ValidatorOut) error {
+		return srv.Send(stream.DataPoint(vOut))
+	})
+}
+
+// StreamEvents streams events as they occur, by filter. Tag scoped sessions
+// only receive events for devices with tags in scope.
+func (s *Stream) StreamEvents(
+	req *message.StreamEventsRequest,
+	srv message.StreamService_StreamEventsServer,
+) error {
+	sess, ok := session.FromContext(srv.Context())
+	if !ok || sess.Role < api.Role_VIEWER {
+		return errPerm(api.Role_VIEWER)
+	}
+
+	sub := s.evHub.Subscribe(stream.Filter{
+		OrgID:  sess.OrgID,
+		UniqID: req.GetUniqId(),
+		DevID:  req.GetDeviceId(),
+		Tag:    req.GetTag(),
+		Attr:   req.GetAttr(),
+
+		ScopeTags: sess.ScopeTags,
+	})
+	defer sub.Unsubscribe()
+
+	return serveSub(srv.Context(), sub, func(eOut *message.EventerOut) error {
+		return srv.Send(stream.Event(eOut))
+	})
+}
+
+// serveSub sends a Sub's messages until the stream ends, the Sub's Hub is
+// closed, or a send fails.
+func serveSub[T stream.Messager](
+	ctx context.Context, sub *stream.Sub[T], send func(T) error,
+) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-sub.C():
+			if !ok {
+				return nil
+			}
+
+			if err := send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
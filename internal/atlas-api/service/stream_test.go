@@ -0,0 +1,222 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/internal/atlas-api/stream"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testStream implements grpc.ServerStreamingServer by sending messages to a
+// channel.
+type testStream[T any] struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	msgs chan *T
+}
+
+// Context returns the testStream's context.
+func (s *testStream[T]) Context() context.Context {
+	return s.ctx
+}
+
+// Send sends a message to the testStream's channel.
+func (s *testStream[T]) Send(msg *T) error {
+	s.msgs <- msg
+
+	return nil
+}
+
+// publishUntil publishes a payload to a queue until a message is received from
+// a channel, as a stream may not yet be subscribed, and returns the message.
+func publishUntil[T any](
+	t *testing.T, q queue.Queuer, payload []byte, msgs <-chan *T,
+) *T {
+	t.Helper()
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	timeout := time.After(testTimeout)
+	for {
+		select {
+		case msg := <-msgs:
+			return msg
+		case <-ticker.C:
+			require.NoError(t, q.Publish("", payload))
+		case <-timeout:
+			t.Fatal("Message timed out")
+		}
+	}
+}
+
+func TestStreamDataPoints(t *testing.T) {
+	t.Parallel()
+
+	dpQueue := queue.NewFake()
+	vOutSub, err := dpQueue.Subscribe("")
+	require.NoError(t, err)
+
+	dpHub := stream.NewHub(vOutSub, func() *message.ValidatorOut {
+		return &message.ValidatorOut{}
+	})
+	go dpHub.Serve()
+
+	t.Run("Stream data points by valid attr", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-stream", uuid.NewV7().String())
+		vOut := &message.ValidatorOut{
+			Point: &common.DataPoint{
+				UniqId: dev.GetUniqId(), Attr: "motion",
+				ValOneof: &common.DataPoint_IntVal{IntVal: 123},
+				Ts:       timestamppb.Now(), TraceId: uuid.NewV7().String(),
+			}, Device: dev,
+		}
+		bVOut, err := proto.Marshal(vOut)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		srv := &testStream[common.DataPoint]{
+			ctx: ctx, msgs: make(chan *common.DataPoint, 10),
+		}
+		errs := make(chan error, 1)
+
+		streamSvc := NewStream(dpHub, nil)
+		go func() {
+			errs <- streamSvc.StreamDataPoints(
+				&message.StreamDataPointsRequest{Attr: "motion"}, srv)
+		}()
+
+		point := publishUntil(t, dpQueue, bVOut, srv.msgs)
+		t.Logf("point: %+v", point)
+		require.EqualExportedValues(t, vOut.GetPoint(), point)
+
+		cancel()
+		require.NoError(t, <-errs)
+	})
+
+	t.Run("Stream data points with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: uuid.NewV7().String(), Role: api.Role_CONTACT,
+			}), testTimeout)
+		defer cancel()
+
+		streamSvc := NewStream(dpHub, nil)
+		err := streamSvc.StreamDataPoints(&message.StreamDataPointsRequest{},
+			&testStream[common.DataPoint]{ctx: ctx})
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+}
+
+func TestStreamEvents(t *testing.T) {
+	t.Parallel()
+
+	eOutQueue := queue.NewFake()
+	eOutSub, err := eOutQueue.Subscribe("")
+	require.NoError(t, err)
+
+	evHub := stream.NewHub(eOutSub, func() *message.EventerOut {
+		return &message.EventerOut{}
+	})
+	go evHub.Serve()
+
+	t.Run("Stream cleared events by valid UniqID", func(t *testing.T) {
+		t.Parallel()
+
+		dev := random.Device("api-stream", uuid.NewV7().String())
+		eOut := &message.EventerOut{
+			Point: &common.DataPoint{
+				UniqId: dev.GetUniqId(), Attr: "motion",
+				ValOneof: &common.DataPoint_IntVal{IntVal: 123},
+				Ts:       timestamppb.Now(), TraceId: uuid.NewV7().String(),
+			}, Device: dev, Rule: random.Rule("api-stream", dev.GetOrgId()),
+			Cleared: true,
+		}
+		bEOut, err := proto.Marshal(eOut)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: dev.GetOrgId(), Role: api.Role_VIEWER}),
+			testTimeout)
+		defer cancel()
+
+		srv := &testStream[message.StreamEvent]{
+			ctx: ctx, msgs: make(chan *message.StreamEvent, 10),
+		}
+		errs := make(chan error, 1)
+
+		streamSvc := NewStream(nil, evHub)
+		go func() {
+			errs <- streamSvc.StreamEvents(&message.StreamEventsRequest{
+				UniqId: dev.GetUniqId(),
+			}, srv)
+		}()
+
+		event := publishUntil(t, eOutQueue, bEOut, srv.msgs)
+		t.Logf("event: %+v", event)
+		require.EqualExportedValues(t, stream.Event(eOut), event)
+		require.True(t, event.GetCleared())
+
+		cancel()
+		require.NoError(t, <-errs)
+	})
+
+	t.Run("Stream events with invalid session", func(t *testing.T) {
+		t.Parallel()
+
+		streamSvc := NewStream(nil, evHub)
+		err := streamSvc.StreamEvents(&message.StreamEventsRequest{},
+			&testStream[message.StreamEvent]{ctx: t.Context()})
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_VIEWER), err)
+	})
+
+	t.Run("Stream events until hub is closed", func(t *testing.T) {
+		t.Parallel()
+
+		eOutQueue := queue.NewFake()
+		eOutSub, err := eOutQueue.Subscribe("")
+		require.NoError(t, err)
+
+		closeHub := stream.NewHub(eOutSub, func() *message.EventerOut {
+			return &message.EventerOut{}
+		})
+		require.NoError(t, closeHub.Close())
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: uuid.NewV7().String(), Role: api.Role_VIEWER,
+			}), testTimeout)
+		defer cancel()
+
+		streamSvc := NewStream(nil, closeHub)
+		err = streamSvc.StreamEvents(&message.StreamEventsRequest{},
+			&testStream[message.StreamEvent]{ctx: ctx})
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+}
@@ -11,7 +11,8 @@ import (
 
 // Tagger defines the methods provided by a tag.DAO.
 type Tagger interface {
-	List(ctx context.Context, orgID string) ([]string, error)
+	List(ctx context.Context, orgID string, scopeTags []string) ([]string,
+		error)
 }
 
 // Tag service contains functions to query tags.
@@ -28,7 +29,7 @@ func NewTag(tagDAO Tagger) *Tag {
 	}
 }
 
-// ListTags retrieves all tags.
+// ListTags retrieves all tags. Tag scoped sessions only retrieve tags in scope.
 func (t *Tag) ListTags(ctx context.Context, _ *api.ListTagsRequest) (
 	*api.ListTagsResponse, error,
 ) {
@@ -37,7 +38,7 @@ func (t *Tag) ListTags(ctx context.Context, _ *api.ListTagsRequest) (
 		return nil, errPerm(api.Role_VIEWER)
 	}
 
-	tags, err := t.tagDAO.List(ctx, sess.OrgID)
+	tags, err := t.tagDAO.List(ctx, sess.OrgID, sess.ScopeTags)
 	if err != nil {
 		return nil, errToStatus(err)
 	}
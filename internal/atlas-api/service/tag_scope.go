@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/proto/go/api"
+)
+
+// readScoped retrieves a device by uniq ID or device ID and verifies that it is
+// within a session's scope tags. If both uniqID and devID are provided, uniqID
+// takes precedence and devID is ignored. Devices that are out of scope are
+// reported as not found, so as not to disclose their existence.
+func readScoped(
+	ctx context.Context, devDAO Devicer, sess *session.Session, uniqID,
+	devID string,
+) (*api.Device, error) {
+	var dev *api.Device
+	var err error
+
+	if uniqID != "" {
+		dev, err = devDAO.ReadByUniqID(ctx, uniqID)
+
+		// Do not reveal whether a device exists in another org.
+		if err == nil && dev.GetOrgId() != sess.OrgID {
+			err = dao.ErrNotFound
+		}
+	} else {
+		dev, err = devDAO.Read(ctx, devID, sess.OrgID)
+	}
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	if !sess.InTagScope(dev.GetTags()) {
+		return nil, errToStatus(dao.ErrNotFound)
+	}
+
+	return dev, nil
+}
@@ -27,7 +27,8 @@ func TestListTags(t *testing.T) {
 		tags := random.Tags("api-tag", 5)
 
 		tagger := NewMockTagger(gomock.NewController(t))
-		tagger.EXPECT().List(gomock.Any(), orgID).Return(tags, nil).Times(1)
+		tagger.EXPECT().List(gomock.Any(), orgID, gomock.Nil()).Return(tags,
+			nil).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
@@ -41,6 +42,30 @@ func TestListTags(t *testing.T) {
 			listTags)
 	})
 
+	t.Run("List tags with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+		scopeTags := []string{"site"}
+		tags := []string{"site", "site/b1"}
+
+		tagger := NewMockTagger(gomock.NewController(t))
+		tagger.EXPECT().List(gomock.Any(), orgID, scopeTags).Return(tags, nil).
+			Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: orgID, Role: api.Role_ADMIN, ScopeTags: scopeTags,
+			}), testTimeout)
+		defer cancel()
+
+		tagSvc := NewTag(tagger)
+		listTags, err := tagSvc.ListTags(ctx, &api.ListTagsRequest{})
+		t.Logf("listTags, err: %+v, %v", listTags, err)
+		require.NoError(t, err)
+		require.Equal(t, tags, listTags.GetTags())
+	})
+
 	t.Run("List tags with invalid session", func(t *testing.T) {
 		t.Parallel()
 
@@ -73,7 +98,7 @@ func TestListTags(t *testing.T) {
 		t.Parallel()
 
 		tagger := NewMockTagger(gomock.NewController(t))
-		tagger.EXPECT().List(gomock.Any(), "aaa").Return(nil,
+		tagger.EXPECT().List(gomock.Any(), "aaa", gomock.Nil()).Return(nil,
 			dao.ErrInvalidFormat).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
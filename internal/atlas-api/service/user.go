@@ -14,7 +14,9 @@ import (
 	"github.com/thingspect/atlas/internal/atlas-api/auth"
 	"github.com/thingspect/atlas/internal/atlas-api/session"
 	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
 	"github.com/thingspect/atlas/pkg/notify"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -39,6 +41,33 @@ type Userer interface {
 	Delete(ctx context.Context, userID, orgID string) error
 	List(ctx context.Context, orgID string, lBoundTS time.Time, prevID string,
 		limit int32, tag string) ([]*api.User, int32, error)
+	UpsertSchedule(ctx context.Context, sched *message.NotificationSchedule) (
+		*message.NotificationSchedule, error)
+	ReadSchedule(ctx context.Context, userID, orgID string) (
+		*message.NotificationSchedule, error)
+	DeleteSchedule(ctx context.Context, userID, orgID string) error
+	CreateSession(ctx context.Context, userID, orgID string, refreshHash []byte,
+		expiresAt time.Time) (*message.UserSession, error)
+	RotateSession(ctx context.Context, refreshHash, newHash []byte,
+		expiresAt time.Time) (*message.UserSession, error)
+	RevokeReusedSession(ctx context.Context, refreshHash []byte) (
+		*message.UserSession, error)
+	RevokeSession(ctx context.Context, sessID, userID, orgID string) error
+	RevokeSessions(ctx context.Context, userID, orgID string) ([]string, error)
+	ListSessions(ctx context.Context, userID, orgID string) (
+		[]*message.UserSession, error)
+	ReadMFA(ctx context.Context, userID, orgID string) (*message.UserMFA,
+		[]byte, error)
+	UpsertMFA(ctx context.Context, userID, orgID string, secret []byte) error
+	ConfirmMFA(ctx context.Context, userID, orgID string, step int64,
+		recoveryHashes []string) error
+	UseMFAStep(ctx context.Context, userID, orgID string, step int64) error
+	UseRecoveryCode(ctx context.Context, userID, orgID, hash string) error
+	DeleteMFA(ctx context.Context, userID, orgID string) error
+	UpsertScope(ctx context.Context, scope *message.UserScope) (
+		*message.UserScope, error)
+	ReadScope(ctx context.Context, userID, orgID string) (*message.UserScope,
+		error)
 }
 
 // User service contains functions to query and modify users.
@@ -47,13 +76,17 @@ type User struct {
 
 	userDAO Userer
 	notify  notify.Notifier
+	cache   cache.Cacher[string]
 }
 
 // NewUser instantiates and returns a new User service.
-func NewUser(userDAO Userer, notify notify.Notifier) *User {
+func NewUser(
+	userDAO Userer, notify notify.Notifier, cache cache.Cacher[string],
+) *User {
 	return &User{
 		userDAO: userDAO,
 		notify:  notify,
+		cache:   cache,
 	}
 }
 
@@ -73,6 +106,11 @@ func (u *User) CreateUser(ctx context.Context, req *api.CreateUserRequest) (
 			"permission denied, role modification not allowed")
 	}
 
+	// Users created by tag scoped sessions would not be scoped.
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
 	// Validate phone number.
 	if req.GetUser().GetPhone() != "" {
 		if !rePhone.MatchString(req.GetUser().GetPhone()) {
@@ -208,6 +246,14 @@ func (u *User) UpdateUser(ctx context.Context, req *api.UpdateUserRequest) (
 		return nil, errToStatus(err)
 	}
 
+	// Revoke the sessions of disabled users.
+	if user.GetStatus() == api.Status_DISABLED {
+		if err := revokeSessions(ctx, u.userDAO, u.cache, user.GetId(),
+			sess.OrgID); err != nil {
+			return nil, errToStatus(err)
+		}
+	}
+
 	return user, nil
 }
 
@@ -237,6 +283,13 @@ func (u *User) UpdateUserPassword(
 		return nil, errToStatus(err)
 	}
 
+	// Revoke all sessions, including the current session, after a password
+	// change.
+	if err := revokeSessions(ctx, u.userDAO, u.cache, req.GetId(),
+		sess.OrgID); err != nil {
+		return nil, errToStatus(err)
+	}
+
 	return &emptypb.Empty{}, nil
 }
 
@@ -249,6 +302,12 @@ func (u *User) DeleteUser(ctx context.Context, req *api.DeleteUserRequest) (
 		return nil, errPerm(api.Role_ADMIN)
 	}
 
+	// Revoke sessions before the user, and its sessions, are removed.
+	if err := revokeSessions(ctx, u.userDAO, u.cache, req.GetId(),
+		sess.OrgID); err != nil {
+		return nil, errToStatus(err)
+	}
+
 	if err := u.userDAO.Delete(ctx, req.GetId(), sess.OrgID); err != nil {
 		return nil, errToStatus(err)
 	}
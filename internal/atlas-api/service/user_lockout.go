@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+// Lockout service contains functions to query and clear login lockouts.
+type Lockout struct {
+	orgDAO  Orger
+	userDAO Userer
+	cache   cache.Cacher[string]
+}
+
+// NewLockout instantiates and returns a new Lockout service.
+func NewLockout(
+	orgDAO Orger, userDAO Userer, cache cache.Cacher[string],
+) *Lockout {
+	return &Lockout{
+		orgDAO:  orgDAO,
+		userDAO: userDAO,
+		cache:   cache,
+	}
+}
+
+// ListUserLockouts retrieves users of the current organization with failed
+// logins in the current failure window, and whether they are locked out.
+func (l *Lockout) ListUserLockouts(ctx context.Context) (
+	*message.ListUserLockoutsResponse, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	org, err := l.orgDAO.Read(ctx, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	users, _, err := l.userDAO.List(ctx, sess.OrgID, time.Time{}, "", 0, "")
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	resp := &message.ListUserLockoutsResponse{}
+	for _, user := range users {
+		failures := loginFailures(ctx, l.cache,
+			key.LoginFailures(org.GetName(), user.GetEmail()))
+		if failures == 0 {
+			continue
+		}
+
+		resp.Lockouts = append(resp.Lockouts, &message.UserLockout{
+			UserId:         user.GetId(),
+			OrgId:          user.GetOrgId(),
+			Email:          user.GetEmail(),
+			FailedAttempts: int32(failures),
+			Locked:         failures >= loginLockout,
+		})
+	}
+
+	return resp, nil
+}
+
+// UnlockUser clears the failed logins of a user by ID, including any lockout.
+func (l *Lockout) UnlockUser(ctx context.Context, userID string) error {
+	logger := alog.FromContext(ctx)
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return errPerm(api.Role_ADMIN)
+	}
+
+	org, err := l.orgDAO.Read(ctx, sess.OrgID)
+	if err != nil {
+		return errToStatus(err)
+	}
+
+	user, err := l.userDAO.Read(ctx, userID, sess.OrgID)
+	if err != nil {
+		return errToStatus(err)
+	}
+
+	if err := l.cache.Del(ctx, key.LoginFailures(org.GetName(),
+		user.GetEmail())); err != nil {
+		logger.Errorf("UnlockUser l.cache.Del: %v", err)
+
+		return errToStatus(err)
+	}
+
+	logger.Infof("UnlockUser unlocked user: %v", userID)
+
+	return nil
+}
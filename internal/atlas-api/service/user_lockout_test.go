@@ -0,0 +1,219 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestListUserLockouts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("List user lockouts", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-lockout")
+		locked := random.User("api-lockout", org.GetId())
+		failed := random.User("api-lockout", org.GetId())
+		cleared := random.User("api-lockout", org.GetId())
+
+		ctrl := gomock.NewController(t)
+		orger := NewMockOrger(ctrl)
+		orger.EXPECT().Read(gomock.Any(), org.GetId()).Return(org, nil).
+			Times(1)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().List(gomock.Any(), org.GetId(), time.Time{}, "",
+			int32(0), "").Return([]*api.User{locked, failed, cleared}, int32(3),
+			nil).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			locked.GetEmail())).Return(strconv.Itoa(loginLockout), nil).
+			Times(1)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			failed.GetEmail())).Return("2", nil).Times(1)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			cleared.GetEmail())).Return("", cache.ErrNotFound).Times(1)
+
+		lockouts := &message.ListUserLockoutsResponse{
+			Lockouts: []*message.UserLockout{
+				{
+					UserId: locked.GetId(), OrgId: org.GetId(),
+					Email: locked.GetEmail(), FailedAttempts: loginLockout,
+					Locked: true,
+				},
+				{
+					UserId: failed.GetId(), OrgId: org.GetId(),
+					Email: failed.GetEmail(), FailedAttempts: 2,
+				},
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: org.GetId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		lockSvc := NewLockout(orger, userer, cacher)
+		listLockouts, err := lockSvc.ListUserLockouts(ctx)
+		t.Logf("listLockouts, err: %+v, %v", listLockouts, err)
+		require.NoError(t, err)
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(lockouts, listLockouts) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", lockouts, listLockouts)
+		}
+	})
+
+	t.Run("List user lockouts with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER,
+			}), testTimeout)
+		defer cancel()
+
+		lockSvc := NewLockout(nil, nil, nil)
+		listLockouts, err := lockSvc.ListUserLockouts(ctx)
+		t.Logf("listLockouts, err: %+v, %v", listLockouts, err)
+		require.Nil(t, listLockouts)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("List user lockouts with unknown org", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := uuid.NewV7().String()
+
+		orger := NewMockOrger(gomock.NewController(t))
+		orger.EXPECT().Read(gomock.Any(), orgID).Return(nil, dao.ErrNotFound).
+			Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		lockSvc := NewLockout(orger, nil, nil)
+		listLockouts, err := lockSvc.ListUserLockouts(ctx)
+		t.Logf("listLockouts, err: %+v, %v", listLockouts, err)
+		require.Nil(t, listLockouts)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+
+	t.Run("List user lockouts with invalid users", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-lockout")
+
+		ctrl := gomock.NewController(t)
+		orger := NewMockOrger(ctrl)
+		orger.EXPECT().Read(gomock.Any(), org.GetId()).Return(org, nil).
+			Times(1)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().List(gomock.Any(), org.GetId(), time.Time{}, "",
+			int32(0), "").Return(nil, int32(0), dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: org.GetId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		lockSvc := NewLockout(orger, userer, nil)
+		listLockouts, err := lockSvc.ListUserLockouts(ctx)
+		t.Logf("listLockouts, err: %+v, %v", listLockouts, err)
+		require.Nil(t, listLockouts)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"dao: invalid format"), err)
+	})
+}
+
+func TestUnlockUser(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Unlock user by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-lockout")
+		user := random.User("api-lockout", org.GetId())
+
+		ctrl := gomock.NewController(t)
+		orger := NewMockOrger(ctrl)
+		orger.EXPECT().Read(gomock.Any(), org.GetId()).Return(org, nil).
+			Times(1)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().Read(gomock.Any(), user.GetId(), org.GetId()).
+			Return(user, nil).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Del(gomock.Any(), key.LoginFailures(org.GetName(),
+			user.GetEmail())).Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: org.GetId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		lockSvc := NewLockout(orger, userer, cacher)
+		err := lockSvc.UnlockUser(ctx, user.GetId())
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Unlock user with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER,
+			}), testTimeout)
+		defer cancel()
+
+		lockSvc := NewLockout(nil, nil, nil)
+		err := lockSvc.UnlockUser(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Unlock unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-lockout")
+
+		ctrl := gomock.NewController(t)
+		orger := NewMockOrger(ctrl)
+		orger.EXPECT().Read(gomock.Any(), org.GetId()).Return(org, nil).
+			Times(1)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().Read(gomock.Any(), gomock.Any(), org.GetId()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: org.GetId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		lockSvc := NewLockout(orger, userer, nil)
+		err := lockSvc.UnlockUser(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-api/auth"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/crypt"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Constants used for MFA challenges and enrollment.
+const (
+	mfaChallengeExp   = 5 * time.Minute
+	maxMFAAttempts    = 5
+	recoveryCodeCount = 10
+)
+
+// mfaChallenge holds the pending state of a login that requires a second
+// factor.
+type mfaChallenge struct {
+	UserID      string    `json:"userID"`
+	OrgID       string    `json:"orgID"`
+	OrgName     string    `json:"orgName"`
+	Email       string    `json:"email"`
+	Refreshable bool      `json:"refreshable"`
+	Enroll      bool      `json:"enroll"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// login authenticates a user and creates a session. If the user is enrolled
+// in MFA, or required to be by organization policy, an MFA challenge is
+// returned as an error detail instead.
+func (s *Session) login(
+	ctx context.Context, req *api.LoginRequest, refreshable bool,
+) (*message.SessionTokens, error) {
+	user, failures, err := s.authenticate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := challengeMFA(ctx, s.userDAO, s.cache, user, req.GetOrgName(),
+		req.GetEmail(), refreshable); err != nil {
+		return nil, err
+	}
+
+	s.clearFailures(ctx, req.GetOrgName(), req.GetEmail(), failures)
+
+	return newSession(ctx, s.userDAO, s.pwtKey, user, refreshable)
+}
+
+// challengeMFA returns an Unauthenticated error with an MFA challenge detail
+// if a user must provide a second factor, and nil otherwise. Challenges retain
+// the login's email and org name to count failed codes as failed logins.
+func challengeMFA(
+	ctx context.Context, userDAO Userer, c cache.Cacher[string],
+	user *api.User, orgName, email string, refreshable bool,
+) error {
+	logger := alog.FromContext(ctx)
+
+	mfa, _, err := userDAO.ReadMFA(ctx, user.GetId(), user.GetOrgId())
+	if err != nil {
+		logger.Errorf("challengeMFA userDAO.ReadMFA: %v", err)
+
+		return status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	if !mfa.GetEnrolled() && !mfa.GetRequired() {
+		return nil
+	}
+
+	chal := &mfaChallenge{
+		UserID: user.GetId(), OrgID: user.GetOrgId(), OrgName: orgName,
+		Email: email, Refreshable: refreshable, Enroll: !mfa.GetEnrolled(),
+		ExpiresAt: time.Now().Add(mfaChallengeExp).UTC(),
+	}
+	token := rand.Text()
+
+	if err := setChallenge(ctx, c, token, chal); err != nil {
+		logger.Errorf("challengeMFA setChallenge: %v", err)
+
+		return status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	msg := "mfa required"
+	if chal.Enroll {
+		msg = "mfa enrollment required"
+	}
+
+	st, err := status.New(codes.Unauthenticated, msg).WithDetails(
+		&message.MFAChallenge{
+			Token: token, ExpiresAt: timestamppb.New(chal.ExpiresAt),
+			EnrollmentRequired: chal.Enroll,
+		})
+	if err != nil {
+		logger.Errorf("challengeMFA status.WithDetails: %v", err)
+
+		return status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	return st.Err()
+}
+
+// setChallenge caches an MFA challenge until it expires.
+func setChallenge(
+	ctx context.Context, c cache.Cacher[string], token string,
+	chal *mfaChallenge,
+) error {
+	bChal, err := json.Marshal(chal)
+	if err != nil {
+		return err
+	}
+
+	return c.SetTTL(ctx, key.MFAChallenge(token), string(bChal),
+		time.Until(chal.ExpiresAt))
+}
+
+// getChallenge retrieves a cached MFA challenge.
+func (s *Session) getChallenge(ctx context.Context, token string) (
+	*mfaChallenge, error,
+) {
+	if token == "" {
+		return nil, dao.ErrNotFound
+	}
+
+	bChal, err := s.cache.Get(ctx, key.MFAChallenge(token))
+	if err != nil {
+		return nil, err
+	}
+
+	chal := &mfaChallenge{}
+	if err := json.Unmarshal([]byte(bChal), chal); err != nil {
+		return nil, err
+	}
+
+	return chal, nil
+}
+
+// VerifyMFA completes a login that requires a second factor, using an MFA
+// challenge token and a TOTP or recovery code. If the challenge requires
+// enrollment, a valid TOTP code also confirms the enrollment and recovery
+// codes are returned. Failed codes are throttled and locked out as failed
+// logins.
+func (s *Session) VerifyMFA(
+	ctx context.Context, req *message.VerifyMFARequest,
+) (*message.VerifyMFAResponse, error) {
+	logger := alog.FromContext(ctx)
+
+	chal, err := s.getChallenge(ctx, req.GetToken())
+	if err != nil {
+		logger.Debugf("VerifyMFA s.getChallenge: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	logger.Logger = logger.WithField("userID", chal.UserID).WithField("orgID",
+		chal.OrgID)
+	ip := clientIP(ctx)
+
+	// Challenges of locked out logins are discarded.
+	locked, failures := s.lockedOut(ctx, chal.OrgName, chal.Email, ip)
+	if locked {
+		logger.Debugf("VerifyMFA s.lockedOut IP: %v", ip)
+
+		if err := s.cache.Del(ctx,
+			key.MFAChallenge(req.GetToken())); err != nil {
+			logger.Errorf("VerifyMFA s.cache.Del: %v", err)
+		}
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	// Attempts are counted atomically, before verification, so that
+	// concurrent attempts may not exceed maxMFAAttempts.
+	attempts, err := s.cache.IncrTTL(ctx, key.MFAAttempts(req.GetToken()),
+		mfaChallengeExp)
+	if err != nil || attempts > maxMFAAttempts {
+		logger.Debugf("VerifyMFA s.cache.IncrTTL attempts, err: %v, %v",
+			attempts, err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	recCodes, err := s.verifyCode(ctx, chal, req.GetCode())
+	if err != nil {
+		logger.Infof("VerifyMFA s.verifyCode attempt, err: %v, %v", attempts,
+			err)
+
+		// Challenges are discarded after too many failed attempts.
+		if attempts == maxMFAAttempts {
+			if err := s.cache.Del(ctx,
+				key.MFAChallenge(req.GetToken())); err != nil {
+				logger.Errorf("VerifyMFA s.cache.Del: %v", err)
+			}
+		}
+		s.failLogin(ctx, chal.OrgName, chal.Email, ip)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	// Challenges may be used once.
+	if err := s.cache.Del(ctx, key.MFAChallenge(req.GetToken())); err != nil {
+		logger.Errorf("VerifyMFA s.cache.Del: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	// Users that have been disabled or demoted since login may not proceed.
+	user, err := s.userDAO.Read(ctx, chal.UserID, chal.OrgID)
+	if err != nil || user.GetStatus() != api.Status_ACTIVE ||
+		user.GetRole() < api.Role_VIEWER {
+		logger.Debugf("VerifyMFA s.userDAO.Read user, err: %+v, %v", user, err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	tokens, err := newSession(ctx, s.userDAO, s.pwtKey, user,
+		chal.Refreshable)
+	if err != nil {
+		return nil, err
+	}
+
+	s.clearFailures(ctx, chal.OrgName, chal.Email, failures)
+
+	return &message.VerifyMFAResponse{Tokens: tokens, RecoveryCodes: recCodes},
+		nil
+}
+
+// verifyCode verifies a TOTP or recovery code for an MFA challenge. If the
+// challenge requires enrollment, the enrollment is confirmed and its recovery
+// codes are returned.
+func (s *Session) verifyCode(
+	ctx context.Context, chal *mfaChallenge, code string,
+) ([]string, error) {
+	mfa, secret, err := s.userDAO.ReadMFA(ctx, chal.UserID, chal.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secret) == 0 {
+		return nil, auth.ErrInvalidCode
+	}
+
+	totpSecret, err := crypt.Decrypt(s.pwtKey, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	step, totpErr := auth.ValidateTOTP(string(totpSecret), code, time.Now())
+
+	switch {
+	case mfa.GetEnrolled() && totpErr == nil:
+		return nil, s.userDAO.UseMFAStep(ctx, chal.UserID, chal.OrgID, step)
+	case mfa.GetEnrolled():
+		return nil, s.userDAO.UseRecoveryCode(ctx, chal.UserID, chal.OrgID,
+			auth.HashRecoveryCode(code))
+	case chal.Enroll && totpErr == nil:
+		recCodes, hashes := auth.GenerateRecoveryCodes(recoveryCodeCount)
+
+		return recCodes, s.userDAO.ConfirmMFA(ctx, chal.UserID, chal.OrgID,
+			step, hashes)
+	default:
+		return nil, auth.ErrInvalidCode
+	}
+}
+
+// EnrollMFA begins TOTP enrollment of the current user, or of the user of an
+// MFA challenge that requires enrollment. Any pending enrollment is replaced.
+func (s *Session) EnrollMFA(
+	ctx context.Context, req *message.EnrollMFARequest,
+) (*message.MFAEnrollment, error) {
+	logger := alog.FromContext(ctx)
+
+	var userID, orgID string
+	if sess, ok := session.FromContext(ctx); ok {
+		if sess.UserID == "" {
+			return nil, status.Error(codes.PermissionDenied,
+				"permission denied, user token required")
+		}
+
+		userID, orgID = sess.UserID, sess.OrgID
+	} else {
+		chal, err := s.getChallenge(ctx, req.GetToken())
+		if err != nil || !chal.Enroll {
+			logger.Debugf("EnrollMFA s.getChallenge chal, err: %+v, %v", chal,
+				err)
+
+			return nil, status.Error(codes.Unauthenticated, errUnauth)
+		}
+
+		userID, orgID = chal.UserID, chal.OrgID
+	}
+
+	user, err := s.userDAO.Read(ctx, userID, orgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		logger.Errorf("EnrollMFA auth.GenerateTOTPSecret: %v", err)
+
+		return nil, errToStatus(err)
+	}
+
+	encSecret, err := crypt.Encrypt(s.pwtKey, []byte(secret))
+	if err != nil {
+		logger.Errorf("EnrollMFA crypt.Encrypt: %v", err)
+
+		return nil, errToStatus(err)
+	}
+
+	// Confirmed enrollments must be reset before enrolling again.
+	err = s.userDAO.UpsertMFA(ctx, userID, orgID, encSecret)
+	if errors.Is(err, dao.ErrNotFound) {
+		return nil, status.Error(codes.FailedPrecondition,
+			"MFA already enrolled")
+	}
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return &message.MFAEnrollment{
+		Secret: secret, Uri: auth.TOTPURI(secret, user.GetEmail()),
+	}, nil
+}
+
+// ConfirmMFA confirms the pending TOTP enrollment of the current user, and
+// returns recovery codes.
+func (s *Session) ConfirmMFA(
+	ctx context.Context, req *message.ConfirmMFARequest,
+) (*message.MFARecoveryCodes, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.UserID == "" {
+		return nil, status.Error(codes.PermissionDenied,
+			"permission denied, user token required")
+	}
+
+	mfa, secret, err := s.userDAO.ReadMFA(ctx, sess.UserID, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	if mfa.GetEnrolled() || len(secret) == 0 {
+		return nil, status.Error(codes.FailedPrecondition,
+			"MFA enrollment not pending")
+	}
+
+	totpSecret, err := crypt.Decrypt(s.pwtKey, secret)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	step, err := auth.ValidateTOTP(string(totpSecret), req.GetCode(),
+		time.Now())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid code")
+	}
+
+	recCodes, hashes := auth.GenerateRecoveryCodes(recoveryCodeCount)
+	if err := s.userDAO.ConfirmMFA(ctx, sess.UserID, sess.OrgID, step,
+		hashes); err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return &message.MFARecoveryCodes{Codes: recCodes}, nil
+}
+
+// GetUserMFA retrieves the MFA status of a user by ID.
+func (s *Session) GetUserMFA(ctx context.Context, userID string) (
+	*message.UserMFA, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || (sess.Role < api.Role_ADMIN && userID != sess.UserID) {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	mfa, _, err := s.userDAO.ReadMFA(ctx, userID, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return mfa, nil
+}
+
+// ResetUserMFA removes the TOTP enrollment and recovery codes of a user by ID.
+// Resets require an admin, so that a session alone may not remove its own
+// second factor.
+func (s *Session) ResetUserMFA(ctx context.Context, userID string) error {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return errPerm(api.Role_ADMIN)
+	}
+
+	if err := s.userDAO.DeleteMFA(ctx, userID, sess.OrgID); err != nil {
+		return errToStatus(err)
+	}
+
+	logger := alog.FromContext(ctx)
+	logger.Infof("ResetUserMFA reset MFA of user: %v", userID)
+
+	return nil
+}
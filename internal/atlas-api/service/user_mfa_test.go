@@ -0,0 +1,853 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/auth"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/crypt"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mfaSecret returns a TOTP secret, its encrypted form, and a PWT key.
+func mfaSecret(t *testing.T) (string, []byte, []byte) {
+	t.Helper()
+
+	pwtKey := make([]byte, 32)
+	_, err := rand.Read(pwtKey)
+	require.NoError(t, err)
+
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	encSecret, err := crypt.Encrypt(pwtKey, []byte(secret))
+	require.NoError(t, err)
+
+	return secret, encSecret, pwtKey
+}
+
+// incrCache wraps a memory Cacher to implement IncrTTL for tests.
+type incrCache struct {
+	cache.Cacher[string]
+
+	mu sync.Mutex
+}
+
+// IncrTTL increments an integer value by key and sets its expiration.
+func (c *incrCache) IncrTTL(ctx context.Context, key string,
+	exp time.Duration,
+) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var i int64
+	if val, err := c.Get(ctx, key); err == nil {
+		i, _ = strconv.ParseInt(val, 10, 64)
+	}
+	i++
+
+	return i, c.SetTTL(ctx, key, strconv.FormatInt(i, 10), exp)
+}
+
+func TestLoginMFA(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpMFA    *message.UserMFA
+		resMsg    string
+		resEnroll bool
+	}{
+		{&message.UserMFA{Enrolled: true}, "mfa required", false},
+		{&message.UserMFA{Required: true}, "mfa enrollment required", true},
+		{
+			&message.UserMFA{Enrolled: true, Required: true}, "mfa required",
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run("Can challenge "+test.resMsg, func(t *testing.T) {
+			t.Parallel()
+
+			org := random.Org("api-mfa")
+			user := random.User("api-mfa", org.GetId())
+			user.Role = api.Role_ADMIN
+			user.Status = api.Status_ACTIVE
+
+			userer := NewMockUserer(gomock.NewController(t))
+			userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+				org.GetName()).Return(user, globalHash, nil).Times(1)
+			userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), org.GetId()).
+				Return(test.inpMFA, nil, nil).Times(1)
+
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			c := cache.NewHeap[string]()
+			sessSvc := NewSession(userer, nil, c, nil)
+			loginResp, err := sessSvc.Login(ctx, &api.LoginRequest{
+				Email: user.GetEmail(), OrgName: org.GetName(),
+				Password: globalPass,
+			})
+			t.Logf("loginResp, err: %+v, %v", loginResp, err)
+			require.Nil(t, loginResp)
+
+			st := status.Convert(err)
+			require.Equal(t, codes.Unauthenticated, st.Code())
+			require.Equal(t, test.resMsg, st.Message())
+			require.Len(t, st.Details(), 1)
+
+			chal, ok := st.Details()[0].(*message.MFAChallenge)
+			require.True(t, ok)
+			require.Equal(t, test.resEnroll, chal.GetEnrollmentRequired())
+			require.WithinDuration(t, time.Now().Add(mfaChallengeExp),
+				chal.GetExpiresAt().AsTime(), 2*time.Second)
+
+			_, err = c.Get(ctx, key.MFAChallenge(chal.GetToken()))
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("Log in with MFA read failure", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-mfa")
+		user := random.User("api-mfa", org.GetId())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, globalHash, nil).Times(1)
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), org.GetId()).
+			Return(nil, nil, dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, cache.NewHeap[string](), nil)
+		tokens, err := sessSvc.LoginWithRefresh(ctx, &api.LoginRequest{
+			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
+		})
+		t.Logf("tokens, err: %+v, %v", tokens, err)
+		require.Nil(t, tokens)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+}
+
+func TestVerifyMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Verify MFA by TOTP code", func(t *testing.T) {
+		t.Parallel()
+
+		secret, encSecret, pwtKey := mfaSecret(t)
+		user := random.User("api-mfa", uuid.NewV7().String())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(&message.UserMFA{Enrolled: true}, encSecret, nil).Times(1)
+		userer.EXPECT().UseMFAStep(gomock.Any(), user.GetId(),
+			user.GetOrgId(), gomock.Any()).Return(nil).Times(1)
+		userer.EXPECT().Read(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(user, nil).Times(1)
+		userer.EXPECT().ReadScope(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(nil, dao.ErrNotFound).Times(1)
+		userer.EXPECT().CreateSession(gomock.Any(), user.GetId(),
+			user.GetOrgId(), gomock.Any(), gomock.Any()).
+			Return(&message.UserSession{Id: uuid.NewV7().String()}, nil).
+			Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		orgName := random.String(10)
+		c := &incrCache{Cacher: cache.NewHeap[string]()}
+		require.NoError(t, c.Set(ctx, key.LoginFailures(orgName,
+			user.GetEmail()), "2"))
+
+		sessSvc := NewSession(userer, nil, c, pwtKey)
+		token := random.String(16)
+		require.NoError(t, setChallenge(ctx, sessSvc.cache, token,
+			&mfaChallenge{
+				UserID: user.GetId(), OrgID: user.GetOrgId(), OrgName: orgName,
+				Email: user.GetEmail(), Refreshable: true,
+				ExpiresAt: time.Now().Add(mfaChallengeExp),
+			}))
+
+		code, err := auth.TOTP(secret, time.Now())
+		require.NoError(t, err)
+
+		verifyResp, err := sessSvc.VerifyMFA(ctx, &message.VerifyMFARequest{
+			Token: token, Code: code,
+		})
+		t.Logf("verifyResp, err: %+v, %v", verifyResp, err)
+		require.NoError(t, err)
+		require.Greater(t, len(verifyResp.GetTokens().GetToken()), 90)
+		require.NotEmpty(t, verifyResp.GetTokens().GetRefreshToken())
+		require.Empty(t, verifyResp.GetRecoveryCodes())
+
+		_, err = c.Get(ctx, key.MFAChallenge(token))
+		require.Equal(t, cache.ErrNotFound, err)
+
+		_, err = c.Get(ctx, key.LoginFailures(orgName, user.GetEmail()))
+		require.Equal(t, cache.ErrNotFound, err)
+	})
+
+	t.Run("Verify MFA by recovery code", func(t *testing.T) {
+		t.Parallel()
+
+		_, encSecret, pwtKey := mfaSecret(t)
+		user := random.User("api-mfa", uuid.NewV7().String())
+		user.Role = api.Role_VIEWER
+		user.Status = api.Status_ACTIVE
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(&message.UserMFA{Enrolled: true}, encSecret, nil).Times(1)
+		userer.EXPECT().UseRecoveryCode(gomock.Any(), user.GetId(),
+			user.GetOrgId(), auth.HashRecoveryCode("ABCDE-FGHIJ")).
+			Return(nil).Times(1)
+		userer.EXPECT().Read(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(user, nil).Times(1)
+		userer.EXPECT().ReadScope(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(nil, dao.ErrNotFound).Times(1)
+		userer.EXPECT().CreateSession(gomock.Any(), user.GetId(),
+			user.GetOrgId(), gomock.Any(), gomock.Any()).
+			Return(&message.UserSession{Id: uuid.NewV7().String()}, nil).
+			Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil,
+			&incrCache{Cacher: cache.NewHeap[string]()}, pwtKey)
+		token := random.String(16)
+		require.NoError(t, setChallenge(ctx, sessSvc.cache, token,
+			&mfaChallenge{
+				UserID: user.GetId(), OrgID: user.GetOrgId(),
+				ExpiresAt: time.Now().Add(mfaChallengeExp),
+			}))
+
+		verifyResp, err := sessSvc.VerifyMFA(ctx, &message.VerifyMFARequest{
+			Token: token, Code: "ABCDE-FGHIJ",
+		})
+		t.Logf("verifyResp, err: %+v, %v", verifyResp, err)
+		require.NoError(t, err)
+		require.Greater(t, len(verifyResp.GetTokens().GetToken()), 90)
+		require.Empty(t, verifyResp.GetTokens().GetRefreshToken())
+	})
+
+	t.Run("Verify MFA with enrollment", func(t *testing.T) {
+		t.Parallel()
+
+		secret, encSecret, pwtKey := mfaSecret(t)
+		user := random.User("api-mfa", uuid.NewV7().String())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(&message.UserMFA{Required: true}, encSecret, nil).Times(1)
+		userer.EXPECT().ConfirmMFA(gomock.Any(), user.GetId(),
+			user.GetOrgId(), gomock.Any(), gomock.Len(recoveryCodeCount)).
+			Return(nil).Times(1)
+		userer.EXPECT().Read(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(user, nil).Times(1)
+		userer.EXPECT().ReadScope(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(nil, dao.ErrNotFound).Times(1)
+		userer.EXPECT().CreateSession(gomock.Any(), user.GetId(),
+			user.GetOrgId(), gomock.Any(), gomock.Any()).
+			Return(&message.UserSession{Id: uuid.NewV7().String()}, nil).
+			Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil,
+			&incrCache{Cacher: cache.NewHeap[string]()}, pwtKey)
+		token := random.String(16)
+		require.NoError(t, setChallenge(ctx, sessSvc.cache, token,
+			&mfaChallenge{
+				UserID: user.GetId(), OrgID: user.GetOrgId(), Enroll: true,
+				ExpiresAt: time.Now().Add(mfaChallengeExp),
+			}))
+
+		code, err := auth.TOTP(secret, time.Now())
+		require.NoError(t, err)
+
+		verifyResp, err := sessSvc.VerifyMFA(ctx, &message.VerifyMFARequest{
+			Token: token, Code: code,
+		})
+		t.Logf("verifyResp, err: %+v, %v", verifyResp, err)
+		require.NoError(t, err)
+		require.Greater(t, len(verifyResp.GetTokens().GetToken()), 90)
+		require.Len(t, verifyResp.GetRecoveryCodes(), recoveryCodeCount)
+	})
+
+	t.Run("Verify MFA with unknown token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(nil, nil, cache.NewHeap[string](), nil)
+		verifyResp, err := sessSvc.VerifyMFA(ctx, &message.VerifyMFARequest{
+			Token: random.String(16), Code: "123456",
+		})
+		t.Logf("verifyResp, err: %+v, %v", verifyResp, err)
+		require.Nil(t, verifyResp)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Verify MFA with invalid codes", func(t *testing.T) {
+		t.Parallel()
+
+		_, encSecret, pwtKey := mfaSecret(t)
+		userID := uuid.NewV7().String()
+		orgID := uuid.NewV7().String()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadMFA(gomock.Any(), userID, orgID).
+			Return(&message.UserMFA{Enrolled: true}, encSecret, nil).
+			Times(maxMFAAttempts)
+		userer.EXPECT().UseRecoveryCode(gomock.Any(), userID, orgID,
+			gomock.Any()).Return(dao.ErrNotFound).Times(maxMFAAttempts)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		orgName := random.String(10)
+		email := random.Email()
+		c := &incrCache{Cacher: cache.NewHeap[string]()}
+		sessSvc := NewSession(userer, nil, c, pwtKey)
+		token := random.String(16)
+		require.NoError(t, setChallenge(ctx, sessSvc.cache, token,
+			&mfaChallenge{
+				UserID: userID, OrgID: orgID, OrgName: orgName, Email: email,
+				ExpiresAt: time.Now().Add(mfaChallengeExp),
+			}))
+
+		// Challenges are discarded after too many failed attempts.
+		for range maxMFAAttempts + 1 {
+			verifyResp, err := sessSvc.VerifyMFA(ctx,
+				&message.VerifyMFARequest{Token: token, Code: "000000"})
+			t.Logf("verifyResp, err: %+v, %v", verifyResp, err)
+			require.Nil(t, verifyResp)
+			require.Equal(t, status.Error(codes.Unauthenticated, errUnauth),
+				err)
+		}
+
+		_, err := c.Get(ctx, key.MFAChallenge(token))
+		require.Equal(t, cache.ErrNotFound, err)
+
+		// Failed codes are counted as failed logins.
+		failures, err := c.Get(ctx, key.LoginFailures(orgName, email))
+		require.NoError(t, err)
+		require.Equal(t, strconv.Itoa(maxMFAAttempts), failures)
+	})
+
+	t.Run("Verify MFA past max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		secret, _, pwtKey := mfaSecret(t)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		c := &incrCache{Cacher: cache.NewHeap[string]()}
+		sessSvc := NewSession(nil, nil, c, pwtKey)
+		token := random.String(16)
+		require.NoError(t, setChallenge(ctx, sessSvc.cache, token,
+			&mfaChallenge{
+				UserID: uuid.NewV7().String(), OrgID: uuid.NewV7().String(),
+				ExpiresAt: time.Now().Add(mfaChallengeExp),
+			}))
+		require.NoError(t, c.Set(ctx, key.MFAAttempts(token),
+			strconv.Itoa(maxMFAAttempts)))
+
+		code, err := auth.TOTP(secret, time.Now())
+		require.NoError(t, err)
+
+		verifyResp, err := sessSvc.VerifyMFA(ctx, &message.VerifyMFARequest{
+			Token: token, Code: code,
+		})
+		t.Logf("verifyResp, err: %+v, %v", verifyResp, err)
+		require.Nil(t, verifyResp)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Verify MFA when locked out", func(t *testing.T) {
+		t.Parallel()
+
+		orgName := random.String(10)
+		email := random.Email()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		c := cache.NewHeap[string]()
+		require.NoError(t, c.Set(ctx, key.LoginFailures(orgName, email),
+			strconv.Itoa(loginLockout)))
+
+		sessSvc := NewSession(nil, nil, c, nil)
+		token := random.String(16)
+		require.NoError(t, setChallenge(ctx, sessSvc.cache, token,
+			&mfaChallenge{
+				UserID: uuid.NewV7().String(), OrgID: uuid.NewV7().String(),
+				OrgName: orgName, Email: email,
+				ExpiresAt: time.Now().Add(mfaChallengeExp),
+			}))
+
+		verifyResp, err := sessSvc.VerifyMFA(ctx, &message.VerifyMFARequest{
+			Token: token, Code: "000000",
+		})
+		t.Logf("verifyResp, err: %+v, %v", verifyResp, err)
+		require.Nil(t, verifyResp)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+
+		_, err = c.Get(ctx, key.MFAChallenge(token))
+		require.Equal(t, cache.ErrNotFound, err)
+	})
+
+	t.Run("Verify MFA with disabled user", func(t *testing.T) {
+		t.Parallel()
+
+		secret, encSecret, pwtKey := mfaSecret(t)
+		user := random.User("api-mfa", uuid.NewV7().String())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_DISABLED
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(&message.UserMFA{Enrolled: true}, encSecret, nil).Times(1)
+		userer.EXPECT().UseMFAStep(gomock.Any(), user.GetId(),
+			user.GetOrgId(), gomock.Any()).Return(nil).Times(1)
+		userer.EXPECT().Read(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(user, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil,
+			&incrCache{Cacher: cache.NewHeap[string]()}, pwtKey)
+		token := random.String(16)
+		require.NoError(t, setChallenge(ctx, sessSvc.cache, token,
+			&mfaChallenge{
+				UserID: user.GetId(), OrgID: user.GetOrgId(),
+				ExpiresAt: time.Now().Add(mfaChallengeExp),
+			}))
+
+		code, err := auth.TOTP(secret, time.Now())
+		require.NoError(t, err)
+
+		verifyResp, err := sessSvc.VerifyMFA(ctx, &message.VerifyMFARequest{
+			Token: token, Code: code,
+		})
+		t.Logf("verifyResp, err: %+v, %v", verifyResp, err)
+		require.Nil(t, verifyResp)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+}
+
+func TestEnrollMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Enroll MFA by session", func(t *testing.T) {
+		t.Parallel()
+
+		user := random.User("api-mfa", uuid.NewV7().String())
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().Read(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(user, nil).Times(1)
+		userer.EXPECT().UpsertMFA(gomock.Any(), user.GetId(), user.GetOrgId(),
+			gomock.Any()).Return(nil).Times(1)
+
+		pwtKey := make([]byte, 32)
+		_, err := rand.Read(pwtKey)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: user.GetId(), OrgID: user.GetOrgId(),
+				Role: api.Role_VIEWER,
+			}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, pwtKey)
+		enroll, err := sessSvc.EnrollMFA(ctx, &message.EnrollMFARequest{})
+		t.Logf("enroll, err: %+v, %v", enroll, err)
+		require.NoError(t, err)
+		require.Len(t, enroll.GetSecret(), 32)
+		require.Contains(t, enroll.GetUri(), "otpauth://totp/")
+		require.Contains(t, enroll.GetUri(), enroll.GetSecret())
+	})
+
+	t.Run("Enroll MFA by challenge", func(t *testing.T) {
+		t.Parallel()
+
+		user := random.User("api-mfa", uuid.NewV7().String())
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().Read(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(user, nil).Times(1)
+		userer.EXPECT().UpsertMFA(gomock.Any(), user.GetId(), user.GetOrgId(),
+			gomock.Any()).Return(nil).Times(1)
+
+		pwtKey := make([]byte, 32)
+		_, err := rand.Read(pwtKey)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, cache.NewHeap[string](), pwtKey)
+		token := random.String(16)
+		require.NoError(t, setChallenge(ctx, sessSvc.cache, token,
+			&mfaChallenge{
+				UserID: user.GetId(), OrgID: user.GetOrgId(), Enroll: true,
+				ExpiresAt: time.Now().Add(mfaChallengeExp),
+			}))
+
+		enroll, err := sessSvc.EnrollMFA(ctx, &message.EnrollMFARequest{
+			Token: token,
+		})
+		t.Logf("enroll, err: %+v, %v", enroll, err)
+		require.NoError(t, err)
+		require.Len(t, enroll.GetSecret(), 32)
+	})
+
+	t.Run("Enroll MFA by non-enrollment challenge", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(nil, nil, cache.NewHeap[string](), nil)
+		token := random.String(16)
+		require.NoError(t, setChallenge(ctx, sessSvc.cache, token,
+			&mfaChallenge{
+				UserID: uuid.NewV7().String(), OrgID: uuid.NewV7().String(),
+				ExpiresAt: time.Now().Add(mfaChallengeExp),
+			}))
+
+		enroll, err := sessSvc.EnrollMFA(ctx, &message.EnrollMFARequest{
+			Token: token,
+		})
+		t.Logf("enroll, err: %+v, %v", enroll, err)
+		require.Nil(t, enroll)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Enroll MFA by API key", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				KeyID: uuid.NewV7().String(), OrgID: uuid.NewV7().String(),
+				Role: api.Role_ADMIN,
+			}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(nil, nil, nil, nil)
+		enroll, err := sessSvc.EnrollMFA(ctx, &message.EnrollMFARequest{})
+		t.Logf("enroll, err: %+v, %v", enroll, err)
+		require.Nil(t, enroll)
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Enroll MFA when enrolled", func(t *testing.T) {
+		t.Parallel()
+
+		user := random.User("api-mfa", uuid.NewV7().String())
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().Read(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(user, nil).Times(1)
+		userer.EXPECT().UpsertMFA(gomock.Any(), user.GetId(), user.GetOrgId(),
+			gomock.Any()).Return(dao.ErrNotFound).Times(1)
+
+		pwtKey := make([]byte, 32)
+		_, err := rand.Read(pwtKey)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: user.GetId(), OrgID: user.GetOrgId(),
+				Role: api.Role_ADMIN,
+			}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, pwtKey)
+		enroll, err := sessSvc.EnrollMFA(ctx, &message.EnrollMFARequest{})
+		t.Logf("enroll, err: %+v, %v", enroll, err)
+		require.Nil(t, enroll)
+		require.Equal(t, status.Error(codes.FailedPrecondition,
+			"MFA already enrolled"), err)
+	})
+}
+
+func TestConfirmMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Confirm MFA", func(t *testing.T) {
+		t.Parallel()
+
+		secret, encSecret, pwtKey := mfaSecret(t)
+		userID := uuid.NewV7().String()
+		orgID := uuid.NewV7().String()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadMFA(gomock.Any(), userID, orgID).
+			Return(&message.UserMFA{}, encSecret, nil).Times(1)
+		userer.EXPECT().ConfirmMFA(gomock.Any(), userID, orgID, gomock.Any(),
+			gomock.Len(recoveryCodeCount)).Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{UserID: userID, OrgID: orgID,
+				Role: api.Role_VIEWER}), testTimeout)
+		defer cancel()
+
+		code, err := auth.TOTP(secret, time.Now())
+		require.NoError(t, err)
+
+		sessSvc := NewSession(userer, nil, nil, pwtKey)
+		recCodes, err := sessSvc.ConfirmMFA(ctx, &message.ConfirmMFARequest{
+			Code: code,
+		})
+		t.Logf("recCodes, err: %+v, %v", recCodes, err)
+		require.NoError(t, err)
+		require.Len(t, recCodes.GetCodes(), recoveryCodeCount)
+	})
+
+	t.Run("Confirm MFA with invalid code", func(t *testing.T) {
+		t.Parallel()
+
+		_, encSecret, pwtKey := mfaSecret(t)
+		userID := uuid.NewV7().String()
+		orgID := uuid.NewV7().String()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadMFA(gomock.Any(), userID, orgID).
+			Return(&message.UserMFA{}, encSecret, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{UserID: userID, OrgID: orgID,
+				Role: api.Role_VIEWER}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, pwtKey)
+		recCodes, err := sessSvc.ConfirmMFA(ctx, &message.ConfirmMFARequest{
+			Code: "12345",
+		})
+		t.Logf("recCodes, err: %+v, %v", recCodes, err)
+		require.Nil(t, recCodes)
+		require.Equal(t, status.Error(codes.InvalidArgument, "invalid code"),
+			err)
+	})
+
+	t.Run("Confirm MFA when not pending", func(t *testing.T) {
+		t.Parallel()
+
+		userID := uuid.NewV7().String()
+		orgID := uuid.NewV7().String()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadMFA(gomock.Any(), userID, orgID).
+			Return(&message.UserMFA{}, nil, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{UserID: userID, OrgID: orgID,
+				Role: api.Role_VIEWER}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		recCodes, err := sessSvc.ConfirmMFA(ctx, &message.ConfirmMFARequest{
+			Code: "123456",
+		})
+		t.Logf("recCodes, err: %+v, %v", recCodes, err)
+		require.Nil(t, recCodes)
+		require.Equal(t, status.Error(codes.FailedPrecondition,
+			"MFA enrollment not pending"), err)
+	})
+
+	t.Run("Confirm MFA with invalid session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(nil, nil, nil, nil)
+		recCodes, err := sessSvc.ConfirmMFA(ctx, &message.ConfirmMFARequest{})
+		t.Logf("recCodes, err: %+v, %v", recCodes, err)
+		require.Nil(t, recCodes)
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestGetUserMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get MFA by admin", func(t *testing.T) {
+		t.Parallel()
+
+		mfa := &message.UserMFA{
+			UserId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+			Enrolled: true, RecoveryCodes: 10,
+		}
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadMFA(gomock.Any(), mfa.GetUserId(),
+			mfa.GetOrgId()).Return(mfa, []byte("secret"), nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: mfa.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		getMFA, err := sessSvc.GetUserMFA(ctx, mfa.GetUserId())
+		t.Logf("getMFA, err: %+v, %v", getMFA, err)
+		require.NoError(t, err)
+		require.Equal(t, mfa, getMFA)
+	})
+
+	t.Run("Get MFA with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: uuid.NewV7().String(), OrgID: uuid.NewV7().String(),
+				Role: api.Role_BUILDER,
+			}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(nil, nil, nil, nil)
+		getMFA, err := sessSvc.GetUserMFA(ctx, uuid.NewV7().String())
+		t.Logf("getMFA, err: %+v, %v", getMFA, err)
+		require.Nil(t, getMFA)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Get MFA of unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		userID := uuid.NewV7().String()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadMFA(gomock.Any(), userID, gomock.Any()).
+			Return(nil, nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: userID, OrgID: uuid.NewV7().String(),
+				Role: api.Role_VIEWER,
+			}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		getMFA, err := sessSvc.GetUserMFA(ctx, userID)
+		t.Logf("getMFA, err: %+v, %v", getMFA, err)
+		require.Nil(t, getMFA)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestResetUserMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Reset MFA by admin", func(t *testing.T) {
+		t.Parallel()
+
+		userID := uuid.NewV7().String()
+		orgID := uuid.NewV7().String()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().DeleteMFA(gomock.Any(), userID, orgID).Return(nil).
+			Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		err := sessSvc.ResetUserMFA(ctx, userID)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Reset MFA with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: uuid.NewV7().String(), OrgID: uuid.NewV7().String(),
+				Role: api.Role_BUILDER,
+			}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(nil, nil, nil, nil)
+		err := sessSvc.ResetUserMFA(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Reset own MFA with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		userID := uuid.NewV7().String()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: userID, OrgID: uuid.NewV7().String(),
+				Role: api.Role_BUILDER,
+			}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(nil, nil, nil, nil)
+		err := sessSvc.ResetUserMFA(ctx, userID)
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Reset MFA with DAO failure", func(t *testing.T) {
+		t.Parallel()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().DeleteMFA(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(errors.New("delete failure")).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN,
+			}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		err := sessSvc.ResetUserMFA(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, codes.Unknown, status.Code(err))
+	})
+}
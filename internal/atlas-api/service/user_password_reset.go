@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-api/auth"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/notify"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Constants used for password resets.
+const (
+	resetTokenExp     = 30 * time.Minute
+	resetRequestDelay = time.Minute
+	resetCompleteRate = time.Second
+	resetEmailTimeout = time.Minute
+
+	resetDisplayName = "Thingspect"
+	resetSubject     = "Password reset"
+	resetBody        = "A password reset was requested for your account in " +
+		"organization %s. Use the following token within %v to set a new " +
+		"password:\n\n%s\n\nIf you did not request a reset, you may ignore " +
+		"this message."
+)
+
+// errResetRate is returned when password reset requests are rate limited.
+const errResetRate = "too many password reset requests, try again later"
+
+// resetToken holds the pending state of a password reset.
+type resetToken struct {
+	UserID string `json:"userID"`
+	OrgID  string `json:"orgID"`
+}
+
+// PasswordReset service contains functions to reset forgotten passwords.
+type PasswordReset struct {
+	userDAO   Userer
+	notify    notify.Notifier
+	cache     cache.Cacher[string]
+	emailFrom string
+}
+
+// NewPasswordReset instantiates and returns a new PasswordReset service.
+func NewPasswordReset(
+	userDAO Userer, notify notify.Notifier, cache cache.Cacher[string],
+	emailFrom string,
+) *PasswordReset {
+	return &PasswordReset{
+		userDAO:   userDAO,
+		notify:    notify,
+		cache:     cache,
+		emailFrom: emailFrom,
+	}
+}
+
+// RequestPasswordReset emails a single-use password reset token to a user.
+// To prevent account enumeration, the result does not depend on whether the
+// user exists, and email is delivered asynchronously. Requests are limited to
+// one per email and organization per resetRequestDelay.
+func (p *PasswordReset) RequestPasswordReset(
+	ctx context.Context, req *message.RequestPasswordResetRequest,
+) error {
+	logger := alog.FromContext(ctx)
+
+	err := p.cache.SetIfNotExistTTL(ctx, key.PasswordResetRate(
+		req.GetOrgName(), req.GetEmail()), "", resetRequestDelay)
+	if errors.Is(err, cache.ErrAlreadyExists) {
+		return status.Error(codes.ResourceExhausted, errResetRate)
+	}
+	if err != nil {
+		logger.Errorf("RequestPasswordReset p.cache.SetIfNotExistTTL: %v", err)
+
+		return errToStatus(err)
+	}
+
+	user, _, err := p.userDAO.ReadByEmail(ctx, req.GetEmail(),
+		req.GetOrgName())
+	if err != nil || user.GetStatus() != api.Status_ACTIVE ||
+		user.GetRole() < api.Role_VIEWER {
+		logger.Debugf("RequestPasswordReset p.userDAO.ReadByEmail Email, "+
+			"OrgName, err: %v, %v, %v", req.GetEmail(), req.GetOrgName(), err)
+
+		return nil
+	}
+
+	// Reset tokens share the format of refresh tokens, and only their hashes
+	// are cached. Each request replaces a user's previous token.
+	token, bHash, err := session.GenerateRefreshToken()
+	if err != nil {
+		logger.Errorf("RequestPasswordReset session.GenerateRefreshToken: %v",
+			err)
+
+		return errToStatus(err)
+	}
+	hash := hex.EncodeToString(bHash)
+
+	bReset, err := json.Marshal(&resetToken{
+		UserID: user.GetId(), OrgID: user.GetOrgId(),
+	})
+	if err != nil {
+		logger.Errorf("RequestPasswordReset json.Marshal: %v", err)
+
+		return errToStatus(err)
+	}
+
+	if err := p.cache.SetTTL(ctx, key.PasswordReset(hash), string(bReset),
+		resetTokenExp); err != nil {
+		logger.Errorf("RequestPasswordReset p.cache.SetTTL: %v", err)
+
+		return errToStatus(err)
+	}
+
+	if err := p.cache.SetTTL(ctx, key.PasswordResetUser(user.GetOrgId(),
+		user.GetId()), hash, resetTokenExp); err != nil {
+		logger.Errorf("RequestPasswordReset p.cache.SetTTL user: %v", err)
+
+		return errToStatus(err)
+	}
+
+	go func() {
+		eCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx),
+			resetEmailTimeout)
+		defer cancel()
+
+		if err := p.notify.Email(eCtx, resetDisplayName, p.emailFrom,
+			user.GetEmail(), resetSubject, fmt.Sprintf(resetBody,
+				req.GetOrgName(), resetTokenExp, token)); err != nil {
+			logger.Errorf("RequestPasswordReset p.notify.Email: %v", err)
+		}
+	}()
+
+	logger.Infof("RequestPasswordReset sent reset token to user: %v",
+		user.GetId())
+
+	return nil
+}
+
+// CompletePasswordReset sets a new password using a password reset token, and
+// revokes the user's sessions. Attempts are limited to one per client IP per
+// resetCompleteRate.
+func (p *PasswordReset) CompletePasswordReset(
+	ctx context.Context, req *message.CompletePasswordResetRequest,
+) error {
+	logger := alog.FromContext(ctx)
+
+	if req.GetToken() == "" {
+		return status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	if ip := clientIP(ctx); ip != "" {
+		err := p.cache.SetIfNotExistTTL(ctx, key.PasswordResetAttempt(ip), "",
+			resetCompleteRate)
+		if errors.Is(err, cache.ErrAlreadyExists) {
+			return status.Error(codes.ResourceExhausted, errResetRate)
+		}
+		if err != nil {
+			logger.Errorf("CompletePasswordReset p.cache.SetIfNotExistTTL: "+
+				"%v", err)
+
+			return errToStatus(err)
+		}
+	}
+
+	// Check the password before the token is used, so that a rejected
+	// password does not consume it.
+	if err := auth.CheckPass(req.GetPassword()); err != nil {
+		return errToStatus(err)
+	}
+
+	passHash, err := auth.HashPass(req.GetPassword())
+	if err != nil {
+		logger.Errorf("CompletePasswordReset crypto.HashPass: %v", err)
+
+		return errToStatus(auth.ErrWeakPass)
+	}
+
+	// Tokens may be used once.
+	hash := hex.EncodeToString(session.HashRefreshToken(req.GetToken()))
+	bReset, err := p.cache.GetDel(ctx, key.PasswordReset(hash))
+	if err != nil {
+		logger.Debugf("CompletePasswordReset p.cache.GetDel: %v", err)
+
+		return status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	reset := &resetToken{}
+	if err := json.Unmarshal([]byte(bReset), reset); err != nil {
+		logger.Errorf("CompletePasswordReset json.Unmarshal: %v", err)
+
+		return status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	// Tokens that have been replaced by a newer request are invalid.
+	latest, err := p.cache.Get(ctx, key.PasswordResetUser(reset.OrgID,
+		reset.UserID))
+	if err != nil || latest != hash {
+		logger.Debugf("CompletePasswordReset p.cache.Get user: %v", err)
+
+		return status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	if err := p.userDAO.UpdatePassword(ctx, reset.UserID, reset.OrgID,
+		passHash); err != nil {
+		return errToStatus(err)
+	}
+
+	if err := revokeSessions(ctx, p.userDAO, p.cache, reset.UserID,
+		reset.OrgID); err != nil {
+		return errToStatus(err)
+	}
+
+	logger.Infof("CompletePasswordReset reset password of user: %v",
+		reset.UserID)
+
+	return nil
+}
@@ -0,0 +1,368 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/auth"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/notify"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// resetEmail returns a Notifier that expects a password reset email to a user
+// and sends its token on the returned channel.
+func resetEmail(t *testing.T, ctrl *gomock.Controller, user *api.User) (
+	notify.Notifier, <-chan string,
+) {
+	t.Helper()
+
+	tokens := make(chan string, 1)
+
+	notifier := notify.NewMockNotifier(ctrl)
+	notifier.EXPECT().Email(gomock.Any(), resetDisplayName, "api@example.com",
+		user.GetEmail(), resetSubject, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _, _, _, body string) error {
+			tokens <- strings.Split(body, "\n\n")[1]
+
+			return nil
+		}).Times(1)
+
+	return notifier, tokens
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Request reset for valid user", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-reset")
+		user := random.User("api-reset", org.GetId())
+		user.Role = api.Role_VIEWER
+		user.Status = api.Status_ACTIVE
+
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, globalHash, nil).Times(1)
+		notifier, tokens := resetEmail(t, ctrl, user)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		resetSvc := NewPasswordReset(userer, notifier, cache.NewHeap[string](),
+			"api@example.com")
+		err := resetSvc.RequestPasswordReset(ctx,
+			&message.RequestPasswordResetRequest{
+				Email: user.GetEmail(), OrgName: org.GetName(),
+			})
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		select {
+		case token := <-tokens:
+			t.Logf("token: %v", token)
+			require.Len(t, token, 43)
+		case <-ctx.Done():
+			t.Fatal("Email timed out")
+		}
+	})
+
+	t.Run("Request reset for unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadByEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		resetSvc := NewPasswordReset(userer, nil, cache.NewHeap[string](),
+			"api@example.com")
+		err := resetSvc.RequestPasswordReset(ctx,
+			&message.RequestPasswordResetRequest{
+				Email: random.Email(), OrgName: random.String(10),
+			})
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Request reset for disabled user", func(t *testing.T) {
+		t.Parallel()
+
+		user := random.User("api-reset", uuid.NewV7().String())
+		user.Status = api.Status_DISABLED
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			gomock.Any()).Return(user, globalHash, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		resetSvc := NewPasswordReset(userer, nil, cache.NewHeap[string](),
+			"api@example.com")
+		err := resetSvc.RequestPasswordReset(ctx,
+			&message.RequestPasswordResetRequest{
+				Email: user.GetEmail(), OrgName: random.String(10),
+			})
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Request reset with rate limit", func(t *testing.T) {
+		t.Parallel()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadByEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		req := &message.RequestPasswordResetRequest{
+			Email: random.Email(), OrgName: random.String(10),
+		}
+
+		resetSvc := NewPasswordReset(userer, nil, cache.NewHeap[string](),
+			"api@example.com")
+		err := resetSvc.RequestPasswordReset(ctx, req)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		err = resetSvc.RequestPasswordReset(ctx, req)
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.ResourceExhausted, errResetRate),
+			err)
+	})
+
+	t.Run("Request reset with cache failure", func(t *testing.T) {
+		t.Parallel()
+
+		cacher := cache.NewMockCacher[string](gomock.NewController(t))
+		cacher.EXPECT().SetIfNotExistTTL(gomock.Any(), gomock.Any(), "",
+			resetRequestDelay).Return(errors.New("cache failure")).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		resetSvc := NewPasswordReset(nil, nil, cacher, "api@example.com")
+		err := resetSvc.RequestPasswordReset(ctx,
+			&message.RequestPasswordResetRequest{
+				Email: random.Email(), OrgName: random.String(10),
+			})
+		t.Logf("err: %v", err)
+		require.Equal(t, codes.Unknown, status.Code(err))
+	})
+}
+
+func TestCompletePasswordReset(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Complete reset with valid token", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-reset")
+		user := random.User("api-reset", org.GetId())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+		sessIDs := []string{uuid.NewV7().String()}
+
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, globalHash, nil).Times(1)
+		userer.EXPECT().UpdatePassword(gomock.Any(), user.GetId(),
+			org.GetId(), gomock.Any()).Return(nil).Times(1)
+		userer.EXPECT().RevokeSessions(gomock.Any(), user.GetId(),
+			org.GetId()).Return(sessIDs, nil).Times(1)
+		notifier, tokens := resetEmail(t, ctrl, user)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		resetSvc := NewPasswordReset(userer, notifier, cache.NewHeap[string](),
+			"api@example.com")
+		require.NoError(t, resetSvc.RequestPasswordReset(ctx,
+			&message.RequestPasswordResetRequest{
+				Email: user.GetEmail(), OrgName: org.GetName(),
+			}))
+
+		var token string
+		select {
+		case token = <-tokens:
+		case <-ctx.Done():
+			t.Fatal("Email timed out")
+		}
+
+		req := &message.CompletePasswordResetRequest{
+			Token: token, Password: random.String(20),
+		}
+		err := resetSvc.CompletePasswordReset(ctx, req)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		// Tokens may be used once.
+		_, err = resetSvc.cache.Get(ctx, key.PasswordReset(hex.EncodeToString(
+			session.HashRefreshToken(token))))
+		require.Equal(t, cache.ErrNotFound, err)
+	})
+
+	t.Run("Complete reset with replaced token", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-reset")
+		user := random.User("api-reset", org.GetId())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, globalHash, nil).Times(2)
+
+		tokens := make(chan string, 2)
+		notifier := notify.NewMockNotifier(ctrl)
+		notifier.EXPECT().Email(gomock.Any(), gomock.Any(), gomock.Any(),
+			user.GetEmail(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, _, _, _, body string) error {
+				tokens <- strings.Split(body, "\n\n")[1]
+
+				return nil
+			}).Times(2)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		c := cache.NewHeap[string]()
+		req := &message.RequestPasswordResetRequest{
+			Email: user.GetEmail(), OrgName: org.GetName(),
+		}
+
+		resetSvc := NewPasswordReset(userer, notifier, c, "api@example.com")
+		require.NoError(t, resetSvc.RequestPasswordReset(ctx, req))
+
+		var oldToken string
+		select {
+		case oldToken = <-tokens:
+		case <-ctx.Done():
+			t.Fatal("Email timed out")
+		}
+
+		// Clear the rate limit to request a replacement token.
+		require.NoError(t, c.Del(ctx, key.PasswordResetRate(req.GetOrgName(),
+			req.GetEmail())))
+		require.NoError(t, resetSvc.RequestPasswordReset(ctx, req))
+
+		select {
+		case <-tokens:
+		case <-ctx.Done():
+			t.Fatal("Email timed out")
+		}
+
+		err := resetSvc.CompletePasswordReset(ctx,
+			&message.CompletePasswordResetRequest{
+				Token: oldToken, Password: random.String(20),
+			})
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Complete reset with unknown token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		resetSvc := NewPasswordReset(nil, nil, cache.NewHeap[string](),
+			"api@example.com")
+
+		for _, token := range []string{"", random.String(43)} {
+			err := resetSvc.CompletePasswordReset(ctx,
+				&message.CompletePasswordResetRequest{
+					Token: token, Password: random.String(20),
+				})
+			t.Logf("err: %v", err)
+			require.Equal(t, status.Error(codes.Unauthenticated, errUnauth),
+				err)
+		}
+	})
+
+	t.Run("Complete reset with weak password", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-reset")
+		user := random.User("api-reset", org.GetId())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+		ip := "10.0.0.1"
+
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, globalHash, nil).Times(1)
+		userer.EXPECT().UpdatePassword(gomock.Any(), user.GetId(),
+			org.GetId(), gomock.Any()).Return(nil).Times(1)
+		userer.EXPECT().RevokeSessions(gomock.Any(), user.GetId(),
+			org.GetId()).Return(nil, nil).Times(1)
+		notifier, tokens := resetEmail(t, ctrl, user)
+
+		ctx, cancel := context.WithTimeout(peer.NewContext(t.Context(),
+			&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip)}}), testTimeout)
+		defer cancel()
+
+		c := cache.NewHeap[string]()
+		resetSvc := NewPasswordReset(userer, notifier, c, "api@example.com")
+		require.NoError(t, resetSvc.RequestPasswordReset(ctx,
+			&message.RequestPasswordResetRequest{
+				Email: user.GetEmail(), OrgName: org.GetName(),
+			}))
+
+		var token string
+		select {
+		case token = <-tokens:
+		case <-ctx.Done():
+			t.Fatal("Email timed out")
+		}
+
+		req := &message.CompletePasswordResetRequest{
+			Token: token, Password: "1234",
+		}
+		err := resetSvc.CompletePasswordReset(ctx, req)
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			auth.ErrWeakPass.Error()), err)
+
+		// Attempts are limited by client IP.
+		err = resetSvc.CompletePasswordReset(ctx, req)
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.ResourceExhausted, errResetRate),
+			err)
+
+		// A rejected password does not consume the token.
+		require.NoError(t, c.Del(ctx, key.PasswordResetAttempt(ip)))
+		req.Password = random.String(20)
+		err = resetSvc.CompletePasswordReset(ctx, req)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"time"
+	"uuid"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Constants used for notification schedule bounds.
+const (
+	maxSchedWindows  = 50
+	maxSchedCritical = 100
+	minutesPerDay    = 24 * 60
+)
+
+// GetUserSchedule retrieves a user's notification schedule by user ID. Users
+// may retrieve their own schedule, and admins any schedule in the org.
+func (u *User) GetUserSchedule(ctx context.Context, userID string) (
+	*message.NotificationSchedule, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || (sess.Role < api.Role_ADMIN && userID != sess.UserID) {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	sched, err := u.userDAO.ReadSchedule(ctx, userID, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return sched, nil
+}
+
+// UpdateUserSchedule creates or replaces a user's notification schedule by
+// user ID. Users may update their own schedule, and admins any schedule in the
+// org.
+func (u *User) UpdateUserSchedule(
+	ctx context.Context, userID string, sched *message.NotificationSchedule,
+) (*message.NotificationSchedule, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || (sess.Role < api.Role_ADMIN && userID != sess.UserID) {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	if err := validateSchedule(sched); err != nil {
+		return nil, err
+	}
+
+	sched.UserId = userID
+	sched.OrgId = sess.OrgID
+
+	upSched, err := u.userDAO.UpsertSchedule(ctx, sched)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return upSched, nil
+}
+
+// DeleteUserSchedule deletes a user's notification schedule by user ID. Alerts
+// to the user are no longer restricted.
+func (u *User) DeleteUserSchedule(ctx context.Context, userID string) error {
+	sess, ok := session.FromContext(ctx)
+	if !ok || (sess.Role < api.Role_ADMIN && userID != sess.UserID) {
+		return errPerm(api.Role_ADMIN)
+	}
+
+	if err := u.userDAO.DeleteSchedule(ctx, userID, sess.OrgID); err != nil {
+		return errToStatus(err)
+	}
+
+	return nil
+}
+
+// validateSchedule validates a notification schedule.
+func validateSchedule(sched *message.NotificationSchedule) error {
+	if _, err := time.LoadLocation(sched.GetTimezone()); err != nil {
+		return status.Error(codes.InvalidArgument, "unknown timezone")
+	}
+
+	if len(sched.GetWindows()) > maxSchedWindows {
+		return status.Error(codes.InvalidArgument, "too many windows")
+	}
+
+	for _, win := range sched.GetWindows() {
+		if !validAlarmType(win.GetType()) {
+			return status.Error(codes.InvalidArgument,
+				"unknown window alarm type")
+		}
+
+		for _, day := range win.GetDays() {
+			if day < 0 || day > 6 {
+				return status.Error(codes.InvalidArgument,
+					"window days must be in the range [0, 6]")
+			}
+		}
+
+		if win.GetStartMinute() < 0 || win.GetStartMinute() >= minutesPerDay ||
+			win.GetEndMinute() < 0 || win.GetEndMinute() >= minutesPerDay {
+			return status.Error(codes.InvalidArgument,
+				"window minutes must be in the range [0, 1440)")
+		}
+	}
+
+	if _, ok := message.ScheduleAction_name[int32(sched.GetAction())]; !ok {
+		return status.Error(codes.InvalidArgument, "unknown schedule action")
+	}
+
+	if !validAlarmType(sched.GetFallbackType()) ||
+		(sched.GetAction() == message.ScheduleAction_FALLBACK &&
+			sched.GetFallbackType() == api.AlarmType_ALARM_TYPE_UNSPECIFIED) {
+		return status.Error(codes.InvalidArgument,
+			"invalid fallback alarm type")
+	}
+
+	if len(sched.GetCriticalAlarmIds()) > maxSchedCritical {
+		return status.Error(codes.InvalidArgument, "too many critical alarms")
+	}
+
+	for _, alarmID := range sched.GetCriticalAlarmIds() {
+		if _, err := uuid.Parse(alarmID); err != nil {
+			return status.Error(codes.InvalidArgument,
+				"critical alarm IDs must be UUIDs")
+		}
+	}
+
+	return nil
+}
+
+// validAlarmType returns whether an alarm type is known. The unspecified alarm
+// type is valid.
+func validAlarmType(alarmType api.AlarmType) bool {
+	_, ok := api.AlarmType_name[int32(alarmType)]
+
+	return ok
+}
@@ -0,0 +1,314 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// testSchedule returns a valid notification schedule.
+func testSchedule() *message.NotificationSchedule {
+	return &message.NotificationSchedule{
+		Timezone: "America/Chicago",
+		Windows: []*message.ScheduleWindow{
+			{
+				Type: api.AlarmType_EMAIL, Days: []int32{1, 2, 3, 4, 5},
+				StartMinute: 540, EndMinute: 1020,
+			},
+			{StartMinute: 1320, EndMinute: 360},
+		},
+		Action:           message.ScheduleAction_FALLBACK,
+		FallbackType:     api.AlarmType_SMS,
+		CriticalAlarmIds: []string{uuid.NewV7().String()},
+	}
+}
+
+func TestGetUserSchedule(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		inpRole   api.Role
+		inpIsSelf bool
+	}{
+		{api.Role_ADMIN, false},
+		{api.Role_VIEWER, true},
+	} {
+		t.Run(fmt.Sprintf("Can get %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			sched := testSchedule()
+			sched.UserId = uuid.NewV7().String()
+			sched.OrgId = uuid.NewV7().String()
+			retSched, _ := proto.Clone(sched).(*message.NotificationSchedule)
+
+			userer := NewMockUserer(gomock.NewController(t))
+			userer.EXPECT().ReadSchedule(gomock.Any(), sched.GetUserId(),
+				sched.GetOrgId()).Return(retSched, nil).Times(1)
+
+			sessUserID := uuid.NewV7().String()
+			if test.inpIsSelf {
+				sessUserID = sched.GetUserId()
+			}
+
+			ctx, cancel := context.WithTimeout(session.NewContext(
+				t.Context(), &session.Session{
+					UserID: sessUserID, OrgID: sched.GetOrgId(),
+					Role: test.inpRole,
+				}), testTimeout)
+			defer cancel()
+
+			userSvc := NewUser(userer, nil, nil)
+			getSched, err := userSvc.GetUserSchedule(ctx, sched.GetUserId())
+			t.Logf("sched, getSched, err: %+v, %+v, %v", sched, getSched, err)
+			require.NoError(t, err)
+
+			// Testify does not currently support protobuf equality:
+			// https://github.com/stretchr/testify/issues/758
+			if !proto.Equal(sched, getSched) {
+				t.Fatalf("\nExpect: %+v\nActual: %+v", sched, getSched)
+			}
+		})
+	}
+
+	t.Run("Get schedule of another user with insufficient role", func(
+		t *testing.T,
+	) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: uuid.NewV7().String(),
+				OrgID:  uuid.NewV7().String(),
+				Role:   api.Role_BUILDER,
+			}), testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(nil, nil, nil)
+		getSched, err := userSvc.GetUserSchedule(ctx, uuid.NewV7().String())
+		t.Logf("getSched, err: %+v, %v", getSched, err)
+		require.Nil(t, getSched)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Get schedule by unknown user ID", func(t *testing.T) {
+		t.Parallel()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadSchedule(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(userer, nil, nil)
+		getSched, err := userSvc.GetUserSchedule(ctx, uuid.NewV7().String())
+		t.Logf("getSched, err: %+v, %v", getSched, err)
+		require.Nil(t, getSched)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestUpdateUserSchedule(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Update own schedule", func(t *testing.T) {
+		t.Parallel()
+
+		userID := uuid.NewV7().String()
+		orgID := uuid.NewV7().String()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().UpsertSchedule(gomock.Any(), gomock.Cond(
+			func(inp *message.NotificationSchedule) bool {
+				return inp.GetUserId() == userID && inp.GetOrgId() == orgID
+			})).DoAndReturn(func(_ any, sched *message.NotificationSchedule) (
+			*message.NotificationSchedule, error,
+		) {
+			return sched, nil
+		}).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: userID, OrgID: orgID, Role: api.Role_CONTACT,
+			}), testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(userer, nil, nil)
+		upSched, err := userSvc.UpdateUserSchedule(ctx, userID, testSchedule())
+		t.Logf("upSched, err: %+v, %v", upSched, err)
+		require.NoError(t, err)
+		require.Equal(t, "America/Chicago", upSched.GetTimezone())
+	})
+
+	t.Run("Update schedule of another user with insufficient role", func(
+		t *testing.T,
+	) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: uuid.NewV7().String(),
+				OrgID:  uuid.NewV7().String(),
+				Role:   api.Role_BUILDER,
+			}), testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(nil, nil, nil)
+		upSched, err := userSvc.UpdateUserSchedule(ctx, uuid.NewV7().String(),
+			testSchedule())
+		t.Logf("upSched, err: %+v, %v", upSched, err)
+		require.Nil(t, upSched)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	badTZ := testSchedule()
+	badTZ.Timezone = "Mars/Olympus_Mons"
+
+	tooManyWindows := testSchedule()
+	tooManyWindows.Windows = make([]*message.ScheduleWindow, 51)
+
+	badWindowType := testSchedule()
+	badWindowType.Windows[0].Type = 99
+
+	badDay := testSchedule()
+	badDay.Windows[0].Days = []int32{7}
+
+	badMinute := testSchedule()
+	badMinute.Windows[1].EndMinute = 1440
+
+	badAction := testSchedule()
+	badAction.Action = 99
+
+	noFallback := testSchedule()
+	noFallback.FallbackType = api.AlarmType_ALARM_TYPE_UNSPECIFIED
+
+	tooManyCritical := testSchedule()
+	tooManyCritical.CriticalAlarmIds = make([]string, 101)
+
+	badCritical := testSchedule()
+	badCritical.CriticalAlarmIds = []string{"api-schedule"}
+
+	for _, test := range []struct {
+		inpSched *message.NotificationSchedule
+		err      string
+	}{
+		{badTZ, "unknown timezone"},
+		{tooManyWindows, "too many windows"},
+		{badWindowType, "unknown window alarm type"},
+		{badDay, "window days must be in the range [0, 6]"},
+		{badMinute, "window minutes must be in the range [0, 1440)"},
+		{badAction, "unknown schedule action"},
+		{noFallback, "invalid fallback alarm type"},
+		{tooManyCritical, "too many critical alarms"},
+		{badCritical, "critical alarm IDs must be UUIDs"},
+	} {
+		t.Run(fmt.Sprintf("Cannot update %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+				&session.Session{
+					OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN,
+				}), testTimeout)
+			defer cancel()
+
+			userSvc := NewUser(nil, nil, nil)
+			upSched, err := userSvc.UpdateUserSchedule(ctx,
+				uuid.NewV7().String(), test.inpSched)
+			t.Logf("upSched, err: %+v, %v", upSched, err)
+			require.Nil(t, upSched)
+			require.Equal(t, status.Error(codes.InvalidArgument, test.err), err)
+		})
+	}
+
+	t.Run("Update schedule by unknown user ID", func(t *testing.T) {
+		t.Parallel()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().UpsertSchedule(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(userer, nil, nil)
+		upSched, err := userSvc.UpdateUserSchedule(ctx, uuid.NewV7().String(),
+			testSchedule())
+		t.Logf("upSched, err: %+v, %v", upSched, err)
+		require.Nil(t, upSched)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestDeleteUserSchedule(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Delete schedule by valid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		userID := uuid.NewV7().String()
+		orgID := uuid.NewV7().String()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().DeleteSchedule(gomock.Any(), userID, orgID).
+			Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(userer, nil, nil)
+		err := userSvc.DeleteUserSchedule(ctx, userID)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete schedule with invalid session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(nil, nil, nil)
+		err := userSvc.DeleteUserSchedule(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Delete schedule by unknown user ID", func(t *testing.T) {
+		t.Parallel()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().DeleteSchedule(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(userer, nil, nil)
+		err := userSvc.DeleteUserSchedule(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+// GetUserScope retrieves a user's scope tags by user ID. Users without scope
+// tags are not found.
+func (u *User) GetUserScope(ctx context.Context, userID string) (
+	*message.UserScope, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	scope, err := u.userDAO.ReadScope(ctx, userID, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return scope, nil
+}
+
+// UpdateUserScope creates or replaces a user's scope tags by user ID. Users
+// with scope tags may only access devices with matching tags, or tags nested
+// beneath them using '/'. Empty scope tags remove the restriction. Changes
+// take effect on the user's next login or token refresh.
+func (u *User) UpdateUserScope(
+	ctx context.Context, userID string, scope *message.UserScope,
+) (*message.UserScope, error) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Role < api.Role_ADMIN {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	// Tag scoped sessions may not modify scopes, including their own.
+	if sess.TagScoped() {
+		return nil, errScope()
+	}
+
+	if err := validateScopeTags(scope.GetTags()); err != nil {
+		return nil, err
+	}
+
+	scope.UserId = userID
+	scope.OrgId = sess.OrgID
+
+	upScope, err := u.userDAO.UpsertScope(ctx, scope)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return upScope, nil
+}
+
+// readScopeTags retrieves a user's scope tags for inclusion in a session
+// token. Users without a scope are not restricted.
+func readScopeTags(ctx context.Context, userDAO Userer, user *api.User) (
+	[]string, error,
+) {
+	scope, err := userDAO.ReadScope(ctx, user.GetId(), user.GetOrgId())
+	if errors.Is(err, dao.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return scope.GetTags(), nil
+}
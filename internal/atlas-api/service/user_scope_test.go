@@ -0,0 +1,185 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetUserScope(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get scope by valid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		scope := &message.UserScope{
+			UserId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+			Tags: []string{"site"},
+		}
+		retScope, _ := proto.Clone(scope).(*message.UserScope)
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadScope(gomock.Any(), scope.GetUserId(),
+			scope.GetOrgId()).Return(retScope, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: scope.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(userer, nil, nil)
+		getScope, err := userSvc.GetUserScope(ctx, scope.GetUserId())
+		t.Logf("scope, getScope, err: %+v, %+v, %v", scope, getScope, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, scope, getScope)
+	})
+
+	t.Run("Get scope with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(nil, nil, nil)
+		getScope, err := userSvc.GetUserScope(ctx, uuid.NewV7().String())
+		t.Logf("getScope, err: %+v, %v", getScope, err)
+		require.Nil(t, getScope)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Get scope by unknown user ID", func(t *testing.T) {
+		t.Parallel()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadScope(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(userer, nil, nil)
+		getScope, err := userSvc.GetUserScope(ctx, uuid.NewV7().String())
+		t.Logf("getScope, err: %+v, %v", getScope, err)
+		require.Nil(t, getScope)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestUpdateUserScope(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Update scope by valid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		userID := uuid.NewV7().String()
+		orgID := uuid.NewV7().String()
+		scope := &message.UserScope{Tags: []string{"site", "site2/b1"}}
+		retScope := &message.UserScope{
+			UserId: userID, OrgId: orgID, Tags: scope.GetTags(),
+		}
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().UpsertScope(gomock.Any(), retScope).
+			Return(retScope, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(userer, nil, nil)
+		upScope, err := userSvc.UpdateUserScope(ctx, userID, scope)
+		t.Logf("retScope, upScope, err: %+v, %+v, %v", retScope, upScope, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, retScope, upScope)
+	})
+
+	t.Run("Update scope with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_BUILDER}),
+			testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(nil, nil, nil)
+		upScope, err := userSvc.UpdateUserScope(ctx, uuid.NewV7().String(),
+			&message.UserScope{})
+		t.Logf("upScope, err: %+v, %v", upScope, err)
+		require.Nil(t, upScope)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Update scope with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(nil, nil, nil)
+		upScope, err := userSvc.UpdateUserScope(ctx, uuid.NewV7().String(),
+			&message.UserScope{Tags: []string{"site/b1"}})
+		t.Logf("upScope, err: %+v, %v", upScope, err)
+		require.Nil(t, upScope)
+		require.Equal(t, errScope(), err)
+	})
+
+	t.Run("Update scope with invalid tags", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(nil, nil, nil)
+		upScope, err := userSvc.UpdateUserScope(ctx, uuid.NewV7().String(),
+			&message.UserScope{Tags: []string{random.String(256)}})
+		t.Logf("upScope, err: %+v, %v", upScope, err)
+		require.Nil(t, upScope)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"scope_tags must be between 1 and 255 characters"), err)
+	})
+
+	t.Run("Update scope by unknown user ID", func(t *testing.T) {
+		t.Parallel()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().UpsertScope(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(userer, nil, nil)
+		upScope, err := userSvc.UpdateUserScope(ctx, uuid.NewV7().String(),
+			&message.UserScope{})
+		t.Logf("upScope, err: %+v, %v", upScope, err)
+		require.Nil(t, upScope)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
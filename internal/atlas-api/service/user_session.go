@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// LoginWithRefresh logs in a user, and returns an access token and a refresh
+// token that can be exchanged for new tokens.
+func (s *Session) LoginWithRefresh(
+	ctx context.Context, req *api.LoginRequest,
+) (*message.SessionTokens, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return s.login(ctx, req, true)
+}
+
+// newSession creates a session for a user and returns its tokens. Refreshable
+// sessions last RefreshTokenExp, and others last as long as their access token.
+func newSession(
+	ctx context.Context, userDAO Userer, pwtKey []byte, user *api.User,
+	refreshable bool,
+) (*message.SessionTokens, error) {
+	logger := alog.FromContext(ctx)
+
+	rToken, hash, err := session.GenerateRefreshToken()
+	if err != nil {
+		logger.Errorf("newSession session.GenerateRefreshToken: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	exp := time.Now().Add(session.WebTokenExp * time.Second)
+	if refreshable {
+		exp = time.Now().Add(session.RefreshTokenExp * time.Second)
+	}
+
+	scopeTags, err := readScopeTags(ctx, userDAO, user)
+	if err != nil {
+		logger.Errorf("newSession readScopeTags: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	sess, err := userDAO.CreateSession(ctx, user.GetId(), user.GetOrgId(),
+		hash, exp)
+	if err != nil {
+		logger.Errorf("newSession userDAO.CreateSession: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	token, tokenExp, err := session.GenerateSessionToken(pwtKey, user,
+		sess.GetId(), scopeTags)
+	if err != nil {
+		logger.Errorf("newSession session.GenerateSessionToken: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	tokens := &message.SessionTokens{Token: token, ExpiresAt: tokenExp}
+	if refreshable {
+		tokens.RefreshToken = rToken
+		tokens.RefreshExpiresAt = timestamppb.New(exp)
+	}
+
+	return tokens, nil
+}
+
+// RefreshToken exchanges a refresh token for new session tokens. Each refresh
+// token may be used once, and reuse of a refresh token revokes its session.
+func (s *Session) RefreshToken(
+	ctx context.Context, req *message.RefreshTokenRequest,
+) (*message.SessionTokens, error) {
+	logger := alog.FromContext(ctx)
+
+	if req.GetRefreshToken() == "" {
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	rToken, newHash, err := session.GenerateRefreshToken()
+	if err != nil {
+		logger.Errorf("RefreshToken session.GenerateRefreshToken: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	hash := session.HashRefreshToken(req.GetRefreshToken())
+	exp := time.Now().Add(session.RefreshTokenExp * time.Second)
+
+	sess, err := s.userDAO.RotateSession(ctx, hash, newHash, exp)
+	if errors.Is(err, dao.ErrNotFound) {
+		// A rotated refresh token may have been stolen, so revoke its session.
+		revSess, revErr := s.userDAO.RevokeReusedSession(ctx, hash)
+		if revErr == nil {
+			logger.Infof("RefreshToken revoked session on refresh token "+
+				"reuse: %+v", revSess)
+
+			if err := s.cache.SetTTL(ctx, key.SessionRevoked(
+				revSess.GetOrgId(), revSess.GetId()), "",
+				session.WebTokenExp*time.Second); err != nil {
+				logger.Errorf("RefreshToken s.cache.SetTTL: %v", err)
+			}
+		}
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	logger.Logger = logger.WithField("userID", sess.GetUserId()).
+		WithField("orgID", sess.GetOrgId())
+
+	// Users that have been disabled or demoted to contacts since login may not
+	// refresh.
+	user, err := s.userDAO.Read(ctx, sess.GetUserId(), sess.GetOrgId())
+	if err != nil || user.GetStatus() != api.Status_ACTIVE ||
+		user.GetRole() < api.Role_VIEWER {
+		logger.Debugf("RefreshToken s.userDAO.Read user, err: %+v, %v", user,
+			err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	// Scope tags may have changed since login, so are read on each refresh.
+	scopeTags, err := readScopeTags(ctx, s.userDAO, user)
+	if err != nil {
+		logger.Errorf("RefreshToken readScopeTags: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	token, tokenExp, err := session.GenerateSessionToken(s.pwtKey, user,
+		sess.GetId(), scopeTags)
+	if err != nil {
+		logger.Errorf("RefreshToken session.GenerateSessionToken: %v", err)
+
+		return nil, status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	return &message.SessionTokens{
+		Token: token, ExpiresAt: tokenExp, RefreshToken: rToken,
+		RefreshExpiresAt: sess.GetExpiresAt(),
+	}, nil
+}
+
+// Logout revokes the session of the current access token.
+func (s *Session) Logout(ctx context.Context) error {
+	sess, ok := session.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, errUnauth)
+	}
+
+	if sess.SessionID == "" {
+		return status.Error(codes.InvalidArgument,
+			"token is not bound to a session")
+	}
+
+	if err := s.userDAO.RevokeSession(ctx, sess.SessionID, sess.UserID,
+		sess.OrgID); err != nil {
+		return errToStatus(err)
+	}
+
+	if err := s.cache.SetTTL(ctx, key.SessionRevoked(sess.OrgID,
+		sess.SessionID), "", session.WebTokenExp*time.Second); err != nil {
+		return errToStatus(err)
+	}
+
+	return nil
+}
+
+// ListUserSessions retrieves all active sessions of a user by ID.
+func (s *Session) ListUserSessions(ctx context.Context, userID string) (
+	*message.ListUserSessionsResponse, error,
+) {
+	sess, ok := session.FromContext(ctx)
+	if !ok || (sess.Role < api.Role_ADMIN && userID != sess.UserID) {
+		return nil, errPerm(api.Role_ADMIN)
+	}
+
+	sesses, err := s.userDAO.ListSessions(ctx, userID, sess.OrgID)
+	if err != nil {
+		return nil, errToStatus(err)
+	}
+
+	return &message.ListUserSessionsResponse{Sessions: sesses}, nil
+}
+
+// RevokeUserSessions revokes all active sessions of a user by ID.
+func (s *Session) RevokeUserSessions(ctx context.Context, userID string) error {
+	sess, ok := session.FromContext(ctx)
+	if !ok || (sess.Role < api.Role_ADMIN && userID != sess.UserID) {
+		return errPerm(api.Role_ADMIN)
+	}
+
+	if err := revokeSessions(ctx, s.userDAO, s.cache, userID,
+		sess.OrgID); err != nil {
+		return errToStatus(err)
+	}
+
+	return nil
+}
+
+// revokeSessions revokes all active sessions of a user, including the access
+// tokens bound to them. Access tokens expire after WebTokenExp, which bounds
+// the lifetime of each cache entry.
+func revokeSessions(
+	ctx context.Context, userDAO Userer, c cache.Cacher[string], userID,
+	orgID string,
+) error {
+	sessIDs, err := userDAO.RevokeSessions(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+
+	for _, sessID := range sessIDs {
+		if err := c.SetTTL(ctx, key.SessionRevoked(orgID, sessID), "",
+			session.WebTokenExp*time.Second); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
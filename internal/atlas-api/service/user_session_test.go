@@ -0,0 +1,559 @@
+//go:build !integration
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLoginWithRefresh(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Log in valid user", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-session")
+		user := random.User("api-session", org.GetId())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+		sessID := uuid.NewV7().String()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, globalHash, nil).Times(1)
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), org.GetId()).
+			Return(&message.UserMFA{}, nil, nil).Times(1)
+		userer.EXPECT().ReadScope(gomock.Any(), user.GetId(), org.GetId()).
+			Return(nil, dao.ErrNotFound).Times(1)
+		userer.EXPECT().CreateSession(gomock.Any(), user.GetId(), org.GetId(),
+			gomock.Any(), gomock.Any()).Return(&message.UserSession{
+			Id: sessID,
+		}, nil).Times(1)
+
+		pwtKey := make([]byte, 32)
+		_, err := rand.Read(pwtKey)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, cache.NewHeap[string](), pwtKey)
+		tokens, err := sessSvc.LoginWithRefresh(ctx, &api.LoginRequest{
+			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
+		})
+		t.Logf("tokens, err: %+v, %v", tokens, err)
+		require.NoError(t, err)
+		require.NotEmpty(t, tokens.GetRefreshToken())
+		require.WithinDuration(t, time.Now().Add(
+			session.RefreshTokenExp*time.Second,
+		), tokens.GetRefreshExpiresAt().AsTime(), 2*time.Second)
+
+		sess, err := session.ValidateWebToken(pwtKey, tokens.GetToken())
+		t.Logf("sess, err: %+v, %v", sess, err)
+		require.NoError(t, err)
+		require.Equal(t, sessID, sess.SessionID)
+		require.Equal(t, user.GetId(), sess.UserID)
+	})
+
+	t.Run("Log in unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-session")
+		user := random.User("api-session", org.GetId())
+
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(nil, nil, dao.ErrNotFound).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().Get(gomock.Any(), key.LoginFailures(org.GetName(),
+			user.GetEmail())).Return("", cache.ErrNotFound).Times(1)
+		cacher.EXPECT().IncrTTL(gomock.Any(), key.LoginFailures(
+			org.GetName(), user.GetEmail()), loginFailWindow).
+			Return(int64(1), nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, cacher, nil)
+		tokens, err := sessSvc.LoginWithRefresh(ctx, &api.LoginRequest{
+			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
+		})
+		t.Logf("tokens, err: %+v, %v", tokens, err)
+		require.Nil(t, tokens)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Log in with session failure", func(t *testing.T) {
+		t.Parallel()
+
+		org := random.Org("api-session")
+		user := random.User("api-session", org.GetId())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ReadByEmail(gomock.Any(), user.GetEmail(),
+			org.GetName()).Return(user, globalHash, nil).Times(1)
+		userer.EXPECT().ReadMFA(gomock.Any(), user.GetId(), org.GetId()).
+			Return(&message.UserMFA{}, nil, nil).Times(1)
+		userer.EXPECT().ReadScope(gomock.Any(), user.GetId(), org.GetId()).
+			Return(nil, dao.ErrNotFound).Times(1)
+		userer.EXPECT().CreateSession(gomock.Any(), user.GetId(), org.GetId(),
+			gomock.Any(), gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, cache.NewHeap[string](), nil)
+		tokens, err := sessSvc.LoginWithRefresh(ctx, &api.LoginRequest{
+			Email: user.GetEmail(), OrgName: org.GetName(), Password: globalPass,
+		})
+		t.Logf("tokens, err: %+v, %v", tokens, err)
+		require.Nil(t, tokens)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+}
+
+func TestRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Refresh valid token", func(t *testing.T) {
+		t.Parallel()
+
+		user := random.User("api-session", uuid.NewV7().String())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+		rToken := random.String(43)
+		retSess := &message.UserSession{
+			Id: uuid.NewV7().String(), OrgId: user.GetOrgId(),
+			UserId:    user.GetId(),
+			ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+		}
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().RotateSession(gomock.Any(),
+			session.HashRefreshToken(rToken), gomock.Any(), gomock.Any()).
+			Return(retSess, nil).Times(1)
+		userer.EXPECT().Read(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(user, nil).Times(1)
+		userer.EXPECT().ReadScope(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(&message.UserScope{Tags: []string{"site"}}, nil).Times(1)
+
+		pwtKey := make([]byte, 32)
+		_, err := rand.Read(pwtKey)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, pwtKey)
+		tokens, err := sessSvc.RefreshToken(ctx,
+			&message.RefreshTokenRequest{RefreshToken: rToken})
+		t.Logf("tokens, err: %+v, %v", tokens, err)
+		require.NoError(t, err)
+		require.NotEmpty(t, tokens.GetRefreshToken())
+		require.NotEqual(t, rToken, tokens.GetRefreshToken())
+		require.Equal(t, retSess.GetExpiresAt(), tokens.GetRefreshExpiresAt())
+
+		sess, err := session.ValidateWebToken(pwtKey, tokens.GetToken())
+		t.Logf("sess, err: %+v, %v", sess, err)
+		require.NoError(t, err)
+		require.Equal(t, retSess.GetId(), sess.SessionID)
+		require.Equal(t, []string{"site"}, sess.ScopeTags)
+	})
+
+	t.Run("Refresh empty token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(nil, nil, nil, nil)
+		tokens, err := sessSvc.RefreshToken(ctx, &message.RefreshTokenRequest{})
+		t.Logf("tokens, err: %+v, %v", tokens, err)
+		require.Nil(t, tokens)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Refresh reused token", func(t *testing.T) {
+		t.Parallel()
+
+		rToken := random.String(43)
+		retSess := &message.UserSession{
+			Id: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+		}
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().RotateSession(gomock.Any(),
+			session.HashRefreshToken(rToken), gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+		userer.EXPECT().RevokeReusedSession(gomock.Any(),
+			session.HashRefreshToken(rToken)).Return(retSess, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		c := cache.NewHeap[string]()
+		sessSvc := NewSession(userer, nil, c, nil)
+		tokens, err := sessSvc.RefreshToken(ctx,
+			&message.RefreshTokenRequest{RefreshToken: rToken})
+		t.Logf("tokens, err: %+v, %v", tokens, err)
+		require.Nil(t, tokens)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+
+		_, err = c.Get(ctx, key.SessionRevoked(retSess.GetOrgId(),
+			retSess.GetId()))
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Refresh unknown token", func(t *testing.T) {
+		t.Parallel()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().RotateSession(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrNotFound).Times(1)
+		userer.EXPECT().RevokeReusedSession(gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		tokens, err := sessSvc.RefreshToken(ctx,
+			&message.RefreshTokenRequest{RefreshToken: random.String(43)})
+		t.Logf("tokens, err: %+v, %v", tokens, err)
+		require.Nil(t, tokens)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Refresh token with database failure", func(t *testing.T) {
+		t.Parallel()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().RotateSession(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		tokens, err := sessSvc.RefreshToken(ctx,
+			&message.RefreshTokenRequest{RefreshToken: random.String(43)})
+		t.Logf("tokens, err: %+v, %v", tokens, err)
+		require.Nil(t, tokens)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"dao: invalid format"), err)
+	})
+
+	t.Run("Refresh token of disabled user", func(t *testing.T) {
+		t.Parallel()
+
+		user := random.User("api-session", uuid.NewV7().String())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_DISABLED
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().RotateSession(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(&message.UserSession{
+			Id: uuid.NewV7().String(), OrgId: user.GetOrgId(),
+			UserId: user.GetId(),
+		}, nil).Times(1)
+		userer.EXPECT().Read(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(user, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		tokens, err := sessSvc.RefreshToken(ctx,
+			&message.RefreshTokenRequest{RefreshToken: random.String(43)})
+		t.Logf("tokens, err: %+v, %v", tokens, err)
+		require.Nil(t, tokens)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Refresh token with scope failure", func(t *testing.T) {
+		t.Parallel()
+
+		user := random.User("api-session", uuid.NewV7().String())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().RotateSession(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(&message.UserSession{
+			Id: uuid.NewV7().String(), OrgId: user.GetOrgId(),
+			UserId: user.GetId(),
+		}, nil).Times(1)
+		userer.EXPECT().Read(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(user, nil).Times(1)
+		userer.EXPECT().ReadScope(gomock.Any(), user.GetId(), user.GetOrgId()).
+			Return(nil, dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		tokens, err := sessSvc.RefreshToken(ctx,
+			&message.RefreshTokenRequest{RefreshToken: random.String(43)})
+		t.Logf("tokens, err: %+v, %v", tokens, err)
+		require.Nil(t, tokens)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Log out valid session", func(t *testing.T) {
+		t.Parallel()
+
+		sess := &session.Session{
+			UserID: uuid.NewV7().String(), OrgID: uuid.NewV7().String(),
+			Role: api.Role_VIEWER, SessionID: uuid.NewV7().String(),
+		}
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().RevokeSession(gomock.Any(), sess.SessionID,
+			sess.UserID, sess.OrgID).Return(nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			sess), testTimeout)
+		defer cancel()
+
+		c := cache.NewHeap[string]()
+		sessSvc := NewSession(userer, nil, c, nil)
+		err := sessSvc.Logout(ctx)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		_, err = c.Get(ctx, key.SessionRevoked(sess.OrgID, sess.SessionID))
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	})
+
+	t.Run("Log out with invalid session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(nil, nil, nil, nil)
+		err := sessSvc.Logout(ctx)
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.Unauthenticated, errUnauth), err)
+	})
+
+	t.Run("Log out unbound token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(nil, nil, nil, nil)
+		err := sessSvc.Logout(ctx)
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"token is not bound to a session"), err)
+	})
+
+	t.Run("Log out revoked session", func(t *testing.T) {
+		t.Parallel()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().RevokeSession(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(dao.ErrNotFound).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: uuid.NewV7().String(), OrgID: uuid.NewV7().String(),
+				Role: api.Role_VIEWER, SessionID: uuid.NewV7().String(),
+			}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		err := sessSvc.Logout(ctx)
+		t.Logf("err: %v", err)
+		require.Equal(t, status.Error(codes.NotFound, "dao: object not found"),
+			err)
+	})
+}
+
+func TestListUserSessions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("List own sessions", func(t *testing.T) {
+		t.Parallel()
+
+		userID := uuid.NewV7().String()
+		orgID := uuid.NewV7().String()
+		sesses := []*message.UserSession{
+			{Id: uuid.NewV7().String(), OrgId: orgID, UserId: userID},
+		}
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ListSessions(gomock.Any(), userID, orgID).
+			Return(sesses, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{UserID: userID, OrgID: orgID,
+				Role: api.Role_VIEWER}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		listSesses, err := sessSvc.ListUserSessions(ctx, userID)
+		t.Logf("listSesses, err: %+v, %v", listSesses, err)
+		require.NoError(t, err)
+		require.Equal(t, sesses, listSesses.GetSessions())
+	})
+
+	t.Run("List sessions by admin", func(t *testing.T) {
+		t.Parallel()
+
+		userID := uuid.NewV7().String()
+		orgID := uuid.NewV7().String()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ListSessions(gomock.Any(), userID, orgID).
+			Return(nil, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		listSesses, err := sessSvc.ListUserSessions(ctx, userID)
+		t.Logf("listSesses, err: %+v, %v", listSesses, err)
+		require.NoError(t, err)
+		require.Empty(t, listSesses.GetSessions())
+	})
+
+	t.Run("List sessions with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: uuid.NewV7().String(), OrgID: uuid.NewV7().String(),
+				Role: api.Role_BUILDER,
+			}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(nil, nil, nil, nil)
+		listSesses, err := sessSvc.ListUserSessions(ctx, uuid.NewV7().String())
+		t.Logf("listSesses, err: %+v, %v", listSesses, err)
+		require.Nil(t, listSesses)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("List sessions by invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().ListSessions(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, dao.ErrInvalidFormat).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, nil, nil)
+		listSesses, err := sessSvc.ListUserSessions(ctx, random.String(10))
+		t.Logf("listSesses, err: %+v, %v", listSesses, err)
+		require.Nil(t, listSesses)
+		require.Equal(t, status.Error(codes.InvalidArgument,
+			"dao: invalid format"), err)
+	})
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Revoke sessions by admin", func(t *testing.T) {
+		t.Parallel()
+
+		userID := uuid.NewV7().String()
+		orgID := uuid.NewV7().String()
+		sessIDs := []string{uuid.NewV7().String(), uuid.NewV7().String()}
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().RevokeSessions(gomock.Any(), userID, orgID).
+			Return(sessIDs, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
+		defer cancel()
+
+		c := cache.NewHeap[string]()
+		sessSvc := NewSession(userer, nil, c, nil)
+		err := sessSvc.RevokeUserSessions(ctx, userID)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		for _, sessID := range sessIDs {
+			_, err = c.Get(ctx, key.SessionRevoked(orgID, sessID))
+			t.Logf("err: %v", err)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("Revoke sessions with insufficient role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				UserID: uuid.NewV7().String(), OrgID: uuid.NewV7().String(),
+				Role: api.Role_BUILDER,
+			}), testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(nil, nil, nil, nil)
+		err := sessSvc.RevokeUserSessions(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, errPerm(api.Role_ADMIN), err)
+	})
+
+	t.Run("Revoke sessions with cache failure", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		userer := NewMockUserer(ctrl)
+		userer.EXPECT().RevokeSessions(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return([]string{uuid.NewV7().String()}, nil).Times(1)
+		cacher := cache.NewMockCacher[string](ctrl)
+		cacher.EXPECT().SetTTL(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(errors.New("cache failure")).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		sessSvc := NewSession(userer, nil, cacher, nil)
+		err := sessSvc.RevokeUserSessions(ctx, uuid.NewV7().String())
+		t.Logf("err: %v", err)
+		require.Equal(t, codes.Unknown, status.Code(err))
+	})
+}
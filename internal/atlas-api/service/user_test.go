@@ -11,7 +11,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/thingspect/atlas/internal/atlas-api/auth"
+	"github.com/thingspect/atlas/internal/atlas-api/key"
 	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/cache"
 	"github.com/thingspect/atlas/pkg/dao"
 	"github.com/thingspect/atlas/pkg/notify"
 	"github.com/thingspect/atlas/pkg/test/matcher"
@@ -44,7 +46,7 @@ func TestCreateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		createUser, err := userSvc.CreateUser(ctx,
 			&api.CreateUserRequest{User: user})
 		t.Logf("user, createUser, err: %+v, %+v, %v", user, createUser, err)
@@ -73,7 +75,7 @@ func TestCreateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, notifier)
+		userSvc := NewUser(userer, notifier, nil)
 		createUser, err := userSvc.CreateUser(ctx,
 			&api.CreateUserRequest{User: user})
 		t.Logf("user, createUser, err: %+v, %+v, %v", user, createUser, err)
@@ -101,7 +103,7 @@ func TestCreateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, notifier)
+		userSvc := NewUser(userer, notifier, nil)
 		createUser, err := userSvc.CreateUser(ctx,
 			&api.CreateUserRequest{User: user})
 		t.Logf("user, createUser, err: %+v, %+v, %v", user, createUser, err)
@@ -109,13 +111,34 @@ func TestCreateUser(t *testing.T) {
 		require.EqualExportedValues(t, user, createUser)
 	})
 
+	t.Run("Create user with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		user := random.User("api-user", uuid.NewV7().String())
+		user.Role = api.Role_BUILDER
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{
+				OrgID: user.GetOrgId(), Role: api.Role_ADMIN,
+				ScopeTags: []string{"site"},
+			}), testTimeout)
+		defer cancel()
+
+		userSvc := NewUser(nil, nil, nil)
+		createUser, err := userSvc.CreateUser(ctx,
+			&api.CreateUserRequest{User: user})
+		t.Logf("user, createUser, err: %+v, %+v, %v", user, createUser, err)
+		require.Nil(t, createUser)
+		require.Equal(t, errScope(), err)
+	})
+
 	t.Run("Create user with invalid session", func(t *testing.T) {
 		t.Parallel()
 
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		createUser, err := userSvc.CreateUser(ctx, &api.CreateUserRequest{})
 		t.Logf("createUser, err: %+v, %v", createUser, err)
 		require.Nil(t, createUser)
@@ -130,7 +153,7 @@ func TestCreateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		createUser, err := userSvc.CreateUser(ctx, &api.CreateUserRequest{})
 		t.Logf("createUser, err: %+v, %v", createUser, err)
 		require.Nil(t, createUser)
@@ -148,7 +171,7 @@ func TestCreateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		createUser, err := userSvc.CreateUser(ctx,
 			&api.CreateUserRequest{User: user})
 		t.Logf("user, createUser, err: %+v, %+v, %v", user, createUser, err)
@@ -172,7 +195,7 @@ func TestCreateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		createUser, err := userSvc.CreateUser(ctx,
 			&api.CreateUserRequest{User: user})
 		t.Logf("user, createUser, err: %+v, %+v, %v", user, createUser, err)
@@ -193,7 +216,7 @@ func TestCreateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		createUser, err := userSvc.CreateUser(ctx,
 			&api.CreateUserRequest{User: user})
 		t.Logf("user, createUser, err: %+v, %+v, %v", user, createUser, err)
@@ -217,7 +240,7 @@ func TestCreateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, notifier)
+		userSvc := NewUser(nil, notifier, nil)
 		createUser, err := userSvc.CreateUser(ctx,
 			&api.CreateUserRequest{User: user})
 		t.Logf("user, createUser, err: %+v, %+v, %v", user, createUser, err)
@@ -241,7 +264,7 @@ func TestCreateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, notifier)
+		userSvc := NewUser(nil, notifier, nil)
 		createUser, err := userSvc.CreateUser(ctx,
 			&api.CreateUserRequest{User: user})
 		t.Logf("user, createUser, err: %+v, %+v, %v", user, createUser, err)
@@ -269,7 +292,7 @@ func TestGetUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		getUser, err := userSvc.GetUser(ctx, &api.GetUserRequest{Id: user.GetId()})
 		t.Logf("user, getUser, err: %+v, %+v, %v", user, getUser, err)
 		require.NoError(t, err)
@@ -282,7 +305,7 @@ func TestGetUser(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		getUser, err := userSvc.GetUser(ctx, &api.GetUserRequest{})
 		t.Logf("getUser, err: %+v, %v", getUser, err)
 		require.Nil(t, getUser)
@@ -300,7 +323,7 @@ func TestGetUser(t *testing.T) {
 			}), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		getUser, err := userSvc.GetUser(ctx, &api.GetUserRequest{})
 		t.Logf("getUser, err: %+v, %v", getUser, err)
 		require.Nil(t, getUser)
@@ -319,7 +342,7 @@ func TestGetUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		getUser, err := userSvc.GetUser(ctx,
 			&api.GetUserRequest{Id: uuid.NewV7().String()})
 		t.Logf("getUser, err: %+v, %v", getUser, err)
@@ -337,6 +360,7 @@ func TestUpdateUser(t *testing.T) {
 
 		user := random.User("api-user", uuid.NewV7().String())
 		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
 		retUser, _ := proto.Clone(user).(*api.User)
 
 		userer := NewMockUserer(gomock.NewController(t))
@@ -347,7 +371,7 @@ func TestUpdateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		updateUser, err := userSvc.UpdateUser(ctx,
 			&api.UpdateUserRequest{User: user})
 		t.Logf("user, updateUser, err: %+v, %+v, %v", user, updateUser, err)
@@ -360,6 +384,7 @@ func TestUpdateUser(t *testing.T) {
 
 		user := random.SMSUser("api-user", uuid.NewV7().String())
 		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
 		retUser, _ := proto.Clone(user).(*api.User)
 
 		ctrl := gomock.NewController(t)
@@ -374,7 +399,7 @@ func TestUpdateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, notifier)
+		userSvc := NewUser(userer, notifier, nil)
 		updateUser, err := userSvc.UpdateUser(ctx,
 			&api.UpdateUserRequest{User: user})
 		t.Logf("user, updateUser, err: %+v, %+v, %v", user, updateUser, err)
@@ -387,6 +412,7 @@ func TestUpdateUser(t *testing.T) {
 
 		user := random.AppUser("api-user", uuid.NewV7().String())
 		user.Role = api.Role_ADMIN
+		user.Status = api.Status_ACTIVE
 		retUser, _ := proto.Clone(user).(*api.User)
 
 		ctrl := gomock.NewController(t)
@@ -400,12 +426,44 @@ func TestUpdateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, notifier)
+		userSvc := NewUser(userer, notifier, nil)
+		updateUser, err := userSvc.UpdateUser(ctx,
+			&api.UpdateUserRequest{User: user})
+		t.Logf("user, updateUser, err: %+v, %+v, %v", user, updateUser, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, user, updateUser)
+	})
+
+	t.Run("Disable user by valid user", func(t *testing.T) {
+		t.Parallel()
+
+		user := random.User("api-user", uuid.NewV7().String())
+		user.Role = api.Role_ADMIN
+		user.Status = api.Status_DISABLED
+		retUser, _ := proto.Clone(user).(*api.User)
+		sessID := uuid.NewV7().String()
+
+		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().Update(gomock.Any(), user).Return(retUser, nil).Times(1)
+		userer.EXPECT().RevokeSessions(gomock.Any(), user.GetId(),
+			user.GetOrgId()).Return([]string{sessID}, nil).Times(1)
+
+		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
+			&session.Session{OrgID: user.GetOrgId(), Role: api.Role_ADMIN}),
+			testTimeout)
+		defer cancel()
+
+		c := cache.NewHeap[string]()
+		userSvc := NewUser(userer, nil, c)
 		updateUser, err := userSvc.UpdateUser(ctx,
 			&api.UpdateUserRequest{User: user})
 		t.Logf("user, updateUser, err: %+v, %+v, %v", user, updateUser, err)
 		require.NoError(t, err)
 		require.EqualExportedValues(t, user, updateUser)
+
+		_, err = c.Get(ctx, key.SessionRevoked(user.GetOrgId(), sessID))
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
 	})
 
 	t.Run("Partial update user by valid user", func(t *testing.T) {
@@ -437,7 +495,7 @@ func TestUpdateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		updateUser, err := userSvc.UpdateUser(ctx, &api.UpdateUserRequest{
 			User: part, UpdateMask: &fieldmaskpb.FieldMask{
 				Paths: []string{"status", "tags"},
@@ -454,7 +512,7 @@ func TestUpdateUser(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		updateUser, err := userSvc.UpdateUser(ctx, &api.UpdateUserRequest{})
 		t.Logf("updateUser, err: %+v, %v", updateUser, err)
 		require.Nil(t, updateUser)
@@ -469,7 +527,7 @@ func TestUpdateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		updateUser, err := userSvc.UpdateUser(ctx,
 			&api.UpdateUserRequest{User: nil})
 		t.Logf("updateUser, err: %+v, %v", updateUser, err)
@@ -489,7 +547,7 @@ func TestUpdateUser(t *testing.T) {
 			}), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		updateUser, err := userSvc.UpdateUser(ctx, &api.UpdateUserRequest{
 			User: random.User("api-user", uuid.NewV7().String()),
 		})
@@ -512,7 +570,7 @@ func TestUpdateUser(t *testing.T) {
 			}), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		updateUser, err := userSvc.UpdateUser(ctx,
 			&api.UpdateUserRequest{User: user})
 		t.Logf("user, updateUser, err: %+v, %+v, %v", user, updateUser, err)
@@ -536,7 +594,7 @@ func TestUpdateUser(t *testing.T) {
 		), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		updateUser, err := userSvc.UpdateUser(ctx,
 			&api.UpdateUserRequest{User: user})
 		t.Logf("user, updateUser, err: %+v, %+v, %v", user, updateUser, err)
@@ -557,7 +615,7 @@ func TestUpdateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		updateUser, err := userSvc.UpdateUser(ctx,
 			&api.UpdateUserRequest{User: user})
 		t.Logf("user, updateUser, err: %+v, %+v, %v", user, updateUser, err)
@@ -581,7 +639,7 @@ func TestUpdateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, notifier)
+		userSvc := NewUser(nil, notifier, nil)
 		updateUser, err := userSvc.UpdateUser(ctx,
 			&api.UpdateUserRequest{User: user})
 		t.Logf("user, updateUser, err: %+v, %+v, %v", user, updateUser, err)
@@ -605,7 +663,7 @@ func TestUpdateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, notifier)
+		userSvc := NewUser(nil, notifier, nil)
 		updateUser, err := userSvc.UpdateUser(ctx,
 			&api.UpdateUserRequest{User: user})
 		t.Logf("user, updateUser, err: %+v, %+v, %v", user, updateUser, err)
@@ -625,7 +683,7 @@ func TestUpdateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		updateUser, err := userSvc.UpdateUser(ctx, &api.UpdateUserRequest{
 			User: user, UpdateMask: &fieldmaskpb.FieldMask{
 				Paths: []string{"aaa"},
@@ -652,7 +710,7 @@ func TestUpdateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		updateUser, err := userSvc.UpdateUser(ctx, &api.UpdateUserRequest{
 			User: part, UpdateMask: &fieldmaskpb.FieldMask{
 				Paths: []string{"status"},
@@ -676,7 +734,7 @@ func TestUpdateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		updateUser, err := userSvc.UpdateUser(ctx,
 			&api.UpdateUserRequest{User: user})
 		t.Logf("user, updateUser, err: %+v, %+v, %v", user, updateUser, err)
@@ -703,7 +761,7 @@ func TestUpdateUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		updateUser, err := userSvc.UpdateUser(ctx,
 			&api.UpdateUserRequest{User: user})
 		t.Logf("user, updateUser, err: %+v, %+v, %v", user, updateUser, err)
@@ -719,22 +777,32 @@ func TestUpdateUserPassword(t *testing.T) {
 	t.Run("Update user password by valid ID", func(t *testing.T) {
 		t.Parallel()
 
+		userID := uuid.NewV7().String()
+		orgID := uuid.NewV7().String()
+		sessID := uuid.NewV7().String()
+
 		userer := NewMockUserer(gomock.NewController(t))
-		userer.EXPECT().UpdatePassword(gomock.Any(), gomock.Any(), gomock.Any(),
+		userer.EXPECT().UpdatePassword(gomock.Any(), userID, orgID,
 			gomock.Any()).Return(nil).Times(1)
+		userer.EXPECT().RevokeSessions(gomock.Any(), userID, orgID).
+			Return([]string{sessID}, nil).Times(1)
 
 		ctx, cancel := context.WithTimeout(session.NewContext(t.Context(),
-			&session.Session{OrgID: uuid.NewV7().String(), Role: api.Role_ADMIN}),
-			testTimeout)
+			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		c := cache.NewHeap[string]()
+		userSvc := NewUser(userer, nil, c)
 		_, err := userSvc.UpdateUserPassword(ctx,
 			&api.UpdateUserPasswordRequest{
-				Id: uuid.NewV7().String(), Password: random.String(20),
+				Id: userID, Password: random.String(20),
 			})
 		t.Logf("err: %v", err)
 		require.NoError(t, err)
+
+		_, err = c.Get(ctx, key.SessionRevoked(orgID, sessID))
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
 	})
 
 	t.Run("Update user password with invalid session", func(t *testing.T) {
@@ -743,7 +811,7 @@ func TestUpdateUserPassword(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		_, err := userSvc.UpdateUserPassword(ctx,
 			&api.UpdateUserPasswordRequest{})
 		t.Logf("err: %v", err)
@@ -761,7 +829,7 @@ func TestUpdateUserPassword(t *testing.T) {
 			}), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		_, err := userSvc.UpdateUserPassword(ctx,
 			&api.UpdateUserPasswordRequest{})
 		t.Logf("err: %v", err)
@@ -776,7 +844,7 @@ func TestUpdateUserPassword(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		_, err := userSvc.UpdateUserPassword(ctx,
 			&api.UpdateUserPasswordRequest{
 				Id: uuid.NewV7().String(), Password: "1234567890",
@@ -798,7 +866,7 @@ func TestUpdateUserPassword(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		_, err := userSvc.UpdateUserPassword(ctx,
 			&api.UpdateUserPasswordRequest{
 				Id: uuid.NewV7().String(), Password: random.String(20),
@@ -816,6 +884,8 @@ func TestDeleteUser(t *testing.T) {
 		t.Parallel()
 
 		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().RevokeSessions(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, nil).Times(1)
 		userer.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(nil).Times(1)
 
@@ -824,7 +894,7 @@ func TestDeleteUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		_, err := userSvc.DeleteUser(ctx,
 			&api.DeleteUserRequest{Id: uuid.NewV7().String()})
 		t.Logf("err: %v", err)
@@ -837,7 +907,7 @@ func TestDeleteUser(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		_, err := userSvc.DeleteUser(ctx, &api.DeleteUserRequest{})
 		t.Logf("err: %v", err)
 		require.Equal(t, errPerm(api.Role_ADMIN), err)
@@ -851,7 +921,7 @@ func TestDeleteUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		_, err := userSvc.DeleteUser(ctx, &api.DeleteUserRequest{})
 		t.Logf("err: %v", err)
 		require.Equal(t, errPerm(api.Role_ADMIN), err)
@@ -861,6 +931,8 @@ func TestDeleteUser(t *testing.T) {
 		t.Parallel()
 
 		userer := NewMockUserer(gomock.NewController(t))
+		userer.EXPECT().RevokeSessions(gomock.Any(), gomock.Any(),
+			gomock.Any()).Return(nil, nil).Times(1)
 		userer.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(dao.ErrNotFound).Times(1)
 
@@ -869,7 +941,7 @@ func TestDeleteUser(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		_, err := userSvc.DeleteUser(ctx,
 			&api.DeleteUserRequest{Id: uuid.NewV7().String()})
 		t.Logf("err: %v", err)
@@ -900,7 +972,7 @@ func TestListUsers(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		listUsers, err := userSvc.ListUsers(ctx, &api.ListUsersRequest{})
 		t.Logf("listUsers, err: %+v, %v", listUsers, err)
 		require.NoError(t, err)
@@ -932,7 +1004,7 @@ func TestListUsers(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		listUsers, err := userSvc.ListUsers(ctx,
 			&api.ListUsersRequest{PageSize: 2})
 		t.Logf("listUsers, err: %+v, %v", listUsers, err)
@@ -949,7 +1021,7 @@ func TestListUsers(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		listUsers, err := userSvc.ListUsers(ctx, &api.ListUsersRequest{})
 		t.Logf("listUsers, err: %+v, %v", listUsers, err)
 		require.Nil(t, listUsers)
@@ -963,7 +1035,7 @@ func TestListUsers(t *testing.T) {
 			&session.Session{Role: api.Role_VIEWER}), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		listUsers, err := userSvc.ListUsers(ctx, &api.ListUsersRequest{})
 		t.Logf("listUsers, err: %+v, %v", listUsers, err)
 		require.NoError(t, err)
@@ -987,7 +1059,7 @@ func TestListUsers(t *testing.T) {
 			}), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		listUsers, err := userSvc.ListUsers(ctx, &api.ListUsersRequest{})
 		t.Logf("listUsers, err: %+v, %v", listUsers, err)
 		require.NoError(t, err)
@@ -1013,7 +1085,7 @@ func TestListUsers(t *testing.T) {
 			}), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		listUsers, err := userSvc.ListUsers(ctx, &api.ListUsersRequest{})
 		t.Logf("listUsers, err: %+v, %v", listUsers, err)
 		require.Nil(t, listUsers)
@@ -1029,7 +1101,7 @@ func TestListUsers(t *testing.T) {
 			testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(nil, nil)
+		userSvc := NewUser(nil, nil, nil)
 		listUsers, err := userSvc.ListUsers(ctx,
 			&api.ListUsersRequest{PageToken: badUUID})
 		t.Logf("listUsers, err: %+v, %v", listUsers, err)
@@ -1050,7 +1122,7 @@ func TestListUsers(t *testing.T) {
 			&session.Session{OrgID: "aaa", Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		listUsers, err := userSvc.ListUsers(ctx, &api.ListUsersRequest{})
 		t.Logf("listUsers, err: %+v, %v", listUsers, err)
 		require.Nil(t, listUsers)
@@ -1078,7 +1150,7 @@ func TestListUsers(t *testing.T) {
 			&session.Session{OrgID: orgID, Role: api.Role_ADMIN}), testTimeout)
 		defer cancel()
 
-		userSvc := NewUser(userer, nil)
+		userSvc := NewUser(userer, nil, nil)
 		listUsers, err := userSvc.ListUsers(ctx,
 			&api.ListUsersRequest{PageSize: 2})
 		t.Logf("listUsers, err: %+v, %v", listUsers, err)
@@ -0,0 +1,37 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// Constants used for refresh tokens.
+const (
+	// RefreshTokenExp represents the lifetime of a refresh token in seconds.
+	RefreshTokenExp = 30 * 24 * 60 * 60
+
+	refreshTokenLen = 32
+)
+
+// GenerateRefreshToken generates a random refresh token in raw (no padding),
+// URL-safe base64 format. It returns the token, its hash for server-side
+// storage, and an error value.
+func GenerateRefreshToken() (string, []byte, error) {
+	bToken := make([]byte, refreshTokenLen)
+	if _, err := rand.Read(bToken); err != nil {
+		return "", nil, err
+	}
+
+	rToken := base64.RawURLEncoding.EncodeToString(bToken)
+
+	return rToken, HashRefreshToken(rToken), nil
+}
+
+// HashRefreshToken returns the SHA-256 hash of a refresh token. Refresh tokens
+// have sufficient entropy to not require a salted, slow hash.
+func HashRefreshToken(rToken string) []byte {
+	hash := sha256.Sum256([]byte(rToken))
+
+	return hash[:]
+}
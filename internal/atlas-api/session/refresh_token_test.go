@@ -0,0 +1,33 @@
+//go:build !integration
+
+package session
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can generate %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			rToken, hash, err := GenerateRefreshToken()
+			t.Logf("rToken, hash, err: %v, %x, %v", rToken, hash, err)
+			require.NoError(t, err)
+			require.Len(t, rToken, 43)
+			require.Len(t, hash, 32)
+			require.Equal(t, hash, HashRefreshToken(rToken))
+
+			rToken2, hash2, err := GenerateRefreshToken()
+			t.Logf("rToken2, hash2, err: %v, %x, %v", rToken2, hash2, err)
+			require.NoError(t, err)
+			require.NotEqual(t, rToken, rToken2)
+			require.NotEqual(t, hash, hash2)
+		})
+	}
+}
@@ -4,6 +4,8 @@ package session
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"uuid"
 
 	"github.com/thingspect/proto/go/api"
@@ -11,13 +13,98 @@ import (
 
 // Session represents session metadata as retrieved from an encrypted token.
 // Either UserID or KeyID will be present, but not both. TraceID will be
-// generated whenever a token is validated and a session is returned.
+// generated whenever a token is validated and a session is returned. SessionID
+// is present for user tokens that are bound to a revocable session. Scopes,
+// UniqIDs, and Tags are optional restrictions of API keys. ScopeTags are
+// optional device tag restrictions of users and API keys.
 type Session struct {
-	UserID  string
-	KeyID   string
-	OrgID   string
-	Role    api.Role
-	TraceID uuid.UUID
+	UserID    string
+	KeyID     string
+	OrgID     string
+	Role      api.Role
+	TraceID   uuid.UUID
+	SessionID string
+
+	Scopes  []string
+	UniqIDs []string
+	Tags    []string
+
+	ScopeTags []string
+}
+
+// InScope returns whether a Session may call a gRPC method, such as
+// '/thingspect.api.DataPointService/PublishDataPoints'. A scope matches a full
+// method or all methods of a service, such as
+// 'thingspect.api.DataPointService'. Sessions without scopes may call all
+// methods.
+func (s *Session) InScope(method string) bool {
+	if len(s.Scopes) == 0 {
+		return true
+	}
+
+	for _, scope := range s.Scopes {
+		if scope == method || strings.HasPrefix(method, "/"+scope+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PublishRestricted returns whether a Session is restricted to publishing data
+// points for a subset of devices.
+func (s *Session) PublishRestricted() bool {
+	return len(s.UniqIDs) > 0 || len(s.Tags) > 0
+}
+
+// CanPublish returns whether a Session may publish data points for a device by
+// uniq ID and tags.
+func (s *Session) CanPublish(uniqID string, tags []string) bool {
+	if !s.PublishRestricted() ||
+		slices.Contains(s.UniqIDs, strings.ToLower(uniqID)) {
+		return true
+	}
+
+	for _, tag := range tags {
+		if slices.Contains(s.Tags, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TagScoped returns whether a Session is restricted to devices by tag.
+func (s *Session) TagScoped() bool {
+	return len(s.ScopeTags) > 0
+}
+
+// TagInScope returns whether a tag is within a Session's scope tags. A tag is
+// in scope if it matches a scope tag or is nested beneath one, such as
+// 'site-a/building-1' for a scope tag of 'site-a'. Sessions without scope tags
+// may access all tags.
+func (s *Session) TagInScope(tag string) bool {
+	if !s.TagScoped() {
+		return true
+	}
+
+	for _, scope := range s.ScopeTags {
+		if tag == scope || strings.HasPrefix(tag, scope+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InTagScope returns whether a Session may access a device by tags. Sessions
+// without scope tags may access all devices.
+func (s *Session) InTagScope(tags []string) bool {
+	if !s.TagScoped() {
+		return true
+	}
+
+	return slices.ContainsFunc(tags, s.TagInScope)
 }
 
 // sessionKey is the key for Session values in Contexts. It is unexported,
@@ -4,6 +4,7 @@ package session
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 	"uuid"
@@ -51,3 +52,121 @@ func TestNewKeyFromContext(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, sess, ctxSess)
 }
+
+func TestInScope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpScopes []string
+		inpMethod string
+		res       bool
+	}{
+		{nil, "/thingspect.api.DeviceService/DeleteDevice", true},
+		{
+			[]string{"/thingspect.api.DataPointService/PublishDataPoints"},
+			"/thingspect.api.DataPointService/PublishDataPoints", true,
+		},
+		{
+			[]string{"thingspect.api.DataPointService"},
+			"/thingspect.api.DataPointService/ListDataPoints", true,
+		},
+		{
+			[]string{"/thingspect.api.DataPointService/PublishDataPoints"},
+			"/thingspect.api.DataPointService/ListDataPoints", false,
+		},
+		{
+			[]string{"thingspect.api.DataPoint"},
+			"/thingspect.api.DataPointService/ListDataPoints", false,
+		},
+		{
+			[]string{"thingspect.api.DataPointService"},
+			"/thingspect.api.DeviceService/DeleteDevice", false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can scope %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			sess := &Session{Scopes: test.inpScopes}
+			require.Equal(t, test.res, sess.InScope(test.inpMethod))
+		})
+	}
+}
+
+func TestCanPublish(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpUniqIDs []string
+		inpTags    []string
+		inpUniqID  string
+		inpDevTags []string
+		resRestr   bool
+		res        bool
+	}{
+		{nil, nil, "session-dev", nil, false, true},
+		{[]string{"session-dev"}, nil, "session-dev", nil, true, true},
+		{[]string{"session-dev"}, nil, "SESSION-DEV", nil, true, true},
+		{[]string{"session-dev"}, nil, "session-other", nil, true, false},
+		{
+			nil, []string{"session-tag"}, "session-dev",
+			[]string{"session-x", "session-tag"}, true, true,
+		},
+		{
+			nil, []string{"session-tag"}, "session-dev",
+			[]string{"session-x"}, true, false,
+		},
+		{
+			[]string{"session-other"}, []string{"session-tag"},
+			"session-dev", []string{"session-tag"}, true, true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can publish %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			sess := &Session{UniqIDs: test.inpUniqIDs, Tags: test.inpTags}
+			require.Equal(t, test.resRestr, sess.PublishRestricted())
+			require.Equal(t, test.res, sess.CanPublish(test.inpUniqID,
+				test.inpDevTags))
+		})
+	}
+}
+
+func TestInTagScope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpScopeTags []string
+		inpDevTags   []string
+		resScoped    bool
+		res          bool
+	}{
+		{nil, nil, false, true},
+		{nil, []string{"session-site"}, false, true},
+		{[]string{"session-site"}, []string{"session-site"}, true, true},
+		{
+			[]string{"session-site"}, []string{"session-x", "session-site/b1"},
+			true, true,
+		},
+		{
+			[]string{"session-site/b1"}, []string{"session-site/b1/f2"}, true,
+			true,
+		},
+		{[]string{"session-site/b1"}, []string{"session-site"}, true, false},
+		{[]string{"session-site"}, []string{"session-site2"}, true, false},
+		{[]string{"session-site"}, nil, true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can scope %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			sess := &Session{ScopeTags: test.inpScopeTags}
+			require.Equal(t, test.resScoped, sess.TagScoped())
+			require.Equal(t, test.res, sess.InTagScope(test.inpDevTags))
+		})
+	}
+}
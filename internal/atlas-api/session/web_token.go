@@ -5,8 +5,9 @@ import (
 	"time"
 	"uuid"
 
-	"github.com/thingspect/atlas/internal/atlas-api/auth"
 	"github.com/thingspect/atlas/pkg/consterr"
+	"github.com/thingspect/atlas/pkg/crypt"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/atlas/proto/go/token"
 	"github.com/thingspect/proto/go/api"
 	"google.golang.org/protobuf/proto"
@@ -27,6 +28,17 @@ const (
 func GenerateWebToken(pwtKey []byte, user *api.User) (
 	string, *timestamppb.Timestamp, error,
 ) {
+	return GenerateSessionToken(pwtKey, user, "", nil)
+}
+
+// GenerateSessionToken generates an encrypted protobuf web token in raw (no
+// padding) base64 format, which is bound to a revocable session. If sessID is
+// empty, the token is not bound to a session. If scopeTags are present, the
+// token is scoped to devices by tag. It returns the token, expiration time,
+// and an error value.
+func GenerateSessionToken(
+	pwtKey []byte, user *api.User, sessID string, scopeTags []string,
+) (string, *timestamppb.Timestamp, error) {
 	// Convert user.Id and user.OrgId to bytes.
 	userUUID, err := uuid.Parse(user.GetId())
 	if err != nil {
@@ -49,6 +61,16 @@ func GenerateWebToken(pwtKey []byte, user *api.User) (
 		OrgId:     orgUUID[:],
 		Role:      user.GetRole(),
 		ExpiresAt: exp,
+		ScopeTags: scopeTags,
+	}
+
+	if sessID != "" {
+		sessUUID, err := uuid.Parse(sessID)
+		if err != nil {
+			return "", nil, err
+		}
+
+		pwt.SessionId = sessUUID[:]
 	}
 
 	bPWT, err := proto.Marshal(pwt)
@@ -57,7 +79,7 @@ func GenerateWebToken(pwtKey []byte, user *api.User) (
 	}
 
 	// Encrypt and encode PWT.
-	ePWT, err := auth.Encrypt(pwtKey, bPWT)
+	ePWT, err := crypt.Encrypt(pwtKey, bPWT)
 	if err != nil {
 		return "", nil, err
 	}
@@ -70,22 +92,44 @@ func GenerateWebToken(pwtKey []byte, user *api.User) (
 func GenerateKeyToken(pwtKey []byte, keyID, orgID string, role api.Role) (
 	string, error,
 ) {
+	return GenerateRestrictedKeyToken(pwtKey, role, &message.KeyRestriction{
+		KeyId: keyID, OrgId: orgID,
+	})
+}
+
+// GenerateRestrictedKeyToken generates an encrypted protobuf API key token in
+// raw (no padding) base64 format, which carries the restrictions of the key. It
+// returns the token and an error value.
+func GenerateRestrictedKeyToken(
+	pwtKey []byte, role api.Role, res *message.KeyRestriction,
+) (string, error) {
 	// Convert keyID and orgID to bytes.
-	keyUUID, err := uuid.Parse(keyID)
+	keyUUID, err := uuid.Parse(res.GetKeyId())
 	if err != nil {
 		return "", err
 	}
 
-	orgUUID, err := uuid.Parse(orgID)
+	orgUUID, err := uuid.Parse(res.GetOrgId())
 	if err != nil {
 		return "", err
 	}
 
-	// Build unencrypted PWT.
+	// Build unencrypted PWT. Set exp.Nanos to zero for compactness.
 	pwt := &token.Web{
 		IdOneof: &token.Web_KeyId{KeyId: keyUUID[:]},
 		OrgId:   orgUUID[:],
 		Role:    role,
+		Scopes:  res.GetScopes(),
+		UniqIds: res.GetUniqIds(),
+		Tags:    res.GetTags(),
+
+		ScopeTags: res.GetScopeTags(),
+	}
+
+	if res.GetExpiresAt() != nil {
+		pwt.ExpiresAt = &timestamppb.Timestamp{
+			Seconds: res.GetExpiresAt().GetSeconds(),
+		}
 	}
 
 	bPWT, err := proto.Marshal(pwt)
@@ -94,7 +138,7 @@ func GenerateKeyToken(pwtKey []byte, keyID, orgID string, role api.Role) (
 	}
 
 	// Encrypt and encode PWT.
-	ePWT, err := auth.Encrypt(pwtKey, bPWT)
+	ePWT, err := crypt.Encrypt(pwtKey, bPWT)
 	if err != nil {
 		return "", err
 	}
@@ -110,7 +154,7 @@ func ValidateWebToken(pwtKey []byte, ciphertoken string) (*Session, error) {
 		return nil, err
 	}
 
-	bPWT, err := auth.Decrypt(pwtKey, ePWT)
+	bPWT, err := crypt.Decrypt(pwtKey, ePWT)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +175,12 @@ func ValidateWebToken(pwtKey []byte, ciphertoken string) (*Session, error) {
 	sess := &Session{
 		Role:    pwt.GetRole(),
 		TraceID: uuid.NewV7(),
+
+		Scopes:  pwt.GetScopes(),
+		UniqIDs: pwt.GetUniqIds(),
+		Tags:    pwt.GetTags(),
+
+		ScopeTags: pwt.GetScopeTags(),
 	}
 
 	var idUUID uuid.UUID
@@ -147,5 +197,11 @@ func ValidateWebToken(pwtKey []byte, ciphertoken string) (*Session, error) {
 	_ = copy(orgUUID[:], pwt.GetOrgId())
 	sess.OrgID = orgUUID.String()
 
+	if len(pwt.GetSessionId()) > 0 {
+		var sessUUID uuid.UUID
+		_ = copy(sessUUID[:], pwt.GetSessionId())
+		sess.SessionID = sessUUID.String()
+	}
+
 	return sess, nil
 }
@@ -11,8 +11,9 @@ import (
 	"uuid"
 
 	"github.com/stretchr/testify/require"
-	"github.com/thingspect/atlas/internal/atlas-api/auth"
+	"github.com/thingspect/atlas/pkg/crypt"
 	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/atlas/proto/go/token"
 	"github.com/thingspect/proto/go/api"
 	"google.golang.org/protobuf/proto"
@@ -47,7 +48,7 @@ func TestGenerateWebToken(t *testing.T) {
 		{
 			[]byte{},
 			uuid.NewV7().String(), uuid.NewV7().String(), 0,
-			auth.ErrKeyLength.Error(),
+			crypt.ErrKeyLength.Error(),
 		},
 	}
 
@@ -103,7 +104,7 @@ func TestGenerateKeyToken(t *testing.T) {
 		{
 			[]byte{},
 			uuid.NewV7().String(), uuid.NewV7().String(), 0,
-			auth.ErrKeyLength.Error(),
+			crypt.ErrKeyLength.Error(),
 		},
 	}
 
@@ -131,14 +132,14 @@ func TestValidateWebToken(t *testing.T) {
 	_, err := rand.Read(key)
 	require.NoError(t, err)
 
-	badCipher, err := auth.Encrypt(key, []byte("aaa"))
+	badCipher, err := crypt.Encrypt(key, []byte("aaa"))
 	require.NoError(t, err)
 
 	oldToken := &token.Web{ExpiresAt: timestamppb.New(time.Now().Add(-2 *
 		WebTokenExp * time.Second))}
 	bOldToken, err := proto.Marshal(oldToken)
 	require.NoError(t, err)
-	eOldToken, err := auth.Encrypt(key, bOldToken)
+	eOldToken, err := crypt.Encrypt(key, bOldToken)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -153,7 +154,7 @@ func TestValidateWebToken(t *testing.T) {
 			key, "...", "illegal base64 data at input byte 0",
 		},
 		{
-			key, random.String(10), "auth: malformed ciphertext",
+			key, random.String(10), "crypt: malformed ciphertext",
 		},
 		{
 			key, base64.RawStdEncoding.EncodeToString(badCipher),
@@ -205,7 +206,7 @@ func TestValidateKeyToken(t *testing.T) {
 	_, err := rand.Read(key)
 	require.NoError(t, err)
 
-	badCipher, err := auth.Encrypt(key, []byte("aaa"))
+	badCipher, err := crypt.Encrypt(key, []byte("aaa"))
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -220,7 +221,7 @@ func TestValidateKeyToken(t *testing.T) {
 			key, "...", "illegal base64 data at input byte 0",
 		},
 		{
-			key, random.String(10), "auth: malformed ciphertext",
+			key, random.String(10), "crypt: malformed ciphertext",
 		},
 		{
 			key, base64.RawStdEncoding.EncodeToString(badCipher),
@@ -263,3 +264,121 @@ func TestValidateKeyToken(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRestrictedKeyToken(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	tests := []struct {
+		inpRes *message.KeyRestriction
+		err    string
+	}{
+		{
+			&message.KeyRestriction{
+				KeyId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+				ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+				Scopes:    []string{"thingspect.api.DataPointService"},
+				UniqIds:   []string{"restricted-dev"},
+				Tags:      []string{"restricted-tag"},
+				ScopeTags: []string{"restricted-scope"},
+			}, "",
+		},
+		{
+			&message.KeyRestriction{
+				KeyId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+			}, "",
+		},
+		{
+			&message.KeyRestriction{
+				KeyId: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+				ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute)),
+			}, errWebTokenExp.Error(),
+		},
+		{
+			&message.KeyRestriction{
+				KeyId: random.String(10), OrgId: uuid.NewV7().String(),
+			}, "invalid uuid",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can validate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			resGen, err := GenerateRestrictedKeyToken(key, api.Role_PUBLISHER,
+				test.inpRes)
+			t.Logf("resGen, err: %v, %v", resGen, err)
+			if err != nil {
+				require.EqualError(t, err, test.err)
+
+				return
+			}
+
+			resVal, err := ValidateWebToken(key, resGen)
+			t.Logf("resVal, err: %+v, %v", resVal, err)
+			if test.err != "" {
+				require.Nil(t, resVal)
+				require.EqualError(t, err, test.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.inpRes.GetKeyId(), resVal.KeyID)
+			require.Equal(t, test.inpRes.GetOrgId(), resVal.OrgID)
+			require.Equal(t, api.Role_PUBLISHER, resVal.Role)
+			require.Equal(t, test.inpRes.GetScopes(), resVal.Scopes)
+			require.Equal(t, test.inpRes.GetUniqIds(), resVal.UniqIDs)
+			require.Equal(t, test.inpRes.GetTags(), resVal.Tags)
+			require.Equal(t, test.inpRes.GetScopeTags(), resVal.ScopeTags)
+		})
+	}
+}
+
+func TestValidateSessionToken(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	tests := []struct {
+		inpSessID    string
+		inpScopeTags []string
+		err          string
+	}{
+		{uuid.NewV7().String(), nil, ""},
+		{"", nil, ""},
+		{uuid.NewV7().String(), []string{"sess-site", "sess-x/y"}, ""},
+		{random.String(10), nil, "invalid uuid"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can validate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			user := random.User("sess", uuid.NewV7().String())
+
+			resGen, exp, err := GenerateSessionToken(key, user, test.inpSessID,
+				test.inpScopeTags)
+			t.Logf("resGen, exp, err: %v, %+v, %v", resGen, exp, err)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+
+				return
+			}
+			require.NoError(t, err)
+
+			resVal, err := ValidateWebToken(key, resGen)
+			t.Logf("resVal, err: %+v, %v", resVal, err)
+			require.NoError(t, err)
+			require.Equal(t, user.GetId(), resVal.UserID)
+			require.Equal(t, user.GetOrgId(), resVal.OrgID)
+			require.Equal(t, test.inpSessID, resVal.SessionID)
+			require.Equal(t, test.inpScopeTags, resVal.ScopeTags)
+		})
+	}
+}
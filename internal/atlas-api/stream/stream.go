@@ -0,0 +1,218 @@
+// Package stream provides functions to fan out queue messages to filtered,
+// live subscribers.
+package stream
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/thingspect/atlas/internal/atlas-api/session"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/metric"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// subBuffer is the number of messages buffered per subscriber. Messages are
+// dropped for subscribers that fall behind.
+const subBuffer = 100
+
+// Messager defines the methods provided by a streamable message, such as
+// message.ValidatorOut and message.EventerOut.
+type Messager interface {
+	proto.Message
+	GetPoint() *common.DataPoint
+	GetDevice() *api.Device
+}
+
+// Filter holds the criteria used to match messages for a subscriber. OrgID is
+// required, and empty fields match all values. ScopeTags are the subscriber
+// session's scope tags, and restrict matches to devices within them.
+type Filter struct {
+	OrgID  string
+	UniqID string
+	DevID  string
+	Tag    string
+	Attr   string
+
+	ScopeTags []string
+}
+
+// Matches returns whether a data point and device match the Filter.
+func (f Filter) Matches(point *common.DataPoint, dev *api.Device) bool {
+	if dev.GetOrgId() != f.OrgID {
+		return false
+	}
+
+	if f.UniqID != "" && !strings.EqualFold(dev.GetUniqId(), f.UniqID) {
+		return false
+	}
+
+	if f.DevID != "" && dev.GetId() != f.DevID {
+		return false
+	}
+
+	if f.Tag != "" && !slices.Contains(dev.GetTags(), f.Tag) {
+		return false
+	}
+
+	sess := &session.Session{ScopeTags: f.ScopeTags}
+	if !sess.InTagScope(dev.GetTags()) {
+		return false
+	}
+
+	return f.Attr == "" || point.GetAttr() == f.Attr
+}
+
+// Sub holds a filtered subscription to a Hub.
+type Sub[T Messager] struct {
+	filter Filter
+	msgs   chan T
+	hub    *Hub[T]
+}
+
+// C returns the channel that carries a Sub's messages. The channel is closed
+// when the Hub is closed.
+func (s *Sub[T]) C() <-chan T {
+	return s.msgs
+}
+
+// Unsubscribe removes a Sub from its Hub. It is safe to call multiple times.
+func (s *Sub[T]) Unsubscribe() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	delete(s.hub.subs, s)
+}
+
+// Hub fans out messages from a queue subscription to filtered subscribers.
+type Hub[T Messager] struct {
+	sub    queue.Subber
+	newMsg func() T
+
+	mu     sync.RWMutex
+	subs   map[*Sub[T]]struct{}
+	closed bool
+}
+
+// NewHub builds a new Hub and returns a reference to it. newMsg returns an
+// empty message to unmarshal queue payloads into.
+func NewHub[T Messager](sub queue.Subber, newMsg func() T) *Hub[T] {
+	return &Hub[T]{
+		sub:    sub,
+		newMsg: newMsg,
+
+		subs: make(map[*Sub[T]]struct{}),
+	}
+}
+
+// Subscribe adds a filtered subscriber to the Hub and returns it.
+func (h *Hub[T]) Subscribe(filter Filter) *Sub[T] {
+	s := &Sub[T]{
+		filter: filter,
+		msgs:   make(chan T, subBuffer),
+		hub:    h,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(s.msgs)
+
+		return s
+	}
+
+	h.subs[s] = struct{}{}
+
+	return s
+}
+
+// Close unsubscribes the Hub from its queue subscription and closes the
+// channels of its subscribers, ending their streams. It is safe to call
+// multiple times.
+func (h *Hub[T]) Close() error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+
+		return nil
+	}
+	h.closed = true
+
+	for s := range h.subs {
+		close(s.msgs)
+		delete(h.subs, s)
+	}
+	h.mu.Unlock()
+
+	// Unsubscribe outside of the lock, as in-flight messages may be waiting on
+	// Serve.
+	return h.sub.Unsubscribe()
+}
+
+// Serve distributes queue messages to matching subscribers until the queue
+// subscription is closed. Messages are acknowledged on receipt, as live
+// streams are best-effort.
+func (h *Hub[T]) Serve() {
+	alog.Info("Serve starting stream hub")
+
+	for qMsg := range h.sub.C() {
+		qMsg.Ack()
+
+		msg := h.newMsg()
+		err := proto.Unmarshal(qMsg.Payload(), msg)
+		if err != nil || msg.GetPoint() == nil || msg.GetDevice() == nil {
+			if !bytes.Equal([]byte{queue.Prime}, qMsg.Payload()) {
+				metric.Incr("error", map[string]string{
+					metric.TagFunc: "streamunmarshal",
+				})
+				alog.Errorf("Serve proto.Unmarshal msg, err: %+v, %v", msg,
+					err)
+			}
+
+			continue
+		}
+
+		h.mu.RLock()
+		for s := range h.subs {
+			if !s.filter.Matches(msg.GetPoint(), msg.GetDevice()) {
+				continue
+			}
+
+			select {
+			case s.msgs <- msg:
+			default:
+				metric.Incr("dropped", map[string]string{
+					metric.TagFunc: "stream",
+				})
+			}
+		}
+		h.mu.RUnlock()
+	}
+}
+
+// DataPoint converts a ValidatorOut to its streamed data point.
+func DataPoint(vOut *message.ValidatorOut) *common.DataPoint {
+	return vOut.GetPoint()
+}
+
+// Event converts an EventerOut to its streamed event. Events for cleared rules
+// are flagged, so that they are not mistaken for new events.
+func Event(eOut *message.EventerOut) *message.StreamEvent {
+	return &message.StreamEvent{
+		Event: &api.Event{
+			OrgId:     eOut.GetDevice().GetOrgId(),
+			UniqId:    eOut.GetDevice().GetUniqId(),
+			RuleId:    eOut.GetRule().GetId(),
+			CreatedAt: eOut.GetPoint().GetTs(),
+			TraceId:   eOut.GetPoint().GetTraceId(),
+		},
+		Cleared: eOut.GetCleared(),
+	}
+}
@@ -0,0 +1,168 @@
+//go:build !integration
+
+package stream
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testTimeout = 2 * time.Second
+
+func TestFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("stream", uuid.NewV7().String())
+	dev.Tags = []string{"stream-tag/b1"}
+	point := &common.DataPoint{UniqId: dev.GetUniqId(), Attr: "motion"}
+
+	tests := []struct {
+		inp Filter
+		res bool
+	}{
+		{Filter{OrgID: dev.GetOrgId()}, true},
+		{Filter{
+			OrgID: dev.GetOrgId(), UniqID: strings.ToUpper(dev.GetUniqId()),
+			DevID: dev.GetId(), Tag: "stream-tag/b1", Attr: "motion",
+		}, true},
+		{Filter{OrgID: uuid.NewV7().String()}, false},
+		{Filter{OrgID: dev.GetOrgId(), UniqID: "stream-unknown"}, false},
+		{Filter{OrgID: dev.GetOrgId(), DevID: uuid.NewV7().String()}, false},
+		{Filter{OrgID: dev.GetOrgId(), Tag: "stream-unknown"}, false},
+		{Filter{OrgID: dev.GetOrgId(), Attr: "temp"}, false},
+		{Filter{
+			OrgID: dev.GetOrgId(), ScopeTags: []string{"stream-tag"},
+		}, true},
+		{Filter{OrgID: dev.GetOrgId(), ScopeTags: []string{"stream"}}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can match %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.res, test.inp.Matches(point, dev))
+		})
+	}
+}
+
+func TestHub(t *testing.T) {
+	t.Parallel()
+
+	dpQueue := queue.NewFake()
+	vOutSub, err := dpQueue.Subscribe("")
+	require.NoError(t, err)
+
+	hub := NewHub(vOutSub, func() *message.ValidatorOut {
+		return &message.ValidatorOut{}
+	})
+	go hub.Serve()
+
+	dev := random.Device("stream", uuid.NewV7().String())
+	vOut := &message.ValidatorOut{
+		Point: &common.DataPoint{
+			UniqId: dev.GetUniqId(), Attr: "motion",
+			ValOneof: &common.DataPoint_IntVal{IntVal: 123},
+		}, Device: dev,
+	}
+	bVOut, err := proto.Marshal(vOut)
+	require.NoError(t, err)
+
+	matchSub := hub.Subscribe(Filter{OrgID: dev.GetOrgId(), Attr: "motion"})
+	defer matchSub.Unsubscribe()
+
+	otherSub := hub.Subscribe(Filter{OrgID: uuid.NewV7().String()})
+	otherSub.Unsubscribe()
+
+	require.NoError(t, dpQueue.Prime(""))
+	require.NoError(t, dpQueue.Publish("", []byte("stream-bad")))
+	require.NoError(t, dpQueue.Publish("", bVOut))
+
+	select {
+	case msg := <-matchSub.C():
+		t.Logf("msg: %+v", msg)
+		require.EqualExportedValues(t, vOut, msg)
+	case <-time.After(testTimeout):
+		t.Fatal("Message timed out")
+	}
+
+	select {
+	case msg := <-otherSub.C():
+		t.Fatalf("Unexpected message: %+v", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestHubClose(t *testing.T) {
+	t.Parallel()
+
+	dpQueue := queue.NewFake()
+	vOutSub, err := dpQueue.Subscribe("")
+	require.NoError(t, err)
+
+	hub := NewHub(vOutSub, func() *message.ValidatorOut {
+		return &message.ValidatorOut{}
+	})
+	go hub.Serve()
+
+	sub := hub.Subscribe(Filter{OrgID: uuid.NewV7().String()})
+	defer sub.Unsubscribe()
+
+	require.NoError(t, hub.Close())
+	require.NoError(t, hub.Close())
+
+	select {
+	case msg, ok := <-sub.C():
+		t.Logf("msg, ok: %+v, %v", msg, ok)
+		require.False(t, ok)
+	case <-time.After(testTimeout):
+		t.Fatal("Close timed out")
+	}
+
+	lateSub := hub.Subscribe(Filter{OrgID: uuid.NewV7().String()})
+	defer lateSub.Unsubscribe()
+
+	_, ok := <-lateSub.C()
+	require.False(t, ok)
+}
+
+func TestEvent(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("stream", uuid.NewV7().String())
+	rule := random.Rule("stream", dev.GetOrgId())
+	point := &common.DataPoint{
+		UniqId: dev.GetUniqId(), Attr: "motion", Ts: timestamppb.Now(),
+		TraceId: uuid.NewV7().String(),
+	}
+
+	for _, cleared := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Can convert %v", cleared), func(t *testing.T) {
+			t.Parallel()
+
+			event := Event(&message.EventerOut{
+				Point: point, Device: dev, Rule: rule, Cleared: cleared,
+			})
+			t.Logf("event: %+v", event)
+			require.EqualExportedValues(t, &message.StreamEvent{
+				Event: &api.Event{
+					OrgId: dev.GetOrgId(), UniqId: dev.GetUniqId(),
+					RuleId: rule.GetId(), CreatedAt: point.GetTs(),
+					TraceId: point.GetTraceId(),
+				},
+				Cleared: cleared,
+			}, event)
+		})
+	}
+}
@@ -10,12 +10,14 @@ import (
 	"uuid"
 
 	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-api/service"
 	"github.com/thingspect/atlas/pkg/decode"
 	"github.com/thingspect/atlas/pkg/decode/radiobridge"
 	"github.com/thingspect/atlas/pkg/test/random"
 	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
 	"github.com/thingspect/proto/go/common"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -270,6 +272,66 @@ func TestListDataPoints(t *testing.T) {
 		}
 	})
 
+	t.Run("Aggregate data points by UniqID and dev ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		devCli := api.NewDeviceServiceClient(globalAdminGRPCConn)
+		createDev, err := devCli.CreateDevice(ctx, &api.CreateDeviceRequest{
+			Device: random.Device("api-point", uuid.NewV7().String()),
+		})
+		t.Logf("createDev, err: %+v, %v", createDev, err)
+		require.NoError(t, err)
+
+		// Align points to a single minute bucket in the past.
+		bucket := time.Now().UTC().Truncate(time.Minute).Add(-time.Minute)
+
+		for i, val := range []float64{9.5, 10.5} {
+			err := globalDPDAO.Create(ctx, &common.DataPoint{
+				UniqId: createDev.GetUniqId(), Attr: decode.AttrTempC,
+				ValOneof: &common.DataPoint_Fl64Val{Fl64Val: val},
+				Ts: timestamppb.New(bucket.Add(time.Duration(i+1) *
+					time.Second)), TraceId: uuid.NewV7().String(),
+			}, globalAdminOrgID)
+			t.Logf("err: %v", err)
+			require.NoError(t, err)
+		}
+
+		aggCtx := metadata.AppendToOutgoingContext(ctx,
+			service.AggregateFuncKey, "avg", service.AggregateBucketKey, "1m")
+
+		// Verify results by UniqID.
+		dpCli := api.NewDataPointServiceClient(globalAdminGRPCConn)
+		aggPointsUniqID, err := dpCli.ListDataPoints(aggCtx,
+			&api.ListDataPointsRequest{
+				IdOneof: &api.ListDataPointsRequest_UniqId{
+					UniqId: createDev.GetUniqId(),
+				}, Attr: decode.AttrTempC,
+			})
+		t.Logf("aggPointsUniqID, err: %+v, %v", aggPointsUniqID, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, &api.ListDataPointsResponse{
+			Points: []*common.DataPoint{{
+				UniqId: createDev.GetUniqId(), Attr: decode.AttrTempC,
+				ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 10},
+				Ts:       timestamppb.New(bucket),
+			}},
+		}, aggPointsUniqID)
+
+		// Verify results by dev ID.
+		aggPointsDevID, err := dpCli.ListDataPoints(aggCtx,
+			&api.ListDataPointsRequest{
+				IdOneof: &api.ListDataPointsRequest_DeviceId{
+					DeviceId: createDev.GetId(),
+				}, Attr: decode.AttrTempC,
+			})
+		t.Logf("aggPointsDevID, err: %+v, %v", aggPointsDevID, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, aggPointsUniqID, aggPointsDevID)
+	})
+
 	t.Run("List data points are isolated by org ID", func(t *testing.T) {
 		t.Parallel()
 
@@ -54,6 +54,9 @@ var (
 
 	globalPubTopic string
 	globalPubSub   queue.Subber
+
+	globalEOutTopic string
+	globalQueue     queue.Queuer
 )
 
 func TestMain(m *testing.M) {
@@ -62,6 +65,11 @@ func TestMain(m *testing.M) {
 		log.Fatalf("TestMain rand.Read: %v", err)
 	}
 
+	hookKey := make([]byte, 32)
+	if _, err := rand.Read(hookKey); err != nil {
+		log.Fatalf("TestMain rand.Read: %v", err)
+	}
+
 	// Set up Config.
 	testConfig := testconfig.New()
 	cfg := config.New()
@@ -73,8 +81,13 @@ func TestMain(m *testing.M) {
 	cfg.NSQPubTopic += "-test-" + random.String(10)
 	globalPubTopic = cfg.NSQPubTopic
 	log.Printf("TestMain cfg.NSQPubTopic: %v", cfg.NSQPubTopic)
+	cfg.NSQLookupAddrs = testConfig.NSQLookupAddrs
+	cfg.NSQSubVOutTopic += "-test-" + random.String(10)
+	cfg.NSQSubEOutTopic += "-test-" + random.String(10)
+	globalEOutTopic = cfg.NSQSubEOutTopic
 
 	cfg.PWTKey = key
+	cfg.HookKey = hookKey
 	cfg.APIHost = testConfig.APIHost
 
 	// Set up API.
@@ -162,6 +175,7 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		log.Fatalf("TestMain nsq.Subscribe: %v", err)
 	}
+	globalQueue = nsq
 	log.Printf("TestMain connected as NSQ sub channel: %v", subChannel)
 
 	os.Exit(m.Run())
@@ -10,6 +10,7 @@ import (
 	"uuid"
 
 	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
 	"github.com/thingspect/atlas/pkg/test/random"
 	"github.com/thingspect/proto/go/api"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
@@ -354,10 +355,26 @@ func TestDeleteOrg(t *testing.T) {
 		t.Logf("createOrg, err: %+v, %v", createOrg, err)
 		require.NoError(t, err)
 
+		createUser, err := globalUserDAO.Create(ctx, random.User("api-org",
+			createOrg.GetId()))
+		t.Logf("createUser, err: %+v, %v", createUser, err)
+		require.NoError(t, err)
+
 		_, err = orgCli.DeleteOrg(ctx, &api.DeleteOrgRequest{Id: createOrg.GetId()})
 		t.Logf("err: %v", err)
 		require.NoError(t, err)
 
+		// Org deletion is asynchronous.
+		require.Eventually(t, func() bool {
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			del, err := globalOrgDAO.ReadDeletion(ctx, createOrg.GetId())
+			t.Logf("del, err: %+v, %v", del, err)
+
+			return err == nil && del.GetCompletedAt() != nil
+		}, testTimeout, 100*time.Millisecond)
+
 		t.Run("Read org by deleted ID", func(t *testing.T) {
 			t.Parallel()
 
@@ -373,6 +390,19 @@ func TestDeleteOrg(t *testing.T) {
 			require.EqualError(t, err, "rpc error: code = NotFound desc = "+
 				"dao: object not found")
 		})
+
+		t.Run("Read user by deleted org", func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			readUser, err := globalUserDAO.Read(ctx, createUser.GetId(),
+				createOrg.GetId())
+			t.Logf("readUser, err: %+v, %v", readUser, err)
+			require.Nil(t, readUser)
+			require.Equal(t, dao.ErrNotFound, err)
+		})
 	})
 
 	t.Run("Delete org with insufficient role", func(t *testing.T) {
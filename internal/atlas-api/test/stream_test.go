@@ -0,0 +1,89 @@
+//go:build !unit
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestStreamEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stream cleared events by UniqID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		dev := random.Device("api-stream", globalAdminOrgID)
+		eOut := &message.EventerOut{
+			Point: &common.DataPoint{
+				UniqId: dev.GetUniqId(), Attr: "motion",
+				ValOneof: &common.DataPoint_IntVal{IntVal: 123},
+				Ts:       timestamppb.Now(), TraceId: uuid.NewV7().String(),
+			}, Device: dev, Rule: random.Rule("api-stream", globalAdminOrgID),
+			Cleared: true,
+		}
+		bEOut, err := proto.Marshal(eOut)
+		require.NoError(t, err)
+
+		streamCli := message.NewStreamServiceClient(globalAdminGRPCConn)
+		evStream, err := streamCli.StreamEvents(ctx,
+			&message.StreamEventsRequest{UniqId: dev.GetUniqId()})
+		require.NoError(t, err)
+
+		// Publish until received, as the stream may not yet be subscribed.
+		pubCtx, pubCancel := context.WithCancel(ctx)
+		defer pubCancel()
+
+		go func() {
+			ticker := time.NewTicker(100 * time.Millisecond)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-pubCtx.Done():
+					return
+				case <-ticker.C:
+					_ = globalQueue.Publish(globalEOutTopic, bEOut)
+				}
+			}
+		}()
+
+		event, err := evStream.Recv()
+		t.Logf("event, err: %+v, %v", event, err)
+		require.NoError(t, err)
+		require.Equal(t, dev.GetUniqId(), event.GetEvent().GetUniqId())
+		require.Equal(t, eOut.GetRule().GetId(), event.GetEvent().GetRuleId())
+		require.True(t, event.GetCleared())
+	})
+
+	t.Run("Stream events without authentication", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		streamCli := message.NewStreamServiceClient(globalNoAuthGRPCConn)
+		evStream, err := streamCli.StreamEvents(ctx,
+			&message.StreamEventsRequest{})
+		require.NoError(t, err)
+
+		event, err := evStream.Recv()
+		t.Logf("event, err: %+v, %v", event, err)
+		require.Nil(t, event)
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
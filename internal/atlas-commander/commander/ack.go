@@ -0,0 +1,154 @@
+package commander
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/metric"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/proto"
+)
+
+// ackMessages receives command acknowledgments and updates command statuses.
+func (com *Commander) ackMessages() {
+	alog.Info("ackMessages starting processor")
+
+	var processCount int
+	for msg := range com.cmdAckSub.C() {
+		// Retrieve published message.
+		metric.Incr("received", nil)
+		cmdAck := &message.CommandAck{}
+		err := proto.Unmarshal(msg.Payload(), cmdAck)
+		if err != nil || (cmdAck.GetId() == "" &&
+			cmdAck.GetLoraQueueId() == "") {
+			msg.Ack()
+
+			if !bytes.Equal([]byte{queue.Prime}, msg.Payload()) {
+				metric.Incr("error",
+					map[string]string{metric.TagFunc: "unmarshal"})
+				alog.Errorf("ackMessages proto.Unmarshal cmdAck, err: %+v, %v",
+					cmdAck, err)
+			}
+
+			continue
+		}
+
+		// Set up logging fields.
+		logger := alog.
+			WithField("traceID", cmdAck.GetTraceId()).
+			WithField("orgID", cmdAck.GetOrgId()).
+			WithField("uniqID", cmdAck.GetUniqId()).
+			WithField("cmdID", cmdAck.GetId())
+
+		// Authenticate acknowledgments by ID, which are published by devices,
+		// by device token. Acknowledgments by LoRaWAN queue item ID are
+		// published by the network server.
+		if cmdAck.GetId() != "" {
+			ctx, cancel := context.WithTimeout(context.Background(),
+				5*time.Second)
+			dev, err := com.devDAO.ReadByUniqID(ctx, cmdAck.GetUniqId())
+			cancel()
+			if errors.Is(err, dao.ErrNotFound) {
+				msg.Ack()
+				metric.Incr("notfound", nil)
+				logger.Debugf("ackMessages device not found: %+v", cmdAck)
+
+				continue
+			}
+			if err != nil {
+				msg.Requeue()
+				metric.Incr("error",
+					map[string]string{metric.TagFunc: "readbyuniqid"})
+				logger.Errorf("ackMessages com.devDAO.ReadByUniqID: %v", err)
+
+				continue
+			}
+
+			if cmdAck.GetOrgId() != dev.GetOrgId() ||
+				cmdAck.GetToken() != dev.GetToken() {
+				msg.Ack()
+				metric.Incr("invalid",
+					map[string]string{metric.TagFunc: "token"})
+				logger.Debugf("ackMessages invalid token: %+v", cmdAck)
+
+				continue
+			}
+		}
+
+		// Update command status. Acknowledgments by LoRaWAN queue item ID may
+		// precede storage of the queue item ID, and are requeued until the
+		// queue's maximum attempts. Acknowledgments of unknown or completed
+		// commands, such as downlinks not sent as commands, are then discarded.
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err = com.cmdDAO.UpdateStatus(ctx, cmdAck)
+		cancel()
+		if errors.Is(err, dao.ErrNotFound) && cmdAck.GetId() == "" {
+			msg.Requeue()
+			metric.Incr("unknown", map[string]string{"type": "lora"})
+			logger.Debugf("ackMessages requeue com.cmdDAO.UpdateStatus: %v",
+				err)
+
+			continue
+		}
+		if errors.Is(err, dao.ErrNotFound) {
+			msg.Ack()
+			metric.Incr("unknown", nil)
+			logger.Debugf("ackMessages discard com.cmdDAO.UpdateStatus: %v",
+				err)
+
+			continue
+		}
+		if errors.Is(err, dao.ErrInvalidFormat) {
+			msg.Ack()
+			metric.Incr("error", map[string]string{metric.TagFunc: "update"})
+			logger.Errorf("ackMessages invalid com.cmdDAO.UpdateStatus: %v",
+				err)
+
+			continue
+		}
+		if err != nil {
+			msg.Requeue()
+			metric.Incr("error", map[string]string{metric.TagFunc: "update"})
+			logger.Errorf("ackMessages requeue com.cmdDAO.UpdateStatus: %v",
+				err)
+
+			continue
+		}
+
+		msg.Ack()
+		metric.Incr("processed", map[string]string{
+			"status": cmdAck.GetStatus().String(),
+		})
+		logger.Debugf("ackMessages processed: %+v", cmdAck)
+
+		processCount++
+		if processCount%100 == 0 {
+			alog.Infof("ackMessages processed %v messages", processCount)
+		}
+	}
+}
+
+// expireCommands marks outstanding commands that are past expiration as
+// expired.
+func (com *Commander) expireCommands() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := com.cmdDAO.Expire(ctx, time.Now())
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "expire"})
+		alog.Errorf("expireCommands com.cmdDAO.Expire: %v", err)
+
+		return
+	}
+
+	if count > 0 {
+		metric.Count("expired", int(count), nil)
+		alog.Infof("expireCommands expired %v commands", count)
+	}
+}
@@ -0,0 +1,226 @@
+//go:build !integration
+
+package commander
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/consterr"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/pkg/test/matcher"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+)
+
+const errTestProc consterr.Error = "commander: test processor error"
+
+func TestAckMessages(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("com", uuid.NewV7().String())
+
+	tests := []struct {
+		inp *message.CommandAck
+	}{
+		{&message.CommandAck{
+			Id: uuid.NewV7().String(), OrgId: dev.GetOrgId(),
+			UniqId: dev.GetUniqId(), Status: message.CommandStatus_ACKED,
+			Token: dev.GetToken(),
+		}},
+		{&message.CommandAck{
+			LoraQueueId: uuid.NewV7().String(),
+			Status:      message.CommandStatus_SENT,
+		}},
+		{&message.CommandAck{
+			LoraQueueId: uuid.NewV7().String(),
+			Status:      message.CommandStatus_FAILED, Error: "DOWNLINK_TIMEOUT",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can ack %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			cmdAckQueue := queue.NewFake()
+			cmdAckSub, err := cmdAckQueue.Subscribe("")
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			var devTimes int
+			if test.inp.GetId() != "" {
+				devTimes = 1
+			}
+
+			ctrl := gomock.NewController(t)
+			devicer := NewMockdevicer(ctrl)
+			devicer.EXPECT().ReadByUniqID(gomock.Any(), dev.GetUniqId()).
+				Return(dev, nil).Times(devTimes)
+			commander := NewMockcommander(ctrl)
+			commander.EXPECT().UpdateStatus(gomock.Any(),
+				matcher.NewProtoMatcher(test.inp)).
+				DoAndReturn(func(_ any, _ any) error {
+					defer wg.Done()
+
+					return nil
+				}).Times(1)
+
+			com := Commander{
+				cmdDAO: commander,
+				devDAO: devicer,
+
+				cmdQueue:  cmdAckQueue,
+				cmdAckSub: cmdAckSub,
+			}
+			go func() {
+				com.ackMessages()
+			}()
+
+			bCmdAck, err := proto.Marshal(test.inp)
+			require.NoError(t, err)
+			t.Logf("bCmdAck: %s", bCmdAck)
+
+			require.NoError(t, cmdAckQueue.Publish("", bCmdAck))
+			wg.Wait()
+		})
+	}
+}
+
+func TestAckMessagesError(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("com", uuid.NewV7().String())
+
+	cmdAck := &message.CommandAck{
+		Id: uuid.NewV7().String(), OrgId: dev.GetOrgId(),
+		UniqId: dev.GetUniqId(), Status: message.CommandStatus_ACKED,
+		Token: dev.GetToken(),
+	}
+
+	badOrg, _ := proto.Clone(cmdAck).(*message.CommandAck)
+	badOrg.OrgId = uuid.NewV7().String()
+
+	badToken, _ := proto.Clone(cmdAck).(*message.CommandAck)
+	badToken.Token = uuid.NewV7().String()
+
+	tests := []struct {
+		inpCmdAck   *message.CommandAck
+		inpDevErr   error
+		inpDevTimes int
+		inpErr      error
+		inpTimes    int
+	}{
+		// Bad payload.
+		{nil, nil, 0, nil, 0},
+		// Missing IDs.
+		{&message.CommandAck{Status: message.CommandStatus_ACKED}, nil, 0, nil,
+			0},
+		// Device not found.
+		{cmdAck, dao.ErrNotFound, 1, nil, 0},
+		// Device error.
+		{cmdAck, errTestProc, 1, nil, 0},
+		// Invalid org ID or token.
+		{badOrg, nil, 1, nil, 0},
+		{badToken, nil, 1, nil, 0},
+		// Unknown or completed command.
+		{cmdAck, nil, 1, dao.ErrNotFound, 1},
+		{&message.CommandAck{
+			LoraQueueId: uuid.NewV7().String(),
+			Status:      message.CommandStatus_SENT,
+		}, nil, 0, dao.ErrNotFound, 1},
+		// Invalid command ID.
+		{cmdAck, nil, 1, dao.ErrInvalidFormat, 1},
+		// Commander error.
+		{cmdAck, nil, 1, errTestProc, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cannot ack %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			cmdAckQueue := queue.NewFake()
+			cmdAckSub, err := cmdAckQueue.Subscribe("")
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			ctrl := gomock.NewController(t)
+			devicer := NewMockdevicer(ctrl)
+			devicer.EXPECT().ReadByUniqID(gomock.Any(), dev.GetUniqId()).
+				Return(dev, test.inpDevErr).Times(test.inpDevTimes)
+			commander := NewMockcommander(ctrl)
+			commander.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ any, _ any) error {
+					defer wg.Done()
+
+					return test.inpErr
+				}).Times(test.inpTimes)
+
+			com := Commander{
+				cmdDAO: commander,
+				devDAO: devicer,
+
+				cmdQueue:  cmdAckQueue,
+				cmdAckSub: cmdAckSub,
+			}
+			go func() {
+				com.ackMessages()
+			}()
+
+			bCmdAck := []byte("com-aaa")
+			if test.inpCmdAck != nil {
+				var err error
+				bCmdAck, err = proto.Marshal(test.inpCmdAck)
+				require.NoError(t, err)
+				t.Logf("bCmdAck: %s", bCmdAck)
+			}
+
+			require.NoError(t, cmdAckQueue.Publish("", bCmdAck))
+			if test.inpTimes > 0 {
+				wg.Wait()
+			} else {
+				// If the failure mode isn't supported by WaitGroup operation,
+				// give it time to traverse the code.
+				time.Sleep(100 * time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestExpireCommands(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpCount int64
+		inpErr   error
+	}{
+		{0, nil},
+		{3, nil},
+		{0, errTestProc},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can expire %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			commander := NewMockcommander(gomock.NewController(t))
+			commander.EXPECT().Expire(gomock.Any(),
+				gomock.Cond(func(x time.Time) bool {
+					return time.Since(x) < time.Minute
+				})).Return(test.inpCount, test.inpErr).Times(1)
+
+			com := Commander{cmdDAO: commander}
+			com.expireCommands()
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Package commander provides functions used to run the Commander service.
+package commander
+
+//go:generate mockgen -source commander.go -destination mock_commander_test.go -package commander
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-commander/config"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/command"
+	"github.com/thingspect/atlas/pkg/dao/device"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+// ServiceName provides consistent naming, including logs and metrics.
+const ServiceName = "commander"
+
+// commander defines the methods provided by a command.DAO.
+type commander interface {
+	UpdateStatus(ctx context.Context, ack *message.CommandAck) error
+	Expire(ctx context.Context, now time.Time) (int64, error)
+}
+
+// devicer defines the methods provided by a device.DAO.
+type devicer interface {
+	ReadByUniqID(ctx context.Context, uniqID string) (*api.Device, error)
+}
+
+// Commander holds references to the database and message broker connections.
+type Commander struct {
+	cmdDAO commander
+	devDAO devicer
+
+	cmdQueue  queue.Queuer
+	cmdAckSub queue.Subber
+}
+
+// New builds a new Commander and returns a reference to it and an error value.
+func New(cfg *config.Config) (*Commander, error) {
+	// Set up database connection.
+	pgRW, err := dao.NewPgDB(cfg.PgRwURI)
+	if err != nil {
+		return nil, err
+	}
+
+	pgRO, err := dao.NewPgDB(cfg.PgRoURI)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the NSQ connection for consuming.
+	nsq, err := queue.NewNSQ(cfg.NSQPubAddr, cfg.NSQLookupAddrs,
+		cfg.NSQSubChannel)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prime the queue before subscribing to allow for discovery by nsqlookupd.
+	if err = nsq.Prime(cfg.NSQSubTopic); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to the topic.
+	cmdAckSub, err := nsq.Subscribe(cfg.NSQSubTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Commander{
+		cmdDAO: command.NewDAO(pgRW, pgRO),
+		devDAO: device.NewDAO(pgRW, pgRO, nil, 0),
+
+		cmdQueue:  nsq,
+		cmdAckSub: cmdAckSub,
+	}, nil
+}
+
+// Serve starts the acknowledgment processors and the expiration of outstanding
+// commands, which runs once per interval.
+func (com *Commander) Serve(concurrency int, expireInterval time.Duration) {
+	for range concurrency {
+		go com.ackMessages()
+	}
+
+	go func() {
+		ticker := time.NewTicker(expireInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			com.expireCommands()
+		}
+	}()
+
+	// Handle graceful shutdown.
+	exitChan := make(chan os.Signal, 1)
+	signal.Notify(exitChan, syscall.SIGINT, syscall.SIGTERM)
+	<-exitChan
+
+	alog.Info("Serve received signal, exiting")
+	if err := com.cmdAckSub.Unsubscribe(); err != nil {
+		alog.Errorf("Serve com.cmdAckSub.Unsubscribe: %v", err)
+	}
+	com.cmdQueue.Disconnect()
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: commander.go
+//
+// Generated by this command:
+//
+//	mockgen -source commander.go -destination mock_commander_test.go -package commander
+//
+
+// Package commander is a generated GoMock package.
+package commander
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	api "github.com/thingspect/proto/go/api"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockcommander is a mock of commander interface.
+type Mockcommander struct {
+	ctrl     *gomock.Controller
+	recorder *MockcommanderMockRecorder
+	isgomock struct{}
+}
+
+// MockcommanderMockRecorder is the mock recorder for Mockcommander.
+type MockcommanderMockRecorder struct {
+	mock *Mockcommander
+}
+
+// NewMockcommander creates a new mock instance.
+func NewMockcommander(ctrl *gomock.Controller) *Mockcommander {
+	mock := &Mockcommander{ctrl: ctrl}
+	mock.recorder = &MockcommanderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcommander) EXPECT() *MockcommanderMockRecorder {
+	return m.recorder
+}
+
+// Expire mocks base method.
+func (m *Mockcommander) Expire(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockcommanderMockRecorder) Expire(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*Mockcommander)(nil).Expire), ctx, now)
+}
+
+// UpdateStatus mocks base method.
+func (m *Mockcommander) UpdateStatus(ctx context.Context, ack *message.CommandAck) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, ack)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockcommanderMockRecorder) UpdateStatus(ctx, ack any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*Mockcommander)(nil).UpdateStatus), ctx, ack)
+}
+
+// Mockdevicer is a mock of devicer interface.
+type Mockdevicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockdevicerMockRecorder
+	isgomock struct{}
+}
+
+// MockdevicerMockRecorder is the mock recorder for Mockdevicer.
+type MockdevicerMockRecorder struct {
+	mock *Mockdevicer
+}
+
+// NewMockdevicer creates a new mock instance.
+func NewMockdevicer(ctrl *gomock.Controller) *Mockdevicer {
+	mock := &Mockdevicer{ctrl: ctrl}
+	mock.recorder = &MockdevicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdevicer) EXPECT() *MockdevicerMockRecorder {
+	return m.recorder
+}
+
+// ReadByUniqID mocks base method.
+func (m *Mockdevicer) ReadByUniqID(ctx context.Context, uniqID string) (*api.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByUniqID", ctx, uniqID)
+	ret0, _ := ret[0].(*api.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByUniqID indicates an expected call of ReadByUniqID.
+func (mr *MockdevicerMockRecorder) ReadByUniqID(ctx, uniqID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByUniqID", reflect.TypeOf((*Mockdevicer)(nil).ReadByUniqID), ctx, uniqID)
+}
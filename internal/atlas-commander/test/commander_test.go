@@ -0,0 +1,156 @@
+//go:build !unit
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testTimeout = 6 * time.Second
+
+func TestAckMessages(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("com"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createDev, err := globalDevDAO.Create(ctx, random.Device("com",
+		createOrg.GetId()))
+	t.Logf("createDev, err: %+v, %v", createDev, err)
+	require.NoError(t, err)
+
+	tests := []struct {
+		inpLoRa   bool
+		inpStatus message.CommandStatus
+		inpErr    string
+	}{
+		{false, message.CommandStatus_ACKED, ""},
+		{false, message.CommandStatus_FAILED, "com-error"},
+		{true, message.CommandStatus_SENT, ""},
+		{true, message.CommandStatus_ACKED, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can ack %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			cmd := random.Command("com", createOrg.GetId())
+			cmd.UniqId = createDev.GetUniqId()
+			cmd.LoraQueueId = uuid.NewV7().String()
+
+			createCmd, err := globalCmdDAO.Create(ctx, cmd)
+			t.Logf("createCmd, err: %+v, %v", createCmd, err)
+			require.NoError(t, err)
+
+			cmdAck := &message.CommandAck{
+				UniqId: createCmd.GetUniqId(), Status: test.inpStatus,
+				Error: test.inpErr, TraceId: uuid.NewV7().String(),
+			}
+			if test.inpLoRa {
+				cmdAck.LoraQueueId = createCmd.GetLoraQueueId()
+			} else {
+				cmdAck.Id = createCmd.GetId()
+				cmdAck.OrgId = createCmd.GetOrgId()
+				cmdAck.Token = createDev.GetToken()
+			}
+
+			bCmdAck, err := proto.Marshal(cmdAck)
+			require.NoError(t, err)
+			t.Logf("bCmdAck: %s", bCmdAck)
+
+			require.NoError(t, globalCmdQueue.Publish(globalCmdAckSubTopic,
+				bCmdAck))
+			time.Sleep(2 * time.Second)
+
+			readCmd, err := globalCmdDAO.Read(ctx, createCmd.GetId(),
+				createCmd.GetOrgId())
+			t.Logf("readCmd, err: %+v, %v", readCmd, err)
+			require.NoError(t, err)
+			require.Equal(t, test.inpStatus, readCmd.GetStatus())
+			require.Equal(t, test.inpErr, readCmd.GetError())
+		})
+	}
+}
+
+func TestAckMessagesInvalidToken(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("com"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createDev, err := globalDevDAO.Create(ctx, random.Device("com",
+		createOrg.GetId()))
+	t.Logf("createDev, err: %+v, %v", createDev, err)
+	require.NoError(t, err)
+
+	cmd := random.Command("com", createOrg.GetId())
+	cmd.UniqId = createDev.GetUniqId()
+
+	createCmd, err := globalCmdDAO.Create(ctx, cmd)
+	t.Logf("createCmd, err: %+v, %v", createCmd, err)
+	require.NoError(t, err)
+
+	bCmdAck, err := proto.Marshal(&message.CommandAck{
+		Id: createCmd.GetId(), OrgId: createCmd.GetOrgId(),
+		UniqId: createCmd.GetUniqId(), Status: message.CommandStatus_ACKED,
+		TraceId: uuid.NewV7().String(), Token: uuid.NewV7().String(),
+	})
+	require.NoError(t, err)
+	t.Logf("bCmdAck: %s", bCmdAck)
+
+	require.NoError(t, globalCmdQueue.Publish(globalCmdAckSubTopic, bCmdAck))
+	time.Sleep(2 * time.Second)
+
+	readCmd, err := globalCmdDAO.Read(ctx, createCmd.GetId(),
+		createCmd.GetOrgId())
+	t.Logf("readCmd, err: %+v, %v", readCmd, err)
+	require.NoError(t, err)
+	require.Equal(t, message.CommandStatus_QUEUED, readCmd.GetStatus())
+}
+
+func TestExpireCommands(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("com"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	cmd := random.Command("com", createOrg.GetId())
+	cmd.ExpiresAt = timestamppb.New(time.Now().Add(time.Second))
+
+	createCmd, err := globalCmdDAO.Create(ctx, cmd)
+	t.Logf("createCmd, err: %+v, %v", createCmd, err)
+	require.NoError(t, err)
+
+	time.Sleep(3 * time.Second)
+
+	readCmd, err := globalCmdDAO.Read(ctx, createCmd.GetId(),
+		createCmd.GetOrgId())
+	t.Logf("readCmd, err: %+v, %v", readCmd, err)
+	require.NoError(t, err)
+	require.Equal(t, message.CommandStatus_EXPIRED, readCmd.GetStatus())
+}
@@ -0,0 +1,74 @@
+//go:build !unit
+
+package test
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-commander/commander"
+	"github.com/thingspect/atlas/internal/atlas-commander/config"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/command"
+	"github.com/thingspect/atlas/pkg/dao/device"
+	"github.com/thingspect/atlas/pkg/dao/org"
+	"github.com/thingspect/atlas/pkg/queue"
+	testconfig "github.com/thingspect/atlas/pkg/test/config"
+	"github.com/thingspect/atlas/pkg/test/random"
+)
+
+var (
+	globalCmdAckSubTopic string
+	globalCmdQueue       queue.Queuer
+
+	globalOrgDAO *org.DAO
+	globalDevDAO *device.DAO
+	globalCmdDAO *command.DAO
+)
+
+func TestMain(m *testing.M) {
+	// Set up Config.
+	testConfig := testconfig.New()
+	cfg := config.New()
+	cfg.PgRwURI = testConfig.PgURI
+	cfg.PgRoURI = testConfig.PgURI
+	cfg.ExpireInterval = time.Second
+
+	cfg.NSQPubAddr = testConfig.NSQPubAddr
+	cfg.NSQLookupAddrs = testConfig.NSQLookupAddrs
+	cfg.NSQSubTopic += "-test-" + random.String(10)
+	globalCmdAckSubTopic = cfg.NSQSubTopic
+	log.Printf("TestMain cfg.NSQSubTopic: %v", cfg.NSQSubTopic)
+	// Use a unique channel for each test run. This prevents failed tests from
+	// interfering with the next run, but does require eventual cleaning.
+	cfg.NSQSubChannel = "commander-test-" + random.String(10)
+
+	// Set up NSQ queue to publish test payloads.
+	var err error
+	globalCmdQueue, err = queue.NewNSQ(cfg.NSQPubAddr, nil, "")
+	if err != nil {
+		log.Fatalf("TestMain queue.NewNSQ: %v", err)
+	}
+
+	// Set up Commander.
+	com, err := commander.New(cfg)
+	if err != nil {
+		log.Fatalf("TestMain commander.New: %v", err)
+	}
+
+	// Serve connections.
+	go com.Serve(cfg.Concurrency, cfg.ExpireInterval)
+
+	// Set up database connection.
+	pg, err := dao.NewPgDB(cfg.PgRwURI)
+	if err != nil {
+		log.Fatalf("TestMain dao.NewPgDB: %v", err)
+	}
+	globalOrgDAO = org.NewDAO(pg, pg)
+	globalDevDAO = device.NewDAO(pg, pg, nil, 0)
+	globalCmdDAO = command.NewDAO(pg, pg)
+
+	os.Exit(m.Run())
+}
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
 	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/latest"
 	"github.com/thingspect/atlas/pkg/metric"
 	"github.com/thingspect/atlas/pkg/queue"
 	"github.com/thingspect/atlas/pkg/rule"
@@ -17,6 +19,10 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// stateExp is the expiration of cached rule state, which is refreshed on each
+// evaluation.
+const stateExp = 30 * 24 * time.Hour
+
 // eventMessages receives data point messages, events based on rule processing,
 // and builds messages for publishing.
 func (ev *Eventer) eventMessages() {
@@ -65,10 +71,100 @@ func (ev *Eventer) eventMessages() {
 		}
 		logger.Debugf("eventMessages rules: %+v", rules)
 
-		// Evaluate, event, and optionally publish EventerOut messages.
+		// Retrieve conditions of stateful rules.
+		var conds map[string]*message.RuleCondition
+		if len(rules) > 0 {
+			ruleIDs := make([]string, 0, len(rules))
+			for _, r := range rules {
+				ruleIDs = append(ruleIDs, r.GetId())
+			}
+
+			dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			conds, err = ev.ruleDAO.ListConditions(dCtx,
+				vOut.GetDevice().GetOrgId(), ruleIDs)
+			cancel()
+			if err != nil {
+				msg.Requeue()
+				metric.Incr("error",
+					map[string]string{metric.TagFunc: "listconditions"})
+				logger.Errorf("eventMessages ev.ruleDAO.ListConditions: %v",
+					err)
+
+				continue
+			}
+		}
+
+		// Retrieve which stateless rules have alarms with a recovery. Only
+		// those rules track their state, to generate clear events.
+		var recRules map[string]bool
+		statelessIDs := make([]string, 0, len(rules))
+		for _, r := range rules {
+			if conds[r.GetId()] == nil {
+				statelessIDs = append(statelessIDs, r.GetId())
+			}
+		}
+
+		if len(statelessIDs) > 0 {
+			dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			recRules, err = ev.alarmDAO.ListRecoveryRules(dCtx,
+				vOut.GetDevice().GetOrgId(), statelessIDs)
+			cancel()
+			if err != nil {
+				msg.Requeue()
+				metric.Incr("error",
+					map[string]string{metric.TagFunc: "listrecoveryrules"})
+				logger.Errorf("eventMessages ev.alarmDAO.ListRecoveryRules: "+
+					"%v", err)
+
+				continue
+			}
+		}
+
+		// Retrieve the latest values of other attributes and the history of the
+		// data point's attribute, as referenced by rules.
+		exprs := make([]string, 0, len(rules))
 		for _, r := range rules {
-			ev.evalRules(alog.NewContext(ctx, &alog.CtxLogger{Logger: logger}),
-				vOut, r)
+			exprs = append(exprs, r.GetExpr(), conds[r.GetId()].GetExitExpr())
+		}
+		useHist := rule.UsesHistory(exprs...)
+
+		env, err := ev.ruleEnv(ctx, vOut, rule.LatestAttrs(exprs...), useHist)
+		if err != nil {
+			msg.Requeue()
+			metric.Incr("error", map[string]string{metric.TagFunc: "ruleenv"})
+			logger.Errorf("eventMessages ev.ruleEnv: %v", err)
+
+			continue
+		}
+
+		// Evaluate, event, and optionally publish EventerOut messages. Requeue
+		// the message if the state of a rule with a condition is unavailable,
+		// as its events would otherwise be lost. States that were already
+		// advanced are skipped by trace ID, and events that were already
+		// created are protected by duplicate events on redelivery.
+		rCtx := alog.NewContext(ctx, &alog.CtxLogger{Logger: logger})
+		for _, r := range rules {
+			if err = ev.evalRules(rCtx, vOut, env, r, conds[r.GetId()],
+				recRules[r.GetId()]); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			msg.Requeue()
+			metric.Incr("error", map[string]string{metric.TagFunc: "state"})
+			logger.Errorf("eventMessages ev.evalRules: %v", err)
+
+			continue
+		}
+
+		// Add the data point to history. Do not attempt to coordinate history
+		// with event failures.
+		if useHist {
+			if err := ev.setHistory(ctx, vOut, env.History); err != nil {
+				metric.Incr("error",
+					map[string]string{metric.TagFunc: "sethistory"})
+				logger.Errorf("eventMessages ev.setHistory: %v", err)
+			}
 		}
 
 		msg.Ack()
@@ -81,74 +177,267 @@ func (ev *Eventer) eventMessages() {
 	}
 }
 
+// ruleEnv builds a rule environment containing the latest cached data points
+// of attributes and, optionally, the cached history of the data point's
+// attribute. The cache is only queried if needed.
+func (ev *Eventer) ruleEnv(
+	ctx context.Context, vOut *message.ValidatorOut, attrs []string,
+	useHist bool,
+) (*rule.Env, error) {
+	env := &rule.Env{}
+
+	if len(attrs) > 0 {
+		var err error
+		env.Latest, err = latest.Get(ctx, ev.stateCache,
+			vOut.GetDevice().GetOrgId(), vOut.GetDevice().GetId(), attrs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if useHist {
+		var err error
+		if env.History, err = ev.getHistory(ctx, vOut); err != nil {
+			return nil, err
+		}
+	}
+
+	return env, nil
+}
+
 // evalRules evaluates rules, generates events, and optionally publishes
-// EventerOut messages.
+// EventerOut messages. Rules without a condition generate an event for each
+// matching data point, while rules with a condition generate an event only
+// when they become active. Rules with a condition, and rules without a
+// condition that have alarms with a recovery, generate a clear event when they
+// become inactive. An error is returned only if the state of a rule with a
+// condition could not be retrieved or stored.
+//
+// The state of a rule with a condition records the trace ID of the last data
+// point applied, so that a requeued data point is not applied twice.
 func (ev *Eventer) evalRules(
-	ctx context.Context, vOut *message.ValidatorOut, r *api.Rule,
+	ctx context.Context, vOut *message.ValidatorOut, env *rule.Env,
+	r *api.Rule, cond *message.RuleCondition, recovery bool,
+) error {
+	if cond == nil {
+		ev.evalStateless(ctx, vOut, env, r, recovery)
+
+		return nil
+	}
+
+	logger := alog.FromContext(ctx)
+	key := stateKey(vOut.GetDevice().GetOrgId(), vOut.GetDevice().GetId(),
+		r.GetId())
+
+	state, err := ev.getState(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	traceID := vOut.GetPoint().GetTraceId()
+	if traceID != "" && state.GetTraceId() == traceID {
+		logger.Debugf("evalRules rule %v already applied", r.GetId())
+
+		return nil
+	}
+
+	newState, trans, err := rule.EvalCondition(vOut.GetPoint(), env,
+		r.GetExpr(), cond, state)
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "eval"})
+		logger.Errorf("eventMessages rule.EvalCondition: %v", err)
+
+		return nil
+	}
+
+	newState.TraceId = traceID
+	if err := ev.setState(ctx, key, newState); err != nil {
+		return err
+	}
+
+	metric.Incr("evaluated", map[string]string{
+		"result": strconv.FormatBool(trans == rule.Enter),
+	})
+
+	if trans != rule.None {
+		metric.Incr("transition", map[string]string{"type": trans.String()})
+		logger.Debugf("evalRules rule %v transition: %v", r.GetId(), trans)
+	}
+
+	switch trans {
+	case rule.Enter:
+		ev.event(ctx, vOut, r, false)
+	case rule.Exit:
+		ev.event(ctx, vOut, r, true)
+	}
+
+	return nil
+}
+
+// evalStateless evaluates a rule without a condition, which generates an event
+// for each matching data point regardless of its cached state. The state is
+// tracked only for rules with a recovery, to generate a clear event, which is
+// skipped if the cache is unavailable.
+func (ev *Eventer) evalStateless(
+	ctx context.Context, vOut *message.ValidatorOut, env *rule.Env,
+	r *api.Rule, recovery bool,
 ) {
 	logger := alog.FromContext(ctx)
 
-	res, err := rule.Eval(vOut.GetPoint(), r.GetExpr())
+	res, err := rule.Eval(vOut.GetPoint(), env, r.GetExpr())
 	if err != nil {
 		metric.Incr("error", map[string]string{metric.TagFunc: "eval"})
 		logger.Errorf("eventMessages rule.Eval: %v", err)
 
 		return
 	}
+
 	metric.Incr("evaluated", map[string]string{
 		"result": strconv.FormatBool(res),
 	})
 
-	if res {
-		event := &api.Event{
-			OrgId:     vOut.GetDevice().GetOrgId(),
-			UniqId:    vOut.GetDevice().GetUniqId(),
-			RuleId:    r.GetId(),
-			CreatedAt: vOut.GetPoint().GetTs(),
-			TraceId:   vOut.GetPoint().GetTraceId(),
-		}
+	key := stateKey(vOut.GetDevice().GetOrgId(), vOut.GetDevice().GetId(),
+		r.GetId())
 
-		dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-		err := ev.evDAO.Create(dCtx, event)
-		cancel()
-		// Use a duplicate event as a tombstone to protect against failure
-		// mid-loop and support fast-forward. Do not attempt to coordinate event
-		// success with publish failures.
-		if errors.Is(err, dao.ErrAlreadyExists) {
-			metric.Incr("duplicate", nil)
-			logger.Infof("eventMessages duplicate ev.evDAO.Create: %v", err)
+	if res {
+		ev.event(ctx, vOut, r, false)
 
+		if !recovery {
 			return
 		}
-		if err != nil {
-			metric.Incr("error", map[string]string{metric.TagFunc: "create"})
-			logger.Errorf("eventMessages ev.evDAO.Create: %v", err)
 
-			return
+		if err := ev.setState(ctx, key,
+			&message.RuleState{Active: true}); err != nil {
+			metric.Incr("error", map[string]string{metric.TagFunc: "setstate"})
+			logger.Errorf("evalStateless ev.setState: %v", err)
 		}
 
-		eOut := &message.EventerOut{
-			Point:  vOut.GetPoint(),
-			Device: vOut.GetDevice(),
-			Rule:   r,
-		}
-		bEOut, err := proto.Marshal(eOut)
-		if err != nil {
-			metric.Incr("error", map[string]string{metric.TagFunc: "marshal"})
-			logger.Errorf("eventMessages proto.Marshal: %v", err)
+		return
+	}
+	if !recovery {
+		return
+	}
 
-			return
-		}
+	state, err := ev.getState(ctx, key)
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "getstate"})
+		logger.Errorf("evalStateless ev.getState: %v", err)
 
-		if err = ev.evQueue.Publish(ev.eOutPubTopic,
-			bEOut); err != nil {
-			metric.Incr("error", map[string]string{metric.TagFunc: "publish"})
-			logger.Errorf("eventMessages ev.evQueue.Publish: %v", err)
+		return
+	}
+	if !state.GetActive() {
+		return
+	}
 
-			return
-		}
+	if err := ev.stateCache.Del(ctx, key); err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "delstate"})
+		logger.Errorf("evalStateless ev.stateCache.Del: %v", err)
 
-		metric.Incr("published", nil)
-		logger.Debugf("eventMessages published: %+v", eOut)
+		return
 	}
+
+	metric.Incr("transition", map[string]string{"type": rule.Exit.String()})
+	ev.event(ctx, vOut, r, true)
+}
+
+// event generates an event and publishes an EventerOut message. A cleared
+// event records that a rule became inactive for a device.
+func (ev *Eventer) event(
+	ctx context.Context, vOut *message.ValidatorOut, r *api.Rule, cleared bool,
+) {
+	logger := alog.FromContext(ctx)
+
+	event := &api.Event{
+		OrgId:     vOut.GetDevice().GetOrgId(),
+		UniqId:    vOut.GetDevice().GetUniqId(),
+		RuleId:    r.GetId(),
+		CreatedAt: vOut.GetPoint().GetTs(),
+		TraceId:   vOut.GetPoint().GetTraceId(),
+	}
+
+	dCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	var err error
+	if cleared {
+		err = ev.evDAO.CreateClear(dCtx, event)
+	} else {
+		err = ev.evDAO.Create(dCtx, event)
+	}
+	cancel()
+	// Use a duplicate event as a tombstone to protect against failure mid-loop
+	// and support fast-forward. Do not attempt to coordinate event success with
+	// publish failures.
+	if errors.Is(err, dao.ErrAlreadyExists) {
+		metric.Incr("duplicate", nil)
+		logger.Infof("eventMessages duplicate ev.evDAO.Create: %v", err)
+
+		return
+	}
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "create"})
+		logger.Errorf("eventMessages ev.evDAO.Create: %v", err)
+
+		return
+	}
+
+	eOut := &message.EventerOut{
+		Point:   vOut.GetPoint(),
+		Device:  vOut.GetDevice(),
+		Rule:    r,
+		Cleared: cleared,
+	}
+	bEOut, err := proto.Marshal(eOut)
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "marshal"})
+		logger.Errorf("eventMessages proto.Marshal: %v", err)
+
+		return
+	}
+
+	if err = ev.evQueue.Publish(ev.eOutPubTopic, bEOut); err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "publish"})
+		logger.Errorf("eventMessages ev.evQueue.Publish: %v", err)
+
+		return
+	}
+
+	metric.Incr("published", map[string]string{
+		"cleared": strconv.FormatBool(cleared),
+	})
+	logger.Debugf("eventMessages published: %+v", eOut)
+}
+
+// getState retrieves the cached state of a rule for a device. A missing state
+// is returned as inactive. Concurrent data points for the same device and rule
+// may race, as data points are not guaranteed to be delivered in order.
+func (ev *Eventer) getState(
+	ctx context.Context, key string,
+) (*message.RuleState, error) {
+	state := &message.RuleState{}
+
+	bState, err := ev.stateCache.Get(ctx, key)
+	if errors.Is(err, cache.ErrNotFound) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := proto.Unmarshal(bState, state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// setState stores the state of a rule for a device, refreshing its
+// expiration.
+func (ev *Eventer) setState(
+	ctx context.Context, key string, state *message.RuleState,
+) error {
+	bState, err := proto.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return ev.stateCache.SetTTL(ctx, key, bState, stateExp)
 }
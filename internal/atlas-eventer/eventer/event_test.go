@@ -4,15 +4,18 @@ package eventer
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 	"uuid"
 
 	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/cache"
 	"github.com/thingspect/atlas/pkg/consterr"
 	"github.com/thingspect/atlas/pkg/dao"
 	"github.com/thingspect/atlas/pkg/decode"
 	"github.com/thingspect/atlas/pkg/decode/radiobridge"
+	"github.com/thingspect/atlas/pkg/latest"
 	"github.com/thingspect/atlas/pkg/queue"
 	"github.com/thingspect/atlas/pkg/rule"
 	"github.com/thingspect/atlas/pkg/test/matcher"
@@ -116,6 +119,17 @@ func TestEventMessages(t *testing.T) {
 				test.inpVOut.GetPoint().GetAttr(),
 				test.inpVOut.GetDevice().GetTags()).
 				Return(test.inpRules, nil).Times(1)
+			ruler.EXPECT().ListConditions(gomock.Any(),
+				test.inpVOut.GetDevice().GetOrgId(), gomock.Len(
+					len(test.inpRules))).Return(nil, nil).
+				Times(min(len(test.inpRules), 1))
+
+			// Rules without a recovery do not track state.
+			alarmer := NewMockalarmer(gomock.NewController(t))
+			alarmer.EXPECT().ListRecoveryRules(gomock.Any(),
+				test.inpVOut.GetDevice().GetOrgId(), gomock.Len(
+					len(test.inpRules))).Return(nil, nil).
+				Times(min(len(test.inpRules), 1))
 
 			// Reuse ruleID for less branching in the mocking paths.
 			event := &api.Event{
@@ -129,8 +143,12 @@ func TestEventMessages(t *testing.T) {
 				Times(test.inpTimes)
 
 			ev := Eventer{
-				ruleDAO: ruler,
-				evDAO:   eventer,
+				ruleDAO:  ruler,
+				alarmDAO: alarmer,
+				evDAO:    eventer,
+
+				stateCache: cache.NewMockCacher[[]byte](
+					gomock.NewController(t)),
 
 				evQueue:      evQueue,
 				vOutSub:      vOutSub,
@@ -184,6 +202,711 @@ func TestEventMessages(t *testing.T) {
 	}
 }
 
+func TestEventMessagesCondition(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("ev", uuid.NewV7().String())
+	r := &api.Rule{
+		Id: uuid.NewV7().String(), OrgId: dev.GetOrgId(), Attr: "ev-temp",
+		Expr: `pointVal > 30`,
+	}
+	cond := &message.RuleCondition{
+		RuleId: r.GetId(), OrgId: dev.GetOrgId(), ForSamples: 2,
+	}
+
+	vOutQueue := queue.NewFake()
+	vOutSub, err := vOutQueue.Subscribe("")
+	require.NoError(t, err)
+
+	evQueue := queue.NewFake()
+	vInSub, err := evQueue.Subscribe("")
+	require.NoError(t, err)
+	eOutPubTopic := "topic-" + random.String(10)
+
+	vals := []float64{40, 40, 40, 20}
+
+	ctrl := gomock.NewController(t)
+	ruler := NewMockruler(ctrl)
+	ruler.EXPECT().ListByTags(gomock.Any(), dev.GetOrgId(), r.GetAttr(),
+		dev.GetTags()).Return([]*api.Rule{r}, nil).Times(len(vals))
+	ruler.EXPECT().ListConditions(gomock.Any(), dev.GetOrgId(),
+		[]string{r.GetId()}).Return(map[string]*message.RuleCondition{
+		r.GetId(): cond,
+	}, nil).Times(len(vals))
+
+	alarmer := NewMockalarmer(ctrl)
+
+	// Only the transitions to active and inactive generate events.
+	eventer := NewMockeventer(ctrl)
+	eventer.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	eventer.EXPECT().CreateClear(gomock.Any(), gomock.Any()).Return(nil).
+		Times(1)
+
+	stateCache := cache.NewHeap[[]byte]()
+
+	ev := Eventer{
+		ruleDAO:  ruler,
+		alarmDAO: alarmer,
+		evDAO:    eventer,
+
+		stateCache: stateCache,
+
+		evQueue:      evQueue,
+		vOutSub:      vOutSub,
+		eOutPubTopic: eOutPubTopic,
+	}
+	go func() {
+		ev.eventMessages()
+	}()
+
+	now := time.Now().Add(-15 * time.Minute)
+	for i, val := range vals {
+		bVOut, err := proto.Marshal(&message.ValidatorOut{
+			Point: &common.DataPoint{
+				UniqId: dev.GetUniqId(), Attr: r.GetAttr(),
+				ValOneof: &common.DataPoint_Fl64Val{Fl64Val: val},
+				Ts:       timestamppb.New(now.Add(time.Duration(i) * time.Minute)),
+				TraceId:  uuid.NewV7().String(),
+			}, Device: dev,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, vOutQueue.Publish("", bVOut))
+	}
+
+	select {
+	case msg := <-vInSub.C():
+		msg.Ack()
+		t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(), msg.Payload())
+
+		eOut := &message.EventerOut{}
+		require.NoError(t, proto.Unmarshal(msg.Payload(), eOut))
+		require.InDelta(t, 40, eOut.GetPoint().GetFl64Val(), 0.01)
+		require.Equal(t, r.GetId(), eOut.GetRule().GetId())
+		require.False(t, eOut.GetCleared())
+	case <-time.After(2 * time.Second):
+		t.Fatal("Message timed out")
+	}
+
+	select {
+	case msg := <-vInSub.C():
+		msg.Ack()
+		t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(), msg.Payload())
+
+		eOut := &message.EventerOut{}
+		require.NoError(t, proto.Unmarshal(msg.Payload(), eOut))
+		require.InDelta(t, 20, eOut.GetPoint().GetFl64Val(), 0.01)
+		require.True(t, eOut.GetCleared())
+	case <-time.After(2 * time.Second):
+		t.Fatal("Message timed out")
+	}
+
+	select {
+	case msg := <-vInSub.C():
+		t.Fatalf("Received unexpected msg.Topic, msg.Payload: %v, %s",
+			msg.Topic(), msg.Payload())
+	case <-time.After(100 * time.Millisecond):
+		// Successful timeout without publish (normally 0.02s).
+	}
+
+	// The final data point resets the state.
+	require.Eventually(t, func() bool {
+		bState, err := stateCache.Get(t.Context(), stateKey(dev.GetOrgId(),
+			dev.GetId(), r.GetId()))
+		if err != nil {
+			return false
+		}
+
+		state := &message.RuleState{}
+
+		return proto.Unmarshal(bState, state) == nil && !state.GetActive() &&
+			state.GetCount() == 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestEventMessagesConditionRedelivery(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("ev", uuid.NewV7().String())
+	r := &api.Rule{
+		Id: uuid.NewV7().String(), OrgId: dev.GetOrgId(), Attr: "ev-temp",
+		Expr: `pointVal > 30`,
+	}
+	cond := &message.RuleCondition{
+		RuleId: r.GetId(), OrgId: dev.GetOrgId(), ForSamples: 2,
+	}
+
+	vOutQueue := queue.NewFake()
+	vOutSub, err := vOutQueue.Subscribe("")
+	require.NoError(t, err)
+
+	evQueue := queue.NewFake()
+	vInSub, err := evQueue.Subscribe("")
+	require.NoError(t, err)
+
+	// The first data point is delivered twice, as on requeue.
+	now := time.Now().Add(-15 * time.Minute)
+	traceID := uuid.NewV7().String()
+	traceIDs := []string{traceID, traceID, uuid.NewV7().String()}
+
+	ctrl := gomock.NewController(t)
+	ruler := NewMockruler(ctrl)
+	ruler.EXPECT().ListByTags(gomock.Any(), dev.GetOrgId(), r.GetAttr(),
+		dev.GetTags()).Return([]*api.Rule{r}, nil).Times(len(traceIDs))
+	ruler.EXPECT().ListConditions(gomock.Any(), dev.GetOrgId(),
+		[]string{r.GetId()}).Return(map[string]*message.RuleCondition{
+		r.GetId(): cond,
+	}, nil).Times(len(traceIDs))
+
+	alarmer := NewMockalarmer(ctrl)
+
+	// Only the second distinct data point transitions to active.
+	eventer := NewMockeventer(ctrl)
+	eventer.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	ev := Eventer{
+		ruleDAO:  ruler,
+		alarmDAO: alarmer,
+		evDAO:    eventer,
+
+		stateCache: cache.NewHeap[[]byte](),
+
+		evQueue:      evQueue,
+		vOutSub:      vOutSub,
+		eOutPubTopic: "topic-" + random.String(10),
+	}
+	go func() {
+		ev.eventMessages()
+	}()
+
+	for i, traceID := range traceIDs {
+		bVOut, err := proto.Marshal(&message.ValidatorOut{
+			Point: &common.DataPoint{
+				UniqId: dev.GetUniqId(), Attr: r.GetAttr(),
+				ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 40},
+				Ts:       timestamppb.New(now.Add(time.Duration(i) * time.Minute)),
+				TraceId:  traceID,
+			}, Device: dev,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, vOutQueue.Publish("", bVOut))
+	}
+
+	select {
+	case msg := <-vInSub.C():
+		msg.Ack()
+		t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(), msg.Payload())
+
+		eOut := &message.EventerOut{}
+		require.NoError(t, proto.Unmarshal(msg.Payload(), eOut))
+		require.Equal(t, traceIDs[2], eOut.GetPoint().GetTraceId())
+		require.False(t, eOut.GetCleared())
+	case <-time.After(2 * time.Second):
+		t.Fatal("Message timed out")
+	}
+
+	select {
+	case msg := <-vInSub.C():
+		t.Fatalf("Received unexpected msg.Topic, msg.Payload: %v, %s",
+			msg.Topic(), msg.Payload())
+	case <-time.After(100 * time.Millisecond):
+		// Successful timeout without publish (normally 0.02s).
+	}
+}
+
+func TestEventMessagesClear(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("ev", uuid.NewV7().String())
+	r := &api.Rule{
+		Id: uuid.NewV7().String(), OrgId: dev.GetOrgId(), Attr: "ev-temp",
+		Expr: `pointVal > 30`,
+	}
+
+	vOutQueue := queue.NewFake()
+	vOutSub, err := vOutQueue.Subscribe("")
+	require.NoError(t, err)
+
+	evQueue := queue.NewFake()
+	vInSub, err := evQueue.Subscribe("")
+	require.NoError(t, err)
+
+	vals := []float64{40, 40, 20, 20}
+
+	ctrl := gomock.NewController(t)
+	ruler := NewMockruler(ctrl)
+	ruler.EXPECT().ListByTags(gomock.Any(), dev.GetOrgId(), r.GetAttr(),
+		dev.GetTags()).Return([]*api.Rule{r}, nil).Times(len(vals))
+	ruler.EXPECT().ListConditions(gomock.Any(), dev.GetOrgId(),
+		[]string{r.GetId()}).Return(nil, nil).Times(len(vals))
+
+	alarmer := NewMockalarmer(ctrl)
+	alarmer.EXPECT().ListRecoveryRules(gomock.Any(), dev.GetOrgId(),
+		[]string{r.GetId()}).Return(map[string]bool{r.GetId(): true}, nil).
+		Times(len(vals))
+
+	// Rules without a condition generate an event for each match, and a single
+	// clear event.
+	eventer := NewMockeventer(ctrl)
+	eventer.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	eventer.EXPECT().CreateClear(gomock.Any(), gomock.Any()).Return(nil).
+		Times(1)
+
+	ev := Eventer{
+		ruleDAO:  ruler,
+		alarmDAO: alarmer,
+		evDAO:    eventer,
+
+		stateCache: cache.NewHeap[[]byte](),
+
+		evQueue:      evQueue,
+		vOutSub:      vOutSub,
+		eOutPubTopic: "topic-" + random.String(10),
+	}
+	go func() {
+		ev.eventMessages()
+	}()
+
+	now := time.Now().Add(-15 * time.Minute)
+	for i, val := range vals {
+		bVOut, err := proto.Marshal(&message.ValidatorOut{
+			Point: &common.DataPoint{
+				UniqId: dev.GetUniqId(), Attr: r.GetAttr(),
+				ValOneof: &common.DataPoint_Fl64Val{Fl64Val: val},
+				Ts:       timestamppb.New(now.Add(time.Duration(i) * time.Minute)),
+				TraceId:  uuid.NewV7().String(),
+			}, Device: dev,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, vOutQueue.Publish("", bVOut))
+	}
+
+	for _, resCleared := range []bool{false, false, true} {
+		select {
+		case msg := <-vInSub.C():
+			msg.Ack()
+			t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(),
+				msg.Payload())
+
+			eOut := &message.EventerOut{}
+			require.NoError(t, proto.Unmarshal(msg.Payload(), eOut))
+			require.Equal(t, resCleared, eOut.GetCleared())
+		case <-time.After(2 * time.Second):
+			t.Fatal("Message timed out")
+		}
+	}
+
+	select {
+	case msg := <-vInSub.C():
+		t.Fatalf("Received unexpected msg.Topic, msg.Payload: %v, %s",
+			msg.Topic(), msg.Payload())
+	case <-time.After(100 * time.Millisecond):
+		// Successful timeout without publish (normally 0.02s).
+	}
+}
+
+func TestEventMessagesLatest(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("ev", uuid.NewV7().String())
+	r := &api.Rule{
+		Id: uuid.NewV7().String(), OrgId: dev.GetOrgId(), Attr: "ev-temp",
+		Expr: `pointVal > 30 && latest["ev-door"]?.val == true`,
+	}
+
+	vOutQueue := queue.NewFake()
+	vOutSub, err := vOutQueue.Subscribe("")
+	require.NoError(t, err)
+
+	evQueue := queue.NewFake()
+	vInSub, err := evQueue.Subscribe("")
+	require.NoError(t, err)
+
+	stateCache := cache.NewHeap[[]byte]()
+	require.NoError(t, latest.Set(t.Context(), stateCache, dev.GetOrgId(),
+		dev.GetId(), &common.DataPoint{
+			Attr: "ev-door", ValOneof: &common.DataPoint_BoolVal{
+				BoolVal: true,
+			}, Ts: timestamppb.Now(),
+		}))
+
+	ctrl := gomock.NewController(t)
+	ruler := NewMockruler(ctrl)
+	ruler.EXPECT().ListByTags(gomock.Any(), dev.GetOrgId(), r.GetAttr(),
+		dev.GetTags()).Return([]*api.Rule{r}, nil).Times(1)
+	ruler.EXPECT().ListConditions(gomock.Any(), dev.GetOrgId(),
+		[]string{r.GetId()}).Return(nil, nil).Times(1)
+
+	alarmer := NewMockalarmer(ctrl)
+	alarmer.EXPECT().ListRecoveryRules(gomock.Any(), dev.GetOrgId(),
+		[]string{r.GetId()}).Return(nil, nil).Times(1)
+
+	eventer := NewMockeventer(ctrl)
+	eventer.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	ev := Eventer{
+		ruleDAO:  ruler,
+		alarmDAO: alarmer,
+		evDAO:    eventer,
+
+		stateCache: stateCache,
+
+		evQueue:      evQueue,
+		vOutSub:      vOutSub,
+		eOutPubTopic: "topic-" + random.String(10),
+	}
+	go func() {
+		ev.eventMessages()
+	}()
+
+	bVOut, err := proto.Marshal(&message.ValidatorOut{
+		Point: &common.DataPoint{
+			UniqId: dev.GetUniqId(), Attr: r.GetAttr(),
+			ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 40},
+			Ts:       timestamppb.Now(), TraceId: uuid.NewV7().String(),
+		}, Device: dev,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, vOutQueue.Publish("", bVOut))
+
+	select {
+	case msg := <-vInSub.C():
+		msg.Ack()
+		t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(), msg.Payload())
+
+		eOut := &message.EventerOut{}
+		require.NoError(t, proto.Unmarshal(msg.Payload(), eOut))
+		require.InDelta(t, 40, eOut.GetPoint().GetFl64Val(), 0.01)
+		require.Equal(t, r.GetId(), eOut.GetRule().GetId())
+	case <-time.After(2 * time.Second):
+		t.Fatal("Message timed out")
+	}
+}
+
+func TestEventMessagesHistory(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("ev", uuid.NewV7().String())
+	r := &api.Rule{
+		Id: uuid.NewV7().String(), OrgId: dev.GetOrgId(), Attr: "ev-temp",
+		Expr: `delta() > 15`,
+	}
+
+	vOutQueue := queue.NewFake()
+	vOutSub, err := vOutQueue.Subscribe("")
+	require.NoError(t, err)
+
+	evQueue := queue.NewFake()
+	vInSub, err := evQueue.Subscribe("")
+	require.NoError(t, err)
+
+	vals := []float64{10, 20, 40}
+
+	ctrl := gomock.NewController(t)
+	ruler := NewMockruler(ctrl)
+	ruler.EXPECT().ListByTags(gomock.Any(), dev.GetOrgId(), r.GetAttr(),
+		dev.GetTags()).Return([]*api.Rule{r}, nil).Times(len(vals))
+	ruler.EXPECT().ListConditions(gomock.Any(), dev.GetOrgId(),
+		[]string{r.GetId()}).Return(nil, nil).Times(len(vals))
+
+	alarmer := NewMockalarmer(ctrl)
+	alarmer.EXPECT().ListRecoveryRules(gomock.Any(), dev.GetOrgId(),
+		[]string{r.GetId()}).Return(nil, nil).Times(len(vals))
+
+	// Only the final data point changes by more than the threshold.
+	eventer := NewMockeventer(ctrl)
+	eventer.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	stateCache := cache.NewHeap[[]byte]()
+
+	ev := Eventer{
+		ruleDAO:  ruler,
+		alarmDAO: alarmer,
+		evDAO:    eventer,
+
+		stateCache: stateCache,
+
+		evQueue:      evQueue,
+		vOutSub:      vOutSub,
+		eOutPubTopic: "topic-" + random.String(10),
+	}
+	go func() {
+		ev.eventMessages()
+	}()
+
+	now := time.Now().Add(-15 * time.Minute)
+	for i, val := range vals {
+		bVOut, err := proto.Marshal(&message.ValidatorOut{
+			Point: &common.DataPoint{
+				UniqId: dev.GetUniqId(), Attr: r.GetAttr(),
+				ValOneof: &common.DataPoint_Fl64Val{Fl64Val: val},
+				Ts:       timestamppb.New(now.Add(time.Duration(i) * time.Minute)),
+				TraceId:  uuid.NewV7().String(),
+			}, Device: dev,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, vOutQueue.Publish("", bVOut))
+	}
+
+	select {
+	case msg := <-vInSub.C():
+		msg.Ack()
+		t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(), msg.Payload())
+
+		eOut := &message.EventerOut{}
+		require.NoError(t, proto.Unmarshal(msg.Payload(), eOut))
+		require.InDelta(t, 40, eOut.GetPoint().GetFl64Val(), 0.01)
+		require.Equal(t, r.GetId(), eOut.GetRule().GetId())
+	case <-time.After(2 * time.Second):
+		t.Fatal("Message timed out")
+	}
+
+	select {
+	case msg := <-vInSub.C():
+		t.Fatalf("Received unexpected msg.Topic, msg.Payload: %v, %s",
+			msg.Topic(), msg.Payload())
+	case <-time.After(100 * time.Millisecond):
+		// Successful timeout without publish (normally 0.02s).
+	}
+
+	require.Eventually(t, func() bool {
+		bHist, err := stateCache.Get(t.Context(), historyKey(dev.GetOrgId(),
+			dev.GetId(), r.GetAttr()))
+		if err != nil {
+			return false
+		}
+
+		hist := &message.DataPointHistory{}
+
+		return proto.Unmarshal(bHist, hist) == nil &&
+			len(hist.GetPoints()) == len(vals)
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestEventMessagesConditionError(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("ev", uuid.NewV7().String())
+	r := &api.Rule{Id: uuid.NewV7().String(), Expr: rule.ExprTrue}
+
+	vOutQueue := queue.NewFake()
+	vOutSub, err := vOutQueue.Subscribe("")
+	require.NoError(t, err)
+
+	evQueue := queue.NewFake()
+	vInSub, err := evQueue.Subscribe("")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	ctrl := gomock.NewController(t)
+	ruler := NewMockruler(ctrl)
+	ruler.EXPECT().ListByTags(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).Return([]*api.Rule{r}, nil).Times(1)
+	ruler.EXPECT().ListConditions(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(map[string]*message.RuleCondition{
+			r.GetId(): {RuleId: r.GetId(), ForSamples: 1},
+		}, nil).Times(1)
+
+	alarmer := NewMockalarmer(ctrl)
+
+	cacher := cache.NewMockCacher[[]byte](ctrl)
+	cacher.EXPECT().Get(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, _ any) ([]byte, error) {
+			defer wg.Done()
+
+			return nil, errTestProc
+		}).Times(1)
+
+	ev := Eventer{
+		ruleDAO:  ruler,
+		alarmDAO: alarmer,
+		evDAO:    NewMockeventer(ctrl),
+
+		stateCache: cacher,
+
+		evQueue:      evQueue,
+		vOutSub:      vOutSub,
+		eOutPubTopic: "topic-" + random.String(10),
+	}
+	go func() {
+		ev.eventMessages()
+	}()
+
+	bVOut, err := proto.Marshal(&message.ValidatorOut{
+		Point: &common.DataPoint{Ts: timestamppb.Now()}, Device: dev,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, vOutQueue.Publish("", bVOut))
+	wg.Wait()
+
+	select {
+	case msg := <-vInSub.C():
+		t.Fatalf("Received unexpected msg.Topic, msg.Payload: %v, %s",
+			msg.Topic(), msg.Payload())
+	case <-time.After(100 * time.Millisecond):
+		// Successful timeout without publish (normally 0.02s).
+	}
+}
+
+func TestEventMessagesStatelessError(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("ev", uuid.NewV7().String())
+	r := &api.Rule{Id: uuid.NewV7().String(), Expr: rule.ExprTrue}
+
+	vOutQueue := queue.NewFake()
+	vOutSub, err := vOutQueue.Subscribe("")
+	require.NoError(t, err)
+
+	evQueue := queue.NewFake()
+	vInSub, err := evQueue.Subscribe("")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	ctrl := gomock.NewController(t)
+	ruler := NewMockruler(ctrl)
+	ruler.EXPECT().ListByTags(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).Return([]*api.Rule{r}, nil).Times(1)
+	ruler.EXPECT().ListConditions(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).Times(1)
+
+	alarmer := NewMockalarmer(ctrl)
+	alarmer.EXPECT().ListRecoveryRules(gomock.Any(), gomock.Any(),
+		gomock.Any()).Return(map[string]bool{r.GetId(): true}, nil).Times(1)
+
+	eventer := NewMockeventer(ctrl)
+	eventer.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	cacher := cache.NewMockCacher[[]byte](ctrl)
+	cacher.EXPECT().SetTTL(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).DoAndReturn(func(_, _, _, _ any) error {
+		defer wg.Done()
+
+		return errTestProc
+	}).Times(1)
+
+	eOutPubTopic := "topic-" + random.String(10)
+
+	ev := Eventer{
+		ruleDAO:  ruler,
+		alarmDAO: alarmer,
+		evDAO:    eventer,
+
+		stateCache: cacher,
+
+		evQueue:      evQueue,
+		vOutSub:      vOutSub,
+		eOutPubTopic: eOutPubTopic,
+	}
+	go func() {
+		ev.eventMessages()
+	}()
+
+	bVOut, err := proto.Marshal(&message.ValidatorOut{
+		Point: &common.DataPoint{Ts: timestamppb.Now()}, Device: dev,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, vOutQueue.Publish("", bVOut))
+
+	select {
+	case msg := <-vInSub.C():
+		msg.Ack()
+		t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(), msg.Payload())
+		require.Equal(t, eOutPubTopic, msg.Topic())
+
+		eOut := &message.EventerOut{}
+		require.NoError(t, proto.Unmarshal(msg.Payload(), eOut))
+		t.Logf("eOut: %+v", eOut)
+
+		require.Equal(t, r.GetId(), eOut.GetRule().GetId())
+		require.False(t, eOut.GetCleared())
+	case <-time.After(2 * time.Second):
+		t.Fatal("Message timed out")
+	}
+
+	wg.Wait()
+}
+
+func TestEventMessagesLatestError(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("ev", uuid.NewV7().String())
+	r := &api.Rule{
+		Id: uuid.NewV7().String(), Expr: `latest["ev-door"]?.val == true`,
+	}
+
+	vOutQueue := queue.NewFake()
+	vOutSub, err := vOutQueue.Subscribe("")
+	require.NoError(t, err)
+
+	evQueue := queue.NewFake()
+	vInSub, err := evQueue.Subscribe("")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	ctrl := gomock.NewController(t)
+	ruler := NewMockruler(ctrl)
+	ruler.EXPECT().ListByTags(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).Return([]*api.Rule{r}, nil).Times(1)
+	ruler.EXPECT().ListConditions(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).Times(1)
+
+	alarmer := NewMockalarmer(ctrl)
+	alarmer.EXPECT().ListRecoveryRules(gomock.Any(), gomock.Any(),
+		gomock.Any()).Return(nil, nil).Times(1)
+
+	cacher := cache.NewMockCacher[[]byte](ctrl)
+	cacher.EXPECT().Get(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, _ any) ([]byte, error) {
+			defer wg.Done()
+
+			return nil, errTestProc
+		}).Times(1)
+
+	ev := Eventer{
+		ruleDAO:  ruler,
+		alarmDAO: alarmer,
+		evDAO:    NewMockeventer(ctrl),
+
+		stateCache: cacher,
+
+		evQueue:      evQueue,
+		vOutSub:      vOutSub,
+		eOutPubTopic: "topic-" + random.String(10),
+	}
+	go func() {
+		ev.eventMessages()
+	}()
+
+	bVOut, err := proto.Marshal(&message.ValidatorOut{
+		Point: &common.DataPoint{Ts: timestamppb.Now()}, Device: dev,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, vOutQueue.Publish("", bVOut))
+	wg.Wait()
+
+	select {
+	case msg := <-vInSub.C():
+		t.Fatalf("Received unexpected msg.Topic, msg.Payload: %v, %s",
+			msg.Topic(), msg.Payload())
+	case <-time.After(100 * time.Millisecond):
+		// Successful timeout without publish (normally 0.02s).
+	}
+}
+
 func TestEventMessagesError(t *testing.T) {
 	t.Parallel()
 
@@ -194,23 +917,44 @@ func TestEventMessagesError(t *testing.T) {
 		inpRulerErr     error
 		inpRulerTimes   int
 		inpRules        []*api.Rule
+		inpCondErr      error
+		inpRecErr       error
+		inpRecTimes     int
 		inpEventerErr   error
 		inpEventerTimes int
 	}{
 		// Bad payload.
-		{nil, nil, 0, nil, nil, 0},
+		{nil, nil, 0, nil, nil, nil, 0, nil, 0},
 		// Missing data point.
-		{&message.ValidatorOut{}, nil, 0, nil, nil, 0},
+		{&message.ValidatorOut{}, nil, 0, nil, nil, nil, 0, nil, 0},
 		// Missing device.
 		{
 			&message.ValidatorOut{Point: &common.DataPoint{}}, nil, 0, nil, nil,
-			0,
+			nil, 0, nil, 0,
 		},
 		// Ruler error.
 		{&message.ValidatorOut{
 			Point:  &common.DataPoint{},
 			Device: &api.Device{},
-		}, errTestProc, 1, nil, nil, 0},
+		}, errTestProc, 1, nil, nil, nil, 0, nil, 0},
+		// Condition error.
+		{
+			&message.ValidatorOut{
+				Point:  &common.DataPoint{Ts: now},
+				Device: &api.Device{},
+			}, nil, 1,
+			[]*api.Rule{{Expr: rule.ExprTrue}},
+			errTestProc, nil, 0, nil, 0,
+		},
+		// Recovery rules error.
+		{
+			&message.ValidatorOut{
+				Point:  &common.DataPoint{Ts: now},
+				Device: &api.Device{},
+			}, nil, 1,
+			[]*api.Rule{{Expr: rule.ExprTrue}},
+			nil, errTestProc, 1, nil, 0,
+		},
 		// Eval error.
 		{
 			&message.ValidatorOut{
@@ -218,7 +962,7 @@ func TestEventMessagesError(t *testing.T) {
 				Device: &api.Device{},
 			}, nil, 1,
 			[]*api.Rule{{Expr: `1 + "aaa"`}},
-			nil, 0,
+			nil, nil, 1, nil, 0,
 		},
 		// Eventer already exists.
 		{
@@ -227,7 +971,7 @@ func TestEventMessagesError(t *testing.T) {
 				Device: &api.Device{},
 			}, nil, 1,
 			[]*api.Rule{{Expr: rule.ExprTrue}},
-			dao.ErrAlreadyExists, 1,
+			nil, nil, 1, dao.ErrAlreadyExists, 1,
 		},
 		// Eventer error.
 		{
@@ -236,7 +980,7 @@ func TestEventMessagesError(t *testing.T) {
 				Device: &api.Device{},
 			}, nil, 1,
 			[]*api.Rule{{Expr: rule.ExprTrue}},
-			errTestProc, 1,
+			nil, nil, 1, errTestProc, 1,
 		},
 	}
 
@@ -257,14 +1001,25 @@ func TestEventMessagesError(t *testing.T) {
 			ruler.EXPECT().ListByTags(gomock.Any(), gomock.Any(), gomock.Any(),
 				gomock.Any()).Return(test.inpRules, test.inpRulerErr).
 				Times(test.inpRulerTimes)
+			ruler.EXPECT().ListConditions(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil, test.inpCondErr).
+				Times(min(len(test.inpRules), 1))
+
+			alarmer := NewMockalarmer(gomock.NewController(t))
+			alarmer.EXPECT().ListRecoveryRules(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(nil, test.inpRecErr).
+				Times(test.inpRecTimes)
 
 			eventer := NewMockeventer(gomock.NewController(t))
 			eventer.EXPECT().Create(gomock.Any(), gomock.Any()).
 				Return(test.inpEventerErr).Times(test.inpEventerTimes)
 
 			ev := Eventer{
-				ruleDAO: ruler,
-				evDAO:   eventer,
+				ruleDAO:  ruler,
+				alarmDAO: alarmer,
+				evDAO:    eventer,
+
+				stateCache: cache.NewHeap[[]byte](),
 
 				evQueue:      evQueue,
 				vOutSub:      vOutSub,
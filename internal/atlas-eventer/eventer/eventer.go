@@ -11,10 +11,13 @@ import (
 
 	"github.com/thingspect/atlas/internal/atlas-eventer/config"
 	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/cache"
 	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/alarm"
 	"github.com/thingspect/atlas/pkg/dao/event"
 	"github.com/thingspect/atlas/pkg/dao/rule"
 	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/proto/go/message"
 	"github.com/thingspect/proto/go/api"
 )
 
@@ -25,17 +28,29 @@ const ServiceName = "eventer"
 type ruler interface {
 	ListByTags(ctx context.Context, orgID string, attr string,
 		deviceTags []string) ([]*api.Rule, error)
+	ListConditions(ctx context.Context, orgID string, ruleIDs []string) (
+		map[string]*message.RuleCondition, error)
+}
+
+// alarmer defines the methods provided by an alarm.DAO.
+type alarmer interface {
+	ListRecoveryRules(ctx context.Context, orgID string, ruleIDs []string) (
+		map[string]bool, error)
 }
 
 // eventer defines the methods provided by an event.DAO.
 type eventer interface {
 	Create(ctx context.Context, event *api.Event) error
+	CreateClear(ctx context.Context, event *api.Event) error
 }
 
 // Eventer holds references to the database and message broker connections.
 type Eventer struct {
-	ruleDAO ruler
-	evDAO   eventer
+	ruleDAO  ruler
+	alarmDAO alarmer
+	evDAO    eventer
+
+	stateCache cache.Cacher[[]byte]
 
 	evQueue      queue.Queuer
 	vOutSub      queue.Subber
@@ -55,6 +70,12 @@ func New(cfg *config.Config) (*Eventer, error) {
 		return nil, err
 	}
 
+	// Set up cache connection.
+	redis, err := cache.NewRedis[[]byte](cfg.RedisHost + ":6379")
+	if err != nil {
+		return nil, err
+	}
+
 	// Build the NSQ connection for consuming and publishing.
 	nsq, err := queue.NewNSQ(cfg.NSQPubAddr, cfg.NSQLookupAddrs,
 		cfg.NSQSubChannel)
@@ -74,8 +95,11 @@ func New(cfg *config.Config) (*Eventer, error) {
 	}
 
 	return &Eventer{
-		ruleDAO: rule.NewDAO(pgRW, pgRO),
-		evDAO:   event.NewDAO(pgRW, pgRO),
+		ruleDAO:  rule.NewDAO(pgRW, pgRO),
+		alarmDAO: alarm.NewDAO(pgRW, pgRO),
+		evDAO:    event.NewDAO(pgRW, pgRO),
+
+		stateCache: redis,
 
 		evQueue:      nsq,
 		vOutSub:      vOutSub,
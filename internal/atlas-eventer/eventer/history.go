@@ -0,0 +1,79 @@
+package eventer
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/rule"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// getHistory retrieves the cached history of a data point's device and
+// attribute, in ascending timestamp order. If none is cached, an empty history
+// is returned.
+func (ev *Eventer) getHistory(ctx context.Context, vOut *message.ValidatorOut) (
+	[]*common.DataPoint, error,
+) {
+	bHist, err := ev.stateCache.Get(ctx, historyKey(
+		vOut.GetDevice().GetOrgId(), vOut.GetDevice().GetId(),
+		vOut.GetPoint().GetAttr()))
+	if errors.Is(err, cache.ErrNotFound) {
+		return []*common.DataPoint{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hist := &message.DataPointHistory{}
+	if err := proto.Unmarshal(bHist, hist); err != nil {
+		return nil, err
+	}
+
+	return hist.GetPoints(), nil
+}
+
+// setHistory adds a data point to the history of its device and attribute and
+// caches it. Only the timestamp and value of the data point are retained, as
+// used by history functions. Data points older than rule.MaxWindow, relative
+// to the newest, are pruned, as are the oldest data points beyond
+// rule.MaxHistory.
+// Concurrent calls for the same device and attribute may race, as data points
+// are not guaranteed to be delivered in order.
+func (ev *Eventer) setHistory(
+	ctx context.Context, vOut *message.ValidatorOut,
+	points []*common.DataPoint,
+) error {
+	point := vOut.GetPoint()
+	ts := point.GetTs().AsTime()
+
+	// Insert in timestamp order, skipping redelivered data points.
+	idx, found := slices.BinarySearchFunc(points, ts,
+		func(p *common.DataPoint, t time.Time) int {
+			return p.GetTs().AsTime().Compare(t)
+		})
+	if !found {
+		points = slices.Insert(points, idx, &common.DataPoint{
+			ValOneof: point.GetValOneof(), Ts: point.GetTs(),
+		})
+	}
+
+	start := points[len(points)-1].GetTs().AsTime().Add(-rule.MaxWindow)
+	idx, _ = slices.BinarySearchFunc(points, start,
+		func(p *common.DataPoint, t time.Time) int {
+			return p.GetTs().AsTime().Compare(t)
+		})
+	points = points[max(idx, len(points)-rule.MaxHistory):]
+
+	bHist, err := proto.Marshal(&message.DataPointHistory{Points: points})
+	if err != nil {
+		return err
+	}
+
+	return ev.stateCache.SetTTL(ctx, historyKey(vOut.GetDevice().GetOrgId(),
+		vOut.GetDevice().GetId(), point.GetAttr()), bHist, rule.MaxWindow)
+}
@@ -0,0 +1,129 @@
+//go:build !integration
+
+package eventer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/rule"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/common"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetSetHistory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	point := func(ago time.Duration) *common.DataPoint {
+		return &common.DataPoint{
+			UniqId: "ev-" + random.String(16), Attr: "ev-temp",
+			ValOneof: &common.DataPoint_IntVal{
+				IntVal: int32(ago / time.Minute),
+			}, Ts: timestamppb.New(now.Add(-ago)),
+			TraceId: uuid.NewV7().String(),
+		}
+	}
+
+	many := make([]time.Duration, 0, rule.MaxHistory+5)
+	for i := range rule.MaxHistory + 5 {
+		many = append(many, time.Duration(rule.MaxHistory+5-i)*time.Second)
+	}
+
+	tests := []struct {
+		inp    []time.Duration
+		resLen int
+		resTS  time.Duration
+	}{
+		{[]time.Duration{0}, 1, 0},
+		{
+			[]time.Duration{5 * time.Minute, 0, 2 * time.Minute}, 3,
+			5 * time.Minute,
+		},
+		{[]time.Duration{time.Minute, time.Minute}, 1, time.Minute},
+		{
+			[]time.Duration{rule.MaxWindow + time.Hour, time.Minute}, 1,
+			time.Minute,
+		},
+		{many, rule.MaxHistory, rule.MaxHistory * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can get and set %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			ev := &Eventer{stateCache: cache.NewHeap[[]byte]()}
+			vOut := &message.ValidatorOut{
+				Device: random.Device("ev", uuid.NewV7().String()),
+			}
+
+			for _, ago := range test.inp {
+				hist, err := ev.getHistory(t.Context(), vOut)
+				require.NoError(t, err)
+
+				vOut.Point = point(ago)
+				require.NoError(t, ev.setHistory(t.Context(), vOut, hist))
+			}
+
+			hist, err := ev.getHistory(t.Context(), vOut)
+			t.Logf("hist, err: %+v, %v", hist, err)
+			require.NoError(t, err)
+			require.Len(t, hist, test.resLen)
+			require.WithinDuration(t, now.Add(-test.resTS),
+				hist[0].GetTs().AsTime(), time.Microsecond)
+
+			for i := 1; i < len(hist); i++ {
+				require.True(t, hist[i-1].GetTs().AsTime().Before(
+					hist[i].GetTs().AsTime()))
+			}
+
+			// Only timestamps and values are retained.
+			for _, hPoint := range hist {
+				require.Empty(t, hPoint.GetUniqId())
+				require.Empty(t, hPoint.GetAttr())
+				require.Empty(t, hPoint.GetTraceId())
+				require.NotNil(t, hPoint.GetValOneof())
+			}
+		})
+	}
+}
+
+func TestGetHistoryError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get history with cache error", func(t *testing.T) {
+		t.Parallel()
+
+		cacher := cache.NewMockCacher[[]byte](gomock.NewController(t))
+		cacher.EXPECT().Get(gomock.Any(), gomock.Any()).
+			Return(nil, errTestProc).Times(1)
+
+		ev := &Eventer{stateCache: cacher}
+		hist, err := ev.getHistory(t.Context(), &message.ValidatorOut{})
+		t.Logf("hist, err: %+v, %v", hist, err)
+		require.Nil(t, hist)
+		require.Equal(t, errTestProc, err)
+	})
+
+	t.Run("Get history with bad payload", func(t *testing.T) {
+		t.Parallel()
+
+		cacher := cache.NewMockCacher[[]byte](gomock.NewController(t))
+		cacher.EXPECT().Get(gomock.Any(), gomock.Any()).
+			Return([]byte("ev-aaa"), nil).Times(1)
+
+		ev := &Eventer{stateCache: cacher}
+		hist, err := ev.getHistory(t.Context(), &message.ValidatorOut{})
+		t.Logf("hist, err: %+v, %v", hist, err)
+		require.Nil(t, hist)
+		require.Error(t, err)
+	})
+}
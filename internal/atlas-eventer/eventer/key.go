@@ -0,0 +1,15 @@
+package eventer
+
+import "fmt"
+
+// stateKey returns a cache key to support stateful rule conditions.
+func stateKey(orgID, devID, ruleID string) string {
+	return fmt.Sprintf("eventer:state:org:%s:dev:%s:rule:%s", orgID, devID,
+		ruleID)
+}
+
+// historyKey returns a cache key to support rule history functions.
+func historyKey(orgID, devID, attr string) string {
+	return fmt.Sprintf("eventer:history:org:%s:dev:%s:attr:%s", orgID, devID,
+		attr)
+}
@@ -0,0 +1,55 @@
+//go:build !integration
+
+package eventer
+
+import (
+	"fmt"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/random"
+)
+
+func TestStateKey(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can key %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			orgID := uuid.NewV7().String()
+			devID := uuid.NewV7().String()
+			ruleID := uuid.NewV7().String()
+
+			key := stateKey(orgID, devID, ruleID)
+			t.Logf("key: %v", key)
+
+			require.Equal(t, fmt.Sprintf("eventer:state:org:%s:dev:%s:rule:%s",
+				orgID, devID, ruleID), key)
+			require.Equal(t, key, stateKey(orgID, devID, ruleID))
+		})
+	}
+}
+
+func TestHistoryKey(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can key %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			orgID := uuid.NewV7().String()
+			devID := uuid.NewV7().String()
+			attr := random.String(10)
+
+			key := historyKey(orgID, devID, attr)
+			t.Logf("key: %v", key)
+
+			require.Equal(t, fmt.Sprintf(
+				"eventer:history:org:%s:dev:%s:attr:%s", orgID, devID, attr),
+				key)
+			require.Equal(t, key, historyKey(orgID, devID, attr))
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	message "github.com/thingspect/atlas/proto/go/message"
 	api "github.com/thingspect/proto/go/api"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -56,6 +57,60 @@ func (mr *MockrulerMockRecorder) ListByTags(ctx, orgID, attr, deviceTags any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTags", reflect.TypeOf((*Mockruler)(nil).ListByTags), ctx, orgID, attr, deviceTags)
 }
 
+// ListConditions mocks base method.
+func (m *Mockruler) ListConditions(ctx context.Context, orgID string, ruleIDs []string) (map[string]*message.RuleCondition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConditions", ctx, orgID, ruleIDs)
+	ret0, _ := ret[0].(map[string]*message.RuleCondition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConditions indicates an expected call of ListConditions.
+func (mr *MockrulerMockRecorder) ListConditions(ctx, orgID, ruleIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConditions", reflect.TypeOf((*Mockruler)(nil).ListConditions), ctx, orgID, ruleIDs)
+}
+
+// Mockalarmer is a mock of alarmer interface.
+type Mockalarmer struct {
+	ctrl     *gomock.Controller
+	recorder *MockalarmerMockRecorder
+	isgomock struct{}
+}
+
+// MockalarmerMockRecorder is the mock recorder for Mockalarmer.
+type MockalarmerMockRecorder struct {
+	mock *Mockalarmer
+}
+
+// NewMockalarmer creates a new mock instance.
+func NewMockalarmer(ctrl *gomock.Controller) *Mockalarmer {
+	mock := &Mockalarmer{ctrl: ctrl}
+	mock.recorder = &MockalarmerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockalarmer) EXPECT() *MockalarmerMockRecorder {
+	return m.recorder
+}
+
+// ListRecoveryRules mocks base method.
+func (m *Mockalarmer) ListRecoveryRules(ctx context.Context, orgID string, ruleIDs []string) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecoveryRules", ctx, orgID, ruleIDs)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecoveryRules indicates an expected call of ListRecoveryRules.
+func (mr *MockalarmerMockRecorder) ListRecoveryRules(ctx, orgID, ruleIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecoveryRules", reflect.TypeOf((*Mockalarmer)(nil).ListRecoveryRules), ctx, orgID, ruleIDs)
+}
+
 // Mockeventer is a mock of eventer interface.
 type Mockeventer struct {
 	ctrl     *gomock.Controller
@@ -93,3 +148,17 @@ func (mr *MockeventerMockRecorder) Create(ctx, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockeventer)(nil).Create), ctx, event)
 }
+
+// CreateClear mocks base method.
+func (m *Mockeventer) CreateClear(ctx context.Context, event *api.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClear", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateClear indicates an expected call of CreateClear.
+func (mr *MockeventerMockRecorder) CreateClear(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClear", reflect.TypeOf((*Mockeventer)(nil).CreateClear), ctx, event)
+}
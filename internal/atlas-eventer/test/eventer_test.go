@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/latest"
 	"github.com/thingspect/atlas/pkg/rule"
 	"github.com/thingspect/atlas/pkg/test/random"
 	"github.com/thingspect/atlas/proto/go/message"
@@ -151,7 +152,7 @@ func TestEventMessages(t *testing.T) {
 				listEvents, err := globalEvDAO.List(ctx, createOrg.GetId(),
 					test.inp.GetDevice().GetUniqId(), "",
 					res.GetRule().GetId(), now.AsTime(), now.AsTime().
-						Add(-time.Millisecond))
+						Add(-time.Millisecond), nil)
 				t.Logf("listEvents, err: %+v, %v", listEvents, err)
 				assert.NoError(t, err)
 				assert.Len(t, listEvents, 1)
@@ -171,6 +172,159 @@ func TestEventMessages(t *testing.T) {
 	}
 }
 
+func TestEventMessagesCondition(t *testing.T) {
+	now := time.Now().Add(-15 * time.Minute)
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("ev"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createDev, err := globalDevDAO.Create(ctx, random.Device("ev",
+		createOrg.GetId()))
+	t.Logf("createDev, err: %+v, %v", createDev, err)
+	require.NoError(t, err)
+
+	cRule := random.Rule("ev", createOrg.GetId())
+	cRule.Status = api.Status_ACTIVE
+	cRule.DeviceTag = createDev.GetTags()[0]
+	cRule.Attr = "ev-cond"
+	cRule.Expr = `pointVal > 30`
+	createRule, err := globalRuleDAO.Create(ctx, cRule)
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	createCond, err := globalRuleDAO.UpsertCondition(ctx,
+		&message.RuleCondition{
+			RuleId: createRule.GetId(), OrgId: createOrg.GetId(),
+			ForSamples: 2, ExitExpr: `pointVal < 25`,
+		})
+	t.Logf("createCond, err: %+v, %v", createCond, err)
+	require.NoError(t, err)
+
+	// Publish serially, as concurrent evaluation of the same device and rule
+	// is not ordered.
+	vals := []int32{40, 35, 28, 40, 20}
+	for i, val := range vals {
+		bVOut, err := proto.Marshal(&message.ValidatorOut{
+			Point: &common.DataPoint{
+				UniqId: createDev.GetUniqId(), Attr: "ev-cond",
+				ValOneof: &common.DataPoint_IntVal{IntVal: val},
+				Ts: timestamppb.New(now.Add(time.Duration(i) *
+					time.Second)),
+				TraceId: uuid.NewV7().String(),
+			}, Device: createDev,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, globalEvQueue.Publish(globalVOutSubTopic, bVOut))
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	// Only the second data point transitions to active.
+	select {
+	case msg := <-globalEOutSub.C():
+		msg.Ack()
+
+		eOut := &message.EventerOut{}
+		require.NoError(t, proto.Unmarshal(msg.Payload(), eOut))
+		t.Logf("eOut: %+v", eOut)
+		require.Equal(t, int32(35), eOut.GetPoint().GetIntVal())
+		require.Equal(t, createRule.GetId(), eOut.GetRule().GetId())
+		require.False(t, eOut.GetCleared())
+	case <-time.After(testTimeout):
+		t.Fatal("Message timed out")
+	}
+
+	// Only the final data point matches the exit expression.
+	select {
+	case msg := <-globalEOutSub.C():
+		msg.Ack()
+
+		eOut := &message.EventerOut{}
+		require.NoError(t, proto.Unmarshal(msg.Payload(), eOut))
+		t.Logf("eOut: %+v", eOut)
+		require.Equal(t, int32(20), eOut.GetPoint().GetIntVal())
+		require.True(t, eOut.GetCleared())
+	case <-time.After(testTimeout):
+		t.Fatal("Message timed out")
+	}
+
+	select {
+	case msg := <-globalEOutSub.C():
+		t.Fatalf("Received unexpected msg.Topic, msg.Payload: %v, %s",
+			msg.Topic(), msg.Payload())
+	case <-time.After(500 * time.Millisecond):
+		// Successful timeout without publish (normally 0.25s).
+	}
+}
+
+func TestEventMessagesTrigger(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("ev"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createDev, err := globalDevDAO.Create(ctx, random.Device("ev",
+		createOrg.GetId()))
+	t.Logf("createDev, err: %+v, %v", createDev, err)
+	require.NoError(t, err)
+
+	cRule := random.Rule("ev", createOrg.GetId())
+	cRule.Status = api.Status_ACTIVE
+	cRule.DeviceTag = createDev.GetTags()[0]
+	cRule.Attr = "ev-temp"
+	cRule.Expr = `latest["ev-temp"]?.val > 30 && latest["ev-door"]?.val == true`
+	createRule, err := globalRuleDAO.Create(ctx, cRule)
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	createTrig, err := globalRuleDAO.UpsertTrigger(ctx,
+		&message.RuleTrigger{
+			RuleId: createRule.GetId(), OrgId: createOrg.GetId(),
+			Attrs: []string{"ev-door"},
+		})
+	t.Logf("createTrig, err: %+v, %v", createTrig, err)
+	require.NoError(t, err)
+
+	// Seed the latest temperature, as would be done by the Accumulator.
+	require.NoError(t, latest.Set(ctx, globalCache, createOrg.GetId(),
+		createDev.GetId(), &common.DataPoint{
+			Attr: "ev-temp", ValOneof: &common.DataPoint_IntVal{IntVal: 40},
+			Ts: timestamppb.Now(),
+		}))
+
+	bVOut, err := proto.Marshal(&message.ValidatorOut{
+		Point: &common.DataPoint{
+			UniqId: createDev.GetUniqId(), Attr: "ev-door",
+			ValOneof: &common.DataPoint_BoolVal{BoolVal: true},
+			Ts:       timestamppb.Now(), TraceId: uuid.NewV7().String(),
+		}, Device: createDev,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, globalEvQueue.Publish(globalVOutSubTopic, bVOut))
+
+	// The door data point triggers the temperature rule.
+	select {
+	case msg := <-globalEOutSub.C():
+		msg.Ack()
+
+		eOut := &message.EventerOut{}
+		require.NoError(t, proto.Unmarshal(msg.Payload(), eOut))
+		t.Logf("eOut: %+v", eOut)
+		require.True(t, eOut.GetPoint().GetBoolVal())
+		require.Equal(t, createRule.GetId(), eOut.GetRule().GetId())
+		require.False(t, eOut.GetCleared())
+	case <-time.After(testTimeout):
+		t.Fatal("Message timed out")
+	}
+}
+
 func TestEventMessagesError(t *testing.T) {
 	now := timestamppb.New(time.Now().Add(-15 * time.Minute))
 
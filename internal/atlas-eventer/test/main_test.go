@@ -9,6 +9,7 @@ import (
 
 	"github.com/thingspect/atlas/internal/atlas-eventer/config"
 	"github.com/thingspect/atlas/internal/atlas-eventer/eventer"
+	"github.com/thingspect/atlas/pkg/cache"
 	"github.com/thingspect/atlas/pkg/dao"
 	"github.com/thingspect/atlas/pkg/dao/device"
 	"github.com/thingspect/atlas/pkg/dao/event"
@@ -30,6 +31,8 @@ var (
 	globalDevDAO  *device.DAO
 	globalRuleDAO *rule.DAO
 	globalEvDAO   *event.DAO
+
+	globalCache cache.Cacher[[]byte]
 )
 
 func TestMain(m *testing.M) {
@@ -38,6 +41,7 @@ func TestMain(m *testing.M) {
 	cfg := config.New()
 	cfg.PgRwURI = testConfig.PgURI
 	cfg.PgRoURI = testConfig.PgURI
+	cfg.RedisHost = testConfig.RedisHost
 
 	cfg.NSQPubAddr = testConfig.NSQPubAddr
 	cfg.NSQLookupAddrs = testConfig.NSQLookupAddrs
@@ -78,6 +82,12 @@ func TestMain(m *testing.M) {
 	globalRuleDAO = rule.NewDAO(pg, pg)
 	globalEvDAO = event.NewDAO(pg, pg)
 
+	// Set up cache connection to seed the latest data points.
+	globalCache, err = cache.NewRedis[[]byte](cfg.RedisHost + ":6379")
+	if err != nil {
+		log.Fatalf("TestMain cache.NewRedis: %v", err)
+	}
+
 	// Set up NSQ subscription to verify published messages.
 	globalEOutSub, err = globalEvQueue.Subscribe(cfg.NSQPubTopic)
 	if err != nil {
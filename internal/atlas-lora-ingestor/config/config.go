@@ -17,10 +17,11 @@ type Config struct {
 	MQTTPass   string
 	MQTTShared bool
 
-	NSQPubAddr      string
-	NSQGWPubTopic   string
-	NSQDevPubTopic  string
-	NSQDataPubTopic string
+	NSQPubAddr        string
+	NSQGWPubTopic     string
+	NSQDevPubTopic    string
+	NSQDataPubTopic   string
+	NSQCmdAckPubTopic string
 }
 
 // New instantiates a service Config, parses the environment, and returns it.
@@ -39,5 +40,7 @@ func New() *Config {
 		NSQGWPubTopic:   config.String(pref+"NSQ_GW_PUB_TOPIC", "ValidatorIn"),
 		NSQDevPubTopic:  config.String(pref+"NSQ_DEV_PUB_TOPIC", "ValidatorIn"),
 		NSQDataPubTopic: config.String(pref+"NSQ_DATA_PUB_TOPIC", "DecoderIn"),
+		NSQCmdAckPubTopic: config.String(pref+"NSQ_CMD_ACK_PUB_TOPIC",
+			"CommandAck"),
 	}
 }
@@ -1,10 +1,12 @@
 package ingestor
 
 import (
+	"fmt"
 	"strings"
 	"uuid"
 
 	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/decode"
 	"github.com/thingspect/atlas/pkg/decode/chirpstack/device"
 	"github.com/thingspect/atlas/pkg/decode/chirpstack/gateway"
 	"github.com/thingspect/atlas/pkg/decode/registry"
@@ -204,6 +206,37 @@ func (ing *Ingestor) decodeDevices() {
 			logger.Debugf("decodeDevices data published: %+v", pIn)
 		}
 
+		// Build and publish CommandAck messages, if present.
+		if cmdAck := pointsToCommandAck(traceID.String(), topicParts[4],
+			points); cmdAck != nil {
+			successTarget++
+
+			bCmdAck, err := proto.Marshal(cmdAck)
+			if err != nil {
+				msg.Ack()
+				metric.Incr("error",
+					map[string]string{metric.TagFunc: "marshal"})
+				logger.Errorf("decodeDevices ack proto.Marshal: %v", err)
+
+				continue
+			}
+
+			if err = ing.ingQueue.Publish(ing.cmdAckPubTopic,
+				bCmdAck); err != nil {
+				// Allow requeue.
+				metric.Incr("error",
+					map[string]string{metric.TagFunc: "publish"})
+				logger.Errorf("decodeDevices ack ing.decoderQueue.Publish: %v",
+					err)
+
+				continue
+			}
+
+			successCount++
+			metric.Incr("published", map[string]string{"type": "ack"})
+			logger.Debugf("decodeDevices ack published: %+v", cmdAck)
+		}
+
 		// Do not ack on points loop errors, as publish may retry successfully.
 		// Deduplication will take place downstream.
 		if successCount == successTarget {
@@ -216,3 +249,34 @@ func (ing *Ingestor) decodeDevices() {
 		}
 	}
 }
+
+// pointsToCommandAck builds a CommandAck from device ACK and TX ACK points that
+// reference an enqueued downlink. nil is returned for all other points.
+func pointsToCommandAck(
+	traceID, uniqID string, points []*decode.Point,
+) *message.CommandAck {
+	cmdAck := &message.CommandAck{UniqId: uniqID, TraceId: traceID}
+
+	for _, point := range points {
+		switch point.Attr {
+		case device.AttrQueueID:
+			cmdAck.LoraQueueId, _ = point.Value.(string)
+		case device.AttrTXQueued:
+			cmdAck.Status = message.CommandStatus_SENT
+		case device.AttrAck:
+			if point.Value == device.AckOK {
+				cmdAck.Status = message.CommandStatus_ACKED
+			} else {
+				cmdAck.Status = message.CommandStatus_FAILED
+				cmdAck.Error = fmt.Sprint(point.Value)
+			}
+		}
+	}
+
+	if cmdAck.GetLoraQueueId() == "" ||
+		cmdAck.GetStatus() == message.CommandStatus_COMMAND_STATUS_UNSPECIFIED {
+		return nil
+	}
+
+	return cmdAck
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"testing"
 	"time"
+	"uuid"
 
 	"github.com/chirpstack/chirpstack/api/go/v4/gw"
 	"github.com/chirpstack/chirpstack/api/go/v4/integration"
@@ -528,3 +529,101 @@ func TestDecodeDevicesError(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeDevicesCommandAck(t *testing.T) {
+	t.Parallel()
+
+	uniqID := random.String(16)
+	queueID := uuid.NewV7().String()
+
+	tests := []struct {
+		inpTopic string
+		inpProto proto.Message
+		res      *message.CommandAck
+	}{
+		{
+			"lora/application/1/device/" + uniqID + "/event/txack",
+			&integration.TxAckEvent{QueueItemId: queueID},
+			&message.CommandAck{
+				UniqId: uniqID, LoraQueueId: queueID,
+				Status: message.CommandStatus_SENT,
+			},
+		},
+		{
+			"lora/application/2/device/" + uniqID + "/event/ack",
+			&integration.AckEvent{QueueItemId: queueID, Acknowledged: true},
+			&message.CommandAck{
+				UniqId: uniqID, LoraQueueId: queueID,
+				Status: message.CommandStatus_ACKED,
+			},
+		},
+		{
+			"lora/application/3/device/" + uniqID + "/event/ack",
+			&integration.AckEvent{QueueItemId: queueID},
+			&message.CommandAck{
+				UniqId: uniqID, LoraQueueId: queueID,
+				Status: message.CommandStatus_FAILED,
+				Error:  device.AckTimeout,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can decode %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			mqttDevQueue := queue.NewFake()
+			mqttDevSub, err := mqttDevQueue.Subscribe("")
+			require.NoError(t, err)
+
+			ingQueue := queue.NewFake()
+			ingSub, err := ingQueue.Subscribe("")
+			require.NoError(t, err)
+			vInDevPubTopic := "topic-" + random.String(10)
+			cmdAckPubTopic := "topic-" + random.String(10)
+
+			ing := Ingestor{
+				mqttDevSub: mqttDevSub,
+
+				ingQueue:       ingQueue,
+				vInDevPubTopic: vInDevPubTopic,
+				cmdAckPubTopic: cmdAckPubTopic,
+			}
+			go func() {
+				ing.decodeDevices()
+			}()
+
+			bInpProto, err := proto.Marshal(test.inpProto)
+			require.NoError(t, err)
+			t.Logf("bInpProto: %s", bInpProto)
+
+			require.NoError(t, mqttDevQueue.Publish(test.inpTopic, bInpProto))
+
+			// Skip data points, which are verified by TestDecodeDevices.
+			for {
+				select {
+				case msg := <-ingSub.C():
+					msg.Ack()
+					t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(),
+						msg.Payload())
+					if msg.Topic() != cmdAckPubTopic {
+						continue
+					}
+
+					cmdAck := &message.CommandAck{}
+					require.NoError(t, proto.Unmarshal(msg.Payload(), cmdAck))
+					t.Logf("cmdAck: %+v", cmdAck)
+
+					// Normalize generated trace ID.
+					test.res.TraceId = cmdAck.GetTraceId()
+
+					require.EqualExportedValues(t, test.res, cmdAck)
+
+					return
+				case <-time.After(2 * time.Second):
+					t.Fatal("Message timed out")
+				}
+			}
+		})
+	}
+}
@@ -33,6 +33,7 @@ type Ingestor struct {
 	vInGWPubTopic  string
 	vInDevPubTopic string
 	dInPubTopic    string
+	cmdAckPubTopic string
 }
 
 // New builds a new Ingestor and returns a reference to it and an error value.
@@ -84,6 +85,7 @@ func New(cfg *config.Config) (*Ingestor, error) {
 		vInGWPubTopic:  cfg.NSQGWPubTopic,
 		vInDevPubTopic: cfg.NSQDevPubTopic,
 		dInPubTopic:    cfg.NSQDataPubTopic,
+		cmdAckPubTopic: cfg.NSQCmdAckPubTopic,
 	}, nil
 }
 
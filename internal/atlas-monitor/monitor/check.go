@@ -0,0 +1,104 @@
+package monitor
+
+import (
+	"context"
+	"time"
+	"uuid"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao/connectivity"
+	"github.com/thingspect/atlas/pkg/metric"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// markFunc defines the signature of a connectivity.DAO's mark method.
+type markFunc func(ctx context.Context, now time.Time) ([]*message.Connectivity,
+	error)
+
+// checkDevices marks devices that have missed their expected reporting
+// interval as offline, and devices that have since reported as online. Each
+// transition is published as a connectivity data point, which is evaluated by
+// the Eventer against an org's rules.
+func (mon *Monitor) checkDevices() {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	onCount := mon.publishMarked(connectivity.Online, now,
+		mon.connDAO.MarkOnline)
+	offCount := mon.publishMarked(connectivity.Offline, now,
+		mon.connDAO.MarkOffline)
+
+	alog.Infof("checkDevices published online, offline: %v, %v", onCount,
+		offCount)
+}
+
+// publishMarked marks devices using the provided mark method and publishes a
+// connectivity data point with value state for each. It returns the number of
+// published data points.
+func (mon *Monitor) publishMarked(
+	state string, now time.Time, mark markFunc,
+) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	conns, err := mark(ctx, now)
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "mark" + state})
+		alog.Errorf("publishMarked mark %v: %v", state, err)
+
+		return 0
+	}
+
+	var count int
+	for _, conn := range conns {
+		traceID := uuid.NewV7().String()
+		logger := alog.
+			WithField("traceID", traceID).
+			WithField("orgID", conn.GetOrgId()).
+			WithField("devID", conn.GetDevId())
+
+		ctx, cancel := context.WithTimeout(context.Background(),
+			5*time.Second)
+		dev, err := mon.devDAO.Read(ctx, conn.GetDevId(), conn.GetOrgId())
+		cancel()
+		if err != nil {
+			metric.Incr("error", map[string]string{metric.TagFunc: "read"})
+			logger.Errorf("publishMarked mon.devDAO.Read: %v", err)
+
+			continue
+		}
+
+		vOut := &message.ValidatorOut{
+			Point: &common.DataPoint{
+				UniqId:   dev.GetUniqId(),
+				Attr:     connectivity.Attr,
+				ValOneof: &common.DataPoint_StrVal{StrVal: state},
+				Ts:       timestamppb.New(now),
+				TraceId:  traceID,
+			},
+			Device: dev,
+		}
+
+		bVOut, err := proto.Marshal(vOut)
+		if err != nil {
+			metric.Incr("error", map[string]string{metric.TagFunc: "marshal"})
+			logger.Errorf("publishMarked proto.Marshal: %v", err)
+
+			continue
+		}
+
+		if err = mon.monQueue.Publish(mon.vOutPubTopic, bVOut); err != nil {
+			metric.Incr("error", map[string]string{metric.TagFunc: "publish"})
+			logger.Errorf("publishMarked mon.monQueue.Publish: %v", err)
+
+			continue
+		}
+
+		count++
+		metric.Incr("published", map[string]string{"state": state})
+		logger.Debugf("publishMarked published: %+v", vOut)
+	}
+
+	return count
+}
@@ -0,0 +1,162 @@
+//go:build !integration
+
+package monitor
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/consterr"
+	"github.com/thingspect/atlas/pkg/dao/connectivity"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+)
+
+const errTestProc consterr.Error = "monitor: test processor error"
+
+func TestCheckDevices(t *testing.T) {
+	t.Parallel()
+
+	offDev := random.Device("mon", uuid.NewV7().String())
+	onDev := random.Device("mon", uuid.NewV7().String())
+
+	tests := []struct {
+		inpOff []*message.Connectivity
+		inpOn  []*message.Connectivity
+		res    map[string]string
+	}{
+		{nil, nil, map[string]string{}},
+		{[]*message.Connectivity{{
+			DevId: offDev.GetId(), OrgId: offDev.GetOrgId(),
+		}}, nil, map[string]string{offDev.GetUniqId(): connectivity.Offline}},
+		{[]*message.Connectivity{{
+			DevId: offDev.GetId(), OrgId: offDev.GetOrgId(),
+		}}, []*message.Connectivity{{
+			DevId: onDev.GetId(), OrgId: onDev.GetOrgId(),
+		}}, map[string]string{
+			offDev.GetUniqId(): connectivity.Offline,
+			onDev.GetUniqId():  connectivity.Online,
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can check %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			monQueue := queue.NewFake()
+			vOutSub, err := monQueue.Subscribe("")
+			require.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+			connectivityer := NewMockconnectivityer(ctrl)
+			connectivityer.EXPECT().MarkOffline(gomock.Any(), gomock.Any()).
+				Return(test.inpOff, nil).Times(1)
+			connectivityer.EXPECT().MarkOnline(gomock.Any(), gomock.Any()).
+				Return(test.inpOn, nil).Times(1)
+
+			devicer := NewMockdevicer(ctrl)
+			devicer.EXPECT().Read(gomock.Any(), offDev.GetId(),
+				offDev.GetOrgId()).Return(offDev, nil).
+				Times(len(test.inpOff))
+			devicer.EXPECT().Read(gomock.Any(), onDev.GetId(),
+				onDev.GetOrgId()).Return(onDev, nil).Times(len(test.inpOn))
+
+			mon := Monitor{
+				connDAO: connectivityer,
+				devDAO:  devicer,
+
+				monQueue: monQueue,
+			}
+			mon.checkDevices()
+
+			res := map[string]string{}
+			for range test.res {
+				select {
+				case msg := <-vOutSub.C():
+					msg.Ack()
+					t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(),
+						msg.Payload())
+
+					vOut := &message.ValidatorOut{}
+					require.NoError(t, proto.Unmarshal(msg.Payload(), vOut))
+					require.Equal(t, connectivity.Attr,
+						vOut.GetPoint().GetAttr())
+					require.NotEmpty(t, vOut.GetPoint().GetTraceId())
+					require.WithinDuration(t, time.Now(),
+						vOut.GetPoint().GetTs().AsTime(), 2*time.Second)
+					require.Equal(t, vOut.GetPoint().GetUniqId(),
+						vOut.GetDevice().GetUniqId())
+					res[vOut.GetPoint().GetUniqId()] =
+						vOut.GetPoint().GetStrVal()
+				case <-time.After(2 * time.Second):
+					t.Fatal("Message timed out")
+				}
+			}
+			require.Equal(t, test.res, res)
+
+			select {
+			case msg := <-vOutSub.C():
+				t.Fatalf("Unexpected message: %+v", msg)
+			case <-time.After(100 * time.Millisecond):
+			}
+		})
+	}
+}
+
+func TestCheckDevicesError(t *testing.T) {
+	t.Parallel()
+
+	dev := random.Device("mon", uuid.NewV7().String())
+
+	tests := []struct {
+		inpMarkErr   error
+		inpReadErr   error
+		inpReadTimes int
+	}{
+		{errTestProc, nil, 0},
+		{nil, errTestProc, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cannot check %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			monQueue := queue.NewFake()
+			vOutSub, err := monQueue.Subscribe("")
+			require.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+			connectivityer := NewMockconnectivityer(ctrl)
+			connectivityer.EXPECT().MarkOffline(gomock.Any(), gomock.Any()).
+				Return([]*message.Connectivity{{
+					DevId: dev.GetId(), OrgId: dev.GetOrgId(),
+				}}, test.inpMarkErr).Times(1)
+			connectivityer.EXPECT().MarkOnline(gomock.Any(), gomock.Any()).
+				Return(nil, test.inpMarkErr).Times(1)
+
+			devicer := NewMockdevicer(ctrl)
+			devicer.EXPECT().Read(gomock.Any(), dev.GetId(), dev.GetOrgId()).
+				Return(nil, test.inpReadErr).Times(test.inpReadTimes)
+
+			mon := Monitor{
+				connDAO: connectivityer,
+				devDAO:  devicer,
+
+				monQueue: monQueue,
+			}
+			mon.checkDevices()
+
+			select {
+			case msg := <-vOutSub.C():
+				t.Fatalf("Unexpected message: %+v", msg)
+			case <-time.After(100 * time.Millisecond):
+			}
+		})
+	}
+}
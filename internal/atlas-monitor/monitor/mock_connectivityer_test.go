@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: monitor.go
+//
+// Generated by this command:
+//
+//	mockgen -source monitor.go -destination mock_connectivityer_test.go -package monitor
+//
+
+// Package monitor is a generated GoMock package.
+package monitor
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	api "github.com/thingspect/proto/go/api"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockconnectivityer is a mock of connectivityer interface.
+type Mockconnectivityer struct {
+	ctrl     *gomock.Controller
+	recorder *MockconnectivityerMockRecorder
+	isgomock struct{}
+}
+
+// MockconnectivityerMockRecorder is the mock recorder for Mockconnectivityer.
+type MockconnectivityerMockRecorder struct {
+	mock *Mockconnectivityer
+}
+
+// NewMockconnectivityer creates a new mock instance.
+func NewMockconnectivityer(ctrl *gomock.Controller) *Mockconnectivityer {
+	mock := &Mockconnectivityer{ctrl: ctrl}
+	mock.recorder = &MockconnectivityerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockconnectivityer) EXPECT() *MockconnectivityerMockRecorder {
+	return m.recorder
+}
+
+// MarkOffline mocks base method.
+func (m *Mockconnectivityer) MarkOffline(ctx context.Context, now time.Time) ([]*message.Connectivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOffline", ctx, now)
+	ret0, _ := ret[0].([]*message.Connectivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOffline indicates an expected call of MarkOffline.
+func (mr *MockconnectivityerMockRecorder) MarkOffline(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOffline", reflect.TypeOf((*Mockconnectivityer)(nil).MarkOffline), ctx, now)
+}
+
+// MarkOnline mocks base method.
+func (m *Mockconnectivityer) MarkOnline(ctx context.Context, now time.Time) ([]*message.Connectivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOnline", ctx, now)
+	ret0, _ := ret[0].([]*message.Connectivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOnline indicates an expected call of MarkOnline.
+func (mr *MockconnectivityerMockRecorder) MarkOnline(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOnline", reflect.TypeOf((*Mockconnectivityer)(nil).MarkOnline), ctx, now)
+}
+
+// Mockdevicer is a mock of devicer interface.
+type Mockdevicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockdevicerMockRecorder
+	isgomock struct{}
+}
+
+// MockdevicerMockRecorder is the mock recorder for Mockdevicer.
+type MockdevicerMockRecorder struct {
+	mock *Mockdevicer
+}
+
+// NewMockdevicer creates a new mock instance.
+func NewMockdevicer(ctrl *gomock.Controller) *Mockdevicer {
+	mock := &Mockdevicer{ctrl: ctrl}
+	mock.recorder = &MockdevicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdevicer) EXPECT() *MockdevicerMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *Mockdevicer) Read(ctx context.Context, devID, orgID string) (*api.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, devID, orgID)
+	ret0, _ := ret[0].(*api.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockdevicerMockRecorder) Read(ctx, devID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*Mockdevicer)(nil).Read), ctx, devID, orgID)
+}
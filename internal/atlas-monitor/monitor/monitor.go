@@ -0,0 +1,95 @@
+// Package monitor provides functions used to run the Monitor service.
+package monitor
+
+//go:generate mockgen -source monitor.go -destination mock_connectivityer_test.go -package monitor
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-monitor/config"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/connectivity"
+	"github.com/thingspect/atlas/pkg/dao/device"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+// ServiceName provides consistent naming, including logs and metrics.
+const ServiceName = "monitor"
+
+// connectivityer defines the methods provided by a connectivity.DAO.
+type connectivityer interface {
+	MarkOffline(ctx context.Context, now time.Time) ([]*message.Connectivity,
+		error)
+	MarkOnline(ctx context.Context, now time.Time) ([]*message.Connectivity,
+		error)
+}
+
+// devicer defines the methods provided by a device.DAO.
+type devicer interface {
+	Read(ctx context.Context, devID, orgID string) (*api.Device, error)
+}
+
+// Monitor holds references to the database and message broker connections.
+type Monitor struct {
+	connDAO connectivityer
+	devDAO  devicer
+
+	monQueue     queue.Queuer
+	vOutPubTopic string
+}
+
+// New builds a new Monitor and returns a reference to it and an error value.
+func New(cfg *config.Config) (*Monitor, error) {
+	// Set up database connection.
+	pgRW, err := dao.NewPgDB(cfg.PgRwURI)
+	if err != nil {
+		return nil, err
+	}
+
+	pgRO, err := dao.NewPgDB(cfg.PgRoURI)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the NSQ connection for publishing.
+	nsq, err := queue.NewNSQ(cfg.NSQPubAddr, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Monitor{
+		connDAO: connectivity.NewDAO(pgRW, pgRO),
+		devDAO:  device.NewDAO(pgRW, pgRO, nil, 0),
+
+		monQueue:     nsq,
+		vOutPubTopic: cfg.NSQPubTopic,
+	}, nil
+}
+
+// Serve starts the monitor, which runs immediately and then once per interval.
+func (mon *Monitor) Serve(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			mon.checkDevices()
+			<-ticker.C
+		}
+	}()
+
+	// Handle graceful shutdown.
+	exitChan := make(chan os.Signal, 1)
+	signal.Notify(exitChan, syscall.SIGINT, syscall.SIGTERM)
+	<-exitChan
+
+	alog.Info("Serve received signal, exiting")
+	mon.monQueue.Disconnect()
+}
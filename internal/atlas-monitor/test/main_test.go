@@ -0,0 +1,78 @@
+//go:build !unit
+
+package test
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-monitor/config"
+	"github.com/thingspect/atlas/internal/atlas-monitor/monitor"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/connectivity"
+	"github.com/thingspect/atlas/pkg/dao/device"
+	"github.com/thingspect/atlas/pkg/dao/org"
+	"github.com/thingspect/atlas/pkg/queue"
+	testconfig "github.com/thingspect/atlas/pkg/test/config"
+	"github.com/thingspect/atlas/pkg/test/random"
+)
+
+var (
+	globalVOutSub queue.Subber
+
+	globalOrgDAO  *org.DAO
+	globalDevDAO  *device.DAO
+	globalConnDAO *connectivity.DAO
+)
+
+func TestMain(m *testing.M) {
+	// Set up Config.
+	testConfig := testconfig.New()
+	cfg := config.New()
+	cfg.PgRwURI = testConfig.PgURI
+	cfg.PgRoURI = testConfig.PgURI
+	cfg.Interval = time.Second
+
+	cfg.NSQPubAddr = testConfig.NSQPubAddr
+	cfg.NSQPubTopic += "-test-" + random.String(10)
+	log.Printf("TestMain cfg.NSQPubTopic: %v", cfg.NSQPubTopic)
+
+	// Set up NSQ subscription to verify published messages. Use a unique
+	// channel for each test run.
+	monQueue, err := queue.NewNSQ(cfg.NSQPubAddr, testConfig.NSQLookupAddrs,
+		"monitor-test-"+random.String(10))
+	if err != nil {
+		log.Fatalf("TestMain queue.NewNSQ: %v", err)
+	}
+
+	if err = monQueue.Prime(cfg.NSQPubTopic); err != nil {
+		log.Fatalf("TestMain monQueue.Prime: %v", err)
+	}
+
+	globalVOutSub, err = monQueue.Subscribe(cfg.NSQPubTopic)
+	if err != nil {
+		log.Fatalf("TestMain monQueue.Subscribe: %v", err)
+	}
+
+	// Set up Monitor.
+	mon, err := monitor.New(cfg)
+	if err != nil {
+		log.Fatalf("TestMain monitor.New: %v", err)
+	}
+
+	// Serve connectivity checks.
+	go mon.Serve(cfg.Interval)
+
+	// Set up database connection.
+	pg, err := dao.NewPgDB(cfg.PgRwURI)
+	if err != nil {
+		log.Fatalf("TestMain dao.NewPgDB: %v", err)
+	}
+	globalOrgDAO = org.NewDAO(pg, pg)
+	globalDevDAO = device.NewDAO(pg, pg, nil, 0)
+	globalConnDAO = connectivity.NewDAO(pg, pg)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,81 @@
+//go:build !unit
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao/connectivity"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const testTimeout = 8 * time.Second
+
+func TestCheckDevices(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("mon"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	dev := random.Device("mon", createOrg.GetId())
+	dev.Status = api.Status_ACTIVE
+	createDev, err := globalDevDAO.Create(ctx, dev)
+	t.Logf("createDev, err: %+v, %v", createDev, err)
+	require.NoError(t, err)
+
+	inter, err := globalConnDAO.UpsertInterval(ctx,
+		&message.ConnectivityInterval{
+			OrgId: createOrg.GetId(), DevId: createDev.GetId(),
+			Interval: durationpb.New(time.Minute),
+		})
+	t.Logf("inter, err: %+v, %v", inter, err)
+	require.NoError(t, err)
+
+	// awaitState waits for a connectivity data point for createDev, skipping
+	// those of other devices in the shared database.
+	awaitState := func(state string) {
+		t.Helper()
+
+		for {
+			select {
+			case msg := <-globalVOutSub.C():
+				msg.Ack()
+
+				vOut := &message.ValidatorOut{}
+				require.NoError(t, proto.Unmarshal(msg.Payload(), vOut))
+				t.Logf("vOut: %+v", vOut)
+				if vOut.GetDevice().GetId() != createDev.GetId() {
+					continue
+				}
+
+				require.Equal(t, createDev.GetUniqId(),
+					vOut.GetPoint().GetUniqId())
+				require.Equal(t, connectivity.Attr, vOut.GetPoint().GetAttr())
+				require.Equal(t, state, vOut.GetPoint().GetStrVal())
+
+				return
+			case <-time.After(testTimeout):
+				t.Fatalf("Message timed out: %v", state)
+			}
+		}
+	}
+
+	require.NoError(t, globalConnDAO.Seen(ctx, createDev.GetId(),
+		createOrg.GetId(), time.Now().Add(-2*time.Minute)))
+	awaitState(connectivity.Offline)
+
+	require.NoError(t, globalConnDAO.Seen(ctx, createDev.GetId(),
+		createOrg.GetId(), time.Now().Add(time.Second)))
+	awaitState(connectivity.Online)
+}
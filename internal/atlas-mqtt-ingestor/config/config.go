@@ -17,8 +17,9 @@ type Config struct {
 	MQTTPass   string
 	MQTTShared bool
 
-	NSQPubAddr  string
-	NSQPubTopic string
+	NSQPubAddr        string
+	NSQPubTopic       string
+	NSQCmdAckPubTopic string
 }
 
 // New instantiates a service Config, parses the environment, and returns it.
@@ -35,5 +36,7 @@ func New() *Config {
 
 		NSQPubAddr:  config.String(pref+"NSQ_PUB_ADDR", "127.0.0.1:4150"),
 		NSQPubTopic: config.String(pref+"NSQ_PUB_TOPIC", "ValidatorIn"),
+		NSQCmdAckPubTopic: config.String(pref+"NSQ_CMD_ACK_PUB_TOPIC",
+			"CommandAck"),
 	}
 }
@@ -0,0 +1,98 @@
+package ingestor
+
+import (
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/metric"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Constants used for command and shadow topics.
+const (
+	cmdTopic    = "cmd"
+	cmdAckTopic = "ack"
+	shadowTopic = "shadow"
+)
+
+// decodeCommandAck decodes a command acknowledgment and builds a message for
+// publishing. Commands published to devices on 'v1/:orgID/:uniqID/cmd' are
+// received due to the wildcard subscription and are discarded.
+func (ing *Ingestor) decodeCommandAck(
+	msg queue.Messager, traceID string, topicParts []string,
+	logger alog.Logger,
+) {
+	// Discard commands published to devices.
+	if len(topicParts) == 4 {
+		msg.Ack()
+
+		return
+	}
+
+	// Validate topic in format: 'v1/:orgID/:uniqID/cmd/ack[/json]'.
+	if len(topicParts) > 6 || topicParts[4] != cmdAckTopic ||
+		(len(topicParts) == 6 && topicParts[5] != "json") {
+		msg.Ack()
+		metric.Incr("error", map[string]string{metric.TagFunc: "topic"})
+		logger.Errorf("decodeCommandAck malformed topic: %v", msg.Topic())
+
+		return
+	}
+	logger = logger.WithField("orgID", topicParts[1])
+
+	// Unmarshal payload based on topic and format.
+	cmdAck := &message.CommandAck{}
+	var err error
+
+	if len(topicParts) == 6 {
+		logger = logger.WithField("paylType", "json")
+		err = protojson.Unmarshal(msg.Payload(), cmdAck)
+	} else {
+		logger = logger.WithField("paylType", "proto")
+		err = proto.Unmarshal(msg.Payload(), cmdAck)
+	}
+	// Acknowledgments are authenticated by device token downstream.
+	if err != nil || cmdAck.GetId() == "" || cmdAck.GetToken() == "" {
+		msg.Ack()
+		metric.Incr("error", map[string]string{metric.TagFunc: "unmarshal"})
+		logger.Errorf("decodeCommandAck proto.Unmarshal cmdAck, err: %+v, %v",
+			cmdAck, err)
+
+		return
+	}
+	metric.Incr("processed", map[string]string{"type": "ack"})
+	logger.Debugf("decodeCommandAck cmdAck: %+v", cmdAck)
+
+	// Override identifiers with topic-based values. Devices report failure by
+	// error.
+	cmdAck.OrgId = topicParts[1]
+	cmdAck.UniqId = topicParts[2]
+	cmdAck.LoraQueueId = ""
+	cmdAck.TraceId = traceID
+	cmdAck.Status = message.CommandStatus_ACKED
+	if cmdAck.GetError() != "" {
+		cmdAck.Status = message.CommandStatus_FAILED
+	}
+
+	bCmdAck, err := proto.Marshal(cmdAck)
+	if err != nil {
+		msg.Ack()
+		metric.Incr("error", map[string]string{metric.TagFunc: "marshal"})
+		logger.Errorf("decodeCommandAck proto.Marshal: %v", err)
+
+		return
+	}
+
+	if err = ing.ingQueue.Publish(ing.cmdAckPubTopic, bCmdAck); err != nil {
+		// Allow requeue.
+		metric.Incr("error", map[string]string{metric.TagFunc: "publish"})
+		logger.Errorf("decodeCommandAck ing.ingQueue.Publish: %v", err)
+
+		return
+	}
+
+	msg.Ack()
+	metric.Incr("published", map[string]string{"type": "ack"})
+	logger.Debugf("decodeCommandAck published: %+v", cmdAck)
+}
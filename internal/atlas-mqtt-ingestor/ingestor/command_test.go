@@ -0,0 +1,185 @@
+//go:build !integration
+
+package ingestor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/queue"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeCommandAck(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.NewV7().String()
+	uniqID := random.String(16)
+	cmdID := uuid.NewV7().String()
+	token := uuid.NewV7().String()
+
+	tests := []struct {
+		inpTopicParts []string
+		inpCmdAck     *message.CommandAck
+		res           *message.CommandAck
+	}{
+		{
+			[]string{"v1", orgID, uniqID, "cmd", "ack"},
+			&message.CommandAck{Id: cmdID, Token: token},
+			&message.CommandAck{
+				Id: cmdID, OrgId: orgID, UniqId: uniqID,
+				Status: message.CommandStatus_ACKED, Token: token,
+			},
+		},
+		{
+			[]string{"v1", orgID, uniqID, "cmd", "ack", "json"},
+			&message.CommandAck{
+				Id: cmdID, OrgId: uuid.NewV7().String(), UniqId: "ing-aaa",
+				LoraQueueId: "ing-aaa", Status: message.CommandStatus_ACKED,
+				Error: "ing-error", Token: token,
+			},
+			&message.CommandAck{
+				Id: cmdID, OrgId: orgID, UniqId: uniqID,
+				Status: message.CommandStatus_FAILED, Error: "ing-error",
+				Token: token,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can decode %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			mqttQueue := queue.NewFake()
+			mqttSub, err := mqttQueue.Subscribe("")
+			require.NoError(t, err)
+
+			ingQueue := queue.NewFake()
+			cmdAckSub, err := ingQueue.Subscribe("")
+			require.NoError(t, err)
+			cmdAckPubTopic := "topic-" + random.String(10)
+
+			ing := Ingestor{
+				mqttSub: mqttSub,
+
+				ingQueue:       ingQueue,
+				cmdAckPubTopic: cmdAckPubTopic,
+			}
+			go func() {
+				ing.decodeMessages()
+			}()
+
+			var bCmdAck []byte
+			if test.inpTopicParts[len(test.inpTopicParts)-1] == "json" {
+				bCmdAck, err = protojson.Marshal(test.inpCmdAck)
+			} else {
+				bCmdAck, err = proto.Marshal(test.inpCmdAck)
+			}
+			require.NoError(t, err)
+			t.Logf("bCmdAck: %s", bCmdAck)
+
+			require.NoError(t, mqttQueue.Publish(strings.
+				Join(test.inpTopicParts, "/"), bCmdAck))
+
+			select {
+			case msg := <-cmdAckSub.C():
+				msg.Ack()
+				t.Logf("msg.Topic, msg.Payload: %v, %s", msg.Topic(),
+					msg.Payload())
+				require.Equal(t, cmdAckPubTopic, msg.Topic())
+
+				cmdAck := &message.CommandAck{}
+				require.NoError(t, proto.Unmarshal(msg.Payload(), cmdAck))
+				t.Logf("cmdAck: %+v", cmdAck)
+
+				// Normalize generated trace ID.
+				test.res.TraceId = cmdAck.GetTraceId()
+
+				require.EqualExportedValues(t, test.res, cmdAck)
+			case <-time.After(2 * time.Second):
+				t.Fatal("Message timed out")
+			}
+		})
+	}
+}
+
+func TestDecodeCommandAckError(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.NewV7().String()
+	uniqID := random.String(16)
+
+	bCmd, err := proto.Marshal(random.Command("ing", orgID))
+	require.NoError(t, err)
+
+	bNoID, err := proto.Marshal(&message.CommandAck{
+		Token: uuid.NewV7().String(), Error: "ing-error",
+	})
+	require.NoError(t, err)
+
+	bNoToken, err := proto.Marshal(&message.CommandAck{
+		Id: uuid.NewV7().String(), Error: "ing-error",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		inpTopicParts []string
+		inpPayl       []byte
+	}{
+		// Command published to device.
+		{[]string{"v1", orgID, uniqID, "cmd"}, bCmd},
+		// Bad topic.
+		{[]string{"v1", orgID, uniqID, "cmd", "nak"}, nil},
+		{[]string{"v1", orgID, uniqID, "cmd", "ack", "xml"}, nil},
+		{[]string{"v1", orgID, uniqID, "cmd", "ack", "json", "ing"}, nil},
+		// Bad payload.
+		{[]string{"v1", orgID, uniqID, "cmd", "ack"}, []byte("ing-aaa")},
+		{[]string{"v1", orgID, uniqID, "cmd", "ack", "json"}, []byte("ing")},
+		{[]string{"v1", orgID, uniqID, "cmd", "ack"}, bNoID},
+		{[]string{"v1", orgID, uniqID, "cmd", "ack"}, bNoToken},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cannot decode %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			mqttQueue := queue.NewFake()
+			mqttSub, err := mqttQueue.Subscribe("")
+			require.NoError(t, err)
+
+			ingQueue := queue.NewFake()
+			ingSub, err := ingQueue.Subscribe("")
+			require.NoError(t, err)
+
+			ing := Ingestor{
+				mqttSub: mqttSub,
+
+				ingQueue:       ingQueue,
+				vInPubTopic:    "topic-" + random.String(10),
+				cmdAckPubTopic: "topic-" + random.String(10),
+			}
+			go func() {
+				ing.decodeMessages()
+			}()
+
+			require.NoError(t, mqttQueue.Publish(strings.Join(
+				test.inpTopicParts, "/",
+			), test.inpPayl))
+
+			select {
+			case msg := <-ingSub.C():
+				t.Fatalf("Received unexpected msg.Topic, msg.Payload: %v, %s",
+					msg.Topic(), msg.Payload())
+			case <-time.After(100 * time.Millisecond):
+				// Successful timeout without publish (normally 0.02s).
+			}
+		})
+	}
+}
@@ -26,9 +26,26 @@ func (ing *Ingestor) decodeMessages() {
 		traceID := uuid.NewV7().String()
 		logger := alog.WithField("traceID", traceID)
 
-		// Parse and validate topic in format: 'v1/:orgID[/:uniqID][/json]'.
+		// Handle command topics in format: 'v1/:orgID/:uniqID/cmd[/ack[/json]]'.
 		topic := msg.Topic()
 		topicParts := strings.Split(topic, "/")
+		if len(topicParts) >= 4 && topicParts[0] == "v1" &&
+			topicParts[3] == cmdTopic {
+			ing.decodeCommandAck(msg, traceID, topicParts, logger)
+
+			continue
+		}
+
+		// Discard shadows published to devices on 'v1/:orgID/:uniqID/shadow',
+		// which are received due to the wildcard subscription.
+		if len(topicParts) == 4 && topicParts[0] == "v1" &&
+			topicParts[3] == shadowTopic {
+			msg.Ack()
+
+			continue
+		}
+
+		// Parse and validate topic in format: 'v1/:orgID[/:uniqID][/json]'.
 		if len(topicParts) < 2 || len(topicParts) > 4 || topicParts[0] != "v1" {
 			msg.Ack()
 			metric.Incr("error", map[string]string{metric.TagFunc: "topic"})
@@ -19,6 +19,7 @@ import (
 	"github.com/thingspect/proto/go/mqtt"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -185,10 +186,19 @@ func TestDecodeMessagesError(t *testing.T) {
 	orgID := uuid.NewV7().String()
 	uniqID := random.String(16)
 
+	desired, err := structpb.NewStruct(map[string]any{"ing-aaa": 123})
+	require.NoError(t, err)
+	bShadow, err := proto.Marshal(&message.Shadow{
+		OrgId: orgID, UniqId: uniqID, Desired: desired, Delta: desired,
+	})
+	require.NoError(t, err)
+
 	tests := []struct {
 		inpTopicParts []string
 		inpPayl       []byte
 	}{
+		// Shadow published to device.
+		{[]string{"v1", orgID, uniqID, "shadow"}, bShadow},
 		// Bad topic.
 		{[]string{"v1"}, nil},
 		{[]string{"v1", orgID, uniqID, "json/ing-aaa"}, nil},
@@ -27,8 +27,9 @@ const (
 type Ingestor struct {
 	mqttSub queue.Subber
 
-	ingQueue    queue.Queuer
-	vInPubTopic string
+	ingQueue       queue.Queuer
+	vInPubTopic    string
+	cmdAckPubTopic string
 }
 
 // New builds a new Ingestor and returns a reference to it and an error value.
@@ -65,8 +66,9 @@ func New(cfg *config.Config) (*Ingestor, error) {
 	return &Ingestor{
 		mqttSub: mqttSub,
 
-		ingQueue:    nsq,
-		vInPubTopic: cfg.NSQPubTopic,
+		ingQueue:       nsq,
+		vInPubTopic:    cfg.NSQPubTopic,
+		cmdAckPubTopic: cfg.NSQCmdAckPubTopic,
 	}, nil
 }
 
@@ -0,0 +1,283 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: purger.go
+//
+// Generated by this command:
+//
+//	mockgen -source purger.go -destination mock_orger_test.go -package purger
+//
+
+// Package purger is a generated GoMock package.
+package purger
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	message "github.com/thingspect/atlas/proto/go/message"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockorger is a mock of orger interface.
+type Mockorger struct {
+	ctrl     *gomock.Controller
+	recorder *MockorgerMockRecorder
+	isgomock struct{}
+}
+
+// MockorgerMockRecorder is the mock recorder for Mockorger.
+type MockorgerMockRecorder struct {
+	mock *Mockorger
+}
+
+// NewMockorger creates a new mock instance.
+func NewMockorger(ctrl *gomock.Controller) *Mockorger {
+	mock := &Mockorger{ctrl: ctrl}
+	mock.recorder = &MockorgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockorger) EXPECT() *MockorgerMockRecorder {
+	return m.recorder
+}
+
+// ListRetentions mocks base method.
+func (m *Mockorger) ListRetentions(ctx context.Context) ([]*message.OrgRetention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRetentions", ctx)
+	ret0, _ := ret[0].([]*message.OrgRetention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRetentions indicates an expected call of ListRetentions.
+func (mr *MockorgerMockRecorder) ListRetentions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRetentions", reflect.TypeOf((*Mockorger)(nil).ListRetentions), ctx)
+}
+
+// Mockdatapointer is a mock of datapointer interface.
+type Mockdatapointer struct {
+	ctrl     *gomock.Controller
+	recorder *MockdatapointerMockRecorder
+	isgomock struct{}
+}
+
+// MockdatapointerMockRecorder is the mock recorder for Mockdatapointer.
+type MockdatapointerMockRecorder struct {
+	mock *Mockdatapointer
+}
+
+// NewMockdatapointer creates a new mock instance.
+func NewMockdatapointer(ctrl *gomock.Controller) *Mockdatapointer {
+	mock := &Mockdatapointer{ctrl: ctrl}
+	mock.recorder = &MockdatapointerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdatapointer) EXPECT() *MockdatapointerMockRecorder {
+	return m.recorder
+}
+
+// Purge mocks base method.
+func (m *Mockdatapointer) Purge(ctx context.Context, orgID string, before time.Time, limit int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, orgID, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockdatapointerMockRecorder) Purge(ctx, orgID, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*Mockdatapointer)(nil).Purge), ctx, orgID, before, limit)
+}
+
+// Mockeventer is a mock of eventer interface.
+type Mockeventer struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventerMockRecorder
+	isgomock struct{}
+}
+
+// MockeventerMockRecorder is the mock recorder for Mockeventer.
+type MockeventerMockRecorder struct {
+	mock *Mockeventer
+}
+
+// NewMockeventer creates a new mock instance.
+func NewMockeventer(ctrl *gomock.Controller) *Mockeventer {
+	mock := &Mockeventer{ctrl: ctrl}
+	mock.recorder = &MockeventerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockeventer) EXPECT() *MockeventerMockRecorder {
+	return m.recorder
+}
+
+// Purge mocks base method.
+func (m *Mockeventer) Purge(ctx context.Context, orgID string, before time.Time, limit int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, orgID, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockeventerMockRecorder) Purge(ctx, orgID, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*Mockeventer)(nil).Purge), ctx, orgID, before, limit)
+}
+
+// Mockcommander is a mock of commander interface.
+type Mockcommander struct {
+	ctrl     *gomock.Controller
+	recorder *MockcommanderMockRecorder
+	isgomock struct{}
+}
+
+// MockcommanderMockRecorder is the mock recorder for Mockcommander.
+type MockcommanderMockRecorder struct {
+	mock *Mockcommander
+}
+
+// NewMockcommander creates a new mock instance.
+func NewMockcommander(ctrl *gomock.Controller) *Mockcommander {
+	mock := &Mockcommander{ctrl: ctrl}
+	mock.recorder = &MockcommanderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcommander) EXPECT() *MockcommanderMockRecorder {
+	return m.recorder
+}
+
+// Purge mocks base method.
+func (m *Mockcommander) Purge(ctx context.Context, orgID string, before time.Time, limit int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, orgID, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockcommanderMockRecorder) Purge(ctx, orgID, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*Mockcommander)(nil).Purge), ctx, orgID, before, limit)
+}
+
+// Mockalerter is a mock of alerter interface.
+type Mockalerter struct {
+	ctrl     *gomock.Controller
+	recorder *MockalerterMockRecorder
+	isgomock struct{}
+}
+
+// MockalerterMockRecorder is the mock recorder for Mockalerter.
+type MockalerterMockRecorder struct {
+	mock *Mockalerter
+}
+
+// NewMockalerter creates a new mock instance.
+func NewMockalerter(ctrl *gomock.Controller) *Mockalerter {
+	mock := &Mockalerter{ctrl: ctrl}
+	mock.recorder = &MockalerterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockalerter) EXPECT() *MockalerterMockRecorder {
+	return m.recorder
+}
+
+// Purge mocks base method.
+func (m *Mockalerter) Purge(ctx context.Context, orgID string, before time.Time, limit int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, orgID, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockalerterMockRecorder) Purge(ctx, orgID, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*Mockalerter)(nil).Purge), ctx, orgID, before, limit)
+}
+
+// PurgeDigests mocks base method.
+func (m *Mockalerter) PurgeDigests(ctx context.Context, orgID string, before time.Time, limit int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDigests", ctx, orgID, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDigests indicates an expected call of PurgeDigests.
+func (mr *MockalerterMockRecorder) PurgeDigests(ctx, orgID, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDigests", reflect.TypeOf((*Mockalerter)(nil).PurgeDigests), ctx, orgID, before, limit)
+}
+
+// PurgeLifecycles mocks base method.
+func (m *Mockalerter) PurgeLifecycles(ctx context.Context, orgID string, before time.Time, limit int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeLifecycles", ctx, orgID, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeLifecycles indicates an expected call of PurgeLifecycles.
+func (mr *MockalerterMockRecorder) PurgeLifecycles(ctx, orgID, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeLifecycles", reflect.TypeOf((*Mockalerter)(nil).PurgeLifecycles), ctx, orgID, before, limit)
+}
+
+// Mockuserer is a mock of userer interface.
+type Mockuserer struct {
+	ctrl     *gomock.Controller
+	recorder *MockusererMockRecorder
+	isgomock struct{}
+}
+
+// MockusererMockRecorder is the mock recorder for Mockuserer.
+type MockusererMockRecorder struct {
+	mock *Mockuserer
+}
+
+// NewMockuserer creates a new mock instance.
+func NewMockuserer(ctrl *gomock.Controller) *Mockuserer {
+	mock := &Mockuserer{ctrl: ctrl}
+	mock.recorder = &MockusererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockuserer) EXPECT() *MockusererMockRecorder {
+	return m.recorder
+}
+
+// PurgeSessions mocks base method.
+func (m *Mockuserer) PurgeSessions(ctx context.Context, orgID string, before time.Time, limit int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeSessions", ctx, orgID, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeSessions indicates an expected call of PurgeSessions.
+func (mr *MockusererMockRecorder) PurgeSessions(ctx, orgID, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeSessions", reflect.TypeOf((*Mockuserer)(nil).PurgeSessions), ctx, orgID, before, limit)
+}
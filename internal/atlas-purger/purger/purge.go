@@ -0,0 +1,99 @@
+package purger
+
+import (
+	"context"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/metric"
+)
+
+// purgeFunc defines the signature of a DAO's batch purge method.
+type purgeFunc func(ctx context.Context, orgID string, before time.Time,
+	limit int32) (int64, error)
+
+// purgeOrgs purges expired data points, events, and alerts for all orgs based
+// on each org's retention policy, falling back to the platform defaults.
+// Commands follow the retention of data points, and alert digests and resolved
+// alert lifecycles follow the retention of alerts. Expired and revoked user
+// sessions are purged once past the platform session retention.
+func (pur *Purger) purgeOrgs() {
+	alog.Info("purgeOrgs starting purge")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	rets, err := pur.orgDAO.ListRetentions(ctx)
+	cancel()
+	if err != nil {
+		metric.Incr("error", map[string]string{metric.TagFunc: "listretentions"})
+		alog.Errorf("purgeOrgs pur.orgDAO.ListRetentions: %v", err)
+
+		return
+	}
+
+	var dpTotal, eventTotal, alertTotal, sessTotal int64
+	for _, ret := range rets {
+		dpTotal += pur.purgeTable(ret.GetOrgId(), "data_points",
+			orDefault(ret.GetDataPointDays(), pur.dpDays), pur.dpDAO.Purge)
+		dpTotal += pur.purgeTable(ret.GetOrgId(), "commands",
+			orDefault(ret.GetDataPointDays(), pur.dpDays), pur.cmdDAO.Purge)
+		eventTotal += pur.purgeTable(ret.GetOrgId(), "events",
+			orDefault(ret.GetEventDays(), pur.eventDays), pur.evDAO.Purge)
+		alertTotal += pur.purgeTable(ret.GetOrgId(), "alerts",
+			orDefault(ret.GetAlertDays(), pur.alertDays), pur.aleDAO.Purge)
+		alertTotal += pur.purgeTable(ret.GetOrgId(), "alert_digests",
+			orDefault(ret.GetAlertDays(), pur.alertDays),
+			pur.aleDAO.PurgeDigests)
+		alertTotal += pur.purgeTable(ret.GetOrgId(), "alert_lifecycles",
+			orDefault(ret.GetAlertDays(), pur.alertDays),
+			pur.aleDAO.PurgeLifecycles)
+		sessTotal += pur.purgeTable(ret.GetOrgId(), "user_sessions",
+			pur.sessionDays, pur.userDAO.PurgeSessions)
+	}
+
+	alog.Infof("purgeOrgs purged %v orgs, data points, events, alerts, "+
+		"sessions: %v, %v, %v, %v", len(rets), dpTotal, eventTotal, alertTotal,
+		sessTotal)
+}
+
+// purgeTable purges expired rows from a table for an org in batches, and
+// returns the number of rows deleted.
+func (pur *Purger) purgeTable(
+	orgID, table string, days int32, purge purgeFunc,
+) int64 {
+	logger := alog.WithField("orgID", orgID).WithField("table", table)
+	before := time.Now().UTC().Add(-time.Duration(days) * 24 * time.Hour)
+
+	var total int64
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		count, err := purge(ctx, orgID, before, pur.batchSize)
+		cancel()
+		if err != nil {
+			metric.Incr("error", map[string]string{
+				metric.TagFunc: "purge", "table": table,
+			})
+			logger.Errorf("purgeTable purge: %v", err)
+
+			break
+		}
+
+		total += count
+		if count < int64(pur.batchSize) {
+			break
+		}
+	}
+
+	metric.Count("purged", int(total), map[string]string{"table": table})
+	logger.Debugf("purgeTable purged %v rows before %v", total, before)
+
+	return total
+}
+
+// orDefault returns days if set, otherwise the default days.
+func orDefault(days, defDays int32) int32 {
+	if days > 0 {
+		return days
+	}
+
+	return defDays
+}
@@ -0,0 +1,173 @@
+//go:build !integration
+
+package purger
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/thingspect/atlas/pkg/consterr"
+	"github.com/thingspect/atlas/proto/go/message"
+	"go.uber.org/mock/gomock"
+)
+
+const errTestProc consterr.Error = "purger: test processor error"
+
+// daysAgo returns a gomock.Matcher for a time the provided number of days in
+// the past.
+func daysAgo(days int32) gomock.Matcher {
+	return gomock.Cond(func(x time.Time) bool {
+		exp := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+
+		return x.Before(exp.Add(time.Minute)) && x.After(exp.Add(-time.Minute))
+	})
+}
+
+func TestPurgeOrgs(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.NewV7().String()
+
+	tests := []struct {
+		inpRet       *message.OrgRetention
+		inpDPCounts  []int64
+		inpDPDays    int32
+		inpEventDays int32
+		inpAlertDays int32
+	}{
+		{&message.OrgRetention{OrgId: orgID}, []int64{0}, 365, 365, 90},
+		{&message.OrgRetention{
+			OrgId: orgID, DataPointDays: 30, EventDays: 60, AlertDays: 7,
+		}, []int64{10, 10, 3}, 30, 60, 7},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can purge %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().ListRetentions(gomock.Any()).
+				Return([]*message.OrgRetention{test.inpRet}, nil).Times(1)
+
+			datapointer := NewMockdatapointer(ctrl)
+			for _, count := range test.inpDPCounts {
+				datapointer.EXPECT().Purge(gomock.Any(), orgID,
+					daysAgo(test.inpDPDays), int32(10)).Return(count, nil).
+					Times(1)
+			}
+
+			commander := NewMockcommander(ctrl)
+			commander.EXPECT().Purge(gomock.Any(), orgID,
+				daysAgo(test.inpDPDays), int32(10)).Return(int64(0), nil).
+				Times(1)
+
+			eventer := NewMockeventer(ctrl)
+			eventer.EXPECT().Purge(gomock.Any(), orgID,
+				daysAgo(test.inpEventDays), int32(10)).Return(int64(0), nil).
+				Times(1)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().Purge(gomock.Any(), orgID,
+				daysAgo(test.inpAlertDays), int32(10)).Return(int64(0), nil).
+				Times(1)
+			alerter.EXPECT().PurgeDigests(gomock.Any(), orgID,
+				daysAgo(test.inpAlertDays), int32(10)).Return(int64(0), nil).
+				Times(1)
+			alerter.EXPECT().PurgeLifecycles(gomock.Any(), orgID,
+				daysAgo(test.inpAlertDays), int32(10)).Return(int64(0), nil).
+				Times(1)
+
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().PurgeSessions(gomock.Any(), orgID, daysAgo(30),
+				int32(10)).Return(int64(0), nil).Times(1)
+
+			pur := Purger{
+				orgDAO:  orger,
+				dpDAO:   datapointer,
+				evDAO:   eventer,
+				cmdDAO:  commander,
+				aleDAO:  alerter,
+				userDAO: userer,
+
+				dpDays:      365,
+				eventDays:   365,
+				alertDays:   90,
+				sessionDays: 30,
+				batchSize:   10,
+			}
+			pur.purgeOrgs()
+		})
+	}
+}
+
+func TestPurgeOrgsError(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.NewV7().String()
+
+	tests := []struct {
+		inpListErr    error
+		inpPurgeErr   error
+		inpPurgeTimes int
+	}{
+		{errTestProc, nil, 0},
+		{nil, errTestProc, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cannot purge %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			orger := NewMockorger(ctrl)
+			orger.EXPECT().ListRetentions(gomock.Any()).
+				Return([]*message.OrgRetention{{OrgId: orgID}},
+					test.inpListErr).Times(1)
+
+			// A failed batch stops purging of that table only.
+			datapointer := NewMockdatapointer(ctrl)
+			datapointer.EXPECT().Purge(gomock.Any(), orgID, gomock.Any(),
+				int32(10)).Return(int64(0), test.inpPurgeErr).
+				Times(test.inpPurgeTimes)
+
+			commander := NewMockcommander(ctrl)
+			commander.EXPECT().Purge(gomock.Any(), orgID, gomock.Any(),
+				int32(10)).Return(int64(0), nil).Times(test.inpPurgeTimes)
+
+			eventer := NewMockeventer(ctrl)
+			eventer.EXPECT().Purge(gomock.Any(), orgID, gomock.Any(),
+				int32(10)).Return(int64(0), nil).Times(test.inpPurgeTimes)
+
+			alerter := NewMockalerter(ctrl)
+			alerter.EXPECT().Purge(gomock.Any(), orgID, gomock.Any(),
+				int32(10)).Return(int64(0), nil).Times(test.inpPurgeTimes)
+			alerter.EXPECT().PurgeDigests(gomock.Any(), orgID, gomock.Any(),
+				int32(10)).Return(int64(0), nil).Times(test.inpPurgeTimes)
+			alerter.EXPECT().PurgeLifecycles(gomock.Any(), orgID, gomock.Any(),
+				int32(10)).Return(int64(0), nil).Times(test.inpPurgeTimes)
+
+			userer := NewMockuserer(ctrl)
+			userer.EXPECT().PurgeSessions(gomock.Any(), orgID, gomock.Any(),
+				int32(10)).Return(int64(0), nil).Times(test.inpPurgeTimes)
+
+			pur := Purger{
+				orgDAO:  orger,
+				dpDAO:   datapointer,
+				evDAO:   eventer,
+				cmdDAO:  commander,
+				aleDAO:  alerter,
+				userDAO: userer,
+
+				dpDays:      365,
+				eventDays:   365,
+				alertDays:   90,
+				sessionDays: 30,
+				batchSize:   10,
+			}
+			pur.purgeOrgs()
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Package purger provides functions used to run the Purger service.
+package purger
+
+//go:generate mockgen -source purger.go -destination mock_orger_test.go -package purger
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-purger/config"
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/consterr"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/alert"
+	"github.com/thingspect/atlas/pkg/dao/command"
+	"github.com/thingspect/atlas/pkg/dao/datapoint"
+	"github.com/thingspect/atlas/pkg/dao/event"
+	"github.com/thingspect/atlas/pkg/dao/org"
+	"github.com/thingspect/atlas/pkg/dao/user"
+	"github.com/thingspect/atlas/proto/go/message"
+)
+
+// ServiceName provides consistent naming, including logs and metrics.
+const ServiceName = "purger"
+
+// errRetentionDays is returned due to a retention that would purge all rows.
+const errRetentionDays consterr.Error = "retention days must be positive"
+
+// orger defines the methods provided by an org.DAO.
+type orger interface {
+	ListRetentions(ctx context.Context) ([]*message.OrgRetention, error)
+}
+
+// datapointer defines the methods provided by a datapoint.DAO.
+type datapointer interface {
+	Purge(ctx context.Context, orgID string, before time.Time,
+		limit int32) (int64, error)
+}
+
+// eventer defines the methods provided by an event.DAO.
+type eventer interface {
+	Purge(ctx context.Context, orgID string, before time.Time,
+		limit int32) (int64, error)
+}
+
+// commander defines the methods provided by a command.DAO.
+type commander interface {
+	Purge(ctx context.Context, orgID string, before time.Time,
+		limit int32) (int64, error)
+}
+
+// alerter defines the methods provided by an alert.DAO.
+type alerter interface {
+	Purge(ctx context.Context, orgID string, before time.Time,
+		limit int32) (int64, error)
+	PurgeDigests(ctx context.Context, orgID string, before time.Time,
+		limit int32) (int64, error)
+	PurgeLifecycles(ctx context.Context, orgID string, before time.Time,
+		limit int32) (int64, error)
+}
+
+// userer defines the methods provided by a user.DAO.
+type userer interface {
+	PurgeSessions(ctx context.Context, orgID string, before time.Time,
+		limit int32) (int64, error)
+}
+
+// Purger holds references to the database connections and retention defaults.
+type Purger struct {
+	orgDAO  orger
+	dpDAO   datapointer
+	evDAO   eventer
+	cmdDAO  commander
+	aleDAO  alerter
+	userDAO userer
+
+	dpDays      int32
+	eventDays   int32
+	alertDays   int32
+	sessionDays int32
+	batchSize   int32
+}
+
+// New builds a new Purger and returns a reference to it and an error value.
+func New(cfg *config.Config) (*Purger, error) {
+	// Validate Config.
+	if cfg.DataPointDays <= 0 || cfg.EventDays <= 0 || cfg.AlertDays <= 0 ||
+		cfg.SessionDays <= 0 {
+		return nil, errRetentionDays
+	}
+
+	// Set up database connection.
+	pgRW, err := dao.NewPgDB(cfg.PgRwURI)
+	if err != nil {
+		return nil, err
+	}
+
+	pgRO, err := dao.NewPgDB(cfg.PgRoURI)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Purger{
+		orgDAO:  org.NewDAO(pgRW, pgRO),
+		dpDAO:   datapoint.NewDAO(pgRW, pgRO),
+		evDAO:   event.NewDAO(pgRW, pgRO),
+		cmdDAO:  command.NewDAO(pgRW, pgRO),
+		aleDAO:  alert.NewDAO(pgRW, pgRO),
+		userDAO: user.NewDAO(pgRW, pgRO),
+
+		dpDays:      int32(cfg.DataPointDays),
+		eventDays:   int32(cfg.EventDays),
+		alertDays:   int32(cfg.AlertDays),
+		sessionDays: int32(cfg.SessionDays),
+		batchSize:   int32(cfg.BatchSize),
+	}, nil
+}
+
+// Serve starts the purger, which runs immediately and then once per interval.
+func (pur *Purger) Serve(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			pur.purgeOrgs()
+			<-ticker.C
+		}
+	}()
+
+	// Handle graceful shutdown.
+	exitChan := make(chan os.Signal, 1)
+	signal.Notify(exitChan, syscall.SIGINT, syscall.SIGTERM)
+	<-exitChan
+
+	alog.Info("Serve received signal, exiting")
+}
@@ -0,0 +1,42 @@
+//go:build !integration
+
+package purger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/internal/atlas-purger/config"
+)
+
+func TestNewError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpDPDays    int
+		inpEventDays int
+		inpAlertDays int
+		inpSessDays  int
+	}{
+		{0, 365, 90, 30},
+		{365, 0, 90, 30},
+		{365, 365, 0, 30},
+		{365, 365, 90, 0},
+		{-1, 365, 90, 30},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cannot build %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			pur, err := New(&config.Config{
+				DataPointDays: test.inpDPDays, EventDays: test.inpEventDays,
+				AlertDays: test.inpAlertDays, SessionDays: test.inpSessDays,
+			})
+			t.Logf("pur, err: %+v, %v", pur, err)
+			require.Nil(t, pur)
+			require.Equal(t, errRetentionDays, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+//go:build !unit
+
+package test
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thingspect/atlas/internal/atlas-purger/config"
+	"github.com/thingspect/atlas/internal/atlas-purger/purger"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/datapoint"
+	"github.com/thingspect/atlas/pkg/dao/org"
+	testconfig "github.com/thingspect/atlas/pkg/test/config"
+)
+
+var (
+	globalOrgDAO *org.DAO
+	globalDPDAO  *datapoint.DAO
+)
+
+func TestMain(m *testing.M) {
+	// Set up Config.
+	testConfig := testconfig.New()
+	cfg := config.New()
+	cfg.PgRwURI = testConfig.PgURI
+	cfg.PgRoURI = testConfig.PgURI
+	cfg.BatchSize = 2
+	cfg.Interval = time.Second
+
+	// Set up Purger.
+	pur, err := purger.New(cfg)
+	if err != nil {
+		log.Fatalf("TestMain purger.New: %v", err)
+	}
+
+	// Serve purges.
+	go pur.Serve(cfg.Interval)
+
+	// Set up database connection.
+	pg, err := dao.NewPgDB(cfg.PgRwURI)
+	if err != nil {
+		log.Fatalf("TestMain dao.NewPgDB: %v", err)
+	}
+	globalOrgDAO = org.NewDAO(pg, pg)
+	globalDPDAO = datapoint.NewDAO(pg, pg)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,64 @@
+//go:build !unit
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testTimeout = 8 * time.Second
+
+func TestPurgeOrgs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("pur"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	err = globalOrgDAO.UpdateRetention(ctx, &message.OrgRetention{
+		OrgId: createOrg.GetId(), DataPointDays: 1,
+	})
+	t.Logf("err: %v", err)
+	require.NoError(t, err)
+
+	uniqID := "pur-" + random.String(16)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	// Create expired data points, and one within the retention policy.
+	for i := range 5 {
+		ts := now.Add(-time.Duration(i+2) * 24 * time.Hour)
+		if i == 0 {
+			ts = now.Add(-time.Hour)
+		}
+
+		err := globalDPDAO.Create(ctx, &common.DataPoint{
+			UniqId: uniqID, Attr: "pur-motion",
+			ValOneof: &common.DataPoint_IntVal{IntVal: int32(i)},
+			Ts:       timestamppb.New(ts), TraceId: uuid.NewV7().String(),
+		}, createOrg.GetId())
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	}
+
+	// Wait for the next purge interval.
+	time.Sleep(3 * time.Second)
+
+	listPoints, err := globalDPDAO.List(ctx, createOrg.GetId(), uniqID, "", "",
+		now, now.Add(-30*24*time.Hour))
+	t.Logf("listPoints, err: %+v, %v", listPoints, err)
+	require.NoError(t, err)
+	require.Len(t, listPoints, 1)
+	require.Equal(t, int32(0), listPoints[0].GetIntVal())
+}
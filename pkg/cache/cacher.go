@@ -43,6 +43,15 @@ type Cacher[V Cacheable] interface {
 	//
 	// Incr is not supported by memory caches.
 	Incr(ctx context.Context, key string) (int64, error)
+	// IncrTTL increments an int64 value at key by one, as Incr does. If the
+	// key does not exist, the value is set to 1 with expiration, atomically.
+	// Expiration is not modified for existing keys.
+	//
+	// IncrTTL is not supported by memory caches.
+	IncrTTL(ctx context.Context, key string, exp time.Duration) (int64, error)
+	// GetDel retrieves a value by key and removes the key, atomically. If the
+	// key does not exist, ErrNotFound is returned.
+	GetDel(ctx context.Context, key string) (V, error)
 	// Del removes the specified key. A key is ignored if it does not exist.
 	Del(ctx context.Context, key string) error
 	// Close closes the Cacher, releasing any open resources.
@@ -85,6 +85,24 @@ func (h *heapCache[V]) Incr(_ context.Context, _ string) (int64, error) {
 	panic("unimplemented")
 }
 
+// IncrTTL is not supported.
+func (h *heapCache[V]) IncrTTL(_ context.Context, _ string, _ time.Duration) (
+	int64, error,
+) {
+	panic("unimplemented")
+}
+
+// GetDel retrieves a value by key and removes the key, atomically. If the key
+// does not exist, ErrNotFound is returned.
+func (h *heapCache[V]) GetDel(_ context.Context, key string) (V, error) {
+	item, ok := h.cache.Invalidate(key)
+	if !ok {
+		return *new(V), ErrNotFound
+	}
+
+	return item, nil
+}
+
 // Del removes the specified key. A key is ignored if it does not exist.
 func (h *heapCache[V]) Del(_ context.Context, key string) error {
 	_, _ = h.cache.Invalidate(key)
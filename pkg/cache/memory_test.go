@@ -222,6 +222,25 @@ func TestMemoryIncrInt64(t *testing.T) {
 	}
 }
 
+func TestMemoryIncrTTLInt64(t *testing.T) {
+	t.Parallel()
+
+	mtx := NewMutex[int64]()
+	heap := NewHeap[int64]()
+
+	key := "testMemoryIncrTTLInt64-" + random.String(10)
+
+	for _, mem := range []Cacher[int64]{mtx, heap} {
+		t.Run(fmt.Sprintf("Can not incr %+v", mem), func(t *testing.T) {
+			t.Parallel()
+
+			require.PanicsWithValue(t, "unimplemented", func() {
+				_, _ = mem.IncrTTL(t.Context(), key, time.Minute)
+			})
+		})
+	}
+}
+
 func TestMemoryDelString(t *testing.T) {
 	t.Parallel()
 
@@ -254,6 +273,39 @@ func TestMemoryDelString(t *testing.T) {
 	}
 }
 
+func TestMemoryGetDelString(t *testing.T) {
+	t.Parallel()
+
+	mtx := NewMutex[string]()
+	heap := NewHeap[string]()
+
+	key := "testMemoryGetDelString-" + random.String(10)
+	val := random.String(10)
+
+	for _, mem := range []Cacher[string]{mtx, heap} {
+		t.Run(fmt.Sprintf("Can get del %+v", mem), func(t *testing.T) {
+			t.Parallel()
+
+			require.NoError(t, mem.Set(t.Context(), key, val))
+
+			res, err := mem.GetDel(t.Context(), key)
+			t.Logf("res, err: %v, %v", res, err)
+			require.Equal(t, val, res)
+			require.NoError(t, err)
+
+			res, err = mem.GetDel(t.Context(), key)
+			t.Logf("res, err: %v, %v", res, err)
+			require.Empty(t, res)
+			require.Equal(t, ErrNotFound, err)
+
+			res, err = mem.Get(t.Context(), key)
+			t.Logf("res, err: %v, %v", res, err)
+			require.Empty(t, res)
+			require.Equal(t, ErrNotFound, err)
+		})
+	}
+}
+
 func TestMemoryClose(t *testing.T) {
 	t.Parallel()
 
@@ -84,6 +84,21 @@ func (mr *MockCacherMockRecorder[V]) Get(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCacher[V])(nil).Get), ctx, key)
 }
 
+// GetDel mocks base method.
+func (m *MockCacher[V]) GetDel(ctx context.Context, key string) (V, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDel", ctx, key)
+	ret0, _ := ret[0].(V)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDel indicates an expected call of GetDel.
+func (mr *MockCacherMockRecorder[V]) GetDel(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDel", reflect.TypeOf((*MockCacher[V])(nil).GetDel), ctx, key)
+}
+
 // Incr mocks base method.
 func (m *MockCacher[V]) Incr(ctx context.Context, key string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +114,21 @@ func (mr *MockCacherMockRecorder[V]) Incr(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockCacher[V])(nil).Incr), ctx, key)
 }
 
+// IncrTTL mocks base method.
+func (m *MockCacher[V]) IncrTTL(ctx context.Context, key string, exp time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrTTL", ctx, key, exp)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrTTL indicates an expected call of IncrTTL.
+func (mr *MockCacherMockRecorder[V]) IncrTTL(ctx, key, exp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrTTL", reflect.TypeOf((*MockCacher[V])(nil).IncrTTL), ctx, key, exp)
+}
+
 // Set mocks base method.
 func (m *MockCacher[V]) Set(ctx context.Context, key string, value V) error {
 	m.ctrl.T.Helper()
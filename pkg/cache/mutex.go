@@ -78,6 +78,24 @@ func (m *mutexCache[V]) Incr(_ context.Context, _ string) (int64, error) {
 	panic("unimplemented")
 }
 
+// IncrTTL is not supported.
+func (m *mutexCache[V]) IncrTTL(_ context.Context, _ string, _ time.Duration) (
+	int64, error,
+) {
+	panic("unimplemented")
+}
+
+// GetDel retrieves a value by key and removes the key, atomically. If the key
+// does not exist, ErrNotFound is returned.
+func (m *mutexCache[V]) GetDel(_ context.Context, key string) (V, error) {
+	item, ok := m.cache.GetAndDelete(key)
+	if !ok || item == nil {
+		return *new(V), ErrNotFound
+	}
+
+	return item.Value(), nil
+}
+
 // Del removes the specified key. A key is ignored if it does not exist.
 func (m *mutexCache[V]) Del(_ context.Context, key string) error {
 	m.cache.Delete(key)
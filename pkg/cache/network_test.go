@@ -398,6 +398,50 @@ func TestNetworkIncrInt64(t *testing.T) {
 	}
 }
 
+func TestNetworkIncrTTLInt64(t *testing.T) {
+	t.Parallel()
+
+	testConfig := config.New()
+
+	redis, err := NewRedis[int64](testConfig.RedisHost + ":6379")
+	t.Logf("redis, err: %+v, %v", redis, err)
+	require.NoError(t, err)
+
+	valkey, err := NewValkey[int64](testConfig.ValkeyHost + ":" +
+		testConfig.ValkeyPort)
+	t.Logf("valkey, err: %+v, %v", valkey, err)
+	require.NoError(t, err)
+
+	for _, net := range []Cacher[int64]{redis, valkey} {
+		t.Run(fmt.Sprintf("Can incr with TTL %+v", net), func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			key := "testNetworkIncrTTLInt64-" + random.String(10)
+
+			res, err := net.IncrTTL(ctx, key, 500*time.Millisecond)
+			t.Logf("res, err: %v, %v", res, err)
+			require.Equal(t, int64(1), res)
+			require.NoError(t, err)
+
+			// Increments do not extend the expiration.
+			time.Sleep(300 * time.Millisecond)
+			res, err = net.IncrTTL(ctx, key, time.Minute)
+			t.Logf("res, err: %v, %v", res, err)
+			require.Equal(t, int64(2), res)
+			require.NoError(t, err)
+
+			time.Sleep(300 * time.Millisecond)
+			res, err = net.Get(ctx, key)
+			t.Logf("res, err: %v, %v", res, err)
+			require.Empty(t, res)
+			require.Equal(t, ErrNotFound, err)
+		})
+	}
+}
+
 func TestNetworkIncrString(t *testing.T) {
 	t.Parallel()
 
@@ -484,3 +528,47 @@ func TestNetworkDelString(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkGetDelString(t *testing.T) {
+	t.Parallel()
+
+	testConfig := config.New()
+
+	redis, err := NewRedis[string](testConfig.RedisHost + ":6379")
+	t.Logf("redis, err: %+v, %v", redis, err)
+	require.NoError(t, err)
+
+	valkey, err := NewValkey[string](testConfig.ValkeyHost + ":" +
+		testConfig.ValkeyPort)
+	t.Logf("valkey, err: %+v, %v", valkey, err)
+	require.NoError(t, err)
+
+	key := "testNetworkGetDelString-" + random.String(10)
+	val := random.String(10)
+
+	for _, net := range []Cacher[string]{redis, valkey} {
+		t.Run(fmt.Sprintf("Can get del %+v", net), func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			require.NoError(t, net.Set(ctx, key, val))
+
+			res, err := net.GetDel(ctx, key)
+			t.Logf("res, err: %v, %v", res, err)
+			require.Equal(t, val, res)
+			require.NoError(t, err)
+
+			res, err = net.GetDel(ctx, key)
+			t.Logf("res, err: %v, %v", res, err)
+			require.Empty(t, res)
+			require.Equal(t, ErrNotFound, err)
+
+			res, err = net.Get(ctx, key)
+			t.Logf("res, err: %v, %v", res, err)
+			require.Empty(t, res)
+			require.Equal(t, ErrNotFound, err)
+		})
+	}
+}
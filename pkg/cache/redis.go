@@ -18,6 +18,17 @@ type redisCache[V Cacheable] struct {
 // Verify redisCache implements Cacher.
 var _ Cacher[string] = &redisCache[string]{}
 
+// incrTTLScript increments a key, and sets its expiration in milliseconds if
+// the key was created by the increment.
+const incrTTLScript = `local i = redis.call("INCR", KEYS[1])
+if i == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return i`
+
+// redisIncrTTL is the Redis script used by IncrTTL.
+var redisIncrTTL = redis.NewScript(incrTTLScript)
+
 // NewRedis builds and verifies a new Cacher and returns it and an error value.
 func NewRedis[V Cacheable](redisAddr string) (Cacher[V], error) {
 	client := redis.NewUniversalClient(&redis.UniversalOptions{
@@ -119,6 +130,39 @@ func (r *redisCache[V]) Incr(ctx context.Context, key string) (int64, error) {
 	return i, nil
 }
 
+// IncrTTL increments an int64 value at key by one. If the key does not exist,
+// the value is set to 1 with expiration. The incremented value is returned.
+func (r *redisCache[V]) IncrTTL(ctx context.Context, key string,
+	exp time.Duration,
+) (int64, error) {
+	i, err := redisIncrTTL.Run(ctx, r.client, []string{key},
+		exp.Milliseconds()).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	return i, nil
+}
+
+// GetDel retrieves a value by key and removes the key, atomically. If the key
+// does not exist, ErrNotFound is returned.
+func (r *redisCache[V]) GetDel(ctx context.Context, key string) (V, error) {
+	b, err := r.client.GetDel(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return *new(V), ErrNotFound
+	}
+	if err != nil {
+		return *new(V), err
+	}
+
+	var item V
+	if err = json.Unmarshal(b, &item); err != nil {
+		return *new(V), err
+	}
+
+	return item, nil
+}
+
 // Del removes the specified key. A key is ignored if it does not exist.
 func (r *redisCache[V]) Del(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"encoding/json/v2"
+	"strconv"
 	"time"
 
 	"github.com/valkey-io/valkey-go"
@@ -17,6 +18,9 @@ type valkeyCache[V Cacheable] struct {
 // Verify valkeyCache implements Cacher.
 var _ Cacher[string] = &valkeyCache[string]{}
 
+// valkeyIncrTTL is the Valkey script used by IncrTTL.
+var valkeyIncrTTL = valkey.NewLuaScript(incrTTLScript)
+
 // NewValkey builds and verifies a new Cacher and returns it and an error value.
 func NewValkey[V Cacheable](valkeyAddr string) (Cacher[V], error) {
 	client, err := valkey.NewClient(valkey.ClientOption{
@@ -128,6 +132,40 @@ func (v *valkeyCache[V]) Incr(ctx context.Context, key string) (int64, error) {
 	return i, nil
 }
 
+// IncrTTL increments an int64 value at key by one. If the key does not exist,
+// the value is set to 1 with expiration. The incremented value is returned.
+func (v *valkeyCache[V]) IncrTTL(ctx context.Context, key string,
+	exp time.Duration,
+) (int64, error) {
+	i, err := valkeyIncrTTL.Exec(ctx, v.client, []string{key},
+		[]string{strconv.FormatInt(exp.Milliseconds(), 10)}).AsInt64()
+	if err != nil {
+		return 0, err
+	}
+
+	return i, nil
+}
+
+// GetDel retrieves a value by key and removes the key, atomically. If the key
+// does not exist, ErrNotFound is returned.
+func (v *valkeyCache[V]) GetDel(ctx context.Context, key string) (V, error) {
+	b, err := v.client.Do(ctx,
+		v.client.B().Getdel().Key(key).Build()).AsBytes()
+	if valkey.IsValkeyNil(err) {
+		return *new(V), ErrNotFound
+	}
+	if err != nil {
+		return *new(V), err
+	}
+
+	var item V
+	if err = json.Unmarshal(b, &item); err != nil {
+		return *new(V), err
+	}
+
+	return item, nil
+}
+
 // Del removes the specified key. A key is ignored if it does not exist.
 func (v *valkeyCache[V]) Del(ctx context.Context, key string) error {
 	return v.client.Do(ctx, v.client.B().Del().Key(key).Build()).Error()
@@ -1,4 +1,5 @@
-package auth
+// Package crypt provides functions to encrypt and decrypt data.
+package crypt
 
 import (
 	"crypto/aes"
@@ -10,8 +11,8 @@ import (
 
 // Errors returned due to encryption or decryption failures.
 const (
-	ErrKeyLength consterr.Error = "auth: incorrect key length"
-	ErrMalformed consterr.Error = "auth: malformed ciphertext"
+	ErrKeyLength consterr.Error = "crypt: incorrect key length"
+	ErrMalformed consterr.Error = "crypt: malformed ciphertext"
 )
 
 // Encrypt encrypts data using 256-bit AES-GCM, providing authenticated
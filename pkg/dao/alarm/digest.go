@@ -0,0 +1,139 @@
+package alarm
+
+import (
+	"context"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const upsertDigest = `
+INSERT INTO alarm_digests (alarm_id, org_id, window_minutes, subject_template,
+body_template, created_at, updated_at)
+SELECT id, org_id, $4, $5, $6, $7, $7
+FROM alarms
+WHERE (id, org_id, rule_id) = ($1, $2, $3)
+ON CONFLICT (alarm_id) DO UPDATE
+SET window_minutes = EXCLUDED.window_minutes,
+subject_template = EXCLUDED.subject_template,
+body_template = EXCLUDED.body_template, updated_at = EXCLUDED.updated_at
+RETURNING created_at
+`
+
+// UpsertDigest creates or updates an alarm's digest.
+func (d *DAO) UpsertDigest(
+	ctx context.Context, digest *message.AlarmDigest,
+) (*message.AlarmDigest, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	digest.UpdatedAt = timestamppb.New(now)
+
+	var createdAt time.Time
+	if err := d.rw.QueryRowContext(ctx, upsertDigest, digest.GetAlarmId(),
+		digest.GetOrgId(), digest.GetRuleId(), digest.GetWindowMinutes(),
+		digest.GetSubjectTemplate(), digest.GetBodyTemplate(),
+		now).Scan(&createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	digest.CreatedAt = timestamppb.New(createdAt)
+
+	return digest, nil
+}
+
+const readDigest = `
+SELECT g.alarm_id, g.org_id, a.rule_id, g.window_minutes, g.subject_template,
+g.body_template, g.created_at, g.updated_at
+FROM alarm_digests g
+INNER JOIN alarms a ON g.alarm_id = a.id
+WHERE (g.alarm_id, g.org_id, a.rule_id) = ($1, $2, $3)
+`
+
+// ReadDigest retrieves an alarm's digest by alarm ID, org ID, and rule ID.
+func (d *DAO) ReadDigest(
+	ctx context.Context, alarmID, orgID, ruleID string,
+) (*message.AlarmDigest, error) {
+	digest := &message.AlarmDigest{}
+	var createdAt, updatedAt time.Time
+
+	if err := d.ro.QueryRowContext(ctx, readDigest, alarmID, orgID,
+		ruleID).Scan(&digest.AlarmId, &digest.OrgId, &digest.RuleId,
+		&digest.WindowMinutes, &digest.SubjectTemplate, &digest.BodyTemplate,
+		&createdAt, &updatedAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	digest.CreatedAt = timestamppb.New(createdAt)
+	digest.UpdatedAt = timestamppb.New(updatedAt)
+
+	return digest, nil
+}
+
+const deleteDigest = `
+DELETE FROM alarm_digests g
+USING alarms a
+WHERE g.alarm_id = a.id
+AND (g.alarm_id, g.org_id, a.rule_id) = ($1, $2, $3)
+RETURNING g.alarm_id
+`
+
+// DeleteDigest deletes an alarm's digest by alarm ID, org ID, and rule ID.
+func (d *DAO) DeleteDigest(
+	ctx context.Context, alarmID, orgID, ruleID string,
+) error {
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, deleteDigest, alarmID,
+		orgID, ruleID).Scan(&alarmID))
+}
+
+const listDigests = `
+SELECT g.alarm_id, g.org_id, a.rule_id, g.window_minutes, g.subject_template,
+g.body_template, g.created_at, g.updated_at
+FROM alarm_digests g
+INNER JOIN alarms a ON g.alarm_id = a.id
+WHERE g.org_id = $1
+AND g.alarm_id = ANY ($2::uuid[])
+`
+
+// ListDigests retrieves the digests of alarms by org ID and alarm IDs,
+// keyed by alarm ID. Alarms without a digest are absent.
+func (d *DAO) ListDigests(
+	ctx context.Context, orgID string, alarmIDs []string,
+) (map[string]*message.AlarmDigest, error) {
+	rows, err := d.ro.QueryContext(ctx, listDigests, orgID, alarmIDs)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ListDigests rows.Close: %v", err)
+		}
+	}()
+
+	digests := map[string]*message.AlarmDigest{}
+	for rows.Next() {
+		digest := &message.AlarmDigest{}
+		var createdAt, updatedAt time.Time
+
+		if err = rows.Scan(&digest.AlarmId, &digest.OrgId, &digest.RuleId,
+			&digest.WindowMinutes, &digest.SubjectTemplate,
+			&digest.BodyTemplate, &createdAt, &updatedAt); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		digest.CreatedAt = timestamppb.New(createdAt)
+		digest.UpdatedAt = timestamppb.New(updatedAt)
+		digests[digest.GetAlarmId()] = digest
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return digests, nil
+}
@@ -0,0 +1,228 @@
+//go:build !unit
+
+package alarm
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+)
+
+func TestUpsertReadDigest(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alarm"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alarm",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	t.Run("Upsert and read valid digest", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+			createOrg.GetId(), createRule.GetId()))
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.NoError(t, err)
+
+		createDigest, err := globalAlarmDAO.UpsertDigest(ctx,
+			&message.AlarmDigest{
+				AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+				RuleId:          createRule.GetId(),
+				WindowMinutes:   15,
+				SubjectTemplate: "dao-alarm-subj",
+				BodyTemplate:    "dao-alarm-body",
+			})
+		t.Logf("createDigest, err: %+v, %v", createDigest, err)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now(),
+			createDigest.GetCreatedAt().AsTime(), 2*time.Second)
+
+		updDigest, err := globalAlarmDAO.UpsertDigest(ctx,
+			&message.AlarmDigest{
+				AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+				RuleId:        createRule.GetId(),
+				WindowMinutes: 30,
+			})
+		t.Logf("updDigest, err: %+v, %v", updDigest, err)
+		require.NoError(t, err)
+		require.Equal(t, createDigest.GetCreatedAt().AsTime(),
+			updDigest.GetCreatedAt().AsTime())
+
+		readDigest, err := globalAlarmDAO.ReadDigest(ctx, createAlarm.GetId(),
+			createOrg.GetId(), createRule.GetId())
+		t.Logf("readDigest, err: %+v, %v", readDigest, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, updDigest, readDigest)
+	})
+
+	t.Run("Upsert digest by unknown alarm", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createDigest, err := globalAlarmDAO.UpsertDigest(ctx,
+			&message.AlarmDigest{
+				AlarmId: uuid.NewV7().String(), OrgId: createOrg.GetId(),
+				RuleId:        createRule.GetId(),
+				WindowMinutes: 15,
+			})
+		t.Logf("createDigest, err: %+v, %v", createDigest, err)
+		require.Nil(t, createDigest)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Upsert invalid digest", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+			createOrg.GetId(), createRule.GetId()))
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.NoError(t, err)
+
+		createDigest, err := globalAlarmDAO.UpsertDigest(ctx,
+			&message.AlarmDigest{
+				AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+				RuleId:        createRule.GetId(),
+				WindowMinutes: 15,
+				BodyTemplate:  "dao-alarm-" + random.String(4096),
+			})
+		t.Logf("createDigest, err: %+v, %v", createDigest, err)
+		require.Nil(t, createDigest)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+
+	t.Run("Read digest by unknown alarm", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readDigest, err := globalAlarmDAO.ReadDigest(ctx, uuid.NewV7().String(),
+			createOrg.GetId(), createRule.GetId())
+		t.Logf("readDigest, err: %+v, %v", readDigest, err)
+		require.Nil(t, readDigest)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestDeleteDigest(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alarm"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alarm",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+		createOrg.GetId(), createRule.GetId()))
+	t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+	require.NoError(t, err)
+
+	_, err = globalAlarmDAO.UpsertDigest(ctx, &message.AlarmDigest{
+		AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+		RuleId:        createRule.GetId(),
+		WindowMinutes: 15,
+	})
+	require.NoError(t, err)
+
+	err = globalAlarmDAO.DeleteDigest(ctx, createAlarm.GetId(),
+		createOrg.GetId(), createRule.GetId())
+	t.Logf("err: %v", err)
+	require.NoError(t, err)
+
+	err = globalAlarmDAO.DeleteDigest(ctx, createAlarm.GetId(),
+		createOrg.GetId(), createRule.GetId())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+}
+
+func TestListDigests(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alarm"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alarm",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	var alarmIDs []string
+	for range 3 {
+		createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+			createOrg.GetId(), createRule.GetId()))
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.NoError(t, err)
+		alarmIDs = append(alarmIDs, createAlarm.GetId())
+	}
+
+	// Leave the last alarm without a digest.
+	for _, alarmID := range alarmIDs[:2] {
+		_, err := globalAlarmDAO.UpsertDigest(ctx, &message.AlarmDigest{
+			AlarmId: alarmID, OrgId: createOrg.GetId(),
+			RuleId:        createRule.GetId(),
+			WindowMinutes: 15,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("List digests by valid alarm IDs", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listDigests, err := globalAlarmDAO.ListDigests(ctx, createOrg.GetId(),
+			alarmIDs)
+		t.Logf("listDigests, err: %+v, %v", listDigests, err)
+		require.NoError(t, err)
+		require.Len(t, listDigests, 2)
+		require.Equal(t, createRule.GetId(),
+			listDigests[alarmIDs[0]].GetRuleId())
+		require.NotContains(t, listDigests, alarmIDs[2])
+	})
+
+	t.Run("List digests by unknown org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listDigests, err := globalAlarmDAO.ListDigests(ctx,
+			uuid.NewV7().String(), alarmIDs)
+		t.Logf("listDigests, err: %+v, %v", listDigests, err)
+		require.NoError(t, err)
+		require.Empty(t, listDigests)
+	})
+}
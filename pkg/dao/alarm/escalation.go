@@ -0,0 +1,100 @@
+package alarm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const upsertEscalation = `
+INSERT INTO alarm_escalations (alarm_id, org_id, policy, created_at,
+updated_at)
+SELECT id, org_id, $4, $5, $5
+FROM alarms
+WHERE (id, org_id, rule_id) = ($1, $2, $3)
+ON CONFLICT (alarm_id) DO UPDATE
+SET policy = EXCLUDED.policy, updated_at = EXCLUDED.updated_at
+RETURNING created_at
+`
+
+// UpsertEscalation creates or replaces an alarm's escalation policy.
+func (d *DAO) UpsertEscalation(
+	ctx context.Context, esc *message.AlarmEscalation,
+) (*message.AlarmEscalation, error) {
+	// Only steps are stored in the policy, identifiers are columns.
+	policy, err := protojson.Marshal(&message.AlarmEscalation{
+		Steps: esc.GetSteps(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", dao.ErrInvalidFormat, err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	esc.UpdatedAt = timestamppb.New(now)
+
+	var createdAt time.Time
+	if err := d.rw.QueryRowContext(ctx, upsertEscalation, esc.GetAlarmId(),
+		esc.GetOrgId(), esc.GetRuleId(), policy, now).Scan(
+		&createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	esc.CreatedAt = timestamppb.New(createdAt)
+
+	return esc, nil
+}
+
+const readEscalation = `
+SELECT e.alarm_id, e.org_id, a.rule_id, e.policy, e.created_at, e.updated_at
+FROM alarm_escalations e
+INNER JOIN alarms a ON e.alarm_id = a.id
+WHERE (e.alarm_id, e.org_id, a.rule_id) = ($1, $2, $3)
+`
+
+// ReadEscalation retrieves an alarm's escalation policy by alarm ID, org ID,
+// and rule ID.
+func (d *DAO) ReadEscalation(
+	ctx context.Context, alarmID, orgID, ruleID string,
+) (*message.AlarmEscalation, error) {
+	esc := &message.AlarmEscalation{}
+	var policy []byte
+	var createdAt, updatedAt time.Time
+
+	if err := d.ro.QueryRowContext(ctx, readEscalation, alarmID, orgID,
+		ruleID).Scan(&esc.AlarmId, &esc.OrgId, &esc.RuleId, &policy,
+		&createdAt, &updatedAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	pol := &message.AlarmEscalation{}
+	if err := protojson.Unmarshal(policy, pol); err != nil {
+		return nil, err
+	}
+	esc.Steps = pol.GetSteps()
+	esc.CreatedAt = timestamppb.New(createdAt)
+	esc.UpdatedAt = timestamppb.New(updatedAt)
+
+	return esc, nil
+}
+
+const deleteEscalation = `
+DELETE FROM alarm_escalations e
+USING alarms a
+WHERE e.alarm_id = a.id
+AND (e.alarm_id, e.org_id, a.rule_id) = ($1, $2, $3)
+RETURNING e.alarm_id
+`
+
+// DeleteEscalation deletes an alarm's escalation policy by alarm ID, org ID,
+// and rule ID. Pending escalations of the alarm's alerts are abandoned.
+func (d *DAO) DeleteEscalation(
+	ctx context.Context, alarmID, orgID, ruleID string,
+) error {
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, deleteEscalation,
+		alarmID, orgID, ruleID).Scan(&alarmID))
+}
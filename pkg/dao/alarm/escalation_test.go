@@ -0,0 +1,148 @@
+//go:build !unit
+
+package alarm
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+func TestUpsertReadEscalation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alarm"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alarm",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	t.Run("Upsert and read valid escalation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+			createOrg.GetId(), createRule.GetId()))
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.NoError(t, err)
+
+		createEsc, err := globalAlarmDAO.UpsertEscalation(ctx,
+			&message.AlarmEscalation{
+				AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+				RuleId: createRule.GetId(), Steps: []*message.EscalationStep{
+					{DelayMinutes: 5, UserTags: []string{"dao-alarm-1"}},
+				},
+			})
+		t.Logf("createEsc, err: %+v, %v", createEsc, err)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now(), createEsc.GetCreatedAt().AsTime(),
+			2*time.Second)
+
+		updEsc, err := globalAlarmDAO.UpsertEscalation(ctx,
+			&message.AlarmEscalation{
+				AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+				RuleId: createRule.GetId(), Steps: []*message.EscalationStep{
+					{DelayMinutes: 5, UserTags: []string{"dao-alarm-1"}},
+					{
+						DelayMinutes: 10, UserTags: []string{"dao-alarm-2"},
+						Type: api.AlarmType_SMS,
+					},
+				},
+			})
+		t.Logf("updEsc, err: %+v, %v", updEsc, err)
+		require.NoError(t, err)
+		require.Equal(t, createEsc.GetCreatedAt().AsTime(),
+			updEsc.GetCreatedAt().AsTime())
+
+		readEsc, err := globalAlarmDAO.ReadEscalation(ctx, createAlarm.GetId(),
+			createOrg.GetId(), createRule.GetId())
+		t.Logf("readEsc, err: %+v, %v", readEsc, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, updEsc, readEsc)
+	})
+
+	t.Run("Upsert escalation by unknown alarm", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createEsc, err := globalAlarmDAO.UpsertEscalation(ctx,
+			&message.AlarmEscalation{
+				AlarmId: uuid.NewV7().String(), OrgId: createOrg.GetId(),
+				RuleId: createRule.GetId(), Steps: []*message.EscalationStep{
+					{DelayMinutes: 5, UserTags: []string{"dao-alarm-1"}},
+				},
+			})
+		t.Logf("createEsc, err: %+v, %v", createEsc, err)
+		require.Nil(t, createEsc)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Read escalation by unknown alarm", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readEsc, err := globalAlarmDAO.ReadEscalation(ctx,
+			uuid.NewV7().String(), createOrg.GetId(), createRule.GetId())
+		t.Logf("readEsc, err: %+v, %v", readEsc, err)
+		require.Nil(t, readEsc)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestDeleteEscalation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alarm"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alarm",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+		createOrg.GetId(), createRule.GetId()))
+	t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+	require.NoError(t, err)
+
+	_, err = globalAlarmDAO.UpsertEscalation(ctx, &message.AlarmEscalation{
+		AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+		RuleId: createRule.GetId(), Steps: []*message.EscalationStep{
+			{DelayMinutes: 5, UserTags: []string{"dao-alarm-1"}},
+		},
+	})
+	require.NoError(t, err)
+
+	err = globalAlarmDAO.DeleteEscalation(ctx, createAlarm.GetId(),
+		createOrg.GetId(), createRule.GetId())
+	t.Logf("err: %v", err)
+	require.NoError(t, err)
+
+	err = globalAlarmDAO.DeleteEscalation(ctx, createAlarm.GetId(),
+		createOrg.GetId(), createRule.GetId())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+}
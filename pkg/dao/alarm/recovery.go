@@ -0,0 +1,182 @@
+package alarm
+
+import (
+	"context"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const upsertRecovery = `
+INSERT INTO alarm_recoveries (alarm_id, org_id, subject_template,
+body_template, created_at, updated_at)
+SELECT id, org_id, $4, $5, $6, $6
+FROM alarms
+WHERE (id, org_id, rule_id) = ($1, $2, $3)
+ON CONFLICT (alarm_id) DO UPDATE
+SET subject_template = EXCLUDED.subject_template,
+body_template = EXCLUDED.body_template, updated_at = EXCLUDED.updated_at
+RETURNING created_at
+`
+
+// UpsertRecovery creates or updates an alarm's recovery.
+func (d *DAO) UpsertRecovery(
+	ctx context.Context, rec *message.AlarmRecovery,
+) (*message.AlarmRecovery, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	rec.UpdatedAt = timestamppb.New(now)
+
+	var createdAt time.Time
+	if err := d.rw.QueryRowContext(ctx, upsertRecovery, rec.GetAlarmId(),
+		rec.GetOrgId(), rec.GetRuleId(), rec.GetSubjectTemplate(),
+		rec.GetBodyTemplate(), now).Scan(&createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	rec.CreatedAt = timestamppb.New(createdAt)
+
+	return rec, nil
+}
+
+const readRecovery = `
+SELECT r.alarm_id, r.org_id, a.rule_id, r.subject_template, r.body_template,
+r.created_at, r.updated_at
+FROM alarm_recoveries r
+INNER JOIN alarms a ON r.alarm_id = a.id
+WHERE (r.alarm_id, r.org_id, a.rule_id) = ($1, $2, $3)
+`
+
+// ReadRecovery retrieves an alarm's recovery by alarm ID, org ID, and rule ID.
+func (d *DAO) ReadRecovery(
+	ctx context.Context, alarmID, orgID, ruleID string,
+) (*message.AlarmRecovery, error) {
+	rec := &message.AlarmRecovery{}
+	var createdAt, updatedAt time.Time
+
+	if err := d.ro.QueryRowContext(ctx, readRecovery, alarmID, orgID,
+		ruleID).Scan(&rec.AlarmId, &rec.OrgId, &rec.RuleId,
+		&rec.SubjectTemplate, &rec.BodyTemplate, &createdAt,
+		&updatedAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	rec.CreatedAt = timestamppb.New(createdAt)
+	rec.UpdatedAt = timestamppb.New(updatedAt)
+
+	return rec, nil
+}
+
+const deleteRecovery = `
+DELETE FROM alarm_recoveries r
+USING alarms a
+WHERE r.alarm_id = a.id
+AND (r.alarm_id, r.org_id, a.rule_id) = ($1, $2, $3)
+RETURNING r.alarm_id
+`
+
+// DeleteRecovery deletes an alarm's recovery by alarm ID, org ID, and rule ID.
+func (d *DAO) DeleteRecovery(
+	ctx context.Context, alarmID, orgID, ruleID string,
+) error {
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, deleteRecovery, alarmID,
+		orgID, ruleID).Scan(&alarmID))
+}
+
+const listRecoveries = `
+SELECT r.alarm_id, r.org_id, a.rule_id, r.subject_template, r.body_template,
+r.created_at, r.updated_at
+FROM alarm_recoveries r
+INNER JOIN alarms a ON r.alarm_id = a.id
+WHERE r.org_id = $1
+AND r.alarm_id = ANY ($2::uuid[])
+`
+
+// ListRecoveries retrieves the recoveries of alarms by org ID and alarm IDs,
+// keyed by alarm ID. Alarms without a recovery are absent.
+func (d *DAO) ListRecoveries(
+	ctx context.Context, orgID string, alarmIDs []string,
+) (map[string]*message.AlarmRecovery, error) {
+	rows, err := d.ro.QueryContext(ctx, listRecoveries, orgID, alarmIDs)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ListRecoveries rows.Close: %v", err)
+		}
+	}()
+
+	recs := map[string]*message.AlarmRecovery{}
+	for rows.Next() {
+		rec := &message.AlarmRecovery{}
+		var createdAt, updatedAt time.Time
+
+		if err = rows.Scan(&rec.AlarmId, &rec.OrgId, &rec.RuleId,
+			&rec.SubjectTemplate, &rec.BodyTemplate, &createdAt,
+			&updatedAt); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		rec.CreatedAt = timestamppb.New(createdAt)
+		rec.UpdatedAt = timestamppb.New(updatedAt)
+		recs[rec.GetAlarmId()] = rec
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return recs, nil
+}
+
+const listRecoveryRules = `
+SELECT DISTINCT a.rule_id
+FROM alarms a
+INNER JOIN alarm_recoveries r ON a.id = r.alarm_id
+WHERE a.org_id = $1
+AND a.rule_id = ANY ($2::uuid[])
+AND a.status = 'ACTIVE'
+`
+
+// ListRecoveryRules retrieves the rule IDs, out of ruleIDs, that have an
+// active alarm with a recovery, keyed by rule ID.
+func (d *DAO) ListRecoveryRules(
+	ctx context.Context, orgID string, ruleIDs []string,
+) (map[string]bool, error) {
+	rows, err := d.ro.QueryContext(ctx, listRecoveryRules, orgID, ruleIDs)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ListRecoveryRules rows.Close: %v", err)
+		}
+	}()
+
+	recRules := map[string]bool{}
+	for rows.Next() {
+		var ruleID string
+		if err = rows.Scan(&ruleID); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		recRules[ruleID] = true
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return recRules, nil
+}
@@ -0,0 +1,291 @@
+//go:build !unit
+
+package alarm
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+func TestUpsertReadRecovery(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alarm"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alarm",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	t.Run("Upsert and read valid recovery", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+			createOrg.GetId(), createRule.GetId()))
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.NoError(t, err)
+
+		createRec, err := globalAlarmDAO.UpsertRecovery(ctx,
+			&message.AlarmRecovery{
+				AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+				RuleId: createRule.GetId(), SubjectTemplate: "dao-alarm-subj",
+				BodyTemplate: "dao-alarm-body",
+			})
+		t.Logf("createRec, err: %+v, %v", createRec, err)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now(), createRec.GetCreatedAt().AsTime(),
+			2*time.Second)
+
+		updRec, err := globalAlarmDAO.UpsertRecovery(ctx,
+			&message.AlarmRecovery{
+				AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+				RuleId: createRule.GetId(), SubjectTemplate: "dao-alarm-subj2",
+			})
+		t.Logf("updRec, err: %+v, %v", updRec, err)
+		require.NoError(t, err)
+		require.Equal(t, createRec.GetCreatedAt().AsTime(),
+			updRec.GetCreatedAt().AsTime())
+
+		readRec, err := globalAlarmDAO.ReadRecovery(ctx, createAlarm.GetId(),
+			createOrg.GetId(), createRule.GetId())
+		t.Logf("readRec, err: %+v, %v", readRec, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, updRec, readRec)
+	})
+
+	t.Run("Upsert recovery by unknown alarm", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createRec, err := globalAlarmDAO.UpsertRecovery(ctx,
+			&message.AlarmRecovery{
+				AlarmId: uuid.NewV7().String(), OrgId: createOrg.GetId(),
+				RuleId: createRule.GetId(), SubjectTemplate: "dao-alarm-subj",
+			})
+		t.Logf("createRec, err: %+v, %v", createRec, err)
+		require.Nil(t, createRec)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Upsert invalid recovery", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+			createOrg.GetId(), createRule.GetId()))
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.NoError(t, err)
+
+		createRec, err := globalAlarmDAO.UpsertRecovery(ctx,
+			&message.AlarmRecovery{
+				AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+				RuleId:          createRule.GetId(),
+				SubjectTemplate: "dao-alarm-" + random.String(1024),
+			})
+		t.Logf("createRec, err: %+v, %v", createRec, err)
+		require.Nil(t, createRec)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+
+	t.Run("Read recovery by unknown alarm", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readRec, err := globalAlarmDAO.ReadRecovery(ctx, uuid.NewV7().String(),
+			createOrg.GetId(), createRule.GetId())
+		t.Logf("readRec, err: %+v, %v", readRec, err)
+		require.Nil(t, readRec)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestDeleteRecovery(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alarm"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alarm",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+		createOrg.GetId(), createRule.GetId()))
+	t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+	require.NoError(t, err)
+
+	_, err = globalAlarmDAO.UpsertRecovery(ctx, &message.AlarmRecovery{
+		AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+		RuleId: createRule.GetId(), SubjectTemplate: "dao-alarm-subj",
+	})
+	require.NoError(t, err)
+
+	err = globalAlarmDAO.DeleteRecovery(ctx, createAlarm.GetId(),
+		createOrg.GetId(), createRule.GetId())
+	t.Logf("err: %v", err)
+	require.NoError(t, err)
+
+	err = globalAlarmDAO.DeleteRecovery(ctx, createAlarm.GetId(),
+		createOrg.GetId(), createRule.GetId())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+}
+
+func TestListRecoveries(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alarm"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alarm",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	var alarmIDs []string
+	for range 3 {
+		createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+			createOrg.GetId(), createRule.GetId()))
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.NoError(t, err)
+		alarmIDs = append(alarmIDs, createAlarm.GetId())
+	}
+
+	// Leave the last alarm without a recovery.
+	for _, alarmID := range alarmIDs[:2] {
+		_, err := globalAlarmDAO.UpsertRecovery(ctx, &message.AlarmRecovery{
+			AlarmId: alarmID, OrgId: createOrg.GetId(),
+			RuleId: createRule.GetId(), SubjectTemplate: "dao-alarm-subj",
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("List recoveries by valid alarm IDs", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listRecs, err := globalAlarmDAO.ListRecoveries(ctx, createOrg.GetId(),
+			alarmIDs)
+		t.Logf("listRecs, err: %+v, %v", listRecs, err)
+		require.NoError(t, err)
+		require.Len(t, listRecs, 2)
+		require.Equal(t, createRule.GetId(), listRecs[alarmIDs[0]].GetRuleId())
+		require.NotContains(t, listRecs, alarmIDs[2])
+	})
+
+	t.Run("List recoveries by unknown org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listRecs, err := globalAlarmDAO.ListRecoveries(ctx,
+			uuid.NewV7().String(), alarmIDs)
+		t.Logf("listRecs, err: %+v, %v", listRecs, err)
+		require.NoError(t, err)
+		require.Empty(t, listRecs)
+	})
+}
+
+func TestListRecoveryRules(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alarm"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	var ruleIDs []string
+	for range 3 {
+		createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alarm",
+			createOrg.GetId()))
+		t.Logf("createRule, err: %+v, %v", createRule, err)
+		require.NoError(t, err)
+		ruleIDs = append(ruleIDs, createRule.GetId())
+	}
+
+	// Leave the last rule with an alarm but without a recovery, and disable
+	// the alarm of the second rule.
+	for i, ruleID := range ruleIDs {
+		alarm := random.Alarm("dao-alarm", createOrg.GetId(), ruleID)
+		alarm.Status = api.Status_ACTIVE
+		if i == 1 {
+			alarm.Status = api.Status_DISABLED
+		}
+
+		createAlarm, err := globalAlarmDAO.Create(ctx, alarm)
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.NoError(t, err)
+
+		if i == 2 {
+			continue
+		}
+
+		_, err = globalAlarmDAO.UpsertRecovery(ctx, &message.AlarmRecovery{
+			AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+			RuleId: ruleID, SubjectTemplate: "dao-alarm-subj",
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("List recovery rules by valid rule IDs", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listRules, err := globalAlarmDAO.ListRecoveryRules(ctx,
+			createOrg.GetId(), ruleIDs)
+		t.Logf("listRules, err: %+v, %v", listRules, err)
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{ruleIDs[0]: true}, listRules)
+	})
+
+	t.Run("List recovery rules by unknown org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listRules, err := globalAlarmDAO.ListRecoveryRules(ctx,
+			uuid.NewV7().String(), ruleIDs)
+		t.Logf("listRules, err: %+v, %v", listRules, err)
+		require.NoError(t, err)
+		require.Empty(t, listRules)
+	})
+}
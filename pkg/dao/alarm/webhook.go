@@ -0,0 +1,140 @@
+package alarm
+
+import (
+	"context"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const upsertWebhook = `
+INSERT INTO alarm_webhooks (alarm_id, org_id, url, secret, created_at,
+updated_at)
+SELECT id, org_id, $4, $5, $6, $6
+FROM alarms
+WHERE (id, org_id, rule_id) = ($1, $2, $3)
+ON CONFLICT (alarm_id) DO UPDATE
+SET url = EXCLUDED.url, secret = EXCLUDED.secret,
+updated_at = EXCLUDED.updated_at
+RETURNING created_at
+`
+
+// UpsertWebhook creates or updates an alarm's webhook and its encrypted secret.
+// The webhook's plaintext secret is not stored.
+func (d *DAO) UpsertWebhook(
+	ctx context.Context, hook *message.AlarmWebhook, secret []byte,
+) (*message.AlarmWebhook, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	hook.UpdatedAt = timestamppb.New(now)
+
+	var createdAt time.Time
+	if err := d.rw.QueryRowContext(ctx, upsertWebhook, hook.GetAlarmId(),
+		hook.GetOrgId(), hook.GetRuleId(), hook.GetUrl(), secret,
+		now).Scan(&createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	hook.CreatedAt = timestamppb.New(createdAt)
+
+	return hook, nil
+}
+
+const readWebhook = `
+SELECT w.alarm_id, w.org_id, a.rule_id, w.url, w.created_at, w.updated_at
+FROM alarm_webhooks w
+INNER JOIN alarms a ON w.alarm_id = a.id
+WHERE (w.alarm_id, w.org_id, a.rule_id) = ($1, $2, $3)
+`
+
+// ReadWebhook retrieves an alarm's webhook by alarm ID, org ID, and rule ID.
+// The webhook's secret is not retrieved.
+func (d *DAO) ReadWebhook(
+	ctx context.Context, alarmID, orgID, ruleID string,
+) (*message.AlarmWebhook, error) {
+	hook := &message.AlarmWebhook{}
+	var createdAt, updatedAt time.Time
+
+	if err := d.ro.QueryRowContext(ctx, readWebhook, alarmID, orgID,
+		ruleID).Scan(&hook.AlarmId, &hook.OrgId, &hook.RuleId, &hook.Url,
+		&createdAt, &updatedAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	hook.CreatedAt = timestamppb.New(createdAt)
+	hook.UpdatedAt = timestamppb.New(updatedAt)
+
+	return hook, nil
+}
+
+const deleteWebhook = `
+DELETE FROM alarm_webhooks w
+USING alarms a
+WHERE w.alarm_id = a.id
+AND (w.alarm_id, w.org_id, a.rule_id) = ($1, $2, $3)
+RETURNING w.alarm_id
+`
+
+// DeleteWebhook deletes an alarm's webhook by alarm ID, org ID, and rule ID.
+func (d *DAO) DeleteWebhook(
+	ctx context.Context, alarmID, orgID, ruleID string,
+) error {
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, deleteWebhook, alarmID,
+		orgID, ruleID).Scan(&alarmID))
+}
+
+const listWebhooks = `
+SELECT w.alarm_id, w.org_id, a.rule_id, w.url, w.secret, w.created_at,
+w.updated_at
+FROM alarm_webhooks w
+INNER JOIN alarms a ON w.alarm_id = a.id
+WHERE w.org_id = $1
+AND w.alarm_id = ANY ($2::uuid[])
+`
+
+// ListWebhooks retrieves the webhooks of alarms, and their encrypted secrets,
+// by org ID and alarm IDs, keyed by alarm ID. Alarms without a webhook are
+// absent.
+func (d *DAO) ListWebhooks(
+	ctx context.Context, orgID string, alarmIDs []string,
+) (map[string]*message.AlarmWebhook, map[string][]byte, error) {
+	rows, err := d.ro.QueryContext(ctx, listWebhooks, orgID, alarmIDs)
+	if err != nil {
+		return nil, nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ListWebhooks rows.Close: %v", err)
+		}
+	}()
+
+	hooks := map[string]*message.AlarmWebhook{}
+	secrets := map[string][]byte{}
+	for rows.Next() {
+		hook := &message.AlarmWebhook{}
+		var secret []byte
+		var createdAt, updatedAt time.Time
+
+		if err = rows.Scan(&hook.AlarmId, &hook.OrgId, &hook.RuleId,
+			&hook.Url, &secret, &createdAt, &updatedAt); err != nil {
+			return nil, nil, dao.DBToSentinel(err)
+		}
+
+		hook.CreatedAt = timestamppb.New(createdAt)
+		hook.UpdatedAt = timestamppb.New(updatedAt)
+		hooks[hook.GetAlarmId()] = hook
+		secrets[hook.GetAlarmId()] = secret
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, dao.DBToSentinel(err)
+	}
+
+	return hooks, secrets, nil
+}
@@ -0,0 +1,229 @@
+//go:build !unit
+
+package alarm
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+)
+
+func TestUpsertReadWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alarm"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alarm",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	t.Run("Upsert and read valid webhook", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+			createOrg.GetId(), createRule.GetId()))
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.NoError(t, err)
+
+		createHook, err := globalAlarmDAO.UpsertWebhook(ctx,
+			&message.AlarmWebhook{
+				AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+				RuleId: createRule.GetId(),
+				Url:    "https://dao-alarm.example.com/hook",
+			}, []byte("dao-alarm-secret"))
+		t.Logf("createHook, err: %+v, %v", createHook, err)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now(), createHook.GetCreatedAt().AsTime(),
+			2*time.Second)
+
+		updHook, err := globalAlarmDAO.UpsertWebhook(ctx,
+			&message.AlarmWebhook{
+				AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+				RuleId: createRule.GetId(),
+				Url:    "https://dao-alarm.example.com/hook2",
+			}, []byte("dao-alarm-secret2"))
+		t.Logf("updHook, err: %+v, %v", updHook, err)
+		require.NoError(t, err)
+		require.Equal(t, createHook.GetCreatedAt().AsTime(),
+			updHook.GetCreatedAt().AsTime())
+
+		readHook, err := globalAlarmDAO.ReadWebhook(ctx, createAlarm.GetId(),
+			createOrg.GetId(), createRule.GetId())
+		t.Logf("readHook, err: %+v, %v", readHook, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, updHook, readHook)
+	})
+
+	t.Run("Upsert webhook by unknown alarm", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createHook, err := globalAlarmDAO.UpsertWebhook(ctx,
+			&message.AlarmWebhook{
+				AlarmId: uuid.NewV7().String(), OrgId: createOrg.GetId(),
+				RuleId: createRule.GetId(),
+				Url:    "https://dao-alarm.example.com/hook",
+			}, []byte("dao-alarm-secret"))
+		t.Logf("createHook, err: %+v, %v", createHook, err)
+		require.Nil(t, createHook)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Upsert invalid webhook", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+			createOrg.GetId(), createRule.GetId()))
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.NoError(t, err)
+
+		createHook, err := globalAlarmDAO.UpsertWebhook(ctx,
+			&message.AlarmWebhook{
+				AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+				RuleId: createRule.GetId(),
+				Url:    "https://dao-alarm.example.com/" + random.String(2048),
+			}, []byte("dao-alarm-secret"))
+		t.Logf("createHook, err: %+v, %v", createHook, err)
+		require.Nil(t, createHook)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+
+	t.Run("Read webhook by unknown alarm", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readHook, err := globalAlarmDAO.ReadWebhook(ctx, uuid.NewV7().String(),
+			createOrg.GetId(), createRule.GetId())
+		t.Logf("readHook, err: %+v, %v", readHook, err)
+		require.Nil(t, readHook)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alarm"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alarm",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+		createOrg.GetId(), createRule.GetId()))
+	t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+	require.NoError(t, err)
+
+	_, err = globalAlarmDAO.UpsertWebhook(ctx, &message.AlarmWebhook{
+		AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+		RuleId: createRule.GetId(),
+		Url:    "https://dao-alarm.example.com/hook",
+	}, []byte("dao-alarm-secret"))
+	require.NoError(t, err)
+
+	err = globalAlarmDAO.DeleteWebhook(ctx, createAlarm.GetId(),
+		createOrg.GetId(), createRule.GetId())
+	t.Logf("err: %v", err)
+	require.NoError(t, err)
+
+	err = globalAlarmDAO.DeleteWebhook(ctx, createAlarm.GetId(),
+		createOrg.GetId(), createRule.GetId())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+}
+
+func TestListWebhooks(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alarm"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alarm",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	var alarmIDs []string
+	for range 3 {
+		createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alarm",
+			createOrg.GetId(), createRule.GetId()))
+		t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+		require.NoError(t, err)
+		alarmIDs = append(alarmIDs, createAlarm.GetId())
+	}
+
+	// Leave the last alarm without a webhook.
+	for _, alarmID := range alarmIDs[:2] {
+		_, err := globalAlarmDAO.UpsertWebhook(ctx, &message.AlarmWebhook{
+			AlarmId: alarmID, OrgId: createOrg.GetId(),
+			RuleId: createRule.GetId(),
+			Url:    "https://dao-alarm.example.com/hook",
+		}, []byte(alarmID))
+		require.NoError(t, err)
+	}
+
+	t.Run("List webhooks by valid alarm IDs", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listHooks, listSecrets, err := globalAlarmDAO.ListWebhooks(ctx,
+			createOrg.GetId(), alarmIDs)
+		t.Logf("listHooks, listSecrets, err: %+v, %s, %v", listHooks,
+			listSecrets, err)
+		require.NoError(t, err)
+		require.Len(t, listHooks, 2)
+		require.Equal(t, createRule.GetId(), listHooks[alarmIDs[0]].GetRuleId())
+		require.NotContains(t, listHooks, alarmIDs[2])
+		require.Len(t, listSecrets, 2)
+		require.Equal(t, []byte(alarmIDs[1]), listSecrets[alarmIDs[1]])
+	})
+
+	t.Run("List webhooks by unknown org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listHooks, listSecrets, err := globalAlarmDAO.ListWebhooks(ctx,
+			uuid.NewV7().String(), alarmIDs)
+		t.Logf("listHooks, listSecrets, err: %+v, %s, %v", listHooks,
+			listSecrets, err)
+		require.NoError(t, err)
+		require.Empty(t, listHooks)
+		require.Empty(t, listSecrets)
+	})
+}
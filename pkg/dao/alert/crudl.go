@@ -21,13 +21,19 @@ VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 // Create creates an alert in the database. Alerts are retrieved elsewhere in
 // bulk, so only an error value is returned.
 func (d *DAO) Create(ctx context.Context, alert *api.Alert) error {
+	return d.create(ctx, alert, alert.GetStatus().String())
+}
+
+// create creates an alert in the database with the provided status.
+func (d *DAO) create(
+	ctx context.Context, alert *api.Alert, status string,
+) error {
 	now := time.Now().UTC().Truncate(time.Microsecond)
 	alert.CreatedAt = timestamppb.New(now)
 
 	_, err := d.rw.ExecContext(ctx, createAlert, alert.GetOrgId(),
 		strings.ToLower(alert.GetUniqId()), alert.GetAlarmId(),
-		alert.GetUserId(), alert.GetStatus().String(), alert.GetError(), now,
-		alert.GetTraceId())
+		alert.GetUserId(), status, alert.GetError(), now, alert.GetTraceId())
 
 	return dao.DBToSentinel(err)
 }
@@ -68,16 +74,22 @@ const listAlertsUserID = `
 AND e.user_id = $%d
 `
 
+const listAlertsScope = `
+AND %s
+`
+
 const listAlertsOrder = `
 ORDER BY e.created_at DESC
 `
 
 // List retrieves all alerts by org ID, [end, start) times, and any of the
-// following: UniqID, device ID, alarm ID, and user ID. If both uniqID and devID
-// are provided, uniqID takes precedence and devID is ignored.
+// following: UniqID, device ID, alarm ID, user ID, and scope tags. If both
+// uniqID and devID are provided, uniqID takes precedence and devID is ignored.
+// If scope tags are provided, only alerts for devices with tags in scope are
+// returned.
 func (d *DAO) List(
 	ctx context.Context, orgID, uniqID, devID, alarmID, userID string, end,
-	start time.Time,
+	start time.Time, scopeTags []string,
 ) ([]*api.Alert, error) {
 	// Build list query.
 	var query string
@@ -109,6 +121,11 @@ func (d *DAO) List(
 		args = append(args, userID)
 	}
 
+	if len(scopeTags) > 0 {
+		args = append(args, scopeTags)
+		query += fmt.Sprintf(listAlertsScope, dao.DeviceScope(len(args)))
+	}
+
 	query += listAlertsOrder
 
 	// Run list query.
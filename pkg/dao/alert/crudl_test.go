@@ -124,7 +124,7 @@ func TestList(t *testing.T) {
 		listAlertsUniqID, err := globalAleDAO.List(ctx, createOrg.GetId(),
 			createDev.GetUniqId(), "", "", "",
 			alerts[0].GetCreatedAt().AsTime(), alerts[len(alerts)-1].
-				GetCreatedAt().AsTime().Add(-time.Millisecond))
+				GetCreatedAt().AsTime().Add(-time.Millisecond), nil)
 		t.Logf("listAlertsUniqID, err: %+v, %v", listAlertsUniqID, err)
 		require.NoError(t, err)
 		require.Len(t, listAlertsUniqID, len(alerts))
@@ -136,7 +136,7 @@ func TestList(t *testing.T) {
 		// Verify results by dev ID without oldest alert.
 		listAlertsDevID, err := globalAleDAO.List(ctx, createOrg.GetId(), "",
 			createDev.GetId(), "", "", alerts[0].GetCreatedAt().AsTime(),
-			alerts[len(alerts)-1].GetCreatedAt().AsTime())
+			alerts[len(alerts)-1].GetCreatedAt().AsTime(), nil)
 		t.Logf("listAlertsDevID, err: %+v, %v", listAlertsDevID, err)
 		require.NoError(t, err)
 		require.Len(t, listAlertsDevID, len(alerts)-1)
@@ -151,7 +151,7 @@ func TestList(t *testing.T) {
 			alerts[len(alerts)-1].GetUserId(),
 			alerts[0].GetCreatedAt().AsTime(),
 			alerts[len(alerts)-1].GetCreatedAt().AsTime().
-				Add(-time.Millisecond))
+				Add(-time.Millisecond), nil)
 		t.Logf("listAlertsAlarmID, err: %+v, %v", listAlertsAlarmID, err)
 		require.NoError(t, err)
 		require.Len(t, listAlertsAlarmID, 1)
@@ -159,6 +159,45 @@ func TestList(t *testing.T) {
 			listAlertsAlarmID[0])
 	})
 
+	t.Run("List alerts with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+		t.Logf("createOrg, err: %+v, %v", createOrg, err)
+		require.NoError(t, err)
+
+		var scopeAlert *api.Alert
+		for _, tag := range []string{"dao-alert-site/b1", "dao-alert-site2"} {
+			dev := random.Device("dao-alert", createOrg.GetId())
+			dev.Tags = []string{tag}
+			createDev, err := globalDevDAO.Create(ctx, dev)
+			t.Logf("createDev, err: %+v, %v", createDev, err)
+			require.NoError(t, err)
+
+			alert := random.Alert("dao-alert", createOrg.GetId())
+			alert.UniqId = createDev.GetUniqId()
+
+			err = globalAleDAO.Create(ctx, alert)
+			t.Logf("err: %v", err)
+			require.NoError(t, err)
+
+			if scopeAlert == nil {
+				scopeAlert = alert
+			}
+		}
+
+		listAlerts, err := globalAleDAO.List(ctx, createOrg.GetId(), "", "",
+			"", "", time.Now().Add(time.Minute), time.Now().Add(-time.Hour),
+			[]string{"dao-alert-site"})
+		t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
+		require.NoError(t, err)
+		require.Len(t, listAlerts, 1)
+		require.EqualExportedValues(t, scopeAlert, listAlerts[0])
+	})
+
 	t.Run("Lists are isolated by org ID", func(t *testing.T) {
 		t.Parallel()
 
@@ -177,7 +216,7 @@ func TestList(t *testing.T) {
 
 		listAlerts, err := globalAleDAO.List(ctx, uuid.NewV7().String(),
 			alert.GetUniqId(), "", "", "", alert.GetCreatedAt().AsTime(),
-			alert.GetCreatedAt().AsTime().Add(-time.Millisecond))
+			alert.GetCreatedAt().AsTime().Add(-time.Millisecond), nil)
 		t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
 		require.NoError(t, err)
 		require.Empty(t, listAlerts)
@@ -190,7 +229,7 @@ func TestList(t *testing.T) {
 		defer cancel()
 
 		listAlerts, err := globalAleDAO.List(ctx, random.String(10),
-			uuid.NewV7().String(), "", "", "", time.Now(), time.Now())
+			uuid.NewV7().String(), "", "", "", time.Now(), time.Now(), nil)
 		t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
 		require.Nil(t, listAlerts)
 		require.ErrorIs(t, err, dao.ErrInvalidFormat)
@@ -0,0 +1,154 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// statusDeferred is the database status of an alert deferred by a
+// notification schedule, which api.AlertStatus does not define. It is
+// retrieved as api.AlertStatus_ALERT_STATUS_UNSPECIFIED.
+const statusDeferred = "DEFERRED"
+
+// CreateRecord creates an alert record in the database. Deferred alerts are
+// stored with a status of DEFERRED.
+func (d *DAO) CreateRecord(
+	ctx context.Context, rec *message.AlertRecord,
+) error {
+	if rec.GetDeferred() {
+		return d.create(ctx, rec.GetAlert(), statusDeferred)
+	}
+
+	return d.Create(ctx, rec.GetAlert())
+}
+
+const deferAlert = `
+INSERT INTO deferred_alerts (org_id, alarm_id, user_id, type, subject, body,
+event, deliver_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id
+`
+
+// Defer stores an alert for delivery once it is due.
+func (d *DAO) Defer(
+	ctx context.Context, da *message.DeferredAlert,
+) (*message.DeferredAlert, error) {
+	event, err := proto.Marshal(da.GetEvent())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", dao.ErrInvalidFormat, err)
+	}
+
+	deliverAt := da.GetDeliverAt().AsTime().UTC().Truncate(time.Microsecond)
+	da.DeliverAt = timestamppb.New(deliverAt)
+
+	if err := d.rw.QueryRowContext(ctx, deferAlert, da.GetOrgId(),
+		da.GetAlarmId(), da.GetUserId(), da.GetType().String(),
+		da.GetSubject(), da.GetBody(), event, deliverAt).Scan(
+		&da.Id); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return da, nil
+}
+
+// Claimed deferred alerts are leased until the provided time, so each is
+// returned once per lease.
+const claimDeferred = `
+UPDATE deferred_alerts
+SET claimed_until = $2
+WHERE id IN (
+  SELECT id
+  FROM deferred_alerts
+  WHERE deliver_at <= $1
+  AND (claimed_until IS NULL OR claimed_until <= $1)
+  ORDER BY deliver_at
+  LIMIT $3
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, org_id, alarm_id, user_id, type, subject, body, event,
+deliver_at
+`
+
+// ClaimDeferred claims and returns up to limit deferred alerts that are due as
+// of now, leasing them for the provided duration. Each deferred alert is
+// returned at most once per lease, including between concurrent callers.
+// Deferred alerts that are not deleted by DeleteDeferred before their lease
+// expires are returned again.
+func (d *DAO) ClaimDeferred(
+	ctx context.Context, now time.Time, lease time.Duration, limit int32,
+) ([]*message.DeferredAlert, error) {
+	rows, err := d.rw.QueryContext(ctx, claimDeferred, now, now.Add(lease),
+		limit)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ClaimDeferred rows.Close: %v", err)
+		}
+	}()
+
+	var das []*message.DeferredAlert
+	for rows.Next() {
+		da := &message.DeferredAlert{Event: &message.EventerOut{}}
+		var alarmType string
+		var event []byte
+		var deliverAt time.Time
+
+		if err = rows.Scan(&da.Id, &da.OrgId, &da.AlarmId, &da.UserId,
+			&alarmType, &da.Subject, &da.Body, &event,
+			&deliverAt); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		if err = proto.Unmarshal(event, da.GetEvent()); err != nil {
+			return nil, err
+		}
+		da.Type = api.AlarmType(api.AlarmType_value[alarmType])
+		da.DeliverAt = timestamppb.New(deliverAt)
+
+		das = append(das, da)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return das, nil
+}
+
+const deleteDeferred = `
+DELETE FROM deferred_alerts
+WHERE (id, org_id) = ($1, $2)
+`
+
+// DeleteDeferred deletes a deferred alert by ID and org ID.
+func (d *DAO) DeleteDeferred(ctx context.Context, daID, orgID string) error {
+	res, err := d.rw.ExecContext(ctx, deleteDeferred, daID, orgID)
+	if err != nil {
+		return dao.DBToSentinel(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return dao.DBToSentinel(err)
+	}
+
+	if rows == 0 {
+		return dao.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+//go:build !unit
+
+package alert
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDeferClaimDeferred(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alert",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alert",
+		createOrg.GetId(), createRule.GetId()))
+	t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+	require.NoError(t, err)
+
+	createUser, err := globalUserDAO.Create(ctx, random.User("dao-alert",
+		createOrg.GetId()))
+	t.Logf("createUser, err: %+v, %v", createUser, err)
+	require.NoError(t, err)
+
+	// Deliver in the future, so that concurrent claims of other tests do not
+	// claim the deferred alert.
+	deliverAt := time.Now().Add(90 * 24 * time.Hour)
+
+	da := &message.DeferredAlert{
+		OrgId: createOrg.GetId(), AlarmId: createAlarm.GetId(),
+		UserId: createUser.GetId(), Type: api.AlarmType_SMS,
+		Subject: "dao-alert-" + random.String(10),
+		Body:    "dao-alert-" + random.String(10),
+		Event: &message.EventerOut{
+			Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+			Device: random.Device("dao-alert", createOrg.GetId()),
+			Rule:   createRule,
+		},
+		DeliverAt: timestamppb.New(deliverAt),
+	}
+
+	createDA, err := globalAleDAO.Defer(ctx,
+		proto.Clone(da).(*message.DeferredAlert))
+	t.Logf("createDA, err: %+v, %v", createDA, err)
+	require.NoError(t, err)
+	require.NotEmpty(t, createDA.GetId())
+
+	// Subtests are not parallel, as the second claim depends on the first.
+
+	t.Run("Claim deferred alert", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		das, err := globalAleDAO.ClaimDeferred(ctx,
+			deliverAt.Add(time.Second), time.Minute, 1000)
+		t.Logf("das, err: %+v, %v", das, err)
+		require.NoError(t, err)
+
+		var claimDA *message.DeferredAlert
+		for _, d := range das {
+			if d.GetId() == createDA.GetId() {
+				claimDA = d
+			}
+		}
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(createDA, claimDA) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", createDA, claimDA)
+		}
+	})
+
+	t.Run("Deferred alert is claimed once per lease", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		das, err := globalAleDAO.ClaimDeferred(ctx,
+			deliverAt.Add(time.Second), time.Minute, 1000)
+		t.Logf("das, err: %+v, %v", das, err)
+		require.NoError(t, err)
+
+		for _, d := range das {
+			require.NotEqual(t, createDA.GetId(), d.GetId())
+		}
+	})
+
+	t.Run("Deferred alert is claimed again after lease", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		das, err := globalAleDAO.ClaimDeferred(ctx,
+			deliverAt.Add(2*time.Minute), time.Minute, 1000)
+		t.Logf("das, err: %+v, %v", das, err)
+		require.NoError(t, err)
+
+		var found bool
+		for _, d := range das {
+			if d.GetId() == createDA.GetId() {
+				found = true
+			}
+		}
+		require.True(t, found)
+	})
+
+	t.Run("Deleted deferred alert is not claimed", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		err := globalAleDAO.DeleteDeferred(ctx, createDA.GetId(),
+			createOrg.GetId())
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		das, err := globalAleDAO.ClaimDeferred(ctx,
+			deliverAt.Add(4*time.Minute), time.Minute, 1000)
+		t.Logf("das, err: %+v, %v", das, err)
+		require.NoError(t, err)
+
+		for _, d := range das {
+			require.NotEqual(t, createDA.GetId(), d.GetId())
+		}
+
+		err = globalAleDAO.DeleteDeferred(ctx, createDA.GetId(),
+			createOrg.GetId())
+		t.Logf("err: %v", err)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestDeferError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createDA, err := globalAleDAO.Defer(ctx, &message.DeferredAlert{
+		OrgId: createOrg.GetId(), AlarmId: uuid.NewV7().String(),
+		UserId: uuid.NewV7().String(), Type: api.AlarmType_APP,
+		Event: &message.EventerOut{}, DeliverAt: timestamppb.Now(),
+	})
+	t.Logf("createDA, err: %+v, %v", createDA, err)
+	require.Nil(t, createDA)
+	require.ErrorIs(t, err, dao.ErrInvalidFormat)
+}
+
+func TestCreateRecord(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	alert := random.Alert("dao-alert", createOrg.GetId())
+
+	err = globalAleDAO.CreateRecord(ctx, &message.AlertRecord{
+		Alert: alert, Deferred: true,
+	})
+	t.Logf("err: %v", err)
+	require.NoError(t, err)
+
+	// Deferred alerts are retrieved with an unspecified status.
+	listAlerts, err := globalAleDAO.List(ctx, createOrg.GetId(),
+		alert.GetUniqId(), "", "", "", time.Now(),
+		time.Now().Add(-time.Minute), nil)
+	t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
+	require.NoError(t, err)
+	require.Len(t, listAlerts, 1)
+	require.Equal(t, api.AlertStatus_ALERT_STATUS_UNSPECIFIED,
+		listAlerts[0].GetStatus())
+}
@@ -0,0 +1,257 @@
+package alert
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const createDigest = `
+INSERT INTO alert_digests (id, org_id, alarm_id, rule_id, user_id, type,
+status, error, flush_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+
+// CreateDigest creates a pending alert digest in the database. The digest ID
+// is provided by the caller, as it is shared with the cache before creation.
+func (d *DAO) CreateDigest(
+	ctx context.Context, digest *message.AlertDigest,
+) (*message.AlertDigest, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	digest.CreatedAt = timestamppb.New(now)
+
+	flushAt := digest.GetFlushAt().AsTime().UTC().Truncate(time.Microsecond)
+	digest.FlushAt = timestamppb.New(flushAt)
+
+	if _, err := d.rw.ExecContext(ctx, createDigest, digest.GetId(),
+		digest.GetOrgId(), digest.GetAlarmId(), digest.GetRuleId(),
+		digest.GetUserId(), digest.GetType().String(),
+		digest.GetStatus().String(), digest.GetError(), flushAt,
+		now); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return digest, nil
+}
+
+const createAlertInDigest = `
+INSERT INTO alerts (org_id, uniq_id, alarm_id, user_id, status, error,
+created_at, trace_id, digest_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+
+// CreateInDigest creates an alert in the database that is linked to an alert
+// digest.
+func (d *DAO) CreateInDigest(
+	ctx context.Context, alert *api.Alert, digestID string,
+) error {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	alert.CreatedAt = timestamppb.New(now)
+
+	_, err := d.rw.ExecContext(ctx, createAlertInDigest, alert.GetOrgId(),
+		strings.ToLower(alert.GetUniqId()), alert.GetAlarmId(),
+		alert.GetUserId(), alert.GetStatus().String(), alert.GetError(), now,
+		alert.GetTraceId(), digestID)
+
+	return dao.DBToSentinel(err)
+}
+
+// Claimed digests are marked as flushed, so each is returned at most once.
+const claimDigests = `
+UPDATE alert_digests
+SET flushed_at = $1
+WHERE id IN (
+  SELECT id
+  FROM alert_digests
+  WHERE flushed_at IS NULL
+  AND flush_at <= $1
+  ORDER BY flush_at
+  LIMIT $2
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, org_id, alarm_id, rule_id, user_id, type, status, error,
+flush_at, created_at
+`
+
+// ClaimDigests claims and returns up to limit alert digests that are due to be
+// flushed as of now. Each digest is returned at most once, including between
+// concurrent callers.
+func (d *DAO) ClaimDigests(
+	ctx context.Context, now time.Time, limit int32,
+) ([]*message.AlertDigest, error) {
+	rows, err := d.rw.QueryContext(ctx, claimDigests, now, limit)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ClaimDigests rows.Close: %v", err)
+		}
+	}()
+
+	var digests []*message.AlertDigest
+	for rows.Next() {
+		digest := &message.AlertDigest{}
+		var alarmType, status string
+		var flushAt, createdAt time.Time
+
+		if err = rows.Scan(&digest.Id, &digest.OrgId, &digest.AlarmId,
+			&digest.RuleId, &digest.UserId, &alarmType, &status, &digest.Error,
+			&flushAt, &createdAt); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		digest.Type = api.AlarmType(api.AlarmType_value[alarmType])
+		digest.Status = api.AlertStatus(api.AlertStatus_value[status])
+		digest.FlushAt = timestamppb.New(flushAt)
+		digest.CreatedAt = timestamppb.New(createdAt)
+
+		digests = append(digests, digest)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return digests, nil
+}
+
+const completeDigest = `
+UPDATE alert_digests
+SET status = $1, error = $2
+WHERE (id, org_id) = ($3, $4)
+`
+
+// CompleteDigest records the status and error, if any, of a sent alert digest.
+func (d *DAO) CompleteDigest(
+	ctx context.Context, digestID, orgID string, status api.AlertStatus,
+	digestErr string,
+) error {
+	res, err := d.rw.ExecContext(ctx, completeDigest, status.String(),
+		digestErr, digestID, orgID)
+	if err != nil {
+		return dao.DBToSentinel(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return dao.DBToSentinel(err)
+	}
+
+	if rows == 0 {
+		return dao.ErrNotFound
+	}
+
+	return nil
+}
+
+const readDigest = `
+SELECT id, org_id, alarm_id, rule_id, user_id, type, status, error, flush_at,
+created_at
+FROM alert_digests
+WHERE (id, org_id) = ($1, $2)
+`
+
+const listDigestAlerts = `
+SELECT org_id, uniq_id, alarm_id, user_id, status, error, created_at, trace_id
+FROM alerts
+WHERE (org_id, digest_id) = ($1, $2)
+ORDER BY created_at
+`
+
+// ReadDigest retrieves an alert digest by ID and org ID, along with the alerts
+// linked to it.
+func (d *DAO) ReadDigest(
+	ctx context.Context, digestID, orgID string,
+) (*message.AlertDigest, error) {
+	digest := &message.AlertDigest{}
+	var alarmType, status string
+	var flushAt, createdAt time.Time
+
+	if err := d.ro.QueryRowContext(ctx, readDigest, digestID, orgID).Scan(
+		&digest.Id, &digest.OrgId, &digest.AlarmId, &digest.RuleId,
+		&digest.UserId, &alarmType, &status, &digest.Error, &flushAt,
+		&createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	digest.Type = api.AlarmType(api.AlarmType_value[alarmType])
+	digest.Status = api.AlertStatus(api.AlertStatus_value[status])
+	digest.FlushAt = timestamppb.New(flushAt)
+	digest.CreatedAt = timestamppb.New(createdAt)
+
+	rows, err := d.ro.QueryContext(ctx, listDigestAlerts, orgID, digestID)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ReadDigest rows.Close: %v", err)
+		}
+	}()
+
+	for rows.Next() {
+		alert := &api.Alert{}
+		var aleStatus string
+		var aleCreatedAt time.Time
+
+		if err = rows.Scan(&alert.OrgId, &alert.UniqId, &alert.AlarmId,
+			&alert.UserId, &aleStatus, &alert.Error, &aleCreatedAt,
+			&alert.TraceId); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		alert.Status = api.AlertStatus(api.AlertStatus_value[aleStatus])
+		alert.CreatedAt = timestamppb.New(aleCreatedAt)
+		digest.Alerts = append(digest.Alerts, alert)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return digest, nil
+}
+
+const purgeDigests = `
+DELETE FROM alert_digests
+WHERE ctid = ANY(ARRAY(
+  SELECT ctid
+  FROM alert_digests
+  WHERE org_id = $1
+  AND created_at < $2
+  LIMIT $3
+))
+`
+
+// PurgeDigests deletes up to limit alert digests by org ID that were created
+// before the provided time and returns the count of deleted digests.
+func (d *DAO) PurgeDigests(
+	ctx context.Context, orgID string, before time.Time, limit int32,
+) (int64, error) {
+	res, err := d.rw.ExecContext(ctx, purgeDigests, orgID, before, limit)
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	return count, nil
+}
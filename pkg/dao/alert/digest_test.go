@@ -0,0 +1,205 @@
+//go:build !unit
+
+package alert
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCreateClaimReadDigest(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	// Flush in the future, so that concurrent claims of other tests do not
+	// claim the digest.
+	flushAt := time.Now().Add(90 * 24 * time.Hour)
+
+	createDigest, err := globalAleDAO.CreateDigest(ctx, &message.AlertDigest{
+		Id: uuid.NewV7().String(), OrgId: createOrg.GetId(),
+		AlarmId: uuid.NewV7().String(), RuleId: uuid.NewV7().String(),
+		UserId: uuid.NewV7().String(), Type: api.AlarmType_EMAIL,
+		FlushAt: timestamppb.New(flushAt),
+	})
+	t.Logf("createDigest, err: %+v, %v", createDigest, err)
+	require.NoError(t, err)
+
+	alerts := []*api.Alert{}
+	for range 2 {
+		alert := random.Alert("dao-alert", createOrg.GetId())
+		alert.AlarmId = createDigest.GetAlarmId()
+		alert.UserId = createDigest.GetUserId()
+
+		err := globalAleDAO.CreateInDigest(ctx, alert, createDigest.GetId())
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		alerts = append(alerts, alert)
+	}
+
+	// Subtests are not parallel, as each depends on the previous.
+
+	t.Run("Claim digest", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		digests, err := globalAleDAO.ClaimDigests(ctx,
+			flushAt.Add(time.Second), 1000)
+		t.Logf("digests, err: %+v, %v", digests, err)
+		require.NoError(t, err)
+
+		var claimDigest *message.AlertDigest
+		for _, d := range digests {
+			if d.GetId() == createDigest.GetId() {
+				claimDigest = d
+			}
+		}
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(createDigest, claimDigest) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", createDigest, claimDigest)
+		}
+	})
+
+	t.Run("Digest is claimed once", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		digests, err := globalAleDAO.ClaimDigests(ctx,
+			flushAt.Add(time.Second), 1000)
+		t.Logf("digests, err: %+v, %v", digests, err)
+		require.NoError(t, err)
+
+		for _, d := range digests {
+			require.NotEqual(t, createDigest.GetId(), d.GetId())
+		}
+	})
+
+	t.Run("Complete and read digest", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		err := globalAleDAO.CompleteDigest(ctx, createDigest.GetId(),
+			createOrg.GetId(), api.AlertStatus_ERROR, "dao-alert-error")
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		readDigest, err := globalAleDAO.ReadDigest(ctx, createDigest.GetId(),
+			createOrg.GetId())
+		t.Logf("readDigest, err: %+v, %v", readDigest, err)
+		require.NoError(t, err)
+
+		expDigest, _ := proto.Clone(createDigest).(*message.AlertDigest)
+		expDigest.Status = api.AlertStatus_ERROR
+		expDigest.Error = "dao-alert-error"
+		expDigest.Alerts = alerts
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(expDigest, readDigest) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", expDigest, readDigest)
+		}
+	})
+}
+
+func TestDigestError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	t.Run("Create digest by unknown org", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createDigest, err := globalAleDAO.CreateDigest(ctx,
+			&message.AlertDigest{
+				Id: uuid.NewV7().String(), OrgId: uuid.NewV7().String(),
+				AlarmId: uuid.NewV7().String(), RuleId: uuid.NewV7().String(),
+				UserId: uuid.NewV7().String(), Type: api.AlarmType_APP,
+				FlushAt: timestamppb.Now(),
+			})
+		t.Logf("createDigest, err: %+v, %v", createDigest, err)
+		require.Nil(t, createDigest)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+
+	t.Run("Complete digest by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		err := globalAleDAO.CompleteDigest(ctx, uuid.NewV7().String(),
+			createOrg.GetId(), api.AlertStatus_SENT, "")
+		t.Logf("err: %v", err)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Read digest by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readDigest, err := globalAleDAO.ReadDigest(ctx, uuid.NewV7().String(),
+			createOrg.GetId())
+		t.Logf("readDigest, err: %+v, %v", readDigest, err)
+		require.Nil(t, readDigest)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestPurgeDigests(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	for range 3 {
+		createDigest, err := globalAleDAO.CreateDigest(ctx,
+			&message.AlertDigest{
+				Id: uuid.NewV7().String(), OrgId: createOrg.GetId(),
+				AlarmId: uuid.NewV7().String(), RuleId: uuid.NewV7().String(),
+				UserId: uuid.NewV7().String(), Type: api.AlarmType_SMS,
+				FlushAt: timestamppb.Now(),
+			})
+		t.Logf("createDigest, err: %+v, %v", createDigest, err)
+		require.NoError(t, err)
+	}
+
+	for _, exp := range []int64{2, 1, 0} {
+		count, err := globalAleDAO.PurgeDigests(ctx, createOrg.GetId(),
+			time.Now().Add(time.Minute), 2)
+		t.Logf("count, err: %v, %v", count, err)
+		require.NoError(t, err)
+		require.Equal(t, exp, count)
+	}
+}
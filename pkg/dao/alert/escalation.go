@@ -0,0 +1,139 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const scheduleEscalation = `
+INSERT INTO alert_escalations (lifecycle_id, org_id, alarm_id, step, next_at,
+event)
+SELECT $1, org_id, alarm_id, 0, $4::timestamptz +
+make_interval(mins => (policy->'steps'->0->>'delayMinutes')::integer), $5
+FROM alarm_escalations
+WHERE (alarm_id, org_id) = ($2, $3)
+AND jsonb_array_length(policy->'steps') > 0
+ON CONFLICT (lifecycle_id) DO NOTHING
+`
+
+// ScheduleEscalation schedules the first escalation step of an alert
+// lifecycle, relative to when it opened, if its alarm has an escalation
+// policy. Scheduling an already scheduled alert lifecycle is a no-op.
+func (d *DAO) ScheduleEscalation(
+	ctx context.Context, lc *message.AlertLifecycle, eOut *message.EventerOut,
+) error {
+	event, err := proto.Marshal(eOut)
+	if err != nil {
+		return fmt.Errorf("%w: %w", dao.ErrInvalidFormat, err)
+	}
+
+	_, err = d.rw.ExecContext(ctx, scheduleEscalation, lc.GetId(),
+		lc.GetAlarmId(), lc.GetOrgId(), lc.GetOpenedAt().AsTime(), event)
+
+	return dao.DBToSentinel(err)
+}
+
+// Escalations are finished when all steps are sent, the alert lifecycle is no
+// longer open, or the policy no longer has the next step.
+const deleteFinishedEscalations = `
+DELETE FROM alert_escalations ae
+WHERE ae.next_at IS NULL
+OR NOT EXISTS (
+  SELECT 1
+  FROM alert_lifecycles lc
+  WHERE lc.id = ae.lifecycle_id
+  AND lc.state = 'OPEN'
+)
+OR NOT EXISTS (
+  SELECT 1
+  FROM alarm_escalations e
+  WHERE e.alarm_id = ae.alarm_id
+  AND e.policy->'steps'->ae.step IS NOT NULL
+)
+`
+
+// SET expressions use pre-update values, while RETURNING uses post-update
+// values. The next step is scheduled relative to now.
+const claimEscalations = `
+UPDATE alert_escalations ae
+SET step = ae.step + 1, next_at = $1::timestamptz + make_interval(
+  mins => (e.policy->'steps'->(ae.step + 1)->>'delayMinutes')::integer)
+FROM alarm_escalations e, alert_lifecycles lc
+WHERE ae.lifecycle_id IN (
+  SELECT lifecycle_id
+  FROM alert_escalations
+  WHERE next_at <= $1::timestamptz
+  ORDER BY next_at
+  LIMIT $2
+  FOR UPDATE SKIP LOCKED
+)
+AND e.alarm_id = ae.alarm_id
+AND e.policy->'steps'->ae.step IS NOT NULL
+AND lc.id = ae.lifecycle_id
+AND lc.state = 'OPEN'
+RETURNING ae.lifecycle_id, ae.org_id, ae.alarm_id, ae.step,
+e.policy->'steps'->(ae.step - 1), ae.event
+`
+
+// ClaimEscalations removes finished escalations, then claims and returns up to
+// limit escalation steps that are due as of now. Claimed steps are advanced,
+// so each step is returned at most once, including between concurrent
+// callers.
+func (d *DAO) ClaimEscalations(
+	ctx context.Context, now time.Time, limit int32,
+) ([]*message.AlertEscalation, error) {
+	if _, err := d.rw.ExecContext(ctx,
+		deleteFinishedEscalations); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	rows, err := d.rw.QueryContext(ctx, claimEscalations, now, limit)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ClaimEscalations rows.Close: %v", err)
+		}
+	}()
+
+	var escs []*message.AlertEscalation
+	for rows.Next() {
+		esc := &message.AlertEscalation{
+			Step:  &message.EscalationStep{},
+			Event: &message.EventerOut{},
+		}
+		var step, event []byte
+
+		if err = rows.Scan(&esc.LifecycleId, &esc.OrgId, &esc.AlarmId,
+			&esc.StepNum, &step, &event); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		if err = protojson.Unmarshal(step, esc.GetStep()); err != nil {
+			return nil, err
+		}
+		if err = proto.Unmarshal(event, esc.GetEvent()); err != nil {
+			return nil, err
+		}
+
+		escs = append(escs, esc)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return escs, nil
+}
@@ -0,0 +1,167 @@
+//go:build !unit
+
+package alert
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"github.com/thingspect/proto/go/common"
+)
+
+// claimLC claims due escalations and returns those of an alert lifecycle, as
+// escalations are claimed across orgs.
+func claimLC(
+	ctx context.Context, t *testing.T, now time.Time, lcID string,
+) []*message.AlertEscalation {
+	t.Helper()
+
+	escs, err := globalAleDAO.ClaimEscalations(ctx, now, 1000)
+	t.Logf("escs, err: %+v, %v", escs, err)
+	require.NoError(t, err)
+
+	var lcEscs []*message.AlertEscalation
+	for _, esc := range escs {
+		if esc.GetLifecycleId() == lcID {
+			lcEscs = append(lcEscs, esc)
+		}
+	}
+
+	return lcEscs
+}
+
+func TestScheduleClaimEscalations(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-alert",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	createAlarm, err := globalAlarmDAO.Create(ctx, random.Alarm("dao-alert",
+		createOrg.GetId(), createRule.GetId()))
+	t.Logf("createAlarm, err: %+v, %v", createAlarm, err)
+	require.NoError(t, err)
+
+	steps := []*message.EscalationStep{
+		{DelayMinutes: 5, UserTags: []string{"dao-alert-1"}},
+		{
+			DelayMinutes: 10, UserTags: []string{"dao-alert-2"},
+			Type: api.AlarmType_SMS,
+		},
+	}
+	_, err = globalAlarmDAO.UpsertEscalation(ctx, &message.AlarmEscalation{
+		AlarmId: createAlarm.GetId(), OrgId: createOrg.GetId(),
+		RuleId: createRule.GetId(), Steps: steps,
+	})
+	require.NoError(t, err)
+
+	// Subtests are not parallel, as claims in the future would advance the
+	// escalations of other subtests.
+
+	t.Run("Escalate open alert lifecycle", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		eOut := &message.EventerOut{
+			Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+			Device: random.Device("dao-alert", createOrg.GetId()),
+			Rule:   createRule,
+		}
+
+		openLC, err := globalAleDAO.Open(ctx, &message.AlertLifecycle{
+			OrgId: createOrg.GetId(), UniqId: eOut.GetDevice().GetUniqId(),
+			AlarmId: createAlarm.GetId(), TraceId: uuid.NewV7().String(),
+		})
+		t.Logf("openLC, err: %+v, %v", openLC, err)
+		require.NoError(t, err)
+
+		require.NoError(t, globalAleDAO.ScheduleEscalation(ctx, openLC, eOut))
+		// Scheduling is idempotent.
+		require.NoError(t, globalAleDAO.ScheduleEscalation(ctx, openLC, eOut))
+
+		opened := openLC.GetOpenedAt().AsTime()
+		require.Empty(t, claimLC(ctx, t, opened.Add(4*time.Minute),
+			openLC.GetId()))
+
+		escs := claimLC(ctx, t, opened.Add(6*time.Minute), openLC.GetId())
+		require.Len(t, escs, 1)
+		require.Equal(t, int32(1), escs[0].GetStepNum())
+		require.EqualExportedValues(t, steps[0], escs[0].GetStep())
+		require.EqualExportedValues(t, eOut, escs[0].GetEvent())
+
+		// The next step is relative to the claim of the previous step.
+		require.Empty(t, claimLC(ctx, t, opened.Add(15*time.Minute),
+			openLC.GetId()))
+
+		escs = claimLC(ctx, t, opened.Add(17*time.Minute), openLC.GetId())
+		require.Len(t, escs, 1)
+		require.Equal(t, int32(2), escs[0].GetStepNum())
+		require.EqualExportedValues(t, steps[1], escs[0].GetStep())
+
+		require.Empty(t, claimLC(ctx, t, opened.Add(time.Hour),
+			openLC.GetId()))
+	})
+
+	t.Run("Do not escalate acknowledged alert lifecycle", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		eOut := &message.EventerOut{
+			Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+			Device: random.Device("dao-alert", createOrg.GetId()),
+			Rule:   createRule,
+		}
+
+		openLC, err := globalAleDAO.Open(ctx, &message.AlertLifecycle{
+			OrgId: createOrg.GetId(), UniqId: eOut.GetDevice().GetUniqId(),
+			AlarmId: createAlarm.GetId(), TraceId: uuid.NewV7().String(),
+		})
+		t.Logf("openLC, err: %+v, %v", openLC, err)
+		require.NoError(t, err)
+
+		require.NoError(t, globalAleDAO.ScheduleEscalation(ctx, openLC, eOut))
+
+		_, err = globalAleDAO.Acknowledge(ctx, openLC.GetId(),
+			createOrg.GetId(), "", "dao-alert")
+		require.NoError(t, err)
+
+		require.Empty(t, claimLC(ctx, t, openLC.GetOpenedAt().AsTime().Add(
+			time.Hour), openLC.GetId()))
+	})
+
+	t.Run("Do not schedule alarm without escalation", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		eOut := &message.EventerOut{
+			Point:  &common.DataPoint{TraceId: uuid.NewV7().String()},
+			Device: random.Device("dao-alert", createOrg.GetId()),
+			Rule:   createRule,
+		}
+
+		openLC, err := globalAleDAO.Open(ctx, &message.AlertLifecycle{
+			OrgId: createOrg.GetId(), UniqId: eOut.GetDevice().GetUniqId(),
+			AlarmId: uuid.NewV7().String(), TraceId: uuid.NewV7().String(),
+		})
+		t.Logf("openLC, err: %+v, %v", openLC, err)
+		require.NoError(t, err)
+
+		require.NoError(t, globalAleDAO.ScheduleEscalation(ctx, openLC, eOut))
+		require.Empty(t, claimLC(ctx, t, openLC.GetOpenedAt().AsTime().Add(
+			time.Hour), openLC.GetId()))
+	})
+}
@@ -0,0 +1,289 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const lifecycleCols = `
+id, org_id, uniq_id, alarm_id, state, COALESCE(assignee_id::text, ''),
+opened_at, COALESCE(acked_by::text, ''), acked_at, ack_note,
+COALESCE(resolved_by::text, ''), resolved_at, resolve_note, updated_at,
+trace_id
+`
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanLifecycle scans lifecycleCols into an alert lifecycle.
+func scanLifecycle(row scanner) (*message.AlertLifecycle, error) {
+	lc := &message.AlertLifecycle{}
+	var state string
+	var openedAt, updatedAt time.Time
+	var ackedAt, resolvedAt sql.NullTime
+
+	if err := row.Scan(&lc.Id, &lc.OrgId, &lc.UniqId, &lc.AlarmId, &state,
+		&lc.AssigneeId, &openedAt, &lc.AckedBy, &ackedAt, &lc.AckNote,
+		&lc.ResolvedBy, &resolvedAt, &lc.ResolveNote, &updatedAt,
+		&lc.TraceId); err != nil {
+		return nil, err
+	}
+
+	lc.State = message.AlertState(message.AlertState_value[state])
+	lc.OpenedAt = timestamppb.New(openedAt)
+	if ackedAt.Valid {
+		lc.AckedAt = timestamppb.New(ackedAt.Time)
+	}
+	if resolvedAt.Valid {
+		lc.ResolvedAt = timestamppb.New(resolvedAt.Time)
+	}
+	lc.UpdatedAt = timestamppb.New(updatedAt)
+
+	return lc, nil
+}
+
+// The no-op update on conflict returns the existing unresolved row.
+const openLifecycle = `
+INSERT INTO alert_lifecycles (org_id, uniq_id, alarm_id, state, opened_at,
+ack_note, resolve_note, updated_at, trace_id)
+VALUES ($1, $2, $3, 'OPEN', $4, '', '', $4, $5)
+ON CONFLICT (org_id, uniq_id, alarm_id) WHERE state != 'RESOLVED'
+DO UPDATE SET updated_at = alert_lifecycles.updated_at
+RETURNING ` + lifecycleCols
+
+// Open opens an alert lifecycle for a device and alarm, or retrieves the
+// unresolved alert lifecycle if one exists.
+func (d *DAO) Open(ctx context.Context, lc *message.AlertLifecycle) (
+	*message.AlertLifecycle, error,
+) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	openLC, err := scanLifecycle(d.rw.QueryRowContext(ctx, openLifecycle,
+		lc.GetOrgId(), strings.ToLower(lc.GetUniqId()), lc.GetAlarmId(), now,
+		lc.GetTraceId()))
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return openLC, nil
+}
+
+const readLifecycle = `
+SELECT ` + lifecycleCols + `
+FROM alert_lifecycles
+WHERE (id, org_id) = ($1, $2)
+`
+
+// ReadLifecycle retrieves an alert lifecycle by ID and org ID.
+func (d *DAO) ReadLifecycle(ctx context.Context, lcID, orgID string) (
+	*message.AlertLifecycle, error,
+) {
+	lc, err := scanLifecycle(d.ro.QueryRowContext(ctx, readLifecycle, lcID,
+		orgID))
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return lc, nil
+}
+
+const acknowledgeLifecycle = `
+UPDATE alert_lifecycles
+SET state = 'ACKNOWLEDGED', acked_by = NULLIF($1, '')::uuid, acked_at = $2,
+ack_note = $3, updated_at = $2
+WHERE (id, org_id) = ($4, $5)
+AND state = 'OPEN'
+RETURNING ` + lifecycleCols
+
+// Acknowledge acknowledges an open alert lifecycle by ID and org ID. Alert
+// lifecycles that are not open are not updated and result in dao.ErrNotFound.
+// An empty userID, such as for API keys, leaves the acknowledging user unset.
+func (d *DAO) Acknowledge(
+	ctx context.Context, lcID, orgID, userID, note string,
+) (*message.AlertLifecycle, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	lc, err := scanLifecycle(d.rw.QueryRowContext(ctx, acknowledgeLifecycle,
+		userID, now, note, lcID, orgID))
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return lc, nil
+}
+
+const resolveLifecycle = `
+UPDATE alert_lifecycles
+SET state = 'RESOLVED', resolved_by = NULLIF($1, '')::uuid, resolved_at = $2,
+resolve_note = $3, updated_at = $2
+WHERE (id, org_id) = ($4, $5)
+AND state != 'RESOLVED'
+RETURNING ` + lifecycleCols
+
+// Resolve resolves an unresolved alert lifecycle by ID and org ID. Resolved
+// alert lifecycles are not updated and result in dao.ErrNotFound.
+func (d *DAO) Resolve(
+	ctx context.Context, lcID, orgID, userID, note string,
+) (*message.AlertLifecycle, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	lc, err := scanLifecycle(d.rw.QueryRowContext(ctx, resolveLifecycle,
+		userID, now, note, lcID, orgID))
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return lc, nil
+}
+
+const assignLifecycle = `
+UPDATE alert_lifecycles
+SET assignee_id = u.id, updated_at = $1
+FROM users u
+WHERE (u.id, u.org_id) = ($2, $4)
+AND (alert_lifecycles.id, alert_lifecycles.org_id) = ($3, $4)
+AND alert_lifecycles.state != 'RESOLVED'
+RETURNING ` + lifecycleCols
+
+const unassignLifecycle = `
+UPDATE alert_lifecycles
+SET assignee_id = NULL, updated_at = $1
+WHERE (id, org_id) = ($2, $3)
+AND state != 'RESOLVED'
+RETURNING ` + lifecycleCols
+
+// Assign assigns an unresolved alert lifecycle by ID and org ID to a user in
+// the same org. An empty assigneeID unassigns the alert lifecycle.
+func (d *DAO) Assign(
+	ctx context.Context, lcID, orgID, assigneeID string,
+) (*message.AlertLifecycle, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	var row *sql.Row
+	if assigneeID == "" {
+		row = d.rw.QueryRowContext(ctx, unassignLifecycle, now, lcID, orgID)
+	} else {
+		row = d.rw.QueryRowContext(ctx, assignLifecycle, now, assigneeID, lcID,
+			orgID)
+	}
+
+	lc, err := scanLifecycle(row)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return lc, nil
+}
+
+const listOpenLifecycles = `
+SELECT ` + lifecycleCols + `
+FROM alert_lifecycles
+WHERE org_id = $1
+AND state != 'RESOLVED'
+`
+
+const listOpenLifecyclesUniqID = `
+AND uniq_id = $%d
+`
+
+const listOpenLifecyclesAlarmID = `
+AND alarm_id = $%d
+`
+
+const listOpenLifecyclesOrder = `
+ORDER BY opened_at DESC
+`
+
+// ListOpen retrieves all unresolved alert lifecycles by org ID, optional
+// UniqID, and optional alarm ID, in descending open timestamp order.
+func (d *DAO) ListOpen(
+	ctx context.Context, orgID, uniqID, alarmID string,
+) ([]*message.AlertLifecycle, error) {
+	// Build list query.
+	query := listOpenLifecycles
+	args := []any{orgID}
+
+	if uniqID != "" {
+		args = append(args, strings.ToLower(uniqID))
+		query += fmt.Sprintf(listOpenLifecyclesUniqID, len(args))
+	}
+
+	if alarmID != "" {
+		args = append(args, alarmID)
+		query += fmt.Sprintf(listOpenLifecyclesAlarmID, len(args))
+	}
+
+	query += listOpenLifecyclesOrder
+
+	// Run list query.
+	rows, err := d.ro.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ListOpen rows.Close: %v", err)
+		}
+	}()
+
+	var lcs []*message.AlertLifecycle
+	for rows.Next() {
+		lc, err := scanLifecycle(rows)
+		if err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		lcs = append(lcs, lc)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return lcs, nil
+}
+
+const purgeLifecycles = `
+DELETE FROM alert_lifecycles
+WHERE ctid = ANY(ARRAY(
+  SELECT ctid
+  FROM alert_lifecycles
+  WHERE org_id = $1
+  AND state = 'RESOLVED'
+  AND resolved_at < $2
+  LIMIT $3
+))
+`
+
+// PurgeLifecycles deletes up to limit resolved alert lifecycles by org ID that
+// were resolved before the provided time and returns the count of deleted
+// alert lifecycles. Unresolved alert lifecycles are never purged.
+func (d *DAO) PurgeLifecycles(
+	ctx context.Context, orgID string, before time.Time, limit int32,
+) (int64, error) {
+	res, err := d.rw.ExecContext(ctx, purgeLifecycles, orgID, before, limit)
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,310 @@
+//go:build !unit
+
+package alert
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+)
+
+func TestOpenLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	lc := &message.AlertLifecycle{
+		OrgId: createOrg.GetId(), UniqId: "dao-alert-" + random.String(16),
+		AlarmId: uuid.NewV7().String(), TraceId: uuid.NewV7().String(),
+	}
+
+	openLC, err := globalAleDAO.Open(ctx, lc)
+	t.Logf("openLC, err: %+v, %v", openLC, err)
+	require.NoError(t, err)
+	require.NotEmpty(t, openLC.GetId())
+	require.Equal(t, message.AlertState_OPEN, openLC.GetState())
+	require.WithinDuration(t, time.Now(), openLC.GetOpenedAt().AsTime(),
+		2*time.Second)
+	require.Nil(t, openLC.GetAckedAt())
+
+	// A second open retrieves the unresolved alert lifecycle.
+	reopenLC, err := globalAleDAO.Open(ctx, &message.AlertLifecycle{
+		OrgId: lc.GetOrgId(), UniqId: lc.GetUniqId(), AlarmId: lc.GetAlarmId(),
+		TraceId: uuid.NewV7().String(),
+	})
+	t.Logf("reopenLC, err: %+v, %v", reopenLC, err)
+	require.NoError(t, err)
+	require.EqualExportedValues(t, openLC, reopenLC)
+
+	readLC, err := globalAleDAO.ReadLifecycle(ctx, openLC.GetId(),
+		createOrg.GetId())
+	t.Logf("readLC, err: %+v, %v", readLC, err)
+	require.NoError(t, err)
+	require.EqualExportedValues(t, openLC, readLC)
+
+	// Once resolved, a new alert lifecycle is opened.
+	_, err = globalAleDAO.Resolve(ctx, openLC.GetId(), createOrg.GetId(),
+		uuid.NewV7().String(), "")
+	require.NoError(t, err)
+
+	newLC, err := globalAleDAO.Open(ctx, lc)
+	t.Logf("newLC, err: %+v, %v", newLC, err)
+	require.NoError(t, err)
+	require.NotEqual(t, openLC.GetId(), newLC.GetId())
+
+	badLC, err := globalAleDAO.Open(ctx, &message.AlertLifecycle{
+		OrgId: createOrg.GetId(), UniqId: lc.GetUniqId(),
+		AlarmId: lc.GetAlarmId(), TraceId: random.String(10),
+	})
+	t.Logf("badLC, err: %+v, %v", badLC, err)
+	require.Nil(t, badLC)
+	require.ErrorIs(t, err, dao.ErrInvalidFormat)
+
+	readLC, err = globalAleDAO.ReadLifecycle(ctx, uuid.NewV7().String(),
+		createOrg.GetId())
+	t.Logf("readLC, err: %+v, %v", readLC, err)
+	require.Nil(t, readLC)
+	require.Equal(t, dao.ErrNotFound, err)
+}
+
+func TestAcknowledgeResolveLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	openLC, err := globalAleDAO.Open(ctx, &message.AlertLifecycle{
+		OrgId: createOrg.GetId(), UniqId: "dao-alert-" + random.String(16),
+		AlarmId: uuid.NewV7().String(), TraceId: uuid.NewV7().String(),
+	})
+	t.Logf("openLC, err: %+v, %v", openLC, err)
+	require.NoError(t, err)
+
+	userID := uuid.NewV7().String()
+
+	ackLC, err := globalAleDAO.Acknowledge(ctx, openLC.GetId(),
+		createOrg.GetId(), userID, "dao-alert-ack")
+	t.Logf("ackLC, err: %+v, %v", ackLC, err)
+	require.NoError(t, err)
+	require.Equal(t, message.AlertState_ACKNOWLEDGED, ackLC.GetState())
+	require.Equal(t, userID, ackLC.GetAckedBy())
+	require.Equal(t, "dao-alert-ack", ackLC.GetAckNote())
+	require.WithinDuration(t, time.Now(), ackLC.GetAckedAt().AsTime(),
+		2*time.Second)
+
+	// Only open alert lifecycles can be acknowledged.
+	_, err = globalAleDAO.Acknowledge(ctx, openLC.GetId(), createOrg.GetId(),
+		userID, "")
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	resLC, err := globalAleDAO.Resolve(ctx, openLC.GetId(), createOrg.GetId(),
+		userID, "dao-alert-res")
+	t.Logf("resLC, err: %+v, %v", resLC, err)
+	require.NoError(t, err)
+	require.Equal(t, message.AlertState_RESOLVED, resLC.GetState())
+	require.Equal(t, userID, resLC.GetResolvedBy())
+	require.Equal(t, "dao-alert-res", resLC.GetResolveNote())
+	require.Equal(t, ackLC.GetAckedAt().AsTime(), resLC.GetAckedAt().AsTime())
+
+	_, err = globalAleDAO.Resolve(ctx, openLC.GetId(), createOrg.GetId(),
+		userID, "")
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	_, err = globalAleDAO.Acknowledge(ctx, uuid.NewV7().String(),
+		createOrg.GetId(), userID, "")
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+}
+
+func TestAssignLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createUser, err := globalUserDAO.Create(ctx, random.User("dao-alert",
+		createOrg.GetId()))
+	t.Logf("createUser, err: %+v, %v", createUser, err)
+	require.NoError(t, err)
+
+	openLC, err := globalAleDAO.Open(ctx, &message.AlertLifecycle{
+		OrgId: createOrg.GetId(), UniqId: "dao-alert-" + random.String(16),
+		AlarmId: uuid.NewV7().String(), TraceId: uuid.NewV7().String(),
+	})
+	t.Logf("openLC, err: %+v, %v", openLC, err)
+	require.NoError(t, err)
+
+	assignLC, err := globalAleDAO.Assign(ctx, openLC.GetId(),
+		createOrg.GetId(), createUser.GetId())
+	t.Logf("assignLC, err: %+v, %v", assignLC, err)
+	require.NoError(t, err)
+	require.Equal(t, createUser.GetId(), assignLC.GetAssigneeId())
+
+	unassignLC, err := globalAleDAO.Assign(ctx, openLC.GetId(),
+		createOrg.GetId(), "")
+	t.Logf("unassignLC, err: %+v, %v", unassignLC, err)
+	require.NoError(t, err)
+	require.Empty(t, unassignLC.GetAssigneeId())
+
+	// Users of other orgs cannot be assigned.
+	otherOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("otherOrg, err: %+v, %v", otherOrg, err)
+	require.NoError(t, err)
+
+	otherUser, err := globalUserDAO.Create(ctx, random.User("dao-alert",
+		otherOrg.GetId()))
+	t.Logf("otherUser, err: %+v, %v", otherUser, err)
+	require.NoError(t, err)
+
+	_, err = globalAleDAO.Assign(ctx, openLC.GetId(), createOrg.GetId(),
+		otherUser.GetId())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	_, err = globalAleDAO.Assign(ctx, openLC.GetId(), createOrg.GetId(),
+		uuid.NewV7().String())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+}
+
+func TestListOpenLifecycles(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	uniqID := "dao-alert-" + random.String(16)
+	alarmID := uuid.NewV7().String()
+
+	var lcIDs []string
+	for _, lc := range []*message.AlertLifecycle{
+		{UniqId: uniqID, AlarmId: alarmID},
+		{UniqId: uniqID, AlarmId: uuid.NewV7().String()},
+		{UniqId: "dao-alert-" + random.String(16), AlarmId: alarmID},
+	} {
+		lc.OrgId = createOrg.GetId()
+		lc.TraceId = uuid.NewV7().String()
+
+		openLC, err := globalAleDAO.Open(ctx, lc)
+		t.Logf("openLC, err: %+v, %v", openLC, err)
+		require.NoError(t, err)
+		lcIDs = append(lcIDs, openLC.GetId())
+	}
+
+	// Resolved alert lifecycles are not listed.
+	_, err = globalAleDAO.Resolve(ctx, lcIDs[2], createOrg.GetId(),
+		uuid.NewV7().String(), "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		inpUniqID  string
+		inpAlarmID string
+		res        []string
+	}{
+		{"", "", []string{lcIDs[1], lcIDs[0]}},
+		{uniqID, "", []string{lcIDs[1], lcIDs[0]}},
+		{"", alarmID, []string{lcIDs[0]}},
+		{uniqID, alarmID, []string{lcIDs[0]}},
+		{"dao-alert-" + random.String(16), "", nil},
+	}
+
+	for _, test := range tests {
+		t.Run("List open alert lifecycles", func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			listLCs, err := globalAleDAO.ListOpen(ctx, createOrg.GetId(),
+				test.inpUniqID, test.inpAlarmID)
+			t.Logf("listLCs, err: %+v, %v", listLCs, err)
+			require.NoError(t, err)
+			require.Len(t, listLCs, len(test.res))
+
+			for i, lc := range listLCs {
+				require.Equal(t, test.res[i], lc.GetId())
+			}
+		})
+	}
+}
+
+func TestPurgeLifecycles(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	var lcIDs []string
+	for range 4 {
+		openLC, err := globalAleDAO.Open(ctx, &message.AlertLifecycle{
+			OrgId: createOrg.GetId(), UniqId: "dao-alert-" + random.String(16),
+			AlarmId: uuid.NewV7().String(), TraceId: uuid.NewV7().String(),
+		})
+		t.Logf("openLC, err: %+v, %v", openLC, err)
+		require.NoError(t, err)
+		lcIDs = append(lcIDs, openLC.GetId())
+	}
+
+	// Resolve all but the last alert lifecycle, which is never purged.
+	for _, lcID := range lcIDs[:3] {
+		_, err := globalAleDAO.Resolve(ctx, lcID, createOrg.GetId(),
+			uuid.NewV7().String(), "")
+		require.NoError(t, err)
+	}
+
+	time.Sleep(time.Millisecond)
+	before := time.Now().UTC()
+
+	for _, exp := range []int64{2, 1, 0} {
+		count, err := globalAleDAO.PurgeLifecycles(ctx, createOrg.GetId(),
+			before, 2)
+		t.Logf("count, err: %v, %v", count, err)
+		require.NoError(t, err)
+		require.Equal(t, exp, count)
+	}
+
+	for i, lcID := range lcIDs {
+		readLC, err := globalAleDAO.ReadLifecycle(ctx, lcID, createOrg.GetId())
+		t.Logf("readLC, err: %+v, %v", readLC, err)
+		if i < 3 {
+			require.Equal(t, dao.ErrNotFound, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+
+	count, err := globalAleDAO.PurgeLifecycles(ctx, random.String(10),
+		time.Now(), 2)
+	t.Logf("count, err: %v, %v", count, err)
+	require.Zero(t, count)
+	require.ErrorIs(t, err, dao.ErrInvalidFormat)
+}
@@ -8,15 +8,21 @@ import (
 	"testing"
 
 	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/alarm"
 	"github.com/thingspect/atlas/pkg/dao/device"
 	"github.com/thingspect/atlas/pkg/dao/org"
+	"github.com/thingspect/atlas/pkg/dao/rule"
+	"github.com/thingspect/atlas/pkg/dao/user"
 	"github.com/thingspect/atlas/pkg/test/config"
 )
 
 var (
-	globalOrgDAO *org.DAO
-	globalDevDAO *device.DAO
-	globalAleDAO *DAO
+	globalOrgDAO   *org.DAO
+	globalDevDAO   *device.DAO
+	globalUserDAO  *user.DAO
+	globalRuleDAO  *rule.DAO
+	globalAlarmDAO *alarm.DAO
+	globalAleDAO   *DAO
 )
 
 func TestMain(m *testing.M) {
@@ -30,6 +36,9 @@ func TestMain(m *testing.M) {
 	}
 	globalOrgDAO = org.NewDAO(pg, pg)
 	globalDevDAO = device.NewDAO(pg, pg, nil, 0)
+	globalUserDAO = user.NewDAO(pg, pg)
+	globalRuleDAO = rule.NewDAO(pg, pg)
+	globalAlarmDAO = alarm.NewDAO(pg, pg)
 	globalAleDAO = NewDAO(pg, pg)
 
 	os.Exit(m.Run())
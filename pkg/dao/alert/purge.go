@@ -0,0 +1,37 @@
+package alert
+
+import (
+	"context"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/dao"
+)
+
+const purgeAlerts = `
+DELETE FROM alerts
+WHERE ctid = ANY(ARRAY(
+  SELECT ctid
+  FROM alerts
+  WHERE org_id = $1
+  AND created_at < $2
+  LIMIT $3
+))
+`
+
+// Purge deletes up to limit alerts by org ID that were created before the
+// provided time and returns the count of deleted alerts.
+func (d *DAO) Purge(
+	ctx context.Context, orgID string, before time.Time, limit int32,
+) (int64, error) {
+	res, err := d.rw.ExecContext(ctx, purgeAlerts, orgID, before, limit)
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,70 @@
+//go:build !unit
+
+package alert
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+)
+
+func TestPurge(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Purge alerts in batches", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-alert"))
+		t.Logf("createOrg, err: %+v, %v", createOrg, err)
+		require.NoError(t, err)
+
+		for range 3 {
+			err := globalAleDAO.Create(ctx, random.Alert("dao-alert",
+				createOrg.GetId()))
+			t.Logf("err: %v", err)
+			require.NoError(t, err)
+		}
+
+		time.Sleep(time.Millisecond)
+		before := time.Now().UTC()
+		time.Sleep(time.Millisecond)
+
+		keep := random.Alert("dao-alert", createOrg.GetId())
+		err = globalAleDAO.Create(ctx, keep)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		for _, exp := range []int64{2, 1, 0} {
+			count, err := globalAleDAO.Purge(ctx, createOrg.GetId(), before, 2)
+			t.Logf("count, err: %v, %v", count, err)
+			require.NoError(t, err)
+			require.Equal(t, exp, count)
+		}
+
+		listAlerts, err := globalAleDAO.List(ctx, createOrg.GetId(), "", "",
+			"", "", time.Now(), before.Add(-time.Minute), nil)
+		t.Logf("listAlerts, err: %+v, %v", listAlerts, err)
+		require.NoError(t, err)
+		require.Len(t, listAlerts, 1)
+		require.EqualExportedValues(t, keep, listAlerts[0])
+	})
+
+	t.Run("Purge alerts by invalid org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		count, err := globalAleDAO.Purge(ctx, random.String(10), time.Now(), 2)
+		t.Logf("count, err: %v, %v", count, err)
+		require.Zero(t, count)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+}
@@ -0,0 +1,189 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const createCommand = `
+INSERT INTO commands (org_id, uniq_id, payload, f_port, status, error,
+lora_queue_id, expires_at, created_at, updated_at, trace_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id
+`
+
+// Create creates a command in the database.
+func (d *DAO) Create(ctx context.Context, cmd *message.Command) (
+	*message.Command, error,
+) {
+	cmd.UniqId = strings.ToLower(cmd.GetUniqId())
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	cmd.CreatedAt = timestamppb.New(now)
+	cmd.UpdatedAt = timestamppb.New(now)
+
+	if err := d.rw.QueryRowContext(ctx, createCommand, cmd.GetOrgId(),
+		cmd.GetUniqId(), cmd.GetPayload(), cmd.GetFPort(),
+		cmd.GetStatus().String(), cmd.GetError(), cmd.GetLoraQueueId(),
+		cmd.GetExpiresAt().AsTime(), now, now,
+		cmd.GetTraceId()).Scan(&cmd.Id); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return cmd, nil
+}
+
+const readCommand = `
+SELECT id, org_id, uniq_id, payload, f_port, status, error, lora_queue_id,
+expires_at, created_at, updated_at, trace_id
+FROM commands
+WHERE (id, org_id) = ($1, $2)
+`
+
+// Read retrieves a command by ID and org ID.
+func (d *DAO) Read(ctx context.Context, cmdID, orgID string) (
+	*message.Command, error,
+) {
+	cmd := &message.Command{}
+	var status string
+	var expiresAt, createdAt, updatedAt time.Time
+
+	if err := d.ro.QueryRowContext(ctx, readCommand, cmdID, orgID).Scan(
+		&cmd.Id, &cmd.OrgId, &cmd.UniqId, &cmd.Payload, &cmd.FPort, &status,
+		&cmd.Error, &cmd.LoraQueueId, &expiresAt, &createdAt, &updatedAt,
+		&cmd.TraceId); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	cmd.Status = message.CommandStatus(message.CommandStatus_value[status])
+	cmd.ExpiresAt = timestamppb.New(expiresAt)
+	cmd.CreatedAt = timestamppb.New(createdAt)
+	cmd.UpdatedAt = timestamppb.New(updatedAt)
+
+	return cmd, nil
+}
+
+const updateCommandStatusByID = `
+UPDATE commands
+SET status = $1, error = $2,
+lora_queue_id = COALESCE(NULLIF($3, ''), lora_queue_id), updated_at = $4
+WHERE (id, org_id, uniq_id) = ($5, $6, $7)
+AND status IN ('QUEUED', 'SENT')
+RETURNING id
+`
+
+const updateCommandStatusByLoRaQueueID = `
+UPDATE commands
+SET status = $1, error = $2, updated_at = $3
+WHERE lora_queue_id = $4
+AND status IN ('QUEUED', 'SENT')
+RETURNING id
+`
+
+// UpdateStatus updates the status of an outstanding command by acknowledgment.
+// Commands are matched by ID, org ID, and unique ID, if present, or by LoRaWAN
+// queue item ID. Commands that have already been acknowledged, failed, or expired are not
+// updated and result in dao.ErrNotFound.
+func (d *DAO) UpdateStatus(ctx context.Context, ack *message.CommandAck) error {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	var cmdID string
+
+	if ack.GetId() != "" {
+		return dao.DBToSentinel(d.rw.QueryRowContext(ctx,
+			updateCommandStatusByID, ack.GetStatus().String(), ack.GetError(),
+			ack.GetLoraQueueId(), now, ack.GetId(), ack.GetOrgId(),
+			strings.ToLower(ack.GetUniqId())).Scan(&cmdID))
+	}
+
+	if ack.GetLoraQueueId() == "" {
+		return dao.ErrNotFound
+	}
+
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx,
+		updateCommandStatusByLoRaQueueID, ack.GetStatus().String(),
+		ack.GetError(), now, ack.GetLoraQueueId()).Scan(&cmdID))
+}
+
+const expireCommands = `
+UPDATE commands
+SET status = 'EXPIRED', updated_at = $1
+WHERE status IN ('QUEUED', 'SENT')
+AND expires_at <= $1
+`
+
+// Expire marks outstanding commands that expired at or before the provided
+// time as expired and returns the count of expired commands.
+func (d *DAO) Expire(ctx context.Context, now time.Time) (int64, error) {
+	res, err := d.rw.ExecContext(ctx, expireCommands, now)
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	return count, nil
+}
+
+const listCommands = `
+SELECT id, org_id, uniq_id, payload, f_port, status, error, lora_queue_id,
+expires_at, created_at, updated_at, trace_id
+FROM commands
+WHERE (org_id, uniq_id) = ($1, $2)
+AND created_at <= $3
+AND created_at > $4
+ORDER BY created_at DESC
+`
+
+// List retrieves all commands by org ID, UniqID, and [end, start) times.
+func (d *DAO) List(
+	ctx context.Context, orgID, uniqID string, end, start time.Time,
+) ([]*message.Command, error) {
+	rows, err := d.ro.QueryContext(ctx, listCommands, orgID,
+		strings.ToLower(uniqID), end, start)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("List rows.Close: %v", err)
+		}
+	}()
+
+	var cmds []*message.Command
+	for rows.Next() {
+		cmd := &message.Command{}
+		var status string
+		var expiresAt, createdAt, updatedAt time.Time
+
+		if err = rows.Scan(&cmd.Id, &cmd.OrgId, &cmd.UniqId, &cmd.Payload,
+			&cmd.FPort, &status, &cmd.Error, &cmd.LoraQueueId, &expiresAt,
+			&createdAt, &updatedAt, &cmd.TraceId); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		cmd.Status = message.CommandStatus(message.CommandStatus_value[status])
+		cmd.ExpiresAt = timestamppb.New(expiresAt)
+		cmd.CreatedAt = timestamppb.New(createdAt)
+		cmd.UpdatedAt = timestamppb.New(updatedAt)
+		cmds = append(cmds, cmd)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return cmds, nil
+}
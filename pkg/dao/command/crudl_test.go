@@ -0,0 +1,379 @@
+//go:build !unit
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testTimeout = 6 * time.Second
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-command"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	t.Run("Create valid command", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		cmd := random.Command("dao-command", createOrg.GetId())
+		createCmd, _ := proto.Clone(cmd).(*message.Command)
+
+		createCmd, err := globalCmdDAO.Create(ctx, createCmd)
+		t.Logf("cmd, createCmd, err: %+v, %+v, %v", cmd, createCmd, err)
+		require.NoError(t, err)
+		require.NotEmpty(t, createCmd.GetId())
+		require.Equal(t, cmd.GetPayload(), createCmd.GetPayload())
+		require.Equal(t, cmd.GetStatus(), createCmd.GetStatus())
+		require.WithinDuration(t, time.Now(), createCmd.GetCreatedAt().AsTime(),
+			2*time.Second)
+		require.Equal(t, createCmd.GetCreatedAt(), createCmd.GetUpdatedAt())
+	})
+
+	t.Run("Create invalid command", func(t *testing.T) {
+		t.Parallel()
+
+		badUniqID := random.Command("dao-command", createOrg.GetId())
+		badUniqID.UniqId = "dao-command-" + random.String(40)
+
+		badFPort := random.Command("dao-command", createOrg.GetId())
+		badFPort.FPort = 224
+
+		badOrgID := random.Command("dao-command", uuid.NewV7().String())
+
+		tests := []struct {
+			inpCmd *message.Command
+			err    error
+		}{
+			{badUniqID, dao.ErrInvalidFormat},
+			{badFPort, dao.ErrInvalidFormat},
+			{badOrgID, dao.ErrInvalidFormat},
+		}
+
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("Cannot create %+v", test), func(t *testing.T) {
+				t.Parallel()
+
+				ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+				defer cancel()
+
+				createCmd, err := globalCmdDAO.Create(ctx, test.inpCmd)
+				t.Logf("createCmd, err: %+v, %v", createCmd, err)
+				require.Nil(t, createCmd)
+				require.ErrorIs(t, err, test.err)
+			})
+		}
+	})
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-command"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createCmd, err := globalCmdDAO.Create(ctx, random.Command("dao-command",
+		createOrg.GetId()))
+	t.Logf("createCmd, err: %+v, %v", createCmd, err)
+	require.NoError(t, err)
+
+	t.Run("Read command by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readCmd, err := globalCmdDAO.Read(ctx, createCmd.GetId(),
+			createCmd.GetOrgId())
+		t.Logf("readCmd, err: %+v, %v", readCmd, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, createCmd, readCmd)
+	})
+
+	t.Run("Read command by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readCmd, err := globalCmdDAO.Read(ctx, uuid.NewV7().String(),
+			uuid.NewV7().String())
+		t.Logf("readCmd, err: %+v, %v", readCmd, err)
+		require.Nil(t, readCmd)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Reads are isolated by org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readCmd, err := globalCmdDAO.Read(ctx, createCmd.GetId(),
+			uuid.NewV7().String())
+		t.Logf("readCmd, err: %+v, %v", readCmd, err)
+		require.Nil(t, readCmd)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Read command by invalid ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readCmd, err := globalCmdDAO.Read(ctx, random.String(10),
+			createCmd.GetOrgId())
+		t.Logf("readCmd, err: %+v, %v", readCmd, err)
+		require.Nil(t, readCmd)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+}
+
+func TestUpdateStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-command"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	t.Run("Update status by ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createCmd, err := globalCmdDAO.Create(ctx, random.Command("dao-command",
+			createOrg.GetId()))
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.NoError(t, err)
+
+		ack := &message.CommandAck{
+			Id: createCmd.GetId(), OrgId: createCmd.GetOrgId(),
+			UniqId: createCmd.GetUniqId(),
+			Status: message.CommandStatus_FAILED, Error: "dao-command-error",
+		}
+
+		err = globalCmdDAO.UpdateStatus(ctx, ack)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		readCmd, err := globalCmdDAO.Read(ctx, createCmd.GetId(),
+			createCmd.GetOrgId())
+		t.Logf("readCmd, err: %+v, %v", readCmd, err)
+		require.NoError(t, err)
+		require.Equal(t, message.CommandStatus_FAILED, readCmd.GetStatus())
+		require.Equal(t, "dao-command-error", readCmd.GetError())
+		require.True(t, readCmd.GetUpdatedAt().AsTime().After(
+			readCmd.GetCreatedAt().AsTime()))
+
+		// Completed commands are not updated.
+		ack.Status = message.CommandStatus_ACKED
+		err = globalCmdDAO.UpdateStatus(ctx, ack)
+		t.Logf("err: %v", err)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Update status by LoRaWAN queue item ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createCmd, err := globalCmdDAO.Create(ctx, random.Command("dao-command",
+			createOrg.GetId()))
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.NoError(t, err)
+
+		loraQueueID := uuid.NewV7().String()
+		err = globalCmdDAO.UpdateStatus(ctx, &message.CommandAck{
+			Id: createCmd.GetId(), OrgId: createCmd.GetOrgId(),
+			UniqId: createCmd.GetUniqId(), LoraQueueId: loraQueueID,
+			Status: message.CommandStatus_QUEUED,
+		})
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		for _, status := range []message.CommandStatus{
+			message.CommandStatus_SENT, message.CommandStatus_ACKED,
+		} {
+			err = globalCmdDAO.UpdateStatus(ctx, &message.CommandAck{
+				LoraQueueId: loraQueueID, Status: status,
+			})
+			t.Logf("err: %v", err)
+			require.NoError(t, err)
+		}
+
+		readCmd, err := globalCmdDAO.Read(ctx, createCmd.GetId(),
+			createCmd.GetOrgId())
+		t.Logf("readCmd, err: %+v, %v", readCmd, err)
+		require.NoError(t, err)
+		require.Equal(t, message.CommandStatus_ACKED, readCmd.GetStatus())
+		require.Equal(t, loraQueueID, readCmd.GetLoraQueueId())
+	})
+
+	t.Run("Update status of unknown command", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createCmd, err := globalCmdDAO.Create(ctx, random.Command("dao-command",
+			createOrg.GetId()))
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.NoError(t, err)
+
+		for _, ack := range []*message.CommandAck{
+			{
+				Id: uuid.NewV7().String(), OrgId: createOrg.GetId(),
+				Status: message.CommandStatus_ACKED,
+			},
+			{
+				Id: createCmd.GetId(), OrgId: createCmd.GetOrgId(),
+				UniqId: "dao-command-" + random.String(16),
+				Status: message.CommandStatus_ACKED,
+			},
+			{
+				LoraQueueId: uuid.NewV7().String(),
+				Status:      message.CommandStatus_ACKED,
+			},
+			{Status: message.CommandStatus_ACKED},
+		} {
+			err := globalCmdDAO.UpdateStatus(ctx, ack)
+			t.Logf("err: %v", err)
+			require.Equal(t, dao.ErrNotFound, err)
+		}
+	})
+}
+
+func TestExpire(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-command"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	expCmd := random.Command("dao-command", createOrg.GetId())
+	expCmd.ExpiresAt = timestamppb.New(time.Now().Add(-time.Minute))
+	expCmd, err = globalCmdDAO.Create(ctx, expCmd)
+	t.Logf("expCmd, err: %+v, %v", expCmd, err)
+	require.NoError(t, err)
+
+	keepCmd, err := globalCmdDAO.Create(ctx, random.Command("dao-command",
+		createOrg.GetId()))
+	t.Logf("keepCmd, err: %+v, %v", keepCmd, err)
+	require.NoError(t, err)
+
+	count, err := globalCmdDAO.Expire(ctx, time.Now())
+	t.Logf("count, err: %v, %v", count, err)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(1))
+
+	readCmd, err := globalCmdDAO.Read(ctx, expCmd.GetId(), expCmd.GetOrgId())
+	t.Logf("readCmd, err: %+v, %v", readCmd, err)
+	require.NoError(t, err)
+	require.Equal(t, message.CommandStatus_EXPIRED, readCmd.GetStatus())
+
+	readCmd, err = globalCmdDAO.Read(ctx, keepCmd.GetId(), keepCmd.GetOrgId())
+	t.Logf("readCmd, err: %+v, %v", readCmd, err)
+	require.NoError(t, err)
+	require.Equal(t, message.CommandStatus_QUEUED, readCmd.GetStatus())
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-command"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	uniqID := "dao-command-" + random.String(16)
+	cmdIDs := make([]string, 0, 3)
+
+	for range 3 {
+		cmd := random.Command("dao-command", createOrg.GetId())
+		cmd.UniqId = uniqID
+
+		createCmd, err := globalCmdDAO.Create(ctx, cmd)
+		t.Logf("createCmd, err: %+v, %v", createCmd, err)
+		require.NoError(t, err)
+		cmdIDs = append(cmdIDs, createCmd.GetId())
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Run("List commands by valid UniqID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listCmds, err := globalCmdDAO.List(ctx, createOrg.GetId(), uniqID,
+			time.Now(), time.Now().Add(-15*time.Minute))
+		t.Logf("listCmds, err: %+v, %v", listCmds, err)
+		require.NoError(t, err)
+		require.Len(t, listCmds, 3)
+
+		// Commands are returned in descending timestamp order.
+		for i, cmd := range listCmds {
+			require.Equal(t, cmdIDs[len(cmdIDs)-1-i], cmd.GetId())
+		}
+	})
+
+	t.Run("Lists are isolated by org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listCmds, err := globalCmdDAO.List(ctx, uuid.NewV7().String(), uniqID,
+			time.Now(), time.Now().Add(-15*time.Minute))
+		t.Logf("listCmds, err: %+v, %v", listCmds, err)
+		require.NoError(t, err)
+		require.Empty(t, listCmds)
+	})
+
+	t.Run("List commands by invalid org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listCmds, err := globalCmdDAO.List(ctx, random.String(10), uniqID,
+			time.Now(), time.Now().Add(-15*time.Minute))
+		t.Logf("listCmds, err: %+v, %v", listCmds, err)
+		require.Nil(t, listCmds)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+}
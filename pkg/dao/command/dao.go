@@ -0,0 +1,21 @@
+// Package command provides functions to create and query device commands in
+// the database.
+package command
+
+import (
+	"database/sql"
+)
+
+// DAO contains functions to create and query device commands in the database.
+type DAO struct {
+	rw *sql.DB
+	ro *sql.DB
+}
+
+// NewDAO instantiates and returns a new DAO.
+func NewDAO(rw *sql.DB, ro *sql.DB) *DAO {
+	return &DAO{
+		rw: rw,
+		ro: ro,
+	}
+}
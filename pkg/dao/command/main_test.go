@@ -0,0 +1,33 @@
+//go:build !unit
+
+package command
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/org"
+	"github.com/thingspect/atlas/pkg/test/config"
+)
+
+var (
+	globalOrgDAO *org.DAO
+	globalCmdDAO *DAO
+)
+
+func TestMain(m *testing.M) {
+	// Set up Config.
+	testConfig := config.New()
+
+	// Set up database connection.
+	pg, err := dao.NewPgDB(testConfig.PgURI)
+	if err != nil {
+		log.Fatalf("TestMain dao.NewPgDB: %v", err)
+	}
+	globalOrgDAO = org.NewDAO(pg, pg)
+	globalCmdDAO = NewDAO(pg, pg)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,74 @@
+//go:build !unit
+
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+)
+
+func TestPurge(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Purge commands in batches", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-command"))
+		t.Logf("createOrg, err: %+v, %v", createOrg, err)
+		require.NoError(t, err)
+
+		uniqID := "dao-command-" + random.String(16)
+		for range 3 {
+			cmd := random.Command("dao-command", createOrg.GetId())
+			cmd.UniqId = uniqID
+
+			_, err := globalCmdDAO.Create(ctx, cmd)
+			t.Logf("err: %v", err)
+			require.NoError(t, err)
+		}
+
+		time.Sleep(time.Millisecond)
+		before := time.Now().UTC()
+		time.Sleep(time.Millisecond)
+
+		keep := random.Command("dao-command", createOrg.GetId())
+		keep.UniqId = uniqID
+		keep, err = globalCmdDAO.Create(ctx, keep)
+		t.Logf("keep, err: %+v, %v", keep, err)
+		require.NoError(t, err)
+
+		for _, exp := range []int64{2, 1, 0} {
+			count, err := globalCmdDAO.Purge(ctx, createOrg.GetId(), before, 2)
+			t.Logf("count, err: %v, %v", count, err)
+			require.NoError(t, err)
+			require.Equal(t, exp, count)
+		}
+
+		listCmds, err := globalCmdDAO.List(ctx, createOrg.GetId(), uniqID,
+			time.Now(), before.Add(-time.Minute))
+		t.Logf("listCmds, err: %+v, %v", listCmds, err)
+		require.NoError(t, err)
+		require.Len(t, listCmds, 1)
+		require.EqualExportedValues(t, keep, listCmds[0])
+	})
+
+	t.Run("Purge commands by invalid org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		count, err := globalCmdDAO.Purge(ctx, random.String(10), time.Now(), 2)
+		t.Logf("count, err: %v, %v", count, err)
+		require.Zero(t, count)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+}
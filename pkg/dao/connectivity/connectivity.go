@@ -0,0 +1,147 @@
+package connectivity
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// intervalSec is the expected reporting interval of a connectivity row c, by
+// device, or by the shortest interval of the tags of its device row d.
+const intervalSec = `
+COALESCE(
+  (SELECT i.interval_sec FROM connectivity_intervals i
+  WHERE (i.org_id, i.dev_id) = (c.org_id, c.dev_id)),
+  (SELECT min(i.interval_sec) FROM connectivity_intervals i
+  WHERE i.org_id = c.org_id AND i.tag = ANY (d.tags))
+)`
+
+const upsertSeen = `
+INSERT INTO connectivity (dev_id, org_id, last_seen_at, changed_at)
+VALUES ($1, $2, $3, $3)
+ON CONFLICT (dev_id) DO UPDATE
+SET last_seen_at = GREATEST(connectivity.last_seen_at, EXCLUDED.last_seen_at)
+`
+
+// Seen records that a device reported at the provided time. Earlier times do
+// not replace later ones.
+func (d *DAO) Seen(
+	ctx context.Context, devID, orgID string, seenAt time.Time,
+) error {
+	_, err := d.rw.ExecContext(ctx, upsertSeen, devID, orgID, seenAt)
+
+	return dao.DBToSentinel(err)
+}
+
+const readConnectivity = `
+SELECT c.dev_id, c.org_id, c.last_seen_at, c.offline, c.changed_at,
+` + intervalSec + `
+FROM connectivity c
+INNER JOIN devices d ON c.dev_id = d.id
+WHERE (c.dev_id, c.org_id) = ($1, $2)
+`
+
+// Read retrieves a device's connectivity by device ID and org ID.
+func (d *DAO) Read(ctx context.Context, devID, orgID string) (
+	*message.Connectivity, error,
+) {
+	conn := &message.Connectivity{}
+	var lastSeenAt, changedAt time.Time
+	var interval sql.NullInt32
+
+	if err := d.ro.QueryRowContext(ctx, readConnectivity, devID, orgID).Scan(
+		&conn.DevId, &conn.OrgId, &lastSeenAt, &conn.Offline, &changedAt,
+		&interval); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	conn.LastSeenAt = timestamppb.New(lastSeenAt)
+	conn.ChangedAt = timestamppb.New(changedAt)
+	if interval.Valid {
+		conn.Interval = durationpb.New(time.Duration(interval.Int32) *
+			time.Second)
+	}
+
+	return conn, nil
+}
+
+const markOffline = `
+UPDATE connectivity c
+SET offline = true, changed_at = $1
+FROM devices d
+WHERE c.dev_id = d.id
+AND NOT c.offline
+AND d.status = 'ACTIVE'
+AND c.last_seen_at + make_interval(secs => ` + intervalSec + `) < $1
+RETURNING c.dev_id, c.org_id, c.last_seen_at, c.offline, c.changed_at
+`
+
+// MarkOffline marks online devices that have missed their expected reporting
+// interval as of the provided time as offline, and returns them.
+func (d *DAO) MarkOffline(ctx context.Context, now time.Time) (
+	[]*message.Connectivity, error,
+) {
+	return d.mark(ctx, markOffline, now)
+}
+
+const markOnline = `
+UPDATE connectivity
+SET offline = false, changed_at = $1
+WHERE offline
+AND last_seen_at > changed_at
+RETURNING dev_id, org_id, last_seen_at, offline, changed_at
+`
+
+// MarkOnline marks offline devices that have reported since they were marked
+// offline as online, and returns them.
+func (d *DAO) MarkOnline(ctx context.Context, now time.Time) (
+	[]*message.Connectivity, error,
+) {
+	return d.mark(ctx, markOnline, now)
+}
+
+// mark executes a connectivity update query and returns the updated rows.
+func (d *DAO) mark(ctx context.Context, query string, now time.Time) (
+	[]*message.Connectivity, error,
+) {
+	rows, err := d.rw.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("mark rows.Close: %v", err)
+		}
+	}()
+
+	var conns []*message.Connectivity
+	for rows.Next() {
+		conn := &message.Connectivity{}
+		var lastSeenAt, changedAt time.Time
+
+		if err = rows.Scan(&conn.DevId, &conn.OrgId, &lastSeenAt,
+			&conn.Offline, &changedAt); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		conn.LastSeenAt = timestamppb.New(lastSeenAt)
+		conn.ChangedAt = timestamppb.New(changedAt)
+		conns = append(conns, conn)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return conns, nil
+}
@@ -0,0 +1,159 @@
+//go:build !unit
+
+package connectivity
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const testTimeout = 6 * time.Second
+
+func TestSeenRead(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-conn"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	t.Run("Seen and read by valid device", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createDev, err := globalDevDAO.Create(ctx, random.Device("dao-conn",
+			createOrg.GetId()))
+		t.Logf("createDev, err: %+v, %v", createDev, err)
+		require.NoError(t, err)
+
+		inter, err := globalConnDAO.UpsertInterval(ctx,
+			&message.ConnectivityInterval{
+				OrgId: createOrg.GetId(), Tag: createDev.GetTags()[0],
+				Interval: durationpb.New(time.Hour),
+			})
+		t.Logf("inter, err: %+v, %v", inter, err)
+		require.NoError(t, err)
+
+		seenAt := time.Now().UTC().Truncate(time.Microsecond)
+		require.NoError(t, globalConnDAO.Seen(ctx, createDev.GetId(),
+			createOrg.GetId(), seenAt))
+		require.NoError(t, globalConnDAO.Seen(ctx, createDev.GetId(),
+			createOrg.GetId(), seenAt.Add(-time.Minute)))
+
+		readConn, err := globalConnDAO.Read(ctx, createDev.GetId(),
+			createOrg.GetId())
+		t.Logf("readConn, err: %+v, %v", readConn, err)
+		require.NoError(t, err)
+		require.Equal(t, seenAt, readConn.GetLastSeenAt().AsTime())
+		require.False(t, readConn.GetOffline())
+		require.Equal(t, time.Hour, readConn.GetInterval().AsDuration())
+	})
+
+	t.Run("Seen by unknown device", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		err := globalConnDAO.Seen(ctx, uuid.NewV7().String(),
+			createOrg.GetId(), time.Now())
+		t.Logf("err: %v", err)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+
+	t.Run("Read by unknown device", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readConn, err := globalConnDAO.Read(ctx, uuid.NewV7().String(),
+			createOrg.GetId())
+		t.Logf("readConn, err: %+v, %v", readConn, err)
+		require.Nil(t, readConn)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestMarkOfflineOnline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-conn"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	dev := random.Device("dao-conn", createOrg.GetId())
+	dev.Status = api.Status_ACTIVE
+	createDev, err := globalDevDAO.Create(ctx, dev)
+	t.Logf("createDev, err: %+v, %v", createDev, err)
+	require.NoError(t, err)
+
+	inter, err := globalConnDAO.UpsertInterval(ctx,
+		&message.ConnectivityInterval{
+			OrgId: createOrg.GetId(), DevId: createDev.GetId(),
+			Interval: durationpb.New(5 * time.Minute),
+		})
+	t.Logf("inter, err: %+v, %v", inter, err)
+	require.NoError(t, err)
+
+	seenAt := time.Now().UTC().Add(-10 * time.Minute)
+	require.NoError(t, globalConnDAO.Seen(ctx, createDev.GetId(),
+		createOrg.GetId(), seenAt))
+
+	// containsDev returns whether a list of connectivity contains createDev.
+	containsDev := func(conns []*message.Connectivity) bool {
+		for _, conn := range conns {
+			if conn.GetDevId() == createDev.GetId() {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	offConns, err := globalConnDAO.MarkOffline(ctx, time.Now())
+	t.Logf("offConns, err: %+v, %v", offConns, err)
+	require.NoError(t, err)
+	require.True(t, containsDev(offConns))
+
+	// Repeated marks do not return the device.
+	offConns, err = globalConnDAO.MarkOffline(ctx, time.Now())
+	t.Logf("offConns, err: %+v, %v", offConns, err)
+	require.NoError(t, err)
+	require.False(t, containsDev(offConns))
+
+	onConns, err := globalConnDAO.MarkOnline(ctx, time.Now())
+	t.Logf("onConns, err: %+v, %v", onConns, err)
+	require.NoError(t, err)
+	require.False(t, containsDev(onConns))
+
+	require.NoError(t, globalConnDAO.Seen(ctx, createDev.GetId(),
+		createOrg.GetId(), time.Now().Add(time.Second)))
+
+	onConns, err = globalConnDAO.MarkOnline(ctx, time.Now())
+	t.Logf("onConns, err: %+v, %v", onConns, err)
+	require.NoError(t, err)
+	require.True(t, containsDev(onConns))
+
+	readConn, err := globalConnDAO.Read(ctx, createDev.GetId(),
+		createOrg.GetId())
+	t.Logf("readConn, err: %+v, %v", readConn, err)
+	require.NoError(t, err)
+	require.False(t, readConn.GetOffline())
+}
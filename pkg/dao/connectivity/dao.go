@@ -0,0 +1,31 @@
+// Package connectivity provides functions to track device connectivity and
+// expected reporting intervals in the database.
+package connectivity
+
+import (
+	"database/sql"
+)
+
+// Constants used for connectivity data points, which are published when a
+// device goes offline or comes back online. Rules on Attr are evaluated as for
+// any other attribute, such as 'point.Val == "offline"'.
+const (
+	Attr    = "connectivity"
+	Offline = "offline"
+	Online  = "online"
+)
+
+// DAO contains functions to track device connectivity and expected reporting
+// intervals in the database.
+type DAO struct {
+	rw *sql.DB
+	ro *sql.DB
+}
+
+// NewDAO instantiates and returns a new DAO.
+func NewDAO(rw *sql.DB, ro *sql.DB) *DAO {
+	return &DAO{
+		rw: rw,
+		ro: ro,
+	}
+}
@@ -0,0 +1,125 @@
+package connectivity
+
+import (
+	"context"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const upsertDevInterval = `
+INSERT INTO connectivity_intervals (org_id, dev_id, interval_sec, created_at,
+updated_at)
+VALUES ($1, $2, $3, $4, $4)
+ON CONFLICT (org_id, dev_id) WHERE dev_id IS NOT NULL DO UPDATE
+SET interval_sec = EXCLUDED.interval_sec, updated_at = EXCLUDED.updated_at
+RETURNING id, created_at
+`
+
+const upsertTagInterval = `
+INSERT INTO connectivity_intervals (org_id, tag, interval_sec, created_at,
+updated_at)
+VALUES ($1, $2, $3, $4, $4)
+ON CONFLICT (org_id, tag) WHERE tag IS NOT NULL DO UPDATE
+SET interval_sec = EXCLUDED.interval_sec, updated_at = EXCLUDED.updated_at
+RETURNING id, created_at
+`
+
+// UpsertInterval creates or updates an expected reporting interval by device
+// ID or tag. Intervals are stored with second precision.
+func (d *DAO) UpsertInterval(
+	ctx context.Context, inter *message.ConnectivityInterval,
+) (*message.ConnectivityInterval, error) {
+	if (inter.GetDevId() == "") == (inter.GetTag() == "") {
+		return nil, dao.ErrInvalidFormat
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	inter.UpdatedAt = timestamppb.New(now)
+
+	query, key := upsertDevInterval, inter.GetDevId()
+	if inter.GetTag() != "" {
+		query, key = upsertTagInterval, inter.GetTag()
+	}
+
+	//nolint:gosec // Safe conversion for limited values.
+	sec := int32(inter.GetInterval().AsDuration() / time.Second)
+
+	var createdAt time.Time
+	if err := d.rw.QueryRowContext(ctx, query, inter.GetOrgId(), key, sec,
+		now).Scan(&inter.Id, &createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	inter.Interval = durationpb.New(time.Duration(sec) * time.Second)
+	inter.CreatedAt = timestamppb.New(createdAt)
+
+	return inter, nil
+}
+
+const deleteInterval = `
+DELETE FROM connectivity_intervals
+WHERE (id, org_id) = ($1, $2)
+RETURNING id
+`
+
+// DeleteInterval deletes an expected reporting interval by ID and org ID.
+func (d *DAO) DeleteInterval(ctx context.Context, interID, orgID string) error {
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, deleteInterval, interID,
+		orgID).Scan(&interID))
+}
+
+const listIntervals = `
+SELECT id, org_id, COALESCE(dev_id::text, ''), COALESCE(tag, ''),
+interval_sec, created_at, updated_at
+FROM connectivity_intervals
+WHERE org_id = $1
+ORDER BY created_at, id
+`
+
+// ListIntervals retrieves all expected reporting intervals by org ID.
+func (d *DAO) ListIntervals(ctx context.Context, orgID string) (
+	[]*message.ConnectivityInterval, error,
+) {
+	rows, err := d.ro.QueryContext(ctx, listIntervals, orgID)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ListIntervals rows.Close: %v", err)
+		}
+	}()
+
+	var inters []*message.ConnectivityInterval
+	for rows.Next() {
+		inter := &message.ConnectivityInterval{}
+		var intervalSec int32
+		var createdAt, updatedAt time.Time
+
+		if err = rows.Scan(&inter.Id, &inter.OrgId, &inter.DevId, &inter.Tag,
+			&intervalSec, &createdAt, &updatedAt); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		inter.Interval = durationpb.New(time.Duration(intervalSec) *
+			time.Second)
+		inter.CreatedAt = timestamppb.New(createdAt)
+		inter.UpdatedAt = timestamppb.New(updatedAt)
+		inters = append(inters, inter)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return inters, nil
+}
@@ -0,0 +1,202 @@
+//go:build !unit
+
+package connectivity
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestUpsertInterval(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-conn"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	t.Run("Upsert valid tag interval", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		inter := &message.ConnectivityInterval{
+			OrgId: createOrg.GetId(), Tag: random.String(10),
+			Interval: durationpb.New(time.Hour + 500*time.Millisecond),
+		}
+
+		createInter, err := globalConnDAO.UpsertInterval(ctx, inter)
+		t.Logf("createInter, err: %+v, %v", createInter, err)
+		require.NoError(t, err)
+		require.NotEmpty(t, createInter.GetId())
+		require.Equal(t, time.Hour, createInter.GetInterval().AsDuration())
+
+		updInter, err := globalConnDAO.UpsertInterval(ctx,
+			&message.ConnectivityInterval{
+				OrgId: createOrg.GetId(), Tag: inter.GetTag(),
+				Interval: durationpb.New(2 * time.Hour),
+			})
+		t.Logf("updInter, err: %+v, %v", updInter, err)
+		require.NoError(t, err)
+		require.Equal(t, createInter.GetId(), updInter.GetId())
+		require.Equal(t, 2*time.Hour, updInter.GetInterval().AsDuration())
+		require.True(t, updInter.GetUpdatedAt().AsTime().After(
+			updInter.GetCreatedAt().AsTime()))
+	})
+
+	t.Run("Upsert invalid interval", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			inp *message.ConnectivityInterval
+		}{
+			{&message.ConnectivityInterval{
+				OrgId: createOrg.GetId(), Interval: durationpb.New(time.Hour),
+			}},
+			{&message.ConnectivityInterval{
+				OrgId: createOrg.GetId(), DevId: uuid.NewV7().String(),
+				Tag: random.String(10), Interval: durationpb.New(time.Hour),
+			}},
+			{&message.ConnectivityInterval{
+				OrgId: createOrg.GetId(), Tag: random.String(10),
+				Interval: durationpb.New(time.Second),
+			}},
+			{&message.ConnectivityInterval{
+				OrgId: createOrg.GetId(), DevId: uuid.NewV7().String(),
+				Interval: durationpb.New(time.Hour),
+			}},
+		}
+
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("Cannot upsert %+v", test), func(t *testing.T) {
+				t.Parallel()
+
+				ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+				defer cancel()
+
+				inter, err := globalConnDAO.UpsertInterval(ctx, test.inp)
+				t.Logf("inter, err: %+v, %v", inter, err)
+				require.Nil(t, inter)
+				require.ErrorIs(t, err, dao.ErrInvalidFormat)
+			})
+		}
+	})
+}
+
+func TestDeleteInterval(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-conn"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	t.Run("Delete interval by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createInter, err := globalConnDAO.UpsertInterval(ctx,
+			&message.ConnectivityInterval{
+				OrgId: createOrg.GetId(), Tag: random.String(10),
+				Interval: durationpb.New(time.Hour),
+			})
+		t.Logf("createInter, err: %+v, %v", createInter, err)
+		require.NoError(t, err)
+
+		err = globalConnDAO.DeleteInterval(ctx, createInter.GetId(),
+			createOrg.GetId())
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		err = globalConnDAO.DeleteInterval(ctx, createInter.GetId(),
+			createOrg.GetId())
+		t.Logf("err: %v", err)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Delete interval by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		err := globalConnDAO.DeleteInterval(ctx, uuid.NewV7().String(),
+			createOrg.GetId())
+		t.Logf("err: %v", err)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestListIntervals(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-conn"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createDev, err := globalDevDAO.Create(ctx, random.Device("dao-conn",
+		createOrg.GetId()))
+	t.Logf("createDev, err: %+v, %v", createDev, err)
+	require.NoError(t, err)
+
+	var inters []*message.ConnectivityInterval
+	for _, inter := range []*message.ConnectivityInterval{
+		{OrgId: createOrg.GetId(), DevId: createDev.GetId()},
+		{OrgId: createOrg.GetId(), Tag: random.String(10)},
+	} {
+		inter.Interval = durationpb.New(time.Duration(random.Intn(60)+1) *
+			time.Minute)
+
+		createInter, err := globalConnDAO.UpsertInterval(ctx, inter)
+		t.Logf("createInter, err: %+v, %v", createInter, err)
+		require.NoError(t, err)
+		inters = append(inters, createInter)
+	}
+
+	t.Run("List intervals by valid org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listInters, err := globalConnDAO.ListIntervals(ctx, createOrg.GetId())
+		t.Logf("listInters, err: %+v, %v", listInters, err)
+		require.NoError(t, err)
+		require.Len(t, listInters, 2)
+		for i := range inters {
+			require.EqualExportedValues(t, inters[i], listInters[i])
+		}
+	})
+
+	t.Run("List intervals by unknown org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listInters, err := globalConnDAO.ListIntervals(ctx,
+			uuid.NewV7().String())
+		t.Logf("listInters, err: %+v, %v", listInters, err)
+		require.NoError(t, err)
+		require.Empty(t, listInters)
+	})
+}
@@ -0,0 +1,198 @@
+package datapoint
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Aggregation functions supported by Aggregate. Count, first, and last support
+// all value types, remaining functions operate on integer and float values.
+const (
+	FuncAvg   = "avg"
+	FuncMin   = "min"
+	FuncMax   = "max"
+	FuncSum   = "sum"
+	FuncCount = "count"
+	FuncFirst = "first"
+	FuncLast  = "last"
+)
+
+// Funcs is the set of aggregation functions supported by Aggregate.
+var Funcs = map[string]struct{}{
+	FuncAvg: {}, FuncMin: {}, FuncMax: {}, FuncSum: {}, FuncCount: {},
+	FuncFirst: {}, FuncLast: {},
+}
+
+// minBucket is the minimum supported bucket width.
+const minBucket = time.Second
+
+// aggNumeric maps numeric aggregation functions to their SQL expressions.
+var aggNumeric = map[string]string{
+	FuncAvg: "AVG(COALESCE(d.int_val, d.fl64_val))",
+	FuncMin: "MIN(COALESCE(d.int_val, d.fl64_val))",
+	FuncMax: "MAX(COALESCE(d.int_val, d.fl64_val))",
+	FuncSum: "SUM(COALESCE(d.int_val, d.fl64_val))",
+}
+
+const aggDataPointsByUniqID = `
+FROM data_points d
+WHERE (d.org_id, d.uniq_id) = ($1, $2)
+AND d.attr = $3
+AND d.created_at <= $4
+AND d.created_at > $5
+`
+
+const aggDataPointsByDevID = `
+FROM data_points d
+INNER JOIN devices de ON (d.org_id, d.uniq_id) = (de.org_id, de.uniq_id)
+WHERE (d.org_id, de.id) = ($1, $2)
+AND d.attr = $3
+AND d.created_at <= $4
+AND d.created_at > $5
+`
+
+const aggDataPointsNumericSelect = `
+SELECT d.uniq_id, d.attr, date_bin(make_interval(secs => $6), d.created_at,
+TIMESTAMPTZ 'epoch') AS bucket, %s
+`
+
+const aggDataPointsNumericGroup = `
+AND (d.int_val IS NOT NULL OR d.fl64_val IS NOT NULL)
+GROUP BY d.uniq_id, d.attr, bucket
+ORDER BY bucket DESC
+`
+
+const aggDataPointsCountSelect = `
+SELECT d.uniq_id, d.attr, date_bin(make_interval(secs => $6), d.created_at,
+TIMESTAMPTZ 'epoch') AS bucket, COUNT(*)::integer
+`
+
+const aggDataPointsCountGroup = `
+GROUP BY d.uniq_id, d.attr, bucket
+ORDER BY bucket DESC
+`
+
+const aggDataPointsEdgeSelect = `
+SELECT DISTINCT ON (bucket) d.uniq_id, d.attr, date_bin(make_interval(
+secs => $6), d.created_at, TIMESTAMPTZ 'epoch') AS bucket, d.int_val,
+d.fl64_val, d.str_val, d.bool_val, d.bytes_val, d.trace_id
+`
+
+const aggDataPointsFirstOrder = `
+ORDER BY bucket DESC, d.created_at ASC
+`
+
+const aggDataPointsLastOrder = `
+ORDER BY bucket DESC, d.created_at DESC
+`
+
+// Aggregate retrieves one data point per time bucket by org ID, UniqID or
+// device ID, attribute, aggregation function, bucket width, and [end, start)
+// times. Buckets are aligned to the Unix epoch and returned in descending
+// order, with each data point timestamp set to the start of its bucket. Avg,
+// min, max, and sum return float values, count returns an integer value, and
+// first and last return the original value and trace ID. If both uniqID and
+// devID are provided, uniqID takes precedence and devID is ignored.
+func (d *DAO) Aggregate(
+	ctx context.Context, orgID, uniqID, devID, attr, fn string,
+	bucket time.Duration, end, start time.Time,
+) ([]*common.DataPoint, error) {
+	if attr == "" || bucket < minBucket {
+		return nil, dao.ErrInvalidFormat
+	}
+
+	// Build aggregate query.
+	from := aggDataPointsByUniqID
+	args := []any{orgID}
+
+	if uniqID == "" && devID != "" {
+		from = aggDataPointsByDevID
+		args = append(args, devID)
+	} else {
+		args = append(args, uniqID)
+	}
+
+	args = append(args, attr, end, start, bucket.Seconds())
+
+	var query string
+	switch fn {
+	case FuncAvg, FuncMin, FuncMax, FuncSum:
+		query = fmt.Sprintf(aggDataPointsNumericSelect, aggNumeric[fn]) + from +
+			aggDataPointsNumericGroup
+	case FuncCount:
+		query = aggDataPointsCountSelect + from + aggDataPointsCountGroup
+	case FuncFirst:
+		query = aggDataPointsEdgeSelect + from + aggDataPointsFirstOrder
+	case FuncLast:
+		query = aggDataPointsEdgeSelect + from + aggDataPointsLastOrder
+	default:
+		return nil, dao.ErrInvalidFormat
+	}
+
+	// Run aggregate query.
+	rows, err := d.ro.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("Aggregate rows.Close: %v", err)
+		}
+	}()
+
+	var points []*common.DataPoint
+	for rows.Next() {
+		point := &common.DataPoint{}
+		var intVal *int32
+		var fl64Val *float64
+		var strVal *string
+		var boolVal *bool
+		var bytesVal []byte
+		var bucketAt time.Time
+
+		switch fn {
+		case FuncCount:
+			err = rows.Scan(&point.UniqId, &point.Attr, &bucketAt, &intVal)
+		case FuncFirst, FuncLast:
+			err = rows.Scan(&point.UniqId, &point.Attr, &bucketAt, &intVal,
+				&fl64Val, &strVal, &boolVal, &bytesVal, &point.TraceId)
+		default:
+			err = rows.Scan(&point.UniqId, &point.Attr, &bucketAt, &fl64Val)
+		}
+		if err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		switch {
+		case intVal != nil:
+			point.ValOneof = &common.DataPoint_IntVal{IntVal: *intVal}
+		case fl64Val != nil:
+			point.ValOneof = &common.DataPoint_Fl64Val{Fl64Val: *fl64Val}
+		case strVal != nil:
+			point.ValOneof = &common.DataPoint_StrVal{StrVal: *strVal}
+		case boolVal != nil:
+			point.ValOneof = &common.DataPoint_BoolVal{BoolVal: *boolVal}
+		case bytesVal != nil:
+			point.ValOneof = &common.DataPoint_BytesVal{BytesVal: bytesVal}
+		}
+
+		point.Ts = timestamppb.New(bucketAt)
+		points = append(points, point)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return points, nil
+}
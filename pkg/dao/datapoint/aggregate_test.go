@@ -0,0 +1,211 @@
+//go:build !unit
+
+package datapoint
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/decode"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-point"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createDev, err := globalDevDAO.Create(ctx, random.Device("dao-point",
+		createOrg.GetId()))
+	t.Logf("createDev, err: %+v, %v", createDev, err)
+	require.NoError(t, err)
+
+	// Align points to two hourly buckets in the past.
+	older := time.Now().UTC().Truncate(time.Hour).Add(-2 * time.Hour)
+	newer := older.Add(time.Hour)
+
+	points := []*common.DataPoint{
+		{
+			UniqId: createDev.GetUniqId(), Attr: decode.AttrTempC,
+			ValOneof: &common.DataPoint_IntVal{IntVal: 20},
+			Ts:       timestamppb.New(older.Add(time.Minute)),
+			TraceId:  uuid.NewV7().String(),
+		},
+		{
+			UniqId: createDev.GetUniqId(), Attr: decode.AttrTempC,
+			ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 23},
+			Ts:       timestamppb.New(older.Add(2 * time.Minute)),
+			TraceId:  uuid.NewV7().String(),
+		},
+		{
+			UniqId: createDev.GetUniqId(), Attr: decode.AttrTempC,
+			ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 30.5},
+			Ts:       timestamppb.New(newer.Add(time.Minute)),
+			TraceId:  uuid.NewV7().String(),
+		},
+		{
+			UniqId: createDev.GetUniqId(), Attr: "power",
+			ValOneof: &common.DataPoint_StrVal{StrVal: "line"},
+			Ts:       timestamppb.New(older.Add(time.Minute)),
+			TraceId:  uuid.NewV7().String(),
+		},
+		{
+			UniqId: createDev.GetUniqId(), Attr: "power",
+			ValOneof: &common.DataPoint_StrVal{StrVal: "battery"},
+			Ts:       timestamppb.New(older.Add(2 * time.Minute)),
+			TraceId:  uuid.NewV7().String(),
+		},
+	}
+
+	for _, point := range points {
+		err := globalDPDAO.Create(ctx, point, createOrg.GetId())
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+	}
+
+	end := newer.Add(time.Hour)
+	start := older.Add(-time.Hour)
+
+	tests := []struct {
+		inpAttr string
+		inpFn   string
+		res     []*common.DataPoint
+	}{
+		{decode.AttrTempC, FuncAvg, []*common.DataPoint{
+			{ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 30.5}},
+			{ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 21.5}},
+		}},
+		{decode.AttrTempC, FuncMin, []*common.DataPoint{
+			{ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 30.5}},
+			{ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 20}},
+		}},
+		{decode.AttrTempC, FuncMax, []*common.DataPoint{
+			{ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 30.5}},
+			{ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 23}},
+		}},
+		{decode.AttrTempC, FuncSum, []*common.DataPoint{
+			{ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 30.5}},
+			{ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 43}},
+		}},
+		{decode.AttrTempC, FuncCount, []*common.DataPoint{
+			{ValOneof: &common.DataPoint_IntVal{IntVal: 1}},
+			{ValOneof: &common.DataPoint_IntVal{IntVal: 2}},
+		}},
+		{decode.AttrTempC, FuncFirst, []*common.DataPoint{
+			{
+				ValOneof: points[2].GetValOneof(),
+				TraceId:  points[2].GetTraceId(),
+			},
+			{
+				ValOneof: points[0].GetValOneof(),
+				TraceId:  points[0].GetTraceId(),
+			},
+		}},
+		{decode.AttrTempC, FuncLast, []*common.DataPoint{
+			{
+				ValOneof: points[2].GetValOneof(),
+				TraceId:  points[2].GetTraceId(),
+			},
+			{
+				ValOneof: points[1].GetValOneof(),
+				TraceId:  points[1].GetTraceId(),
+			},
+		}},
+		{"power", FuncCount, []*common.DataPoint{
+			{ValOneof: &common.DataPoint_IntVal{IntVal: 2}},
+		}},
+		{"power", FuncLast, []*common.DataPoint{
+			{
+				ValOneof: points[4].GetValOneof(),
+				TraceId:  points[4].GetTraceId(),
+			},
+		}},
+		{"power", FuncAvg, nil},
+	}
+
+	for _, test := range tests {
+		t.Run("Aggregate "+test.inpAttr+" by "+test.inpFn, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			// Populate expected identifiers and bucket timestamps.
+			for i, point := range test.res {
+				point.UniqId = createDev.GetUniqId()
+				point.Attr = test.inpAttr
+				point.Ts = timestamppb.New(newer)
+				if len(test.res) == 1 || i == 1 {
+					point.Ts = timestamppb.New(older)
+				}
+			}
+
+			// Verify results by UniqID.
+			aggPointsUniqID, err := globalDPDAO.Aggregate(ctx,
+				createOrg.GetId(), createDev.GetUniqId(), "", test.inpAttr,
+				test.inpFn, time.Hour, end, start)
+			t.Logf("aggPointsUniqID, err: %+v, %v", aggPointsUniqID, err)
+			require.NoError(t, err)
+			require.Len(t, aggPointsUniqID, len(test.res))
+
+			for i, point := range test.res {
+				require.EqualExportedValues(t, point, aggPointsUniqID[i])
+			}
+
+			// Verify results by dev ID.
+			aggPointsDevID, err := globalDPDAO.Aggregate(ctx,
+				createOrg.GetId(), "", createDev.GetId(), test.inpAttr,
+				test.inpFn, time.Hour, end, start)
+			t.Logf("aggPointsDevID, err: %+v, %v", aggPointsDevID, err)
+			require.NoError(t, err)
+			require.Len(t, aggPointsDevID, len(test.res))
+
+			for i, point := range test.res {
+				require.EqualExportedValues(t, point, aggPointsDevID[i])
+			}
+		})
+	}
+}
+
+func TestAggregateInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpOrgID  string
+		inpAttr   string
+		inpFn     string
+		inpBucket time.Duration
+	}{
+		{uuid.NewV7().String(), "", FuncAvg, time.Minute},
+		{uuid.NewV7().String(), decode.AttrTempC, FuncAvg, time.Millisecond},
+		{uuid.NewV7().String(), decode.AttrTempC, "median", time.Minute},
+		{random.String(10), decode.AttrTempC, FuncAvg, time.Minute},
+	}
+
+	for _, test := range tests {
+		t.Run("Aggregate with invalid "+test.inpAttr+test.inpFn, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+			defer cancel()
+
+			aggPoints, err := globalDPDAO.Aggregate(ctx, test.inpOrgID,
+				uuid.NewV7().String(), "", test.inpAttr, test.inpFn,
+				test.inpBucket, time.Now(), time.Now().Add(-time.Hour))
+			t.Logf("aggPoints, err: %+v, %v", aggPoints, err)
+			require.Nil(t, aggPoints)
+			require.ErrorIs(t, err, dao.ErrInvalidFormat)
+		})
+	}
+}
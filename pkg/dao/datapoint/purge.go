@@ -0,0 +1,39 @@
+package datapoint
+
+import (
+	"context"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/dao"
+)
+
+const purgeDataPoints = `
+DELETE FROM data_points
+WHERE ctid = ANY(ARRAY(
+  SELECT ctid
+  FROM data_points
+  WHERE org_id = $1
+  AND created_at < $2
+  LIMIT $3
+))
+`
+
+// Purge deletes up to limit data points by org ID that were created before the
+// provided time, and returns the number of deleted rows. Batches are bounded
+// to keep locks short, and callers should repeat Purge until fewer than limit
+// rows are deleted.
+func (d *DAO) Purge(
+	ctx context.Context, orgID string, before time.Time, limit int32,
+) (int64, error) {
+	res, err := d.rw.ExecContext(ctx, purgeDataPoints, orgID, before, limit)
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,78 @@
+//go:build !unit
+
+package datapoint
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/decode/radiobridge"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestPurge(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Purge data points in batches", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-point"))
+		t.Logf("createOrg, err: %+v, %v", createOrg, err)
+		require.NoError(t, err)
+
+		uniqID := "dao-point-" + random.String(16)
+		before := time.Now().UTC().Truncate(time.Millisecond).
+			Add(-24 * time.Hour)
+
+		for i := range 4 {
+			// The final point is newer than the purge boundary.
+			ts := before.Add(-time.Duration(i+1) * time.Hour)
+			if i == 3 {
+				ts = before.Add(time.Hour)
+			}
+
+			err := globalDPDAO.Create(ctx, &common.DataPoint{
+				UniqId: uniqID, Attr: radiobridge.AttrCount,
+				ValOneof: &common.DataPoint_IntVal{IntVal: int32(i)},
+				Ts:       timestamppb.New(ts), TraceId: uuid.NewV7().String(),
+			}, createOrg.GetId())
+			t.Logf("err: %v", err)
+			require.NoError(t, err)
+		}
+
+		for _, exp := range []int64{2, 1, 0} {
+			count, err := globalDPDAO.Purge(ctx, createOrg.GetId(), before, 2)
+			t.Logf("count, err: %v, %v", count, err)
+			require.NoError(t, err)
+			require.Equal(t, exp, count)
+		}
+
+		listPoints, err := globalDPDAO.List(ctx, createOrg.GetId(), uniqID, "",
+			"", time.Now(), before.Add(-24*time.Hour))
+		t.Logf("listPoints, err: %+v, %v", listPoints, err)
+		require.NoError(t, err)
+		require.Len(t, listPoints, 1)
+		require.Equal(t, int32(3), listPoints[0].GetIntVal())
+	})
+
+	t.Run("Purge data points by invalid org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		count, err := globalDPDAO.Purge(ctx, random.String(10), time.Now(), 2)
+		t.Logf("count, err: %v, %v", count, err)
+		require.Zero(t, count)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+}
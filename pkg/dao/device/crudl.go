@@ -256,7 +256,11 @@ WHERE org_id = $1
 `
 
 const countDevicesTag = `
-AND $2 = ANY (tags)
+AND $%d = ANY (tags)
+`
+
+const countDevicesScope = `
+AND %s
 `
 
 const listDevices = `
@@ -277,26 +281,38 @@ const listDevicesTag = `
 AND $%d = ANY (tags)
 `
 
+const listDevicesScope = `
+AND %s
+`
+
 const listDevicesLimit = `
 ORDER BY created_at ASC, id ASC
 LIMIT %d
 `
 
-// List retrieves all devices by org ID with pagination and optional tag filter.
-// If lBoundTS and prevID are zero values, the first page of results is
-// returned. Limits of 0 or less do not apply a limit. List returns a slice of
-// devices, a total count, and an error value.
+// List retrieves all devices by org ID with pagination, optional tag filter,
+// and optional scope tags. If scope tags are provided, only devices with a tag
+// matching or nested beneath a scope tag are returned. If lBoundTS and prevID
+// are zero values, the first page of results is returned. Limits of 0 or less
+// do not apply a limit. List returns a slice of devices, a total count, and an
+// error value.
 func (d *DAO) List(
 	ctx context.Context, orgID string, lBoundTS time.Time, prevID string,
-	limit int32, tag string,
+	limit int32, tag string, scopeTags []string,
 ) ([]*api.Device, int32, error) {
 	// Build count query.
 	cQuery := countDevices
 	cArgs := []any{orgID}
 
 	if tag != "" {
-		cQuery += countDevicesTag
 		cArgs = append(cArgs, tag)
+		cQuery += fmt.Sprintf(countDevicesTag, len(cArgs))
+	}
+
+	if len(scopeTags) > 0 {
+		cArgs = append(cArgs, scopeTags)
+		cQuery += fmt.Sprintf(countDevicesScope, dao.TagScope("tags",
+			len(cArgs)))
 	}
 
 	// Run count query.
@@ -313,14 +329,17 @@ func (d *DAO) List(
 	if prevID != "" && !lBoundTS.IsZero() {
 		lQuery += fmt.Sprintf(listDevicesTSAndID, 2, 2, 3)
 		lArgs = append(lArgs, lBoundTS, prevID)
+	}
 
-		if tag != "" {
-			lQuery += fmt.Sprintf(listDevicesTag, 4)
-			lArgs = append(lArgs, tag)
-		}
-	} else if tag != "" {
-		lQuery += fmt.Sprintf(listDevicesTag, 2)
+	if tag != "" {
 		lArgs = append(lArgs, tag)
+		lQuery += fmt.Sprintf(listDevicesTag, len(lArgs))
+	}
+
+	if len(scopeTags) > 0 {
+		lArgs = append(lArgs, scopeTags)
+		lQuery += fmt.Sprintf(listDevicesScope, dao.TagScope("tags",
+			len(lArgs)))
 	}
 
 	// Ordering is applied with the limit, which will always be present for API
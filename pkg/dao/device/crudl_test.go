@@ -611,7 +611,7 @@ func TestList(t *testing.T) {
 		defer cancel()
 
 		listDevs, listCount, err := globalDevDAO.List(ctx, createOrg.GetId(),
-			time.Time{}, "", 0, "")
+			time.Time{}, "", 0, "", nil)
 		t.Logf("listDevs, listCount, err: %+v, %v, %v", listDevs, listCount,
 			err)
 		require.NoError(t, err)
@@ -638,7 +638,7 @@ func TestList(t *testing.T) {
 		defer cancel()
 
 		listDevs, listCount, err := globalDevDAO.List(ctx, createOrg.GetId(),
-			devTSes[0], devIDs[0], 5, "")
+			devTSes[0], devIDs[0], 5, "", nil)
 		t.Logf("listDevs, listCount, err: %+v, %v, %v", listDevs, listCount,
 			err)
 		require.NoError(t, err)
@@ -665,7 +665,7 @@ func TestList(t *testing.T) {
 		defer cancel()
 
 		listDevs, listCount, err := globalDevDAO.List(ctx, createOrg.GetId(),
-			time.Time{}, "", 1, "")
+			time.Time{}, "", 1, "", nil)
 		t.Logf("listDevs, listCount, err: %+v, %v, %v", listDevs, listCount,
 			err)
 		require.NoError(t, err)
@@ -680,7 +680,7 @@ func TestList(t *testing.T) {
 		defer cancel()
 
 		listDevs, listCount, err := globalDevDAO.List(ctx, createOrg.GetId(),
-			time.Time{}, "", 5, devTags[2][0])
+			time.Time{}, "", 5, devTags[2][0], nil)
 		t.Logf("listDevs, listCount, err: %+v, %v, %v", listDevs, listCount,
 			err)
 		require.NoError(t, err)
@@ -701,7 +701,7 @@ func TestList(t *testing.T) {
 		defer cancel()
 
 		listDevs, listCount, err := globalDevDAO.List(ctx, createOrg.GetId(),
-			devTSes[0], devIDs[0], 5, devTags[2][0])
+			devTSes[0], devIDs[0], 5, devTags[2][0], nil)
 		t.Logf("listDevs, listCount, err: %+v, %v, %v", listDevs, listCount,
 			err)
 		require.NoError(t, err)
@@ -715,6 +715,50 @@ func TestList(t *testing.T) {
 		require.Equal(t, devTags[len(devTags)-1], listDevs[0].GetTags())
 	})
 
+	t.Run("List devices with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		scopeOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-device"))
+		t.Logf("scopeOrg, err: %+v, %v", scopeOrg, err)
+		require.NoError(t, err)
+
+		var scopeDevID string
+		for _, tags := range [][]string{
+			{"dao-device-site/b1"}, {"dao-device-site2"}, nil,
+		} {
+			dev := random.Device("dao-device", scopeOrg.GetId())
+			dev.Tags = tags
+			createDev, err := globalDevDAO.Create(ctx, dev)
+			t.Logf("createDev, err: %+v, %v", createDev, err)
+			require.NoError(t, err)
+
+			if scopeDevID == "" {
+				scopeDevID = createDev.GetId()
+			}
+		}
+
+		listDevs, listCount, err := globalDevDAO.List(ctx, scopeOrg.GetId(),
+			time.Time{}, "", 5, "", []string{"dao-device-site"})
+		t.Logf("listDevs, listCount, err: %+v, %v, %v", listDevs, listCount,
+			err)
+		require.NoError(t, err)
+		require.Len(t, listDevs, 1)
+		require.Equal(t, int32(1), listCount)
+		require.Equal(t, scopeDevID, listDevs[0].GetId())
+
+		listDevs, listCount, err = globalDevDAO.List(ctx, scopeOrg.GetId(),
+			time.Time{}, "", 5, "dao-device-site2",
+			[]string{"dao-device-site"})
+		t.Logf("listDevs, listCount, err: %+v, %v, %v", listDevs, listCount,
+			err)
+		require.NoError(t, err)
+		require.Empty(t, listDevs)
+		require.Equal(t, int32(0), listCount)
+	})
+
 	t.Run("Lists are isolated by org ID", func(t *testing.T) {
 		t.Parallel()
 
@@ -722,7 +766,7 @@ func TestList(t *testing.T) {
 		defer cancel()
 
 		listDevs, listCount, err := globalDevDAO.List(ctx, uuid.NewV7().String(),
-			time.Time{}, "", 0, "")
+			time.Time{}, "", 0, "", nil)
 		t.Logf("listDevs, listCount, err: %+v, %v, %v", listDevs, listCount,
 			err)
 		require.NoError(t, err)
@@ -737,7 +781,7 @@ func TestList(t *testing.T) {
 		defer cancel()
 
 		listDevs, listCount, err := globalDevDAO.List(ctx, random.String(10),
-			time.Time{}, "", 0, "")
+			time.Time{}, "", 0, "", nil)
 		t.Logf("listDevs, listCount, err: %+v, %v, %v", listDevs, listCount,
 			err)
 		require.Nil(t, listDevs)
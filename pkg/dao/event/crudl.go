@@ -2,6 +2,7 @@ package event
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -29,10 +30,29 @@ func (d *DAO) Create(ctx context.Context, event *api.Event) error {
 	return dao.DBToSentinel(err)
 }
 
+const createClearEvent = `
+INSERT INTO events (org_id, uniq_id, rule_id, created_at, trace_id, cleared)
+VALUES ($1, $2, $3, $4, $5, true)
+`
+
+// CreateClear creates an event in the database recording that a rule cleared
+// for a device. Clear events are excluded from List and Latest.
+func (d *DAO) CreateClear(ctx context.Context, event *api.Event) error {
+	// Truncate timestamp to milliseconds for deduplication.
+	createdAt := event.GetCreatedAt().AsTime().Truncate(time.Millisecond)
+
+	_, err := d.rw.ExecContext(ctx, createClearEvent, event.GetOrgId(),
+		strings.ToLower(event.GetUniqId()), event.GetRuleId(), createdAt,
+		event.GetTraceId())
+
+	return dao.DBToSentinel(err)
+}
+
 const listEventsByUniqID = `
 SELECT e.org_id, e.uniq_id, e.rule_id, e.created_at, e.trace_id
 FROM events e
 WHERE (e.org_id, e.uniq_id) = ($1, $2)
+AND NOT e.cleared
 AND e.created_at <= $3
 AND e.created_at > $4
 `
@@ -42,6 +62,7 @@ SELECT e.org_id, e.uniq_id, e.rule_id, e.created_at, e.trace_id
 FROM events e
 INNER JOIN devices d ON (e.org_id, e.uniq_id) = (d.org_id, d.uniq_id)
 WHERE (e.org_id, d.id) = ($1, $2)
+AND NOT e.cleared
 AND e.created_at <= $3
 AND e.created_at > $4
 `
@@ -50,16 +71,21 @@ const listEventsRuleID = `
 AND e.rule_id = $5
 `
 
+const listEventsScope = `
+AND %s
+`
+
 const listEventsOrder = `
 ORDER BY e.created_at DESC
 `
 
 // List retrieves all events by org ID, UniqID or device ID, optional rule ID,
-// and [end, start) times. If both uniqID and devID are provided, uniqID takes
-// precedence and devID is ignored.
+// [end, start) times, and optional scope tags. If both uniqID and devID are
+// provided, uniqID takes precedence and devID is ignored. If scope tags are
+// provided, only events for devices with tags in scope are returned.
 func (d *DAO) List(
 	ctx context.Context, orgID, uniqID, devID, ruleID string, end,
-	start time.Time,
+	start time.Time, scopeTags []string,
 ) ([]*api.Event, error) {
 	// Build list query.
 	query := listEventsByUniqID
@@ -77,6 +103,11 @@ func (d *DAO) List(
 		args = append(args, ruleID)
 	}
 
+	if len(scopeTags) > 0 {
+		args = append(args, scopeTags)
+		query += fmt.Sprintf(listEventsScope, dao.DeviceScope(len(args)))
+	}
+
 	query += listEventsOrder
 
 	// Run list query.
@@ -133,15 +164,21 @@ FROM
       events
     WHERE
       org_id = $1
+      AND NOT cleared
     GROUP BY
       org_id,
       uniq_id
   ) m ON (e.org_id, e.uniq_id, e.created_at) = (
     m.org_id, m.uniq_id, m.created_at)
+  AND NOT e.cleared
+`
+
+const latestEventsScope = `
+AND %s
 `
 
 const latestEventsRuleID = `
-WHERE e.rule_id = $2
+WHERE e.rule_id = $%d
 `
 
 const latestEventsOrder = `
@@ -149,17 +186,23 @@ ORDER BY e.created_at DESC
 `
 
 // Latest retrieves the latest events for each of an organization's devices by
-// org ID and optional rule ID.
-func (d *DAO) Latest(ctx context.Context, orgID, ruleID string) (
-	[]*api.Event, error,
-) {
+// org ID, optional rule ID, and optional scope tags. If scope tags are
+// provided, only events for devices with tags in scope are returned.
+func (d *DAO) Latest(
+	ctx context.Context, orgID, ruleID string, scopeTags []string,
+) ([]*api.Event, error) {
 	// Build latest query.
 	query := latestEvents
 	args := []any{orgID}
 
+	if len(scopeTags) > 0 {
+		args = append(args, scopeTags)
+		query += fmt.Sprintf(latestEventsScope, dao.DeviceScope(len(args)))
+	}
+
 	if ruleID != "" {
-		query += latestEventsRuleID
 		args = append(args, ruleID)
+		query += fmt.Sprintf(latestEventsRuleID, len(args))
 	}
 
 	query += latestEventsOrder
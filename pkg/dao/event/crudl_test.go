@@ -14,6 +14,7 @@ import (
 	"github.com/thingspect/atlas/pkg/dao"
 	"github.com/thingspect/atlas/pkg/test/random"
 	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const testTimeout = 6 * time.Second
@@ -98,6 +99,48 @@ func TestCreate(t *testing.T) {
 	})
 }
 
+func TestCreateClear(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-event"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	event := random.Event("dao-event", createOrg.GetId())
+	require.NoError(t, globalEvDAO.Create(ctx, event))
+
+	clearEvent := random.Event("dao-event", createOrg.GetId())
+	clearEvent.UniqId = event.GetUniqId()
+	clearEvent.RuleId = event.GetRuleId()
+	clearEvent.CreatedAt = timestamppb.New(event.GetCreatedAt().AsTime().Add(
+		time.Second))
+
+	err = globalEvDAO.CreateClear(ctx, clearEvent)
+	t.Logf("err: %v", err)
+	require.NoError(t, err)
+
+	err = globalEvDAO.CreateClear(ctx, clearEvent)
+	t.Logf("err: %#v", err)
+	require.Equal(t, dao.ErrAlreadyExists, err)
+
+	// Clear events are not listed.
+	listEvents, err := globalEvDAO.List(ctx, createOrg.GetId(),
+		event.GetUniqId(), "", "", time.Now().Add(time.Minute),
+		time.Now().Add(-time.Hour), nil)
+	t.Logf("listEvents, err: %+v, %v", listEvents, err)
+	require.NoError(t, err)
+	require.Len(t, listEvents, 1)
+
+	latEvents, err := globalEvDAO.Latest(ctx, createOrg.GetId(), "", nil)
+	t.Logf("latEvents, err: %+v, %v", latEvents, err)
+	require.NoError(t, err)
+	require.Len(t, latEvents, 1)
+	require.Equal(t, event.GetTraceId(), latEvents[0].GetTraceId())
+}
+
 func TestList(t *testing.T) {
 	t.Parallel()
 
@@ -146,7 +189,7 @@ func TestList(t *testing.T) {
 		listEventsUniqID, err := globalEvDAO.List(ctx, createOrg.GetId(),
 			createDev.GetUniqId(), "", "", events[0].GetCreatedAt().AsTime(),
 			events[len(events)-1].GetCreatedAt().AsTime().
-				Add(-time.Millisecond))
+				Add(-time.Millisecond), nil)
 		t.Logf("listEventsUniqID, err: %+v, %v", listEventsUniqID, err)
 		require.NoError(t, err)
 		require.Len(t, listEventsUniqID, len(events))
@@ -158,7 +201,7 @@ func TestList(t *testing.T) {
 		// Verify results by dev ID without oldest event.
 		listEventsDevID, err := globalEvDAO.List(ctx, createOrg.GetId(), "",
 			createDev.GetId(), "", events[0].GetCreatedAt().AsTime(),
-			events[len(events)-1].GetCreatedAt().AsTime())
+			events[len(events)-1].GetCreatedAt().AsTime(), nil)
 		t.Logf("listEventsDevID, err: %+v, %v", listEventsDevID, err)
 		require.NoError(t, err)
 		require.Len(t, listEventsDevID, len(events)-1)
@@ -172,7 +215,7 @@ func TestList(t *testing.T) {
 			createDev.GetUniqId(), "", events[len(events)-1].GetRuleId(),
 			events[0].GetCreatedAt().AsTime(),
 			events[len(events)-1].GetCreatedAt().AsTime().
-				Add(-time.Millisecond))
+				Add(-time.Millisecond), nil)
 		t.Logf("listEventsUniqID, err: %+v, %v", listEventsUniqID, err)
 		require.NoError(t, err)
 		require.Len(t, listEventsUniqID, 1)
@@ -198,7 +241,7 @@ func TestList(t *testing.T) {
 
 		listEvents, err := globalEvDAO.List(ctx, uuid.NewV7().String(),
 			event.GetUniqId(), "", "", event.GetCreatedAt().AsTime(),
-			event.GetCreatedAt().AsTime().Add(-time.Millisecond))
+			event.GetCreatedAt().AsTime().Add(-time.Millisecond), nil)
 		t.Logf("listEvents, err: %+v, %v", listEvents, err)
 		require.NoError(t, err)
 		require.Empty(t, listEvents)
@@ -211,7 +254,7 @@ func TestList(t *testing.T) {
 		defer cancel()
 
 		listEvents, err := globalEvDAO.List(ctx, random.String(10),
-			uuid.NewV7().String(), "", "", time.Now(), time.Now())
+			uuid.NewV7().String(), "", "", time.Now(), time.Now(), nil)
 		t.Logf("listEvents, err: %+v, %v", listEvents, err)
 		require.Nil(t, listEvents)
 		require.ErrorIs(t, err, dao.ErrInvalidFormat)
@@ -257,7 +300,7 @@ func TestLatest(t *testing.T) {
 		defer cancel()
 
 		// Verify results.
-		latEvents, err := globalEvDAO.Latest(ctx, createOrg.GetId(), "")
+		latEvents, err := globalEvDAO.Latest(ctx, createOrg.GetId(), "", nil)
 		t.Logf("latEvents, err: %+v, %v", latEvents, err)
 		require.NoError(t, err)
 		require.Len(t, latEvents, len(events))
@@ -268,7 +311,7 @@ func TestLatest(t *testing.T) {
 
 		// Verify results by rule ID.
 		latEventsRuleID, err := globalEvDAO.Latest(ctx, createOrg.GetId(),
-			events[len(events)-1].GetRuleId())
+			events[len(events)-1].GetRuleId(), nil)
 		t.Logf("latEventsRuleID, err: %+v, %v", latEventsRuleID, err)
 		require.NoError(t, err)
 		require.Len(t, latEventsRuleID, 1)
@@ -276,6 +319,59 @@ func TestLatest(t *testing.T) {
 			latEventsRuleID[0])
 	})
 
+	t.Run("Latest and list events with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-event"))
+		t.Logf("createOrg, err: %+v, %v", createOrg, err)
+		require.NoError(t, err)
+
+		var scopeEvent *api.Event
+		for _, tag := range []string{"dao-event-site/b1", "dao-event-site2"} {
+			dev := random.Device("dao-event", createOrg.GetId())
+			dev.Tags = []string{tag}
+			createDev, err := globalDevDAO.Create(ctx, dev)
+			t.Logf("createDev, err: %+v, %v", createDev, err)
+			require.NoError(t, err)
+
+			event := random.Event("dao-event", createOrg.GetId())
+			event.UniqId = createDev.GetUniqId()
+
+			err = globalEvDAO.Create(ctx, event)
+			t.Logf("err: %v", err)
+			require.NoError(t, err)
+
+			if scopeEvent == nil {
+				scopeEvent = event
+			}
+		}
+
+		scopeTags := []string{"dao-event-site"}
+
+		latEvents, err := globalEvDAO.Latest(ctx, createOrg.GetId(), "",
+			scopeTags)
+		t.Logf("latEvents, err: %+v, %v", latEvents, err)
+		require.NoError(t, err)
+		require.Len(t, latEvents, 1)
+		require.EqualExportedValues(t, scopeEvent, latEvents[0])
+
+		latEvents, err = globalEvDAO.Latest(ctx, createOrg.GetId(),
+			scopeEvent.GetRuleId(), scopeTags)
+		t.Logf("latEvents, err: %+v, %v", latEvents, err)
+		require.NoError(t, err)
+		require.Len(t, latEvents, 1)
+
+		listEvents, err := globalEvDAO.List(ctx, createOrg.GetId(),
+			scopeEvent.GetUniqId(), "", "", time.Now().Add(time.Minute),
+			time.Now().Add(-time.Hour), []string{"dao-event-site2"})
+		t.Logf("listEvents, err: %+v, %v", listEvents, err)
+		require.NoError(t, err)
+		require.Empty(t, listEvents)
+	})
+
 	t.Run("Latest events are isolated by org ID", func(t *testing.T) {
 		t.Parallel()
 
@@ -292,7 +388,8 @@ func TestLatest(t *testing.T) {
 		t.Logf("err: %#v", err)
 		require.NoError(t, err)
 
-		latEvents, err := globalEvDAO.Latest(ctx, uuid.NewV7().String(), "")
+		latEvents, err := globalEvDAO.Latest(ctx, uuid.NewV7().String(), "",
+			nil)
 		t.Logf("latEvents, err: %+v, %v", latEvents, err)
 		require.NoError(t, err)
 		require.Empty(t, latEvents)
@@ -305,7 +402,7 @@ func TestLatest(t *testing.T) {
 		defer cancel()
 
 		latEvents, err := globalEvDAO.Latest(ctx, uuid.NewV7().String(),
-			random.String(10))
+			random.String(10), nil)
 		t.Logf("latEvents, err: %+v, %v", latEvents, err)
 		require.Nil(t, latEvents)
 		require.ErrorIs(t, err, dao.ErrInvalidFormat)
@@ -0,0 +1,38 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/dao"
+)
+
+const purgeEvents = `
+DELETE FROM events
+WHERE ctid = ANY(ARRAY(
+  SELECT ctid
+  FROM events
+  WHERE org_id = $1
+  AND created_at < $2
+  LIMIT $3
+))
+`
+
+// Purge deletes a batch of up to limit events by org ID that were created
+// before the provided time. It returns the number of events deleted, which is
+// less than limit once no expired events remain.
+func (d *DAO) Purge(
+	ctx context.Context, orgID string, before time.Time, limit int32,
+) (int64, error) {
+	res, err := d.rw.ExecContext(ctx, purgeEvents, orgID, before, limit)
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	return count, nil
+}
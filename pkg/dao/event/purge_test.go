@@ -0,0 +1,73 @@
+//go:build !unit
+
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestPurge(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Purge events in batches", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-event"))
+		t.Logf("createOrg, err: %+v, %v", createOrg, err)
+		require.NoError(t, err)
+
+		before := time.Now().UTC().Add(-24 * time.Hour)
+
+		for i := range 3 {
+			event := random.Event("dao-event", createOrg.GetId())
+			event.CreatedAt = timestamppb.New(before.Add(-time.Duration(i+1) *
+				time.Hour))
+
+			err := globalEvDAO.Create(ctx, event)
+			t.Logf("err: %v", err)
+			require.NoError(t, err)
+		}
+
+		keep := random.Event("dao-event", createOrg.GetId())
+		err = globalEvDAO.Create(ctx, keep)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		for _, exp := range []int64{2, 1, 0} {
+			count, err := globalEvDAO.Purge(ctx, createOrg.GetId(), before, 2)
+			t.Logf("count, err: %v, %v", count, err)
+			require.NoError(t, err)
+			require.Equal(t, exp, count)
+		}
+
+		listEvents, err := globalEvDAO.List(ctx, createOrg.GetId(),
+			keep.GetUniqId(), "", "", time.Now(), before.Add(-24*time.Hour),
+			nil)
+		t.Logf("listEvents, err: %+v, %v", listEvents, err)
+		require.NoError(t, err)
+		require.Len(t, listEvents, 1)
+		require.EqualExportedValues(t, keep, listEvents[0])
+	})
+
+	t.Run("Purge events by invalid org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		count, err := globalEvDAO.Purge(ctx, random.String(10), time.Now(), 2)
+		t.Logf("count, err: %v, %v", count, err)
+		require.Zero(t, count)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+}
@@ -0,0 +1,100 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const createRestrictedKey = `
+WITH key AS (
+  INSERT INTO keys (org_id, name, role, created_at)
+  VALUES ($1, $2, $3, $4)
+  RETURNING id, org_id
+)
+INSERT INTO key_restrictions (key_id, org_id, expires_at, scopes, uniq_ids,
+tags, scope_tags, created_at)
+SELECT id, org_id, $5, $6, $7, $8, $9, $4
+FROM key
+RETURNING key_id
+`
+
+// CreateRestricted creates an API key and its restriction in the database in a
+// single statement.
+func (d *DAO) CreateRestricted(
+	ctx context.Context, key *api.Key, res *message.KeyRestriction,
+) (*api.Key, *message.KeyRestriction, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	key.CreatedAt = timestamppb.New(now)
+	res.OrgId = key.GetOrgId()
+	res.CreatedAt = key.GetCreatedAt()
+
+	var expiresAt *time.Time
+	if res.GetExpiresAt() != nil {
+		exp := res.GetExpiresAt().AsTime().Truncate(time.Microsecond)
+		expiresAt = &exp
+		res.ExpiresAt = timestamppb.New(exp)
+	}
+
+	// Store empty slices as empty arrays, rather than NULL.
+	scopes, uniqIDs, tags := res.GetScopes(), res.GetUniqIds(), res.GetTags()
+	scopeTags := res.GetScopeTags()
+	if scopes == nil {
+		scopes = []string{}
+	}
+	if uniqIDs == nil {
+		uniqIDs = []string{}
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+	if scopeTags == nil {
+		scopeTags = []string{}
+	}
+
+	if err := d.rw.QueryRowContext(ctx, createRestrictedKey, key.GetOrgId(),
+		key.GetName(), key.GetRole().String(), now, expiresAt, scopes, uniqIDs,
+		tags, scopeTags).Scan(&key.Id); err != nil {
+		return nil, nil, dao.DBToSentinel(err)
+	}
+	res.KeyId = key.GetId()
+
+	return key, res, nil
+}
+
+const readRestriction = `
+SELECT key_id, org_id, expires_at, scopes, uniq_ids, tags, scope_tags,
+created_at
+FROM key_restrictions
+WHERE (key_id, org_id) = ($1, $2)
+`
+
+// ReadRestriction retrieves an API key's restriction by key ID and org ID.
+func (d *DAO) ReadRestriction(ctx context.Context, keyID, orgID string) (
+	*message.KeyRestriction, error,
+) {
+	res := &message.KeyRestriction{}
+	var expiresAt sql.NullTime
+	var createdAt time.Time
+	pgtmap := pgtype.NewMap()
+
+	if err := d.ro.QueryRowContext(ctx, readRestriction, keyID, orgID).Scan(
+		&res.KeyId, &res.OrgId, &expiresAt, pgtmap.SQLScanner(&res.Scopes),
+		pgtmap.SQLScanner(&res.UniqIds), pgtmap.SQLScanner(&res.Tags),
+		pgtmap.SQLScanner(&res.ScopeTags), &createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	if expiresAt.Valid {
+		res.ExpiresAt = timestamppb.New(expiresAt.Time)
+	}
+	res.CreatedAt = timestamppb.New(createdAt)
+
+	return res, nil
+}
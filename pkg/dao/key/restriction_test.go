@@ -0,0 +1,139 @@
+//go:build !unit
+
+package key
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCreateReadRestricted(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-key"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	t.Run("Create and read valid restricted key", func(t *testing.T) {
+		t.Parallel()
+
+		key := random.Key("dao-key", createOrg.GetId())
+		res := &message.KeyRestriction{
+			ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+			Scopes:    []string{"thingspect.api.DataPointService"},
+			UniqIds:   []string{"dao-key-" + random.String(10)},
+			Tags:      []string{random.String(10)},
+			ScopeTags: []string{random.String(10)},
+		}
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createKey, createRes, err := globalKeyDAO.CreateRestricted(ctx, key,
+			res)
+		t.Logf("createKey, createRes, err: %+v, %+v, %v", createKey, createRes,
+			err)
+		require.NoError(t, err)
+		require.NotEmpty(t, createKey.GetId())
+		require.Equal(t, createKey.GetId(), createRes.GetKeyId())
+		require.Equal(t, createOrg.GetId(), createRes.GetOrgId())
+
+		readKey, err := globalKeyDAO.read(ctx, createKey.GetId(),
+			createOrg.GetId())
+		t.Logf("readKey, err: %+v, %v", readKey, err)
+		require.NoError(t, err)
+		require.Equal(t, createKey, readKey)
+
+		readRes, err := globalKeyDAO.ReadRestriction(ctx, createKey.GetId(),
+			createOrg.GetId())
+		t.Logf("readRes, err: %+v, %v", readRes, err)
+		require.NoError(t, err)
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(createRes, readRes) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", createRes, readRes)
+		}
+	})
+
+	t.Run("Create and read unexpiring restricted key", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createKey, createRes, err := globalKeyDAO.CreateRestricted(ctx,
+			random.Key("dao-key", createOrg.GetId()), &message.KeyRestriction{
+				Scopes: []string{"thingspect.api.DeviceService"},
+			})
+		t.Logf("createKey, createRes, err: %+v, %+v, %v", createKey, createRes,
+			err)
+		require.NoError(t, err)
+
+		readRes, err := globalKeyDAO.ReadRestriction(ctx, createKey.GetId(),
+			createOrg.GetId())
+		t.Logf("readRes, err: %+v, %v", readRes, err)
+		require.NoError(t, err)
+		require.Nil(t, readRes.GetExpiresAt())
+		require.Equal(t, createRes.GetScopes(), readRes.GetScopes())
+		require.Empty(t, readRes.GetUniqIds())
+		require.Empty(t, readRes.GetTags())
+		require.Empty(t, readRes.GetScopeTags())
+	})
+
+	t.Run("Create invalid restricted key", func(t *testing.T) {
+		t.Parallel()
+
+		key := random.Key("dao-key", createOrg.GetId())
+		key.Name = "dao-key-" + random.String(80)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createKey, createRes, err := globalKeyDAO.CreateRestricted(ctx, key,
+			&message.KeyRestriction{})
+		t.Logf("createKey, createRes, err: %+v, %+v, %v", createKey, createRes,
+			err)
+		require.Nil(t, createKey)
+		require.Nil(t, createRes)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+
+	t.Run("Read restriction by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readRes, err := globalKeyDAO.ReadRestriction(ctx, uuid.NewV7().String(),
+			createOrg.GetId())
+		t.Logf("readRes, err: %+v, %v", readRes, err)
+		require.Nil(t, readRes)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Read restriction by invalid ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readRes, err := globalKeyDAO.ReadRestriction(ctx, random.String(10),
+			createOrg.GetId())
+		t.Logf("readRes, err: %+v, %v", readRes, err)
+		require.Nil(t, readRes)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+}
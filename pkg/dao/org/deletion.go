@@ -0,0 +1,227 @@
+package org
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Deletion steps that are not table names.
+const (
+	StepPending  = "pending"
+	StepDevices  = "devices"
+	StepComplete = "complete"
+)
+
+// purgeTables are the tables that reference orgs, ordered such that each table
+// is purged before any table that it references.
+var purgeTables = []string{
+	"data_points", "events", "alerts", "alert_digests", "alert_escalations",
+	"alert_lifecycles", "deferred_alerts", "commands", "connectivity_intervals",
+	"connectivity", "shadows", "alarm_recoveries", "alarm_escalations",
+	"alarm_webhooks", "alarm_digests", "alarms", "rule_conditions",
+	"rule_triggers", "rules", "user_mfas", "user_scopes", "user_schedules",
+	"user_sessions", "users", "key_restrictions", "keys", "devices",
+	"org_oidcs", "org_mfa_policies",
+}
+
+const markDeleteOrg = `
+WITH org AS (
+  SELECT id
+  FROM orgs
+  WHERE id = $1
+), devices AS (
+  UPDATE devices
+  SET status = 'DISABLED', updated_at = $3
+  WHERE org_id IN (SELECT id FROM org)
+  AND status != 'DISABLED'
+), users AS (
+  UPDATE users
+  SET status = 'DISABLED', updated_at = $3
+  WHERE org_id IN (SELECT id FROM org)
+  AND status != 'DISABLED'
+)
+INSERT INTO org_deletions (org_id, step, purged_rows, created_at, updated_at)
+SELECT id, $2, 0, $3, $3
+FROM org
+ON CONFLICT (org_id) DO UPDATE
+SET org_id = EXCLUDED.org_id
+RETURNING org_id, step, purged_rows, created_at, updated_at, completed_at
+`
+
+// MarkDelete marks an organization as pending deletion and disables its
+// devices and users in a single statement. MarkDelete is idempotent, and
+// returns the existing deletion if the organization is already pending.
+func (d *DAO) MarkDelete(ctx context.Context, orgID string) (
+	*message.OrgDeletion, error,
+) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	return scanDeletion(d.rw.QueryRowContext(ctx, markDeleteOrg, orgID,
+		StepPending, now))
+}
+
+const readDeletion = `
+SELECT org_id, step, purged_rows, created_at, updated_at, completed_at
+FROM org_deletions
+WHERE org_id = $1
+`
+
+// ReadDeletion retrieves an organization's deletion by ID. Deletions remain
+// readable after the organization is removed.
+func (d *DAO) ReadDeletion(ctx context.Context, orgID string) (
+	*message.OrgDeletion, error,
+) {
+	return scanDeletion(d.ro.QueryRowContext(ctx, readDeletion, orgID))
+}
+
+const listDeletions = `
+SELECT org_id, step, purged_rows, created_at, updated_at, completed_at
+FROM org_deletions
+WHERE completed_at IS NULL
+ORDER BY created_at ASC, org_id ASC
+`
+
+// ListDeletions retrieves all pending deletions.
+func (d *DAO) ListDeletions(ctx context.Context) (
+	[]*message.OrgDeletion, error,
+) {
+	rows, err := d.ro.QueryContext(ctx, listDeletions)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ListDeletions rows.Close: %v", err)
+		}
+	}()
+
+	var dels []*message.OrgDeletion
+	for rows.Next() {
+		del, err := scanDeletion(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		dels = append(dels, del)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return dels, nil
+}
+
+const updateDeletion = `
+UPDATE org_deletions
+SET step = $1, purged_rows = purged_rows + $2, updated_at = $3
+WHERE org_id = $4
+AND completed_at IS NULL
+RETURNING org_id
+`
+
+// UpdateDeletion records the progress of a pending deletion, adding count to
+// its purged rows.
+func (d *DAO) UpdateDeletion(
+	ctx context.Context, orgID, step string, count int64,
+) error {
+	updatedAt := time.Now().UTC().Truncate(time.Microsecond)
+
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, updateDeletion, step,
+		count, updatedAt, orgID).Scan(&orgID))
+}
+
+const purgeDeletion = `
+DELETE FROM %[1]s
+WHERE ctid = ANY(ARRAY(
+  SELECT ctid
+  FROM %[1]s
+  WHERE org_id = $1
+  LIMIT $2
+))
+`
+
+// PurgeDeletion deletes up to limit rows by org ID from the first table, in
+// dependency order, that has rows remaining. It returns the table name and the
+// number of deleted rows, which is zero once all tables are purged. Callers
+// should repeat PurgeDeletion until no rows are deleted.
+func (d *DAO) PurgeDeletion(ctx context.Context, orgID string, limit int32) (
+	string, int64, error,
+) {
+	for _, table := range purgeTables {
+		res, err := d.rw.ExecContext(ctx, fmt.Sprintf(purgeDeletion, table),
+			orgID, limit)
+		if err != nil {
+			return "", 0, dao.DBToSentinel(err)
+		}
+
+		count, err := res.RowsAffected()
+		if err != nil {
+			return "", 0, dao.DBToSentinel(err)
+		}
+
+		if count > 0 {
+			return table, count, nil
+		}
+	}
+
+	return "", 0, nil
+}
+
+const completeDeletion = `
+WITH org AS (
+  DELETE FROM orgs
+  WHERE id = $1
+)
+UPDATE org_deletions
+SET step = $2, updated_at = $3, completed_at = $3
+WHERE org_id = $1
+AND completed_at IS NULL
+RETURNING org_id
+`
+
+// CompleteDeletion removes a purged organization and marks its deletion as
+// complete. It fails if any child rows remain, in which case the deletion stays
+// pending.
+func (d *DAO) CompleteDeletion(ctx context.Context, orgID string) error {
+	completedAt := time.Now().UTC().Truncate(time.Microsecond)
+
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, completeDeletion, orgID,
+		StepComplete, completedAt).Scan(&orgID))
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanDeletion scans a row into an OrgDeletion.
+func scanDeletion(row scanner) (*message.OrgDeletion, error) {
+	del := &message.OrgDeletion{}
+	var createdAt, updatedAt time.Time
+	var completedAt sql.NullTime
+
+	if err := row.Scan(&del.OrgId, &del.Step, &del.PurgedRows, &createdAt,
+		&updatedAt, &completedAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	del.CreatedAt = timestamppb.New(createdAt)
+	del.UpdatedAt = timestamppb.New(updatedAt)
+	if completedAt.Valid {
+		del.CompletedAt = timestamppb.New(completedAt.Time)
+	}
+
+	return del, nil
+}
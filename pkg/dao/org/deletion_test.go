@@ -0,0 +1,253 @@
+//go:build !unit
+
+package org
+
+import (
+	"context"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMarkReadDeletion(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-org"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createDev, err := globalDevDAO.Create(ctx, random.Device("dao-org",
+		createOrg.GetId()))
+	t.Logf("createDev, err: %+v, %v", createDev, err)
+	require.NoError(t, err)
+
+	createUser, err := globalUserDAO.Create(ctx, random.User("dao-org",
+		createOrg.GetId()))
+	t.Logf("createUser, err: %+v, %v", createUser, err)
+	require.NoError(t, err)
+
+	markDel, err := globalOrgDAO.MarkDelete(ctx, createOrg.GetId())
+	t.Logf("markDel, err: %+v, %v", markDel, err)
+	require.NoError(t, err)
+	require.Equal(t, createOrg.GetId(), markDel.GetOrgId())
+	require.Equal(t, StepPending, markDel.GetStep())
+	require.Zero(t, markDel.GetPurgedRows())
+	require.Nil(t, markDel.GetCompletedAt())
+
+	t.Run("Devices and users are disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readDev, err := globalDevDAO.Read(ctx, createDev.GetId(),
+			createOrg.GetId())
+		t.Logf("readDev, err: %+v, %v", readDev, err)
+		require.NoError(t, err)
+		require.Equal(t, api.Status_DISABLED, readDev.GetStatus())
+
+		readUser, err := globalUserDAO.Read(ctx, createUser.GetId(),
+			createOrg.GetId())
+		t.Logf("readUser, err: %+v, %v", readUser, err)
+		require.NoError(t, err)
+		require.Equal(t, api.Status_DISABLED, readUser.GetStatus())
+	})
+
+	t.Run("Mark deletion is idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		markDel2, err := globalOrgDAO.MarkDelete(ctx, createOrg.GetId())
+		t.Logf("markDel2, err: %+v, %v", markDel2, err)
+		require.NoError(t, err)
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(markDel, markDel2) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", markDel, markDel2)
+		}
+	})
+
+	t.Run("Read deletion by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readDel, err := globalOrgDAO.ReadDeletion(ctx, createOrg.GetId())
+		t.Logf("readDel, err: %+v, %v", readDel, err)
+		require.NoError(t, err)
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(markDel, readDel) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", markDel, readDel)
+		}
+	})
+
+	t.Run("List pending deletions", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listDels, err := globalOrgDAO.ListDeletions(ctx)
+		t.Logf("listDels, err: %+v, %v", listDels, err)
+		require.NoError(t, err)
+
+		var found bool
+		for _, del := range listDels {
+			if del.GetOrgId() == createOrg.GetId() {
+				found = true
+			}
+		}
+		require.True(t, found)
+	})
+}
+
+func TestMarkReadDeletionError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Mark deletion by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		markDel, err := globalOrgDAO.MarkDelete(ctx, uuid.NewV7().String())
+		t.Logf("markDel, err: %+v, %v", markDel, err)
+		require.Nil(t, markDel)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Mark deletion by invalid ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		markDel, err := globalOrgDAO.MarkDelete(ctx, random.String(10))
+		t.Logf("markDel, err: %+v, %v", markDel, err)
+		require.Nil(t, markDel)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+
+	t.Run("Read deletion by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readDel, err := globalOrgDAO.ReadDeletion(ctx, uuid.NewV7().String())
+		t.Logf("readDel, err: %+v, %v", readDel, err)
+		require.Nil(t, readDel)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Update deletion by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		err := globalOrgDAO.UpdateDeletion(ctx, uuid.NewV7().String(),
+			StepDevices, 1)
+		t.Logf("err: %v", err)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestPurgeCompleteDeletion(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-org"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	for range 3 {
+		createDev, err := globalDevDAO.Create(ctx, random.Device("dao-org",
+			createOrg.GetId()))
+		t.Logf("createDev, err: %+v, %v", createDev, err)
+		require.NoError(t, err)
+	}
+
+	markDel, err := globalOrgDAO.MarkDelete(ctx, createOrg.GetId())
+	t.Logf("markDel, err: %+v, %v", markDel, err)
+	require.NoError(t, err)
+
+	// Subtests are not parallel, as each depends on the previous.
+
+	t.Run("Complete deletion with remaining rows", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		err := globalOrgDAO.CompleteDeletion(ctx, createOrg.GetId())
+		t.Logf("err: %v", err)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+
+	t.Run("Purge and update deletion", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		for _, exp := range []int64{2, 1, 0} {
+			table, count, err := globalOrgDAO.PurgeDeletion(ctx,
+				createOrg.GetId(), 2)
+			t.Logf("table, count, err: %v, %v, %v", table, count, err)
+			require.NoError(t, err)
+			require.Equal(t, exp, count)
+
+			if count > 0 {
+				require.Equal(t, "devices", table)
+
+				err := globalOrgDAO.UpdateDeletion(ctx, createOrg.GetId(),
+					table, count)
+				t.Logf("err: %v", err)
+				require.NoError(t, err)
+			}
+		}
+	})
+
+	t.Run("Complete and read deletion", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		err := globalOrgDAO.CompleteDeletion(ctx, createOrg.GetId())
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		readDel, err := globalOrgDAO.ReadDeletion(ctx, createOrg.GetId())
+		t.Logf("readDel, err: %+v, %v", readDel, err)
+		require.NoError(t, err)
+		require.Equal(t, StepComplete, readDel.GetStep())
+		require.Equal(t, int64(3), readDel.GetPurgedRows())
+		require.NotNil(t, readDel.GetCompletedAt())
+
+		readOrg, err := globalOrgDAO.Read(ctx, createOrg.GetId())
+		t.Logf("readOrg, err: %+v, %v", readOrg, err)
+		require.Nil(t, readOrg)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Complete deletion is not repeated", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		err := globalOrgDAO.CompleteDeletion(ctx, createOrg.GetId())
+		t.Logf("err: %v", err)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
@@ -8,10 +8,16 @@ import (
 	"testing"
 
 	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/dao/device"
+	"github.com/thingspect/atlas/pkg/dao/user"
 	"github.com/thingspect/atlas/pkg/test/config"
 )
 
-var globalOrgDAO *DAO
+var (
+	globalOrgDAO  *DAO
+	globalDevDAO  *device.DAO
+	globalUserDAO *user.DAO
+)
 
 func TestMain(m *testing.M) {
 	// Set up Config.
@@ -23,6 +29,8 @@ func TestMain(m *testing.M) {
 		log.Fatalf("TestMain dao.NewPgDB: %v", err)
 	}
 	globalOrgDAO = NewDAO(pg, pg)
+	globalDevDAO = device.NewDAO(pg, pg, nil, 0)
+	globalUserDAO = user.NewDAO(pg, pg)
 
 	os.Exit(m.Run())
 }
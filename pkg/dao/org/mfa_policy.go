@@ -0,0 +1,63 @@
+package org
+
+import (
+	"context"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const upsertMFAPolicy = `
+INSERT INTO org_mfa_policies (org_id, min_role, created_at, updated_at)
+VALUES ($1, $2, $3, $3)
+ON CONFLICT (org_id) DO UPDATE
+SET min_role = EXCLUDED.min_role, updated_at = EXCLUDED.updated_at
+RETURNING created_at
+`
+
+// UpsertMFAPolicy creates or replaces an organization's MFA policy.
+func (d *DAO) UpsertMFAPolicy(
+	ctx context.Context, policy *message.OrgMFAPolicy,
+) (*message.OrgMFAPolicy, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	policy.UpdatedAt = timestamppb.New(now)
+
+	var createdAt time.Time
+	if err := d.rw.QueryRowContext(ctx, upsertMFAPolicy, policy.GetOrgId(),
+		policy.GetMinRole().String(), now).Scan(&createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	policy.CreatedAt = timestamppb.New(createdAt)
+
+	return policy, nil
+}
+
+const readMFAPolicy = `
+SELECT org_id, min_role, created_at, updated_at
+FROM org_mfa_policies
+WHERE org_id = $1
+`
+
+// ReadMFAPolicy retrieves an organization's MFA policy by org ID.
+func (d *DAO) ReadMFAPolicy(ctx context.Context, orgID string) (
+	*message.OrgMFAPolicy, error,
+) {
+	policy := &message.OrgMFAPolicy{}
+	var minRole string
+	var createdAt, updatedAt time.Time
+
+	if err := d.ro.QueryRowContext(ctx, readMFAPolicy, orgID).Scan(
+		&policy.OrgId, &minRole, &createdAt, &updatedAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	policy.MinRole = api.Role(api.Role_value[minRole])
+	policy.CreatedAt = timestamppb.New(createdAt)
+	policy.UpdatedAt = timestamppb.New(updatedAt)
+
+	return policy, nil
+}
@@ -0,0 +1,72 @@
+//go:build !unit
+
+package org
+
+import (
+	"context"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+func TestUpsertReadMFAPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-org"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	readPolicy, err := globalOrgDAO.ReadMFAPolicy(ctx, createOrg.GetId())
+	t.Logf("readPolicy, err: %+v, %v", readPolicy, err)
+	require.Nil(t, readPolicy)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	createPolicy, err := globalOrgDAO.UpsertMFAPolicy(ctx,
+		&message.OrgMFAPolicy{
+			OrgId: createOrg.GetId(), MinRole: api.Role_ADMIN,
+		})
+	t.Logf("createPolicy, err: %+v, %v", createPolicy, err)
+	require.NoError(t, err)
+
+	readPolicy, err = globalOrgDAO.ReadMFAPolicy(ctx, createOrg.GetId())
+	t.Logf("readPolicy, err: %+v, %v", readPolicy, err)
+	require.NoError(t, err)
+	require.EqualExportedValues(t, createPolicy, readPolicy)
+
+	updPolicy, err := globalOrgDAO.UpsertMFAPolicy(ctx,
+		&message.OrgMFAPolicy{
+			OrgId: createOrg.GetId(), MinRole: api.Role_ROLE_UNSPECIFIED,
+		})
+	t.Logf("updPolicy, err: %+v, %v", updPolicy, err)
+	require.NoError(t, err)
+	require.Equal(t, createPolicy.GetCreatedAt().AsTime(),
+		updPolicy.GetCreatedAt().AsTime())
+
+	readPolicy, err = globalOrgDAO.ReadMFAPolicy(ctx, createOrg.GetId())
+	t.Logf("readPolicy, err: %+v, %v", readPolicy, err)
+	require.NoError(t, err)
+	require.Equal(t, api.Role_ROLE_UNSPECIFIED, readPolicy.GetMinRole())
+
+	t.Run("Upsert policy by unknown org", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createPolicy, err := globalOrgDAO.UpsertMFAPolicy(ctx,
+			&message.OrgMFAPolicy{
+				OrgId: uuid.NewV7().String(), MinRole: api.Role_ADMIN,
+			})
+		t.Logf("createPolicy, err: %+v, %v", createPolicy, err)
+		require.Nil(t, createPolicy)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+}
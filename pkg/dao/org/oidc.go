@@ -0,0 +1,148 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const upsertOIDC = `
+INSERT INTO org_oidcs (org_id, issuer, client_id, client_secret, role_claim,
+role_map, default_role, jit_users, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+ON CONFLICT (org_id) DO UPDATE
+SET issuer = EXCLUDED.issuer, client_id = EXCLUDED.client_id,
+client_secret = COALESCE(EXCLUDED.client_secret, org_oidcs.client_secret),
+role_claim = EXCLUDED.role_claim, role_map = EXCLUDED.role_map,
+default_role = EXCLUDED.default_role, jit_users = EXCLUDED.jit_users,
+updated_at = EXCLUDED.updated_at
+RETURNING created_at
+`
+
+// UpsertOIDC creates or updates an organization's OIDC configuration. The
+// client secret is stored as provided, and a nil secret retains any existing
+// secret. The client secret of the returned configuration is empty.
+func (d *DAO) UpsertOIDC(
+	ctx context.Context, oidc *message.OrgOIDC, secret []byte,
+) (*message.OrgOIDC, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	oidc.UpdatedAt = timestamppb.New(now)
+	oidc.ClientSecret = ""
+
+	roleMap, err := marshalRoleMap(oidc.GetRoleMap())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", dao.ErrInvalidFormat, err)
+	}
+
+	var createdAt time.Time
+	if err := d.rw.QueryRowContext(ctx, upsertOIDC, oidc.GetOrgId(),
+		oidc.GetIssuer(), oidc.GetClientId(), secret, oidc.GetRoleClaim(),
+		roleMap, oidc.GetDefaultRole().String(), oidc.GetJitUsers(),
+		now).Scan(&createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	oidc.CreatedAt = timestamppb.New(createdAt)
+
+	return oidc, nil
+}
+
+const readOIDC = `
+SELECT o.org_id, o.issuer, o.client_id, o.client_secret, o.role_claim,
+o.role_map, o.default_role, o.jit_users, o.created_at, o.updated_at
+FROM org_oidcs o
+`
+
+const readOIDCByID = readOIDC + `
+WHERE o.org_id = $1
+`
+
+// ReadOIDC retrieves an organization's OIDC configuration and its stored
+// client secret by org ID.
+func (d *DAO) ReadOIDC(ctx context.Context, orgID string) (
+	*message.OrgOIDC, []byte, error,
+) {
+	return scanOIDC(d.ro.QueryRowContext(ctx, readOIDCByID, orgID))
+}
+
+const readOIDCByName = readOIDC + `
+INNER JOIN orgs g ON o.org_id = g.id
+WHERE g.name = $1
+`
+
+// ReadOIDCByName retrieves an organization's OIDC configuration and its stored
+// client secret by org name.
+func (d *DAO) ReadOIDCByName(ctx context.Context, orgName string) (
+	*message.OrgOIDC, []byte, error,
+) {
+	return scanOIDC(d.ro.QueryRowContext(ctx, readOIDCByName, orgName))
+}
+
+const deleteOIDC = `
+DELETE FROM org_oidcs
+WHERE org_id = $1
+RETURNING org_id
+`
+
+// DeleteOIDC deletes an organization's OIDC configuration by org ID.
+func (d *DAO) DeleteOIDC(ctx context.Context, orgID string) error {
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, deleteOIDC,
+		orgID).Scan(&orgID))
+}
+
+// scanOIDC scans a row into an OrgOIDC and its stored client secret.
+func scanOIDC(row scanner) (*message.OrgOIDC, []byte, error) {
+	oidc := &message.OrgOIDC{}
+	var secret, roleMap []byte
+	var defaultRole string
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&oidc.OrgId, &oidc.Issuer, &oidc.ClientId, &secret,
+		&oidc.RoleClaim, &roleMap, &defaultRole, &oidc.JitUsers, &createdAt,
+		&updatedAt); err != nil {
+		return nil, nil, dao.DBToSentinel(err)
+	}
+
+	var err error
+	if oidc.RoleMap, err = unmarshalRoleMap(roleMap); err != nil {
+		return nil, nil, err
+	}
+
+	oidc.DefaultRole = api.Role(api.Role_value[defaultRole])
+	oidc.CreatedAt = timestamppb.New(createdAt)
+	oidc.UpdatedAt = timestamppb.New(updatedAt)
+
+	return oidc, secret, nil
+}
+
+// marshalRoleMap marshals a role map to JSON, using role names for
+// readability.
+func marshalRoleMap(roleMap map[string]api.Role) ([]byte, error) {
+	names := make(map[string]string, len(roleMap))
+	for val, role := range roleMap {
+		names[val] = role.String()
+	}
+
+	return json.Marshal(names)
+}
+
+// unmarshalRoleMap unmarshals a role map from JSON.
+func unmarshalRoleMap(b []byte) (map[string]api.Role, error) {
+	names := map[string]string{}
+	if err := json.Unmarshal(b, &names); err != nil {
+		return nil, err
+	}
+
+	roleMap := make(map[string]api.Role, len(names))
+	for val, name := range names {
+		roleMap[val] = api.Role(api.Role_value[name])
+	}
+
+	return roleMap, nil
+}
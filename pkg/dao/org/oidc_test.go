@@ -0,0 +1,157 @@
+//go:build !unit
+
+package org
+
+import (
+	"context"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUpsertReadOIDC(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-org"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	oidc := &message.OrgOIDC{
+		OrgId: createOrg.GetId(), Issuer: "https://" + random.String(10),
+		ClientId: random.String(10), RoleClaim: "groups",
+		RoleMap: map[string]api.Role{
+			"atlas-admins": api.Role_ADMIN, "atlas-builders": api.Role_BUILDER,
+		},
+		DefaultRole: api.Role_VIEWER, JitUsers: true,
+	}
+	secret := random.Bytes(20)
+
+	createOIDC, err := globalOrgDAO.UpsertOIDC(ctx, oidc, secret)
+	t.Logf("createOIDC, err: %+v, %v", createOIDC, err)
+	require.NoError(t, err)
+	require.NotNil(t, createOIDC.GetCreatedAt())
+
+	readOIDC, readSecret, err := globalOrgDAO.ReadOIDC(ctx, createOrg.GetId())
+	t.Logf("readOIDC, err: %+v, %v", readOIDC, err)
+	require.NoError(t, err)
+	require.EqualExportedValues(t, createOIDC, readOIDC)
+	require.Equal(t, secret, readSecret)
+
+	readOIDC, readSecret, err = globalOrgDAO.ReadOIDCByName(ctx,
+		createOrg.GetName())
+	t.Logf("readOIDC, err: %+v, %v", readOIDC, err)
+	require.NoError(t, err)
+	require.EqualExportedValues(t, createOIDC, readOIDC)
+	require.Equal(t, secret, readSecret)
+
+	// Update and retain the existing secret.
+	updOIDC, _ := proto.Clone(createOIDC).(*message.OrgOIDC)
+	updOIDC.ClientId = random.String(10)
+	updOIDC.RoleMap = nil
+	updOIDC.JitUsers = false
+
+	updOIDC, err = globalOrgDAO.UpsertOIDC(ctx, updOIDC, nil)
+	t.Logf("updOIDC, err: %+v, %v", updOIDC, err)
+	require.NoError(t, err)
+	require.Equal(t, createOIDC.GetCreatedAt().AsTime(),
+		updOIDC.GetCreatedAt().AsTime())
+
+	readOIDC, readSecret, err = globalOrgDAO.ReadOIDC(ctx, createOrg.GetId())
+	t.Logf("readOIDC, err: %+v, %v", readOIDC, err)
+	require.NoError(t, err)
+	require.Equal(t, updOIDC.GetClientId(), readOIDC.GetClientId())
+	require.Empty(t, readOIDC.GetRoleMap())
+	require.False(t, readOIDC.GetJitUsers())
+	require.Equal(t, secret, readSecret)
+
+	t.Run("Read OIDC by unknown org", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readOIDC, readSecret, err := globalOrgDAO.ReadOIDC(ctx,
+			uuid.NewV7().String())
+		t.Logf("readOIDC, err: %+v, %v", readOIDC, err)
+		require.Nil(t, readOIDC)
+		require.Nil(t, readSecret)
+		require.Equal(t, dao.ErrNotFound, err)
+
+		readOIDC, readSecret, err = globalOrgDAO.ReadOIDCByName(ctx,
+			random.String(10))
+		t.Logf("readOIDC, err: %+v, %v", readOIDC, err)
+		require.Nil(t, readOIDC)
+		require.Nil(t, readSecret)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Upsert OIDC by unknown org", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		unkOIDC, _ := proto.Clone(oidc).(*message.OrgOIDC)
+		unkOIDC.OrgId = uuid.NewV7().String()
+
+		createOIDC, err := globalOrgDAO.UpsertOIDC(ctx, unkOIDC, secret)
+		t.Logf("createOIDC, err: %+v, %v", createOIDC, err)
+		require.Nil(t, createOIDC)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+
+	t.Run("Upsert OIDC with invalid issuer", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		badOIDC, _ := proto.Clone(oidc).(*message.OrgOIDC)
+		badOIDC.Issuer = random.String(256)
+
+		createOIDC, err := globalOrgDAO.UpsertOIDC(ctx, badOIDC, secret)
+		t.Logf("createOIDC, err: %+v, %v", createOIDC, err)
+		require.Nil(t, createOIDC)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+}
+
+func TestDeleteOIDC(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-org"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createOIDC, err := globalOrgDAO.UpsertOIDC(ctx, &message.OrgOIDC{
+		OrgId: createOrg.GetId(), Issuer: "https://" + random.String(10),
+		ClientId: random.String(10),
+	}, nil)
+	t.Logf("createOIDC, err: %+v, %v", createOIDC, err)
+	require.NoError(t, err)
+
+	err = globalOrgDAO.DeleteOIDC(ctx, createOrg.GetId())
+	t.Logf("err: %v", err)
+	require.NoError(t, err)
+
+	readOIDC, _, err := globalOrgDAO.ReadOIDC(ctx, createOrg.GetId())
+	t.Logf("readOIDC, err: %+v, %v", readOIDC, err)
+	require.Nil(t, readOIDC)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	err = globalOrgDAO.DeleteOIDC(ctx, createOrg.GetId())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+}
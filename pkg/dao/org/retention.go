@@ -0,0 +1,122 @@
+package org
+
+import (
+	"context"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+)
+
+const readRetention = `
+SELECT id, data_point_retention_days, event_retention_days,
+alert_retention_days
+FROM orgs
+WHERE id = $1
+`
+
+// ReadRetention retrieves an organization's retention policy by ID. Zero values
+// indicate that the platform default applies.
+func (d *DAO) ReadRetention(ctx context.Context, orgID string) (
+	*message.OrgRetention, error,
+) {
+	ret := &message.OrgRetention{}
+	var dpDays, eventDays, alertDays *int32
+
+	if err := d.ro.QueryRowContext(ctx, readRetention, orgID).Scan(&ret.OrgId,
+		&dpDays, &eventDays, &alertDays); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	ret.DataPointDays = derefDays(dpDays)
+	ret.EventDays = derefDays(eventDays)
+	ret.AlertDays = derefDays(alertDays)
+
+	return ret, nil
+}
+
+const updateRetention = `
+UPDATE orgs
+SET data_point_retention_days = $1, event_retention_days = $2,
+alert_retention_days = $3
+WHERE id = $4
+RETURNING id
+`
+
+// UpdateRetention updates an organization's retention policy in the database.
+// Zero values revert to the platform default.
+func (d *DAO) UpdateRetention(
+	ctx context.Context, ret *message.OrgRetention,
+) error {
+	var orgID string
+
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, updateRetention,
+		nilDays(ret.GetDataPointDays()), nilDays(ret.GetEventDays()),
+		nilDays(ret.GetAlertDays()), ret.GetOrgId()).Scan(&orgID))
+}
+
+const listRetentions = `
+SELECT id, data_point_retention_days, event_retention_days,
+alert_retention_days
+FROM orgs
+ORDER BY created_at ASC, id ASC
+`
+
+// ListRetentions retrieves the retention policies of all organizations.
+func (d *DAO) ListRetentions(ctx context.Context) (
+	[]*message.OrgRetention, error,
+) {
+	rows, err := d.ro.QueryContext(ctx, listRetentions)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ListRetentions rows.Close: %v", err)
+		}
+	}()
+
+	var rets []*message.OrgRetention
+	for rows.Next() {
+		ret := &message.OrgRetention{}
+		var dpDays, eventDays, alertDays *int32
+
+		if err = rows.Scan(&ret.OrgId, &dpDays, &eventDays,
+			&alertDays); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		ret.DataPointDays = derefDays(dpDays)
+		ret.EventDays = derefDays(eventDays)
+		ret.AlertDays = derefDays(alertDays)
+		rets = append(rets, ret)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return rets, nil
+}
+
+// nilDays returns nil for zero values, which are stored as NULL.
+func nilDays(days int32) *int32 {
+	if days <= 0 {
+		return nil
+	}
+
+	return &days
+}
+
+// derefDays returns zero for NULL values.
+func derefDays(days *int32) int32 {
+	if days == nil {
+		return 0
+	}
+
+	return *days
+}
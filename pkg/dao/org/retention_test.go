@@ -0,0 +1,118 @@
+//go:build !unit
+
+package org
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReadRetention(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-org"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	t.Run("Read retention by valid ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readRet, err := globalOrgDAO.ReadRetention(ctx, createOrg.GetId())
+		t.Logf("readRet, err: %+v, %v", readRet, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t,
+			&message.OrgRetention{OrgId: createOrg.GetId()}, readRet)
+	})
+
+	t.Run("Read retention by unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readRet, err := globalOrgDAO.ReadRetention(ctx, uuid.NewV7().String())
+		t.Logf("readRet, err: %+v, %v", readRet, err)
+		require.Nil(t, readRet)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Read retention by invalid ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readRet, err := globalOrgDAO.ReadRetention(ctx, random.String(10))
+		t.Logf("readRet, err: %+v, %v", readRet, err)
+		require.Nil(t, readRet)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+}
+
+func TestUpdateRetention(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Update retention by valid retention", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-org"))
+		t.Logf("createOrg, err: %+v, %v", createOrg, err)
+		require.NoError(t, err)
+
+		ret := &message.OrgRetention{
+			OrgId: createOrg.GetId(), DataPointDays: 30, AlertDays: 7,
+		}
+
+		err = globalOrgDAO.UpdateRetention(ctx, ret)
+		t.Logf("err: %v", err)
+		require.NoError(t, err)
+
+		readRet, err := globalOrgDAO.ReadRetention(ctx, createOrg.GetId())
+		t.Logf("readRet, err: %+v, %v", readRet, err)
+		require.NoError(t, err)
+
+		// Testify does not currently support protobuf equality:
+		// https://github.com/stretchr/testify/issues/758
+		if !proto.Equal(ret, readRet) {
+			t.Fatalf("\nExpect: %+v\nActual: %+v", ret, readRet)
+		}
+
+		listRets, err := globalOrgDAO.ListRetentions(ctx)
+		t.Logf("listRets, err: %+v, %v", listRets, err)
+		require.NoError(t, err)
+		require.True(t, slices.ContainsFunc(listRets,
+			func(listRet *message.OrgRetention) bool {
+				return proto.Equal(ret, listRet)
+			}))
+	})
+
+	t.Run("Update retention of unknown org", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		err := globalOrgDAO.UpdateRetention(ctx, &message.OrgRetention{
+			OrgId: uuid.NewV7().String(), EventDays: 30,
+		})
+		t.Logf("err: %v", err)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
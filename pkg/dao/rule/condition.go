@@ -0,0 +1,139 @@
+package rule
+
+import (
+	"context"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const upsertCondition = `
+INSERT INTO rule_conditions (rule_id, org_id, for_samples, for_sec, exit_expr,
+created_at, updated_at)
+SELECT id, org_id, $3, $4, $5, $6, $6
+FROM rules
+WHERE (id, org_id) = ($1, $2)
+ON CONFLICT (rule_id) DO UPDATE
+SET for_samples = EXCLUDED.for_samples, for_sec = EXCLUDED.for_sec,
+exit_expr = EXCLUDED.exit_expr, updated_at = EXCLUDED.updated_at
+RETURNING created_at
+`
+
+// UpsertCondition creates or updates a rule's condition. Durations are stored
+// with second precision.
+func (d *DAO) UpsertCondition(
+	ctx context.Context, cond *message.RuleCondition,
+) (*message.RuleCondition, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	cond.UpdatedAt = timestamppb.New(now)
+
+	//nolint:gosec // Safe conversion for limited values.
+	sec := int32(cond.GetForDuration().AsDuration() / time.Second)
+
+	var createdAt time.Time
+	if err := d.rw.QueryRowContext(ctx, upsertCondition, cond.GetRuleId(),
+		cond.GetOrgId(), cond.GetForSamples(), sec, cond.GetExitExpr(),
+		now).Scan(&createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	cond.ForDuration = durationpb.New(time.Duration(sec) * time.Second)
+	cond.CreatedAt = timestamppb.New(createdAt)
+
+	return cond, nil
+}
+
+const readCondition = `
+SELECT rule_id, org_id, for_samples, for_sec, exit_expr, created_at,
+updated_at
+FROM rule_conditions
+WHERE (rule_id, org_id) = ($1, $2)
+`
+
+// ReadCondition retrieves a rule's condition by rule ID and org ID.
+func (d *DAO) ReadCondition(ctx context.Context, ruleID, orgID string) (
+	*message.RuleCondition, error,
+) {
+	cond := &message.RuleCondition{}
+	var sec int32
+	var createdAt, updatedAt time.Time
+
+	if err := d.ro.QueryRowContext(ctx, readCondition, ruleID, orgID).Scan(
+		&cond.RuleId, &cond.OrgId, &cond.ForSamples, &sec, &cond.ExitExpr,
+		&createdAt, &updatedAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	cond.ForDuration = durationpb.New(time.Duration(sec) * time.Second)
+	cond.CreatedAt = timestamppb.New(createdAt)
+	cond.UpdatedAt = timestamppb.New(updatedAt)
+
+	return cond, nil
+}
+
+const deleteCondition = `
+DELETE FROM rule_conditions
+WHERE (rule_id, org_id) = ($1, $2)
+RETURNING rule_id
+`
+
+// DeleteCondition deletes a rule's condition by rule ID and org ID.
+func (d *DAO) DeleteCondition(ctx context.Context, ruleID, orgID string) error {
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, deleteCondition, ruleID,
+		orgID).Scan(&ruleID))
+}
+
+const listConditions = `
+SELECT rule_id, org_id, for_samples, for_sec, exit_expr, created_at,
+updated_at
+FROM rule_conditions
+WHERE org_id = $1
+AND rule_id = ANY ($2::uuid[])
+`
+
+// ListConditions retrieves the conditions of rules by org ID and rule IDs,
+// keyed by rule ID. Rules without a condition are absent.
+func (d *DAO) ListConditions(
+	ctx context.Context, orgID string, ruleIDs []string,
+) (map[string]*message.RuleCondition, error) {
+	rows, err := d.ro.QueryContext(ctx, listConditions, orgID, ruleIDs)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ListConditions rows.Close: %v", err)
+		}
+	}()
+
+	conds := map[string]*message.RuleCondition{}
+	for rows.Next() {
+		cond := &message.RuleCondition{}
+		var sec int32
+		var createdAt, updatedAt time.Time
+
+		if err = rows.Scan(&cond.RuleId, &cond.OrgId, &cond.ForSamples, &sec,
+			&cond.ExitExpr, &createdAt, &updatedAt); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		cond.ForDuration = durationpb.New(time.Duration(sec) * time.Second)
+		cond.CreatedAt = timestamppb.New(createdAt)
+		cond.UpdatedAt = timestamppb.New(updatedAt)
+		conds[cond.GetRuleId()] = cond
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return conds, nil
+}
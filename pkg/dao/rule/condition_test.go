@@ -0,0 +1,204 @@
+//go:build !unit
+
+package rule
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestUpsertReadCondition(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-rule"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	t.Run("Upsert and read valid condition", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-rule",
+			createOrg.GetId()))
+		t.Logf("createRule, err: %+v, %v", createRule, err)
+		require.NoError(t, err)
+
+		createCond, err := globalRuleDAO.UpsertCondition(ctx,
+			&message.RuleCondition{
+				RuleId: createRule.GetId(), OrgId: createOrg.GetId(),
+				ForSamples:  3,
+				ForDuration: durationpb.New(5*time.Minute + time.Millisecond),
+				ExitExpr:    `pointVal < 30`,
+			})
+		t.Logf("createCond, err: %+v, %v", createCond, err)
+		require.NoError(t, err)
+		require.Equal(t, 5*time.Minute, createCond.GetForDuration().AsDuration())
+		require.WithinDuration(t, time.Now(), createCond.GetCreatedAt().AsTime(),
+			2*time.Second)
+
+		updCond, err := globalRuleDAO.UpsertCondition(ctx,
+			&message.RuleCondition{
+				RuleId: createRule.GetId(), OrgId: createOrg.GetId(),
+				ForSamples: 1, ForDuration: durationpb.New(0),
+			})
+		t.Logf("updCond, err: %+v, %v", updCond, err)
+		require.NoError(t, err)
+		require.Equal(t, createCond.GetCreatedAt().AsTime(),
+			updCond.GetCreatedAt().AsTime())
+
+		readCond, err := globalRuleDAO.ReadCondition(ctx, createRule.GetId(),
+			createOrg.GetId())
+		t.Logf("readCond, err: %+v, %v", readCond, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, updCond, readCond)
+	})
+
+	t.Run("Upsert condition by unknown rule", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createCond, err := globalRuleDAO.UpsertCondition(ctx,
+			&message.RuleCondition{
+				RuleId: uuid.NewV7().String(), OrgId: createOrg.GetId(),
+				ForSamples: 1,
+			})
+		t.Logf("createCond, err: %+v, %v", createCond, err)
+		require.Nil(t, createCond)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Upsert invalid condition", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-rule",
+			createOrg.GetId()))
+		t.Logf("createRule, err: %+v, %v", createRule, err)
+		require.NoError(t, err)
+
+		createCond, err := globalRuleDAO.UpsertCondition(ctx,
+			&message.RuleCondition{
+				RuleId: createRule.GetId(), OrgId: createOrg.GetId(),
+			})
+		t.Logf("createCond, err: %+v, %v", createCond, err)
+		require.Nil(t, createCond)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+
+	t.Run("Read condition by unknown rule", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readCond, err := globalRuleDAO.ReadCondition(ctx, uuid.NewV7().String(),
+			createOrg.GetId())
+		t.Logf("readCond, err: %+v, %v", readCond, err)
+		require.Nil(t, readCond)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestDeleteCondition(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-rule"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-rule",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	_, err = globalRuleDAO.UpsertCondition(ctx, &message.RuleCondition{
+		RuleId: createRule.GetId(), OrgId: createOrg.GetId(), ForSamples: 2,
+	})
+	require.NoError(t, err)
+
+	err = globalRuleDAO.DeleteCondition(ctx, createRule.GetId(),
+		createOrg.GetId())
+	t.Logf("err: %v", err)
+	require.NoError(t, err)
+
+	err = globalRuleDAO.DeleteCondition(ctx, createRule.GetId(),
+		createOrg.GetId())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+}
+
+func TestListConditions(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-rule"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	var ruleIDs []string
+	for range 3 {
+		createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-rule",
+			createOrg.GetId()))
+		t.Logf("createRule, err: %+v, %v", createRule, err)
+		require.NoError(t, err)
+		ruleIDs = append(ruleIDs, createRule.GetId())
+	}
+
+	// Leave the last rule without a condition.
+	for _, ruleID := range ruleIDs[:2] {
+		_, err := globalRuleDAO.UpsertCondition(ctx, &message.RuleCondition{
+			RuleId: ruleID, OrgId: createOrg.GetId(), ForSamples: 2,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("List conditions by valid rule IDs", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listConds, err := globalRuleDAO.ListConditions(ctx, createOrg.GetId(),
+			ruleIDs)
+		t.Logf("listConds, err: %+v, %v", listConds, err)
+		require.NoError(t, err)
+		require.Len(t, listConds, 2)
+		require.Equal(t, int32(2), listConds[ruleIDs[0]].GetForSamples())
+		require.NotContains(t, listConds, ruleIDs[2])
+	})
+
+	t.Run("List conditions by unknown org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listConds, err := globalRuleDAO.ListConditions(ctx,
+			uuid.NewV7().String(), ruleIDs)
+		t.Logf("listConds, err: %+v, %v", listConds, err)
+		require.NoError(t, err)
+		require.Empty(t, listConds)
+	})
+}
@@ -200,15 +200,19 @@ func (d *DAO) List(
 }
 
 const listByTags = `
-SELECT id, org_id, name, status, device_tag, attr, expr, created_at, updated_at
-FROM rules
-WHERE (org_id, status, attr) = ($1, 'ACTIVE', $2)
-AND device_tag = ANY ($3::varchar(255)[])
-ORDER BY created_at
+SELECT r.id, r.org_id, r.name, r.status, r.device_tag, r.attr, r.expr,
+r.created_at, r.updated_at
+FROM rules r
+LEFT JOIN rule_triggers t ON r.id = t.rule_id
+WHERE (r.org_id, r.status) = ($1, 'ACTIVE')
+AND (r.attr = $2 OR $2 = ANY (t.attrs))
+AND r.device_tag = ANY ($3::varchar(255)[])
+ORDER BY r.created_at
 `
 
 // ListByTags retrieves all active rules by org ID, attribute, and any matching
-// device tags.
+// device tags. Rules match by their attribute or any of their trigger's
+// attributes.
 func (d *DAO) ListByTags(
 	ctx context.Context, orgID string, attr string, deviceTags []string,
 ) ([]*api.Rule, error) {
@@ -0,0 +1,76 @@
+package rule
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const upsertTrigger = `
+INSERT INTO rule_triggers (rule_id, org_id, attrs, created_at, updated_at)
+SELECT id, org_id, $3, $4, $4
+FROM rules
+WHERE (id, org_id) = ($1, $2)
+ON CONFLICT (rule_id) DO UPDATE
+SET attrs = EXCLUDED.attrs, updated_at = EXCLUDED.updated_at
+RETURNING created_at
+`
+
+// UpsertTrigger creates or updates a rule's trigger.
+func (d *DAO) UpsertTrigger(
+	ctx context.Context, trig *message.RuleTrigger,
+) (*message.RuleTrigger, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	trig.UpdatedAt = timestamppb.New(now)
+
+	var createdAt time.Time
+	if err := d.rw.QueryRowContext(ctx, upsertTrigger, trig.GetRuleId(),
+		trig.GetOrgId(), trig.GetAttrs(), now).Scan(&createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	trig.CreatedAt = timestamppb.New(createdAt)
+
+	return trig, nil
+}
+
+const readTrigger = `
+SELECT rule_id, org_id, attrs, created_at, updated_at
+FROM rule_triggers
+WHERE (rule_id, org_id) = ($1, $2)
+`
+
+// ReadTrigger retrieves a rule's trigger by rule ID and org ID.
+func (d *DAO) ReadTrigger(ctx context.Context, ruleID, orgID string) (
+	*message.RuleTrigger, error,
+) {
+	trig := &message.RuleTrigger{}
+	var createdAt, updatedAt time.Time
+
+	if err := d.ro.QueryRowContext(ctx, readTrigger, ruleID, orgID).Scan(
+		&trig.RuleId, &trig.OrgId, pgtype.NewMap().SQLScanner(&trig.Attrs),
+		&createdAt, &updatedAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	trig.CreatedAt = timestamppb.New(createdAt)
+	trig.UpdatedAt = timestamppb.New(updatedAt)
+
+	return trig, nil
+}
+
+const deleteTrigger = `
+DELETE FROM rule_triggers
+WHERE (rule_id, org_id) = ($1, $2)
+RETURNING rule_id
+`
+
+// DeleteTrigger deletes a rule's trigger by rule ID and org ID.
+func (d *DAO) DeleteTrigger(ctx context.Context, ruleID, orgID string) error {
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, deleteTrigger, ruleID,
+		orgID).Scan(&ruleID))
+}
@@ -0,0 +1,189 @@
+//go:build !unit
+
+package rule
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+func TestUpsertReadTrigger(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-rule"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	t.Run("Upsert and read valid trigger", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-rule",
+			createOrg.GetId()))
+		t.Logf("createRule, err: %+v, %v", createRule, err)
+		require.NoError(t, err)
+
+		createTrig, err := globalRuleDAO.UpsertTrigger(ctx,
+			&message.RuleTrigger{
+				RuleId: createRule.GetId(), OrgId: createOrg.GetId(),
+				Attrs: []string{"dao-rule-" + random.String(10)},
+			})
+		t.Logf("createTrig, err: %+v, %v", createTrig, err)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now(),
+			createTrig.GetCreatedAt().AsTime(), 2*time.Second)
+
+		updTrig, err := globalRuleDAO.UpsertTrigger(ctx,
+			&message.RuleTrigger{
+				RuleId: createRule.GetId(), OrgId: createOrg.GetId(),
+				Attrs: []string{
+					"dao-rule-" + random.String(10),
+					"dao-rule-" + random.String(10),
+				},
+			})
+		t.Logf("updTrig, err: %+v, %v", updTrig, err)
+		require.NoError(t, err)
+		require.Equal(t, createTrig.GetCreatedAt().AsTime(),
+			updTrig.GetCreatedAt().AsTime())
+
+		readTrig, err := globalRuleDAO.ReadTrigger(ctx, createRule.GetId(),
+			createOrg.GetId())
+		t.Logf("readTrig, err: %+v, %v", readTrig, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, updTrig, readTrig)
+	})
+
+	t.Run("Upsert trigger by unknown rule", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createTrig, err := globalRuleDAO.UpsertTrigger(ctx,
+			&message.RuleTrigger{
+				RuleId: uuid.NewV7().String(), OrgId: createOrg.GetId(),
+				Attrs: []string{"dao-rule-" + random.String(10)},
+			})
+		t.Logf("createTrig, err: %+v, %v", createTrig, err)
+		require.Nil(t, createTrig)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Upsert invalid trigger", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-rule",
+			createOrg.GetId()))
+		t.Logf("createRule, err: %+v, %v", createRule, err)
+		require.NoError(t, err)
+
+		createTrig, err := globalRuleDAO.UpsertTrigger(ctx,
+			&message.RuleTrigger{
+				RuleId: createRule.GetId(), OrgId: createOrg.GetId(),
+				Attrs: []string{},
+			})
+		t.Logf("createTrig, err: %+v, %v", createTrig, err)
+		require.Nil(t, createTrig)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+
+	t.Run("Read trigger by unknown rule", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readTrig, err := globalRuleDAO.ReadTrigger(ctx, uuid.NewV7().String(),
+			createOrg.GetId())
+		t.Logf("readTrig, err: %+v, %v", readTrig, err)
+		require.Nil(t, readTrig)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestDeleteTrigger(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-rule"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createRule, err := globalRuleDAO.Create(ctx, random.Rule("dao-rule",
+		createOrg.GetId()))
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	_, err = globalRuleDAO.UpsertTrigger(ctx, &message.RuleTrigger{
+		RuleId: createRule.GetId(), OrgId: createOrg.GetId(),
+		Attrs: []string{"dao-rule-" + random.String(10)},
+	})
+	require.NoError(t, err)
+
+	err = globalRuleDAO.DeleteTrigger(ctx, createRule.GetId(),
+		createOrg.GetId())
+	t.Logf("err: %v", err)
+	require.NoError(t, err)
+
+	err = globalRuleDAO.DeleteTrigger(ctx, createRule.GetId(),
+		createOrg.GetId())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+}
+
+func TestListByTagsTrigger(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-rule"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	rule := random.Rule("dao-rule", createOrg.GetId())
+	rule.Status = api.Status_ACTIVE
+	createRule, err := globalRuleDAO.Create(ctx, rule)
+	t.Logf("createRule, err: %+v, %v", createRule, err)
+	require.NoError(t, err)
+
+	trigAttr := "dao-rule-" + random.String(10)
+	_, err = globalRuleDAO.UpsertTrigger(ctx, &message.RuleTrigger{
+		RuleId: createRule.GetId(), OrgId: createOrg.GetId(),
+		Attrs: []string{trigAttr},
+	})
+	require.NoError(t, err)
+
+	for _, attr := range []string{createRule.GetAttr(), trigAttr} {
+		listRules, err := globalRuleDAO.ListByTags(ctx, createOrg.GetId(),
+			attr, []string{createRule.GetDeviceTag()})
+		t.Logf("listRules, err: %+v, %v", listRules, err)
+		require.NoError(t, err)
+		require.Len(t, listRules, 1)
+		require.EqualExportedValues(t, createRule, listRules[0])
+	}
+
+	listRules, err := globalRuleDAO.ListByTags(ctx, createOrg.GetId(),
+		"dao-rule-"+random.String(10), []string{createRule.GetDeviceTag()})
+	t.Logf("listRules, err: %+v, %v", listRules, err)
+	require.NoError(t, err)
+	require.Empty(t, listRules)
+}
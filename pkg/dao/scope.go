@@ -0,0 +1,41 @@
+package dao
+
+import "fmt"
+
+// tagScope is a condition on an array of tags that is met when any tag matches
+// a scope tag, or is nested beneath one using '/'. Scope tags are provided as a
+// text array argument.
+const tagScope = `
+EXISTS (
+  SELECT 1
+  FROM unnest(%[1]s) AS t(tag), unnest($%[2]d::text[]) AS s(tag)
+  WHERE t.tag = s.tag
+  OR starts_with(t.tag, s.tag || '/')
+)
+`
+
+// deviceScope is a condition on a table aliased as 'e' with org_id and uniq_id
+// columns that is met when the related device's tags are in scope.
+const deviceScope = `
+EXISTS (
+  SELECT 1
+  FROM devices sd
+  WHERE (sd.org_id, sd.uniq_id) = (e.org_id, e.uniq_id)
+  AND %s
+)
+`
+
+// TagScope returns a condition, for use in a WHERE clause, that tags are in
+// scope by scope tags argument position. tagsExpr must evaluate to a text
+// array, such as a column name. This function should only be used from within
+// DAO packages.
+func TagScope(tagsExpr string, arg int) string {
+	return fmt.Sprintf(tagScope, tagsExpr, arg)
+}
+
+// DeviceScope returns a condition, for use in a WHERE clause, that the device
+// of a row in a table aliased as 'e' is in scope by scope tags argument
+// position. This function should only be used from within DAO packages.
+func DeviceScope(arg int) string {
+	return fmt.Sprintf(deviceScope, TagScope("sd.tags", arg))
+}
@@ -0,0 +1,28 @@
+//go:build !integration
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagScope(t *testing.T) {
+	t.Parallel()
+
+	scope := TagScope("d.tags", 3)
+	t.Logf("scope: %v", scope)
+	require.Contains(t, scope, "unnest(d.tags)")
+	require.Contains(t, scope, "unnest($3::text[])")
+}
+
+func TestDeviceScope(t *testing.T) {
+	t.Parallel()
+
+	scope := DeviceScope(5)
+	t.Logf("scope: %v", scope)
+	require.Contains(t, scope, "(sd.org_id, sd.uniq_id) = (e.org_id, e.uniq_id)")
+	require.Contains(t, scope, "unnest(sd.tags)")
+	require.Contains(t, scope, "unnest($5::text[])")
+}
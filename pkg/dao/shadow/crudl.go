@@ -0,0 +1,73 @@
+package shadow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const readDesired = `
+SELECT dev_id, org_id, desired, version, updated_at
+FROM shadows
+WHERE (dev_id, org_id) = ($1, $2)
+`
+
+// ReadDesired retrieves a shadow's desired state by device ID and org ID.
+func (d *DAO) ReadDesired(ctx context.Context, devID, orgID string) (
+	*message.Shadow, error,
+) {
+	shadow := &message.Shadow{}
+	var desired []byte
+	var updatedAt time.Time
+
+	if err := d.ro.QueryRowContext(ctx, readDesired, devID, orgID).Scan(
+		&shadow.DevId, &shadow.OrgId, &desired, &shadow.Version,
+		&updatedAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	shadow.Desired = &structpb.Struct{}
+	if err := protojson.Unmarshal(desired, shadow.GetDesired()); err != nil {
+		return nil, err
+	}
+	shadow.UpdatedAt = timestamppb.New(updatedAt)
+
+	return shadow, nil
+}
+
+const upsertDesired = `
+INSERT INTO shadows (dev_id, org_id, desired, version, updated_at)
+VALUES ($1, $2, $3, 1, $4)
+ON CONFLICT (dev_id) DO UPDATE
+SET desired = EXCLUDED.desired, version = shadows.version + 1,
+updated_at = EXCLUDED.updated_at
+WHERE shadows.org_id = EXCLUDED.org_id
+RETURNING version
+`
+
+// UpdateDesired creates or replaces a shadow's desired state, incrementing
+// its version.
+func (d *DAO) UpdateDesired(ctx context.Context, shadow *message.Shadow) (
+	*message.Shadow, error,
+) {
+	desired, err := protojson.Marshal(shadow.GetDesired())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", dao.ErrInvalidFormat, err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	shadow.UpdatedAt = timestamppb.New(now)
+
+	if err := d.rw.QueryRowContext(ctx, upsertDesired, shadow.GetDevId(),
+		shadow.GetOrgId(), desired, now).Scan(&shadow.Version); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return shadow, nil
+}
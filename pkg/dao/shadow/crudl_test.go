@@ -0,0 +1,140 @@
+//go:build !unit
+
+package shadow
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const testTimeout = 6 * time.Second
+
+func TestReadUpdateDesired(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-shadow"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	t.Run("Update and read desired by valid shadow", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createDev, err := globalDevDAO.Create(ctx, random.Device("dao-shadow",
+			createOrg.GetId()))
+		t.Logf("createDev, err: %+v, %v", createDev, err)
+		require.NoError(t, err)
+
+		desired, err := structpb.NewStruct(map[string]any{
+			"setpoint": 21.5, "mode": "heat", "led": true,
+		})
+		require.NoError(t, err)
+
+		shadow := &message.Shadow{
+			DevId: createDev.GetId(), OrgId: createOrg.GetId(), Desired: desired,
+		}
+
+		updShadow, err := globalShadowDAO.UpdateDesired(ctx, shadow)
+		t.Logf("updShadow, err: %+v, %v", updShadow, err)
+		require.NoError(t, err)
+		require.Equal(t, int32(1), updShadow.GetVersion())
+		require.WithinDuration(t, time.Now(), updShadow.GetUpdatedAt().AsTime(),
+			2*time.Second)
+
+		updShadow, err = globalShadowDAO.UpdateDesired(ctx, shadow)
+		t.Logf("updShadow, err: %+v, %v", updShadow, err)
+		require.NoError(t, err)
+		require.Equal(t, int32(2), updShadow.GetVersion())
+
+		readShadow, err := globalShadowDAO.ReadDesired(ctx, createDev.GetId(),
+			createOrg.GetId())
+		t.Logf("readShadow, err: %+v, %v", readShadow, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, updShadow, readShadow)
+	})
+
+	t.Run("Update desired by wrong org", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createDev, err := globalDevDAO.Create(ctx, random.Device("dao-shadow",
+			createOrg.GetId()))
+		t.Logf("createDev, err: %+v, %v", createDev, err)
+		require.NoError(t, err)
+
+		updShadow, err := globalShadowDAO.UpdateDesired(ctx, &message.Shadow{
+			DevId: createDev.GetId(), OrgId: createOrg.GetId(),
+			Desired: &structpb.Struct{},
+		})
+		t.Logf("updShadow, err: %+v, %v", updShadow, err)
+		require.NoError(t, err)
+
+		otherOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-shadow"))
+		t.Logf("otherOrg, err: %+v, %v", otherOrg, err)
+		require.NoError(t, err)
+
+		updShadow, err = globalShadowDAO.UpdateDesired(ctx, &message.Shadow{
+			DevId: createDev.GetId(), OrgId: otherOrg.GetId(),
+			Desired: &structpb.Struct{},
+		})
+		t.Logf("updShadow, err: %+v, %v", updShadow, err)
+		require.Nil(t, updShadow)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Update desired by unknown device", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		updShadow, err := globalShadowDAO.UpdateDesired(ctx, &message.Shadow{
+			DevId: uuid.NewV7().String(), OrgId: createOrg.GetId(),
+			Desired: &structpb.Struct{},
+		})
+		t.Logf("updShadow, err: %+v, %v", updShadow, err)
+		require.Nil(t, updShadow)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+
+	t.Run("Read desired by unknown device", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readShadow, err := globalShadowDAO.ReadDesired(ctx,
+			uuid.NewV7().String(), createOrg.GetId())
+		t.Logf("readShadow, err: %+v, %v", readShadow, err)
+		require.Nil(t, readShadow)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Read desired by invalid device ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readShadow, err := globalShadowDAO.ReadDesired(ctx, random.String(10),
+			createOrg.GetId())
+		t.Logf("readShadow, err: %+v, %v", readShadow, err)
+		require.Nil(t, readShadow)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+}
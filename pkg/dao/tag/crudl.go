@@ -2,25 +2,51 @@ package tag
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/thingspect/atlas/pkg/alog"
 	"github.com/thingspect/atlas/pkg/dao"
 )
 
 const listTags = `
-SELECT unnest(tags) AS tag
-FROM devices
-WHERE org_id = $1
-UNION
-SELECT unnest(tags) AS tag
-FROM users
-WHERE org_id = $1
+SELECT tag
+FROM (
+  SELECT unnest(tags) AS tag
+  FROM devices
+  WHERE org_id = $1
+  UNION
+  SELECT unnest(tags) AS tag
+  FROM users
+  WHERE org_id = $1
+) AS dt
+`
+
+const listTagsScope = `
+WHERE %s
+`
+
+const listTagsOrder = `
 ORDER BY tag
 `
 
-// List retrieves all device and user tags by org ID.
-func (d *DAO) List(ctx context.Context, orgID string) ([]string, error) {
-	rows, err := d.ro.QueryContext(ctx, listTags, orgID)
+// List retrieves all device and user tags by org ID and optional scope tags.
+// If scope tags are provided, only tags matching or nested beneath a scope tag
+// are returned.
+func (d *DAO) List(ctx context.Context, orgID string, scopeTags []string) (
+	[]string, error,
+) {
+	// Build list query.
+	query := listTags
+	args := []any{orgID}
+
+	if len(scopeTags) > 0 {
+		query += fmt.Sprintf(listTagsScope, dao.TagScope("ARRAY[dt.tag]", 2))
+		args = append(args, scopeTags)
+	}
+
+	query += listTagsOrder
+
+	rows, err := d.ro.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, dao.DBToSentinel(err)
 	}
@@ -53,7 +53,7 @@ func TestList(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		listTags, err := globalTagDAO.List(ctx, createOrg.GetId())
+		listTags, err := globalTagDAO.List(ctx, createOrg.GetId(), nil)
 		t.Logf("listTags, err: %+v, %v", listTags, err)
 		require.NoError(t, err)
 		require.Len(t, listTags, tCount)
@@ -87,7 +87,7 @@ func TestList(t *testing.T) {
 		t.Logf("createUser, err: %+v, %v", createUser, err)
 		require.NoError(t, err)
 
-		listTags, err := globalTagDAO.List(ctx, createOrg.GetId())
+		listTags, err := globalTagDAO.List(ctx, createOrg.GetId(), nil)
 		t.Logf("listTags, err: %+v, %v", listTags, err)
 		require.NoError(t, err)
 		require.Len(t, listTags, tCount)
@@ -101,13 +101,37 @@ func TestList(t *testing.T) {
 		require.True(t, found)
 	})
 
+	t.Run("List tags with scope tags", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		scopeOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-tag"))
+		t.Logf("scopeOrg, err: %+v, %v", scopeOrg, err)
+		require.NoError(t, err)
+
+		dev := random.Device("dao-tag", scopeOrg.GetId())
+		dev.Tags = []string{"dao-tag-site", "dao-tag-site/b1", "dao-tag-site2"}
+
+		createDev, err := globalDevDAO.Create(ctx, dev)
+		t.Logf("createDev, err: %+v, %v", createDev, err)
+		require.NoError(t, err)
+
+		listTags, err := globalTagDAO.List(ctx, scopeOrg.GetId(),
+			[]string{"dao-tag-site"})
+		t.Logf("listTags, err: %+v, %v", listTags, err)
+		require.NoError(t, err)
+		require.Equal(t, []string{"dao-tag-site", "dao-tag-site/b1"}, listTags)
+	})
+
 	t.Run("Lists are isolated by org ID", func(t *testing.T) {
 		t.Parallel()
 
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		listTags, err := globalTagDAO.List(ctx, uuid.NewV7().String())
+		listTags, err := globalTagDAO.List(ctx, uuid.NewV7().String(), nil)
 		t.Logf("listTags, err: %+v, %v", listTags, err)
 		require.NoError(t, err)
 		require.Empty(t, listTags)
@@ -119,7 +143,7 @@ func TestList(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
 		defer cancel()
 
-		listTags, err := globalTagDAO.List(ctx, random.String(10))
+		listTags, err := globalTagDAO.List(ctx, random.String(10), nil)
 		t.Logf("listTags, err: %+v, %v", listTags, err)
 		require.Nil(t, listTags)
 		require.ErrorIs(t, err, dao.ErrInvalidFormat)
@@ -0,0 +1,142 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const readMFA = `
+SELECT u.id, u.org_id, m.secret, m.confirmed_at,
+COALESCE(cardinality(m.recovery_hashes), 0),
+COALESCE(p.min_role != 'ROLE_UNSPECIFIED' AND u.role >= p.min_role, false)
+FROM users u
+LEFT JOIN user_mfas m ON u.id = m.user_id
+LEFT JOIN org_mfa_policies p ON u.org_id = p.org_id
+WHERE (u.id, u.org_id) = ($1, $2)
+`
+
+// ReadMFA retrieves a user's MFA status and stored TOTP secret by user ID and
+// org ID. The secret is nil if the user has not begun enrollment. Whether MFA
+// is required is determined by the organization's MFA policy.
+func (d *DAO) ReadMFA(ctx context.Context, userID, orgID string) (
+	*message.UserMFA, []byte, error,
+) {
+	mfa := &message.UserMFA{}
+	var secret []byte
+	var confirmedAt sql.NullTime
+
+	if err := d.ro.QueryRowContext(ctx, readMFA, userID, orgID).Scan(
+		&mfa.UserId, &mfa.OrgId, &secret, &confirmedAt, &mfa.RecoveryCodes,
+		&mfa.Required); err != nil {
+		return nil, nil, dao.DBToSentinel(err)
+	}
+
+	if confirmedAt.Valid {
+		mfa.Enrolled = true
+		mfa.ConfirmedAt = timestamppb.New(confirmedAt.Time)
+	}
+
+	return mfa, secret, nil
+}
+
+const upsertMFA = `
+INSERT INTO user_mfas (user_id, org_id, secret, created_at, updated_at)
+SELECT id, org_id, $3, $4, $4
+FROM users
+WHERE (id, org_id) = ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, updated_at = EXCLUDED.updated_at
+WHERE user_mfas.confirmed_at IS NULL
+RETURNING user_id
+`
+
+// UpsertMFA creates or replaces a user's pending TOTP enrollment. The secret
+// is stored as provided. Confirmed enrollments are not replaced, and return
+// dao.ErrNotFound.
+func (d *DAO) UpsertMFA(
+	ctx context.Context, userID, orgID string, secret []byte,
+) error {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, upsertMFA, userID,
+		orgID, secret, now).Scan(&userID))
+}
+
+const confirmMFA = `
+UPDATE user_mfas
+SET confirmed_at = $5, updated_at = $5, last_step = $3, recovery_hashes = $4
+WHERE (user_id, org_id) = ($1, $2)
+AND confirmed_at IS NULL
+AND last_step < $3
+RETURNING user_id
+`
+
+// ConfirmMFA confirms a user's pending TOTP enrollment using an accepted TOTP
+// time step, and sets the hashes of the user's recovery codes.
+func (d *DAO) ConfirmMFA(
+	ctx context.Context, userID, orgID string, step int64,
+	recoveryHashes []string,
+) error {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, confirmMFA, userID,
+		orgID, step, recoveryHashes, now).Scan(&userID))
+}
+
+const useMFAStep = `
+UPDATE user_mfas
+SET last_step = $3, updated_at = $4
+WHERE (user_id, org_id) = ($1, $2)
+AND confirmed_at IS NOT NULL
+AND last_step < $3
+RETURNING user_id
+`
+
+// UseMFAStep records an accepted TOTP time step for a user's confirmed
+// enrollment. Time steps at or before the last accepted step return
+// dao.ErrNotFound, which prevents code replay.
+func (d *DAO) UseMFAStep(
+	ctx context.Context, userID, orgID string, step int64,
+) error {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, useMFAStep, userID,
+		orgID, step, now).Scan(&userID))
+}
+
+const useRecoveryCode = `
+UPDATE user_mfas
+SET recovery_hashes = array_remove(recovery_hashes, $3), updated_at = $4
+WHERE (user_id, org_id) = ($1, $2)
+AND confirmed_at IS NOT NULL
+AND $3 = ANY (recovery_hashes)
+RETURNING user_id
+`
+
+// UseRecoveryCode consumes a recovery code of a user's confirmed enrollment by
+// hash. Unknown or used recovery codes return dao.ErrNotFound.
+func (d *DAO) UseRecoveryCode(
+	ctx context.Context, userID, orgID, hash string,
+) error {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, useRecoveryCode, userID,
+		orgID, hash, now).Scan(&userID))
+}
+
+const deleteMFA = `
+DELETE FROM user_mfas
+WHERE (user_id, org_id) = ($1, $2)
+RETURNING user_id
+`
+
+// DeleteMFA deletes a user's TOTP enrollment by user ID and org ID.
+func (d *DAO) DeleteMFA(ctx context.Context, userID, orgID string) error {
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, deleteMFA, userID,
+		orgID).Scan(&userID))
+}
@@ -0,0 +1,182 @@
+//go:build !unit
+
+package user
+
+import (
+	"context"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+func TestEnrollMFA(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-user"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	user := random.User("dao-user", createOrg.GetId())
+	user.Role = api.Role_ADMIN
+	createUser, err := globalUserDAO.Create(ctx, user)
+	t.Logf("createUser, err: %+v, %v", createUser, err)
+	require.NoError(t, err)
+
+	// Unenrolled users are not required to use MFA without a policy.
+	readMFA, secret, err := globalUserDAO.ReadMFA(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("readMFA, err: %+v, %v", readMFA, err)
+	require.NoError(t, err)
+	require.Equal(t, &message.UserMFA{
+		UserId: createUser.GetId(), OrgId: createOrg.GetId(),
+	}, readMFA)
+	require.Nil(t, secret)
+
+	_, err = globalOrgDAO.UpsertMFAPolicy(ctx, &message.OrgMFAPolicy{
+		OrgId: createOrg.GetId(), MinRole: api.Role_ADMIN,
+	})
+	require.NoError(t, err)
+
+	// Begin, replace, and confirm enrollment.
+	require.NoError(t, globalUserDAO.UpsertMFA(ctx, createUser.GetId(),
+		createOrg.GetId(), random.Bytes(20)))
+
+	encSecret := random.Bytes(20)
+	require.NoError(t, globalUserDAO.UpsertMFA(ctx, createUser.GetId(),
+		createOrg.GetId(), encSecret))
+
+	readMFA, secret, err = globalUserDAO.ReadMFA(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("readMFA, err: %+v, %v", readMFA, err)
+	require.NoError(t, err)
+	require.False(t, readMFA.GetEnrolled())
+	require.True(t, readMFA.GetRequired())
+	require.Equal(t, encSecret, secret)
+
+	hashes := []string{random.String(64), random.String(64)}
+	require.NoError(t, globalUserDAO.ConfirmMFA(ctx, createUser.GetId(),
+		createOrg.GetId(), 100, hashes))
+
+	readMFA, _, err = globalUserDAO.ReadMFA(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("readMFA, err: %+v, %v", readMFA, err)
+	require.NoError(t, err)
+	require.True(t, readMFA.GetEnrolled())
+	require.Equal(t, int32(2), readMFA.GetRecoveryCodes())
+	require.NotNil(t, readMFA.GetConfirmedAt())
+
+	// Confirmed enrollments are not replaced or reconfirmed.
+	err = globalUserDAO.UpsertMFA(ctx, createUser.GetId(), createOrg.GetId(),
+		random.Bytes(20))
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	err = globalUserDAO.ConfirmMFA(ctx, createUser.GetId(), createOrg.GetId(),
+		200, hashes)
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	// Time steps may be used once.
+	require.NoError(t, globalUserDAO.UseMFAStep(ctx, createUser.GetId(),
+		createOrg.GetId(), 101))
+
+	for _, step := range []int64{100, 101} {
+		err = globalUserDAO.UseMFAStep(ctx, createUser.GetId(),
+			createOrg.GetId(), step)
+		t.Logf("err: %v", err)
+		require.Equal(t, dao.ErrNotFound, err)
+	}
+
+	// Recovery codes may be used once.
+	require.NoError(t, globalUserDAO.UseRecoveryCode(ctx, createUser.GetId(),
+		createOrg.GetId(), hashes[0]))
+
+	err = globalUserDAO.UseRecoveryCode(ctx, createUser.GetId(),
+		createOrg.GetId(), hashes[0])
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	readMFA, _, err = globalUserDAO.ReadMFA(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("readMFA, err: %+v, %v", readMFA, err)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), readMFA.GetRecoveryCodes())
+
+	// Reset enrollment.
+	require.NoError(t, globalUserDAO.DeleteMFA(ctx, createUser.GetId(),
+		createOrg.GetId()))
+
+	readMFA, secret, err = globalUserDAO.ReadMFA(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("readMFA, err: %+v, %v", readMFA, err)
+	require.NoError(t, err)
+	require.False(t, readMFA.GetEnrolled())
+	require.True(t, readMFA.GetRequired())
+	require.Nil(t, secret)
+
+	err = globalUserDAO.DeleteMFA(ctx, createUser.GetId(), createOrg.GetId())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+}
+
+func TestEnrollMFAError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-user"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	user := random.User("dao-user", createOrg.GetId())
+	user.Role = api.Role_VIEWER
+	createUser, err := globalUserDAO.Create(ctx, user)
+	t.Logf("createUser, err: %+v, %v", createUser, err)
+	require.NoError(t, err)
+
+	_, err = globalOrgDAO.UpsertMFAPolicy(ctx, &message.OrgMFAPolicy{
+		OrgId: createOrg.GetId(), MinRole: api.Role_ADMIN,
+	})
+	require.NoError(t, err)
+
+	// Users below the policy's minimum role are not required to use MFA.
+	readMFA, _, err := globalUserDAO.ReadMFA(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("readMFA, err: %+v, %v", readMFA, err)
+	require.NoError(t, err)
+	require.False(t, readMFA.GetRequired())
+
+	readMFA, _, err = globalUserDAO.ReadMFA(ctx, createUser.GetId(),
+		uuid.NewV7().String())
+	t.Logf("readMFA, err: %+v, %v", readMFA, err)
+	require.Nil(t, readMFA)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	err = globalUserDAO.UpsertMFA(ctx, createUser.GetId(),
+		uuid.NewV7().String(), random.Bytes(20))
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	// Unconfirmed enrollments do not accept time steps or recovery codes.
+	require.NoError(t, globalUserDAO.UpsertMFA(ctx, createUser.GetId(),
+		createOrg.GetId(), random.Bytes(20)))
+
+	err = globalUserDAO.UseMFAStep(ctx, createUser.GetId(), createOrg.GetId(),
+		100)
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	err = globalUserDAO.UseRecoveryCode(ctx, createUser.GetId(),
+		createOrg.GetId(), random.String(64))
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+}
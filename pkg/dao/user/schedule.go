@@ -0,0 +1,158 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const upsertSchedule = `
+INSERT INTO user_schedules (user_id, org_id, schedule, created_at,
+updated_at)
+SELECT id, org_id, $3, $4, $4
+FROM users
+WHERE (id, org_id) = ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET schedule = EXCLUDED.schedule, updated_at = EXCLUDED.updated_at
+RETURNING created_at
+`
+
+// UpsertSchedule creates or replaces a user's notification schedule.
+func (d *DAO) UpsertSchedule(
+	ctx context.Context, sched *message.NotificationSchedule,
+) (*message.NotificationSchedule, error) {
+	// Identifiers and timestamps are columns, and are not stored in the
+	// schedule.
+	doc, err := protojson.Marshal(&message.NotificationSchedule{
+		Timezone:         sched.GetTimezone(),
+		Windows:          sched.GetWindows(),
+		Action:           sched.GetAction(),
+		FallbackType:     sched.GetFallbackType(),
+		CriticalAlarmIds: sched.GetCriticalAlarmIds(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", dao.ErrInvalidFormat, err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	sched.UpdatedAt = timestamppb.New(now)
+
+	var createdAt time.Time
+	if err := d.rw.QueryRowContext(ctx, upsertSchedule, sched.GetUserId(),
+		sched.GetOrgId(), doc, now).Scan(&createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	sched.CreatedAt = timestamppb.New(createdAt)
+
+	return sched, nil
+}
+
+// scanSchedule builds a notification schedule from its columns.
+func scanSchedule(
+	userID, orgID string, doc []byte, createdAt, updatedAt time.Time,
+) (*message.NotificationSchedule, error) {
+	sched := &message.NotificationSchedule{}
+	if err := protojson.Unmarshal(doc, sched); err != nil {
+		return nil, err
+	}
+
+	sched.UserId = userID
+	sched.OrgId = orgID
+	sched.CreatedAt = timestamppb.New(createdAt)
+	sched.UpdatedAt = timestamppb.New(updatedAt)
+
+	return sched, nil
+}
+
+const readSchedule = `
+SELECT user_id, org_id, schedule, created_at, updated_at
+FROM user_schedules
+WHERE (user_id, org_id) = ($1, $2)
+`
+
+// ReadSchedule retrieves a user's notification schedule by user ID and org
+// ID.
+func (d *DAO) ReadSchedule(
+	ctx context.Context, userID, orgID string,
+) (*message.NotificationSchedule, error) {
+	var doc []byte
+	var createdAt, updatedAt time.Time
+
+	if err := d.ro.QueryRowContext(ctx, readSchedule, userID, orgID).Scan(
+		&userID, &orgID, &doc, &createdAt, &updatedAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return scanSchedule(userID, orgID, doc, createdAt, updatedAt)
+}
+
+const deleteSchedule = `
+DELETE FROM user_schedules
+WHERE (user_id, org_id) = ($1, $2)
+RETURNING user_id
+`
+
+// DeleteSchedule deletes a user's notification schedule by user ID and org
+// ID.
+func (d *DAO) DeleteSchedule(ctx context.Context, userID, orgID string) error {
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, deleteSchedule, userID,
+		orgID).Scan(&userID))
+}
+
+const listSchedules = `
+SELECT user_id, org_id, schedule, created_at, updated_at
+FROM user_schedules
+WHERE org_id = $1
+AND user_id = ANY ($2::uuid[])
+`
+
+// ListSchedules retrieves the notification schedules of users by org ID and
+// user IDs, keyed by user ID. Users without a schedule are absent.
+func (d *DAO) ListSchedules(
+	ctx context.Context, orgID string, userIDs []string,
+) (map[string]*message.NotificationSchedule, error) {
+	rows, err := d.ro.QueryContext(ctx, listSchedules, orgID, userIDs)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ListSchedules rows.Close: %v", err)
+		}
+	}()
+
+	scheds := map[string]*message.NotificationSchedule{}
+	for rows.Next() {
+		var userID, orgID string
+		var doc []byte
+		var createdAt, updatedAt time.Time
+
+		if err = rows.Scan(&userID, &orgID, &doc, &createdAt,
+			&updatedAt); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		sched, err := scanSchedule(userID, orgID, doc, createdAt, updatedAt)
+		if err != nil {
+			return nil, err
+		}
+		scheds[userID] = sched
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return scheds, nil
+}
@@ -0,0 +1,190 @@
+//go:build !unit
+
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/api"
+)
+
+func TestUpsertReadSchedule(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-user"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	t.Run("Upsert and read valid schedule", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createUser, err := globalUserDAO.Create(ctx, random.User("dao-user",
+			createOrg.GetId()))
+		t.Logf("createUser, err: %+v, %v", createUser, err)
+		require.NoError(t, err)
+
+		createSched, err := globalUserDAO.UpsertSchedule(ctx,
+			&message.NotificationSchedule{
+				UserId: createUser.GetId(), OrgId: createOrg.GetId(),
+				Timezone: "America/Chicago",
+				Windows: []*message.ScheduleWindow{{
+					Type: api.AlarmType_SMS, Days: []int32{1, 2, 3, 4, 5},
+					StartMinute: 8 * 60, EndMinute: 18 * 60,
+				}},
+				Action:       message.ScheduleAction_FALLBACK,
+				FallbackType: api.AlarmType_EMAIL,
+			})
+		t.Logf("createSched, err: %+v, %v", createSched, err)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now(),
+			createSched.GetCreatedAt().AsTime(), 2*time.Second)
+
+		updSched, err := globalUserDAO.UpsertSchedule(ctx,
+			&message.NotificationSchedule{
+				UserId: createUser.GetId(), OrgId: createOrg.GetId(),
+				Timezone:         "UTC",
+				Action:           message.ScheduleAction_DROP,
+				CriticalAlarmIds: []string{uuid.NewV7().String()},
+			})
+		t.Logf("updSched, err: %+v, %v", updSched, err)
+		require.NoError(t, err)
+		require.Equal(t, createSched.GetCreatedAt().AsTime(),
+			updSched.GetCreatedAt().AsTime())
+
+		readSched, err := globalUserDAO.ReadSchedule(ctx, createUser.GetId(),
+			createOrg.GetId())
+		t.Logf("readSched, err: %+v, %v", readSched, err)
+		require.NoError(t, err)
+		require.EqualExportedValues(t, updSched, readSched)
+	})
+
+	t.Run("Upsert schedule by unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createSched, err := globalUserDAO.UpsertSchedule(ctx,
+			&message.NotificationSchedule{
+				UserId: uuid.NewV7().String(), OrgId: createOrg.GetId(),
+				Timezone: "UTC",
+			})
+		t.Logf("createSched, err: %+v, %v", createSched, err)
+		require.Nil(t, createSched)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Read schedule by unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readSched, err := globalUserDAO.ReadSchedule(ctx,
+			uuid.NewV7().String(), createOrg.GetId())
+		t.Logf("readSched, err: %+v, %v", readSched, err)
+		require.Nil(t, readSched)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestDeleteSchedule(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-user"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createUser, err := globalUserDAO.Create(ctx, random.User("dao-user",
+		createOrg.GetId()))
+	t.Logf("createUser, err: %+v, %v", createUser, err)
+	require.NoError(t, err)
+
+	_, err = globalUserDAO.UpsertSchedule(ctx, &message.NotificationSchedule{
+		UserId: createUser.GetId(), OrgId: createOrg.GetId(), Timezone: "UTC",
+	})
+	require.NoError(t, err)
+
+	err = globalUserDAO.DeleteSchedule(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("err: %v", err)
+	require.NoError(t, err)
+
+	err = globalUserDAO.DeleteSchedule(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+}
+
+func TestListSchedules(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-user"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	var userIDs []string
+	for range 3 {
+		createUser, err := globalUserDAO.Create(ctx, random.User("dao-user",
+			createOrg.GetId()))
+		t.Logf("createUser, err: %+v, %v", createUser, err)
+		require.NoError(t, err)
+		userIDs = append(userIDs, createUser.GetId())
+	}
+
+	// Leave the last user without a schedule.
+	for _, userID := range userIDs[:2] {
+		_, err := globalUserDAO.UpsertSchedule(ctx,
+			&message.NotificationSchedule{
+				UserId: userID, OrgId: createOrg.GetId(), Timezone: "UTC",
+			})
+		require.NoError(t, err)
+	}
+
+	t.Run("List schedules by valid user IDs", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listScheds, err := globalUserDAO.ListSchedules(ctx, createOrg.GetId(),
+			userIDs)
+		t.Logf("listScheds, err: %+v, %v", listScheds, err)
+		require.NoError(t, err)
+		require.Len(t, listScheds, 2)
+		require.Equal(t, "UTC", listScheds[userIDs[0]].GetTimezone())
+		require.NotContains(t, listScheds, userIDs[2])
+	})
+
+	t.Run("List schedules by unknown org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		listScheds, err := globalUserDAO.ListSchedules(ctx,
+			uuid.NewV7().String(), userIDs)
+		t.Logf("listScheds, err: %+v, %v", listScheds, err)
+		require.NoError(t, err)
+		require.Empty(t, listScheds)
+	})
+}
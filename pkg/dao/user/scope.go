@@ -0,0 +1,72 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const upsertScope = `
+INSERT INTO user_scopes (user_id, org_id, tags, created_at, updated_at)
+SELECT id, org_id, $3, $4, $4
+FROM users
+WHERE (id, org_id) = ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET tags = EXCLUDED.tags, updated_at = EXCLUDED.updated_at
+RETURNING created_at
+`
+
+// UpsertScope creates or replaces a user's scope tags. Users that do not
+// exist return dao.ErrNotFound.
+func (d *DAO) UpsertScope(
+	ctx context.Context, scope *message.UserScope,
+) (*message.UserScope, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	scope.UpdatedAt = timestamppb.New(now)
+
+	// Store empty slices as empty arrays, rather than NULL.
+	tags := scope.GetTags()
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var createdAt time.Time
+	if err := d.rw.QueryRowContext(ctx, upsertScope, scope.GetUserId(),
+		scope.GetOrgId(), tags, now).Scan(&createdAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	scope.CreatedAt = timestamppb.New(createdAt)
+
+	return scope, nil
+}
+
+const readScope = `
+SELECT user_id, org_id, tags, created_at, updated_at
+FROM user_scopes
+WHERE (user_id, org_id) = ($1, $2)
+`
+
+// ReadScope retrieves a user's scope tags by user ID and org ID.
+func (d *DAO) ReadScope(ctx context.Context, userID, orgID string) (
+	*message.UserScope, error,
+) {
+	scope := &message.UserScope{}
+	var createdAt, updatedAt time.Time
+	pgtmap := pgtype.NewMap()
+
+	if err := d.ro.QueryRowContext(ctx, readScope, userID, orgID).Scan(
+		&scope.UserId, &scope.OrgId, pgtmap.SQLScanner(&scope.Tags),
+		&createdAt, &updatedAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	scope.CreatedAt = timestamppb.New(createdAt)
+	scope.UpdatedAt = timestamppb.New(updatedAt)
+
+	return scope, nil
+}
@@ -0,0 +1,91 @@
+//go:build !unit
+
+package user
+
+import (
+	"context"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/atlas/proto/go/message"
+)
+
+func TestUpsertReadScope(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-user"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createUser, err := globalUserDAO.Create(ctx, random.User("dao-user",
+		createOrg.GetId()))
+	t.Logf("createUser, err: %+v, %v", createUser, err)
+	require.NoError(t, err)
+
+	readScope, err := globalUserDAO.ReadScope(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("readScope, err: %+v, %v", readScope, err)
+	require.Nil(t, readScope)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	createScope, err := globalUserDAO.UpsertScope(ctx, &message.UserScope{
+		UserId: createUser.GetId(), OrgId: createOrg.GetId(),
+		Tags: []string{"dao-user-site", "dao-user-site/b1"},
+	})
+	t.Logf("createScope, err: %+v, %v", createScope, err)
+	require.NoError(t, err)
+
+	readScope, err = globalUserDAO.ReadScope(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("readScope, err: %+v, %v", readScope, err)
+	require.NoError(t, err)
+	require.EqualExportedValues(t, createScope, readScope)
+
+	updScope, err := globalUserDAO.UpsertScope(ctx, &message.UserScope{
+		UserId: createUser.GetId(), OrgId: createOrg.GetId(),
+	})
+	t.Logf("updScope, err: %+v, %v", updScope, err)
+	require.NoError(t, err)
+	require.Equal(t, createScope.GetCreatedAt().AsTime(),
+		updScope.GetCreatedAt().AsTime())
+
+	readScope, err = globalUserDAO.ReadScope(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("readScope, err: %+v, %v", readScope, err)
+	require.NoError(t, err)
+	require.Empty(t, readScope.GetTags())
+
+	t.Run("Upsert scope by unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createScope, err := globalUserDAO.UpsertScope(ctx, &message.UserScope{
+			UserId: uuid.NewV7().String(), OrgId: createOrg.GetId(),
+			Tags: []string{"dao-user-site"},
+		})
+		t.Logf("createScope, err: %+v, %v", createScope, err)
+		require.Nil(t, createScope)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Read scope by wrong org", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		readScope, err := globalUserDAO.ReadScope(ctx, createUser.GetId(),
+			uuid.NewV7().String())
+		t.Logf("readScope, err: %+v, %v", readScope, err)
+		require.Nil(t, readScope)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
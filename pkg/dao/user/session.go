@@ -0,0 +1,239 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const createSession = `
+INSERT INTO user_sessions (org_id, user_id, refresh_hash, created_at,
+updated_at, expires_at)
+SELECT org_id, id, $3, $4, $4, $5
+FROM users
+WHERE (id, org_id) = ($1, $2)
+RETURNING id, org_id, user_id, created_at, updated_at, expires_at, revoked_at
+`
+
+// CreateSession creates a user session with a refresh token hash and
+// expiration.
+func (d *DAO) CreateSession(
+	ctx context.Context, userID, orgID string, refreshHash []byte,
+	expiresAt time.Time,
+) (*message.UserSession, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	return scanSession(d.rw.QueryRowContext(ctx, createSession, userID, orgID,
+		refreshHash, now, expiresAt.UTC().Truncate(time.Microsecond)))
+}
+
+const rotateSession = `
+UPDATE user_sessions
+SET prev_hash = refresh_hash, refresh_hash = $2, updated_at = $3,
+expires_at = $4
+WHERE refresh_hash = $1
+AND revoked_at IS NULL
+AND expires_at > $3
+RETURNING id, org_id, user_id, created_at, updated_at, expires_at, revoked_at
+`
+
+// RotateSession replaces the refresh token hash of an active session and
+// extends its expiration. The replaced hash is retained to detect reuse.
+func (d *DAO) RotateSession(
+	ctx context.Context, refreshHash, newHash []byte, expiresAt time.Time,
+) (*message.UserSession, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	return scanSession(d.rw.QueryRowContext(ctx, rotateSession, refreshHash,
+		newHash, now, expiresAt.UTC().Truncate(time.Microsecond)))
+}
+
+const revokeReusedSession = `
+UPDATE user_sessions
+SET revoked_at = $2, updated_at = $2
+WHERE prev_hash = $1
+AND revoked_at IS NULL
+RETURNING id, org_id, user_id, created_at, updated_at, expires_at, revoked_at
+`
+
+// RevokeReusedSession revokes the session whose previous refresh token hash
+// matches, which indicates that a rotated refresh token was reused.
+func (d *DAO) RevokeReusedSession(ctx context.Context, refreshHash []byte) (
+	*message.UserSession, error,
+) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	return scanSession(d.rw.QueryRowContext(ctx, revokeReusedSession,
+		refreshHash, now))
+}
+
+const revokeSession = `
+UPDATE user_sessions
+SET revoked_at = $4, updated_at = $4
+WHERE (id, user_id, org_id) = ($1, $2, $3)
+AND revoked_at IS NULL
+RETURNING id
+`
+
+// RevokeSession revokes a user session by ID, user ID, and org ID.
+func (d *DAO) RevokeSession(
+	ctx context.Context, sessID, userID, orgID string,
+) error {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	return dao.DBToSentinel(d.rw.QueryRowContext(ctx, revokeSession, sessID,
+		userID, orgID, now).Scan(&sessID))
+}
+
+const revokeSessions = `
+UPDATE user_sessions
+SET revoked_at = $3, updated_at = $3
+WHERE (user_id, org_id) = ($1, $2)
+AND revoked_at IS NULL
+AND expires_at > $3
+RETURNING id
+`
+
+// RevokeSessions revokes all active sessions of a user by user ID and org ID,
+// and returns the IDs of the revoked sessions.
+func (d *DAO) RevokeSessions(ctx context.Context, userID, orgID string) (
+	[]string, error,
+) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	rows, err := d.rw.QueryContext(ctx, revokeSessions, userID, orgID, now)
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("RevokeSessions rows.Close: %v", err)
+		}
+	}()
+
+	var sessIDs []string
+	for rows.Next() {
+		var sessID string
+		if err = rows.Scan(&sessID); err != nil {
+			return nil, dao.DBToSentinel(err)
+		}
+
+		sessIDs = append(sessIDs, sessID)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return sessIDs, nil
+}
+
+const listSessions = `
+SELECT id, org_id, user_id, created_at, updated_at, expires_at, revoked_at
+FROM user_sessions
+WHERE (user_id, org_id) = ($1, $2)
+AND revoked_at IS NULL
+AND expires_at > $3
+ORDER BY created_at ASC, id ASC
+`
+
+// ListSessions retrieves all active sessions of a user by user ID and org ID.
+func (d *DAO) ListSessions(ctx context.Context, userID, orgID string) (
+	[]*message.UserSession, error,
+) {
+	rows, err := d.ro.QueryContext(ctx, listSessions, userID, orgID,
+		time.Now().UTC())
+	if err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("ListSessions rows.Close: %v", err)
+		}
+	}()
+
+	var sesses []*message.UserSession
+	for rows.Next() {
+		sess, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sesses = append(sesses, sess)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	return sesses, nil
+}
+
+const purgeSessions = `
+DELETE FROM user_sessions
+WHERE ctid = ANY(ARRAY(
+  SELECT ctid
+  FROM user_sessions
+  WHERE org_id = $1
+  AND (expires_at < $2 OR revoked_at < $2)
+  LIMIT $3
+))
+`
+
+// PurgeSessions deletes up to limit user sessions by org ID that expired or
+// were revoked before the provided time and returns the count of deleted
+// sessions.
+func (d *DAO) PurgeSessions(
+	ctx context.Context, orgID string, before time.Time, limit int32,
+) (int64, error) {
+	res, err := d.rw.ExecContext(ctx, purgeSessions, orgID, before, limit)
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, dao.DBToSentinel(err)
+	}
+
+	return count, nil
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanSession scans a row into a UserSession.
+func scanSession(row scanner) (*message.UserSession, error) {
+	sess := &message.UserSession{}
+	var createdAt, updatedAt, expiresAt time.Time
+	var revokedAt sql.NullTime
+
+	if err := row.Scan(&sess.Id, &sess.OrgId, &sess.UserId, &createdAt,
+		&updatedAt, &expiresAt, &revokedAt); err != nil {
+		return nil, dao.DBToSentinel(err)
+	}
+
+	sess.CreatedAt = timestamppb.New(createdAt)
+	sess.UpdatedAt = timestamppb.New(updatedAt)
+	sess.ExpiresAt = timestamppb.New(expiresAt)
+	if revokedAt.Valid {
+		sess.RevokedAt = timestamppb.New(revokedAt.Time)
+	}
+
+	return sess, nil
+}
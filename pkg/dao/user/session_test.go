@@ -0,0 +1,267 @@
+//go:build !unit
+
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/dao"
+	"github.com/thingspect/atlas/pkg/test/random"
+)
+
+func TestCreateRotateSession(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-user"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createUser, err := globalUserDAO.Create(ctx, random.User("dao-user",
+		createOrg.GetId()))
+	t.Logf("createUser, err: %+v, %v", createUser, err)
+	require.NoError(t, err)
+
+	t.Run("Create and rotate valid session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		hash := []byte(random.String(32))
+		createSess, err := globalUserDAO.CreateSession(ctx, createUser.GetId(),
+			createOrg.GetId(), hash, time.Now().Add(time.Hour))
+		t.Logf("createSess, err: %+v, %v", createSess, err)
+		require.NoError(t, err)
+		require.NotEmpty(t, createSess.GetId())
+		require.Equal(t, createUser.GetId(), createSess.GetUserId())
+		require.WithinDuration(t, time.Now(),
+			createSess.GetCreatedAt().AsTime(), 2*time.Second)
+		require.Nil(t, createSess.GetRevokedAt())
+
+		newHash := []byte(random.String(32))
+		rotSess, err := globalUserDAO.RotateSession(ctx, hash, newHash,
+			time.Now().Add(2*time.Hour))
+		t.Logf("rotSess, err: %+v, %v", rotSess, err)
+		require.NoError(t, err)
+		require.Equal(t, createSess.GetId(), rotSess.GetId())
+		require.True(t, rotSess.GetExpiresAt().AsTime().After(
+			createSess.GetExpiresAt().AsTime()))
+
+		// A rotated refresh token cannot be rotated again.
+		reSess, err := globalUserDAO.RotateSession(ctx, hash,
+			[]byte(random.String(32)), time.Now().Add(time.Hour))
+		t.Logf("reSess, err: %+v, %v", reSess, err)
+		require.Nil(t, reSess)
+		require.Equal(t, dao.ErrNotFound, err)
+
+		// Reuse of a rotated refresh token revokes the session.
+		revSess, err := globalUserDAO.RevokeReusedSession(ctx, hash)
+		t.Logf("revSess, err: %+v, %v", revSess, err)
+		require.NoError(t, err)
+		require.Equal(t, createSess.GetId(), revSess.GetId())
+		require.NotNil(t, revSess.GetRevokedAt())
+
+		reSess, err = globalUserDAO.RotateSession(ctx, newHash,
+			[]byte(random.String(32)), time.Now().Add(time.Hour))
+		t.Logf("reSess, err: %+v, %v", reSess, err)
+		require.Nil(t, reSess)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Rotate expired session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		hash := []byte(random.String(32))
+		createSess, err := globalUserDAO.CreateSession(ctx, createUser.GetId(),
+			createOrg.GetId(), hash, time.Now().Add(-time.Minute))
+		t.Logf("createSess, err: %+v, %v", createSess, err)
+		require.NoError(t, err)
+
+		rotSess, err := globalUserDAO.RotateSession(ctx, hash,
+			[]byte(random.String(32)), time.Now().Add(time.Hour))
+		t.Logf("rotSess, err: %+v, %v", rotSess, err)
+		require.Nil(t, rotSess)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Create session by unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createSess, err := globalUserDAO.CreateSession(ctx,
+			uuid.NewV7().String(), createOrg.GetId(),
+			[]byte(random.String(32)), time.Now().Add(time.Hour))
+		t.Logf("createSess, err: %+v, %v", createSess, err)
+		require.Nil(t, createSess)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+
+	t.Run("Revoke unused refresh token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		revSess, err := globalUserDAO.RevokeReusedSession(ctx,
+			[]byte(random.String(32)))
+		t.Logf("revSess, err: %+v, %v", revSess, err)
+		require.Nil(t, revSess)
+		require.Equal(t, dao.ErrNotFound, err)
+	})
+}
+
+func TestRevokeListSessions(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-user"))
+	t.Logf("createOrg, err: %+v, %v", createOrg, err)
+	require.NoError(t, err)
+
+	createUser, err := globalUserDAO.Create(ctx, random.User("dao-user",
+		createOrg.GetId()))
+	t.Logf("createUser, err: %+v, %v", createUser, err)
+	require.NoError(t, err)
+
+	sessIDs := []string{}
+	for range 3 {
+		createSess, err := globalUserDAO.CreateSession(ctx, createUser.GetId(),
+			createOrg.GetId(), []byte(random.String(32)),
+			time.Now().Add(time.Hour))
+		t.Logf("createSess, err: %+v, %v", createSess, err)
+		require.NoError(t, err)
+
+		sessIDs = append(sessIDs, createSess.GetId())
+	}
+
+	listSesses, err := globalUserDAO.ListSessions(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("listSesses, err: %+v, %v", listSesses, err)
+	require.NoError(t, err)
+	require.Len(t, listSesses, 3)
+	require.Equal(t, sessIDs[0], listSesses[0].GetId())
+
+	// Revoke one session.
+	err = globalUserDAO.RevokeSession(ctx, sessIDs[0], createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("err: %v", err)
+	require.NoError(t, err)
+
+	err = globalUserDAO.RevokeSession(ctx, sessIDs[0], createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	// Sessions are isolated by user ID.
+	err = globalUserDAO.RevokeSession(ctx, sessIDs[1], uuid.NewV7().String(),
+		createOrg.GetId())
+	t.Logf("err: %v", err)
+	require.Equal(t, dao.ErrNotFound, err)
+
+	// Revoke remaining sessions.
+	revIDs, err := globalUserDAO.RevokeSessions(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("revIDs, err: %+v, %v", revIDs, err)
+	require.NoError(t, err)
+	require.ElementsMatch(t, sessIDs[1:], revIDs)
+
+	listSesses, err = globalUserDAO.ListSessions(ctx, createUser.GetId(),
+		createOrg.GetId())
+	t.Logf("listSesses, err: %+v, %v", listSesses, err)
+	require.NoError(t, err)
+	require.Empty(t, listSesses)
+
+	// Revoke sessions with invalid ID.
+	revIDs, err = globalUserDAO.RevokeSessions(ctx, random.String(10),
+		createOrg.GetId())
+	t.Logf("revIDs, err: %+v, %v", revIDs, err)
+	require.Nil(t, revIDs)
+	require.ErrorIs(t, err, dao.ErrInvalidFormat)
+}
+
+func TestPurgeSessions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Purge sessions in batches", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		createOrg, err := globalOrgDAO.Create(ctx, random.Org("dao-user"))
+		t.Logf("createOrg, err: %+v, %v", createOrg, err)
+		require.NoError(t, err)
+
+		createUser, err := globalUserDAO.Create(ctx, random.User("dao-user",
+			createOrg.GetId()))
+		t.Logf("createUser, err: %+v, %v", createUser, err)
+		require.NoError(t, err)
+
+		for range 2 {
+			_, err := globalUserDAO.CreateSession(ctx, createUser.GetId(),
+				createOrg.GetId(), []byte(random.String(32)),
+				time.Now().Add(-time.Hour))
+			t.Logf("err: %v", err)
+			require.NoError(t, err)
+		}
+
+		revSess, err := globalUserDAO.CreateSession(ctx, createUser.GetId(),
+			createOrg.GetId(), []byte(random.String(32)),
+			time.Now().Add(time.Hour))
+		t.Logf("revSess, err: %+v, %v", revSess, err)
+		require.NoError(t, err)
+		require.NoError(t, globalUserDAO.RevokeSession(ctx, revSess.GetId(),
+			createUser.GetId(), createOrg.GetId()))
+
+		time.Sleep(time.Millisecond)
+		before := time.Now().UTC()
+
+		keep, err := globalUserDAO.CreateSession(ctx, createUser.GetId(),
+			createOrg.GetId(), []byte(random.String(32)),
+			time.Now().Add(time.Hour))
+		t.Logf("keep, err: %+v, %v", keep, err)
+		require.NoError(t, err)
+
+		for _, exp := range []int64{2, 1, 0} {
+			count, err := globalUserDAO.PurgeSessions(ctx, createOrg.GetId(),
+				before, 2)
+			t.Logf("count, err: %v, %v", count, err)
+			require.NoError(t, err)
+			require.Equal(t, exp, count)
+		}
+
+		listSesses, err := globalUserDAO.ListSessions(ctx, createUser.GetId(),
+			createOrg.GetId())
+		t.Logf("listSesses, err: %+v, %v", listSesses, err)
+		require.NoError(t, err)
+		require.Len(t, listSesses, 1)
+		require.Equal(t, keep.GetId(), listSesses[0].GetId())
+	})
+
+	t.Run("Purge sessions by invalid org ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		count, err := globalUserDAO.PurgeSessions(ctx, random.String(10),
+			time.Now(), 2)
+		t.Logf("count, err: %v, %v", count, err)
+		require.Zero(t, count)
+		require.ErrorIs(t, err, dao.ErrInvalidFormat)
+	})
+}
@@ -10,10 +10,13 @@ import (
 
 // Constants used for ChirpStack device attributes.
 const (
-	AttrRaw  = "raw_device"
-	AttrDR   = "data_rate"
-	AttrADR  = "adr"
-	attrConf = "confirmed"
+	AttrRaw      = "raw_device"
+	AttrDR       = "data_rate"
+	AttrADR      = "adr"
+	AttrAck      = "ack"
+	AttrTXQueued = "tx_queued"
+	AttrQueueID  = "queue_item_id"
+	attrConf     = "confirmed"
 )
 
 // Parse parses a device payload from a []byte according to the spec. Points,
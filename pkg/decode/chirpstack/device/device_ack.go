@@ -9,9 +9,10 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// Constants used for device ACK values.
 const (
-	ackOK      = "OK"
-	ackTimeout = "DOWNLINK_TIMEOUT"
+	AckOK      = "OK"
+	AckTimeout = "DOWNLINK_TIMEOUT"
 )
 
 // deviceAck parses a device ACK payload from a []byte according to the spec.
@@ -27,9 +28,16 @@ func deviceAck(body []byte) ([]*decode.Point, error) {
 
 	// Parse AckEvent. A false ack means it timed out.
 	if ackMsg.GetAcknowledged() {
-		msgs = append(msgs, &decode.Point{Attr: "ack", Value: ackOK})
+		msgs = append(msgs, &decode.Point{Attr: AttrAck, Value: AckOK})
 	} else {
-		msgs = append(msgs, &decode.Point{Attr: "ack", Value: ackTimeout})
+		msgs = append(msgs, &decode.Point{Attr: AttrAck, Value: AckTimeout})
+	}
+
+	// The queue item ID links the ACK to its enqueued downlink.
+	if ackMsg.GetQueueItemId() != "" {
+		msgs = append(msgs, &decode.Point{
+			Attr: AttrQueueID, Value: ackMsg.GetQueueItemId(),
+		})
 	}
 
 	return msgs, nil
@@ -5,6 +5,7 @@ package device
 import (
 	"fmt"
 	"testing"
+	"uuid"
 
 	"github.com/chirpstack/chirpstack/api/go/v4/integration"
 	"github.com/stretchr/testify/require"
@@ -15,6 +16,8 @@ import (
 func TestDeviceAck(t *testing.T) {
 	t.Parallel()
 
+	queueID := uuid.NewV7().String()
+
 	// Device ACK payloads, see deviceAck() for format description.
 	tests := []struct {
 		inp *integration.AckEvent
@@ -24,11 +27,19 @@ func TestDeviceAck(t *testing.T) {
 		// Device ACK.
 		{&integration.AckEvent{}, []*decode.Point{
 			{Attr: AttrRaw, Value: `{}`},
-			{Attr: "ack", Value: ackTimeout},
+			{Attr: "ack", Value: AckTimeout},
 		}, ""},
 		{&integration.AckEvent{Acknowledged: true}, []*decode.Point{
 			{Attr: AttrRaw, Value: `{"acknowledged":true}`},
-			{Attr: "ack", Value: ackOK},
+			{Attr: "ack", Value: AckOK},
+		}, ""},
+		{&integration.AckEvent{
+			QueueItemId: queueID, Acknowledged: true,
+		}, []*decode.Point{
+			{Attr: AttrRaw, Value: fmt.Sprintf(`{"queueItemId":"%s",`+
+				`"acknowledged":true}`, queueID)},
+			{Attr: "ack", Value: AckOK},
+			{Attr: "queue_item_id", Value: queueID},
 		}, ""},
 		// Device ACK bad length.
 		{nil, nil, "cannot parse invalid wire-format data"},
@@ -39,7 +39,7 @@ func TestParse(t *testing.T) {
 		}, nil},
 		{"ack", "", []*decode.Point{
 			{Attr: AttrRaw, Value: `{}`},
-			{Attr: "ack", Value: ackTimeout},
+			{Attr: "ack", Value: AckTimeout},
 		}, nil},
 		{"log", "", []*decode.Point{
 			{Attr: AttrRaw, Value: `{}`},
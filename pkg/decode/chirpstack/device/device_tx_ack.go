@@ -22,7 +22,12 @@ func deviceTXAck(body []byte) ([]*decode.Point, error) {
 		protojson.MarshalOptions{}.Format(txAckMsg), " ", "")}}
 
 	// Parse TxAckEvent.
-	msgs = append(msgs, &decode.Point{Attr: "tx_queued", Value: true})
+	msgs = append(msgs, &decode.Point{Attr: AttrTXQueued, Value: true})
+	if txAckMsg.GetQueueItemId() != "" {
+		msgs = append(msgs, &decode.Point{
+			Attr: AttrQueueID, Value: txAckMsg.GetQueueItemId(),
+		})
+	}
 	if txAckMsg.GetGatewayId() != "" {
 		msgs = append(msgs, &decode.Point{
 			Attr: "tx_gateway_id", Value: txAckMsg.GetGatewayId(),
@@ -5,6 +5,7 @@ package device
 import (
 	"fmt"
 	"testing"
+	"uuid"
 
 	"github.com/chirpstack/chirpstack/api/go/v4/gw"
 	"github.com/chirpstack/chirpstack/api/go/v4/integration"
@@ -18,6 +19,7 @@ func TestDeviceTXAck(t *testing.T) {
 	t.Parallel()
 
 	gatewayID := random.String(16)
+	queueID := uuid.NewV7().String()
 
 	// Device TX ACK payloads, see deviceTXAck() for format description.
 	tests := []struct {
@@ -44,6 +46,14 @@ func TestDeviceTXAck(t *testing.T) {
 				{Attr: "tx_frequency", Value: int32(902700000)},
 			}, "",
 		},
+		{
+			&integration.TxAckEvent{QueueItemId: queueID}, []*decode.Point{
+				{Attr: AttrRaw, Value: fmt.Sprintf(`{"queueItemId":"%s"}`,
+					queueID)},
+				{Attr: "tx_queued", Value: true},
+				{Attr: "queue_item_id", Value: queueID},
+			}, "",
+		},
 		// Device TX ACK bad length.
 		{
 			nil, nil, "cannot parse invalid wire-format data",
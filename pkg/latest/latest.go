@@ -0,0 +1,90 @@
+// Package latest provides functions to cache the latest data point of each of
+// a device's attributes. The cache is kept warm as data points are stored, so
+// that rules may reference other attributes without querying the database.
+package latest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// exp is the expiration of a cached data point, which is refreshed by each
+// newer data point of the attribute.
+const exp = 30 * 24 * time.Hour
+
+// key returns a cache key for the latest data point of a device's attribute.
+func key(orgID, devID, attr string) string {
+	return fmt.Sprintf("latest:org:%s:dev:%s:attr:%s", orgID, devID, attr)
+}
+
+// Set caches a data point as the latest of its attribute for a device, unless
+// a newer data point is already cached. Concurrent calls for the same device
+// and attribute may race, as data points are not guaranteed to be delivered in
+// order.
+func Set(
+	ctx context.Context, c cache.Cacher[[]byte], orgID, devID string,
+	point *common.DataPoint,
+) error {
+	k := key(orgID, devID, point.GetAttr())
+
+	prev, err := get(ctx, c, k)
+	if err != nil && !errors.Is(err, cache.ErrNotFound) {
+		return err
+	}
+	if err == nil && prev.GetTs().AsTime().After(point.GetTs().AsTime()) {
+		return nil
+	}
+
+	bPoint, err := proto.Marshal(point)
+	if err != nil {
+		return err
+	}
+
+	return c.SetTTL(ctx, k, bPoint, exp)
+}
+
+// Get retrieves the latest cached data points of a device's attributes, keyed
+// by attribute. Attributes without a cached data point are absent.
+func Get(
+	ctx context.Context, c cache.Cacher[[]byte], orgID, devID string,
+	attrs []string,
+) (map[string]*common.DataPoint, error) {
+	points := make(map[string]*common.DataPoint, len(attrs))
+	for _, attr := range attrs {
+		point, err := get(ctx, c, key(orgID, devID, attr))
+		if errors.Is(err, cache.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		points[attr] = point
+	}
+
+	return points, nil
+}
+
+// get retrieves a cached data point by key. If the key does not exist,
+// cache.ErrNotFound is returned.
+func get(
+	ctx context.Context, c cache.Cacher[[]byte], k string,
+) (*common.DataPoint, error) {
+	bPoint, err := c.Get(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+
+	point := &common.DataPoint{}
+	if err := proto.Unmarshal(bPoint, point); err != nil {
+		return nil, err
+	}
+
+	return point, nil
+}
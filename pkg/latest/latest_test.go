@@ -0,0 +1,126 @@
+//go:build !integration
+
+package latest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/cache"
+	"github.com/thingspect/atlas/pkg/consterr"
+	"github.com/thingspect/atlas/pkg/test/random"
+	"github.com/thingspect/proto/go/common"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const errTestCache consterr.Error = "latest: test cache error"
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.NewV7().String()
+	devID := uuid.NewV7().String()
+	attr := random.String(10)
+
+	res := key(orgID, devID, attr)
+	t.Logf("res: %v", res)
+	require.Equal(t, fmt.Sprintf("latest:org:%s:dev:%s:attr:%s", orgID, devID,
+		attr), res)
+}
+
+func TestSetGet(t *testing.T) {
+	t.Parallel()
+
+	c := cache.NewHeap[[]byte]()
+	orgID := uuid.NewV7().String()
+	devID := uuid.NewV7().String()
+	now := time.Now()
+
+	temp := &common.DataPoint{
+		Attr: "temp", ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 21.5},
+		Ts: timestamppb.New(now),
+	}
+	door := &common.DataPoint{
+		Attr: "door", ValOneof: &common.DataPoint_BoolVal{BoolVal: true},
+		Ts: timestamppb.New(now),
+	}
+	oldTemp := &common.DataPoint{
+		Attr: "temp", ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 18},
+		Ts: timestamppb.New(now.Add(-time.Minute)),
+	}
+
+	for _, point := range []*common.DataPoint{temp, door, oldTemp} {
+		require.NoError(t, Set(t.Context(), c, orgID, devID, point))
+	}
+
+	points, err := Get(t.Context(), c, orgID, devID,
+		[]string{"temp", "door", "humidity"})
+	t.Logf("points, err: %+v, %v", points, err)
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+
+	// Testify does not currently support protobuf equality:
+	// https://github.com/stretchr/testify/issues/758
+	if !proto.Equal(temp, points["temp"]) {
+		t.Fatalf("\nExpect: %+v\nActual: %+v", temp, points["temp"])
+	}
+	if !proto.Equal(door, points["door"]) {
+		t.Fatalf("\nExpect: %+v\nActual: %+v", door, points["door"])
+	}
+
+	points, err = Get(t.Context(), c, uuid.NewV7().String(), devID,
+		[]string{"temp"})
+	t.Logf("points, err: %+v, %v", points, err)
+	require.NoError(t, err)
+	require.Empty(t, points)
+}
+
+func TestSetGetError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Set with cache error", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMockCacher[[]byte](gomock.NewController(t))
+		c.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errTestCache).
+			Times(1)
+
+		err := Set(t.Context(), c, uuid.NewV7().String(),
+			uuid.NewV7().String(), &common.DataPoint{Attr: "temp"})
+		t.Logf("err: %v", err)
+		require.Equal(t, errTestCache, err)
+	})
+
+	t.Run("Get with cache error", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMockCacher[[]byte](gomock.NewController(t))
+		c.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errTestCache).
+			Times(1)
+
+		points, err := Get(t.Context(), c, uuid.NewV7().String(),
+			uuid.NewV7().String(), []string{"temp"})
+		t.Logf("points, err: %+v, %v", points, err)
+		require.Nil(t, points)
+		require.Equal(t, errTestCache, err)
+	})
+
+	t.Run("Get invalid data point", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMockCacher[[]byte](gomock.NewController(t))
+		c.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte("latest-aaa"),
+			nil).Times(1)
+
+		points, err := Get(t.Context(), c, uuid.NewV7().String(),
+			uuid.NewV7().String(), []string{"temp"})
+		t.Logf("points, err: %+v, %v", points, err)
+		require.Nil(t, points)
+		require.Error(t, err)
+	})
+}
@@ -3,12 +3,23 @@ package notify
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/thingspect/atlas/pkg/cache"
 )
 
+// emailer defines the methods provided by an email backend.
+type emailer interface {
+	sendEmail(ctx context.Context, displayName, from, to, subject,
+		body string) error
+}
+
+// Verify backends implement emailer.
+var (
+	_ emailer = &mailgun{}
+	_ emailer = &smtpRelay{}
+)
+
 const (
 	emailKey       string = "notify.email"
 	emailRateDelay        = 333 * time.Millisecond
@@ -19,8 +30,8 @@ const (
 func (n *notify) Email(
 	ctx context.Context, displayName, from, to, subject, body string,
 ) error {
-	// Mailgun does not employ a rate limit, so default to 3 per second,
-	// serially.
+	// Neither Mailgun nor SMTP relays employ a known rate limit, so default
+	// to 3 per second, serially.
 	err := n.cache.SetIfNotExistTTL(ctx, emailKey, "", emailRateDelay)
 	if err != nil && !errors.Is(err, cache.ErrAlreadyExists) {
 		return err
@@ -34,7 +45,5 @@ func (n *notify) Email(
 		}
 	}
 
-	sender := fmt.Sprintf("%s <%s>", displayName, from)
-
-	return n.mailgun.sendEmail(ctx, sender, to, subject, body)
+	return n.email.sendEmail(ctx, displayName, from, to, subject, body)
 }
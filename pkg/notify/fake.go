@@ -20,6 +20,8 @@ func NewFake() Notifier {
 		gomock.Any()).Return(nil).AnyTimes()
 	notifier.EXPECT().Email(gomock.Any(), gomock.Any(), gomock.Any(),
 		gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	notifier.EXPECT().Webhook(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).Return(nil).AnyTimes()
 
 	return notifier
 }
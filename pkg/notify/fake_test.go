@@ -35,6 +35,8 @@ func TestNewFake(t *testing.T) {
 			require.NoError(t, notifier.Email(ctx, random.String(10),
 				random.String(10), random.String(10), random.String(10),
 				random.String(10)))
+			require.NoError(t, notifier.Webhook(ctx, random.String(10),
+				random.String(10), []byte(random.String(10))))
 		})
 	}
 }
@@ -37,11 +37,11 @@ func (te *mailgunError) Error() string {
 
 // sendEmail calls the Messages API to send an email.
 func (t *mailgun) sendEmail(
-	ctx context.Context, from, to, subject, body string,
+	ctx context.Context, displayName, from, to, subject, body string,
 ) error {
 	// Create request.
 	vals := url.Values{}
-	vals.Set("from", from)
+	vals.Set("from", fmt.Sprintf("%s <%s>", displayName, from))
 	vals.Set("to", to)
 	vals.Set("subject", subject)
 	vals.Set("text", body)
@@ -109,3 +109,17 @@ func (mr *MockNotifierMockRecorder) ValidateSMS(ctx, phone any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSMS", reflect.TypeOf((*MockNotifier)(nil).ValidateSMS), ctx, phone)
 }
+
+// Webhook mocks base method.
+func (m *MockNotifier) Webhook(ctx context.Context, url, secret string, doc []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Webhook", ctx, url, secret, doc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Webhook indicates an expected call of Webhook.
+func (mr *MockNotifierMockRecorder) Webhook(ctx, url, secret, doc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Webhook", reflect.TypeOf((*MockNotifier)(nil).Webhook), ctx, url, secret, doc)
+}
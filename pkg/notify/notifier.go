@@ -22,4 +22,8 @@ type Notifier interface {
 	// limiting.
 	Email(ctx context.Context, displayName, from, to, subject,
 		body string) error
+	// Webhook POSTs a JSON document to a URL, signed with an HMAC-SHA256 of
+	// the document using secret. Requests are retried with backoff on 5xx
+	// responses.
+	Webhook(ctx context.Context, url, secret string, doc []byte) error
 }
@@ -1,6 +1,16 @@
 package notify
 
-import "github.com/thingspect/atlas/pkg/cache"
+import (
+	"net/http"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/cache"
+)
+
+const (
+	defaultWebhookBackoff = time.Second
+	defaultWebhookTimeout = 10 * time.Second
+)
 
 // notify contains methods to send notifications and implements the Notifier
 // interface.
@@ -9,17 +19,29 @@ type notify struct {
 
 	appAPIKey string
 	twilio    *twilio
-	mailgun   *mailgun
+	email     emailer
+
+	webhookBackoff time.Duration
+	webhookClient  *http.Client
 }
 
 // Verify notify implements Notifier.
 var _ Notifier = &notify{}
 
-// New builds a new Notifier and returns it.
+// New builds a new Notifier and returns it. Email is sent using Mailgun,
+// unless smtp is provided, in which case it is sent through the SMTP relay.
 func New(
 	cache cache.Cacher[string], appAPIKey, smsKeyID, smsAccountID, smsKeySecret,
-	smsPhone, emailDomain, emailAPIKey string,
+	smsPhone, emailDomain, emailAPIKey string, smtp *SMTP,
 ) Notifier {
+	var email emailer = &mailgun{
+		domain: emailDomain,
+		apiKey: emailAPIKey,
+	}
+	if smtp != nil {
+		email = &smtpRelay{cfg: *smtp}
+	}
+
 	return &notify{
 		cache: cache,
 
@@ -30,9 +52,9 @@ func New(
 			keySecret:  smsKeySecret,
 			phone:      smsPhone,
 		},
-		mailgun: &mailgun{
-			domain: emailDomain,
-			apiKey: emailAPIKey,
-		},
+		email: email,
+
+		webhookBackoff: defaultWebhookBackoff,
+		webhookClient:  newWebhookClient(defaultWebhookTimeout, webhookControl),
 	}
 }
@@ -0,0 +1,267 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+	"uuid"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/consterr"
+)
+
+// Constants used for SMTP TLS modes and authentication mechanisms.
+const (
+	SMTPStartTLS    = "starttls"
+	SMTPImplicitTLS = "tls"
+	SMTPAuthPlain   = "plain"
+	SMTPAuthLogin   = "login"
+)
+
+// errSMTP is returned when an SMTP relay does not behave as configured.
+const errSMTP consterr.Error = "smtp"
+
+// SMTP holds the settings of an SMTP relay, used to send email in place of
+// Mailgun.
+type SMTP struct {
+	Host string
+	Port int
+	// Username and Password are used for authentication. If Username is
+	// empty, authentication is skipped.
+	Username string
+	Password string
+	// TLS is one of SMTPStartTLS or SMTPImplicitTLS. Connections are always
+	// encrypted, and default to SMTPStartTLS.
+	TLS string
+	// Auth is one of SMTPAuthPlain or SMTPAuthLogin, and defaults to
+	// SMTPAuthPlain.
+	Auth string
+}
+
+// smtpRelay contains fields and methods of an SMTP client.
+type smtpRelay struct {
+	cfg SMTP
+	// tlsConfig is the base TLS configuration of connections. It may be nil.
+	tlsConfig *tls.Config
+}
+
+// sendEmail sends a multipart text and HTML email through an SMTP relay.
+func (s *smtpRelay) sendEmail(
+	ctx context.Context, displayName, from, to, subject, body string,
+) error {
+	msg, err := smtpMessage(displayName, from, to, subject, body, time.Now())
+	if err != nil {
+		return err
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if s.tlsConfig != nil {
+		tlsConfig = s.tlsConfig.Clone()
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = s.cfg.Host
+	}
+
+	// Connect, encrypting immediately for implicit TLS.
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	dialer := &net.Dialer{}
+
+	var conn net.Conn
+	if s.cfg.TLS == SMTPImplicitTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).
+			DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		return err
+	}
+
+	if err = s.deliver(client, tlsConfig, from, to, msg); err != nil {
+		// The client may have already closed the connection, such as after a
+		// failed authentication.
+		if cErr := client.Close(); cErr != nil &&
+			!errors.Is(cErr, net.ErrClosed) {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("sendEmail client.Close: %v", cErr)
+		}
+
+		return err
+	}
+
+	return client.Quit()
+}
+
+// deliver negotiates encryption and authentication on a connected client and
+// sends a message.
+func (s *smtpRelay) deliver(
+	client *smtp.Client, tlsConfig *tls.Config, from, to string, msg []byte,
+) error {
+	if s.cfg.TLS != SMTPImplicitTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("%w: STARTTLS not supported", errSMTP)
+		}
+
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if s.cfg.Username != "" {
+		var auth smtp.Auth
+		switch s.cfg.Auth {
+		case SMTPAuthLogin:
+			auth = &loginAuth{
+				username: s.cfg.Username, password: s.cfg.Password,
+			}
+		default:
+			auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password,
+				s.cfg.Host)
+		}
+
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+// smtpMessage builds a MIME message with text and HTML alternatives of body.
+func smtpMessage(
+	displayName, from, to, subject, body string, now time.Time,
+) ([]byte, error) {
+	var parts bytes.Buffer
+	mw := multipart.NewWriter(&parts)
+
+	htmlBody := "<html><body>" + strings.ReplaceAll(html.EscapeString(body),
+		"\n", "<br>\n") + "</body></html>"
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain", body},
+		{"text/html", htmlBody},
+	} {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType+"; charset=utf-8")
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+		pw, err := mw.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+
+		qw := quotedprintable.NewWriter(pw)
+		if _, err = qw.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+
+		if err = qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = from[at+1:]
+	}
+
+	var msg bytes.Buffer
+	for _, header := range [][2]string{
+		{"From", (&mail.Address{Name: displayName, Address: from}).String()},
+		{"To", (&mail.Address{Address: to}).String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@%s>", uuid.NewV7(), domain)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", mime.FormatMediaType("multipart/alternative",
+			map[string]string{"boundary": mw.Boundary()})},
+	} {
+		msg.WriteString(header[0] + ": " + header[1] + "\r\n")
+	}
+	msg.WriteString("\r\n")
+	msg.Write(parts.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+// loginAuth implements the LOGIN authentication mechanism, which is not
+// provided by net/smtp.
+type loginAuth struct {
+	username string
+	password string
+}
+
+// Start begins an authentication with a server and implements the smtp.Auth
+// interface. Credentials are only sent over encrypted connections.
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS {
+		return "", nil, fmt.Errorf("%w: unencrypted connection", errSMTP)
+	}
+
+	return "LOGIN", nil, nil
+}
+
+// Next continues an authentication and implements the smtp.Auth interface.
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(string(fromServer))) {
+	case "username:":
+		return []byte(a.username), nil
+	case "password:":
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected LOGIN challenge: %s", errSMTP,
+			fromServer)
+	}
+}
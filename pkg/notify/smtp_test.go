@@ -0,0 +1,399 @@
+//go:build !integration
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/random"
+)
+
+// smtpStandIn is a minimal SMTP server, supporting STARTTLS, implicit TLS,
+// and PLAIN and LOGIN authentication. It records delivered messages.
+type smtpStandIn struct {
+	ln        net.Listener
+	tlsConfig *tls.Config
+	implicit  bool
+	username  string
+	password  string
+
+	mu   sync.Mutex
+	from string
+	to   string
+	msg  []byte
+}
+
+// newSMTPStandIn starts an SMTP stand-in on a random local port and returns it
+// along with a client TLS configuration that trusts it.
+func newSMTPStandIn(
+	t *testing.T, implicit bool, username, password string,
+) (*smtpStandIn, *tls.Config) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &priv.PublicKey,
+		priv)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ln.Close()) })
+
+	s := &smtpStandIn{
+		ln: ln,
+		tlsConfig: &tls.Config{
+			Certificates: []tls.Certificate{{
+				Certificate: [][]byte{der}, PrivateKey: priv,
+			}},
+			MinVersion: tls.VersionTLS12,
+		},
+		implicit: implicit,
+		username: username,
+		password: password,
+	}
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go s.serve(conn)
+		}
+	}()
+
+	return s, &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+}
+
+// port returns the port of an SMTP stand-in.
+func (s *smtpStandIn) port() int {
+	addr, _ := s.ln.Addr().(*net.TCPAddr)
+
+	return addr.Port
+}
+
+// serve handles an SMTP session.
+func (s *smtpStandIn) serve(conn net.Conn) {
+	var encrypted bool
+	if s.implicit {
+		conn = tls.Server(conn, s.tlsConfig)
+		encrypted = true
+	}
+	defer func() { _ = conn.Close() }()
+
+	tp := textproto.NewConn(conn)
+	reply := func(format string, args ...any) bool {
+		return tp.PrintfLine(format, args...) == nil
+	}
+	authed := s.username == ""
+
+	if !reply("220 127.0.0.1 ESMTP") {
+		return
+	}
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			ext := "250-127.0.0.1\r\n"
+			if !encrypted {
+				ext += "250-STARTTLS\r\n"
+			}
+			if !reply("%s250 AUTH PLAIN LOGIN", ext) {
+				return
+			}
+		case "STARTTLS":
+			if !reply("220 ready") {
+				return
+			}
+			conn = tls.Server(conn, s.tlsConfig)
+			tp = textproto.NewConn(conn)
+			encrypted = true
+		case "AUTH":
+			mech, resp, _ := strings.Cut(arg, " ")
+			var user, pass string
+
+			switch strings.ToUpper(mech) {
+			case "PLAIN":
+				b, _ := base64.StdEncoding.DecodeString(resp)
+				if fields := bytes.Split(b, []byte{0}); len(fields) == 3 {
+					user, pass = string(fields[1]), string(fields[2])
+				}
+			case "LOGIN":
+				for _, prompt := range []string{"Username:", "Password:"} {
+					if !reply("334 %s", base64.StdEncoding.EncodeToString(
+						[]byte(prompt))) {
+						return
+					}
+
+					line, err := tp.ReadLine()
+					if err != nil {
+						return
+					}
+					b, _ := base64.StdEncoding.DecodeString(line)
+					if prompt == "Username:" {
+						user = string(b)
+					} else {
+						pass = string(b)
+					}
+				}
+			}
+
+			authed = encrypted && user == s.username && pass == s.password
+			if authed {
+				reply("235 authenticated")
+			} else {
+				reply("535 authentication failed")
+			}
+		case "MAIL":
+			if !authed {
+				reply("530 authentication required")
+
+				continue
+			}
+			s.mu.Lock()
+			s.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			s.mu.Unlock()
+			reply("250 ok")
+		case "RCPT":
+			s.mu.Lock()
+			s.to = strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			s.mu.Unlock()
+			reply("250 ok")
+		case "DATA":
+			if !reply("354 go ahead") {
+				return
+			}
+			msg, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.msg = msg
+			s.mu.Unlock()
+			reply("250 ok")
+		case "QUIT":
+			reply("221 bye")
+
+			return
+		default:
+			reply("502 unknown command")
+		}
+	}
+}
+
+func TestSMTPSendEmail(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpTLS  string
+		inpAuth string
+		inpUser string
+	}{
+		{SMTPStartTLS, SMTPAuthPlain, "notify-user"},
+		{SMTPStartTLS, SMTPAuthLogin, "notify-user"},
+		{SMTPImplicitTLS, SMTPAuthPlain, "notify-user"},
+		{SMTPImplicitTLS, SMTPAuthLogin, "notify-user"},
+		{"", "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can send %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			pass := random.String(10)
+			srv, tlsConfig := newSMTPStandIn(t, test.inpTLS == SMTPImplicitTLS,
+				test.inpUser, pass)
+
+			relay := &smtpRelay{
+				cfg: SMTP{
+					Host: "127.0.0.1", Port: srv.port(), Username: test.inpUser,
+					Password: pass, TLS: test.inpTLS, Auth: test.inpAuth,
+				},
+				tlsConfig: tlsConfig,
+			}
+
+			subj := "notify-subj-" + random.String(10) + " é"
+			body := "notify-body <b>\n" + random.String(10)
+
+			ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+			defer cancel()
+
+			err := relay.sendEmail(ctx, "Notify Org", "notify@example.com",
+				"user@example.com", subj, body)
+			t.Logf("err: %v", err)
+			require.NoError(t, err)
+
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			require.Equal(t, "notify@example.com", srv.from)
+			require.Equal(t, "user@example.com", srv.to)
+
+			msg, err := mail.ReadMessage(bytes.NewReader(srv.msg))
+			require.NoError(t, err)
+
+			from, err := msg.Header.AddressList("From")
+			require.NoError(t, err)
+			require.Equal(t, "Notify Org", from[0].Name)
+
+			decSubj, err := new(mime.WordDecoder).DecodeHeader(
+				msg.Header.Get("Subject"))
+			require.NoError(t, err)
+			require.Equal(t, subj, decSubj)
+
+			mediaType, params, err := mime.ParseMediaType(
+				msg.Header.Get("Content-Type"))
+			require.NoError(t, err)
+			require.Equal(t, "multipart/alternative", mediaType)
+
+			mr := multipart.NewReader(msg.Body, params["boundary"])
+			parts := map[string]string{}
+			for {
+				part, err := mr.NextRawPart()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+
+				b, err := io.ReadAll(quotedprintable.NewReader(part))
+				require.NoError(t, err)
+				parts[part.Header.Get("Content-Type")] = string(b)
+			}
+
+			require.Equal(t, body, parts["text/plain; charset=utf-8"])
+			require.Contains(t, parts["text/html; charset=utf-8"],
+				"notify-body &lt;b&gt;<br>\n")
+		})
+	}
+}
+
+func TestSMTPSendEmailError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Send email with bad password", func(t *testing.T) {
+		t.Parallel()
+
+		srv, tlsConfig := newSMTPStandIn(t, false, "notify-user",
+			random.String(10))
+
+		relay := &smtpRelay{
+			cfg: SMTP{
+				Host: "127.0.0.1", Port: srv.port(), Username: "notify-user",
+				Password: random.String(10), TLS: SMTPStartTLS,
+			},
+			tlsConfig: tlsConfig,
+		}
+
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+
+		err := relay.sendEmail(ctx, "Notify Org", "notify@example.com",
+			"user@example.com", "notify-subj", "notify-body")
+		t.Logf("err: %v", err)
+		require.ErrorContains(t, err, "authentication failed")
+	})
+
+	t.Run("Send email to untrusted server", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _ := newSMTPStandIn(t, true, "", "")
+
+		relay := &smtpRelay{
+			cfg: SMTP{
+				Host: "127.0.0.1", Port: srv.port(), TLS: SMTPImplicitTLS,
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+
+		err := relay.sendEmail(ctx, "Notify Org", "notify@example.com",
+			"user@example.com", "notify-subj", "notify-body")
+		t.Logf("err: %v", err)
+		require.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("Send email to unreachable server", func(t *testing.T) {
+		t.Parallel()
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr, _ := ln.Addr().(*net.TCPAddr)
+		require.NoError(t, ln.Close())
+
+		relay := &smtpRelay{cfg: SMTP{Host: "127.0.0.1", Port: addr.Port}}
+
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+
+		err = relay.sendEmail(ctx, "Notify Org", "notify@example.com",
+			"user@example.com", "notify-subj", "notify-body")
+		t.Logf("err: %v", err)
+		require.Error(t, err)
+	})
+}
+
+func TestLoginAuth(t *testing.T) {
+	t.Parallel()
+
+	auth := &loginAuth{username: "notify-user", password: "notify-pass"}
+
+	resp, err := auth.Next([]byte("Username:"), true)
+	require.NoError(t, err)
+	require.Equal(t, "notify-user", string(resp))
+
+	resp, err = auth.Next([]byte("Password:"), true)
+	require.NoError(t, err)
+	require.Equal(t, "notify-pass", string(resp))
+
+	resp, err = auth.Next(nil, false)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	_, err = auth.Next([]byte("Token:"), true)
+	require.ErrorIs(t, err, errSMTP)
+}
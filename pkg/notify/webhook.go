@@ -0,0 +1,172 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/alog"
+	"github.com/thingspect/atlas/pkg/consterr"
+)
+
+const (
+	// WebhookSignatureHeader is the header containing the HMAC-SHA256
+	// signature of a webhook request, formatted as 'sha256=<hex>'. The
+	// signature is computed over the timestamp header value, a '.', and the
+	// request body.
+	WebhookSignatureHeader = "X-Thingspect-Signature"
+	// WebhookTimestampHeader is the header containing the Unix time, in
+	// seconds, at which a webhook request was signed.
+	WebhookTimestampHeader = "X-Thingspect-Timestamp"
+
+	webhookAttempts                = 3
+	errWebhook      consterr.Error = "webhook"
+	errWebhookAddr  consterr.Error = "webhook: address not allowed"
+)
+
+// sharedAddrs is the shared address space used by carrier-grade NAT and some
+// cloud metadata services.
+var sharedAddrs = netip.MustParsePrefix("100.64.0.0/10")
+
+// newWebhookClient builds a new HTTP client for webhook requests. Redirects are
+// not followed, so that a request may not be sent to a different destination
+// than the validated URL. If control is non-nil, it is called before each
+// connection is made, after name resolution.
+func newWebhookClient(
+	timeout time.Duration,
+	control func(network, address string, c syscall.RawConn) error,
+) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   control,
+	}
+
+	// Proxies are not used, so that control applies to the destination.
+	transport, _ := http.DefaultTransport.(*http.Transport)
+	transport = transport.Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// webhookControl rejects connections to addresses that are not public, such as
+// loopback, link-local, and private addresses, so that webhooks may not be
+// used to reach internal services.
+func webhookControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || sharedAddrs.Contains(ip) {
+		return fmt.Errorf("%w: %s", errWebhookAddr, ip)
+	}
+
+	return nil
+}
+
+// Webhook POSTs a JSON document to a URL, signed with an HMAC-SHA256 of the
+// document using secret. Requests are retried with backoff on 5xx responses.
+// Redirect responses are treated as failures.
+func (n *notify) Webhook(
+	ctx context.Context, url, secret string, doc []byte,
+) error {
+	var err error
+	backoff := n.webhookBackoff
+
+	for attempt := range webhookAttempts {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		var retry bool
+		if retry, err = postWebhook(ctx, n.webhookClient, url, secret,
+			doc); !retry {
+			return err
+		}
+	}
+
+	return err
+}
+
+// SignWebhook returns the signature of a webhook document for a timestamp,
+// as would be found in a WebhookSignatureHeader.
+func SignWebhook(secret, timestamp string, doc []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(doc)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// postWebhook makes a single webhook request. It returns whether the request
+// may be retried along with any error.
+func postWebhook(
+	ctx context.Context, client *http.Client, url, secret string, doc []byte,
+) (bool, error) {
+	// Create request.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url,
+		bytes.NewReader(doc))
+	if err != nil {
+		return false, err
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(WebhookTimestampHeader, ts)
+	req.Header.Add(WebhookSignatureHeader, SignWebhook(secret, ts, doc))
+
+	// Send request. Disallowed addresses are not retried.
+	resp, err := client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, errWebhookAddr), err
+	}
+	defer func() {
+		if err = resp.Body.Close(); err != nil {
+			logger := alog.FromContext(ctx)
+			logger.Errorf("postWebhook resp.Body.Close: %v", err)
+		}
+	}()
+
+	// Drain the response so that the connection may be reused.
+	if _, err = io.Copy(io.Discard, resp.Body); err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode >= http.StatusInternalServerError,
+			fmt.Errorf("%w: %d", errWebhook, resp.StatusCode)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,183 @@
+//go:build !integration
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/pkg/test/random"
+)
+
+func TestWebhook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpStatuses []int
+		resAttempts int32
+		err         string
+	}{
+		{[]int{http.StatusNoContent}, 1, ""},
+		{[]int{http.StatusBadGateway, http.StatusOK}, 2, ""},
+		{[]int{http.StatusBadRequest}, 1, "webhook: 400"},
+		{[]int{http.StatusFound}, 1, "webhook: 302"},
+		{[]int{http.StatusTemporaryRedirect}, 1, "webhook: 307"},
+		{[]int{
+			http.StatusServiceUnavailable, http.StatusInternalServerError,
+			http.StatusBadGateway,
+		}, 3, "webhook: 502"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can send %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			secret := random.String(16)
+			doc := []byte(`{"subject":"` + random.String(10) + `"}`)
+
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(
+				w http.ResponseWriter, r *http.Request,
+			) {
+				attempt := attempts.Add(1)
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil || string(body) != string(doc) ||
+					r.Header.Get("Content-Type") != "application/json" ||
+					r.Header.Get(WebhookSignatureHeader) != SignWebhook(secret,
+						r.Header.Get(WebhookTimestampHeader), body) {
+					w.WriteHeader(http.StatusUnauthorized)
+
+					return
+				}
+
+				// Redirect to the same server, which would fail the
+				// signature check if followed.
+				w.Header().Set("Location", "/redirect")
+				w.WriteHeader(test.inpStatuses[attempt-1])
+			}))
+			defer srv.Close()
+
+			notifier := &notify{
+				webhookBackoff: time.Millisecond,
+				webhookClient:  newWebhookClient(time.Second, nil),
+			}
+
+			ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
+			defer cancel()
+
+			err := notifier.Webhook(ctx, srv.URL, secret, doc)
+			t.Logf("err: %#v", err)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.err)
+			}
+			require.Equal(t, test.resAttempts, attempts.Load())
+		})
+	}
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(
+		w http.ResponseWriter, r *http.Request,
+	) {
+		attempts.Add(1)
+
+		select {
+		case <-r.Context().Done():
+		case <-time.After(500 * time.Millisecond):
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	notifier := &notify{
+		webhookBackoff: time.Millisecond,
+		webhookClient:  newWebhookClient(50*time.Millisecond, nil),
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := notifier.Webhook(ctx, srv.URL, random.String(16), []byte(`{}`))
+	t.Logf("err: %v", err)
+	require.ErrorContains(t, err, "Client.Timeout exceeded")
+	require.Equal(t, int32(webhookAttempts), attempts.Load())
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestWebhookControl(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpAddress string
+		err        string
+	}{
+		{"93.184.215.14:443", ""},
+		{"[2606:4700::6810:84e5]:443", ""},
+		{"127.0.0.1:443", "webhook: address not allowed: 127.0.0.1"},
+		{"[::1]:443", "webhook: address not allowed: ::1"},
+		{"169.254.169.254:80", "webhook: address not allowed: 169.254.169.254"},
+		{"10.0.0.1:443", "webhook: address not allowed: 10.0.0.1"},
+		{"172.16.0.1:443", "webhook: address not allowed: 172.16.0.1"},
+		{"192.168.1.1:443", "webhook: address not allowed: 192.168.1.1"},
+		{"100.100.100.200:80", "webhook: address not allowed: 100.100.100.200"},
+		{"0.0.0.0:443", "webhook: address not allowed: 0.0.0.0"},
+		{"[fd00::1]:443", "webhook: address not allowed: fd00::1"},
+		{"[::ffff:127.0.0.1]:443", "webhook: address not allowed: 127.0.0.1"},
+		{"not-an-address", "address not-an-address: missing port in address"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can control %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			err := webhookControl("tcp", test.inpAddress, nil)
+			t.Logf("err: %v", err)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestWebhookDisallowed(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(
+		w http.ResponseWriter, _ *http.Request,
+	) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	notifier := &notify{
+		webhookBackoff: time.Millisecond,
+		webhookClient:  newWebhookClient(time.Second, webhookControl),
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
+	defer cancel()
+
+	err := notifier.Webhook(ctx, srv.URL, random.String(16), []byte(`{}`))
+	t.Logf("err: %v", err)
+	require.ErrorIs(t, err, errWebhookAddr)
+	require.Equal(t, int32(0), attempts.Load())
+}
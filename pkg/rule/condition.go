@@ -0,0 +1,93 @@
+package rule
+
+import (
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/common"
+)
+
+// Transition is the change in a rule's state resulting from a data point.
+type Transition int
+
+// Transitions returned by EvalCondition.
+const (
+	None Transition = iota
+	Enter
+	Exit
+)
+
+// String returns the name of a Transition.
+func (t Transition) String() string {
+	switch t {
+	case Enter:
+		return "enter"
+	case Exit:
+		return "exit"
+	default:
+		return "none"
+	}
+}
+
+// EvalCondition evaluates a rule expression with a stateful condition against
+// a data point and the rule's previous state for a device. It returns the new
+// state and the transition, if any.
+//
+// An inactive rule becomes active once ruleExpr has matched for ForSamples
+// consecutive data points spanning at least ForDuration. An active rule becomes
+// inactive on the first data point that matches ExitExpr or, if ExitExpr is
+// empty, that does not match ruleExpr.
+func EvalCondition(
+	point *common.DataPoint, env *Env, ruleExpr string,
+	cond *message.RuleCondition, state *message.RuleState,
+) (*message.RuleState, Transition, error) {
+	if state.GetActive() {
+		exit, err := evalExit(point, env, ruleExpr, cond.GetExitExpr())
+		if err != nil {
+			return nil, None, err
+		}
+
+		if exit {
+			return &message.RuleState{}, Exit, nil
+		}
+
+		return state, None, nil
+	}
+
+	res, err := Eval(point, env, ruleExpr)
+	if err != nil {
+		return nil, None, err
+	}
+
+	// Any non-matching data point resets the sequence.
+	if !res {
+		return &message.RuleState{}, None, nil
+	}
+
+	newState := &message.RuleState{
+		Count: state.GetCount() + 1,
+		Since: state.GetSince(),
+	}
+	if newState.GetSince() == nil {
+		newState.Since = point.GetTs()
+	}
+
+	if newState.GetCount() >= cond.GetForSamples() &&
+		point.GetTs().AsTime().Sub(newState.GetSince().AsTime()) >=
+			cond.GetForDuration().AsDuration() {
+		return &message.RuleState{Active: true}, Enter, nil
+	}
+
+	return newState, None, nil
+}
+
+// evalExit returns whether an active rule should become inactive.
+func evalExit(
+	point *common.DataPoint, env *Env, ruleExpr, exitExpr string,
+) (bool, error) {
+	if exitExpr != "" {
+		return Eval(point, env, exitExpr)
+	}
+
+	res, err := Eval(point, env, ruleExpr)
+
+	return !res, err
+}
@@ -0,0 +1,117 @@
+//go:build !integration
+
+package rule
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/atlas/proto/go/message"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEvalCondition(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		inpCond  *message.RuleCondition
+		inpVals  []float64
+		inpStep  time.Duration
+		resTrans []Transition
+		resState *message.RuleState
+	}{
+		// Samples.
+		{
+			&message.RuleCondition{ForSamples: 3},
+			[]float64{40, 40, 40, 40}, time.Minute,
+			[]Transition{None, None, Enter, None},
+			&message.RuleState{Active: true},
+		},
+		// Samples with reset.
+		{
+			&message.RuleCondition{ForSamples: 2},
+			[]float64{40, 20, 40}, time.Minute,
+			[]Transition{None, None, None},
+			&message.RuleState{
+				Count: 1, Since: timestamppb.New(now.Add(2 * time.Minute)),
+			},
+		},
+		// Duration.
+		{
+			&message.RuleCondition{ForDuration: durationpb.New(5 * time.Minute)},
+			[]float64{40, 40, 40, 40}, 2 * time.Minute,
+			[]Transition{None, None, None, Enter},
+			&message.RuleState{Active: true},
+		},
+		// Exit without exit expression.
+		{
+			&message.RuleCondition{ForSamples: 1},
+			[]float64{40, 40, 20, 40}, time.Minute,
+			[]Transition{Enter, None, Exit, Enter},
+			&message.RuleState{Active: true},
+		},
+		// Hysteresis.
+		{
+			&message.RuleCondition{ForSamples: 1, ExitExpr: `pointVal < 25`},
+			[]float64{40, 28, 31, 24, 28}, time.Minute,
+			[]Transition{Enter, None, None, Exit, None},
+			&message.RuleState{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can evaluate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			state := &message.RuleState{}
+			for i, val := range test.inpVals {
+				point := &common.DataPoint{
+					ValOneof: &common.DataPoint_Fl64Val{Fl64Val: val},
+					Ts: timestamppb.New(now.Add(time.Duration(i) *
+						test.inpStep)),
+				}
+
+				var trans Transition
+				var err error
+				state, trans, err = EvalCondition(point, nil, `pointVal > 30`,
+					test.inpCond, state)
+				t.Logf("state, trans, err: %+v, %v, %v", state, trans, err)
+				require.NoError(t, err)
+				require.Equal(t, test.resTrans[i], trans)
+			}
+
+			require.EqualExportedValues(t, test.resState, state)
+		})
+	}
+}
+
+func TestEvalConditionError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpCond  *message.RuleCondition
+		inpState *message.RuleState
+	}{
+		{&message.RuleCondition{}, &message.RuleState{}},
+		{&message.RuleCondition{}, &message.RuleState{Active: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cannot evaluate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			state, trans, err := EvalCondition(&common.DataPoint{}, nil,
+				`1 + "a"`, test.inpCond, test.inpState)
+			t.Logf("state, trans, err: %+v, %v, %v", state, trans, err)
+			require.Nil(t, state)
+			require.Equal(t, None, trans)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package rule
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
+	"github.com/thingspect/atlas/pkg/consterr"
+	"github.com/thingspect/proto/go/common"
+)
+
+// Errors returned by history functions.
+const (
+	errWindow    consterr.Error = "window must be a duration of up to 24h"
+	errNotNumber consterr.Error = "not a number"
+	errTruncated consterr.Error = "window exceeds retained history"
+)
+
+// MaxWindow is the longest window supported by history functions. History
+// older than MaxWindow need not be retained.
+const MaxWindow = 24 * time.Hour
+
+// MaxHistory is the maximum count of data points retained per device and
+// attribute, which supports one data point per minute over MaxWindow. History
+// of MaxHistory data points may have been truncated, and windows that extend
+// beyond its oldest data point return an error rather than a partial result.
+const MaxHistory = 1440
+
+// historyFuncs returns the history functions available to a rule expression,
+// which operate on a data point and the prior history of its device and
+// attribute. The built-in 'min', 'max', and 'count' functions are not
+// overridden, so windowed variants are suffixed with 'Over'.
+func historyFuncs(
+	point *common.DataPoint, history []*common.DataPoint,
+) map[string]any {
+	return map[string]any{
+		// avg returns the mean of numeric values within a window.
+		"avg": func(window any) (float64, error) {
+			vals, err := windowVals(point, history, window)
+			if err != nil {
+				return 0, err
+			}
+
+			var sum float64
+			for _, val := range vals {
+				sum += val
+			}
+
+			return sum / float64(len(vals)), nil
+		},
+		// minOver returns the minimum of numeric values within a window.
+		"minOver": func(window any) (float64, error) {
+			vals, err := windowVals(point, history, window)
+			if err != nil {
+				return 0, err
+			}
+
+			return slices.Min(vals), nil
+		},
+		// maxOver returns the maximum of numeric values within a window.
+		"maxOver": func(window any) (float64, error) {
+			vals, err := windowVals(point, history, window)
+			if err != nil {
+				return 0, err
+			}
+
+			return slices.Max(vals), nil
+		},
+		// countOver returns the count of data points within a window, of any
+		// value type.
+		"countOver": func(window any) (int, error) {
+			points, err := windowPoints(point, history, window)
+			if err != nil {
+				return 0, err
+			}
+
+			return len(points), nil
+		},
+		// delta returns the change in value since the previous numeric data
+		// point, or zero if there is none.
+		"delta": func() (float64, error) {
+			curr, prev, prevVal, err := currPrev(point, history)
+			if err != nil || prev == nil {
+				return 0, err
+			}
+
+			return curr - prevVal, nil
+		},
+		// rate returns the rate of change in value per minute since the
+		// previous numeric data point, or zero if there is none.
+		"rate": func() (float64, error) {
+			curr, prev, prevVal, err := currPrev(point, history)
+			if err != nil || prev == nil {
+				return 0, err
+			}
+
+			mins := point.GetTs().AsTime().Sub(prev.GetTs().AsTime()).Minutes()
+			if mins == 0 {
+				return 0, nil
+			}
+
+			return (curr - prevVal) / mins, nil
+		},
+	}
+}
+
+// UsesHistory returns whether any rule expression calls a history function.
+// Expressions that do not parse are ignored, as they fail evaluation.
+func UsesHistory(ruleExprs ...string) bool {
+	v := &historyVisitor{}
+	for _, ruleExpr := range ruleExprs {
+		tree, err := parser.Parse(ruleExpr)
+		if err != nil {
+			continue
+		}
+
+		ast.Walk(&tree.Node, v)
+		if v.found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// historyVisitor records whether a history function is called.
+type historyVisitor struct {
+	found bool
+}
+
+// Visit implements ast.Visitor.
+func (v *historyVisitor) Visit(node *ast.Node) {
+	call, ok := (*node).(*ast.CallNode)
+	if !ok {
+		return
+	}
+
+	if ident, ok := call.Callee.(*ast.IdentifierNode); ok {
+		if _, ok := historyFuncs(nil, nil)[ident.Value]; ok {
+			v.found = true
+		}
+	}
+}
+
+// windowPoints returns a data point and the points of its history within a
+// window ending at its timestamp. History at or after the data point's
+// timestamp is excluded, as it may be a redelivery of the data point or have
+// arrived out of order. An error is returned if the window may extend beyond
+// truncated history.
+func windowPoints(
+	point *common.DataPoint, history []*common.DataPoint, window any,
+) ([]*common.DataPoint, error) {
+	dur, err := toWindow(window)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := point.GetTs().AsTime()
+	start := ts.Add(-dur)
+
+	if len(history) >= MaxHistory {
+		oldest := slices.MinFunc(history, func(a, b *common.DataPoint) int {
+			return a.GetTs().AsTime().Compare(b.GetTs().AsTime())
+		})
+		if oldest.GetTs().AsTime().After(start) {
+			return nil, fmt.Errorf("%w: %v", errTruncated, dur)
+		}
+	}
+
+	points := []*common.DataPoint{point}
+	for _, hPoint := range history {
+		if hTS := hPoint.GetTs().AsTime(); hTS.After(start) && hTS.Before(ts) {
+			points = append(points, hPoint)
+		}
+	}
+
+	return points, nil
+}
+
+// windowVals returns the numeric values of the data points within a window.
+// Non-numeric history is skipped, but the data point must be numeric.
+func windowVals(
+	point *common.DataPoint, history []*common.DataPoint, window any,
+) ([]float64, error) {
+	points, err := windowPoints(point, history, window)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := toFloat(point); err != nil {
+		return nil, err
+	}
+
+	vals := make([]float64, 0, len(points))
+	for _, wPoint := range points {
+		if val, err := toFloat(wPoint); err == nil {
+			vals = append(vals, val)
+		}
+	}
+
+	return vals, nil
+}
+
+// currPrev returns the numeric value of a data point, along with the most
+// recent numeric point of its history prior to it and its value. The prior
+// point is nil if there is none.
+func currPrev(point *common.DataPoint, history []*common.DataPoint) (
+	float64, *common.DataPoint, float64, error,
+) {
+	curr, err := toFloat(point)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+
+	ts := point.GetTs().AsTime()
+
+	var prev *common.DataPoint
+	var prevVal float64
+	for _, hPoint := range history {
+		hTS := hPoint.GetTs().AsTime()
+		if !hTS.Before(ts) ||
+			(prev != nil && !hTS.After(prev.GetTs().AsTime())) {
+			continue
+		}
+
+		if val, err := toFloat(hPoint); err == nil {
+			prev = hPoint
+			prevVal = val
+		}
+	}
+
+	return curr, prev, prevVal, nil
+}
+
+// toWindow converts a window, as either a duration or a duration string such
+// as "5m", to a time.Duration.
+func toWindow(window any) (time.Duration, error) {
+	var dur time.Duration
+	switch v := window.(type) {
+	case time.Duration:
+		dur = v
+	case string:
+		var err error
+		if dur, err = time.ParseDuration(v); err != nil {
+			return 0, fmt.Errorf("%w: %w", errWindow, err)
+		}
+	default:
+		return 0, fmt.Errorf("%w: %T", errWindow, window)
+	}
+
+	if dur <= 0 || dur > MaxWindow {
+		return 0, fmt.Errorf("%w: %v", errWindow, dur)
+	}
+
+	return dur, nil
+}
+
+// toFloat returns the value of a numeric data point as a float64.
+func toFloat(point *common.DataPoint) (float64, error) {
+	switch v := point.GetValOneof().(type) {
+	case *common.DataPoint_IntVal:
+		return float64(v.IntVal), nil
+	case *common.DataPoint_Fl64Val:
+		return v.Fl64Val, nil
+	default:
+		return 0, fmt.Errorf("%w: %T", errNotNumber, point.GetValOneof())
+	}
+}
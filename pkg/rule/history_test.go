@@ -0,0 +1,196 @@
+//go:build !integration
+
+package rule
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEvalHistory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	point := &common.DataPoint{
+		Attr: "temp_c", ValOneof: &common.DataPoint_IntVal{IntVal: 30},
+		Ts: timestamppb.New(now),
+	}
+
+	hist := func(ago time.Duration, val int32) *common.DataPoint {
+		return &common.DataPoint{
+			Attr: "temp_c", ValOneof: &common.DataPoint_IntVal{IntVal: val},
+			Ts: timestamppb.New(now.Add(-ago)),
+		}
+	}
+
+	// History is out of order, and includes a redelivery of the data point, a
+	// non-numeric value, and a data point that arrived after it.
+	env := &Env{History: []*common.DataPoint{
+		hist(10*time.Minute, 10),
+		hist(2*time.Minute, 24),
+		hist(4*time.Minute, 18),
+		hist(0, 30),
+		{
+			Attr: "temp_c", ValOneof: &common.DataPoint_StrVal{StrVal: "err"},
+			Ts: timestamppb.New(now.Add(-time.Minute)),
+		},
+		hist(-time.Minute, 99),
+	}}
+
+	tests := []struct {
+		inpEnv      *Env
+		inpRuleExpr string
+		res         bool
+		err         string
+	}{
+		{env, `avg("5m") == 24`, true, ""},
+		{env, `avg(duration("15m")) == 20.5`, true, ""},
+		{env, `minOver("5m") == 18 && maxOver("5m") == 30`, true, ""},
+		{env, `minOver("15m") == 10`, true, ""},
+		{env, `countOver("5m") == 4`, true, ""},
+		{env, `countOver("30s") == 1`, true, ""},
+		{env, `delta() == 6`, true, ""},
+		{env, `rate() == 3`, true, ""},
+		{nil, `avg("5m") == pointVal && delta() == 0 && rate() == 0`, true, ""},
+		{nil, `countOver("1h") == 1`, true, ""},
+		{env, `min(pointVal, 10) == 10 && max(1, 2) == 2`, true, ""},
+		{env, `avg("25h") > 0`, false, "window must be"},
+		{env, `avg("-5m") > 0`, false, "window must be"},
+		{env, `avg("aaa") > 0`, false, "window must be"},
+		{env, `avg(5) > 0`, false, "window must be"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can evaluate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Eval(point, test.inpEnv, test.inpRuleExpr)
+			t.Logf("res, err: %v, %#v", res, err)
+			require.Equal(t, test.res, res)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestEvalHistoryTruncated(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	point := &common.DataPoint{
+		Attr: "temp_c", ValOneof: &common.DataPoint_IntVal{IntVal: 30},
+		Ts: timestamppb.New(now),
+	}
+
+	// Full history of one data point per second may have been truncated
+	// beyond its oldest data point.
+	full := make([]*common.DataPoint, 0, MaxHistory)
+	for i := range MaxHistory {
+		full = append(full, &common.DataPoint{
+			Attr: "temp_c", ValOneof: &common.DataPoint_IntVal{IntVal: 10},
+			Ts: timestamppb.New(now.Add(-time.Duration(MaxHistory-i) *
+				time.Second)),
+		})
+	}
+
+	tests := []struct {
+		inpHist     []*common.DataPoint
+		inpRuleExpr string
+		res         bool
+		err         string
+	}{
+		{full, `countOver("10m") == 600`, true, ""},
+		{full, `avg("10m") < 11 && delta() == 20`, true, ""},
+		{full[1:], `countOver("1h") == 1440`, true, ""},
+		{full, `countOver("1h") > 0`, false, "window exceeds retained"},
+		{full, `avg("24h") > 0`, false, "window exceeds retained"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can evaluate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Eval(point, &Env{History: test.inpHist},
+				test.inpRuleExpr)
+			t.Logf("res, err: %v, %#v", res, err)
+			require.Equal(t, test.res, res)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestEvalHistoryNotNumber(t *testing.T) {
+	t.Parallel()
+
+	point := &common.DataPoint{
+		Attr: "door", ValOneof: &common.DataPoint_BoolVal{BoolVal: true},
+		Ts: timestamppb.Now(),
+	}
+
+	tests := []struct {
+		inpRuleExpr string
+		res         bool
+		err         string
+	}{
+		{`countOver("5m") == 1`, true, ""},
+		{`avg("5m") > 0`, false, "not a number"},
+		{`maxOver("5m") > 0`, false, "not a number"},
+		{`delta() > 0`, false, "not a number"},
+		{`rate() > 0`, false, "not a number"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can evaluate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Eval(point, nil, test.inpRuleExpr)
+			t.Logf("res, err: %v, %#v", res, err)
+			require.Equal(t, test.res, res)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestUsesHistory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpRuleExprs []string
+		res          bool
+	}{
+		{nil, false},
+		{[]string{`pointVal > 8`}, false},
+		{[]string{`max(pointVal, 2) > 8`}, false},
+		{[]string{`pointVal > 8`, `avg("5m") > 8`}, true},
+		{[]string{`pointVal > 8 && rate() > 1`}, true},
+		{[]string{`1 +`, `countOver("1h") > 3`}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can parse %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			res := UsesHistory(test.inpRuleExprs...)
+			t.Logf("res: %v", res)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -2,9 +2,12 @@
 package rule
 
 import (
+	"slices"
 	"time"
 
 	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
 	"github.com/thingspect/atlas/pkg/consterr"
 	"github.com/thingspect/proto/go/common"
 )
@@ -18,10 +21,31 @@ const (
 	ExprFalse = `false`
 )
 
+// Env holds the values available to a rule expression in addition to the data
+// point being evaluated. Any value may be nil.
+type Env struct {
+	// Latest holds the latest data points of the device, keyed by attribute.
+	Latest map[string]*common.DataPoint
+	// History holds prior data points of the evaluated data point's device and
+	// attribute, in any order.
+	History []*common.DataPoint
+}
+
 // Eval evaluates a boolean expression using the Expr language:
 // https://expr-lang.org/docs/language-definition
-func Eval(point *common.DataPoint, ruleExpr string) (bool, error) {
-	env := map[string]any{
+//
+// The latest values of the device's other attributes are available as
+// 'latest', keyed by attribute, of which each element contains 'val' and 'ts'.
+// The data point being evaluated is always included as the latest value of its
+// attribute. Attributes without a known value are absent, and can be guarded
+// against using optional chaining, such as 'latest.door_open?.val == true'.
+//
+// History functions operate on the data point and the prior history of its
+// attribute: 'avg', 'minOver', 'maxOver', and 'countOver' accept a window as a
+// duration string, such as 'avg("5m") > 30', while 'delta' and 'rate' compare
+// against the previous numeric data point, with rate expressed per minute.
+func Eval(point *common.DataPoint, env *Env, ruleExpr string) (bool, error) {
+	vals := map[string]any{
 		"point":   point,
 		"pointTS": point.GetTs().GetSeconds(),
 		"currTS":  time.Now().Unix(),
@@ -29,18 +53,30 @@ func Eval(point *common.DataPoint, ruleExpr string) (bool, error) {
 
 	// Populate point value for convenience. []byte is not supported. If point
 	// doesn't validate, pointVal remains unset.
-	switch v := point.GetValOneof().(type) {
-	case *common.DataPoint_IntVal:
-		env["pointVal"] = v.IntVal
-	case *common.DataPoint_Fl64Val:
-		env["pointVal"] = v.Fl64Val
-	case *common.DataPoint_StrVal:
-		env["pointVal"] = v.StrVal
-	case *common.DataPoint_BoolVal:
-		env["pointVal"] = v.BoolVal
+	if v := pointVal(point); v != nil {
+		vals["pointVal"] = v
+	}
+
+	latest := make(map[string]any, len(env.latest())+1)
+	for attr, lPoint := range env.latest() {
+		latest[attr] = map[string]any{
+			"val": pointVal(lPoint),
+			"ts":  lPoint.GetTs().GetSeconds(),
+		}
+	}
+	if point.GetAttr() != "" {
+		latest[point.GetAttr()] = map[string]any{
+			"val": vals["pointVal"],
+			"ts":  vals["pointTS"],
+		}
+	}
+	vals["latest"] = latest
+
+	for name, fn := range historyFuncs(point, env.history()) {
+		vals[name] = fn
 	}
 
-	res, err := expr.Eval(ruleExpr, env)
+	res, err := expr.Eval(ruleExpr, vals)
 	if err != nil {
 		return false, err
 	}
@@ -52,3 +88,84 @@ func Eval(point *common.DataPoint, ruleExpr string) (bool, error) {
 
 	return b, nil
 }
+
+// LatestAttrs returns the sorted attributes that rule expressions reference by
+// name in 'latest', such as 'latest.temp' or 'latest["temp"]'. Attributes that
+// are referenced dynamically are not returned. Expressions that do not parse
+// are ignored, as they fail evaluation.
+func LatestAttrs(ruleExprs ...string) []string {
+	v := &latestVisitor{attrs: map[string]struct{}{}}
+	for _, ruleExpr := range ruleExprs {
+		tree, err := parser.Parse(ruleExpr)
+		if err != nil {
+			continue
+		}
+
+		ast.Walk(&tree.Node, v)
+	}
+
+	attrs := make([]string, 0, len(v.attrs))
+	for attr := range v.attrs {
+		attrs = append(attrs, attr)
+	}
+	slices.Sort(attrs)
+
+	return attrs
+}
+
+// latestVisitor collects the attributes of member access on 'latest'.
+type latestVisitor struct {
+	attrs map[string]struct{}
+}
+
+// Visit implements ast.Visitor.
+func (v *latestVisitor) Visit(node *ast.Node) {
+	member, ok := (*node).(*ast.MemberNode)
+	if !ok {
+		return
+	}
+
+	if ident, ok := member.Node.(*ast.IdentifierNode); !ok ||
+		ident.Value != "latest" {
+		return
+	}
+
+	if prop, ok := member.Property.(*ast.StringNode); ok {
+		v.attrs[prop.Value] = struct{}{}
+	}
+}
+
+// latest returns the latest data points of an environment, which may be nil.
+func (e *Env) latest() map[string]*common.DataPoint {
+	if e == nil {
+		return nil
+	}
+
+	return e.Latest
+}
+
+// history returns the history of an environment, which may be nil.
+func (e *Env) history() []*common.DataPoint {
+	if e == nil {
+		return nil
+	}
+
+	return e.History
+}
+
+// pointVal returns the value of a point, or nil if it is not set or is of an
+// unsupported type.
+func pointVal(point *common.DataPoint) any {
+	switch v := point.GetValOneof().(type) {
+	case *common.DataPoint_IntVal:
+		return v.IntVal
+	case *common.DataPoint_Fl64Val:
+		return v.Fl64Val
+	case *common.DataPoint_StrVal:
+		return v.StrVal
+	case *common.DataPoint_BoolVal:
+		return v.BoolVal
+	default:
+		return nil
+	}
+}
@@ -65,7 +65,7 @@ func TestEval(t *testing.T) {
 
 			test.inpPoint.Ts = timestamppb.New(time.Now().Add(-time.Second))
 
-			res, err := Eval(test.inpPoint, test.inpRuleExpr)
+			res, err := Eval(test.inpPoint, nil, test.inpRuleExpr)
 			t.Logf("res, err: %v, %#v", res, err)
 			require.Equal(t, test.res, res)
 			if test.err == "" {
@@ -76,3 +76,90 @@ func TestEval(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalLatest(t *testing.T) {
+	t.Parallel()
+
+	point := &common.DataPoint{
+		Attr: "temp_c", ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 9.5},
+		Ts: timestamppb.Now(),
+	}
+
+	env := &Env{Latest: map[string]*common.DataPoint{
+		"door_open": {
+			Attr:     "door_open",
+			ValOneof: &common.DataPoint_BoolVal{BoolVal: true},
+			Ts:       timestamppb.New(time.Unix(1700000000, 0)),
+		},
+		"temp_c": {
+			Attr:     "temp_c",
+			ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 2},
+		},
+	}}
+
+	tests := []struct {
+		inpEnv      *Env
+		inpRuleExpr string
+		res         bool
+		err         string
+	}{
+		{env, `pointVal > 8 && latest.door_open.val == true`, true, ""},
+		{env, `latest["door_open"].ts == 1700000000`, true, ""},
+		{env, `latest.temp_c.val == pointVal`, true, ""},
+		{env, `latest.power?.val == true`, false, ""},
+		{env, `"power" in latest`, false, ""},
+		{nil, `latest.temp_c.val > 8`, true, ""},
+		{nil, `latest.door_open?.val == true`, false, ""},
+		{nil, `latest.door_open.val == true`, false, "cannot fetch val"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can evaluate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Eval(point, test.inpEnv, test.inpRuleExpr)
+			t.Logf("res, err: %v, %#v", res, err)
+			require.Equal(t, test.res, res)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestLatestAttrs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpRuleExprs []string
+		res          []string
+	}{
+		{nil, []string{}},
+		{[]string{`pointVal > 8`}, []string{}},
+		{
+			[]string{`pointVal > 8 && latest.door_open?.val == true`},
+			[]string{"door_open"},
+		},
+		{
+			[]string{
+				`latest["humidity"].val > 50 || latest.temp.val < 0`,
+				`latest.temp.ts > currTS - 60`,
+			},
+			[]string{"humidity", "temp"},
+		},
+		{[]string{`latest[point.Attr].val > 0`}, []string{}},
+		{[]string{`1 +`, `latest.temp.val > 0`}, []string{"temp"}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can parse %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			res := LatestAttrs(test.inpRuleExprs...)
+			t.Logf("res: %#v", res)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
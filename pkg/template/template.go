@@ -1,21 +1,191 @@
-// Package template provides functions to generate HTML-safe output from
-// templates.
+// Package template provides functions to generate output from templates. Output
+// is HTML-safe by default, with a plain text mode for channels that do not
+// render HTML.
 package template
 
 import (
-	"html/template"
+	"fmt"
+	htmltemplate "html/template"
+	"math"
+	"slices"
 	"strings"
+	texttemplate "text/template"
+	"text/template/parse"
+	"time"
 
+	"github.com/thingspect/atlas/pkg/consterr"
+	"github.com/thingspect/atlas/pkg/decode"
 	"github.com/thingspect/proto/go/api"
 	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-// Generate generates HTML-safe output from templates using the Go template
-// engine: https://golang.org/pkg/html/template/
-func Generate(
-	point *common.DataPoint, rule *api.Rule, dev *api.Device, templ string,
+// Errors returned by helper functions when they receive a value of the wrong
+// type.
+const (
+	errNotNumber consterr.Error = "not a number"
+	errNotTime   consterr.Error = "not a time"
+)
+
+// Mode is the output mode of a template.
+type Mode int
+
+// Template modes.
+const (
+	// ModeHTML generates HTML-safe output: https://pkg.go.dev/html/template
+	ModeHTML Mode = iota
+	// ModeText generates plain text output, without escaping:
+	// https://pkg.go.dev/text/template
+	ModeText
+)
+
+// ModeFor returns the template mode of an alarm type. SMS and push
+// notifications are not rendered as HTML, so they use plain text.
+func ModeFor(alarmType api.AlarmType) Mode {
+	switch alarmType {
+	case api.AlarmType_SMS, api.AlarmType_APP:
+		return ModeText
+	default:
+		return ModeHTML
+	}
+}
+
+// Env holds the values available to a template. Any value may be nil.
+type Env struct {
+	Point  *common.DataPoint
+	Rule   *api.Rule
+	Device *api.Device
+	User   *api.User
+	Org    *api.Org
+	Alarm  *api.Alarm
+
+	// Latest holds the latest data points of the device, keyed by attribute.
+	Latest map[string]*common.DataPoint
+	// Timezone is the IANA time zone used to format times. If empty, UTC is
+	// used.
+	Timezone string
+}
+
+// Generate generates output from a template using the Go template engine. The
+// template environment contains 'point', 'pointVal', 'rule', 'device', 'user',
+// 'org', 'alarm', and 'timezone', along with the helper functions 'formatTime',
+// 'round', 'cToF', and 'latest'.
+func Generate(env *Env, mode Mode, templ string) (string, error) {
+	if env == nil {
+		env = &Env{}
+	}
+
+	vals := pointVals(env.Point, env.Rule, env.Device)
+	env.addRecipient(vals)
+
+	return execute(vals, env, mode, templ)
+}
+
+// DigestEvent holds the values of a single event within a digest.
+type DigestEvent struct {
+	Point   *common.DataPoint
+	Rule    *api.Rule
+	Device  *api.Device
+	Cleared bool
+}
+
+// GenerateDigest generates output from a template that summarizes multiple
+// events of an alarm. The template environment contains 'alarm', 'user',
+// 'org', 'timezone', 'count', and 'events', of which each element contains
+// 'point', 'pointVal', 'rule', 'device', and 'cleared'. The point values of
+// env are not used.
+func GenerateDigest(
+	env *Env, events []*DigestEvent, mode Mode, templ string,
 ) (string, error) {
-	env := map[string]any{
+	if env == nil {
+		env = &Env{}
+	}
+
+	evals := make([]map[string]any, 0, len(events))
+	for _, event := range events {
+		eval := pointVals(event.Point, event.Rule, event.Device)
+		eval["cleared"] = event.Cleared
+		evals = append(evals, eval)
+	}
+
+	vals := map[string]any{
+		"count":  len(events),
+		"events": evals,
+	}
+	env.addRecipient(vals)
+
+	return execute(vals, env, mode, templ)
+}
+
+// Validate returns an error if a template can not be parsed. Templates are
+// parsed as HTML, which is the stricter of the modes.
+func Validate(templ string) error {
+	_, err := htmltemplate.New("template").Funcs(funcs(&Env{})).Parse(templ)
+
+	return err
+}
+
+// UsesLatest returns whether any template calls the 'latest' helper function.
+// Templates that do not parse are ignored, as they fail generation.
+func UsesLatest(templs ...string) bool {
+	for _, templ := range templs {
+		t, err := texttemplate.New("template").Funcs(funcs(&Env{})).Parse(templ)
+		if err != nil {
+			continue
+		}
+
+		for _, tt := range t.Templates() {
+			if tt.Tree != nil && callsLatest(tt.Root) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// callsLatest returns whether a template node, or any of its children, calls
+// the 'latest' helper function.
+func callsLatest(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.IdentifierNode:
+		return n.Ident == "latest"
+	case *parse.ListNode:
+		return n != nil && slices.ContainsFunc(n.Nodes, callsLatest)
+	case *parse.ActionNode:
+		return callsLatest(n.Pipe)
+	case *parse.PipeNode:
+		return n != nil && slices.ContainsFunc(n.Cmds,
+			func(cmd *parse.CommandNode) bool { return callsLatest(cmd) })
+	case *parse.CommandNode:
+		return slices.ContainsFunc(n.Args, callsLatest)
+	case *parse.ChainNode:
+		return callsLatest(n.Node)
+	case *parse.IfNode:
+		return callsBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		return callsBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return callsBranch(&n.BranchNode)
+	case *parse.TemplateNode:
+		return callsLatest(n.Pipe)
+	default:
+		return false
+	}
+}
+
+// callsBranch returns whether the pipeline or lists of a branch call the
+// 'latest' helper function.
+func callsBranch(n *parse.BranchNode) bool {
+	return callsLatest(n.Pipe) || callsLatest(n.List) ||
+		callsLatest(n.ElseList)
+}
+
+// pointVals builds template values from a point, rule, and device.
+func pointVals(
+	point *common.DataPoint, rule *api.Rule, dev *api.Device,
+) map[string]any {
+	vals := map[string]any{
 		"point":  point,
 		"rule":   rule,
 		"device": dev,
@@ -23,29 +193,152 @@ func Generate(
 
 	// Populate point value for convenience. If point doesn't validate, pointVal
 	// remains unset.
+	if v := pointVal(point); v != nil {
+		vals["pointVal"] = v
+	}
+
+	return vals
+}
+
+// pointVal returns the value of a point, or nil if it is not set.
+func pointVal(point *common.DataPoint) any {
 	switch v := point.GetValOneof().(type) {
 	case *common.DataPoint_IntVal:
-		env["pointVal"] = v.IntVal
+		return v.IntVal
 	case *common.DataPoint_Fl64Val:
-		env["pointVal"] = v.Fl64Val
+		return v.Fl64Val
 	case *common.DataPoint_StrVal:
-		env["pointVal"] = v.StrVal
+		return v.StrVal
 	case *common.DataPoint_BoolVal:
-		env["pointVal"] = v.BoolVal
+		return v.BoolVal
 	case *common.DataPoint_BytesVal:
-		env["pointVal"] = v.BytesVal
+		return v.BytesVal
+	default:
+		return nil
 	}
+}
 
-	t, err := template.New("template").Parse(templ)
-	if err != nil {
-		return "", err
+// addRecipient adds the recipient and alarm values of an environment to
+// template values.
+func (e *Env) addRecipient(vals map[string]any) {
+	vals["user"] = e.User
+	vals["org"] = e.Org
+	vals["alarm"] = e.Alarm
+	vals["timezone"] = e.location().String()
+}
+
+// location returns the time zone of an environment, defaulting to UTC if it is
+// unset or unknown.
+func (e *Env) location() *time.Location {
+	if loc, err := time.LoadLocation(e.Timezone); err == nil {
+		return loc
 	}
 
+	return time.UTC
+}
+
+// funcs returns the helper functions of an environment.
+func funcs(env *Env) map[string]any {
+	return map[string]any{
+		// formatTime formats a timestamp using a Go layout in the time zone of
+		// the environment, or in an optional IANA time zone.
+		"formatTime": func(
+			ts any, layout string, tz ...string,
+		) (string, error) {
+			var t time.Time
+			switch v := ts.(type) {
+			case *timestamppb.Timestamp:
+				t = v.AsTime()
+			case time.Time:
+				t = v
+			default:
+				return "", fmt.Errorf("%w: %T", errNotTime, ts)
+			}
+
+			loc := env.location()
+			if len(tz) > 0 {
+				var err error
+				if loc, err = time.LoadLocation(tz[0]); err != nil {
+					return "", err
+				}
+			}
+
+			return t.In(loc).Format(layout), nil
+		},
+		// round rounds a number to a count of decimal places.
+		"round": func(val any, places int) (float64, error) {
+			f, err := toFloat(val)
+			if err != nil {
+				return 0, err
+			}
+
+			pow := math.Pow(10, float64(places))
+
+			return math.Round(f*pow) / pow, nil
+		},
+		// cToF converts a temperature in Celsius to Fahrenheit.
+		"cToF": func(val any) (float64, error) {
+			f, err := toFloat(val)
+			if err != nil {
+				return 0, err
+			}
+
+			return decode.CToF(f), nil
+		},
+		// latest returns the latest value of another attribute of the device,
+		// or nil if it is not known.
+		"latest": func(attr string) any {
+			return pointVal(env.Latest[attr])
+		},
+	}
+}
+
+// toFloat converts a numeric template value to a float64.
+func toFloat(val any) (float64, error) {
+	switch v := val.(type) {
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("%w: %T", errNotNumber, val)
+	}
+}
+
+// execute parses and executes a template against template values, using the
+// helper functions of an environment.
+func execute(
+	vals map[string]any, env *Env, mode Mode, templ string,
+) (string, error) {
 	res := &strings.Builder{}
-	err = t.Execute(res, env)
+
+	if mode == ModeText {
+		t, err := texttemplate.New("template").Funcs(funcs(env)).Parse(templ)
+		if err != nil {
+			return "", err
+		}
+
+		if err := t.Execute(res, vals); err != nil {
+			return "", err
+		}
+
+		return res.String(), nil
+	}
+
+	t, err := htmltemplate.New("template").Funcs(funcs(env)).Parse(templ)
 	if err != nil {
 		return "", err
 	}
 
+	if err := t.Execute(res, vals); err != nil {
+		return "", err
+	}
+
 	return res.String(), nil
 }
@@ -5,11 +5,13 @@ package template
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/thingspect/atlas/pkg/test/random"
 	"github.com/thingspect/proto/go/api"
 	"github.com/thingspect/proto/go/common"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestGenerate(t *testing.T) {
@@ -71,8 +73,9 @@ func TestGenerate(t *testing.T) {
 		t.Run(fmt.Sprintf("Can generate %+v", test), func(t *testing.T) {
 			t.Parallel()
 
-			res, err := Generate(test.inpPoint, test.inpRule, test.inpDev,
-				test.inpTempl)
+			res, err := Generate(&Env{
+				Point: test.inpPoint, Rule: test.inpRule, Device: test.inpDev,
+			}, ModeHTML, test.inpTempl)
 			t.Logf("res, err: %v, %#v", res, err)
 			require.Equal(t, test.res, res)
 			if test.err == "" {
@@ -83,3 +86,214 @@ func TestGenerate(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateDigest(t *testing.T) {
+	t.Parallel()
+
+	events := []*DigestEvent{
+		{
+			Point: &common.DataPoint{ValOneof: &common.DataPoint_IntVal{
+				IntVal: 40,
+			}},
+			Device: &api.Device{UniqId: "dev-1"},
+		},
+		{
+			Point: &common.DataPoint{ValOneof: &common.DataPoint_Fl64Val{
+				Fl64Val: 37.7,
+			}},
+			Device: &api.Device{UniqId: "dev-2"}, Cleared: true,
+		},
+	}
+
+	tests := []struct {
+		inpAlarm  *api.Alarm
+		inpEvents []*DigestEvent
+		inpTempl  string
+		res       string
+		err       string
+	}{
+		{nil, nil, `test`, "test", ""},
+		{
+			&api.Alarm{Name: "test alarm"}, events, `{{.alarm.Name}}: ` +
+				`{{.count}} events`, "test alarm: 2 events", "",
+		},
+		{
+			nil, events, `{{range .events}}{{.device.UniqId}}={{.pointVal}}` +
+				`{{if .cleared}} (cleared){{end}};{{end}}`,
+			"dev-1=40;dev-2=37.7 (cleared);", "",
+		},
+		{nil, events, `{{if`, "", "unclosed action"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can generate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			res, err := GenerateDigest(&Env{Alarm: test.inpAlarm},
+				test.inpEvents, ModeHTML, test.inpTempl)
+			t.Logf("res, err: %v, %#v", res, err)
+			require.Equal(t, test.res, res)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Contains(t, err.Error(), test.err)
+			}
+		})
+	}
+}
+
+func TestGenerateEnv(t *testing.T) {
+	t.Parallel()
+
+	env := &Env{
+		Point: &common.DataPoint{
+			Attr: "temp", ValOneof: &common.DataPoint_Fl64Val{Fl64Val: 21.456},
+			Ts: timestamppb.New(time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)),
+		},
+		User:  &api.User{Name: "Test User"},
+		Org:   &api.Org{Name: "Test Org"},
+		Alarm: &api.Alarm{Name: "Test Alarm"},
+		Latest: map[string]*common.DataPoint{
+			"humidity": {ValOneof: &common.DataPoint_IntVal{IntVal: 45}},
+		},
+		Timezone: "America/New_York",
+	}
+
+	tests := []struct {
+		inpEnv   *Env
+		inpMode  Mode
+		inpTempl string
+		res      string
+		err      string
+	}{
+		{nil, ModeHTML, `{{.timezone}}`, "UTC", ""},
+		{
+			env, ModeHTML, `{{.user.Name}}, {{.org.Name}}: {{.alarm.Name}}`,
+			"Test User, Test Org: Test Alarm", "",
+		},
+		{
+			env, ModeHTML, `{{formatTime .point.Ts "2006-01-02 15:04 MST"}}`,
+			"2026-01-02 10:04 EST", "",
+		},
+		{
+			env, ModeHTML, `{{formatTime .point.Ts "15:04" "Asia/Tokyo"}}`,
+			"00:04", "",
+		},
+		{env, ModeHTML, `{{round .pointVal 1}}`, "21.5", ""},
+		{env, ModeHTML, `{{cToF .pointVal}}`, "70.6", ""},
+		{
+			env, ModeHTML, `{{latest "humidity"}}%{{with latest "co2"}}` +
+				`{{.}}{{end}}`, "45%", "",
+		},
+		{
+			&Env{User: &api.User{Name: "<b>"}}, ModeHTML, `{{.user.Name}}`,
+			"&lt;b&gt;", "",
+		},
+		{
+			&Env{User: &api.User{Name: "<b>"}}, ModeText, `{{.user.Name}}`,
+			"<b>", "",
+		},
+		{env, ModeText, `{{round .pointVal 0}}`, "21", ""},
+		{env, ModeHTML, `{{round "a" 1}}`, "", "not a number"},
+		{env, ModeText, `{{formatTime .pointVal "15:04"}}`, "", "not a time"},
+		{
+			env, ModeHTML, `{{formatTime .point.Ts "15:04" "Not/AZone"}}`, "",
+			"unknown time zone",
+		},
+		{env, ModeText, `{{if`, "", "unclosed action"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can generate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Generate(test.inpEnv, test.inpMode, test.inpTempl)
+			t.Logf("res, err: %v, %#v", res, err)
+			require.Equal(t, test.res, res)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestModeFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inp api.AlarmType
+		res Mode
+	}{
+		{api.AlarmType_APP, ModeText},
+		{api.AlarmType_SMS, ModeText},
+		{api.AlarmType_EMAIL, ModeHTML},
+		{api.AlarmType_ALARM_TYPE_UNSPECIFIED, ModeHTML},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can map %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.res, ModeFor(test.inp))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpTempl string
+		err      string
+	}{
+		{`test`, ""},
+		{`{{range .events}}{{.pointVal}}{{end}}`, ""},
+		{`{{if`, "unclosed action"},
+		{`{{end}}`, "unexpected {{end}}"},
+		{`{{round (cToF .pointVal) 1}} {{latest "temp"}}`, ""},
+		{`{{unknown .pointVal}}`, "function \"unknown\" not defined"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can validate %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			err := Validate(test.inpTempl)
+			t.Logf("err: %#v", err)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestUsesLatest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inpTempls []string
+		res       bool
+	}{
+		{[]string{`{{latest "temp"}}`}, true},
+		{[]string{`test`, `{{round (latest "temp") 1}}`}, true},
+		{[]string{`{{if .pointVal}}{{else}}{{latest "temp"}}{{end}}`}, true},
+		{[]string{`{{range .events}}{{latest "temp" | print}}{{end}}`}, true},
+		{[]string{`{{define "a"}}{{latest "temp"}}{{end}}`}, true},
+		{[]string{`the latest value is {{.pointVal}}`}, false},
+		{[]string{`{{.latest}} {{"latest"}}`}, false},
+		{[]string{`{{if`, `{{latest`}, false},
+		{nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Can check %+v", test), func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.res, UsesLatest(test.inpTempls...))
+		})
+	}
+}
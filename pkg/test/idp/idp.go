@@ -0,0 +1,242 @@
+// Package idp provides a mock OpenID Connect identity provider for tests.
+package idp
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"maps"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/thingspect/atlas/pkg/test/random"
+)
+
+// keyID is the key ID of the IdP's signing key.
+const keyID = "idp-test"
+
+// authCode holds the parameters of an issued authorization code.
+type authCode struct {
+	nonce       string
+	challenge   string
+	redirectURI string
+	claims      map[string]any
+}
+
+// IdP represents a mock identity provider that supports discovery, the
+// authorization code flow with PKCE, and RS256 ID tokens.
+type IdP struct {
+	*httptest.Server
+
+	ClientID     string
+	ClientSecret string
+
+	key *rsa.PrivateKey
+
+	claimsMu sync.Mutex
+	claims   map[string]any
+
+	codesMu sync.Mutex
+	codes   map[string]*authCode
+}
+
+// New builds and starts a new IdP and returns it. Close should be called when
+// the IdP is no longer needed.
+func New() (*IdP, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	idp := &IdP{
+		ClientID:     "idp-" + random.String(10),
+		ClientSecret: random.String(20),
+
+		key: key,
+
+		claims: map[string]any{},
+		codes:  map[string]*authCode{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("GET /authorize", idp.authorize)
+	mux.HandleFunc("POST /token", idp.token)
+	mux.HandleFunc("GET /jwks", idp.jwks)
+	idp.Server = httptest.NewServer(mux)
+
+	return idp, nil
+}
+
+// SetClaims sets the identity claims, such as 'sub' and 'email', included in
+// ID tokens for subsequent authorizations.
+func (i *IdP) SetClaims(claims map[string]any) {
+	i.claimsMu.Lock()
+	defer i.claimsMu.Unlock()
+
+	i.claims = maps.Clone(claims)
+}
+
+// SignToken signs a set of claims as an RS256 ID token.
+func (i *IdP) SignToken(claims map[string]any) (string, error) {
+	bHead, err := json.Marshal(map[string]string{
+		"alg": "RS256", "kid": keyID, "typ": "JWT",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	bClaims, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	input := base64.RawURLEncoding.EncodeToString(bHead) + "." +
+		base64.RawURLEncoding.EncodeToString(bClaims)
+	hash := sha256.Sum256([]byte(input))
+
+	sig, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// discovery serves the provider configuration.
+func (i *IdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 i.URL,
+		"authorization_endpoint": i.URL + "/authorize",
+		"token_endpoint":         i.URL + "/token",
+		"jwks_uri":               i.URL + "/jwks",
+	})
+}
+
+// authorize authenticates a user without interaction and redirects with an
+// authorization code.
+func (i *IdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != i.ClientID ||
+		query.Get("response_type") != "code" ||
+		query.Get("code_challenge_method") != "S256" {
+		writeJSON(w, http.StatusBadRequest,
+			map[string]string{"error": "invalid_request"})
+
+		return
+	}
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || !redirectURI.IsAbs() {
+		writeJSON(w, http.StatusBadRequest,
+			map[string]string{"error": "invalid_request"})
+
+		return
+	}
+
+	i.claimsMu.Lock()
+	claims := maps.Clone(i.claims)
+	i.claimsMu.Unlock()
+
+	code := random.String(20)
+	i.codesMu.Lock()
+	i.codes[code] = &authCode{
+		nonce:       query.Get("nonce"),
+		challenge:   query.Get("code_challenge"),
+		redirectURI: redirectURI.String(),
+		claims:      claims,
+	}
+	i.codesMu.Unlock()
+
+	redirQuery := redirectURI.Query()
+	redirQuery.Set("code", code)
+	redirQuery.Set("state", query.Get("state"))
+	redirectURI.RawQuery = redirQuery.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+// token exchanges an authorization code for an ID token. Each code may be
+// used once.
+func (i *IdP) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != i.ClientID || subtle.ConstantTimeCompare(
+		[]byte(clientSecret), []byte(i.ClientSecret)) != 1 {
+		writeJSON(w, http.StatusUnauthorized,
+			map[string]string{"error": "invalid_client"})
+
+		return
+	}
+
+	i.codesMu.Lock()
+	code, ok := i.codes[r.PostFormValue("code")]
+	delete(i.codes, r.PostFormValue("code"))
+	i.codesMu.Unlock()
+
+	verifierHash := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if !ok || r.PostFormValue("grant_type") != "authorization_code" ||
+		r.PostFormValue("redirect_uri") != code.redirectURI ||
+		base64.RawURLEncoding.EncodeToString(verifierHash[:]) !=
+			code.challenge {
+		writeJSON(w, http.StatusBadRequest,
+			map[string]string{"error": "invalid_grant"})
+
+		return
+	}
+
+	claims := map[string]any{
+		"iss":   i.URL,
+		"aud":   i.ClientID,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": code.nonce,
+	}
+	maps.Copy(claims, code.claims)
+
+	idToken, err := i.SignToken(claims)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError,
+			map[string]string{"error": "server_error"})
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": random.String(20),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+// jwks serves the public signing key.
+func (i *IdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n": base64.RawURLEncoding.EncodeToString(
+				i.key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(
+				big.NewInt(int64(i.key.E)).Bytes()),
+		}},
+	})
+}
+
+// writeJSON writes a JSON response with the provided status code.
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	//nolint:errchkjson // Test responses are always encodable.
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,24 @@
+package random
+
+import (
+	"time"
+	"uuid"
+
+	"github.com/thingspect/atlas/proto/go/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Command generates a random queued command with prefixed identifiers.
+func Command(prefix, orgID string) *message.Command {
+	return &message.Command{
+		OrgId:   orgID,
+		UniqId:  prefix + "-" + String(16),
+		Payload: Bytes(10),
+		//nolint:gosec // Safe conversion for limited values.
+		FPort:  []uint32{0, uint32(Intn(223) + 1)}[Intn(2)],
+		Status: message.CommandStatus_QUEUED,
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour).UTC().
+			Truncate(time.Microsecond)),
+		TraceId: uuid.NewV7().String(),
+	}
+}
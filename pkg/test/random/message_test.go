@@ -0,0 +1,34 @@
+//go:build !integration
+
+package random
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"uuid"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+
+	for i := range 5 {
+		t.Run(fmt.Sprintf("Can generate %v", i), func(t *testing.T) {
+			t.Parallel()
+
+			prefix := String(10)
+			orgID := uuid.NewV7().String()
+
+			c1 := Command(prefix, orgID)
+			c2 := Command(prefix, orgID)
+			t.Logf("c1, c2: %+v, %+v", c1, c2)
+
+			require.NotEqual(t, c1, c2)
+			require.True(t, strings.HasPrefix(c1.GetUniqId(), prefix))
+			require.True(t, strings.HasPrefix(c2.GetUniqId(), prefix))
+			require.LessOrEqual(t, c1.GetFPort(), uint32(223))
+		})
+	}
+}
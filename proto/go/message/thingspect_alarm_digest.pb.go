@@ -0,0 +1,362 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_alarm_digest.proto
+
+package message
+
+import (
+	api "github.com/thingspect/proto/go/api"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AlarmDigest represents the digest settings of an alarm. An alarm with a
+// digest collects alerts per user over a window, and sends them as a single
+// notification.
+type AlarmDigest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Alarm ID (UUID).
+	AlarmId string `protobuf:"bytes,1,opt,name=alarm_id,json=alarmId,proto3" json:"alarm_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Rule ID (UUID) of the alarm.
+	RuleId string `protobuf:"bytes,3,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// Window, in minutes, over which alerts are collected.
+	WindowMinutes int32 `protobuf:"varint,4,opt,name=window_minutes,json=windowMinutes,proto3" json:"window_minutes,omitempty"`
+	// Digest subject template. If empty, a default template is used.
+	SubjectTemplate string `protobuf:"bytes,5,opt,name=subject_template,json=subjectTemplate,proto3" json:"subject_template,omitempty"`
+	// Digest body template. If empty, a default template is used.
+	BodyTemplate string `protobuf:"bytes,6,opt,name=body_template,json=bodyTemplate,proto3" json:"body_template,omitempty"`
+	// Digest creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Digest modification timestamp.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlarmDigest) Reset() {
+	*x = AlarmDigest{}
+	mi := &file_message_thingspect_alarm_digest_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlarmDigest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlarmDigest) ProtoMessage() {}
+
+func (x *AlarmDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_alarm_digest_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlarmDigest.ProtoReflect.Descriptor instead.
+func (*AlarmDigest) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_alarm_digest_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AlarmDigest) GetAlarmId() string {
+	if x != nil {
+		return x.AlarmId
+	}
+	return ""
+}
+
+func (x *AlarmDigest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AlarmDigest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *AlarmDigest) GetWindowMinutes() int32 {
+	if x != nil {
+		return x.WindowMinutes
+	}
+	return 0
+}
+
+func (x *AlarmDigest) GetSubjectTemplate() string {
+	if x != nil {
+		return x.SubjectTemplate
+	}
+	return ""
+}
+
+func (x *AlarmDigest) GetBodyTemplate() string {
+	if x != nil {
+		return x.BodyTemplate
+	}
+	return ""
+}
+
+func (x *AlarmDigest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AlarmDigest) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// AlertDigest represents a digest of alerts sent to a user.
+type AlertDigest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Alert digest ID (UUID).
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Alarm ID (UUID).
+	AlarmId string `protobuf:"bytes,3,opt,name=alarm_id,json=alarmId,proto3" json:"alarm_id,omitempty"`
+	// Rule ID (UUID) of the alarm.
+	RuleId string `protobuf:"bytes,4,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// User ID (UUID).
+	UserId string `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Alarm type to notify by.
+	Type api.AlarmType `protobuf:"varint,6,opt,name=type,proto3,enum=thingspect.api.AlarmType" json:"type,omitempty"`
+	// Alert digest status. The status is unspecified until the digest is sent.
+	Status api.AlertStatus `protobuf:"varint,7,opt,name=status,proto3,enum=thingspect.api.AlertStatus" json:"status,omitempty"`
+	// Alert digest error, if any.
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	// Alert digest flush timestamp, at which the digest is sent.
+	FlushAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=flush_at,json=flushAt,proto3" json:"flush_at,omitempty"`
+	// Alert digest creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Alerts of the digest. Only populated when retrieving a single digest.
+	Alerts        []*api.Alert `protobuf:"bytes,11,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertDigest) Reset() {
+	*x = AlertDigest{}
+	mi := &file_message_thingspect_alarm_digest_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertDigest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertDigest) ProtoMessage() {}
+
+func (x *AlertDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_alarm_digest_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertDigest.ProtoReflect.Descriptor instead.
+func (*AlertDigest) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_alarm_digest_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AlertDigest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AlertDigest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AlertDigest) GetAlarmId() string {
+	if x != nil {
+		return x.AlarmId
+	}
+	return ""
+}
+
+func (x *AlertDigest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *AlertDigest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AlertDigest) GetType() api.AlarmType {
+	if x != nil {
+		return x.Type
+	}
+	return api.AlarmType(0)
+}
+
+func (x *AlertDigest) GetStatus() api.AlertStatus {
+	if x != nil {
+		return x.Status
+	}
+	return api.AlertStatus(0)
+}
+
+func (x *AlertDigest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AlertDigest) GetFlushAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FlushAt
+	}
+	return nil
+}
+
+func (x *AlertDigest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AlertDigest) GetAlerts() []*api.Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+var File_message_thingspect_alarm_digest_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_alarm_digest_proto_rawDesc = "" +
+	"\n" +
+	"%message/thingspect_alarm_digest.proto\x12\x16thingspect.int.message\x1a\x1aapi/thingspect_alert.proto\x1a\x1fapi/thingspect_rule_alarm.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc5\x02\n" +
+	"\vAlarmDigest\x12\x19\n" +
+	"\balarm_id\x18\x01 \x01(\tR\aalarmId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\arule_id\x18\x03 \x01(\tR\x06ruleId\x12%\n" +
+	"\x0ewindow_minutes\x18\x04 \x01(\x05R\rwindowMinutes\x12)\n" +
+	"\x10subject_template\x18\x05 \x01(\tR\x0fsubjectTemplate\x12#\n" +
+	"\rbody_template\x18\x06 \x01(\tR\fbodyTemplate\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x9c\x03\n" +
+	"\vAlertDigest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x19\n" +
+	"\balarm_id\x18\x03 \x01(\tR\aalarmId\x12\x17\n" +
+	"\arule_id\x18\x04 \x01(\tR\x06ruleId\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12-\n" +
+	"\x04type\x18\x06 \x01(\x0e2\x19.thingspect.api.AlarmTypeR\x04type\x123\n" +
+	"\x06status\x18\a \x01(\x0e2\x1b.thingspect.api.AlertStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x125\n" +
+	"\bflush_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\aflushAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12-\n" +
+	"\x06alerts\x18\v \x03(\v2\x15.thingspect.api.AlertR\x06alertsB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_alarm_digest_proto_rawDescOnce sync.Once
+	file_message_thingspect_alarm_digest_proto_rawDescData []byte
+)
+
+func file_message_thingspect_alarm_digest_proto_rawDescGZIP() []byte {
+	file_message_thingspect_alarm_digest_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_alarm_digest_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_alarm_digest_proto_rawDesc), len(file_message_thingspect_alarm_digest_proto_rawDesc)))
+	})
+	return file_message_thingspect_alarm_digest_proto_rawDescData
+}
+
+var file_message_thingspect_alarm_digest_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_message_thingspect_alarm_digest_proto_goTypes = []any{
+	(*AlarmDigest)(nil),           // 0: thingspect.int.message.AlarmDigest
+	(*AlertDigest)(nil),           // 1: thingspect.int.message.AlertDigest
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(api.AlarmType)(0),            // 3: thingspect.api.AlarmType
+	(api.AlertStatus)(0),          // 4: thingspect.api.AlertStatus
+	(*api.Alert)(nil),             // 5: thingspect.api.Alert
+}
+var file_message_thingspect_alarm_digest_proto_depIdxs = []int32{
+	2, // 0: thingspect.int.message.AlarmDigest.created_at:type_name -> google.protobuf.Timestamp
+	2, // 1: thingspect.int.message.AlarmDigest.updated_at:type_name -> google.protobuf.Timestamp
+	3, // 2: thingspect.int.message.AlertDigest.type:type_name -> thingspect.api.AlarmType
+	4, // 3: thingspect.int.message.AlertDigest.status:type_name -> thingspect.api.AlertStatus
+	2, // 4: thingspect.int.message.AlertDigest.flush_at:type_name -> google.protobuf.Timestamp
+	2, // 5: thingspect.int.message.AlertDigest.created_at:type_name -> google.protobuf.Timestamp
+	5, // 6: thingspect.int.message.AlertDigest.alerts:type_name -> thingspect.api.Alert
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_alarm_digest_proto_init() }
+func file_message_thingspect_alarm_digest_proto_init() {
+	if File_message_thingspect_alarm_digest_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_alarm_digest_proto_rawDesc), len(file_message_thingspect_alarm_digest_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_alarm_digest_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_alarm_digest_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_alarm_digest_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_alarm_digest_proto = out.File
+	file_message_thingspect_alarm_digest_proto_goTypes = nil
+	file_message_thingspect_alarm_digest_proto_depIdxs = nil
+}
@@ -0,0 +1,358 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_alarm_escalation.proto
+
+package message
+
+import (
+	api "github.com/thingspect/proto/go/api"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EscalationStep represents a step of an escalation policy. A step notifies
+// users by tag if an alert remains unacknowledged after its delay.
+type EscalationStep struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Delay in minutes, after the alert opens or the previous step is sent.
+	DelayMinutes int32 `protobuf:"varint,1,opt,name=delay_minutes,json=delayMinutes,proto3" json:"delay_minutes,omitempty"`
+	// User tags to notify.
+	UserTags []string `protobuf:"bytes,2,rep,name=user_tags,json=userTags,proto3" json:"user_tags,omitempty"`
+	// Alarm type to notify by. If unspecified, the alarm's type is used.
+	Type          api.AlarmType `protobuf:"varint,3,opt,name=type,proto3,enum=thingspect.api.AlarmType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EscalationStep) Reset() {
+	*x = EscalationStep{}
+	mi := &file_message_thingspect_alarm_escalation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EscalationStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EscalationStep) ProtoMessage() {}
+
+func (x *EscalationStep) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_alarm_escalation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EscalationStep.ProtoReflect.Descriptor instead.
+func (*EscalationStep) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_alarm_escalation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EscalationStep) GetDelayMinutes() int32 {
+	if x != nil {
+		return x.DelayMinutes
+	}
+	return 0
+}
+
+func (x *EscalationStep) GetUserTags() []string {
+	if x != nil {
+		return x.UserTags
+	}
+	return nil
+}
+
+func (x *EscalationStep) GetType() api.AlarmType {
+	if x != nil {
+		return x.Type
+	}
+	return api.AlarmType(0)
+}
+
+// AlarmEscalation represents the escalation policy of an alarm, as ordered
+// steps executed while an alert remains unacknowledged.
+type AlarmEscalation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Alarm ID (UUID).
+	AlarmId string `protobuf:"bytes,1,opt,name=alarm_id,json=alarmId,proto3" json:"alarm_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Rule ID (UUID) of the alarm.
+	RuleId string `protobuf:"bytes,3,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// Ordered escalation steps.
+	Steps []*EscalationStep `protobuf:"bytes,4,rep,name=steps,proto3" json:"steps,omitempty"`
+	// Escalation creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Escalation modification timestamp.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlarmEscalation) Reset() {
+	*x = AlarmEscalation{}
+	mi := &file_message_thingspect_alarm_escalation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlarmEscalation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlarmEscalation) ProtoMessage() {}
+
+func (x *AlarmEscalation) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_alarm_escalation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlarmEscalation.ProtoReflect.Descriptor instead.
+func (*AlarmEscalation) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_alarm_escalation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AlarmEscalation) GetAlarmId() string {
+	if x != nil {
+		return x.AlarmId
+	}
+	return ""
+}
+
+func (x *AlarmEscalation) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AlarmEscalation) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *AlarmEscalation) GetSteps() []*EscalationStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *AlarmEscalation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AlarmEscalation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// AlertEscalation represents a due escalation step of an alert lifecycle.
+type AlertEscalation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Alert lifecycle ID (UUID).
+	LifecycleId string `protobuf:"bytes,1,opt,name=lifecycle_id,json=lifecycleId,proto3" json:"lifecycle_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Alarm ID (UUID).
+	AlarmId string `protobuf:"bytes,3,opt,name=alarm_id,json=alarmId,proto3" json:"alarm_id,omitempty"`
+	// Step number, starting at 1.
+	StepNum int32 `protobuf:"varint,4,opt,name=step_num,json=stepNum,proto3" json:"step_num,omitempty"`
+	// Escalation step.
+	Step *EscalationStep `protobuf:"bytes,5,opt,name=step,proto3" json:"step,omitempty"`
+	// Event that opened the alert lifecycle.
+	Event         *EventerOut `protobuf:"bytes,6,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertEscalation) Reset() {
+	*x = AlertEscalation{}
+	mi := &file_message_thingspect_alarm_escalation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertEscalation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertEscalation) ProtoMessage() {}
+
+func (x *AlertEscalation) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_alarm_escalation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertEscalation.ProtoReflect.Descriptor instead.
+func (*AlertEscalation) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_alarm_escalation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AlertEscalation) GetLifecycleId() string {
+	if x != nil {
+		return x.LifecycleId
+	}
+	return ""
+}
+
+func (x *AlertEscalation) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AlertEscalation) GetAlarmId() string {
+	if x != nil {
+		return x.AlarmId
+	}
+	return ""
+}
+
+func (x *AlertEscalation) GetStepNum() int32 {
+	if x != nil {
+		return x.StepNum
+	}
+	return 0
+}
+
+func (x *AlertEscalation) GetStep() *EscalationStep {
+	if x != nil {
+		return x.Step
+	}
+	return nil
+}
+
+func (x *AlertEscalation) GetEvent() *EventerOut {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+var File_message_thingspect_alarm_escalation_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_alarm_escalation_proto_rawDesc = "" +
+	"\n" +
+	")message/thingspect_alarm_escalation.proto\x12\x16thingspect.int.message\x1a\x1fapi/thingspect_rule_alarm.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a$message/thingspect_eventer_out.proto\"\x81\x01\n" +
+	"\x0eEscalationStep\x12#\n" +
+	"\rdelay_minutes\x18\x01 \x01(\x05R\fdelayMinutes\x12\x1b\n" +
+	"\tuser_tags\x18\x02 \x03(\tR\buserTags\x12-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x19.thingspect.api.AlarmTypeR\x04type\"\x90\x02\n" +
+	"\x0fAlarmEscalation\x12\x19\n" +
+	"\balarm_id\x18\x01 \x01(\tR\aalarmId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\arule_id\x18\x03 \x01(\tR\x06ruleId\x12<\n" +
+	"\x05steps\x18\x04 \x03(\v2&.thingspect.int.message.EscalationStepR\x05steps\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf7\x01\n" +
+	"\x0fAlertEscalation\x12!\n" +
+	"\flifecycle_id\x18\x01 \x01(\tR\vlifecycleId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x19\n" +
+	"\balarm_id\x18\x03 \x01(\tR\aalarmId\x12\x19\n" +
+	"\bstep_num\x18\x04 \x01(\x05R\astepNum\x12:\n" +
+	"\x04step\x18\x05 \x01(\v2&.thingspect.int.message.EscalationStepR\x04step\x128\n" +
+	"\x05event\x18\x06 \x01(\v2\".thingspect.int.message.EventerOutR\x05eventB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_alarm_escalation_proto_rawDescOnce sync.Once
+	file_message_thingspect_alarm_escalation_proto_rawDescData []byte
+)
+
+func file_message_thingspect_alarm_escalation_proto_rawDescGZIP() []byte {
+	file_message_thingspect_alarm_escalation_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_alarm_escalation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_alarm_escalation_proto_rawDesc), len(file_message_thingspect_alarm_escalation_proto_rawDesc)))
+	})
+	return file_message_thingspect_alarm_escalation_proto_rawDescData
+}
+
+var file_message_thingspect_alarm_escalation_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_message_thingspect_alarm_escalation_proto_goTypes = []any{
+	(*EscalationStep)(nil),        // 0: thingspect.int.message.EscalationStep
+	(*AlarmEscalation)(nil),       // 1: thingspect.int.message.AlarmEscalation
+	(*AlertEscalation)(nil),       // 2: thingspect.int.message.AlertEscalation
+	(api.AlarmType)(0),            // 3: thingspect.api.AlarmType
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*EventerOut)(nil),            // 5: thingspect.int.message.EventerOut
+}
+var file_message_thingspect_alarm_escalation_proto_depIdxs = []int32{
+	3, // 0: thingspect.int.message.EscalationStep.type:type_name -> thingspect.api.AlarmType
+	0, // 1: thingspect.int.message.AlarmEscalation.steps:type_name -> thingspect.int.message.EscalationStep
+	4, // 2: thingspect.int.message.AlarmEscalation.created_at:type_name -> google.protobuf.Timestamp
+	4, // 3: thingspect.int.message.AlarmEscalation.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 4: thingspect.int.message.AlertEscalation.step:type_name -> thingspect.int.message.EscalationStep
+	5, // 5: thingspect.int.message.AlertEscalation.event:type_name -> thingspect.int.message.EventerOut
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_alarm_escalation_proto_init() }
+func file_message_thingspect_alarm_escalation_proto_init() {
+	if File_message_thingspect_alarm_escalation_proto != nil {
+		return
+	}
+	file_message_thingspect_eventer_out_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_alarm_escalation_proto_rawDesc), len(file_message_thingspect_alarm_escalation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_alarm_escalation_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_alarm_escalation_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_alarm_escalation_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_alarm_escalation_proto = out.File
+	file_message_thingspect_alarm_escalation_proto_goTypes = nil
+	file_message_thingspect_alarm_escalation_proto_depIdxs = nil
+}
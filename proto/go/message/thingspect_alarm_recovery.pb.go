@@ -0,0 +1,191 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_alarm_recovery.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AlarmRecovery represents the notification sent by an alarm when its rule
+// clears for a device. An alarm without a recovery does not notify on clear.
+type AlarmRecovery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Alarm ID (UUID).
+	AlarmId string `protobuf:"bytes,1,opt,name=alarm_id,json=alarmId,proto3" json:"alarm_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Rule ID (UUID) of the alarm.
+	RuleId string `protobuf:"bytes,3,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// Recovery subject template.
+	SubjectTemplate string `protobuf:"bytes,4,opt,name=subject_template,json=subjectTemplate,proto3" json:"subject_template,omitempty"`
+	// Recovery body template.
+	BodyTemplate string `protobuf:"bytes,5,opt,name=body_template,json=bodyTemplate,proto3" json:"body_template,omitempty"`
+	// Recovery creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Recovery modification timestamp.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlarmRecovery) Reset() {
+	*x = AlarmRecovery{}
+	mi := &file_message_thingspect_alarm_recovery_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlarmRecovery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlarmRecovery) ProtoMessage() {}
+
+func (x *AlarmRecovery) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_alarm_recovery_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlarmRecovery.ProtoReflect.Descriptor instead.
+func (*AlarmRecovery) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_alarm_recovery_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AlarmRecovery) GetAlarmId() string {
+	if x != nil {
+		return x.AlarmId
+	}
+	return ""
+}
+
+func (x *AlarmRecovery) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AlarmRecovery) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *AlarmRecovery) GetSubjectTemplate() string {
+	if x != nil {
+		return x.SubjectTemplate
+	}
+	return ""
+}
+
+func (x *AlarmRecovery) GetBodyTemplate() string {
+	if x != nil {
+		return x.BodyTemplate
+	}
+	return ""
+}
+
+func (x *AlarmRecovery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AlarmRecovery) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_message_thingspect_alarm_recovery_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_alarm_recovery_proto_rawDesc = "" +
+	"\n" +
+	"'message/thingspect_alarm_recovery.proto\x12\x16thingspect.int.message\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa0\x02\n" +
+	"\rAlarmRecovery\x12\x19\n" +
+	"\balarm_id\x18\x01 \x01(\tR\aalarmId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\arule_id\x18\x03 \x01(\tR\x06ruleId\x12)\n" +
+	"\x10subject_template\x18\x04 \x01(\tR\x0fsubjectTemplate\x12#\n" +
+	"\rbody_template\x18\x05 \x01(\tR\fbodyTemplate\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_alarm_recovery_proto_rawDescOnce sync.Once
+	file_message_thingspect_alarm_recovery_proto_rawDescData []byte
+)
+
+func file_message_thingspect_alarm_recovery_proto_rawDescGZIP() []byte {
+	file_message_thingspect_alarm_recovery_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_alarm_recovery_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_alarm_recovery_proto_rawDesc), len(file_message_thingspect_alarm_recovery_proto_rawDesc)))
+	})
+	return file_message_thingspect_alarm_recovery_proto_rawDescData
+}
+
+var file_message_thingspect_alarm_recovery_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_message_thingspect_alarm_recovery_proto_goTypes = []any{
+	(*AlarmRecovery)(nil),         // 0: thingspect.int.message.AlarmRecovery
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_message_thingspect_alarm_recovery_proto_depIdxs = []int32{
+	1, // 0: thingspect.int.message.AlarmRecovery.created_at:type_name -> google.protobuf.Timestamp
+	1, // 1: thingspect.int.message.AlarmRecovery.updated_at:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_alarm_recovery_proto_init() }
+func file_message_thingspect_alarm_recovery_proto_init() {
+	if File_message_thingspect_alarm_recovery_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_alarm_recovery_proto_rawDesc), len(file_message_thingspect_alarm_recovery_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_alarm_recovery_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_alarm_recovery_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_alarm_recovery_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_alarm_recovery_proto = out.File
+	file_message_thingspect_alarm_recovery_proto_goTypes = nil
+	file_message_thingspect_alarm_recovery_proto_depIdxs = nil
+}
@@ -0,0 +1,391 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_alarm_webhook.proto
+
+package message
+
+import (
+	api "github.com/thingspect/proto/go/api"
+	common "github.com/thingspect/proto/go/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AlarmWebhook represents the webhook of an alarm. An alarm with a webhook
+// also delivers its alerts as signed HTTP requests.
+type AlarmWebhook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Alarm ID (UUID).
+	AlarmId string `protobuf:"bytes,1,opt,name=alarm_id,json=alarmId,proto3" json:"alarm_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Rule ID (UUID) of the alarm.
+	RuleId string `protobuf:"bytes,3,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// Webhook URL.
+	Url string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	// Webhook secret, used to sign requests. Stored encrypted, and only returned on update.
+	Secret string `protobuf:"bytes,5,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Webhook creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Webhook modification timestamp.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlarmWebhook) Reset() {
+	*x = AlarmWebhook{}
+	mi := &file_message_thingspect_alarm_webhook_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlarmWebhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlarmWebhook) ProtoMessage() {}
+
+func (x *AlarmWebhook) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_alarm_webhook_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlarmWebhook.ProtoReflect.Descriptor instead.
+func (*AlarmWebhook) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_alarm_webhook_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AlarmWebhook) GetAlarmId() string {
+	if x != nil {
+		return x.AlarmId
+	}
+	return ""
+}
+
+func (x *AlarmWebhook) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AlarmWebhook) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *AlarmWebhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *AlarmWebhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *AlarmWebhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AlarmWebhook) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// WebhookEvent represents the JSON document delivered to an alarm's webhook.
+type WebhookEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Alarm ID (UUID).
+	AlarmId string `protobuf:"bytes,1,opt,name=alarm_id,json=alarmId,proto3" json:"alarm_id,omitempty"`
+	// Rendered alert subject.
+	Subject string `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Rendered alert body.
+	Body string `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	// Whether the alert is for a cleared rule.
+	Cleared bool `protobuf:"varint,4,opt,name=cleared,proto3" json:"cleared,omitempty"`
+	// Data point.
+	Point *common.DataPoint `protobuf:"bytes,5,opt,name=point,proto3" json:"point,omitempty"`
+	// Device.
+	Device *api.Device `protobuf:"bytes,6,opt,name=device,proto3" json:"device,omitempty"`
+	// Rule.
+	Rule *api.Rule `protobuf:"bytes,7,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Organization.
+	Org           *api.Org `protobuf:"bytes,8,opt,name=org,proto3" json:"org,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookEvent) Reset() {
+	*x = WebhookEvent{}
+	mi := &file_message_thingspect_alarm_webhook_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookEvent) ProtoMessage() {}
+
+func (x *WebhookEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_alarm_webhook_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookEvent.ProtoReflect.Descriptor instead.
+func (*WebhookEvent) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_alarm_webhook_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WebhookEvent) GetAlarmId() string {
+	if x != nil {
+		return x.AlarmId
+	}
+	return ""
+}
+
+func (x *WebhookEvent) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *WebhookEvent) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *WebhookEvent) GetCleared() bool {
+	if x != nil {
+		return x.Cleared
+	}
+	return false
+}
+
+func (x *WebhookEvent) GetPoint() *common.DataPoint {
+	if x != nil {
+		return x.Point
+	}
+	return nil
+}
+
+func (x *WebhookEvent) GetDevice() *api.Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+func (x *WebhookEvent) GetRule() *api.Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *WebhookEvent) GetOrg() *api.Org {
+	if x != nil {
+		return x.Org
+	}
+	return nil
+}
+
+// WebhookDelivery represents an alert pending delivery to an alarm's webhook,
+// as used in message queues.
+type WebhookDelivery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Webhook URL.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Encrypted webhook secret.
+	Secret []byte `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Webhook event to deliver.
+	Event         *WebhookEvent `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_message_thingspect_alarm_webhook_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_alarm_webhook_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_alarm_webhook_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WebhookDelivery) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetSecret() []byte {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetEvent() *WebhookEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+var File_message_thingspect_alarm_webhook_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_alarm_webhook_proto_rawDesc = "" +
+	"\n" +
+	"&message/thingspect_alarm_webhook.proto\x12\x16thingspect.int.message\x1a\x1bapi/thingspect_device.proto\x1a\x18api/thingspect_org.proto\x1a\x1fapi/thingspect_rule_alarm.proto\x1a!common/thingspect_datapoint.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf9\x01\n" +
+	"\fAlarmWebhook\x12\x19\n" +
+	"\balarm_id\x18\x01 \x01(\tR\aalarmId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\arule_id\x18\x03 \x01(\tR\x06ruleId\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x05 \x01(\tR\x06secret\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa6\x02\n" +
+	"\fWebhookEvent\x12\x19\n" +
+	"\balarm_id\x18\x01 \x01(\tR\aalarmId\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x12\x18\n" +
+	"\acleared\x18\x04 \x01(\bR\acleared\x122\n" +
+	"\x05point\x18\x05 \x01(\v2\x1c.thingspect.common.DataPointR\x05point\x12.\n" +
+	"\x06device\x18\x06 \x01(\v2\x16.thingspect.api.DeviceR\x06device\x12(\n" +
+	"\x04rule\x18\a \x01(\v2\x14.thingspect.api.RuleR\x04rule\x12%\n" +
+	"\x03org\x18\b \x01(\v2\x13.thingspect.api.OrgR\x03org\"w\n" +
+	"\x0fWebhookDelivery\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\fR\x06secret\x12:\n" +
+	"\x05event\x18\x03 \x01(\v2$.thingspect.int.message.WebhookEventR\x05eventB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_alarm_webhook_proto_rawDescOnce sync.Once
+	file_message_thingspect_alarm_webhook_proto_rawDescData []byte
+)
+
+func file_message_thingspect_alarm_webhook_proto_rawDescGZIP() []byte {
+	file_message_thingspect_alarm_webhook_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_alarm_webhook_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_alarm_webhook_proto_rawDesc), len(file_message_thingspect_alarm_webhook_proto_rawDesc)))
+	})
+	return file_message_thingspect_alarm_webhook_proto_rawDescData
+}
+
+var file_message_thingspect_alarm_webhook_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_message_thingspect_alarm_webhook_proto_goTypes = []any{
+	(*AlarmWebhook)(nil),          // 0: thingspect.int.message.AlarmWebhook
+	(*WebhookEvent)(nil),          // 1: thingspect.int.message.WebhookEvent
+	(*WebhookDelivery)(nil),       // 2: thingspect.int.message.WebhookDelivery
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*common.DataPoint)(nil),      // 4: thingspect.common.DataPoint
+	(*api.Device)(nil),            // 5: thingspect.api.Device
+	(*api.Rule)(nil),              // 6: thingspect.api.Rule
+	(*api.Org)(nil),               // 7: thingspect.api.Org
+}
+var file_message_thingspect_alarm_webhook_proto_depIdxs = []int32{
+	3, // 0: thingspect.int.message.AlarmWebhook.created_at:type_name -> google.protobuf.Timestamp
+	3, // 1: thingspect.int.message.AlarmWebhook.updated_at:type_name -> google.protobuf.Timestamp
+	4, // 2: thingspect.int.message.WebhookEvent.point:type_name -> thingspect.common.DataPoint
+	5, // 3: thingspect.int.message.WebhookEvent.device:type_name -> thingspect.api.Device
+	6, // 4: thingspect.int.message.WebhookEvent.rule:type_name -> thingspect.api.Rule
+	7, // 5: thingspect.int.message.WebhookEvent.org:type_name -> thingspect.api.Org
+	1, // 6: thingspect.int.message.WebhookDelivery.event:type_name -> thingspect.int.message.WebhookEvent
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_alarm_webhook_proto_init() }
+func file_message_thingspect_alarm_webhook_proto_init() {
+	if File_message_thingspect_alarm_webhook_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_alarm_webhook_proto_rawDesc), len(file_message_thingspect_alarm_webhook_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_alarm_webhook_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_alarm_webhook_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_alarm_webhook_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_alarm_webhook_proto = out.File
+	file_message_thingspect_alarm_webhook_proto_goTypes = nil
+	file_message_thingspect_alarm_webhook_proto_depIdxs = nil
+}
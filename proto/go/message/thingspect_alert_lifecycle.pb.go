@@ -0,0 +1,403 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_alert_lifecycle.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AlertState represents the handling state of an alert.
+type AlertState int32
+
+const (
+	// Alert state is not specified.
+	AlertState_ALERT_STATE_UNSPECIFIED AlertState = 0
+	// Alert is open and notifications repeat per the alarm's repeat interval.
+	AlertState_OPEN AlertState = 1
+	// Alert is acknowledged by a user and notifications are suppressed.
+	AlertState_ACKNOWLEDGED AlertState = 2
+	// Alert is resolved. A subsequent event opens a new alert.
+	AlertState_RESOLVED AlertState = 3
+)
+
+// Enum value maps for AlertState.
+var (
+	AlertState_name = map[int32]string{
+		0: "ALERT_STATE_UNSPECIFIED",
+		1: "OPEN",
+		2: "ACKNOWLEDGED",
+		3: "RESOLVED",
+	}
+	AlertState_value = map[string]int32{
+		"ALERT_STATE_UNSPECIFIED": 0,
+		"OPEN":                    1,
+		"ACKNOWLEDGED":            2,
+		"RESOLVED":                3,
+	}
+)
+
+func (x AlertState) Enum() *AlertState {
+	p := new(AlertState)
+	*p = x
+	return p
+}
+
+func (x AlertState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertState) Descriptor() protoreflect.EnumDescriptor {
+	return file_message_thingspect_alert_lifecycle_proto_enumTypes[0].Descriptor()
+}
+
+func (AlertState) Type() protoreflect.EnumType {
+	return &file_message_thingspect_alert_lifecycle_proto_enumTypes[0]
+}
+
+func (x AlertState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertState.Descriptor instead.
+func (AlertState) EnumDescriptor() ([]byte, []int) {
+	return file_message_thingspect_alert_lifecycle_proto_rawDescGZIP(), []int{0}
+}
+
+// AlertLifecycle represents the handling of alerts for a device and alarm,
+// from open through acknowledged to resolved.
+type AlertLifecycle struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Alert lifecycle ID (UUID).
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Device unique ID.
+	UniqId string `protobuf:"bytes,3,opt,name=uniq_id,json=uniqId,proto3" json:"uniq_id,omitempty"`
+	// Alarm ID (UUID).
+	AlarmId string `protobuf:"bytes,4,opt,name=alarm_id,json=alarmId,proto3" json:"alarm_id,omitempty"`
+	// Alert state.
+	State AlertState `protobuf:"varint,5,opt,name=state,proto3,enum=thingspect.int.message.AlertState" json:"state,omitempty"`
+	// Assigned user ID (UUID), if any.
+	AssigneeId string `protobuf:"bytes,6,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	// Open timestamp.
+	OpenedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`
+	// Acknowledging user ID (UUID), if acknowledged.
+	AckedBy string `protobuf:"bytes,8,opt,name=acked_by,json=ackedBy,proto3" json:"acked_by,omitempty"`
+	// Acknowledgement timestamp, if acknowledged.
+	AckedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=acked_at,json=ackedAt,proto3" json:"acked_at,omitempty"`
+	// Acknowledgement note.
+	AckNote string `protobuf:"bytes,10,opt,name=ack_note,json=ackNote,proto3" json:"ack_note,omitempty"`
+	// Resolving user ID (UUID), if resolved.
+	ResolvedBy string `protobuf:"bytes,11,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	// Resolution timestamp, if resolved.
+	ResolvedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	// Resolution note.
+	ResolveNote string `protobuf:"bytes,13,opt,name=resolve_note,json=resolveNote,proto3" json:"resolve_note,omitempty"`
+	// Update timestamp.
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Trace ID (UUID) of the event that opened the alert.
+	TraceId       string `protobuf:"bytes,15,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertLifecycle) Reset() {
+	*x = AlertLifecycle{}
+	mi := &file_message_thingspect_alert_lifecycle_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertLifecycle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertLifecycle) ProtoMessage() {}
+
+func (x *AlertLifecycle) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_alert_lifecycle_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertLifecycle.ProtoReflect.Descriptor instead.
+func (*AlertLifecycle) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_alert_lifecycle_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AlertLifecycle) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AlertLifecycle) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AlertLifecycle) GetUniqId() string {
+	if x != nil {
+		return x.UniqId
+	}
+	return ""
+}
+
+func (x *AlertLifecycle) GetAlarmId() string {
+	if x != nil {
+		return x.AlarmId
+	}
+	return ""
+}
+
+func (x *AlertLifecycle) GetState() AlertState {
+	if x != nil {
+		return x.State
+	}
+	return AlertState_ALERT_STATE_UNSPECIFIED
+}
+
+func (x *AlertLifecycle) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+func (x *AlertLifecycle) GetOpenedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return nil
+}
+
+func (x *AlertLifecycle) GetAckedBy() string {
+	if x != nil {
+		return x.AckedBy
+	}
+	return ""
+}
+
+func (x *AlertLifecycle) GetAckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AckedAt
+	}
+	return nil
+}
+
+func (x *AlertLifecycle) GetAckNote() string {
+	if x != nil {
+		return x.AckNote
+	}
+	return ""
+}
+
+func (x *AlertLifecycle) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *AlertLifecycle) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *AlertLifecycle) GetResolveNote() string {
+	if x != nil {
+		return x.ResolveNote
+	}
+	return ""
+}
+
+func (x *AlertLifecycle) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *AlertLifecycle) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+// AlertAction represents a user's change to an alert lifecycle.
+type AlertAction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Note to record with an acknowledgement or resolution.
+	Note string `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"`
+	// User ID (UUID) to assign. If empty, the alert is unassigned.
+	AssigneeId    string `protobuf:"bytes,2,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertAction) Reset() {
+	*x = AlertAction{}
+	mi := &file_message_thingspect_alert_lifecycle_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertAction) ProtoMessage() {}
+
+func (x *AlertAction) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_alert_lifecycle_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertAction.ProtoReflect.Descriptor instead.
+func (*AlertAction) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_alert_lifecycle_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AlertAction) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *AlertAction) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+var File_message_thingspect_alert_lifecycle_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_alert_lifecycle_proto_rawDesc = "" +
+	"\n" +
+	"(message/thingspect_alert_lifecycle.proto\x12\x16thingspect.int.message\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc3\x04\n" +
+	"\x0eAlertLifecycle\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auniq_id\x18\x03 \x01(\tR\x06uniqId\x12\x19\n" +
+	"\balarm_id\x18\x04 \x01(\tR\aalarmId\x128\n" +
+	"\x05state\x18\x05 \x01(\x0e2\".thingspect.int.message.AlertStateR\x05state\x12\x1f\n" +
+	"\vassignee_id\x18\x06 \x01(\tR\n" +
+	"assigneeId\x127\n" +
+	"\topened_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bopenedAt\x12\x19\n" +
+	"\backed_by\x18\b \x01(\tR\aackedBy\x125\n" +
+	"\backed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\aackedAt\x12\x19\n" +
+	"\back_note\x18\n" +
+	" \x01(\tR\aackNote\x12\x1f\n" +
+	"\vresolved_by\x18\v \x01(\tR\n" +
+	"resolvedBy\x12;\n" +
+	"\vresolved_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\x12!\n" +
+	"\fresolve_note\x18\r \x01(\tR\vresolveNote\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x19\n" +
+	"\btrace_id\x18\x0f \x01(\tR\atraceId\"B\n" +
+	"\vAlertAction\x12\x12\n" +
+	"\x04note\x18\x01 \x01(\tR\x04note\x12\x1f\n" +
+	"\vassignee_id\x18\x02 \x01(\tR\n" +
+	"assigneeId*S\n" +
+	"\n" +
+	"AlertState\x12\x1b\n" +
+	"\x17ALERT_STATE_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04OPEN\x10\x01\x12\x10\n" +
+	"\fACKNOWLEDGED\x10\x02\x12\f\n" +
+	"\bRESOLVED\x10\x03B.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_alert_lifecycle_proto_rawDescOnce sync.Once
+	file_message_thingspect_alert_lifecycle_proto_rawDescData []byte
+)
+
+func file_message_thingspect_alert_lifecycle_proto_rawDescGZIP() []byte {
+	file_message_thingspect_alert_lifecycle_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_alert_lifecycle_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_alert_lifecycle_proto_rawDesc), len(file_message_thingspect_alert_lifecycle_proto_rawDesc)))
+	})
+	return file_message_thingspect_alert_lifecycle_proto_rawDescData
+}
+
+var file_message_thingspect_alert_lifecycle_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_message_thingspect_alert_lifecycle_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_message_thingspect_alert_lifecycle_proto_goTypes = []any{
+	(AlertState)(0),               // 0: thingspect.int.message.AlertState
+	(*AlertLifecycle)(nil),        // 1: thingspect.int.message.AlertLifecycle
+	(*AlertAction)(nil),           // 2: thingspect.int.message.AlertAction
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_message_thingspect_alert_lifecycle_proto_depIdxs = []int32{
+	0, // 0: thingspect.int.message.AlertLifecycle.state:type_name -> thingspect.int.message.AlertState
+	3, // 1: thingspect.int.message.AlertLifecycle.opened_at:type_name -> google.protobuf.Timestamp
+	3, // 2: thingspect.int.message.AlertLifecycle.acked_at:type_name -> google.protobuf.Timestamp
+	3, // 3: thingspect.int.message.AlertLifecycle.resolved_at:type_name -> google.protobuf.Timestamp
+	3, // 4: thingspect.int.message.AlertLifecycle.updated_at:type_name -> google.protobuf.Timestamp
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_alert_lifecycle_proto_init() }
+func file_message_thingspect_alert_lifecycle_proto_init() {
+	if File_message_thingspect_alert_lifecycle_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_alert_lifecycle_proto_rawDesc), len(file_message_thingspect_alert_lifecycle_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_alert_lifecycle_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_alert_lifecycle_proto_depIdxs,
+		EnumInfos:         file_message_thingspect_alert_lifecycle_proto_enumTypes,
+		MessageInfos:      file_message_thingspect_alert_lifecycle_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_alert_lifecycle_proto = out.File
+	file_message_thingspect_alert_lifecycle_proto_goTypes = nil
+	file_message_thingspect_alert_lifecycle_proto_depIdxs = nil
+}
@@ -0,0 +1,321 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_command.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CommandStatus represents the delivery status of a device command.
+type CommandStatus int32
+
+const (
+	// Command status is not specified.
+	CommandStatus_COMMAND_STATUS_UNSPECIFIED CommandStatus = 0
+	// Command is queued for delivery.
+	CommandStatus_QUEUED CommandStatus = 1
+	// Command was delivered to the broker or network server.
+	CommandStatus_SENT CommandStatus = 2
+	// Command was acknowledged by the device.
+	CommandStatus_ACKED CommandStatus = 3
+	// Command was rejected by the device or timed out in transit.
+	CommandStatus_FAILED CommandStatus = 4
+	// Command was not acknowledged before expiration.
+	CommandStatus_EXPIRED CommandStatus = 5
+)
+
+// Enum value maps for CommandStatus.
+var (
+	CommandStatus_name = map[int32]string{
+		0: "COMMAND_STATUS_UNSPECIFIED",
+		1: "QUEUED",
+		2: "SENT",
+		3: "ACKED",
+		4: "FAILED",
+		5: "EXPIRED",
+	}
+	CommandStatus_value = map[string]int32{
+		"COMMAND_STATUS_UNSPECIFIED": 0,
+		"QUEUED":                     1,
+		"SENT":                       2,
+		"ACKED":                      3,
+		"FAILED":                     4,
+		"EXPIRED":                    5,
+	}
+)
+
+func (x CommandStatus) Enum() *CommandStatus {
+	p := new(CommandStatus)
+	*p = x
+	return p
+}
+
+func (x CommandStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CommandStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_message_thingspect_command_proto_enumTypes[0].Descriptor()
+}
+
+func (CommandStatus) Type() protoreflect.EnumType {
+	return &file_message_thingspect_command_proto_enumTypes[0]
+}
+
+func (x CommandStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CommandStatus.Descriptor instead.
+func (CommandStatus) EnumDescriptor() ([]byte, []int) {
+	return file_message_thingspect_command_proto_rawDescGZIP(), []int{0}
+}
+
+// Command represents a command to a device, as stored and delivered over MQTT.
+type Command struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Command ID (UUID).
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Device unique ID.
+	UniqId string `protobuf:"bytes,3,opt,name=uniq_id,json=uniqId,proto3" json:"uniq_id,omitempty"`
+	// Command payload.
+	Payload []byte `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	// LoRaWAN port. If non-zero, the command is delivered as a LoRaWAN downlink.
+	FPort uint32 `protobuf:"varint,5,opt,name=f_port,json=fPort,proto3" json:"f_port,omitempty"`
+	// Command status.
+	Status CommandStatus `protobuf:"varint,6,opt,name=status,proto3,enum=thingspect.int.message.CommandStatus" json:"status,omitempty"`
+	// Command error, if any.
+	Error string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	// LoRaWAN queue item ID, if delivered as a LoRaWAN downlink.
+	LoraQueueId string `protobuf:"bytes,8,opt,name=lora_queue_id,json=loraQueueId,proto3" json:"lora_queue_id,omitempty"`
+	// Expiration timestamp.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Update timestamp.
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Trace ID (UUID).
+	TraceId       string `protobuf:"bytes,12,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Command) Reset() {
+	*x = Command{}
+	mi := &file_message_thingspect_command_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Command) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Command) ProtoMessage() {}
+
+func (x *Command) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_command_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Command.ProtoReflect.Descriptor instead.
+func (*Command) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_command_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Command) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Command) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *Command) GetUniqId() string {
+	if x != nil {
+		return x.UniqId
+	}
+	return ""
+}
+
+func (x *Command) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Command) GetFPort() uint32 {
+	if x != nil {
+		return x.FPort
+	}
+	return 0
+}
+
+func (x *Command) GetStatus() CommandStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CommandStatus_COMMAND_STATUS_UNSPECIFIED
+}
+
+func (x *Command) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Command) GetLoraQueueId() string {
+	if x != nil {
+		return x.LoraQueueId
+	}
+	return ""
+}
+
+func (x *Command) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Command) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Command) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Command) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+var File_message_thingspect_command_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_command_proto_rawDesc = "" +
+	"\n" +
+	" message/thingspect_command.proto\x12\x16thingspect.int.message\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbf\x03\n" +
+	"\aCommand\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auniq_id\x18\x03 \x01(\tR\x06uniqId\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\fR\apayload\x12\x15\n" +
+	"\x06f_port\x18\x05 \x01(\rR\x05fPort\x12=\n" +
+	"\x06status\x18\x06 \x01(\x0e2%.thingspect.int.message.CommandStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\"\n" +
+	"\rlora_queue_id\x18\b \x01(\tR\vloraQueueId\x129\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x19\n" +
+	"\btrace_id\x18\f \x01(\tR\atraceId*i\n" +
+	"\rCommandStatus\x12\x1e\n" +
+	"\x1aCOMMAND_STATUS_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06QUEUED\x10\x01\x12\b\n" +
+	"\x04SENT\x10\x02\x12\t\n" +
+	"\x05ACKED\x10\x03\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x04\x12\v\n" +
+	"\aEXPIRED\x10\x05B.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_command_proto_rawDescOnce sync.Once
+	file_message_thingspect_command_proto_rawDescData []byte
+)
+
+func file_message_thingspect_command_proto_rawDescGZIP() []byte {
+	file_message_thingspect_command_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_command_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_command_proto_rawDesc), len(file_message_thingspect_command_proto_rawDesc)))
+	})
+	return file_message_thingspect_command_proto_rawDescData
+}
+
+var file_message_thingspect_command_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_message_thingspect_command_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_message_thingspect_command_proto_goTypes = []any{
+	(CommandStatus)(0),            // 0: thingspect.int.message.CommandStatus
+	(*Command)(nil),               // 1: thingspect.int.message.Command
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_message_thingspect_command_proto_depIdxs = []int32{
+	0, // 0: thingspect.int.message.Command.status:type_name -> thingspect.int.message.CommandStatus
+	2, // 1: thingspect.int.message.Command.expires_at:type_name -> google.protobuf.Timestamp
+	2, // 2: thingspect.int.message.Command.created_at:type_name -> google.protobuf.Timestamp
+	2, // 3: thingspect.int.message.Command.updated_at:type_name -> google.protobuf.Timestamp
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_command_proto_init() }
+func file_message_thingspect_command_proto_init() {
+	if File_message_thingspect_command_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_command_proto_rawDesc), len(file_message_thingspect_command_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_command_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_command_proto_depIdxs,
+		EnumInfos:         file_message_thingspect_command_proto_enumTypes,
+		MessageInfos:      file_message_thingspect_command_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_command_proto = out.File
+	file_message_thingspect_command_proto_goTypes = nil
+	file_message_thingspect_command_proto_depIdxs = nil
+}
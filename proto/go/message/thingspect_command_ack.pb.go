@@ -0,0 +1,198 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_command_ack.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CommandAck represents a command acknowledgment as used in message queues. Devices using MQTT provide the ID, device token, and error, if any.
+type CommandAck struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Command ID (UUID). May be empty if acknowledged by LoRaWAN queue item ID.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Organization ID (UUID). May be empty if acknowledged by LoRaWAN queue item ID.
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Device unique ID.
+	UniqId string `protobuf:"bytes,3,opt,name=uniq_id,json=uniqId,proto3" json:"uniq_id,omitempty"`
+	// LoRaWAN queue item ID, if delivered as a LoRaWAN downlink.
+	LoraQueueId string `protobuf:"bytes,4,opt,name=lora_queue_id,json=loraQueueId,proto3" json:"lora_queue_id,omitempty"`
+	// Command status.
+	Status CommandStatus `protobuf:"varint,5,opt,name=status,proto3,enum=thingspect.int.message.CommandStatus" json:"status,omitempty"`
+	// Command error, if any.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// Trace ID (UUID).
+	TraceId string `protobuf:"bytes,7,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// Device token. Required if acknowledged by ID.
+	Token         string `protobuf:"bytes,8,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandAck) Reset() {
+	*x = CommandAck{}
+	mi := &file_message_thingspect_command_ack_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandAck) ProtoMessage() {}
+
+func (x *CommandAck) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_command_ack_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandAck.ProtoReflect.Descriptor instead.
+func (*CommandAck) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_command_ack_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CommandAck) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CommandAck) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *CommandAck) GetUniqId() string {
+	if x != nil {
+		return x.UniqId
+	}
+	return ""
+}
+
+func (x *CommandAck) GetLoraQueueId() string {
+	if x != nil {
+		return x.LoraQueueId
+	}
+	return ""
+}
+
+func (x *CommandAck) GetStatus() CommandStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CommandStatus_COMMAND_STATUS_UNSPECIFIED
+}
+
+func (x *CommandAck) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CommandAck) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *CommandAck) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+var File_message_thingspect_command_ack_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_command_ack_proto_rawDesc = "" +
+	"\n" +
+	"$message/thingspect_command_ack.proto\x12\x16thingspect.int.message\x1a message/thingspect_command.proto\"\xf6\x01\n" +
+	"\n" +
+	"CommandAck\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auniq_id\x18\x03 \x01(\tR\x06uniqId\x12\"\n" +
+	"\rlora_queue_id\x18\x04 \x01(\tR\vloraQueueId\x12=\n" +
+	"\x06status\x18\x05 \x01(\x0e2%.thingspect.int.message.CommandStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x19\n" +
+	"\btrace_id\x18\a \x01(\tR\atraceId\x12\x14\n" +
+	"\x05token\x18\b \x01(\tR\x05tokenB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_command_ack_proto_rawDescOnce sync.Once
+	file_message_thingspect_command_ack_proto_rawDescData []byte
+)
+
+func file_message_thingspect_command_ack_proto_rawDescGZIP() []byte {
+	file_message_thingspect_command_ack_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_command_ack_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_command_ack_proto_rawDesc), len(file_message_thingspect_command_ack_proto_rawDesc)))
+	})
+	return file_message_thingspect_command_ack_proto_rawDescData
+}
+
+var file_message_thingspect_command_ack_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_message_thingspect_command_ack_proto_goTypes = []any{
+	(*CommandAck)(nil), // 0: thingspect.int.message.CommandAck
+	(CommandStatus)(0), // 1: thingspect.int.message.CommandStatus
+}
+var file_message_thingspect_command_ack_proto_depIdxs = []int32{
+	1, // 0: thingspect.int.message.CommandAck.status:type_name -> thingspect.int.message.CommandStatus
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_command_ack_proto_init() }
+func file_message_thingspect_command_ack_proto_init() {
+	if File_message_thingspect_command_ack_proto != nil {
+		return
+	}
+	file_message_thingspect_command_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_command_ack_proto_rawDesc), len(file_message_thingspect_command_ack_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_command_ack_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_command_ack_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_command_ack_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_command_ack_proto = out.File
+	file_message_thingspect_command_ack_proto_goTypes = nil
+	file_message_thingspect_command_ack_proto_depIdxs = nil
+}
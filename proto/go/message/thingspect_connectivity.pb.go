@@ -0,0 +1,300 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_connectivity.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Connectivity represents a device's reporting state.
+type Connectivity struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Device ID (UUID).
+	DevId string `protobuf:"bytes,1,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Timestamp of the latest data point.
+	LastSeenAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	// Whether the device has missed its expected reporting interval.
+	Offline bool `protobuf:"varint,4,opt,name=offline,proto3" json:"offline,omitempty"`
+	// Timestamp of the latest change to offline.
+	ChangedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	// Expected reporting interval, if any, by device or by its tags.
+	Interval      *durationpb.Duration `protobuf:"bytes,6,opt,name=interval,proto3" json:"interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Connectivity) Reset() {
+	*x = Connectivity{}
+	mi := &file_message_thingspect_connectivity_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Connectivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connectivity) ProtoMessage() {}
+
+func (x *Connectivity) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_connectivity_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connectivity.ProtoReflect.Descriptor instead.
+func (*Connectivity) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_connectivity_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Connectivity) GetDevId() string {
+	if x != nil {
+		return x.DevId
+	}
+	return ""
+}
+
+func (x *Connectivity) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *Connectivity) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+func (x *Connectivity) GetOffline() bool {
+	if x != nil {
+		return x.Offline
+	}
+	return false
+}
+
+func (x *Connectivity) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *Connectivity) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+// ConnectivityInterval represents an expected reporting interval for a device
+// or for devices with a tag. Exactly one of dev_id and tag is set. Device
+// intervals take precedence, followed by the shortest interval of a device's
+// tags.
+type ConnectivityInterval struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Connectivity interval ID (UUID).
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Device ID (UUID).
+	DevId string `protobuf:"bytes,3,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
+	// Device tag.
+	Tag string `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Expected reporting interval.
+	Interval *durationpb.Duration `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Update timestamp.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectivityInterval) Reset() {
+	*x = ConnectivityInterval{}
+	mi := &file_message_thingspect_connectivity_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectivityInterval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectivityInterval) ProtoMessage() {}
+
+func (x *ConnectivityInterval) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_connectivity_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectivityInterval.ProtoReflect.Descriptor instead.
+func (*ConnectivityInterval) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_connectivity_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConnectivityInterval) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ConnectivityInterval) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ConnectivityInterval) GetDevId() string {
+	if x != nil {
+		return x.DevId
+	}
+	return ""
+}
+
+func (x *ConnectivityInterval) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ConnectivityInterval) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *ConnectivityInterval) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ConnectivityInterval) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_message_thingspect_connectivity_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_connectivity_proto_rawDesc = "" +
+	"\n" +
+	"%message/thingspect_connectivity.proto\x12\x16thingspect.int.message\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x86\x02\n" +
+	"\fConnectivity\x12\x15\n" +
+	"\x06dev_id\x18\x01 \x01(\tR\x05devId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12<\n" +
+	"\flast_seen_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\x12\x18\n" +
+	"\aoffline\x18\x04 \x01(\bR\aoffline\x129\n" +
+	"\n" +
+	"changed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\x125\n" +
+	"\binterval\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\binterval\"\x93\x02\n" +
+	"\x14ConnectivityInterval\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x15\n" +
+	"\x06dev_id\x18\x03 \x01(\tR\x05devId\x12\x10\n" +
+	"\x03tag\x18\x04 \x01(\tR\x03tag\x125\n" +
+	"\binterval\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\binterval\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_connectivity_proto_rawDescOnce sync.Once
+	file_message_thingspect_connectivity_proto_rawDescData []byte
+)
+
+func file_message_thingspect_connectivity_proto_rawDescGZIP() []byte {
+	file_message_thingspect_connectivity_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_connectivity_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_connectivity_proto_rawDesc), len(file_message_thingspect_connectivity_proto_rawDesc)))
+	})
+	return file_message_thingspect_connectivity_proto_rawDescData
+}
+
+var file_message_thingspect_connectivity_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_message_thingspect_connectivity_proto_goTypes = []any{
+	(*Connectivity)(nil),          // 0: thingspect.int.message.Connectivity
+	(*ConnectivityInterval)(nil),  // 1: thingspect.int.message.ConnectivityInterval
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 3: google.protobuf.Duration
+}
+var file_message_thingspect_connectivity_proto_depIdxs = []int32{
+	2, // 0: thingspect.int.message.Connectivity.last_seen_at:type_name -> google.protobuf.Timestamp
+	2, // 1: thingspect.int.message.Connectivity.changed_at:type_name -> google.protobuf.Timestamp
+	3, // 2: thingspect.int.message.Connectivity.interval:type_name -> google.protobuf.Duration
+	3, // 3: thingspect.int.message.ConnectivityInterval.interval:type_name -> google.protobuf.Duration
+	2, // 4: thingspect.int.message.ConnectivityInterval.created_at:type_name -> google.protobuf.Timestamp
+	2, // 5: thingspect.int.message.ConnectivityInterval.updated_at:type_name -> google.protobuf.Timestamp
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_connectivity_proto_init() }
+func file_message_thingspect_connectivity_proto_init() {
+	if File_message_thingspect_connectivity_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_connectivity_proto_rawDesc), len(file_message_thingspect_connectivity_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_connectivity_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_connectivity_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_connectivity_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_connectivity_proto = out.File
+	file_message_thingspect_connectivity_proto_goTypes = nil
+	file_message_thingspect_connectivity_proto_depIdxs = nil
+}
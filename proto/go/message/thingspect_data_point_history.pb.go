@@ -0,0 +1,129 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_data_point_history.proto
+
+package message
+
+import (
+	common "github.com/thingspect/proto/go/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DataPointHistory represents recent data points of a device's attribute, as
+// used by rule history functions.
+type DataPointHistory struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Data points, in ascending timestamp order. Only the timestamp and value
+	// of each data point are set.
+	Points        []*common.DataPoint `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataPointHistory) Reset() {
+	*x = DataPointHistory{}
+	mi := &file_message_thingspect_data_point_history_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataPointHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataPointHistory) ProtoMessage() {}
+
+func (x *DataPointHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_data_point_history_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataPointHistory.ProtoReflect.Descriptor instead.
+func (*DataPointHistory) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_data_point_history_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DataPointHistory) GetPoints() []*common.DataPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+var File_message_thingspect_data_point_history_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_data_point_history_proto_rawDesc = "" +
+	"\n" +
+	"+message/thingspect_data_point_history.proto\x12\x16thingspect.int.message\x1a!common/thingspect_datapoint.proto\"H\n" +
+	"\x10DataPointHistory\x124\n" +
+	"\x06points\x18\x01 \x03(\v2\x1c.thingspect.common.DataPointR\x06pointsB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_data_point_history_proto_rawDescOnce sync.Once
+	file_message_thingspect_data_point_history_proto_rawDescData []byte
+)
+
+func file_message_thingspect_data_point_history_proto_rawDescGZIP() []byte {
+	file_message_thingspect_data_point_history_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_data_point_history_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_data_point_history_proto_rawDesc), len(file_message_thingspect_data_point_history_proto_rawDesc)))
+	})
+	return file_message_thingspect_data_point_history_proto_rawDescData
+}
+
+var file_message_thingspect_data_point_history_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_message_thingspect_data_point_history_proto_goTypes = []any{
+	(*DataPointHistory)(nil), // 0: thingspect.int.message.DataPointHistory
+	(*common.DataPoint)(nil), // 1: thingspect.common.DataPoint
+}
+var file_message_thingspect_data_point_history_proto_depIdxs = []int32{
+	1, // 0: thingspect.int.message.DataPointHistory.points:type_name -> thingspect.common.DataPoint
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_data_point_history_proto_init() }
+func file_message_thingspect_data_point_history_proto_init() {
+	if File_message_thingspect_data_point_history_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_data_point_history_proto_rawDesc), len(file_message_thingspect_data_point_history_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_data_point_history_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_data_point_history_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_data_point_history_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_data_point_history_proto = out.File
+	file_message_thingspect_data_point_history_proto_goTypes = nil
+	file_message_thingspect_data_point_history_proto_depIdxs = nil
+}
@@ -31,7 +31,9 @@ type EventerOut struct {
 	// Device.
 	Device *api.Device `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
 	// Rule.
-	Rule          *api.Rule `protobuf:"bytes,3,opt,name=rule,proto3" json:"rule,omitempty"`
+	Rule *api.Rule `protobuf:"bytes,3,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Whether the rule cleared, rather than matched, for the device.
+	Cleared       bool `protobuf:"varint,4,opt,name=cleared,proto3" json:"cleared,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -87,16 +89,24 @@ func (x *EventerOut) GetRule() *api.Rule {
 	return nil
 }
 
+func (x *EventerOut) GetCleared() bool {
+	if x != nil {
+		return x.Cleared
+	}
+	return false
+}
+
 var File_message_thingspect_eventer_out_proto protoreflect.FileDescriptor
 
 const file_message_thingspect_eventer_out_proto_rawDesc = "" +
 	"\n" +
-	"$message/thingspect_eventer_out.proto\x12\x16thingspect.int.message\x1a\x1bapi/thingspect_device.proto\x1a\x1fapi/thingspect_rule_alarm.proto\x1a!common/thingspect_datapoint.proto\"\x9a\x01\n" +
+	"$message/thingspect_eventer_out.proto\x12\x16thingspect.int.message\x1a\x1bapi/thingspect_device.proto\x1a\x1fapi/thingspect_rule_alarm.proto\x1a!common/thingspect_datapoint.proto\"\xb4\x01\n" +
 	"\n" +
 	"EventerOut\x122\n" +
 	"\x05point\x18\x01 \x01(\v2\x1c.thingspect.common.DataPointR\x05point\x12.\n" +
 	"\x06device\x18\x02 \x01(\v2\x16.thingspect.api.DeviceR\x06device\x12(\n" +
-	"\x04rule\x18\x03 \x01(\v2\x14.thingspect.api.RuleR\x04ruleB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+	"\x04rule\x18\x03 \x01(\v2\x14.thingspect.api.RuleR\x04rule\x12\x18\n" +
+	"\acleared\x18\x04 \x01(\bR\aclearedB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
 
 var (
 	file_message_thingspect_eventer_out_proto_rawDescOnce sync.Once
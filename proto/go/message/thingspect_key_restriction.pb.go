@@ -0,0 +1,336 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_key_restriction.proto
+
+package message
+
+import (
+	api "github.com/thingspect/proto/go/api"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// KeyRestriction represents the optional restrictions of an API key. Each
+// restriction is carried in the key's token.
+type KeyRestriction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key ID (UUID).
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Key expiration timestamp. If absent, the key does not expire.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// gRPC methods, such as '/thingspect.api.DataPointService/PublishDataPoints', or services, such as 'thingspect.api.DataPointService', that the key may call. If empty, the key may call any method allowed by its role.
+	Scopes []string `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Device unique IDs that a PUBLISHER key may publish data points for.
+	UniqIds []string `protobuf:"bytes,5,rep,name=uniq_ids,json=uniqIds,proto3" json:"uniq_ids,omitempty"`
+	// Device tags that a PUBLISHER key may publish data points for.
+	Tags []string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Restriction creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Device tags that scope the key. If present, the key may only access devices with a matching tag, or a tag nested beneath one using '/'.
+	ScopeTags     []string `protobuf:"bytes,8,rep,name=scope_tags,json=scopeTags,proto3" json:"scope_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyRestriction) Reset() {
+	*x = KeyRestriction{}
+	mi := &file_message_thingspect_key_restriction_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyRestriction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRestriction) ProtoMessage() {}
+
+func (x *KeyRestriction) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_key_restriction_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRestriction.ProtoReflect.Descriptor instead.
+func (*KeyRestriction) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_key_restriction_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *KeyRestriction) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *KeyRestriction) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *KeyRestriction) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *KeyRestriction) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *KeyRestriction) GetUniqIds() []string {
+	if x != nil {
+		return x.UniqIds
+	}
+	return nil
+}
+
+func (x *KeyRestriction) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *KeyRestriction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *KeyRestriction) GetScopeTags() []string {
+	if x != nil {
+		return x.ScopeTags
+	}
+	return nil
+}
+
+// CreateRestrictedKeyRequest is sent to create an API key with restrictions.
+type CreateRestrictedKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// API key object.
+	Key *api.Key `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Key restrictions. Key and organization IDs are ignored.
+	Restriction   *KeyRestriction `protobuf:"bytes,2,opt,name=restriction,proto3" json:"restriction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRestrictedKeyRequest) Reset() {
+	*x = CreateRestrictedKeyRequest{}
+	mi := &file_message_thingspect_key_restriction_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRestrictedKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRestrictedKeyRequest) ProtoMessage() {}
+
+func (x *CreateRestrictedKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_key_restriction_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRestrictedKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateRestrictedKeyRequest) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_key_restriction_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateRestrictedKeyRequest) GetKey() *api.Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *CreateRestrictedKeyRequest) GetRestriction() *KeyRestriction {
+	if x != nil {
+		return x.Restriction
+	}
+	return nil
+}
+
+// CreateRestrictedKeyResponse is returned from a restricted API key create.
+type CreateRestrictedKeyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// API key object.
+	Key *api.Key `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Key restrictions.
+	Restriction *KeyRestriction `protobuf:"bytes,2,opt,name=restriction,proto3" json:"restriction,omitempty"`
+	// API key token.
+	Token         string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRestrictedKeyResponse) Reset() {
+	*x = CreateRestrictedKeyResponse{}
+	mi := &file_message_thingspect_key_restriction_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRestrictedKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRestrictedKeyResponse) ProtoMessage() {}
+
+func (x *CreateRestrictedKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_key_restriction_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRestrictedKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateRestrictedKeyResponse) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_key_restriction_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateRestrictedKeyResponse) GetKey() *api.Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *CreateRestrictedKeyResponse) GetRestriction() *KeyRestriction {
+	if x != nil {
+		return x.Restriction
+	}
+	return nil
+}
+
+func (x *CreateRestrictedKeyResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+var File_message_thingspect_key_restriction_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_key_restriction_proto_rawDesc = "" +
+	"\n" +
+	"(message/thingspect_key_restriction.proto\x12\x16thingspect.int.message\x1a\x1capi/thingspect_session.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9a\x02\n" +
+	"\x0eKeyRestriction\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x12\x19\n" +
+	"\buniq_ids\x18\x05 \x03(\tR\auniqIds\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"scope_tags\x18\b \x03(\tR\tscopeTags\"\x8d\x01\n" +
+	"\x1aCreateRestrictedKeyRequest\x12%\n" +
+	"\x03key\x18\x01 \x01(\v2\x13.thingspect.api.KeyR\x03key\x12H\n" +
+	"\vrestriction\x18\x02 \x01(\v2&.thingspect.int.message.KeyRestrictionR\vrestriction\"\xa4\x01\n" +
+	"\x1bCreateRestrictedKeyResponse\x12%\n" +
+	"\x03key\x18\x01 \x01(\v2\x13.thingspect.api.KeyR\x03key\x12H\n" +
+	"\vrestriction\x18\x02 \x01(\v2&.thingspect.int.message.KeyRestrictionR\vrestriction\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05tokenB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_key_restriction_proto_rawDescOnce sync.Once
+	file_message_thingspect_key_restriction_proto_rawDescData []byte
+)
+
+func file_message_thingspect_key_restriction_proto_rawDescGZIP() []byte {
+	file_message_thingspect_key_restriction_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_key_restriction_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_key_restriction_proto_rawDesc), len(file_message_thingspect_key_restriction_proto_rawDesc)))
+	})
+	return file_message_thingspect_key_restriction_proto_rawDescData
+}
+
+var file_message_thingspect_key_restriction_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_message_thingspect_key_restriction_proto_goTypes = []any{
+	(*KeyRestriction)(nil),              // 0: thingspect.int.message.KeyRestriction
+	(*CreateRestrictedKeyRequest)(nil),  // 1: thingspect.int.message.CreateRestrictedKeyRequest
+	(*CreateRestrictedKeyResponse)(nil), // 2: thingspect.int.message.CreateRestrictedKeyResponse
+	(*timestamppb.Timestamp)(nil),       // 3: google.protobuf.Timestamp
+	(*api.Key)(nil),                     // 4: thingspect.api.Key
+}
+var file_message_thingspect_key_restriction_proto_depIdxs = []int32{
+	3, // 0: thingspect.int.message.KeyRestriction.expires_at:type_name -> google.protobuf.Timestamp
+	3, // 1: thingspect.int.message.KeyRestriction.created_at:type_name -> google.protobuf.Timestamp
+	4, // 2: thingspect.int.message.CreateRestrictedKeyRequest.key:type_name -> thingspect.api.Key
+	0, // 3: thingspect.int.message.CreateRestrictedKeyRequest.restriction:type_name -> thingspect.int.message.KeyRestriction
+	4, // 4: thingspect.int.message.CreateRestrictedKeyResponse.key:type_name -> thingspect.api.Key
+	0, // 5: thingspect.int.message.CreateRestrictedKeyResponse.restriction:type_name -> thingspect.int.message.KeyRestriction
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_key_restriction_proto_init() }
+func file_message_thingspect_key_restriction_proto_init() {
+	if File_message_thingspect_key_restriction_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_key_restriction_proto_rawDesc), len(file_message_thingspect_key_restriction_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_key_restriction_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_key_restriction_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_key_restriction_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_key_restriction_proto = out.File
+	file_message_thingspect_key_restriction_proto_goTypes = nil
+	file_message_thingspect_key_restriction_proto_depIdxs = nil
+}
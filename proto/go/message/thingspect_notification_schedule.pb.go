@@ -0,0 +1,566 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_notification_schedule.proto
+
+package message
+
+import (
+	api "github.com/thingspect/proto/go/api"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ScheduleAction represents the handling of an alert that falls outside of a
+// user's notification windows.
+type ScheduleAction int32
+
+const (
+	// Schedule action is not specified, and alerts are deferred.
+	ScheduleAction_SCHEDULE_ACTION_UNSPECIFIED ScheduleAction = 0
+	// Alert is deferred until the next window of its alarm type opens.
+	ScheduleAction_DEFER ScheduleAction = 1
+	// Alert is dropped.
+	ScheduleAction_DROP ScheduleAction = 2
+	// Alert is sent by the fallback alarm type, if its window is open.
+	// Otherwise, it is deferred.
+	ScheduleAction_FALLBACK ScheduleAction = 3
+)
+
+// Enum value maps for ScheduleAction.
+var (
+	ScheduleAction_name = map[int32]string{
+		0: "SCHEDULE_ACTION_UNSPECIFIED",
+		1: "DEFER",
+		2: "DROP",
+		3: "FALLBACK",
+	}
+	ScheduleAction_value = map[string]int32{
+		"SCHEDULE_ACTION_UNSPECIFIED": 0,
+		"DEFER":                       1,
+		"DROP":                        2,
+		"FALLBACK":                    3,
+	}
+)
+
+func (x ScheduleAction) Enum() *ScheduleAction {
+	p := new(ScheduleAction)
+	*p = x
+	return p
+}
+
+func (x ScheduleAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScheduleAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_message_thingspect_notification_schedule_proto_enumTypes[0].Descriptor()
+}
+
+func (ScheduleAction) Type() protoreflect.EnumType {
+	return &file_message_thingspect_notification_schedule_proto_enumTypes[0]
+}
+
+func (x ScheduleAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduleAction.Descriptor instead.
+func (ScheduleAction) EnumDescriptor() ([]byte, []int) {
+	return file_message_thingspect_notification_schedule_proto_rawDescGZIP(), []int{0}
+}
+
+// ScheduleWindow represents a weekly time window in which alerts are sent.
+type ScheduleWindow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Alarm type the window applies to. If unspecified, the window applies to
+	// all alarm types.
+	Type api.AlarmType `protobuf:"varint,1,opt,name=type,proto3,enum=thingspect.api.AlarmType" json:"type,omitempty"`
+	// Days of the week the window opens on, where 0 is Sunday. If empty, the
+	// window opens every day.
+	Days []int32 `protobuf:"varint,2,rep,packed,name=days,proto3" json:"days,omitempty"`
+	// Minute of the day the window opens, in the range [0, 1440).
+	StartMinute int32 `protobuf:"varint,3,opt,name=start_minute,json=startMinute,proto3" json:"start_minute,omitempty"`
+	// Minute of the day the window closes, in the range [0, 1440). If not
+	// after the start minute, the window closes on the following day.
+	EndMinute     int32 `protobuf:"varint,4,opt,name=end_minute,json=endMinute,proto3" json:"end_minute,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleWindow) Reset() {
+	*x = ScheduleWindow{}
+	mi := &file_message_thingspect_notification_schedule_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleWindow) ProtoMessage() {}
+
+func (x *ScheduleWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_notification_schedule_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleWindow.ProtoReflect.Descriptor instead.
+func (*ScheduleWindow) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_notification_schedule_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ScheduleWindow) GetType() api.AlarmType {
+	if x != nil {
+		return x.Type
+	}
+	return api.AlarmType(0)
+}
+
+func (x *ScheduleWindow) GetDays() []int32 {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *ScheduleWindow) GetStartMinute() int32 {
+	if x != nil {
+		return x.StartMinute
+	}
+	return 0
+}
+
+func (x *ScheduleWindow) GetEndMinute() int32 {
+	if x != nil {
+		return x.EndMinute
+	}
+	return 0
+}
+
+// NotificationSchedule represents the notification schedule of a user. Alarm
+// types without windows are not restricted.
+type NotificationSchedule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (UUID).
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// IANA time zone that windows are evaluated in, such as
+	// 'America/Chicago'.
+	Timezone string `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// Weekly time windows.
+	Windows []*ScheduleWindow `protobuf:"bytes,4,rep,name=windows,proto3" json:"windows,omitempty"`
+	// Action for alerts outside of windows.
+	Action ScheduleAction `protobuf:"varint,5,opt,name=action,proto3,enum=thingspect.int.message.ScheduleAction" json:"action,omitempty"`
+	// Fallback alarm type, used by the FALLBACK action.
+	FallbackType api.AlarmType `protobuf:"varint,6,opt,name=fallback_type,json=fallbackType,proto3,enum=thingspect.api.AlarmType" json:"fallback_type,omitempty"`
+	// Critical alarm IDs (UUID). Alerts of critical alarms are always sent
+	// immediately.
+	CriticalAlarmIds []string `protobuf:"bytes,7,rep,name=critical_alarm_ids,json=criticalAlarmIds,proto3" json:"critical_alarm_ids,omitempty"`
+	// Schedule creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Schedule modification timestamp.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationSchedule) Reset() {
+	*x = NotificationSchedule{}
+	mi := &file_message_thingspect_notification_schedule_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationSchedule) ProtoMessage() {}
+
+func (x *NotificationSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_notification_schedule_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationSchedule.ProtoReflect.Descriptor instead.
+func (*NotificationSchedule) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_notification_schedule_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NotificationSchedule) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *NotificationSchedule) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *NotificationSchedule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *NotificationSchedule) GetWindows() []*ScheduleWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+func (x *NotificationSchedule) GetAction() ScheduleAction {
+	if x != nil {
+		return x.Action
+	}
+	return ScheduleAction_SCHEDULE_ACTION_UNSPECIFIED
+}
+
+func (x *NotificationSchedule) GetFallbackType() api.AlarmType {
+	if x != nil {
+		return x.FallbackType
+	}
+	return api.AlarmType(0)
+}
+
+func (x *NotificationSchedule) GetCriticalAlarmIds() []string {
+	if x != nil {
+		return x.CriticalAlarmIds
+	}
+	return nil
+}
+
+func (x *NotificationSchedule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *NotificationSchedule) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// DeferredAlert represents an alert deferred by a notification schedule.
+type DeferredAlert struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deferred alert ID (UUID).
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Alarm ID (UUID).
+	AlarmId string `protobuf:"bytes,3,opt,name=alarm_id,json=alarmId,proto3" json:"alarm_id,omitempty"`
+	// User ID (UUID).
+	UserId string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Alarm type to notify by.
+	Type api.AlarmType `protobuf:"varint,5,opt,name=type,proto3,enum=thingspect.api.AlarmType" json:"type,omitempty"`
+	// Rendered alert subject.
+	Subject string `protobuf:"bytes,6,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Rendered alert body.
+	Body string `protobuf:"bytes,7,opt,name=body,proto3" json:"body,omitempty"`
+	// Event that generated the alert.
+	Event *EventerOut `protobuf:"bytes,8,opt,name=event,proto3" json:"event,omitempty"`
+	// Deferred alert delivery timestamp.
+	DeliverAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeferredAlert) Reset() {
+	*x = DeferredAlert{}
+	mi := &file_message_thingspect_notification_schedule_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeferredAlert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeferredAlert) ProtoMessage() {}
+
+func (x *DeferredAlert) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_notification_schedule_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeferredAlert.ProtoReflect.Descriptor instead.
+func (*DeferredAlert) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_notification_schedule_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeferredAlert) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeferredAlert) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *DeferredAlert) GetAlarmId() string {
+	if x != nil {
+		return x.AlarmId
+	}
+	return ""
+}
+
+func (x *DeferredAlert) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeferredAlert) GetType() api.AlarmType {
+	if x != nil {
+		return x.Type
+	}
+	return api.AlarmType(0)
+}
+
+func (x *DeferredAlert) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *DeferredAlert) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *DeferredAlert) GetEvent() *EventerOut {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *DeferredAlert) GetDeliverAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliverAt
+	}
+	return nil
+}
+
+// AlertRecord represents an alert to be stored, including whether it was
+// deferred by a notification schedule, which api.AlertStatus does not define.
+type AlertRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Alert. The status of deferred alerts is unspecified.
+	Alert *api.Alert `protobuf:"bytes,1,opt,name=alert,proto3" json:"alert,omitempty"`
+	// Whether the alert was deferred by a notification schedule.
+	Deferred      bool `protobuf:"varint,2,opt,name=deferred,proto3" json:"deferred,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertRecord) Reset() {
+	*x = AlertRecord{}
+	mi := &file_message_thingspect_notification_schedule_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertRecord) ProtoMessage() {}
+
+func (x *AlertRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_notification_schedule_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertRecord.ProtoReflect.Descriptor instead.
+func (*AlertRecord) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_notification_schedule_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AlertRecord) GetAlert() *api.Alert {
+	if x != nil {
+		return x.Alert
+	}
+	return nil
+}
+
+func (x *AlertRecord) GetDeferred() bool {
+	if x != nil {
+		return x.Deferred
+	}
+	return false
+}
+
+var File_message_thingspect_notification_schedule_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_notification_schedule_proto_rawDesc = "" +
+	"\n" +
+	".message/thingspect_notification_schedule.proto\x12\x16thingspect.int.message\x1a\x1aapi/thingspect_alert.proto\x1a\x1fapi/thingspect_rule_alarm.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a$message/thingspect_eventer_out.proto\"\x95\x01\n" +
+	"\x0eScheduleWindow\x12-\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x19.thingspect.api.AlarmTypeR\x04type\x12\x12\n" +
+	"\x04days\x18\x02 \x03(\x05R\x04days\x12!\n" +
+	"\fstart_minute\x18\x03 \x01(\x05R\vstartMinute\x12\x1d\n" +
+	"\n" +
+	"end_minute\x18\x04 \x01(\x05R\tendMinute\"\xc8\x03\n" +
+	"\x14NotificationSchedule\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x12@\n" +
+	"\awindows\x18\x04 \x03(\v2&.thingspect.int.message.ScheduleWindowR\awindows\x12>\n" +
+	"\x06action\x18\x05 \x01(\x0e2&.thingspect.int.message.ScheduleActionR\x06action\x12>\n" +
+	"\rfallback_type\x18\x06 \x01(\x0e2\x19.thingspect.api.AlarmTypeR\ffallbackType\x12,\n" +
+	"\x12critical_alarm_ids\x18\a \x03(\tR\x10criticalAlarmIds\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xbc\x02\n" +
+	"\rDeferredAlert\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x19\n" +
+	"\balarm_id\x18\x03 \x01(\tR\aalarmId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12-\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x19.thingspect.api.AlarmTypeR\x04type\x12\x18\n" +
+	"\asubject\x18\x06 \x01(\tR\asubject\x12\x12\n" +
+	"\x04body\x18\a \x01(\tR\x04body\x128\n" +
+	"\x05event\x18\b \x01(\v2\".thingspect.int.message.EventerOutR\x05event\x129\n" +
+	"\n" +
+	"deliver_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tdeliverAt\"V\n" +
+	"\vAlertRecord\x12+\n" +
+	"\x05alert\x18\x01 \x01(\v2\x15.thingspect.api.AlertR\x05alert\x12\x1a\n" +
+	"\bdeferred\x18\x02 \x01(\bR\bdeferred*T\n" +
+	"\x0eScheduleAction\x12\x1f\n" +
+	"\x1bSCHEDULE_ACTION_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05DEFER\x10\x01\x12\b\n" +
+	"\x04DROP\x10\x02\x12\f\n" +
+	"\bFALLBACK\x10\x03B.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_notification_schedule_proto_rawDescOnce sync.Once
+	file_message_thingspect_notification_schedule_proto_rawDescData []byte
+)
+
+func file_message_thingspect_notification_schedule_proto_rawDescGZIP() []byte {
+	file_message_thingspect_notification_schedule_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_notification_schedule_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_notification_schedule_proto_rawDesc), len(file_message_thingspect_notification_schedule_proto_rawDesc)))
+	})
+	return file_message_thingspect_notification_schedule_proto_rawDescData
+}
+
+var file_message_thingspect_notification_schedule_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_message_thingspect_notification_schedule_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_message_thingspect_notification_schedule_proto_goTypes = []any{
+	(ScheduleAction)(0),           // 0: thingspect.int.message.ScheduleAction
+	(*ScheduleWindow)(nil),        // 1: thingspect.int.message.ScheduleWindow
+	(*NotificationSchedule)(nil),  // 2: thingspect.int.message.NotificationSchedule
+	(*DeferredAlert)(nil),         // 3: thingspect.int.message.DeferredAlert
+	(*AlertRecord)(nil),           // 4: thingspect.int.message.AlertRecord
+	(api.AlarmType)(0),            // 5: thingspect.api.AlarmType
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*EventerOut)(nil),            // 7: thingspect.int.message.EventerOut
+	(*api.Alert)(nil),             // 8: thingspect.api.Alert
+}
+var file_message_thingspect_notification_schedule_proto_depIdxs = []int32{
+	5,  // 0: thingspect.int.message.ScheduleWindow.type:type_name -> thingspect.api.AlarmType
+	1,  // 1: thingspect.int.message.NotificationSchedule.windows:type_name -> thingspect.int.message.ScheduleWindow
+	0,  // 2: thingspect.int.message.NotificationSchedule.action:type_name -> thingspect.int.message.ScheduleAction
+	5,  // 3: thingspect.int.message.NotificationSchedule.fallback_type:type_name -> thingspect.api.AlarmType
+	6,  // 4: thingspect.int.message.NotificationSchedule.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 5: thingspect.int.message.NotificationSchedule.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 6: thingspect.int.message.DeferredAlert.type:type_name -> thingspect.api.AlarmType
+	7,  // 7: thingspect.int.message.DeferredAlert.event:type_name -> thingspect.int.message.EventerOut
+	6,  // 8: thingspect.int.message.DeferredAlert.deliver_at:type_name -> google.protobuf.Timestamp
+	8,  // 9: thingspect.int.message.AlertRecord.alert:type_name -> thingspect.api.Alert
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_notification_schedule_proto_init() }
+func file_message_thingspect_notification_schedule_proto_init() {
+	if File_message_thingspect_notification_schedule_proto != nil {
+		return
+	}
+	file_message_thingspect_eventer_out_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_notification_schedule_proto_rawDesc), len(file_message_thingspect_notification_schedule_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_notification_schedule_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_notification_schedule_proto_depIdxs,
+		EnumInfos:         file_message_thingspect_notification_schedule_proto_enumTypes,
+		MessageInfos:      file_message_thingspect_notification_schedule_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_notification_schedule_proto = out.File
+	file_message_thingspect_notification_schedule_proto_goTypes = nil
+	file_message_thingspect_notification_schedule_proto_depIdxs = nil
+}
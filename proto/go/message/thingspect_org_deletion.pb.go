@@ -0,0 +1,184 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_org_deletion.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OrgDeletion represents the progress of an organization's deletion. An
+// organization pending deletion is disabled, and its child rows are purged in
+// batches before the organization itself is removed.
+type OrgDeletion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Latest purge step, by device removal or table name.
+	Step string `protobuf:"bytes,2,opt,name=step,proto3" json:"step,omitempty"`
+	// Count of purged rows and removed devices.
+	PurgedRows int64 `protobuf:"varint,3,opt,name=purged_rows,json=purgedRows,proto3" json:"purged_rows,omitempty"`
+	// Deletion request timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Latest progress timestamp.
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Deletion completion timestamp, if completed.
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgDeletion) Reset() {
+	*x = OrgDeletion{}
+	mi := &file_message_thingspect_org_deletion_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgDeletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgDeletion) ProtoMessage() {}
+
+func (x *OrgDeletion) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_org_deletion_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgDeletion.ProtoReflect.Descriptor instead.
+func (*OrgDeletion) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_org_deletion_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrgDeletion) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *OrgDeletion) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *OrgDeletion) GetPurgedRows() int64 {
+	if x != nil {
+		return x.PurgedRows
+	}
+	return 0
+}
+
+func (x *OrgDeletion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OrgDeletion) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *OrgDeletion) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+var File_message_thingspect_org_deletion_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_org_deletion_proto_rawDesc = "" +
+	"\n" +
+	"%message/thingspect_org_deletion.proto\x12\x16thingspect.int.message\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8e\x02\n" +
+	"\vOrgDeletion\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\tR\x04step\x12\x1f\n" +
+	"\vpurged_rows\x18\x03 \x01(\x03R\n" +
+	"purgedRows\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
+	"\fcompleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAtB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_org_deletion_proto_rawDescOnce sync.Once
+	file_message_thingspect_org_deletion_proto_rawDescData []byte
+)
+
+func file_message_thingspect_org_deletion_proto_rawDescGZIP() []byte {
+	file_message_thingspect_org_deletion_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_org_deletion_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_org_deletion_proto_rawDesc), len(file_message_thingspect_org_deletion_proto_rawDesc)))
+	})
+	return file_message_thingspect_org_deletion_proto_rawDescData
+}
+
+var file_message_thingspect_org_deletion_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_message_thingspect_org_deletion_proto_goTypes = []any{
+	(*OrgDeletion)(nil),           // 0: thingspect.int.message.OrgDeletion
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_message_thingspect_org_deletion_proto_depIdxs = []int32{
+	1, // 0: thingspect.int.message.OrgDeletion.created_at:type_name -> google.protobuf.Timestamp
+	1, // 1: thingspect.int.message.OrgDeletion.updated_at:type_name -> google.protobuf.Timestamp
+	1, // 2: thingspect.int.message.OrgDeletion.completed_at:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_org_deletion_proto_init() }
+func file_message_thingspect_org_deletion_proto_init() {
+	if File_message_thingspect_org_deletion_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_org_deletion_proto_rawDesc), len(file_message_thingspect_org_deletion_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_org_deletion_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_org_deletion_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_org_deletion_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_org_deletion_proto = out.File
+	file_message_thingspect_org_deletion_proto_goTypes = nil
+	file_message_thingspect_org_deletion_proto_depIdxs = nil
+}
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_org_mfa_policy.proto
+
+package message
+
+import (
+	api "github.com/thingspect/proto/go/api"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OrgMFAPolicy represents the multi-factor authentication policy of an organization.
+type OrgMFAPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Minimum role that is required to use MFA. If ROLE_UNSPECIFIED, MFA is optional.
+	MinRole api.Role `protobuf:"varint,2,opt,name=min_role,json=minRole,proto3,enum=thingspect.api.Role" json:"min_role,omitempty"`
+	// Policy creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Policy modification timestamp.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgMFAPolicy) Reset() {
+	*x = OrgMFAPolicy{}
+	mi := &file_message_thingspect_org_mfa_policy_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgMFAPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgMFAPolicy) ProtoMessage() {}
+
+func (x *OrgMFAPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_org_mfa_policy_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgMFAPolicy.ProtoReflect.Descriptor instead.
+func (*OrgMFAPolicy) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_org_mfa_policy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrgMFAPolicy) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *OrgMFAPolicy) GetMinRole() api.Role {
+	if x != nil {
+		return x.MinRole
+	}
+	return api.Role(0)
+}
+
+func (x *OrgMFAPolicy) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OrgMFAPolicy) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_message_thingspect_org_mfa_policy_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_org_mfa_policy_proto_rawDesc = "" +
+	"\n" +
+	"'message/thingspect_org_mfa_policy.proto\x12\x16thingspect.int.message\x1a\x19api/thingspect_role.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcc\x01\n" +
+	"\fOrgMFAPolicy\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12/\n" +
+	"\bmin_role\x18\x02 \x01(\x0e2\x14.thingspect.api.RoleR\aminRole\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_org_mfa_policy_proto_rawDescOnce sync.Once
+	file_message_thingspect_org_mfa_policy_proto_rawDescData []byte
+)
+
+func file_message_thingspect_org_mfa_policy_proto_rawDescGZIP() []byte {
+	file_message_thingspect_org_mfa_policy_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_org_mfa_policy_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_org_mfa_policy_proto_rawDesc), len(file_message_thingspect_org_mfa_policy_proto_rawDesc)))
+	})
+	return file_message_thingspect_org_mfa_policy_proto_rawDescData
+}
+
+var file_message_thingspect_org_mfa_policy_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_message_thingspect_org_mfa_policy_proto_goTypes = []any{
+	(*OrgMFAPolicy)(nil),          // 0: thingspect.int.message.OrgMFAPolicy
+	(api.Role)(0),                 // 1: thingspect.api.Role
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_message_thingspect_org_mfa_policy_proto_depIdxs = []int32{
+	1, // 0: thingspect.int.message.OrgMFAPolicy.min_role:type_name -> thingspect.api.Role
+	2, // 1: thingspect.int.message.OrgMFAPolicy.created_at:type_name -> google.protobuf.Timestamp
+	2, // 2: thingspect.int.message.OrgMFAPolicy.updated_at:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_org_mfa_policy_proto_init() }
+func file_message_thingspect_org_mfa_policy_proto_init() {
+	if File_message_thingspect_org_mfa_policy_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_org_mfa_policy_proto_rawDesc), len(file_message_thingspect_org_mfa_policy_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_org_mfa_policy_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_org_mfa_policy_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_org_mfa_policy_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_org_mfa_policy_proto = out.File
+	file_message_thingspect_org_mfa_policy_proto_goTypes = nil
+	file_message_thingspect_org_mfa_policy_proto_depIdxs = nil
+}
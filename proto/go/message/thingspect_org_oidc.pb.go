@@ -0,0 +1,232 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_org_oidc.proto
+
+package message
+
+import (
+	api "github.com/thingspect/proto/go/api"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OrgOIDC represents the OpenID Connect single sign-on configuration of an
+// organization.
+type OrgOIDC struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Identity provider issuer URL, used for discovery.
+	Issuer string `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// Client ID registered with the identity provider.
+	ClientId string `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Client secret registered with the identity provider. The secret is stored encrypted and is never returned. If empty on update, the existing secret is retained.
+	ClientSecret string `protobuf:"bytes,4,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	// ID token claim, such as 'groups', whose values are mapped to roles.
+	RoleClaim string `protobuf:"bytes,5,opt,name=role_claim,json=roleClaim,proto3" json:"role_claim,omitempty"`
+	// Roles by role claim value. If multiple values match, the highest role applies.
+	RoleMap map[string]api.Role `protobuf:"bytes,6,rep,name=role_map,json=roleMap,proto3" json:"role_map,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=thingspect.api.Role"`
+	// Role used when no role claim value matches. If ROLE_UNSPECIFIED, unmatched users are not created.
+	DefaultRole api.Role `protobuf:"varint,7,opt,name=default_role,json=defaultRole,proto3,enum=thingspect.api.Role" json:"default_role,omitempty"`
+	// Whether to create users just-in-time on their first login.
+	JitUsers bool `protobuf:"varint,8,opt,name=jit_users,json=jitUsers,proto3" json:"jit_users,omitempty"`
+	// Configuration creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Configuration modification timestamp.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgOIDC) Reset() {
+	*x = OrgOIDC{}
+	mi := &file_message_thingspect_org_oidc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgOIDC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgOIDC) ProtoMessage() {}
+
+func (x *OrgOIDC) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_org_oidc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgOIDC.ProtoReflect.Descriptor instead.
+func (*OrgOIDC) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_org_oidc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrgOIDC) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *OrgOIDC) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *OrgOIDC) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OrgOIDC) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *OrgOIDC) GetRoleClaim() string {
+	if x != nil {
+		return x.RoleClaim
+	}
+	return ""
+}
+
+func (x *OrgOIDC) GetRoleMap() map[string]api.Role {
+	if x != nil {
+		return x.RoleMap
+	}
+	return nil
+}
+
+func (x *OrgOIDC) GetDefaultRole() api.Role {
+	if x != nil {
+		return x.DefaultRole
+	}
+	return api.Role(0)
+}
+
+func (x *OrgOIDC) GetJitUsers() bool {
+	if x != nil {
+		return x.JitUsers
+	}
+	return false
+}
+
+func (x *OrgOIDC) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OrgOIDC) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_message_thingspect_org_oidc_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_org_oidc_proto_rawDesc = "" +
+	"\n" +
+	"!message/thingspect_org_oidc.proto\x12\x16thingspect.int.message\x1a\x19api/thingspect_role.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x80\x04\n" +
+	"\aOrgOIDC\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06issuer\x18\x02 \x01(\tR\x06issuer\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x04 \x01(\tR\fclientSecret\x12\x1d\n" +
+	"\n" +
+	"role_claim\x18\x05 \x01(\tR\troleClaim\x12G\n" +
+	"\brole_map\x18\x06 \x03(\v2,.thingspect.int.message.OrgOIDC.RoleMapEntryR\aroleMap\x127\n" +
+	"\fdefault_role\x18\a \x01(\x0e2\x14.thingspect.api.RoleR\vdefaultRole\x12\x1b\n" +
+	"\tjit_users\x18\b \x01(\bR\bjitUsers\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1aP\n" +
+	"\fRoleMapEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\x0e2\x14.thingspect.api.RoleR\x05value:\x028\x01B.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_org_oidc_proto_rawDescOnce sync.Once
+	file_message_thingspect_org_oidc_proto_rawDescData []byte
+)
+
+func file_message_thingspect_org_oidc_proto_rawDescGZIP() []byte {
+	file_message_thingspect_org_oidc_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_org_oidc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_org_oidc_proto_rawDesc), len(file_message_thingspect_org_oidc_proto_rawDesc)))
+	})
+	return file_message_thingspect_org_oidc_proto_rawDescData
+}
+
+var file_message_thingspect_org_oidc_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_message_thingspect_org_oidc_proto_goTypes = []any{
+	(*OrgOIDC)(nil),               // 0: thingspect.int.message.OrgOIDC
+	nil,                           // 1: thingspect.int.message.OrgOIDC.RoleMapEntry
+	(api.Role)(0),                 // 2: thingspect.api.Role
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_message_thingspect_org_oidc_proto_depIdxs = []int32{
+	1, // 0: thingspect.int.message.OrgOIDC.role_map:type_name -> thingspect.int.message.OrgOIDC.RoleMapEntry
+	2, // 1: thingspect.int.message.OrgOIDC.default_role:type_name -> thingspect.api.Role
+	3, // 2: thingspect.int.message.OrgOIDC.created_at:type_name -> google.protobuf.Timestamp
+	3, // 3: thingspect.int.message.OrgOIDC.updated_at:type_name -> google.protobuf.Timestamp
+	2, // 4: thingspect.int.message.OrgOIDC.RoleMapEntry.value:type_name -> thingspect.api.Role
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_org_oidc_proto_init() }
+func file_message_thingspect_org_oidc_proto_init() {
+	if File_message_thingspect_org_oidc_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_org_oidc_proto_rawDesc), len(file_message_thingspect_org_oidc_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_org_oidc_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_org_oidc_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_org_oidc_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_org_oidc_proto = out.File
+	file_message_thingspect_org_oidc_proto_goTypes = nil
+	file_message_thingspect_org_oidc_proto_depIdxs = nil
+}
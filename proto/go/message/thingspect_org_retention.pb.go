@@ -0,0 +1,156 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_org_retention.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OrgRetention represents the retention policy of an organization, in days by data type.
+type OrgRetention struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Days to retain data points. If zero, the platform default applies.
+	DataPointDays int32 `protobuf:"varint,2,opt,name=data_point_days,json=dataPointDays,proto3" json:"data_point_days,omitempty"`
+	// Days to retain events. If zero, the platform default applies.
+	EventDays int32 `protobuf:"varint,3,opt,name=event_days,json=eventDays,proto3" json:"event_days,omitempty"`
+	// Days to retain alerts. If zero, the platform default applies.
+	AlertDays     int32 `protobuf:"varint,4,opt,name=alert_days,json=alertDays,proto3" json:"alert_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgRetention) Reset() {
+	*x = OrgRetention{}
+	mi := &file_message_thingspect_org_retention_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgRetention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgRetention) ProtoMessage() {}
+
+func (x *OrgRetention) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_org_retention_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgRetention.ProtoReflect.Descriptor instead.
+func (*OrgRetention) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_org_retention_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrgRetention) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *OrgRetention) GetDataPointDays() int32 {
+	if x != nil {
+		return x.DataPointDays
+	}
+	return 0
+}
+
+func (x *OrgRetention) GetEventDays() int32 {
+	if x != nil {
+		return x.EventDays
+	}
+	return 0
+}
+
+func (x *OrgRetention) GetAlertDays() int32 {
+	if x != nil {
+		return x.AlertDays
+	}
+	return 0
+}
+
+var File_message_thingspect_org_retention_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_org_retention_proto_rawDesc = "" +
+	"\n" +
+	"&message/thingspect_org_retention.proto\x12\x16thingspect.int.message\"\x8b\x01\n" +
+	"\fOrgRetention\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12&\n" +
+	"\x0fdata_point_days\x18\x02 \x01(\x05R\rdataPointDays\x12\x1d\n" +
+	"\n" +
+	"event_days\x18\x03 \x01(\x05R\teventDays\x12\x1d\n" +
+	"\n" +
+	"alert_days\x18\x04 \x01(\x05R\talertDaysB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_org_retention_proto_rawDescOnce sync.Once
+	file_message_thingspect_org_retention_proto_rawDescData []byte
+)
+
+func file_message_thingspect_org_retention_proto_rawDescGZIP() []byte {
+	file_message_thingspect_org_retention_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_org_retention_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_org_retention_proto_rawDesc), len(file_message_thingspect_org_retention_proto_rawDesc)))
+	})
+	return file_message_thingspect_org_retention_proto_rawDescData
+}
+
+var file_message_thingspect_org_retention_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_message_thingspect_org_retention_proto_goTypes = []any{
+	(*OrgRetention)(nil), // 0: thingspect.int.message.OrgRetention
+}
+var file_message_thingspect_org_retention_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_org_retention_proto_init() }
+func file_message_thingspect_org_retention_proto_init() {
+	if File_message_thingspect_org_retention_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_org_retention_proto_rawDesc), len(file_message_thingspect_org_retention_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_org_retention_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_org_retention_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_org_retention_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_org_retention_proto = out.File
+	file_message_thingspect_org_retention_proto_goTypes = nil
+	file_message_thingspect_org_retention_proto_depIdxs = nil
+}
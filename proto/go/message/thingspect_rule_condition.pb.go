@@ -0,0 +1,279 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_rule_condition.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RuleCondition represents stateful evaluation settings for a rule. A rule
+// with a condition generates an event only when it becomes active, rather than
+// on each matching data point.
+type RuleCondition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Rule ID (UUID).
+	RuleId string `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Number of consecutive matching data points required to become active.
+	ForSamples int32 `protobuf:"varint,3,opt,name=for_samples,json=forSamples,proto3" json:"for_samples,omitempty"`
+	// Duration the rule expression must match continuously to become active,
+	// measured by data point timestamps.
+	ForDuration *durationpb.Duration `protobuf:"bytes,4,opt,name=for_duration,json=forDuration,proto3" json:"for_duration,omitempty"`
+	// Expression that, while active, must be true to become inactive. If empty,
+	// the rule becomes inactive when its expression no longer matches.
+	ExitExpr string `protobuf:"bytes,5,opt,name=exit_expr,json=exitExpr,proto3" json:"exit_expr,omitempty"`
+	// Condition creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Condition modification timestamp.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleCondition) Reset() {
+	*x = RuleCondition{}
+	mi := &file_message_thingspect_rule_condition_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleCondition) ProtoMessage() {}
+
+func (x *RuleCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_rule_condition_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleCondition.ProtoReflect.Descriptor instead.
+func (*RuleCondition) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_rule_condition_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RuleCondition) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *RuleCondition) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RuleCondition) GetForSamples() int32 {
+	if x != nil {
+		return x.ForSamples
+	}
+	return 0
+}
+
+func (x *RuleCondition) GetForDuration() *durationpb.Duration {
+	if x != nil {
+		return x.ForDuration
+	}
+	return nil
+}
+
+func (x *RuleCondition) GetExitExpr() string {
+	if x != nil {
+		return x.ExitExpr
+	}
+	return ""
+}
+
+func (x *RuleCondition) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RuleCondition) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// RuleState represents the evaluation state of a rule with a condition for a
+// device.
+type RuleState struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the rule is active.
+	Active bool `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
+	// Number of consecutive matching data points while inactive.
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Timestamp of the first consecutive matching data point while inactive.
+	Since *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	// Trace ID (UUID) of the last data point applied to the state.
+	TraceId       string `protobuf:"bytes,4,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleState) Reset() {
+	*x = RuleState{}
+	mi := &file_message_thingspect_rule_condition_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleState) ProtoMessage() {}
+
+func (x *RuleState) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_rule_condition_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleState.ProtoReflect.Descriptor instead.
+func (*RuleState) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_rule_condition_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RuleState) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *RuleState) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RuleState) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *RuleState) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+var File_message_thingspect_rule_condition_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_rule_condition_proto_rawDesc = "" +
+	"\n" +
+	"'message/thingspect_rule_condition.proto\x12\x16thingspect.int.message\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb1\x02\n" +
+	"\rRuleCondition\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x1f\n" +
+	"\vfor_samples\x18\x03 \x01(\x05R\n" +
+	"forSamples\x12<\n" +
+	"\ffor_duration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\vforDuration\x12\x1b\n" +
+	"\texit_expr\x18\x05 \x01(\tR\bexitExpr\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x86\x01\n" +
+	"\tRuleState\x12\x16\n" +
+	"\x06active\x18\x01 \x01(\bR\x06active\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x19\n" +
+	"\btrace_id\x18\x04 \x01(\tR\atraceIdB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_rule_condition_proto_rawDescOnce sync.Once
+	file_message_thingspect_rule_condition_proto_rawDescData []byte
+)
+
+func file_message_thingspect_rule_condition_proto_rawDescGZIP() []byte {
+	file_message_thingspect_rule_condition_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_rule_condition_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_rule_condition_proto_rawDesc), len(file_message_thingspect_rule_condition_proto_rawDesc)))
+	})
+	return file_message_thingspect_rule_condition_proto_rawDescData
+}
+
+var file_message_thingspect_rule_condition_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_message_thingspect_rule_condition_proto_goTypes = []any{
+	(*RuleCondition)(nil),         // 0: thingspect.int.message.RuleCondition
+	(*RuleState)(nil),             // 1: thingspect.int.message.RuleState
+	(*durationpb.Duration)(nil),   // 2: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_message_thingspect_rule_condition_proto_depIdxs = []int32{
+	2, // 0: thingspect.int.message.RuleCondition.for_duration:type_name -> google.protobuf.Duration
+	3, // 1: thingspect.int.message.RuleCondition.created_at:type_name -> google.protobuf.Timestamp
+	3, // 2: thingspect.int.message.RuleCondition.updated_at:type_name -> google.protobuf.Timestamp
+	3, // 3: thingspect.int.message.RuleState.since:type_name -> google.protobuf.Timestamp
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_rule_condition_proto_init() }
+func file_message_thingspect_rule_condition_proto_init() {
+	if File_message_thingspect_rule_condition_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_rule_condition_proto_rawDesc), len(file_message_thingspect_rule_condition_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_rule_condition_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_rule_condition_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_rule_condition_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_rule_condition_proto = out.File
+	file_message_thingspect_rule_condition_proto_goTypes = nil
+	file_message_thingspect_rule_condition_proto_depIdxs = nil
+}
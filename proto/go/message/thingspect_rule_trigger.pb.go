@@ -0,0 +1,172 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_rule_trigger.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RuleTrigger represents additional attributes that trigger evaluation of a
+// rule. A rule with a trigger is evaluated for data points of its own
+// attribute and of any of the trigger's attributes.
+type RuleTrigger struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Rule ID (UUID).
+	RuleId string `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Additional data point attributes.
+	Attrs []string `protobuf:"bytes,3,rep,name=attrs,proto3" json:"attrs,omitempty"`
+	// Trigger creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Trigger modification timestamp.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleTrigger) Reset() {
+	*x = RuleTrigger{}
+	mi := &file_message_thingspect_rule_trigger_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleTrigger) ProtoMessage() {}
+
+func (x *RuleTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_rule_trigger_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleTrigger.ProtoReflect.Descriptor instead.
+func (*RuleTrigger) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_rule_trigger_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RuleTrigger) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *RuleTrigger) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RuleTrigger) GetAttrs() []string {
+	if x != nil {
+		return x.Attrs
+	}
+	return nil
+}
+
+func (x *RuleTrigger) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RuleTrigger) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_message_thingspect_rule_trigger_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_rule_trigger_proto_rawDesc = "" +
+	"\n" +
+	"%message/thingspect_rule_trigger.proto\x12\x16thingspect.int.message\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc9\x01\n" +
+	"\vRuleTrigger\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05attrs\x18\x03 \x03(\tR\x05attrs\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_rule_trigger_proto_rawDescOnce sync.Once
+	file_message_thingspect_rule_trigger_proto_rawDescData []byte
+)
+
+func file_message_thingspect_rule_trigger_proto_rawDescGZIP() []byte {
+	file_message_thingspect_rule_trigger_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_rule_trigger_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_rule_trigger_proto_rawDesc), len(file_message_thingspect_rule_trigger_proto_rawDesc)))
+	})
+	return file_message_thingspect_rule_trigger_proto_rawDescData
+}
+
+var file_message_thingspect_rule_trigger_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_message_thingspect_rule_trigger_proto_goTypes = []any{
+	(*RuleTrigger)(nil),           // 0: thingspect.int.message.RuleTrigger
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_message_thingspect_rule_trigger_proto_depIdxs = []int32{
+	1, // 0: thingspect.int.message.RuleTrigger.created_at:type_name -> google.protobuf.Timestamp
+	1, // 1: thingspect.int.message.RuleTrigger.updated_at:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_rule_trigger_proto_init() }
+func file_message_thingspect_rule_trigger_proto_init() {
+	if File_message_thingspect_rule_trigger_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_rule_trigger_proto_rawDesc), len(file_message_thingspect_rule_trigger_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_rule_trigger_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_rule_trigger_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_rule_trigger_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_rule_trigger_proto = out.File
+	file_message_thingspect_rule_trigger_proto_goTypes = nil
+	file_message_thingspect_rule_trigger_proto_depIdxs = nil
+}
@@ -0,0 +1,204 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_shadow.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Shadow represents a device's desired and reported state, as served by the
+// API and delivered over MQTT.
+type Shadow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Device ID (UUID).
+	DevId string `protobuf:"bytes,1,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Device unique ID.
+	UniqId string `protobuf:"bytes,3,opt,name=uniq_id,json=uniqId,proto3" json:"uniq_id,omitempty"`
+	// Desired state, as set through the API.
+	Desired *structpb.Struct `protobuf:"bytes,4,opt,name=desired,proto3" json:"desired,omitempty"`
+	// Reported state, as built from the latest data point of each attribute.
+	Reported *structpb.Struct `protobuf:"bytes,5,opt,name=reported,proto3" json:"reported,omitempty"`
+	// Desired state that differs from, or is absent from, reported state.
+	Delta *structpb.Struct `protobuf:"bytes,6,opt,name=delta,proto3" json:"delta,omitempty"`
+	// Desired state version, incremented on each update.
+	Version int32 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	// Desired state update timestamp.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Shadow) Reset() {
+	*x = Shadow{}
+	mi := &file_message_thingspect_shadow_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shadow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shadow) ProtoMessage() {}
+
+func (x *Shadow) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_shadow_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shadow.ProtoReflect.Descriptor instead.
+func (*Shadow) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_shadow_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Shadow) GetDevId() string {
+	if x != nil {
+		return x.DevId
+	}
+	return ""
+}
+
+func (x *Shadow) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *Shadow) GetUniqId() string {
+	if x != nil {
+		return x.UniqId
+	}
+	return ""
+}
+
+func (x *Shadow) GetDesired() *structpb.Struct {
+	if x != nil {
+		return x.Desired
+	}
+	return nil
+}
+
+func (x *Shadow) GetReported() *structpb.Struct {
+	if x != nil {
+		return x.Reported
+	}
+	return nil
+}
+
+func (x *Shadow) GetDelta() *structpb.Struct {
+	if x != nil {
+		return x.Delta
+	}
+	return nil
+}
+
+func (x *Shadow) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Shadow) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_message_thingspect_shadow_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_shadow_proto_rawDesc = "" +
+	"\n" +
+	"\x1fmessage/thingspect_shadow.proto\x12\x16thingspect.int.message\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbb\x02\n" +
+	"\x06Shadow\x12\x15\n" +
+	"\x06dev_id\x18\x01 \x01(\tR\x05devId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auniq_id\x18\x03 \x01(\tR\x06uniqId\x121\n" +
+	"\adesired\x18\x04 \x01(\v2\x17.google.protobuf.StructR\adesired\x123\n" +
+	"\breported\x18\x05 \x01(\v2\x17.google.protobuf.StructR\breported\x12-\n" +
+	"\x05delta\x18\x06 \x01(\v2\x17.google.protobuf.StructR\x05delta\x12\x18\n" +
+	"\aversion\x18\a \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_shadow_proto_rawDescOnce sync.Once
+	file_message_thingspect_shadow_proto_rawDescData []byte
+)
+
+func file_message_thingspect_shadow_proto_rawDescGZIP() []byte {
+	file_message_thingspect_shadow_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_shadow_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_shadow_proto_rawDesc), len(file_message_thingspect_shadow_proto_rawDesc)))
+	})
+	return file_message_thingspect_shadow_proto_rawDescData
+}
+
+var file_message_thingspect_shadow_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_message_thingspect_shadow_proto_goTypes = []any{
+	(*Shadow)(nil),                // 0: thingspect.int.message.Shadow
+	(*structpb.Struct)(nil),       // 1: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_message_thingspect_shadow_proto_depIdxs = []int32{
+	1, // 0: thingspect.int.message.Shadow.desired:type_name -> google.protobuf.Struct
+	1, // 1: thingspect.int.message.Shadow.reported:type_name -> google.protobuf.Struct
+	1, // 2: thingspect.int.message.Shadow.delta:type_name -> google.protobuf.Struct
+	2, // 3: thingspect.int.message.Shadow.updated_at:type_name -> google.protobuf.Timestamp
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_shadow_proto_init() }
+func file_message_thingspect_shadow_proto_init() {
+	if File_message_thingspect_shadow_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_shadow_proto_rawDesc), len(file_message_thingspect_shadow_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_shadow_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_shadow_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_shadow_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_shadow_proto = out.File
+	file_message_thingspect_shadow_proto_goTypes = nil
+	file_message_thingspect_shadow_proto_depIdxs = nil
+}
@@ -0,0 +1,304 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_stream.proto
+
+package message
+
+import (
+	api "github.com/thingspect/proto/go/api"
+	common "github.com/thingspect/proto/go/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StreamDataPointsRequest is sent to stream data points. Empty fields match all values.
+type StreamDataPointsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Device unique ID.
+	UniqId string `protobuf:"bytes,1,opt,name=uniq_id,json=uniqId,proto3" json:"uniq_id,omitempty"`
+	// Device ID (UUID).
+	DeviceId string `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Device tag.
+	Tag string `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Data point attribute.
+	Attr          string `protobuf:"bytes,4,opt,name=attr,proto3" json:"attr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamDataPointsRequest) Reset() {
+	*x = StreamDataPointsRequest{}
+	mi := &file_message_thingspect_stream_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamDataPointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamDataPointsRequest) ProtoMessage() {}
+
+func (x *StreamDataPointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_stream_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamDataPointsRequest.ProtoReflect.Descriptor instead.
+func (*StreamDataPointsRequest) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_stream_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamDataPointsRequest) GetUniqId() string {
+	if x != nil {
+		return x.UniqId
+	}
+	return ""
+}
+
+func (x *StreamDataPointsRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *StreamDataPointsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *StreamDataPointsRequest) GetAttr() string {
+	if x != nil {
+		return x.Attr
+	}
+	return ""
+}
+
+// StreamEventsRequest is sent to stream events. Empty fields match all values.
+type StreamEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Device unique ID.
+	UniqId string `protobuf:"bytes,1,opt,name=uniq_id,json=uniqId,proto3" json:"uniq_id,omitempty"`
+	// Device ID (UUID).
+	DeviceId string `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Device tag.
+	Tag string `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Data point attribute.
+	Attr          string `protobuf:"bytes,4,opt,name=attr,proto3" json:"attr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_message_thingspect_stream_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_stream_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_stream_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StreamEventsRequest) GetUniqId() string {
+	if x != nil {
+		return x.UniqId
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetAttr() string {
+	if x != nil {
+		return x.Attr
+	}
+	return ""
+}
+
+// StreamEvent represents a streamed event.
+type StreamEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event.
+	Event *api.Event `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	// Whether the rule cleared, rather than matched, for the device.
+	Cleared       bool `protobuf:"varint,2,opt,name=cleared,proto3" json:"cleared,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEvent) Reset() {
+	*x = StreamEvent{}
+	mi := &file_message_thingspect_stream_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEvent) ProtoMessage() {}
+
+func (x *StreamEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_stream_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEvent.ProtoReflect.Descriptor instead.
+func (*StreamEvent) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_stream_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StreamEvent) GetEvent() *api.Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *StreamEvent) GetCleared() bool {
+	if x != nil {
+		return x.Cleared
+	}
+	return false
+}
+
+var File_message_thingspect_stream_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_stream_proto_rawDesc = "" +
+	"\n" +
+	"\x1fmessage/thingspect_stream.proto\x12\x16thingspect.int.message\x1a\x1aapi/thingspect_event.proto\x1a!common/thingspect_datapoint.proto\"u\n" +
+	"\x17StreamDataPointsRequest\x12\x17\n" +
+	"\auniq_id\x18\x01 \x01(\tR\x06uniqId\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x10\n" +
+	"\x03tag\x18\x03 \x01(\tR\x03tag\x12\x12\n" +
+	"\x04attr\x18\x04 \x01(\tR\x04attr\"q\n" +
+	"\x13StreamEventsRequest\x12\x17\n" +
+	"\auniq_id\x18\x01 \x01(\tR\x06uniqId\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x10\n" +
+	"\x03tag\x18\x03 \x01(\tR\x03tag\x12\x12\n" +
+	"\x04attr\x18\x04 \x01(\tR\x04attr\"T\n" +
+	"\vStreamEvent\x12+\n" +
+	"\x05event\x18\x01 \x01(\v2\x15.thingspect.api.EventR\x05event\x12\x18\n" +
+	"\acleared\x18\x02 \x01(\bR\acleared2\xd8\x01\n" +
+	"\rStreamService\x12c\n" +
+	"\x10StreamDataPoints\x12/.thingspect.int.message.StreamDataPointsRequest\x1a\x1c.thingspect.common.DataPoint0\x01\x12b\n" +
+	"\fStreamEvents\x12+.thingspect.int.message.StreamEventsRequest\x1a#.thingspect.int.message.StreamEvent0\x01B.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_stream_proto_rawDescOnce sync.Once
+	file_message_thingspect_stream_proto_rawDescData []byte
+)
+
+func file_message_thingspect_stream_proto_rawDescGZIP() []byte {
+	file_message_thingspect_stream_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_stream_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_stream_proto_rawDesc), len(file_message_thingspect_stream_proto_rawDesc)))
+	})
+	return file_message_thingspect_stream_proto_rawDescData
+}
+
+var file_message_thingspect_stream_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_message_thingspect_stream_proto_goTypes = []any{
+	(*StreamDataPointsRequest)(nil), // 0: thingspect.int.message.StreamDataPointsRequest
+	(*StreamEventsRequest)(nil),     // 1: thingspect.int.message.StreamEventsRequest
+	(*StreamEvent)(nil),             // 2: thingspect.int.message.StreamEvent
+	(*api.Event)(nil),               // 3: thingspect.api.Event
+	(*common.DataPoint)(nil),        // 4: thingspect.common.DataPoint
+}
+var file_message_thingspect_stream_proto_depIdxs = []int32{
+	3, // 0: thingspect.int.message.StreamEvent.event:type_name -> thingspect.api.Event
+	0, // 1: thingspect.int.message.StreamService.StreamDataPoints:input_type -> thingspect.int.message.StreamDataPointsRequest
+	1, // 2: thingspect.int.message.StreamService.StreamEvents:input_type -> thingspect.int.message.StreamEventsRequest
+	4, // 3: thingspect.int.message.StreamService.StreamDataPoints:output_type -> thingspect.common.DataPoint
+	2, // 4: thingspect.int.message.StreamService.StreamEvents:output_type -> thingspect.int.message.StreamEvent
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_stream_proto_init() }
+func file_message_thingspect_stream_proto_init() {
+	if File_message_thingspect_stream_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_stream_proto_rawDesc), len(file_message_thingspect_stream_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_message_thingspect_stream_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_stream_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_stream_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_stream_proto = out.File
+	file_message_thingspect_stream_proto_goTypes = nil
+	file_message_thingspect_stream_proto_depIdxs = nil
+}
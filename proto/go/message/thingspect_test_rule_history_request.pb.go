@@ -0,0 +1,168 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_test_rule_history_request.proto
+
+package message
+
+import (
+	api "github.com/thingspect/proto/go/api"
+	common "github.com/thingspect/proto/go/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TestRuleHistoryRequest is sent to test a rule against a data point, along
+// with sample history and latest values of other attributes.
+type TestRuleHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Rule to test.
+	Rule *api.Rule `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Data point to evaluate.
+	Point *common.DataPoint `protobuf:"bytes,2,opt,name=point,proto3" json:"point,omitempty"`
+	// Prior data points of the same device and attribute.
+	History []*common.DataPoint `protobuf:"bytes,3,rep,name=history,proto3" json:"history,omitempty"`
+	// Latest data points of other attributes of the device, keyed by attribute.
+	Latest        map[string]*common.DataPoint `protobuf:"bytes,4,rep,name=latest,proto3" json:"latest,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestRuleHistoryRequest) Reset() {
+	*x = TestRuleHistoryRequest{}
+	mi := &file_message_thingspect_test_rule_history_request_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestRuleHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestRuleHistoryRequest) ProtoMessage() {}
+
+func (x *TestRuleHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_test_rule_history_request_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestRuleHistoryRequest.ProtoReflect.Descriptor instead.
+func (*TestRuleHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_test_rule_history_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TestRuleHistoryRequest) GetRule() *api.Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *TestRuleHistoryRequest) GetPoint() *common.DataPoint {
+	if x != nil {
+		return x.Point
+	}
+	return nil
+}
+
+func (x *TestRuleHistoryRequest) GetHistory() []*common.DataPoint {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *TestRuleHistoryRequest) GetLatest() map[string]*common.DataPoint {
+	if x != nil {
+		return x.Latest
+	}
+	return nil
+}
+
+var File_message_thingspect_test_rule_history_request_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_test_rule_history_request_proto_rawDesc = "" +
+	"\n" +
+	"2message/thingspect_test_rule_history_request.proto\x12\x16thingspect.int.message\x1a\x1fapi/thingspect_rule_alarm.proto\x1a!common/thingspect_datapoint.proto\"\xdb\x02\n" +
+	"\x16TestRuleHistoryRequest\x12(\n" +
+	"\x04rule\x18\x01 \x01(\v2\x14.thingspect.api.RuleR\x04rule\x122\n" +
+	"\x05point\x18\x02 \x01(\v2\x1c.thingspect.common.DataPointR\x05point\x126\n" +
+	"\ahistory\x18\x03 \x03(\v2\x1c.thingspect.common.DataPointR\ahistory\x12R\n" +
+	"\x06latest\x18\x04 \x03(\v2:.thingspect.int.message.TestRuleHistoryRequest.LatestEntryR\x06latest\x1aW\n" +
+	"\vLatestEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x122\n" +
+	"\x05value\x18\x02 \x01(\v2\x1c.thingspect.common.DataPointR\x05value:\x028\x01B.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_test_rule_history_request_proto_rawDescOnce sync.Once
+	file_message_thingspect_test_rule_history_request_proto_rawDescData []byte
+)
+
+func file_message_thingspect_test_rule_history_request_proto_rawDescGZIP() []byte {
+	file_message_thingspect_test_rule_history_request_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_test_rule_history_request_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_test_rule_history_request_proto_rawDesc), len(file_message_thingspect_test_rule_history_request_proto_rawDesc)))
+	})
+	return file_message_thingspect_test_rule_history_request_proto_rawDescData
+}
+
+var file_message_thingspect_test_rule_history_request_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_message_thingspect_test_rule_history_request_proto_goTypes = []any{
+	(*TestRuleHistoryRequest)(nil), // 0: thingspect.int.message.TestRuleHistoryRequest
+	nil,                            // 1: thingspect.int.message.TestRuleHistoryRequest.LatestEntry
+	(*api.Rule)(nil),               // 2: thingspect.api.Rule
+	(*common.DataPoint)(nil),       // 3: thingspect.common.DataPoint
+}
+var file_message_thingspect_test_rule_history_request_proto_depIdxs = []int32{
+	2, // 0: thingspect.int.message.TestRuleHistoryRequest.rule:type_name -> thingspect.api.Rule
+	3, // 1: thingspect.int.message.TestRuleHistoryRequest.point:type_name -> thingspect.common.DataPoint
+	3, // 2: thingspect.int.message.TestRuleHistoryRequest.history:type_name -> thingspect.common.DataPoint
+	1, // 3: thingspect.int.message.TestRuleHistoryRequest.latest:type_name -> thingspect.int.message.TestRuleHistoryRequest.LatestEntry
+	3, // 4: thingspect.int.message.TestRuleHistoryRequest.LatestEntry.value:type_name -> thingspect.common.DataPoint
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_test_rule_history_request_proto_init() }
+func file_message_thingspect_test_rule_history_request_proto_init() {
+	if File_message_thingspect_test_rule_history_request_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_test_rule_history_request_proto_rawDesc), len(file_message_thingspect_test_rule_history_request_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_test_rule_history_request_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_test_rule_history_request_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_test_rule_history_request_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_test_rule_history_request_proto = out.File
+	file_message_thingspect_test_rule_history_request_proto_goTypes = nil
+	file_message_thingspect_test_rule_history_request_proto_depIdxs = nil
+}
@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_user_lockout.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// UserLockout represents the failed login attempts of a user, within the current failure window.
+type UserLockout struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (UUID).
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// User email.
+	Email string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	// Number of failed login attempts.
+	FailedAttempts int32 `protobuf:"varint,4,opt,name=failed_attempts,json=failedAttempts,proto3" json:"failed_attempts,omitempty"`
+	// Whether logins are locked out until the failure window expires or an admin unlocks the user.
+	Locked        bool `protobuf:"varint,5,opt,name=locked,proto3" json:"locked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserLockout) Reset() {
+	*x = UserLockout{}
+	mi := &file_message_thingspect_user_lockout_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserLockout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserLockout) ProtoMessage() {}
+
+func (x *UserLockout) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_user_lockout_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserLockout.ProtoReflect.Descriptor instead.
+func (*UserLockout) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_user_lockout_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserLockout) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserLockout) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *UserLockout) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserLockout) GetFailedAttempts() int32 {
+	if x != nil {
+		return x.FailedAttempts
+	}
+	return 0
+}
+
+func (x *UserLockout) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+// ListUserLockoutsResponse is sent in response to a user lockout list.
+type ListUserLockoutsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User lockout array, ordered by user.
+	Lockouts      []*UserLockout `protobuf:"bytes,1,rep,name=lockouts,proto3" json:"lockouts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserLockoutsResponse) Reset() {
+	*x = ListUserLockoutsResponse{}
+	mi := &file_message_thingspect_user_lockout_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserLockoutsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserLockoutsResponse) ProtoMessage() {}
+
+func (x *ListUserLockoutsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_user_lockout_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserLockoutsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserLockoutsResponse) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_user_lockout_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListUserLockoutsResponse) GetLockouts() []*UserLockout {
+	if x != nil {
+		return x.Lockouts
+	}
+	return nil
+}
+
+var File_message_thingspect_user_lockout_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_user_lockout_proto_rawDesc = "" +
+	"\n" +
+	"%message/thingspect_user_lockout.proto\x12\x16thingspect.int.message\"\x94\x01\n" +
+	"\vUserLockout\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12'\n" +
+	"\x0ffailed_attempts\x18\x04 \x01(\x05R\x0efailedAttempts\x12\x16\n" +
+	"\x06locked\x18\x05 \x01(\bR\x06locked\"[\n" +
+	"\x18ListUserLockoutsResponse\x12?\n" +
+	"\blockouts\x18\x01 \x03(\v2#.thingspect.int.message.UserLockoutR\blockoutsB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_user_lockout_proto_rawDescOnce sync.Once
+	file_message_thingspect_user_lockout_proto_rawDescData []byte
+)
+
+func file_message_thingspect_user_lockout_proto_rawDescGZIP() []byte {
+	file_message_thingspect_user_lockout_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_user_lockout_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_user_lockout_proto_rawDesc), len(file_message_thingspect_user_lockout_proto_rawDesc)))
+	})
+	return file_message_thingspect_user_lockout_proto_rawDescData
+}
+
+var file_message_thingspect_user_lockout_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_message_thingspect_user_lockout_proto_goTypes = []any{
+	(*UserLockout)(nil),              // 0: thingspect.int.message.UserLockout
+	(*ListUserLockoutsResponse)(nil), // 1: thingspect.int.message.ListUserLockoutsResponse
+}
+var file_message_thingspect_user_lockout_proto_depIdxs = []int32{
+	0, // 0: thingspect.int.message.ListUserLockoutsResponse.lockouts:type_name -> thingspect.int.message.UserLockout
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_user_lockout_proto_init() }
+func file_message_thingspect_user_lockout_proto_init() {
+	if File_message_thingspect_user_lockout_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_user_lockout_proto_rawDesc), len(file_message_thingspect_user_lockout_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_user_lockout_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_user_lockout_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_user_lockout_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_user_lockout_proto = out.File
+	file_message_thingspect_user_lockout_proto_goTypes = nil
+	file_message_thingspect_user_lockout_proto_depIdxs = nil
+}
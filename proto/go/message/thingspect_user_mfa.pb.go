@@ -0,0 +1,575 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_user_mfa.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// UserMFA represents the multi-factor authentication status of a user.
+type UserMFA struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (UUID).
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Whether a TOTP authenticator is enrolled and confirmed.
+	Enrolled bool `protobuf:"varint,3,opt,name=enrolled,proto3" json:"enrolled,omitempty"`
+	// Whether the organization's MFA policy requires MFA for the user.
+	Required bool `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	// Number of unused recovery codes.
+	RecoveryCodes int32 `protobuf:"varint,5,opt,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	// Enrollment confirmation timestamp.
+	ConfirmedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=confirmed_at,json=confirmedAt,proto3" json:"confirmed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserMFA) Reset() {
+	*x = UserMFA{}
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserMFA) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserMFA) ProtoMessage() {}
+
+func (x *UserMFA) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserMFA.ProtoReflect.Descriptor instead.
+func (*UserMFA) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_user_mfa_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserMFA) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserMFA) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *UserMFA) GetEnrolled() bool {
+	if x != nil {
+		return x.Enrolled
+	}
+	return false
+}
+
+func (x *UserMFA) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *UserMFA) GetRecoveryCodes() int32 {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return 0
+}
+
+func (x *UserMFA) GetConfirmedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConfirmedAt
+	}
+	return nil
+}
+
+// MFAChallenge is returned as an error detail from a login that requires a second factor. Its token is exchanged, along with a TOTP or recovery code, for a session.
+type MFAChallenge struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Challenge token.
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Challenge token expiration timestamp.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Whether the user must enroll a TOTP authenticator before verifying, as required by the organization's MFA policy.
+	EnrollmentRequired bool `protobuf:"varint,3,opt,name=enrollment_required,json=enrollmentRequired,proto3" json:"enrollment_required,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *MFAChallenge) Reset() {
+	*x = MFAChallenge{}
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MFAChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MFAChallenge) ProtoMessage() {}
+
+func (x *MFAChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MFAChallenge.ProtoReflect.Descriptor instead.
+func (*MFAChallenge) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_user_mfa_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MFAChallenge) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *MFAChallenge) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *MFAChallenge) GetEnrollmentRequired() bool {
+	if x != nil {
+		return x.EnrollmentRequired
+	}
+	return false
+}
+
+// EnrollMFARequest is sent to begin TOTP enrollment.
+type EnrollMFARequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Challenge token, used to enroll without a session when MFA enrollment is required at login.
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollMFARequest) Reset() {
+	*x = EnrollMFARequest{}
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollMFARequest) ProtoMessage() {}
+
+func (x *EnrollMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollMFARequest.ProtoReflect.Descriptor instead.
+func (*EnrollMFARequest) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_user_mfa_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EnrollMFARequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// MFAEnrollment is returned from a TOTP enrollment.
+type MFAEnrollment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// TOTP secret, in base32.
+	Secret string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	// TOTP provisioning URI, suitable for display as a QR code.
+	Uri           string `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MFAEnrollment) Reset() {
+	*x = MFAEnrollment{}
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MFAEnrollment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MFAEnrollment) ProtoMessage() {}
+
+func (x *MFAEnrollment) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MFAEnrollment.ProtoReflect.Descriptor instead.
+func (*MFAEnrollment) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_user_mfa_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MFAEnrollment) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *MFAEnrollment) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// ConfirmMFARequest is sent to confirm a pending TOTP enrollment.
+type ConfirmMFARequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// TOTP code.
+	Code          string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmMFARequest) Reset() {
+	*x = ConfirmMFARequest{}
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmMFARequest) ProtoMessage() {}
+
+func (x *ConfirmMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmMFARequest.ProtoReflect.Descriptor instead.
+func (*ConfirmMFARequest) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_user_mfa_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConfirmMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// MFARecoveryCodes is returned from a confirmed TOTP enrollment. Each recovery code may be used once in place of a TOTP code, and codes are not retrievable later.
+type MFARecoveryCodes struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Recovery code array.
+	Codes         []string `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MFARecoveryCodes) Reset() {
+	*x = MFARecoveryCodes{}
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MFARecoveryCodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MFARecoveryCodes) ProtoMessage() {}
+
+func (x *MFARecoveryCodes) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MFARecoveryCodes.ProtoReflect.Descriptor instead.
+func (*MFARecoveryCodes) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_user_mfa_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MFARecoveryCodes) GetCodes() []string {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+// VerifyMFARequest is sent to complete a login that requires a second factor.
+type VerifyMFARequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Challenge token.
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// TOTP or recovery code.
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMFARequest) Reset() {
+	*x = VerifyMFARequest{}
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFARequest) ProtoMessage() {}
+
+func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFARequest.ProtoReflect.Descriptor instead.
+func (*VerifyMFARequest) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_user_mfa_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VerifyMFARequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *VerifyMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// VerifyMFAResponse is returned from a completed MFA login.
+type VerifyMFAResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Session tokens. The refresh token is only set if the challenge was issued by a refreshable login.
+	Tokens *SessionTokens `protobuf:"bytes,1,opt,name=tokens,proto3" json:"tokens,omitempty"`
+	// Recovery codes, set if the verification also confirmed a required enrollment.
+	RecoveryCodes []string `protobuf:"bytes,2,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMFAResponse) Reset() {
+	*x = VerifyMFAResponse{}
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFAResponse) ProtoMessage() {}
+
+func (x *VerifyMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_user_mfa_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFAResponse.ProtoReflect.Descriptor instead.
+func (*VerifyMFAResponse) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_user_mfa_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VerifyMFAResponse) GetTokens() *SessionTokens {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *VerifyMFAResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+var File_message_thingspect_user_mfa_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_user_mfa_proto_rawDesc = "" +
+	"\n" +
+	"!message/thingspect_user_mfa.proto\x12\x16thingspect.int.message\x1a\x1fgoogle/protobuf/timestamp.proto\x1a%message/thingspect_user_session.proto\"\xd7\x01\n" +
+	"\aUserMFA\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x1a\n" +
+	"\benrolled\x18\x03 \x01(\bR\benrolled\x12\x1a\n" +
+	"\brequired\x18\x04 \x01(\bR\brequired\x12%\n" +
+	"\x0erecovery_codes\x18\x05 \x01(\x05R\rrecoveryCodes\x12=\n" +
+	"\fconfirmed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vconfirmedAt\"\x90\x01\n" +
+	"\fMFAChallenge\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12/\n" +
+	"\x13enrollment_required\x18\x03 \x01(\bR\x12enrollmentRequired\"(\n" +
+	"\x10EnrollMFARequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"9\n" +
+	"\rMFAEnrollment\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x10\n" +
+	"\x03uri\x18\x02 \x01(\tR\x03uri\"'\n" +
+	"\x11ConfirmMFARequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"(\n" +
+	"\x10MFARecoveryCodes\x12\x14\n" +
+	"\x05codes\x18\x01 \x03(\tR\x05codes\"<\n" +
+	"\x10VerifyMFARequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"y\n" +
+	"\x11VerifyMFAResponse\x12=\n" +
+	"\x06tokens\x18\x01 \x01(\v2%.thingspect.int.message.SessionTokensR\x06tokens\x12%\n" +
+	"\x0erecovery_codes\x18\x02 \x03(\tR\rrecoveryCodesB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_user_mfa_proto_rawDescOnce sync.Once
+	file_message_thingspect_user_mfa_proto_rawDescData []byte
+)
+
+func file_message_thingspect_user_mfa_proto_rawDescGZIP() []byte {
+	file_message_thingspect_user_mfa_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_user_mfa_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_user_mfa_proto_rawDesc), len(file_message_thingspect_user_mfa_proto_rawDesc)))
+	})
+	return file_message_thingspect_user_mfa_proto_rawDescData
+}
+
+var file_message_thingspect_user_mfa_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_message_thingspect_user_mfa_proto_goTypes = []any{
+	(*UserMFA)(nil),               // 0: thingspect.int.message.UserMFA
+	(*MFAChallenge)(nil),          // 1: thingspect.int.message.MFAChallenge
+	(*EnrollMFARequest)(nil),      // 2: thingspect.int.message.EnrollMFARequest
+	(*MFAEnrollment)(nil),         // 3: thingspect.int.message.MFAEnrollment
+	(*ConfirmMFARequest)(nil),     // 4: thingspect.int.message.ConfirmMFARequest
+	(*MFARecoveryCodes)(nil),      // 5: thingspect.int.message.MFARecoveryCodes
+	(*VerifyMFARequest)(nil),      // 6: thingspect.int.message.VerifyMFARequest
+	(*VerifyMFAResponse)(nil),     // 7: thingspect.int.message.VerifyMFAResponse
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(*SessionTokens)(nil),         // 9: thingspect.int.message.SessionTokens
+}
+var file_message_thingspect_user_mfa_proto_depIdxs = []int32{
+	8, // 0: thingspect.int.message.UserMFA.confirmed_at:type_name -> google.protobuf.Timestamp
+	8, // 1: thingspect.int.message.MFAChallenge.expires_at:type_name -> google.protobuf.Timestamp
+	9, // 2: thingspect.int.message.VerifyMFAResponse.tokens:type_name -> thingspect.int.message.SessionTokens
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_user_mfa_proto_init() }
+func file_message_thingspect_user_mfa_proto_init() {
+	if File_message_thingspect_user_mfa_proto != nil {
+		return
+	}
+	file_message_thingspect_user_session_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_user_mfa_proto_rawDesc), len(file_message_thingspect_user_mfa_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_user_mfa_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_user_mfa_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_user_mfa_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_user_mfa_proto = out.File
+	file_message_thingspect_user_mfa_proto_goTypes = nil
+	file_message_thingspect_user_mfa_proto_depIdxs = nil
+}
@@ -0,0 +1,170 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.1
+// source: message/thingspect_user_scope.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// UserScope represents the device tags that scope a user. Scopes are carried in the user's session tokens.
+type UserScope struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (UUID).
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Organization ID (UUID).
+	OrgId string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Device tags that scope the user. If empty, the user may access all devices allowed by their role. Otherwise, only devices with a matching tag, or a tag nested beneath one using '/', are accessible.
+	Tags []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Scope creation timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Scope modification timestamp.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserScope) Reset() {
+	*x = UserScope{}
+	mi := &file_message_thingspect_user_scope_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserScope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserScope) ProtoMessage() {}
+
+func (x *UserScope) ProtoReflect() protoreflect.Message {
+	mi := &file_message_thingspect_user_scope_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserScope.ProtoReflect.Descriptor instead.
+func (*UserScope) Descriptor() ([]byte, []int) {
+	return file_message_thingspect_user_scope_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserScope) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserScope) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *UserScope) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UserScope) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UserScope) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_message_thingspect_user_scope_proto protoreflect.FileDescriptor
+
+const file_message_thingspect_user_scope_proto_rawDesc = "" +
+	"\n" +
+	"#message/thingspect_user_scope.proto\x12\x16thingspect.int.message\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc5\x01\n" +
+	"\tUserScope\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB.Z,github.com/thingspect/atlas/proto/go/messageb\x06proto3"
+
+var (
+	file_message_thingspect_user_scope_proto_rawDescOnce sync.Once
+	file_message_thingspect_user_scope_proto_rawDescData []byte
+)
+
+func file_message_thingspect_user_scope_proto_rawDescGZIP() []byte {
+	file_message_thingspect_user_scope_proto_rawDescOnce.Do(func() {
+		file_message_thingspect_user_scope_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_thingspect_user_scope_proto_rawDesc), len(file_message_thingspect_user_scope_proto_rawDesc)))
+	})
+	return file_message_thingspect_user_scope_proto_rawDescData
+}
+
+var file_message_thingspect_user_scope_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_message_thingspect_user_scope_proto_goTypes = []any{
+	(*UserScope)(nil),             // 0: thingspect.int.message.UserScope
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_message_thingspect_user_scope_proto_depIdxs = []int32{
+	1, // 0: thingspect.int.message.UserScope.created_at:type_name -> google.protobuf.Timestamp
+	1, // 1: thingspect.int.message.UserScope.updated_at:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_message_thingspect_user_scope_proto_init() }
+func file_message_thingspect_user_scope_proto_init() {
+	if File_message_thingspect_user_scope_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_thingspect_user_scope_proto_rawDesc), len(file_message_thingspect_user_scope_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_thingspect_user_scope_proto_goTypes,
+		DependencyIndexes: file_message_thingspect_user_scope_proto_depIdxs,
+		MessageInfos:      file_message_thingspect_user_scope_proto_msgTypes,
+	}.Build()
+	File_message_thingspect_user_scope_proto = out.File
+	file_message_thingspect_user_scope_proto_goTypes = nil
+	file_message_thingspect_user_scope_proto_depIdxs = nil
+}
@@ -37,8 +37,18 @@ type Web struct {
 	OrgId []byte `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
 	// User role.
 	Role api.Role `protobuf:"varint,4,opt,name=role,proto3,enum=thingspect.api.Role" json:"role,omitempty"`
-	// Token expiration timestamp. If present, nanos should be zeroed for compactness. Will not be present for API key use.
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Token expiration timestamp. If present, nanos should be zeroed for compactness. Will only be present for API key use if the key expires.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// gRPC methods or services that an API key may call. If empty, all methods are allowed.
+	Scopes []string `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Device unique IDs that an API key may publish data points for.
+	UniqIds []string `protobuf:"bytes,7,rep,name=uniq_ids,json=uniqIds,proto3" json:"uniq_ids,omitempty"`
+	// Device tags that an API key may publish data points for.
+	Tags []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Session ID (UUID). Will only be present for user sessions that can be revoked.
+	SessionId []byte `protobuf:"bytes,9,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Device tags that scope a user or API key. If present, only devices with a matching tag, or a tag nested beneath one using '/', are accessible.
+	ScopeTags     []string `protobuf:"bytes,10,rep,name=scope_tags,json=scopeTags,proto3" json:"scope_tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -119,6 +129,41 @@ func (x *Web) GetExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Web) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *Web) GetUniqIds() []string {
+	if x != nil {
+		return x.UniqIds
+	}
+	return nil
+}
+
+func (x *Web) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Web) GetSessionId() []byte {
+	if x != nil {
+		return x.SessionId
+	}
+	return nil
+}
+
+func (x *Web) GetScopeTags() []string {
+	if x != nil {
+		return x.ScopeTags
+	}
+	return nil
+}
+
 type isWeb_IdOneof interface {
 	isWeb_IdOneof()
 }
@@ -141,14 +186,22 @@ var File_token_thingspect_web_proto protoreflect.FileDescriptor
 
 const file_token_thingspect_web_proto_rawDesc = "" +
 	"\n" +
-	"\x1atoken/thingspect_web.proto\x12\x14thingspect.int.token\x1a\x19api/thingspect_role.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc1\x01\n" +
+	"\x1atoken/thingspect_web.proto\x12\x14thingspect.int.token\x1a\x19api/thingspect_role.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc6\x02\n" +
 	"\x03Web\x12\x19\n" +
 	"\auser_id\x18\x01 \x01(\fH\x00R\x06userId\x12\x17\n" +
 	"\x06key_id\x18\x02 \x01(\fH\x00R\x05keyId\x12\x15\n" +
 	"\x06org_id\x18\x03 \x01(\fR\x05orgId\x12(\n" +
 	"\x04role\x18\x04 \x01(\x0e2\x14.thingspect.api.RoleR\x04role\x129\n" +
 	"\n" +
-	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAtB\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x16\n" +
+	"\x06scopes\x18\x06 \x03(\tR\x06scopes\x12\x19\n" +
+	"\buniq_ids\x18\a \x03(\tR\auniqIds\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\t \x01(\fR\tsessionId\x12\x1d\n" +
+	"\n" +
+	"scope_tags\x18\n" +
+	" \x03(\tR\tscopeTagsB\n" +
 	"\n" +
 	"\bid_oneofB,Z*github.com/thingspect/atlas/proto/go/tokenb\x06proto3"
 